@@ -47,6 +47,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CronTaskList{},
 		&Context{},
 		&ContextList{},
+		&AgentEval{},
+		&AgentEvalList{},
+		&TaskQuota{},
+		&TaskQuotaList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
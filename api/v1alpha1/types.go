@@ -6,10 +6,11 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ContextType defines the type of context source
-// +kubebuilder:validation:Enum=Inline;ConfigMap;Git;Ref
+// +kubebuilder:validation:Enum=Inline;ConfigMap;Git;Kubernetes;URL;ObjectStore;OCI;Ref
 type ContextType string
 
 const (
@@ -21,13 +22,32 @@ const (
 
 	// ContextTypeGit represents content from a Git repository
 	ContextTypeGit ContextType = "Git"
+
+	// ContextTypeKubernetes represents live objects read from the cluster
+	ContextTypeKubernetes ContextType = "Kubernetes"
+
+	// ContextTypeURL represents content fetched from an HTTP(S) endpoint
+	ContextTypeURL ContextType = "URL"
+
+	// ContextTypeObjectStore represents objects in S3, GCS or Azure Blob storage
+	ContextTypeObjectStore ContextType = "ObjectStore"
+
+	// ContextTypeOCI represents a context bundle packaged as an OCI artifact
+	ContextTypeOCI ContextType = "OCI"
 )
 
 // InlineContext provides content directly in the YAML.
+// Exactly one of Content and BinaryContent must be set.
 type InlineContext struct {
-	// Content is the inline content to mount as a file.
-	// +required
-	Content string `json:"content"`
+	// Content is the inline text content to mount as a file.
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// BinaryContent is base64-encoded content for assets such as images or archives.
+	// Binary content cannot be appended to task.md, so the Context must be
+	// referenced with a mountPath.
+	// +optional
+	BinaryContent []byte `json:"binaryContent,omitempty"`
 }
 
 // ConfigMapContext references a ConfigMap for context content.
@@ -36,7 +56,8 @@ type ConfigMapContext struct {
 	// +required
 	Name string `json:"name"`
 
-	// Key specifies a single key to mount as a file.
+	// Key specifies a single key to mount as a file. Keys from binaryData are
+	// supported when the Context is referenced with a mountPath.
 	// If not specified, all keys are mounted as files in the directory.
 	// +optional
 	Key string `json:"key,omitempty"`
@@ -86,6 +107,191 @@ type GitContext struct {
 	SecretRef *GitSecretReference `json:"secretRef,omitempty"`
 }
 
+// KubernetesContext selects live cluster objects and renders them into the context
+// when the Task starts, e.g. the failing Deployments a Task should analyze.
+// Namespaced objects are only read from the Context's namespace. Secrets cannot
+// be selected.
+type KubernetesContext struct {
+	// APIVersion of the objects, e.g. "apps/v1" or "v1"
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the objects, e.g. "Deployment"
+	// +required
+	Kind string `json:"kind"`
+
+	// Name selects a single object. If empty, all objects matching LabelSelector
+	// are selected.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// LabelSelector filters the selected objects. Ignored when Name is set.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// JSONPath is a kubectl-style JSONPath template applied to the object (when Name
+	// is set) or to a List of the selected objects, e.g. "{.items[*].status.conditions}".
+	// If empty, the objects are rendered as YAML without managedFields.
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+}
+
+// URLContext fetches the content from an HTTP(S) endpoint when the Task starts,
+// e.g. a runbook on an internal wiki or a prompt in object storage. The response
+// body is used as is and must not exceed 512 KiB.
+type URLContext struct {
+	// URL to GET the content from
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	URL string `json:"url"`
+
+	// HeadersSecretRef names a Secret in the Context's namespace whose keys and
+	// values are sent as request headers, e.g. an "Authorization" key
+	// +optional
+	HeadersSecretRef *corev1.LocalObjectReference `json:"headersSecretRef,omitempty"`
+
+	// SHA256 is the expected hex-encoded SHA-256 checksum of the content. Tasks
+	// fail to start when the fetched content does not match.
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// CacheTTL is how long the controller reuses fetched content for other Tasks
+	// before requesting it again. If not set, the content is fetched for every Task.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+}
+
+// ObjectStoreProvider is the object storage service of an ObjectStore Context
+// +kubebuilder:validation:Enum=S3;GCS;Azure
+type ObjectStoreProvider string
+
+const (
+	// ObjectStoreProviderS3 is Amazon S3, or an S3-compatible store with an endpoint
+	ObjectStoreProviderS3 ObjectStoreProvider = "S3"
+
+	// ObjectStoreProviderGCS is Google Cloud Storage
+	ObjectStoreProviderGCS ObjectStoreProvider = "GCS"
+
+	// ObjectStoreProviderAzure is Azure Blob Storage
+	ObjectStoreProviderAzure ObjectStoreProvider = "Azure"
+)
+
+// ObjectStoreResolution decides where the objects of an ObjectStore Context are downloaded
+// +kubebuilder:validation:Enum=Controller;InitContainer
+type ObjectStoreResolution string
+
+const (
+	// ObjectStoreResolutionController downloads a single object in the controller and
+	// stores it in the Task's context ConfigMap, like a URL Context
+	ObjectStoreResolutionController ObjectStoreResolution = "Controller"
+
+	// ObjectStoreResolutionInitContainer downloads the objects in an init container
+	// of the Task's Pod, so they are not limited by the size of a ConfigMap
+	ObjectStoreResolutionInitContainer ObjectStoreResolution = "InitContainer"
+)
+
+// ObjectStoreContext provides a single object, or every object under a prefix,
+// from object storage, e.g. a corpus of documents too large for a ConfigMap.
+// +kubebuilder:validation:XValidation:rule="!has(self.key) || !has(self.prefix)",message="key and prefix are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="self.provider != 'Azure' || has(self.endpoint)",message="endpoint is required for Azure"
+// +kubebuilder:validation:XValidation:rule="!has(self.resolution) || self.resolution != 'Controller' || has(self.key)",message="Controller resolution requires key"
+type ObjectStoreContext struct {
+	// Provider of the object storage: S3, GCS or Azure
+	// +required
+	Provider ObjectStoreProvider `json:"provider"`
+
+	// Bucket holding the objects; the container for Azure
+	// +required
+	Bucket string `json:"bucket"`
+
+	// Key of a single object, mounted as a file
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Prefix of the objects mounted as a directory, keeping their paths below the
+	// prefix. If neither key nor prefix is set, the whole bucket is mounted.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region of the S3 bucket. Defaults to "us-east-1".
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint of the service, e.g. "https://minio.example.com" for an
+	// S3-compatible store, or "https://<account>.blob.core.windows.net" for Azure.
+	// Defaults to the provider's public endpoint; required for Azure.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretRef names a Secret in the Context's namespace with the
+	// credentials: "accessKeyID", "secretAccessKey" and optionally "sessionToken"
+	// for S3, and HMAC keys under the same names for GCS; "sasToken" for Azure.
+	// If not set, the controller reads objects anonymously and init containers use
+	// the Pod's workload identity.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// Resolution decides where objects are downloaded: Controller (the default for a
+	// key), limited to objects of up to 512 KiB, or InitContainer (the default for
+	// a prefix).
+	// +optional
+	Resolution ObjectStoreResolution `json:"resolution,omitempty"`
+}
+
+// OCIContext references a context bundle packaged as an OCI artifact, e.g. pushed
+// with "oras push", so prompt and context bundles are versioned, mirrored and
+// signed like images. The artifact is pulled by an init container into the
+// workspace; directories pushed with oras are unpacked.
+type OCIContext struct {
+	// Image is the reference of the artifact, e.g.
+	// "registry.example.com/prompts/review:v3". Pin a digest with
+	// "@sha256:..." to run Tasks only with a reviewed bundle.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Image string `json:"image"`
+
+	// Path is the path within the artifact to mount, a file or directory.
+	// If empty, the whole artifact is mounted.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// PullSecretRef names a kubernetes.io/dockerconfigjson Secret in the Task's
+	// namespace with the registry credentials. If not set, the artifact is
+	// pulled anonymously.
+	// +optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
+}
+
+// FreshnessPolicy decides what happens when a Context violates its freshness limits
+// +kubebuilder:validation:Enum=Warn;Fail
+type FreshnessPolicy string
+
+const (
+	// FreshnessPolicyWarn sets the ContextsFresh condition of the Task to False
+	FreshnessPolicyWarn FreshnessPolicy = "Warn"
+
+	// FreshnessPolicyFail fails the Task
+	FreshnessPolicyFail FreshnessPolicy = "Fail"
+)
+
+// ContextFreshness limits how old the source of a Context may be when a Task
+// starts, and reports sources that change while the Task is running.
+// Supported for Inline and ConfigMap contexts; the source of an Inline context
+// is the Context itself.
+type ContextFreshness struct {
+	// MaxAge is the maximum time since the source was last modified when a Task
+	// starts, e.g. "720h". If not specified, only changes after resolution are reported.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// Policy decides whether a stale or changed source only warns (the default)
+	// or fails the Task
+	// +optional
+	Policy FreshnessPolicy `json:"policy,omitempty"`
+}
+
 // GitSecretReference references a Secret for Git authentication.
 type GitSecretReference struct {
 	// Name of the Secret containing Git credentials.
@@ -135,6 +341,12 @@ type ContextMount struct {
 	// which the agent can parse and understand.
 	// +optional
 	MountPath string `json:"mountPath,omitempty"`
+
+	// Optional skips the Context with a warning on the Task's ContextsResolved
+	// condition when it is missing or cannot be resolved, instead of failing
+	// the Task. Useful for default contexts that are rolled out gradually.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // TaskPhase represents the current phase of a task
@@ -189,16 +401,33 @@ type TaskSpec struct {
 	// +optional
 	Description *string `json:"description,omitempty"`
 
+	// DescriptionFrom reads the task instruction from a ConfigMap or Secret key in
+	// the Task's namespace, so large prompts maintained elsewhere do not bloat the
+	// Task object. The key is read when the Task starts. If description is also
+	// set, it is appended after the referenced content.
+	// +optional
+	DescriptionFrom *DescriptionSource `json:"descriptionFrom,omitempty"`
+
 	// Contexts references Context CRDs to include in this task.
 	// Each ContextMount specifies which Context to use and where to mount it.
 	//
 	// Context priority (lowest to highest):
-	//   1. Agent.contexts (Agent-level defaults)
-	//   2. Task.contexts (Task-specific contexts)
-	//   3. Task.description (highest, becomes ${WORKSPACE_DIR}/task.md)
+	//   1. KubeTaskConfig.defaultContexts (namespace-wide defaults)
+	//   2. Agent.contexts (Agent-level defaults)
+	//   3. Task.contexts (Task-specific contexts)
+	//   4. Task.description (highest, becomes ${WORKSPACE_DIR}/task.md)
 	// +optional
 	Contexts []ContextMount `json:"contexts,omitempty"`
 
+	// Files are additional files the controller writes into the workspace next to
+	// task.md, such as a plan.md skeleton or a config file for the agent. They are
+	// stored in the same context ConfigMap as task.md.
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+	// +kubebuilder:validation:MaxItems=20
+	Files []WorkspaceFile `json:"files,omitempty"`
+
 	// AgentRef references an Agent for this task.
 	// If not specified, uses the "default" Agent in the same namespace.
 	// +optional
@@ -213,6 +442,146 @@ type TaskSpec struct {
 	// Without Command in the Agent, the controller cannot wrap the entrypoint.
 	// +optional
 	HumanInTheLoop *HumanInTheLoop `json:"humanInTheLoop,omitempty"`
+
+	// RuntimeClassName overrides the Agent's podSpec.runtimeClassName for this Task.
+	// Use it to require a stronger sandbox (e.g. gvisor) for specific tasks even when
+	// the Agent defaults to the cluster runtime.
+	//
+	// The effective RuntimeClass must not be weaker than
+	// runtimePolicy.minimumRuntimeClassName in the namespace's KubeTaskConfig,
+	// otherwise the Task fails without creating a Job.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// Resources overrides the Agent's podSpec.resources for this Task, per resource:
+	// a request or limit set here replaces the Agent's for the same resource, and
+	// the others are kept. Use it for Tasks that need more memory or a GPU than the
+	// Agent's typical Task.
+	//
+	// Example:
+	//   resources:
+	//     limits:
+	//       memory: 16Gi
+	//       nvidia.com/gpu: "1"
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// TimeoutSeconds bounds how long the Job of the Task may run, as its
+	// activeDeadlineSeconds. A Task whose agent does not finish in time is stopped
+	// and fails with reason DeadlineExceeded. With a retry policy, each attempt
+	// gets the full timeout. Defaults to taskLifecycle.defaultTimeoutSeconds of the
+	// namespace's KubeTaskConfig; without either, the agent may run forever.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// Replicas runs the Task more than once, for example against several Agents
+	// to compare their results.
+	// +optional
+	Replicas *TaskReplicas `json:"replicas,omitempty"`
+
+	// RunAfter lists Tasks in the same namespace that must complete before this
+	// Task starts. The Task stays Pending until all of them are Completed, and
+	// fails if any of them fails. Use it to run the items of a batch in a defined order.
+	// +optional
+	// +listType=set
+	RunAfter []string `json:"runAfter,omitempty"`
+
+	// Suspend pauses the Task. A Pending Task is not started, and the Job of a
+	// Running Task is suspended, which deletes its Pod and frees its resources.
+	// Setting it back to false resumes the Task with the same Job; the agent starts
+	// over in a new Pod. Unlike cancellation, suspending does not fail the Task.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// RetryPolicy recreates the Job of the Task when it fails, instead of failing
+	// the Task on the first failed Job. The Job then has a backoffLimit of 0, and
+	// the Task's Agent must not use the OnFailure restartPolicy.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// TaskReplicas configures running the same Task more than once
+type TaskReplicas struct {
+	// Compare runs the Task against each listed Agent in parallel, to evaluate prompt
+	// or model changes on real work. The Task becomes a comparison parent: instead of
+	// a Job, it creates one child Task per Agent, named <task>-<agent> and labeled
+	// kubetask.io/comparison, and reports their results side by side in
+	// status.comparison. The parent's agentRef is ignored.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=10
+	Compare []string `json:"compare,omitempty"`
+}
+
+// WorkspaceFile is a file written into the workspace of a Task.
+// Exactly one of content and template must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.content) != has(self.template)",message="exactly one of content and template must be set"
+type WorkspaceFile struct {
+	// Path of the file relative to the workspace directory, e.g. "plan.md" or
+	// ".agent/config.json". It must not leave the workspace or replace task.md.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Path string `json:"path"`
+
+	// Content is the content of the file
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// Template is a Go text/template rendered into the content of the file when
+	// the Task starts. It can refer to .Name, .Namespace, .Labels, .Annotations,
+	// .Description and .WorkspaceDir of the Task.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// DescriptionSource references the key holding a Task description.
+// Exactly one of configMapKeyRef and secretKeyRef must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef and secretKeyRef must be set"
+type DescriptionSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret. Like any description, its content
+	// is copied into the Task's context ConfigMap to become task.md.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// RetryOn is a kind of Job failure after which a Task is retried
+// +kubebuilder:validation:Enum=Failed;Error
+type RetryOn string
+
+const (
+	// RetryOnFailed retries when the agent container exits with a non-zero code
+	RetryOnFailed RetryOn = "Failed"
+
+	// RetryOnError retries when the agent Pod fails for any other reason, such as
+	// an OOM kill, eviction, or the Job's deadline
+	RetryOnError RetryOn = "Error"
+)
+
+// RetryPolicy configures how the Job of a failed Task is recreated
+type RetryPolicy struct {
+	// MaxRetries is how many times the Job is recreated after the first attempt fails
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries int32 `json:"maxRetries"`
+
+	// BackoffSeconds is the delay before the first retry. It doubles with every
+	// further retry, up to one hour.
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	BackoffSeconds *int32 `json:"backoffSeconds,omitempty"`
+
+	// RetryOn lists the kinds of failures that are retried. Defaults to both.
+	// +optional
+	// +listType=set
+	RetryOn []RetryOn `json:"retryOn,omitempty"`
 }
 
 // TaskExecutionStatus defines the observed state of Task
@@ -225,19 +594,237 @@ type TaskExecutionStatus struct {
 	// +optional
 	JobName string `json:"jobName,omitempty"`
 
-	// Start time
+	// PodName is the name of the most recent agent Pod of the Job
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// ContextConfigMap is the name of the context ConfigMap the Job mounts, holding
+	// task.md and the mounted context files. Unless the Task refreshes its contexts,
+	// it is immutable and its name ends with a hash of its content.
+	// +optional
+	ContextConfigMap string `json:"contextConfigMap,omitempty"`
+
+	// Attempts is the number of Jobs created for the Task, including retries
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// NextRetryTime is when the Job of the Task is recreated after a failed attempt
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// Start time, when the Task was admitted and its Job created
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 
+	// PodScheduledTime is when the first agent Pod was bound to a node
+	// +optional
+	PodScheduledTime *metav1.Time `json:"podScheduledTime,omitempty"`
+
+	// AgentStartTime is when the agent container first started running,
+	// after image pulls and context init containers
+	// +optional
+	AgentStartTime *metav1.Time `json:"agentStartTime,omitempty"`
+
 	// Completion time
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// AgentImage identifies the agent image that ran, as resolved by the container
+	// runtime, so changes can be traced to an exact agent build even when the
+	// Agent uses a mutable tag. Updated when a retried Pod runs a different image.
+	// +optional
+	AgentImage *AgentImageStatus `json:"agentImage,omitempty"`
+
+	// Changes lists the pull requests, commits, and files the agent reported.
+	// Agents report them as trailers in the container termination message, or
+	// push them to the result API while running; see docs/agent-context-spec.md.
+	// +optional
+	Changes *TaskChanges `json:"changes,omitempty"`
+
+	// Result is what the agent reported when it finished: the summary and outputs
+	// in its termination message, and the exit code of the agent container.
+	// See docs/agent-context-spec.md.
+	// +optional
+	Result *TaskResult `json:"result,omitempty"`
+
+	// Comparison reports the child Task of each compared Agent, in
+	// spec.replicas.compare order. Only set on comparison parents.
+	// +optional
+	Comparison []ComparisonResult `json:"comparison,omitempty"`
+
+	// Progress is the latest progress the agent pushed to the result API.
+	// Only populated when the controller serves the result API.
+	// +optional
+	Progress *TaskProgress `json:"progress,omitempty"`
+
+	// FailureArtifacts lists the workspace snapshots captured when the agent failed.
+	// Only populated when the Agent enables failureSnapshot.
+	// +optional
+	FailureArtifacts []FailureArtifact `json:"failureArtifacts,omitempty"`
+
+	// ContextSources records the sources of Contexts with a freshness policy as
+	// they were when the Task resolved them
+	// +optional
+	ContextSources []ContextSource `json:"contextSources,omitempty"`
+
+	// URL of the web UI of the agent, set while the Task runs when its Agent
+	// exposes one
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// PeakUsage is the highest CPU and memory usage of the agent container that the
+	// metrics API reported while the Task ran. Only sampled for Agents with sizing.
+	// +optional
+	PeakUsage corev1.ResourceList `json:"peakUsage,omitempty"`
+
 	// Kubernetes standard conditions
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// AgentImageStatus identifies the image of the agent container of a Task
+type AgentImageStatus struct {
+	// Image is the image of the agent container as reported by the kubelet,
+	// e.g. quay.io/kubetask/kubetask-agent-claude:latest
+	Image string `json:"image"`
+
+	// ImageID is the image ID reported by the container runtime, typically
+	// repository@sha256:<digest>
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// Digest is the sha256 digest of the image, taken from ImageID
+	// +optional
+	Digest string `json:"digest,omitempty"`
+}
+
+// TaskChanges are the repository changes reported by the agent of a Task
+type TaskChanges struct {
+	// PullRequests are the URLs of pull requests the agent created or updated
+	// +optional
+	PullRequests []string `json:"pullRequests,omitempty"`
+
+	// Commits are the SHAs of commits the agent pushed
+	// +optional
+	Commits []string `json:"commits,omitempty"`
+
+	// ChangedFiles are the repository paths the agent modified
+	// +optional
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+}
+
+// TaskResult is the result the agent of a Task reported when it finished
+type TaskResult struct {
+	// Summary is the human-readable part of the termination message
+	// +optional
+	Summary string `json:"summary,omitempty"`
+
+	// ExitCode is the exit code of the agent container
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Outputs are the named values the agent reported as KubeTask-Output trailers,
+	// for consumers that act on the result of the Task
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// ComparisonResult is the outcome of a compared Agent
+type ComparisonResult struct {
+	// AgentRef is the compared Agent
+	AgentRef string `json:"agentRef"`
+
+	// TaskName is the child Task running the Agent
+	TaskName string `json:"taskName"`
+
+	// Phase is the phase of the child Task
+	// +optional
+	Phase TaskPhase `json:"phase,omitempty"`
+
+	// Message is the Ready condition message of the child Task
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Duration is how long the child Task ran, once it finished
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Changes lists the pull requests, commits, and files the Agent reported
+	// +optional
+	Changes *TaskChanges `json:"changes,omitempty"`
+}
+
+// TaskProgress is a progress report an agent pushed while running
+type TaskProgress struct {
+	// Message describes what the agent is doing
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Percent is the estimated completion of the Task
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent *int32 `json:"percent,omitempty"`
+
+	// UpdateTime is when the agent pushed the report
+	UpdateTime metav1.Time `json:"updateTime"`
+}
+
+// FailureArtifactType identifies how a failure artifact was captured
+// +kubebuilder:validation:Enum=Tarball
+type FailureArtifactType string
+
+const (
+	// FailureArtifactTypeTarball is a gzipped tarball of ${WORKSPACE_DIR}
+	FailureArtifactTypeTarball FailureArtifactType = "Tarball"
+)
+
+// FailureArtifact references a workspace snapshot of a failed Task
+type FailureArtifact struct {
+	// Type of the artifact
+	// +required
+	Type FailureArtifactType `json:"type"`
+
+	// ClaimName is the PersistentVolumeClaim holding the artifact
+	// +required
+	ClaimName string `json:"claimName"`
+
+	// Path of the artifact relative to the root of the claim
+	// +required
+	Path string `json:"path"`
+}
+
+// ContextSource is the source object of a Context resolved for a Task
+type ContextSource struct {
+	// Context is the name of the Context
+	// +required
+	Context string `json:"context"`
+
+	// Namespace of the Context and its source
+	// +required
+	Namespace string `json:"namespace"`
+
+	// Kind of the source object: ConfigMap, or Context for inline content
+	// +required
+	Kind string `json:"kind"`
+
+	// Name of the source object
+	// +required
+	Name string `json:"name"`
+
+	// ResourceVersion of the source object when it was resolved
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// ModifiedTime is when the source object was last modified
+	// +optional
+	ModifiedTime *metav1.Time `json:"modifiedTime,omitempty"`
+
+	// ResolvedTime is when the Task resolved the Context
+	// +required
+	ResolvedTime metav1.Time `json:"resolvedTime"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TaskList contains a list of Task
@@ -249,7 +836,10 @@ type TaskList struct {
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:JSONPath=`.status.preflight.phase`,name="Preflight",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.canary.phase`,name="Canary",type=string
 // +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
 
 // Agent defines the AI agent configuration for task execution.
@@ -261,25 +851,41 @@ type Agent struct {
 
 	// Spec defines the agent configuration
 	Spec AgentSpec `json:"spec"`
+
+	// Status represents the observed state of the Agent
+	// +optional
+	Status AgentStatus `json:"status,omitempty"`
 }
 
 // AgentSpec defines agent configuration
 type AgentSpec struct {
 	// Agent container image to use for task execution.
 	// The controller generates Jobs with this image.
-	// If not specified, defaults to "quay.io/kubetask/kubetask-agent:latest".
+	// If not specified, uses agentDefaults.agentImage from the namespace's
+	// KubeTaskConfig, then the controller's --default-agent-image flag
+	// (built-in default: "quay.io/kubetask/kubetask-agent-gemini:latest").
 	// +optional
 	AgentImage string `json:"agentImage,omitempty"`
 
 	// WorkspaceDir specifies the working directory inside the agent container.
 	// This is where task.md and context files are mounted.
 	// The agent image must support the WORKSPACE_DIR environment variable.
-	// Defaults to "/workspace" if not specified.
+	// Defaults to "/workspace" if not specified, or to "C:\workspace" when
+	// podSpec.scheduling.nodeSelector selects kubernetes.io/os=windows nodes.
 	// +optional
-	// +kubebuilder:default="/workspace"
-	// +kubebuilder:validation:Pattern=`^/.*`
+	// +kubebuilder:validation:Pattern=`^(/|[A-Za-z]:[\\/]).*`
 	WorkspaceDir string `json:"workspaceDir,omitempty"`
 
+	// ContainerName is the name of the agent container in Task Pods.
+	// Human-in-the-loop and failure snapshot wrapping, status reporting and
+	// "kubectl exec/logs" (through the kubectl.kubernetes.io/default-container
+	// annotation) target this container, also when sidecars are injected.
+	// Defaults to "agent".
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	ContainerName string `json:"containerName,omitempty"`
+
 	// Command specifies the entrypoint command for the agent container.
 	// This overrides the default ENTRYPOINT of the container image.
 	//
@@ -295,12 +901,13 @@ type AgentSpec struct {
 	Command []string `json:"command,omitempty"`
 
 	// Contexts references Context CRDs as defaults for all tasks using this Agent.
-	// These have the lowest priority in context merging.
+	// Only the namespace's KubeTaskConfig defaultContexts have lower priority.
 	//
 	// Context priority (lowest to highest):
-	//   1. Agent.contexts (Agent-level defaults)
-	//   2. Task.contexts (Task-specific contexts)
-	//   3. Task.description (highest, becomes ${WORKSPACE_DIR}/task.md)
+	//   1. KubeTaskConfig.defaultContexts (namespace-wide defaults)
+	//   2. Agent.contexts (Agent-level defaults)
+	//   3. Task.contexts (Task-specific contexts)
+	//   4. Task.description (highest, becomes ${WORKSPACE_DIR}/task.md)
 	//
 	// Use this for organization-wide defaults like coding standards, security policies,
 	// or common tool configurations that should apply to all tasks.
@@ -319,6 +926,24 @@ type AgentSpec struct {
 	// +optional
 	Credentials []Credential `json:"credentials,omitempty"`
 
+	// EnvFrom injects every key of the referenced ConfigMaps or Secrets as
+	// environment variables of the agent container. Use it for environment
+	// bundles such as proxy settings or provider endpoints that would be
+	// tedious to list key by key.
+	//
+	// Sources are applied in order before Credentials, so Credentials win when
+	// the same variable is defined twice.
+	//
+	// Example:
+	//   envFrom:
+	//     - configMapRef:
+	//         name: corporate-proxy
+	//     - secretRef:
+	//         name: llm-endpoints
+	//       prefix: LLM_
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
 	// PodSpec defines advanced Pod configuration for agent pods.
 	// This includes labels, scheduling, runtime class, and other Pod-level settings.
 	// Use this for fine-grained control over how agent pods are created.
@@ -334,38 +959,681 @@ type AgentSpec struct {
 	//
 	// +required
 	ServiceAccountName string `json:"serviceAccountName"`
-}
 
-// AgentPodSpec defines advanced Pod configuration for agent pods.
-// This groups all Pod-level settings that control how the agent container runs.
-type AgentPodSpec struct {
-	// Labels defines additional labels to add to the agent pod.
-	// These labels are applied to the Job's pod template and enable integration with:
-	//   - NetworkPolicy podSelector for network isolation
-	//   - Service selector for service discovery
-	//   - PodMonitor/ServiceMonitor for Prometheus monitoring
-	//   - Any other label-based pod selection
-	//
-	// Example: To make pods match a NetworkPolicy with podSelector:
-	//   labels:
-	//     network-policy: agent-restricted
+	// Preflight configures a validation Job that checks the agent image honors the
+	// execution environment contract before any Task runs with this Agent.
+	// While the check is running, new Tasks stay Pending; if it fails, new Tasks fail
+	// immediately instead of each discovering the broken image on its own.
 	// +optional
-	Labels map[string]string `json:"labels,omitempty"`
+	Preflight *AgentPreflight `json:"preflight,omitempty"`
 
-	// Scheduling defines pod scheduling configuration for agent pods.
-	// This includes node selection, tolerations, and affinity rules.
+	// Canary rolls out AgentImage changes gradually instead of switching all new
+	// Tasks to the new image at once.
 	// +optional
-	Scheduling *PodScheduling `json:"scheduling,omitempty"`
+	Canary *AgentCanary `json:"canary,omitempty"`
 
-	// RuntimeClassName specifies the RuntimeClass to use for agent pods.
-	// RuntimeClass provides a way to select container runtime configurations
-	// such as gVisor (runsc) or Kata Containers for enhanced isolation.
-	//
-	// This is useful when running untrusted AI agent code that may generate
-	// and execute arbitrary commands. Using gVisor or Kata provides an
-	// additional layer of security beyond standard container isolation.
+	// FailureSnapshot archives the workspace of failed Tasks so engineers can
+	// inspect exactly what the agent did after the Pod is gone.
 	//
-	// The RuntimeClass must exist in the cluster before use.
+	// IMPORTANT: When failureSnapshot is enabled, the Agent MUST also specify the
+	// Command field, as the controller wraps the command to archive the workspace
+	// when it exits with a non-zero code.
+	// +optional
+	FailureSnapshot *FailureSnapshot `json:"failureSnapshot,omitempty"`
+
+	// Cancellation configures how the agent is stopped when its Task is cancelled,
+	// for example when the Task is deleted or replaced by a CronTask.
+	// +optional
+	Cancellation *AgentCancellation `json:"cancellation,omitempty"`
+
+	// StuckDetection flags Tasks that run much longer than expected, such as a
+	// forgotten human-in-the-loop session or a hung agent, and optionally cancels them.
+	// +optional
+	StuckDetection *AgentStuckDetection `json:"stuckDetection,omitempty"`
+
+	// Sizing publishes recommended CPU and memory requests for the agent container
+	// in status.sizing, computed from the usage of the Agent's Pods as reported by
+	// the metrics API, and optionally applies them to new Tasks. Requires
+	// metrics-server or another provider of the metrics.k8s.io API.
+	// +optional
+	Sizing *AgentSizing `json:"sizing,omitempty"`
+
+	// Retention overrides how long the Agent's finished Tasks are kept, and bounds
+	// how many of them are kept, so throwaway test Agents and production Agents can
+	// be retained differently within one namespace.
+	// +optional
+	Retention *AgentRetention `json:"retention,omitempty"`
+
+	// CredentialExpiry fails Tasks at start when a credential's Secret expires
+	// before the Task is expected to finish, rather than letting the agent fail
+	// partway through with a rejected token.
+	// +optional
+	CredentialExpiry *CredentialExpiryPolicy `json:"credentialExpiry,omitempty"`
+
+	// RBAC makes the controller create the ServiceAccount named by
+	// serviceAccountName and grant it the permissions of curated presets, instead
+	// of requiring the ServiceAccount, Roles, and bindings to be created by hand.
+	// +optional
+	RBAC *AgentRBAC `json:"rbac,omitempty"`
+
+	// SecurityProfile applies a built-in set of security settings to agent pods:
+	// securityContext, seccomp, service account token automounting, and a
+	// NetworkPolicy for each Task. Unset leaves pods as configured elsewhere.
+	//   - restricted: meets the Pod Security "restricted" standard; the agent must
+	//     run as a non-root user. Ingress is denied and egress is limited to DNS,
+	//     HTTPS (443), and SSH (22). The token is not mounted unless rbac is set.
+	//   - baseline: RuntimeDefault seccomp and no privilege escalation. Ingress is denied.
+	//   - privileged-ci: a privileged agent container for builds that need it,
+	//     such as Docker-in-Docker. No NetworkPolicy.
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// ContextCompression gzips large context files in the Task's context ConfigMap,
+	// so more content fits under the 1 MiB ConfigMap limit. Files are decompressed
+	// into place by an init container running agentImage, which must provide gzip.
+	// +optional
+	ContextCompression *ContextCompression `json:"contextCompression,omitempty"`
+
+	// Monitoring lets Prometheus scrape metrics served by the agent, such as token
+	// usage and LLM latency, through a PodMonitor the controller creates for the
+	// Agent's Pods. Requires the Prometheus Operator's PodMonitor CRD.
+	// +optional
+	Monitoring *AgentMonitoring `json:"monitoring,omitempty"`
+
+	// Expose publishes a web UI the agent serves during human-in-the-loop sessions,
+	// such as a code review interface. For each Task with humanInTheLoop enabled,
+	// the controller creates a Service and an Ingress or HTTPRoute, records the
+	// URL in the Task's status, and removes them when the Task finishes.
+	// +optional
+	Expose *AgentExpose `json:"expose,omitempty"`
+
+	// JobTemplateOverrides is a strategic merge patch applied to the Job generated
+	// for each Task, an escape hatch for settings the structured fields do not cover
+	// yet, such as exotic volumes or extra containers. Containers and volumes merge
+	// by name. Only labels and annotations of the Job and its Pod template, and
+	// spec.template.spec may be patched; fields the controller or the security
+	// profile rely on, such as hostNetwork, securityContext, serviceAccountName,
+	// hostPath volumes and the agent container's image and command, are rejected.
+	// Labels and annotations with the kubetask.io/ prefix are reserved.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=object
+	// +optional
+	JobTemplateOverrides *runtime.RawExtension `json:"jobTemplateOverrides,omitempty"`
+}
+
+// AgentMonitoring configures the PodMonitor of an Agent
+type AgentMonitoring struct {
+	// Enabled creates a PodMonitor selecting the Agent's Pods, and exposes the
+	// metrics port on the agent container
+	Enabled bool `json:"enabled"`
+
+	// Port the agent serves metrics on. It is named "metrics" on the agent container
+	// and passed to the agent in KUBETASK_METRICS_PORT. Defaults to 9090.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// Path of the metrics endpoint. Defaults to "/metrics".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Interval between scrapes, e.g. "30s". Defaults to the scrape interval of Prometheus.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ms|s|m|h))+$`
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// Labels of the PodMonitor, e.g. to match the podMonitorSelector of a Prometheus
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// AgentExpose configures how the web UI of an Agent's Tasks is published.
+// Without ingress and httpRoute, only the Service is created, e.g. for
+// kubectl port-forward.
+//
+// Example:
+//
+//	expose:
+//	  port: 8080
+//	  ingress:
+//	    host: "{{ .Name }}.review.example.com"
+//	    className: nginx
+//	    tlsSecretName: review-wildcard-tls
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.ingress) && has(self.httpRoute))",message="ingress and httpRoute are mutually exclusive"
+type AgentExpose struct {
+	// Port the web UI listens on in the agent container. It is named "ui" on the
+	// agent container and passed to the agent in KUBETASK_UI_PORT.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +required
+	Port int32 `json:"port"`
+
+	// Ingress routes the host of each Task to its Service with an Ingress
+	// +optional
+	Ingress *ExposeIngress `json:"ingress,omitempty"`
+
+	// HTTPRoute routes the host of each Task to its Service with a Gateway API
+	// HTTPRoute. Requires the HTTPRoute CRD.
+	// +optional
+	HTTPRoute *ExposeHTTPRoute `json:"httpRoute,omitempty"`
+}
+
+// ExposeIngress configures the Ingress of a Task's web UI
+type ExposeIngress struct {
+	// Host is a Go text/template of the host name of a Task, rendered with .Name
+	// and .Namespace of the Task, e.g. "{{ .Name }}.review.example.com". Tools such
+	// as ExternalDNS create the DNS record from the Ingress.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Host string `json:"host"`
+
+	// ClassName is the IngressClass of the Ingress. Defaults to the cluster's
+	// default IngressClass.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// TLSSecretName serves the host over HTTPS with the certificate of this
+	// Secret, typically a wildcard certificate of the hosts' domain
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Annotations of the Ingress, e.g. for authentication in the ingress
+	// controller or cert-manager
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ExposeHTTPRoute configures the HTTPRoute of a Task's web UI
+type ExposeHTTPRoute struct {
+	// Host is a Go text/template of the host name of a Task, rendered with .Name
+	// and .Namespace of the Task, e.g. "{{ .Name }}.review.example.com"
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Host string `json:"host"`
+
+	// ParentRefs are the Gateways the HTTPRoute attaches to
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	ParentRefs []ExposeParentRef `json:"parentRefs"`
+
+	// Scheme of the URL recorded in the Task's status, "https" unless the
+	// Gateway listener serves plain HTTP
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+
+	// Annotations of the HTTPRoute
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ExposeParentRef references a Gateway an HTTPRoute attaches to
+type ExposeParentRef struct {
+	// Name of the Gateway
+	// +required
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the Task's namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName selects a listener of the Gateway
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ContextCompression configures compression of context files
+type ContextCompression struct {
+	// MinSizeBytes is the size from which a context file is compressed.
+	// Defaults to 65536 (64 KiB).
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinSizeBytes *int32 `json:"minSizeBytes,omitempty"`
+}
+
+// SecurityProfile is a built-in set of security settings for agent pods
+// +kubebuilder:validation:Enum=restricted;baseline;privileged-ci
+type SecurityProfile string
+
+const (
+	// SecurityProfileRestricted hardens agent pods to the Pod Security restricted standard
+	SecurityProfileRestricted SecurityProfile = "restricted"
+	// SecurityProfileBaseline applies defaults that work with most agent images
+	SecurityProfileBaseline SecurityProfile = "baseline"
+	// SecurityProfilePrivilegedCI runs the agent container privileged
+	SecurityProfilePrivilegedCI SecurityProfile = "privileged-ci"
+)
+
+// AgentRBACPreset is a curated set of permissions for agent pods
+// +kubebuilder:validation:Enum=read-only-namespace;deploy-namespace;read-only-cluster
+type AgentRBACPreset string
+
+const (
+	// AgentRBACPresetReadOnlyNamespace reads workloads, Services, ConfigMaps, Events,
+	// and Pod logs in the Agent's namespace. Secrets are not readable.
+	AgentRBACPresetReadOnlyNamespace AgentRBACPreset = "read-only-namespace"
+	// AgentRBACPresetDeployNamespace manages workloads, Services, ConfigMaps,
+	// Ingresses, and HorizontalPodAutoscalers in the Agent's namespace
+	AgentRBACPresetDeployNamespace AgentRBACPreset = "deploy-namespace"
+	// AgentRBACPresetReadOnlyCluster reads workloads, Services, ConfigMaps, Events,
+	// Nodes, and Namespaces in all namespaces. Secrets are not readable.
+	AgentRBACPresetReadOnlyCluster AgentRBACPreset = "read-only-cluster"
+)
+
+// AgentRBAC configures the controller-managed ServiceAccount of an Agent.
+//
+// The controller creates the ServiceAccount, owned by the Agent, and binds it to
+// the kubetask-agent-<preset> ClusterRole installed with the controller for every
+// preset: through a RoleBinding in the Agent's namespace for namespace presets,
+// and a ClusterRoleBinding for cluster presets. Bindings of removed presets are
+// deleted, and everything is deleted with the Agent or when rbac is removed.
+// An existing ServiceAccount not created for this Agent is never taken over.
+//
+// Example:
+//
+//	serviceAccountName: deploy-bot
+//	rbac:
+//	  presets:
+//	    - read-only-cluster
+//	    - deploy-namespace
+type AgentRBAC struct {
+	// Presets granted to the ServiceAccount
+	// +optional
+	// +listType=set
+	Presets []AgentRBACPreset `json:"presets,omitempty"`
+}
+
+// StuckAction is what the controller does with a stuck Task
+// +kubebuilder:validation:Enum=Alert;Cancel
+type StuckAction string
+
+const (
+	// StuckActionAlert sets the Task's Stuck condition and counts it in metrics
+	StuckActionAlert StuckAction = "Alert"
+	// StuckActionCancel additionally stops the agent and fails the Task
+	StuckActionCancel StuckAction = "Cancel"
+)
+
+// AgentStuckDetection configures when a running Task is considered stuck.
+// A Task is stuck once it has run longer than either bound.
+//
+// Example:
+//
+//	stuckDetection:
+//	  maxDuration: 6h
+//	  expectedDurationPercentile: 99
+//	  action: Cancel
+type AgentStuckDetection struct {
+	// MaxDuration is an absolute bound on how long a Task may run
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// ExpectedDurationPercentile bounds Tasks by the given percentile of the
+	// durations of the Agent's completed Tasks in the namespace. Only applied
+	// once at least 10 completed Tasks are available.
+	// +optional
+	// +kubebuilder:validation:Minimum=50
+	// +kubebuilder:validation:Maximum=100
+	ExpectedDurationPercentile *int32 `json:"expectedDurationPercentile,omitempty"`
+
+	// Action taken when a Task is stuck
+	// +optional
+	// +kubebuilder:default=Alert
+	Action StuckAction `json:"action,omitempty"`
+}
+
+// AgentSizing configures resource recommendations for the agent container.
+//
+// While a Task runs, the controller samples the usage of its agent container every
+// minute and records the peak in the Task's status.peakUsage. The recommendation is
+// the given percentile of the peaks of the Agent's finished Tasks in the namespace,
+// plus a 15% margin, once at least 5 of them have usage samples.
+//
+// Example:
+//
+//	sizing:
+//	  percentile: 90
+//	  autoApply: true
+//	  minAllowed: {cpu: 250m, memory: 512Mi}
+//	  maxAllowed: {cpu: "2", memory: 4Gi}
+type AgentSizing struct {
+	// Percentile of the peak usage of finished Tasks that the recommendation covers
+	// +optional
+	// +kubebuilder:default=90
+	// +kubebuilder:validation:Minimum=50
+	// +kubebuilder:validation:Maximum=100
+	Percentile *int32 `json:"percentile,omitempty"`
+
+	// AutoApply sets the CPU and memory requests of the agent container of new
+	// Tasks to the recommendation, within minAllowed and maxAllowed. Without it,
+	// the recommendation is only reported.
+	// +optional
+	AutoApply bool `json:"autoApply,omitempty"`
+
+	// MinAllowed is the lowest request applied for each resource
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// MaxAllowed is the highest request applied for each resource
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// AgentRetention configures the cleanup of an Agent's finished Tasks
+type AgentRetention struct {
+	// TTLSecondsAfterFinished specifies how long the Agent's completed or failed
+	// Tasks are kept before they are deleted. It overrides the TTL of the
+	// namespace's KubeTaskConfig, except its rules, which select Tasks by label
+	// and stay more specific. 0 disables automatic cleanup.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// SuccessfulTasksHistoryLimit is the number of the Agent's most recent
+	// completed Tasks kept in the namespace; older ones are deleted before their
+	// TTL. Unlimited if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulTasksHistoryLimit *int32 `json:"successfulTasksHistoryLimit,omitempty"`
+
+	// FailedTasksHistoryLimit is the number of the Agent's most recent failed
+	// Tasks kept in the namespace; older ones are deleted before their TTL.
+	// Unlimited if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FailedTasksHistoryLimit *int32 `json:"failedTasksHistoryLimit,omitempty"`
+}
+
+// AgentCancellation configures graceful cancellation of agents.
+//
+// When the Task is cancelled, Kubernetes sends SIGTERM to the agent container and kills
+// it after the grace period. Agents with a command receive the signal through the
+// controller's wrapper, which first writes ${WORKSPACE_DIR}/.kubetask-cancelled so
+// agents that do not handle signals can poll for it and save partial results.
+//
+// Example:
+//
+//	cancellation:
+//	  gracePeriodSeconds: 120
+//	  signalDelaySeconds: 60
+type AgentCancellation struct {
+	// GracePeriodSeconds is how long the agent may take to stop before it is killed.
+	// Sets the Pod's terminationGracePeriodSeconds. Defaults to 30.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// SignalDelaySeconds delays SIGTERM to the agent after the cancellation file is
+	// written, for agents that poll the file rather than handle signals. Must be less
+	// than the grace period. Requires command.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SignalDelaySeconds *int64 `json:"signalDelaySeconds,omitempty"`
+}
+
+// FailureSnapshot configures workspace snapshots of failed Tasks.
+// When the agent command exits with a non-zero code, ${WORKSPACE_DIR} is archived as
+// <task-name>/<task-uid>.tar.gz into the referenced PersistentVolumeClaim before the
+// container exits, and the tarball is recorded in the Task's status.failureArtifacts.
+//
+// Tarballs outlive the Task and its Job; pruning the claim is up to the user.
+//
+// Example:
+//
+//	failureSnapshot:
+//	  enabled: true
+//	  claimName: agent-post-mortems
+type FailureSnapshot struct {
+	// Enabled indicates whether failed workspaces are archived.
+	// +required
+	Enabled bool `json:"enabled"`
+
+	// ClaimName is a PersistentVolumeClaim in the Task namespace that receives the tarballs.
+	// Agent Pods may run concurrently on different nodes, so the claim should
+	// support ReadWriteMany access.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+}
+
+// AgentPreflight configures the pre-flight validation of an Agent.
+// The controller runs a short-lived Job with the agent image that asserts:
+//   - ${WORKSPACE_DIR} exists (or can be created) and is writable
+//   - The agent CLI is available (CLICommand exits with code 0)
+//   - The provider endpoint is reachable (any HTTP response counts)
+//
+// The check runs again whenever the Agent spec changes.
+type AgentPreflight struct {
+	// Enabled indicates whether pre-flight validation is active.
+	// +required
+	Enabled bool `json:"enabled"`
+
+	// CLICommand is a command that must succeed inside the agent image.
+	// Example: ["gemini", "--version"]
+	// +optional
+	CLICommand []string `json:"cliCommand,omitempty"`
+
+	// Endpoint is the provider URL the agent must be able to reach.
+	// Requires curl or wget in the agent image.
+	// Example: "https://generativelanguage.googleapis.com"
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ActiveDeadlineSeconds bounds how long the validation Job may run.
+	// Defaults to 120 seconds.
+	// +optional
+	// +kubebuilder:default=120
+	// +kubebuilder:validation:Minimum=1
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// AgentCanary configures canary rollouts of AgentImage changes.
+// When AgentImage changes, only Weight percent of new Tasks use the new image while the
+// rest keep using the last promoted image. Once MinTasks canary Tasks have finished, the
+// failure rates of both groups are compared and the new image is promoted or rolled back.
+//
+// Example:
+//
+//	canary:
+//	  enabled: true
+//	  weight: 20
+//	  minTasks: 10
+//	  maxFailureRateIncrease: 5
+type AgentCanary struct {
+	// Enabled indicates whether AgentImage changes are rolled out as a canary.
+	// +required
+	Enabled bool `json:"enabled"`
+
+	// Weight is the percentage of new Tasks routed to the new image during a rollout.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight,omitempty"`
+
+	// MinTasks is the number of finished canary Tasks required before a decision is made.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	MinTasks int32 `json:"minTasks,omitempty"`
+
+	// MaxFailureRateIncrease is how many percentage points the canary failure rate may
+	// exceed the stable failure rate before the rollout is rolled back.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxFailureRateIncrease int32 `json:"maxFailureRateIncrease,omitempty"`
+}
+
+// CanaryPhase represents the state of an Agent image canary rollout
+// +kubebuilder:validation:Enum=Progressing;Promoted;RolledBack
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing means new Tasks are split between the stable and canary images
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+	// CanaryPhasePromoted means the canary image became the stable image
+	CanaryPhasePromoted CanaryPhase = "Promoted"
+	// CanaryPhaseRolledBack means the canary image failed and all new Tasks use the stable image
+	CanaryPhaseRolledBack CanaryPhase = "RolledBack"
+)
+
+// PreflightPhase represents the result of an Agent pre-flight check
+// +kubebuilder:validation:Enum=Running;Passed;Failed
+type PreflightPhase string
+
+const (
+	// PreflightPhaseRunning means the validation Job is running
+	PreflightPhaseRunning PreflightPhase = "Running"
+	// PreflightPhasePassed means the agent image satisfied the execution contract
+	PreflightPhasePassed PreflightPhase = "Passed"
+	// PreflightPhaseFailed means the agent image did not satisfy the execution contract
+	PreflightPhaseFailed PreflightPhase = "Failed"
+)
+
+// AgentStatus defines the observed state of Agent
+type AgentStatus struct {
+	// Preflight reports the result of the most recent pre-flight check.
+	// +optional
+	Preflight *AgentPreflightStatus `json:"preflight,omitempty"`
+
+	// Canary reports the state of the current or most recent image rollout.
+	// +optional
+	Canary *AgentCanaryStatus `json:"canary,omitempty"`
+
+	// Sizing reports the recommended requests of the agent container.
+	// Only populated when the Agent sets spec.sizing.
+	// +optional
+	Sizing *AgentSizingStatus `json:"sizing,omitempty"`
+
+	// Kubernetes standard conditions
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// AgentPreflightStatus reports the result of an Agent pre-flight check
+type AgentPreflightStatus struct {
+	// Phase of the pre-flight check
+	// +optional
+	Phase PreflightPhase `json:"phase,omitempty"`
+
+	// ObservedGeneration is the Agent generation that was validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Image is the agent image that was validated.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// JobName is the name of the validation Job.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// LastCheckTime is when the validation Job finished.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Message is a human-readable description of the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AgentSizingStatus reports the recommended requests of the agent container
+type AgentSizingStatus struct {
+	// Recommended requests of the agent container, before minAllowed and maxAllowed
+	// +optional
+	Recommended corev1.ResourceList `json:"recommended,omitempty"`
+
+	// Samples is the number of finished Tasks the recommendation is based on
+	// +optional
+	Samples int32 `json:"samples,omitempty"`
+
+	// LastUpdateTime is when the recommendation last changed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// AgentCanaryStatus reports the state of an Agent image canary rollout
+type AgentCanaryStatus struct {
+	// Phase of the rollout
+	// +optional
+	Phase CanaryPhase `json:"phase,omitempty"`
+
+	// StableImage is the last promoted agent image.
+	// +optional
+	StableImage string `json:"stableImage,omitempty"`
+
+	// CanaryImage is the agent image being rolled out.
+	// +optional
+	CanaryImage string `json:"canaryImage,omitempty"`
+
+	// CanaryTasks and CanaryFailures count finished Tasks that ran the canary image.
+	// +optional
+	CanaryTasks int32 `json:"canaryTasks,omitempty"`
+	// +optional
+	CanaryFailures int32 `json:"canaryFailures,omitempty"`
+
+	// StableTasks and StableFailures count finished Tasks that ran the stable image
+	// during the rollout.
+	// +optional
+	StableTasks int32 `json:"stableTasks,omitempty"`
+	// +optional
+	StableFailures int32 `json:"stableFailures,omitempty"`
+
+	// Message is a human-readable description of the rollout state.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AgentPodSpec defines advanced Pod configuration for agent pods.
+// This groups all Pod-level settings that control how the agent container runs.
+type AgentPodSpec struct {
+	// Labels defines additional labels to add to the agent pod.
+	// These labels are applied to the Job's pod template and enable integration with:
+	//   - NetworkPolicy podSelector for network isolation
+	//   - Service selector for service discovery
+	//   - PodMonitor/ServiceMonitor for Prometheus monitoring
+	//   - Any other label-based pod selection
+	//
+	// Example: To make pods match a NetworkPolicy with podSelector:
+	//   labels:
+	//     network-policy: agent-restricted
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations defines additional annotations to add to the agent pod.
+	// These annotations are applied to the Job's pod template and enable integration with
+	// admission controllers and tooling that key off pod annotations:
+	//   - Istio sidecar injection (sidecar.istio.io/inject: "false")
+	//   - Vault agent injector (vault.hashicorp.com/agent-inject: "true")
+	//   - Karpenter eviction protection (karpenter.sh/do-not-disrupt: "true")
+	//
+	// Example:
+	//   annotations:
+	//     sidecar.istio.io/inject: "false"
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Scheduling defines pod scheduling configuration for agent pods.
+	// This includes node selection, tolerations, and affinity rules.
+	// +optional
+	Scheduling *PodScheduling `json:"scheduling,omitempty"`
+
+	// RuntimeClassName specifies the RuntimeClass to use for agent pods.
+	// RuntimeClass provides a way to select container runtime configurations
+	// such as gVisor (runsc) or Kata Containers for enhanced isolation.
+	//
+	// This is useful when running untrusted AI agent code that may generate
+	// and execute arbitrary commands. Using gVisor or Kata provides an
+	// additional layer of security beyond standard container isolation.
+	//
+	// The RuntimeClass must exist in the cluster before use.
 	// Common values: "gvisor", "kata", "runc" (default if not specified)
 	//
 	// Example:
@@ -374,6 +1642,65 @@ type AgentPodSpec struct {
 	// See: https://kubernetes.io/docs/concepts/containers/runtime-class/
 	// +optional
 	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// Resources sets the CPU, memory and extended resources such as GPUs of the
+	// agent container. Without them, agents are scheduled regardless of what they
+	// use, so a long-running agent can starve its node or be OOMKilled when the node
+	// runs out of memory.
+	// Tasks can override it with spec.resources. With sizing.autoApply, the
+	// recommended requests replace the requests set here for the same resources.
+	//
+	// Example:
+	//   resources:
+	//     requests:
+	//       cpu: "1"
+	//       memory: 2Gi
+	//     limits:
+	//       memory: 4Gi
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// DNSPolicy sets the DNS policy for agent pods.
+	// Defaults to "ClusterFirst" if not specified.
+	// Set to "None" to rely entirely on DNSConfig, for example in air-gapped
+	// environments where internal Git or LLM endpoints are only resolvable
+	// through custom nameservers.
+	// +optional
+	DNSPolicy *corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig specifies additional DNS parameters for agent pods.
+	// Nameservers, searches, and options are merged with the ones generated
+	// from DNSPolicy (or used exclusively when DNSPolicy is "None").
+	//
+	// Example:
+	//   dnsConfig:
+	//     nameservers: ["10.0.0.10"]
+	//     searches: ["corp.internal"]
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// HostAliases adds entries to the agent pod's /etc/hosts file.
+	// Useful when internal endpoints have no DNS records at all.
+	//
+	// Example:
+	//   hostAliases:
+	//     - ip: "10.1.2.3"
+	//       hostnames: ["git.corp.internal", "llm.corp.internal"]
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// RestartPolicy specifies the restart policy for the agent container.
+	// Only "Never" and "OnFailure" are allowed, matching Kubernetes Job constraints.
+	// Defaults to "Never", where every failure creates a new Pod.
+	//
+	// Use "OnFailure" for idempotent agents where restarting the container
+	// in place is cheaper than rescheduling a whole new Pod (e.g., to reuse
+	// cloned Git contexts and pulled images). Container restarts count towards
+	// the Job's backoff limit of 6. Tasks with a retryPolicy cannot run on
+	// Agents with "OnFailure", since their Jobs have a backoff limit of 0.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;OnFailure
+	RestartPolicy *corev1.RestartPolicy `json:"restartPolicy,omitempty"`
 }
 
 // PodScheduling defines scheduling configuration for agent pods.
@@ -415,10 +1742,28 @@ type PodScheduling struct {
 	//                 values: ["us-west-2a", "us-west-2b"]
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how agent pods should be spread
+	// across topology domains such as zones or nodes.
+	// Use this when running many Tasks at once so agent pods do not pack onto
+	// a single node and saturate its network egress to the LLM provider.
+	//
+	// Example:
+	//   topologySpreadConstraints:
+	//     - maxSkew: 1
+	//       topologyKey: kubernetes.io/hostname
+	//       whenUnsatisfiable: ScheduleAnyway
+	//       labelSelector:
+	//         matchLabels:
+	//           app: kubetask
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
 // Credential represents a secret that should be available to the agent.
 // Each credential references a Kubernetes Secret and specifies how to expose it.
+// +kubebuilder:validation:XValidation:rule="!has(self.reloadOnChange) || !self.reloadOnChange || (has(self.mountPath) && has(self.secretRef.key))",message="reloadOnChange requires secretRef.key and mountPath"
+// +kubebuilder:validation:XValidation:rule="!has(self.envPrefix) || !has(self.secretRef.key)",message="envPrefix requires the entire Secret, without secretRef.key"
 type Credential struct {
 	// Name is a descriptive name for this credential (for documentation purposes).
 	// +required
@@ -438,7 +1783,15 @@ type Credential struct {
 	// If specified, the secret key's value is set as this environment variable.
 	// Example: "GITHUB_TOKEN" for GitHub API access
 	// +optional
-	Env *string `json:"env,omitempty"`
+	Env *string `json:"env,omitempty"`
+
+	// EnvPrefix is prepended to the names of the environment variables created
+	// from an entire Secret, when SecretRef.Key is omitted. Use it to expose all
+	// keys of a provider's Secret at once without clashing with other variables.
+	// Example: "AZURE_OPENAI_" turns the key "API_KEY" into AZURE_OPENAI_API_KEY
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	EnvPrefix *string `json:"envPrefix,omitempty"`
 
 	// FileMode specifies the permission mode for mounted files.
 	// Only applicable when MountPath is specified.
@@ -446,6 +1799,40 @@ type Credential struct {
 	// Use 0400 for read-only files like SSH keys.
 	// +optional
 	FileMode *int32 `json:"fileMode,omitempty"`
+
+	// ReloadOnChange mounts the credential through a projected volume, which the
+	// kubelet updates in place when the Secret is rotated, instead of a subPath
+	// mount that keeps the value the Pod started with. The projected volume
+	// occupies the directory of MountPath, shared by the reloaded credentials in
+	// that directory, so use a directory dedicated to credentials, such as
+	// "/var/run/secrets/github/token". A directory that is or contains the
+	// workspaceDir is rejected by the Agent webhook and keeps a subPath mount.
+	// Requires SecretRef.Key and MountPath; environment variables cannot be reloaded.
+	// +optional
+	ReloadOnChange bool `json:"reloadOnChange,omitempty"`
+
+	// Optional skips the credential when its Secret or key does not exist, instead
+	// of keeping the Task Pending. Skipped credentials are listed on the Task's
+	// CredentialsResolved condition, and the agent runs without the environment
+	// variable or file. Useful for integrations not every team has provisioned.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// CredentialExpiryPolicy fails Tasks whose credentials would expire while they run.
+// A credential expires at the RFC 3339 time in the kubetask.io/expires-at annotation
+// of its Secret, which is typically maintained by the tool that issues or rotates it.
+//
+// Example:
+//
+//	credentialExpiry:
+//	  minValidity: 2h
+type CredentialExpiryPolicy struct {
+	// MinValidity is how long every credential must remain valid when the Task
+	// starts. Defaults to stuckDetection.maxDuration if set; otherwise only Tasks
+	// with already expired credentials fail.
+	// +optional
+	MinValidity *metav1.Duration `json:"minValidity,omitempty"`
 }
 
 // SecretReference references a Kubernetes Secret.
@@ -514,14 +1901,28 @@ type HumanInTheLoop struct {
 	// after task completion, allowing time for human interaction.
 	// Users can kubectl exec into the container during this period.
 	// Defaults to 3600 (1 hour) if not specified when enabled is true.
+	//
+	// The default is applied by the controller rather than the CRD schema: TaskSpecs are
+	// embedded in lists (AgentEval cases), where schema defaults show up as perpetual
+	// drift in GitOps tools that diff list items wholesale.
 	// +optional
-	// +kubebuilder:default=3600
 	KeepAliveSeconds *int32 `json:"keepAliveSeconds,omitempty"`
+
+	// RefreshContexts live-syncs updated Context content into the workspace while
+	// the Task runs, so reviewers can correct instructions mid-session.
+	// Context files are copied into place instead of being mounted read-only, and
+	// each sync writes the time of the update to ${WORKSPACE_DIR}/.kubetask-context-updated
+	// for the agent to watch. Requires the Agent to set a command.
+	// +optional
+	RefreshContexts bool `json:"refreshContexts,omitempty"`
 }
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Valid")].status`,name="Valid",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.effective.taskLifecycle.ttlSecondsAfterFinished`,name="TTL",type=integer
 // +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
 
 // KubeTaskConfig defines system-level configuration for KubeTask.
@@ -533,6 +1934,10 @@ type KubeTaskConfig struct {
 
 	// Spec defines the KubeTask configuration
 	Spec KubeTaskConfigSpec `json:"spec"`
+
+	// Status reports the validated, effective configuration
+	// +optional
+	Status KubeTaskConfigStatus `json:"status,omitempty"`
 }
 
 // KubeTaskConfigSpec defines the system-level configuration
@@ -540,6 +1945,158 @@ type KubeTaskConfigSpec struct {
 	// TaskLifecycle configures task lifecycle management including cleanup policies.
 	// +optional
 	TaskLifecycle *TaskLifecycleConfig `json:"taskLifecycle,omitempty"`
+
+	// AgentDefaults configures defaults for Agents in this namespace.
+	// +optional
+	AgentDefaults *AgentDefaultsConfig `json:"agentDefaults,omitempty"`
+
+	// RuntimePolicy mandates a minimum sandbox for Tasks in this namespace.
+	// +optional
+	RuntimePolicy *RuntimePolicyConfig `json:"runtimePolicy,omitempty"`
+
+	// ContextCache shares fetched Git contexts between the Tasks in this namespace.
+	// +optional
+	ContextCache *ContextCacheConfig `json:"contextCache,omitempty"`
+
+	// Prompt adds instructions to the task.md of every Task in this namespace.
+	// +optional
+	Prompt *PromptConfig `json:"prompt,omitempty"`
+
+	// DefaultContexts are added to every Task in this namespace, with lower
+	// priority than the contexts of its Agent. They apply to Tasks of every
+	// Agent, so mandated contexts such as compliance policies cannot be left out
+	// by a minimal custom Agent. A default context that the Agent or Task
+	// references itself is only included once, where they mount it.
+	//
+	// Example:
+	//
+	//	defaultContexts:
+	//	- name: security-policy
+	//	- name: data-handling
+	//	  namespace: compliance
+	// +optional
+	DefaultContexts []ContextMount `json:"defaultContexts,omitempty"`
+
+	// JobMutation lets webhooks adjust the Jobs of Tasks in this namespace
+	// before they are created.
+	// +optional
+	JobMutation *JobMutationConfig `json:"jobMutation,omitempty"`
+}
+
+// JobMutationConfig configures webhooks that mutate the Job built for a Task.
+// Webhooks run in order, after the mutators registered with the controller.
+//
+// Example:
+//
+//	jobMutation:
+//	  webhooks:
+//	  - name: cost-center
+//	    url: http://job-mutator.platform.svc/mutate
+//	    failurePolicy: Ignore
+type JobMutationConfig struct {
+	// Webhooks are called in order, each receiving the Job returned by the previous one
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Webhooks []JobMutationWebhook `json:"webhooks,omitempty"`
+}
+
+// JobMutationFailurePolicy decides what happens when a job mutation webhook fails
+// +kubebuilder:validation:Enum=Fail;Ignore
+type JobMutationFailurePolicy string
+
+const (
+	// JobMutationFailurePolicyFail holds the Task and retries until the webhook succeeds
+	JobMutationFailurePolicyFail JobMutationFailurePolicy = "Fail"
+
+	// JobMutationFailurePolicyIgnore creates the Job without the webhook's changes
+	JobMutationFailurePolicyIgnore JobMutationFailurePolicy = "Ignore"
+)
+
+// JobMutationWebhook is an HTTP endpoint that mutates Jobs. The controller POSTs
+// {"task": <Task>, "job": <Job>} and expects {"job": <Job>} in response.
+// The Job's name, namespace and owner references cannot be changed.
+type JobMutationWebhook struct {
+	// Name identifies the webhook in conditions and events
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// URL of the mutation endpoint
+	// +required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// FailurePolicy decides what happens when the webhook cannot be reached or
+	// returns an invalid response
+	// +optional
+	// +kubebuilder:default=Fail
+	FailurePolicy JobMutationFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// PromptConfig configures instructions the controller prepends to every generated
+// task.md, after the cluster-wide preamble of the controller's --task-preamble flag.
+//
+// Example:
+//
+//	prompt:
+//	  language: Japanese
+//	  preamble: |
+//	    Never push directly to the main branch.
+type PromptConfig struct {
+	// Language the agent should respond and write in, e.g. "Japanese" or "de-DE".
+	// Rendered as a "Respond in <language>." instruction.
+	// +optional
+	// +kubebuilder:validation:MaxLength=64
+	Language string `json:"language,omitempty"`
+
+	// Preamble is prepended to task.md, before the Task's description
+	// +optional
+	Preamble string `json:"preamble,omitempty"`
+}
+
+// ContextCacheConfig configures a content-addressed cache of Git contexts.
+// Repositories are stored by commit SHA, so Tasks referencing the same commit
+// only fetch it once and copy it from the cache afterwards.
+//
+// Example:
+//
+//	contextCache:
+//	  claimName: context-cache
+type ContextCacheConfig struct {
+	// ClaimName is a ReadWriteMany PersistentVolumeClaim in this namespace holding
+	// the cache. It must be writable by the git-sync user (uid 65533).
+	// +required
+	ClaimName string `json:"claimName"`
+}
+
+// RuntimePolicyConfig defines the minimum isolation required for agent pods.
+//
+// Example:
+//
+//	runtimePolicy:
+//	  runtimeClassRanking: [kata, gvisor]
+//	  minimumRuntimeClassName: gvisor
+type RuntimePolicyConfig struct {
+	// RuntimeClassRanking lists RuntimeClass names from weakest to strongest isolation.
+	// The cluster default runtime (no runtimeClassName) is always weaker than every
+	// listed class. RuntimeClasses that are not listed cannot satisfy a minimum.
+	// +optional
+	RuntimeClassRanking []string `json:"runtimeClassRanking,omitempty"`
+
+	// MinimumRuntimeClassName is the weakest RuntimeClass Tasks may run with.
+	// Must be listed in RuntimeClassRanking. Tasks whose Agent or Task-level
+	// runtimeClassName ranks lower fail without creating a Job.
+	// +optional
+	MinimumRuntimeClassName string `json:"minimumRuntimeClassName,omitempty"`
+}
+
+// AgentDefaultsConfig defines namespace-wide defaults for Agents
+type AgentDefaultsConfig struct {
+	// AgentImage is used by Agents that do not set spec.agentImage.
+	// Overrides the controller's --default-agent-image flag for this namespace.
+	// +optional
+	AgentImage string `json:"agentImage,omitempty"`
 }
 
 // TaskLifecycleConfig defines task lifecycle management settings
@@ -553,6 +2110,149 @@ type TaskLifecycleConfig struct {
 	// +optional
 	// +kubebuilder:default=604800
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// Rules override TTLSecondsAfterFinished for Tasks matching a label selector.
+	// Rules are evaluated in order and the first matching rule wins; Tasks that
+	// match no rule use TTLSecondsAfterFinished.
+	//
+	// Example:
+	//   rules:
+	//     - selector:
+	//         matchLabels:
+	//           team: sre
+	//       ttlSecondsAfterFinished: 2592000  # 30 days
+	//     - selector:
+	//         matchExpressions:
+	//           - key: kubetask.io/crontask
+	//             operator: Exists
+	//       ttlSecondsAfterFinished: 86400    # 1 day
+	// +optional
+	Rules []TaskLifecycleRule `json:"rules,omitempty"`
+
+	// QuietHours are recurring windows in which new Tasks are not started, to cap
+	// spend for agents that are only used at certain times. Tasks created during
+	// quiet hours stay Pending and start automatically when the window ends.
+	// Running Tasks are not affected.
+	//
+	// Example:
+	//   quietHours:
+	//     - schedule: "0 20 * * 1-5"   # weeknights from 20:00
+	//       duration: 12h
+	//       timeZone: Europe/Berlin
+	//     - schedule: "0 0 * * 6"     # whole weekend
+	//       duration: 48h
+	// +optional
+	QuietHours []QuietHours `json:"quietHours,omitempty"`
+
+	// LateJobChanges decides what happens when the Job of a finished Task changes
+	// its outcome afterwards, e.g. reports Failed after the Task was Completed.
+	// Defaults to Record.
+	// +optional
+	LateJobChanges LateJobChangePolicy `json:"lateJobChanges,omitempty"`
+
+	// DefaultTimeoutSeconds is the timeoutSeconds of Tasks that do not set one,
+	// so agents that hang do not run forever. Unset means no timeout.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DefaultTimeoutSeconds *int64 `json:"defaultTimeoutSeconds,omitempty"`
+}
+
+// LateJobChangePolicy decides how the controller handles Job outcome changes
+// after a Task finished
+// +kubebuilder:validation:Enum=Ignore;Record;Update
+type LateJobChangePolicy string
+
+const (
+	// LateJobChangeIgnore does not check the Jobs of finished Tasks
+	LateJobChangeIgnore LateJobChangePolicy = "Ignore"
+
+	// LateJobChangeRecord keeps the Task's phase and sets its JobOutcomeConsistent
+	// condition to False
+	LateJobChangeRecord LateJobChangePolicy = "Record"
+
+	// LateJobChangeUpdate records the change and moves the Task to the Job's new outcome
+	LateJobChangeUpdate LateJobChangePolicy = "Update"
+)
+
+// QuietHours is a recurring window in which new Tasks are not started
+type QuietHours struct {
+	// Schedule is a cron expression for when the window starts
+	// +required
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window lasts
+	// +required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of the schedule. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// TaskLifecycleRule sets the retention of Tasks matching a label selector
+type TaskLifecycleRule struct {
+	// Selector matches Task labels. An empty selector matches all Tasks.
+	// +required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// TTLSecondsAfterFinished specifies how long matching Tasks are retained
+	// after they finish. Set to 0 to disable automatic cleanup for them.
+	// +required
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished int32 `json:"ttlSecondsAfterFinished"`
+}
+
+// KubeTaskConfigStatus defines the observed state of KubeTaskConfig
+type KubeTaskConfigStatus struct {
+	// ObservedGeneration is the KubeTaskConfig generation that was validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Effective is the configuration controllers actually use, with defaults
+	// applied and invalid entries removed.
+	// +optional
+	Effective *EffectiveKubeTaskConfig `json:"effective,omitempty"`
+
+	// Kubernetes standard conditions.
+	// "Valid" reports whether every setting passed validation.
+	// "Active" reports whether controllers read this KubeTaskConfig
+	// (only the one named "default" is used).
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// EffectiveKubeTaskConfig is the merged configuration in effect
+type EffectiveKubeTaskConfig struct {
+	// TaskLifecycle is the effective task lifecycle configuration.
+	// TTLSecondsAfterFinished is always set.
+	// +optional
+	TaskLifecycle *TaskLifecycleConfig `json:"taskLifecycle,omitempty"`
+
+	// AgentDefaults is the effective Agent defaults configuration.
+	// AgentImage is always set.
+	// +optional
+	AgentDefaults *AgentDefaultsConfig `json:"agentDefaults,omitempty"`
+
+	// RuntimePolicy is the runtime policy in effect, if any.
+	// An invalid policy is enforced conservatively and rejects every Task.
+	// +optional
+	RuntimePolicy *RuntimePolicyConfig `json:"runtimePolicy,omitempty"`
+
+	// ContextCache is the context cache in use, if any.
+	// +optional
+	ContextCache *ContextCacheConfig `json:"contextCache,omitempty"`
+
+	// Prompt is the namespace's prompt configuration, if any.
+	// +optional
+	Prompt *PromptConfig `json:"prompt,omitempty"`
+
+	// DefaultContexts are the contexts added to every Task in the namespace, if any.
+	// +optional
+	DefaultContexts []ContextMount `json:"defaultContexts,omitempty"`
+
+	// JobMutation is the namespace's job mutation configuration, if any.
+	// +optional
+	JobMutation *JobMutationConfig `json:"jobMutation,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -630,12 +2330,14 @@ type CronTaskSpec struct {
 	// Defaults to 3.
 	// +optional
 	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
 	SuccessfulTasksHistoryLimit *int32 `json:"successfulTasksHistoryLimit,omitempty"`
 
 	// FailedTasksHistoryLimit specifies how many failed Tasks should be kept.
 	// Defaults to 1.
 	// +optional
 	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
 	FailedTasksHistoryLimit *int32 `json:"failedTasksHistoryLimit,omitempty"`
 
 	// TaskTemplate is the template for the Task that will be created when the schedule triggers.
@@ -714,7 +2416,7 @@ type Context struct {
 // Context uses the same simplified structure as ContextItem but without mountPath,
 // since the mount path is specified by the referencing Task/Agent via ContextMount.
 type ContextSpec struct {
-	// Type of context source: Inline, ConfigMap, or Git
+	// Type of context source: Inline, ConfigMap, Git, Kubernetes, URL, ObjectStore, or OCI
 	// +required
 	Type ContextType `json:"type"`
 
@@ -729,6 +2431,27 @@ type ContextSpec struct {
 	// Git context (required when Type == "Git")
 	// +optional
 	Git *GitContext `json:"git,omitempty"`
+
+	// Kubernetes context (required when Type == "Kubernetes")
+	// +optional
+	Kubernetes *KubernetesContext `json:"kubernetes,omitempty"`
+
+	// URL context (required when Type == "URL")
+	// +optional
+	URL *URLContext `json:"url,omitempty"`
+
+	// ObjectStore context (required when Type == "ObjectStore")
+	// +optional
+	ObjectStore *ObjectStoreContext `json:"objectStore,omitempty"`
+
+	// OCI context (required when Type == "OCI")
+	// +optional
+	OCI *OCIContext `json:"oci,omitempty"`
+
+	// Freshness limits the age of the context source and reports changes made to
+	// it after a Task resolved it
+	// +optional
+	Freshness *ContextFreshness `json:"freshness,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -739,3 +2462,265 @@ type ContextList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Context `json:"items"`
 }
+
+// EvalPhase represents the current phase of an AgentEval
+// +kubebuilder:validation:Enum=Running;Completed
+type EvalPhase string
+
+const (
+	// EvalPhaseRunning means benchmark Tasks or verifiers are still running
+	EvalPhaseRunning EvalPhase = "Running"
+	// EvalPhaseCompleted means every case has a result for every Agent
+	EvalPhaseCompleted EvalPhase = "Completed"
+)
+
+// EvalCaseOutcome represents the result of a single benchmark case
+// +kubebuilder:validation:Enum=Pending;Passed;Failed
+type EvalCaseOutcome string
+
+const (
+	// EvalCaseOutcomePending means the Task or its verifier has not finished yet
+	EvalCaseOutcomePending EvalCaseOutcome = "Pending"
+	// EvalCaseOutcomePassed means the Task completed and the verifier exited with code 0
+	EvalCaseOutcomePassed EvalCaseOutcome = "Passed"
+	// EvalCaseOutcomeFailed means the Task failed or the verifier exited with a non-zero code
+	EvalCaseOutcomeFailed EvalCaseOutcome = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:JSONPath=`.status.phase`,name="Phase",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// AgentEval runs a fixed suite of benchmark Tasks against one Agent, or two Agents
+// for A/B comparison, and reports pass rates.
+// Each case runs as a regular Task; when the Task completes, a verifier container
+// scores the result. Use AgentEval to qualify a new agent image before rolling it out.
+type AgentEval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the benchmark suite
+	Spec AgentEvalSpec `json:"spec"`
+
+	// Status reports per-case outcomes and per-Agent pass rates
+	// +optional
+	Status AgentEvalStatus `json:"status,omitempty"`
+}
+
+// AgentEvalSpec defines the benchmark suite and the Agents under evaluation
+type AgentEvalSpec struct {
+	// AgentRefs lists the Agents to evaluate.
+	// Specify two Agents to compare them side by side (A/B).
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	AgentRefs []string `json:"agentRefs"`
+
+	// Cases is the fixed suite of benchmark cases.
+	// Every case runs once for every Agent in AgentRefs.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	Cases []EvalCase `json:"cases"`
+}
+
+// EvalCase defines a single benchmark case
+type EvalCase struct {
+	// Name identifies the case. Must be unique within the AgentEval and a valid DNS label.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Task is the Task to run for this case.
+	// AgentRef is ignored; it is set to each Agent under evaluation.
+	// +required
+	Task TaskSpec `json:"task"`
+
+	// Verifier scores the result after the Task completes.
+	// If not specified, a Completed Task counts as passed.
+	// +optional
+	Verifier *EvalVerifier `json:"verifier,omitempty"`
+}
+
+// EvalVerifier defines the container that scores a benchmark case.
+// The verifier runs as a Job after the Task completes and receives the
+// TASK_NAME, TASK_NAMESPACE, AGENT_NAME, and EVAL_CASE environment variables.
+// Exit code 0 means the case passed.
+type EvalVerifier struct {
+	// Image is the verifier container image.
+	// +required
+	Image string `json:"image"`
+
+	// Command overrides the verifier image's entrypoint.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount for the verifier pod,
+	// for verifiers that inspect cluster state or read Secrets.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// AgentEvalStatus defines the observed state of AgentEval
+type AgentEvalStatus struct {
+	// Phase of the evaluation
+	// +optional
+	Phase EvalPhase `json:"phase,omitempty"`
+
+	// Results summarizes pass rates per Agent
+	// +optional
+	Results []AgentEvalResult `json:"results,omitempty"`
+
+	// Cases reports the outcome of every case for every Agent
+	// +optional
+	Cases []EvalCaseResult `json:"cases,omitempty"`
+
+	// CompletionTime is when every case finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Kubernetes standard conditions
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// AgentEvalResult summarizes the results for one Agent
+type AgentEvalResult struct {
+	// AgentRef is the Agent these results belong to
+	AgentRef string `json:"agentRef"`
+
+	// Passed is the number of passed cases
+	Passed int32 `json:"passed"`
+
+	// Failed is the number of failed cases
+	Failed int32 `json:"failed"`
+
+	// Total is the number of cases
+	Total int32 `json:"total"`
+
+	// PassRatePercent is Passed / Total as a whole percentage
+	PassRatePercent int32 `json:"passRatePercent"`
+}
+
+// EvalCaseResult reports the outcome of one case for one Agent
+type EvalCaseResult struct {
+	// AgentRef is the Agent the case ran with
+	AgentRef string `json:"agentRef"`
+
+	// Case is the name of the case
+	Case string `json:"case"`
+
+	// TaskName is the Task created for this case
+	TaskName string `json:"taskName"`
+
+	// Outcome of the case
+	Outcome EvalCaseOutcome `json:"outcome"`
+
+	// Message explains a failed outcome
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AgentEvalList contains a list of AgentEval
+type AgentEvalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AgentEval `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:JSONPath=`.status.used.concurrentTasks`,name="Concurrent",type=integer
+// +kubebuilder:printcolumn:JSONPath=`.status.used.tasksPerDay`,name="Today",type=integer
+// +kubebuilder:printcolumn:JSONPath=`.status.used.runtimePerDay`,name="Runtime",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Exceeded")].status`,name="Exceeded",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// TaskQuota limits the Tasks a team can run in a namespace.
+// Tasks that would exceed a limit are rejected at admission, so quotas are only
+// enforced when the admission webhooks are enabled. Daily limits cover a rolling
+// 24-hour window. Every TaskQuota in the namespace whose selector matches a Task
+// applies to it.
+type TaskQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the limits
+	Spec TaskQuotaSpec `json:"spec"`
+
+	// Status reports current consumption
+	// +optional
+	Status TaskQuotaStatus `json:"status,omitempty"`
+}
+
+// TaskQuotaSpec defines the Tasks a quota applies to and its limits
+type TaskQuotaSpec struct {
+	// Selector matches the labels of the Tasks the quota applies to.
+	// If not specified, the quota applies to all Tasks in the namespace.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// MaxConcurrentTasks is the maximum number of unfinished Tasks
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentTasks *int32 `json:"maxConcurrentTasks,omitempty"`
+
+	// MaxTasksPerDay is the maximum number of Tasks created in the last 24 hours
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxTasksPerDay *int32 `json:"maxTasksPerDay,omitempty"`
+
+	// MaxRuntimeHoursPerDay is the maximum total runtime, in hours, of Tasks
+	// during the last 24 hours. Once reached, new Tasks are rejected until
+	// earlier runtime falls out of the window.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRuntimeHoursPerDay *int32 `json:"maxRuntimeHoursPerDay,omitempty"`
+}
+
+// TaskQuotaStatus defines the observed state of TaskQuota
+type TaskQuotaStatus struct {
+	// ObservedGeneration is the generation of the spec the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Used is the current consumption of the Tasks matched by the quota
+	// +optional
+	Used TaskQuotaUsage `json:"used,omitempty"`
+
+	// LastUpdateTime is when Used was last computed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// Kubernetes standard conditions
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// TaskQuotaUsage reports the consumption counted against a TaskQuota
+type TaskQuotaUsage struct {
+	// ConcurrentTasks is the number of unfinished Tasks
+	ConcurrentTasks int32 `json:"concurrentTasks"`
+
+	// TasksPerDay is the number of Tasks created in the last 24 hours
+	TasksPerDay int32 `json:"tasksPerDay"`
+
+	// RuntimePerDay is the total runtime of Tasks during the last 24 hours
+	RuntimePerDay metav1.Duration `json:"runtimePerDay"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskQuotaList contains a list of TaskQuota
+type TaskQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskQuota `json:"items"`
+}
@@ -18,6 +18,7 @@ func (in *Agent) DeepCopyInto(out *Agent) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Agent.
@@ -39,186 +40,96 @@ func (in *Agent) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentList) DeepCopyInto(out *AgentList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Agent, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentList.
-func (in *AgentList) DeepCopy() *AgentList {
-	if in == nil {
-		return nil
-	}
-	out := new(AgentList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AgentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentPodSpec) DeepCopyInto(out *AgentPodSpec) {
+func (in *AgentCanary) DeepCopyInto(out *AgentCanary) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Scheduling != nil {
-		in, out := &in.Scheduling, &out.Scheduling
-		*out = new(PodScheduling)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPodSpec.
-func (in *AgentPodSpec) DeepCopy() *AgentPodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentCanary.
+func (in *AgentCanary) DeepCopy() *AgentCanary {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentPodSpec)
+	out := new(AgentCanary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
+func (in *AgentCanaryStatus) DeepCopyInto(out *AgentCanaryStatus) {
 	*out = *in
-	if in.Command != nil {
-		in, out := &in.Command, &out.Command
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Contexts != nil {
-		in, out := &in.Contexts, &out.Contexts
-		*out = make([]ContextMount, len(*in))
-		copy(*out, *in)
-	}
-	if in.Credentials != nil {
-		in, out := &in.Credentials, &out.Credentials
-		*out = make([]Credential, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PodSpec != nil {
-		in, out := &in.PodSpec, &out.PodSpec
-		*out = new(AgentPodSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
-func (in *AgentSpec) DeepCopy() *AgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentCanaryStatus.
+func (in *AgentCanaryStatus) DeepCopy() *AgentCanaryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AgentSpec)
+	out := new(AgentCanaryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigMapContext) DeepCopyInto(out *ConfigMapContext) {
+func (in *AgentCancellation) DeepCopyInto(out *AgentCancellation) {
 	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
-		*out = new(bool)
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapContext.
-func (in *ConfigMapContext) DeepCopy() *ConfigMapContext {
-	if in == nil {
-		return nil
-	}
-	out := new(ConfigMapContext)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
-	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
-		*out = new(bool)
+	if in.SignalDelaySeconds != nil {
+		in, out := &in.SignalDelaySeconds, &out.SignalDelaySeconds
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
-func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentCancellation.
+func (in *AgentCancellation) DeepCopy() *AgentCancellation {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigMapKeySelector)
+	out := new(AgentCancellation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+func (in *AgentDefaultsConfig) DeepCopyInto(out *AgentDefaultsConfig) {
 	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
-func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentDefaultsConfig.
+func (in *AgentDefaultsConfig) DeepCopy() *AgentDefaultsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigMapReference)
+	out := new(AgentDefaultsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Context) DeepCopyInto(out *Context) {
+func (in *AgentEval) DeepCopyInto(out *AgentEval) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Context.
-func (in *Context) DeepCopy() *Context {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEval.
+func (in *AgentEval) DeepCopy() *AgentEval {
 	if in == nil {
 		return nil
 	}
-	out := new(Context)
+	out := new(AgentEval)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Context) DeepCopyObject() runtime.Object {
+func (in *AgentEval) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -226,31 +137,31 @@ func (in *Context) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContextList) DeepCopyInto(out *ContextList) {
+func (in *AgentEvalList) DeepCopyInto(out *AgentEvalList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Context, len(*in))
+		*out = make([]AgentEval, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextList.
-func (in *ContextList) DeepCopy() *ContextList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalList.
+func (in *AgentEvalList) DeepCopy() *AgentEvalList {
 	if in == nil {
 		return nil
 	}
-	out := new(ContextList)
+	out := new(AgentEvalList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ContextList) DeepCopyObject() runtime.Object {
+func (in *AgentEvalList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -258,134 +169,149 @@ func (in *ContextList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContextMount) DeepCopyInto(out *ContextMount) {
+func (in *AgentEvalResult) DeepCopyInto(out *AgentEvalResult) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextMount.
-func (in *ContextMount) DeepCopy() *ContextMount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalResult.
+func (in *AgentEvalResult) DeepCopy() *AgentEvalResult {
 	if in == nil {
 		return nil
 	}
-	out := new(ContextMount)
+	out := new(AgentEvalResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContextSpec) DeepCopyInto(out *ContextSpec) {
+func (in *AgentEvalSpec) DeepCopyInto(out *AgentEvalSpec) {
 	*out = *in
-	if in.Inline != nil {
-		in, out := &in.Inline, &out.Inline
-		*out = new(InlineContext)
-		**out = **in
-	}
-	if in.ConfigMap != nil {
-		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(ConfigMapContext)
-		(*in).DeepCopyInto(*out)
+	if in.AgentRefs != nil {
+		in, out := &in.AgentRefs, &out.AgentRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Git != nil {
-		in, out := &in.Git, &out.Git
-		*out = new(GitContext)
-		(*in).DeepCopyInto(*out)
+	if in.Cases != nil {
+		in, out := &in.Cases, &out.Cases
+		*out = make([]EvalCase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextSpec.
-func (in *ContextSpec) DeepCopy() *ContextSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalSpec.
+func (in *AgentEvalSpec) DeepCopy() *AgentEvalSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ContextSpec)
+	out := new(AgentEvalSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Credential) DeepCopyInto(out *Credential) {
+func (in *AgentEvalStatus) DeepCopyInto(out *AgentEvalStatus) {
 	*out = *in
-	in.SecretRef.DeepCopyInto(&out.SecretRef)
-	if in.MountPath != nil {
-		in, out := &in.MountPath, &out.MountPath
-		*out = new(string)
-		**out = **in
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]AgentEvalResult, len(*in))
+		copy(*out, *in)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = new(string)
-		**out = **in
+	if in.Cases != nil {
+		in, out := &in.Cases, &out.Cases
+		*out = make([]EvalCaseResult, len(*in))
+		copy(*out, *in)
 	}
-	if in.FileMode != nil {
-		in, out := &in.FileMode, &out.FileMode
-		*out = new(int32)
-		**out = **in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
-func (in *Credential) DeepCopy() *Credential {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentEvalStatus.
+func (in *AgentEvalStatus) DeepCopy() *AgentEvalStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Credential)
+	out := new(AgentEvalStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronTask) DeepCopyInto(out *CronTask) {
+func (in *AgentExpose) DeepCopyInto(out *AgentExpose) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ExposeIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(ExposeHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTask.
-func (in *CronTask) DeepCopy() *CronTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentExpose.
+func (in *AgentExpose) DeepCopy() *AgentExpose {
 	if in == nil {
 		return nil
 	}
-	out := new(CronTask)
+	out := new(AgentExpose)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CronTask) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentImageStatus) DeepCopyInto(out *AgentImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentImageStatus.
+func (in *AgentImageStatus) DeepCopy() *AgentImageStatus {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(AgentImageStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronTaskList) DeepCopyInto(out *CronTaskList) {
+func (in *AgentList) DeepCopyInto(out *AgentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CronTask, len(*in))
+		*out = make([]Agent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTaskList.
-func (in *CronTaskList) DeepCopy() *CronTaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentList.
+func (in *AgentList) DeepCopy() *AgentList {
 	if in == nil {
 		return nil
 	}
-	out := new(CronTaskList)
+	out := new(AgentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CronTaskList) DeepCopyObject() runtime.Object {
+func (in *AgentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -393,52 +319,1384 @@ func (in *CronTaskList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronTaskSpec) DeepCopyInto(out *CronTaskSpec) {
+func (in *AgentMonitoring) DeepCopyInto(out *AgentMonitoring) {
 	*out = *in
-	if in.Suspend != nil {
-		in, out := &in.Suspend, &out.Suspend
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SuccessfulTasksHistoryLimit != nil {
-		in, out := &in.SuccessfulTasksHistoryLimit, &out.SuccessfulTasksHistoryLimit
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
 		*out = new(int32)
 		**out = **in
 	}
-	if in.FailedTasksHistoryLimit != nil {
-		in, out := &in.FailedTasksHistoryLimit, &out.FailedTasksHistoryLimit
-		*out = new(int32)
-		**out = **in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	in.TaskTemplate.DeepCopyInto(&out.TaskTemplate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTaskSpec.
-func (in *CronTaskSpec) DeepCopy() *CronTaskSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentMonitoring.
+func (in *AgentMonitoring) DeepCopy() *AgentMonitoring {
 	if in == nil {
 		return nil
 	}
-	out := new(CronTaskSpec)
+	out := new(AgentMonitoring)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronTaskStatus) DeepCopyInto(out *CronTaskStatus) {
+func (in *AgentPodSpec) DeepCopyInto(out *AgentPodSpec) {
 	*out = *in
-	if in.Active != nil {
-		in, out := &in.Active, &out.Active
-		*out = make([]corev1.ObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.LastScheduleTime != nil {
-		in, out := &in.LastScheduleTime, &out.LastScheduleTime
-		*out = (*in).DeepCopy()
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.LastSuccessfulTime != nil {
-		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(PodScheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSPolicy != nil {
+		in, out := &in.DNSPolicy, &out.DNSPolicy
+		*out = new(corev1.DNSPolicy)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestartPolicy != nil {
+		in, out := &in.RestartPolicy, &out.RestartPolicy
+		*out = new(corev1.RestartPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPodSpec.
+func (in *AgentPodSpec) DeepCopy() *AgentPodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPreflight) DeepCopyInto(out *AgentPreflight) {
+	*out = *in
+	if in.CLICommand != nil {
+		in, out := &in.CLICommand, &out.CLICommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPreflight.
+func (in *AgentPreflight) DeepCopy() *AgentPreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPreflight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPreflightStatus) DeepCopyInto(out *AgentPreflightStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPreflightStatus.
+func (in *AgentPreflightStatus) DeepCopy() *AgentPreflightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPreflightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentRBAC) DeepCopyInto(out *AgentRBAC) {
+	*out = *in
+	if in.Presets != nil {
+		in, out := &in.Presets, &out.Presets
+		*out = make([]AgentRBACPreset, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRBAC.
+func (in *AgentRBAC) DeepCopy() *AgentRBAC {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentRBAC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentRetention) DeepCopyInto(out *AgentRetention) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessfulTasksHistoryLimit != nil {
+		in, out := &in.SuccessfulTasksHistoryLimit, &out.SuccessfulTasksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedTasksHistoryLimit != nil {
+		in, out := &in.FailedTasksHistoryLimit, &out.FailedTasksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentRetention.
+func (in *AgentRetention) DeepCopy() *AgentRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSizing) DeepCopyInto(out *AgentSizing) {
+	*out = *in
+	if in.Percentile != nil {
+		in, out := &in.Percentile, &out.Percentile
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSizing.
+func (in *AgentSizing) DeepCopy() *AgentSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSizingStatus) DeepCopyInto(out *AgentSizingStatus) {
+	*out = *in
+	if in.Recommended != nil {
+		in, out := &in.Recommended, &out.Recommended
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
 		*out = (*in).DeepCopy()
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSizingStatus.
+func (in *AgentSizingStatus) DeepCopy() *AgentSizingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSizingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Contexts != nil {
+		in, out := &in.Contexts, &out.Contexts
+		*out = make([]ContextMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = make([]Credential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSpec != nil {
+		in, out := &in.PodSpec, &out.PodSpec
+		*out = new(AgentPodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(AgentPreflight)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(AgentCanary)
+		**out = **in
+	}
+	if in.FailureSnapshot != nil {
+		in, out := &in.FailureSnapshot, &out.FailureSnapshot
+		*out = new(FailureSnapshot)
+		**out = **in
+	}
+	if in.Cancellation != nil {
+		in, out := &in.Cancellation, &out.Cancellation
+		*out = new(AgentCancellation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StuckDetection != nil {
+		in, out := &in.StuckDetection, &out.StuckDetection
+		*out = new(AgentStuckDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sizing != nil {
+		in, out := &in.Sizing, &out.Sizing
+		*out = new(AgentSizing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(AgentRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialExpiry != nil {
+		in, out := &in.CredentialExpiry, &out.CredentialExpiry
+		*out = new(CredentialExpiryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RBAC != nil {
+		in, out := &in.RBAC, &out.RBAC
+		*out = new(AgentRBAC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContextCompression != nil {
+		in, out := &in.ContextCompression, &out.ContextCompression
+		*out = new(ContextCompression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(AgentMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(AgentExpose)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobTemplateOverrides != nil {
+		in, out := &in.JobTemplateOverrides, &out.JobTemplateOverrides
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
+func (in *AgentSpec) DeepCopy() *AgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
+	*out = *in
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(AgentPreflightStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(AgentCanaryStatus)
+		**out = **in
+	}
+	if in.Sizing != nil {
+		in, out := &in.Sizing, &out.Sizing
+		*out = new(AgentSizingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStatus.
+func (in *AgentStatus) DeepCopy() *AgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentStuckDetection) DeepCopyInto(out *AgentStuckDetection) {
+	*out = *in
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpectedDurationPercentile != nil {
+		in, out := &in.ExpectedDurationPercentile, &out.ExpectedDurationPercentile
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStuckDetection.
+func (in *AgentStuckDetection) DeepCopy() *AgentStuckDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentStuckDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComparisonResult) DeepCopyInto(out *ComparisonResult) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(TaskChanges)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComparisonResult.
+func (in *ComparisonResult) DeepCopy() *ComparisonResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ComparisonResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapContext) DeepCopyInto(out *ConfigMapContext) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapContext.
+func (in *ConfigMapContext) DeepCopy() *ConfigMapContext {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Context) DeepCopyInto(out *Context) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Context.
+func (in *Context) DeepCopy() *Context {
+	if in == nil {
+		return nil
+	}
+	out := new(Context)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Context) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextCacheConfig) DeepCopyInto(out *ContextCacheConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextCacheConfig.
+func (in *ContextCacheConfig) DeepCopy() *ContextCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextCompression) DeepCopyInto(out *ContextCompression) {
+	*out = *in
+	if in.MinSizeBytes != nil {
+		in, out := &in.MinSizeBytes, &out.MinSizeBytes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextCompression.
+func (in *ContextCompression) DeepCopy() *ContextCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextFreshness) DeepCopyInto(out *ContextFreshness) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextFreshness.
+func (in *ContextFreshness) DeepCopy() *ContextFreshness {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextFreshness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextList) DeepCopyInto(out *ContextList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Context, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextList.
+func (in *ContextList) DeepCopy() *ContextList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContextList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextMount) DeepCopyInto(out *ContextMount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextMount.
+func (in *ContextMount) DeepCopy() *ContextMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextSource) DeepCopyInto(out *ContextSource) {
+	*out = *in
+	if in.ModifiedTime != nil {
+		in, out := &in.ModifiedTime, &out.ModifiedTime
+		*out = (*in).DeepCopy()
+	}
+	in.ResolvedTime.DeepCopyInto(&out.ResolvedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextSource.
+func (in *ContextSource) DeepCopy() *ContextSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContextSpec) DeepCopyInto(out *ContextSpec) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(InlineContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(KubernetesContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(URLContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(ObjectStoreContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Freshness != nil {
+		in, out := &in.Freshness, &out.Freshness
+		*out = new(ContextFreshness)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContextSpec.
+func (in *ContextSpec) DeepCopy() *ContextSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContextSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Credential) DeepCopyInto(out *Credential) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+	if in.MountPath != nil {
+		in, out := &in.MountPath, &out.MountPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnvPrefix != nil {
+		in, out := &in.EnvPrefix, &out.EnvPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.FileMode != nil {
+		in, out := &in.FileMode, &out.FileMode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
+func (in *Credential) DeepCopy() *Credential {
+	if in == nil {
+		return nil
+	}
+	out := new(Credential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialExpiryPolicy) DeepCopyInto(out *CredentialExpiryPolicy) {
+	*out = *in
+	if in.MinValidity != nil {
+		in, out := &in.MinValidity, &out.MinValidity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialExpiryPolicy.
+func (in *CredentialExpiryPolicy) DeepCopy() *CredentialExpiryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialExpiryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronTask) DeepCopyInto(out *CronTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTask.
+func (in *CronTask) DeepCopy() *CronTask {
+	if in == nil {
+		return nil
+	}
+	out := new(CronTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CronTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronTaskList) DeepCopyInto(out *CronTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CronTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTaskList.
+func (in *CronTaskList) DeepCopy() *CronTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(CronTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CronTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronTaskSpec) DeepCopyInto(out *CronTaskSpec) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuccessfulTasksHistoryLimit != nil {
+		in, out := &in.SuccessfulTasksHistoryLimit, &out.SuccessfulTasksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedTasksHistoryLimit != nil {
+		in, out := &in.FailedTasksHistoryLimit, &out.FailedTasksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.TaskTemplate.DeepCopyInto(&out.TaskTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTaskSpec.
+func (in *CronTaskSpec) DeepCopy() *CronTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CronTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronTaskStatus) DeepCopyInto(out *CronTaskStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]corev1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTaskStatus.
+func (in *CronTaskStatus) DeepCopy() *CronTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CronTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DescriptionSource) DeepCopyInto(out *DescriptionSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DescriptionSource.
+func (in *DescriptionSource) DeepCopy() *DescriptionSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DescriptionSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveKubeTaskConfig) DeepCopyInto(out *EffectiveKubeTaskConfig) {
+	*out = *in
+	if in.TaskLifecycle != nil {
+		in, out := &in.TaskLifecycle, &out.TaskLifecycle
+		*out = new(TaskLifecycleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentDefaults != nil {
+		in, out := &in.AgentDefaults, &out.AgentDefaults
+		*out = new(AgentDefaultsConfig)
+		**out = **in
+	}
+	if in.RuntimePolicy != nil {
+		in, out := &in.RuntimePolicy, &out.RuntimePolicy
+		*out = new(RuntimePolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContextCache != nil {
+		in, out := &in.ContextCache, &out.ContextCache
+		*out = new(ContextCacheConfig)
+		**out = **in
+	}
+	if in.Prompt != nil {
+		in, out := &in.Prompt, &out.Prompt
+		*out = new(PromptConfig)
+		**out = **in
+	}
+	if in.DefaultContexts != nil {
+		in, out := &in.DefaultContexts, &out.DefaultContexts
+		*out = make([]ContextMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.JobMutation != nil {
+		in, out := &in.JobMutation, &out.JobMutation
+		*out = new(JobMutationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveKubeTaskConfig.
+func (in *EffectiveKubeTaskConfig) DeepCopy() *EffectiveKubeTaskConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveKubeTaskConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvalCase) DeepCopyInto(out *EvalCase) {
+	*out = *in
+	in.Task.DeepCopyInto(&out.Task)
+	if in.Verifier != nil {
+		in, out := &in.Verifier, &out.Verifier
+		*out = new(EvalVerifier)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvalCase.
+func (in *EvalCase) DeepCopy() *EvalCase {
+	if in == nil {
+		return nil
+	}
+	out := new(EvalCase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvalCaseResult) DeepCopyInto(out *EvalCaseResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvalCaseResult.
+func (in *EvalCaseResult) DeepCopy() *EvalCaseResult {
+	if in == nil {
+		return nil
+	}
+	out := new(EvalCaseResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvalVerifier) DeepCopyInto(out *EvalVerifier) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvalVerifier.
+func (in *EvalVerifier) DeepCopy() *EvalVerifier {
+	if in == nil {
+		return nil
+	}
+	out := new(EvalVerifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeHTTPRoute) DeepCopyInto(out *ExposeHTTPRoute) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]ExposeParentRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeHTTPRoute.
+func (in *ExposeHTTPRoute) DeepCopy() *ExposeHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeIngress) DeepCopyInto(out *ExposeIngress) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeIngress.
+func (in *ExposeIngress) DeepCopy() *ExposeIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeParentRef) DeepCopyInto(out *ExposeParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeParentRef.
+func (in *ExposeParentRef) DeepCopy() *ExposeParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ExposeParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureArtifact) DeepCopyInto(out *FailureArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureArtifact.
+func (in *FailureArtifact) DeepCopy() *FailureArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureSnapshot) DeepCopyInto(out *FailureSnapshot) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureSnapshot.
+func (in *FailureSnapshot) DeepCopy() *FailureSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSource) DeepCopyInto(out *FileSource) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSource.
+func (in *FileSource) DeepCopy() *FileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitContext) DeepCopyInto(out *GitContext) {
+	*out = *in
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(GitSecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitContext.
+func (in *GitContext) DeepCopy() *GitContext {
+	if in == nil {
+		return nil
+	}
+	out := new(GitContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSecretReference) DeepCopyInto(out *GitSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSecretReference.
+func (in *GitSecretReference) DeepCopy() *GitSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HumanInTheLoop) DeepCopyInto(out *HumanInTheLoop) {
+	*out = *in
+	if in.KeepAliveSeconds != nil {
+		in, out := &in.KeepAliveSeconds, &out.KeepAliveSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HumanInTheLoop.
+func (in *HumanInTheLoop) DeepCopy() *HumanInTheLoop {
+	if in == nil {
+		return nil
+	}
+	out := new(HumanInTheLoop)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InlineContext) DeepCopyInto(out *InlineContext) {
+	*out = *in
+	if in.BinaryContent != nil {
+		in, out := &in.BinaryContent, &out.BinaryContent
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineContext.
+func (in *InlineContext) DeepCopy() *InlineContext {
+	if in == nil {
+		return nil
+	}
+	out := new(InlineContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobMutationConfig) DeepCopyInto(out *JobMutationConfig) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]JobMutationWebhook, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobMutationConfig.
+func (in *JobMutationConfig) DeepCopy() *JobMutationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobMutationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobMutationWebhook) DeepCopyInto(out *JobMutationWebhook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobMutationWebhook.
+func (in *JobMutationWebhook) DeepCopy() *JobMutationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(JobMutationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeTaskConfig) DeepCopyInto(out *KubeTaskConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfig.
+func (in *KubeTaskConfig) DeepCopy() *KubeTaskConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeTaskConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeTaskConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeTaskConfigList) DeepCopyInto(out *KubeTaskConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubeTaskConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfigList.
+func (in *KubeTaskConfigList) DeepCopy() *KubeTaskConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeTaskConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeTaskConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeTaskConfigSpec) DeepCopyInto(out *KubeTaskConfigSpec) {
+	*out = *in
+	if in.TaskLifecycle != nil {
+		in, out := &in.TaskLifecycle, &out.TaskLifecycle
+		*out = new(TaskLifecycleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentDefaults != nil {
+		in, out := &in.AgentDefaults, &out.AgentDefaults
+		*out = new(AgentDefaultsConfig)
+		**out = **in
+	}
+	if in.RuntimePolicy != nil {
+		in, out := &in.RuntimePolicy, &out.RuntimePolicy
+		*out = new(RuntimePolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContextCache != nil {
+		in, out := &in.ContextCache, &out.ContextCache
+		*out = new(ContextCacheConfig)
+		**out = **in
+	}
+	if in.Prompt != nil {
+		in, out := &in.Prompt, &out.Prompt
+		*out = new(PromptConfig)
+		**out = **in
+	}
+	if in.DefaultContexts != nil {
+		in, out := &in.DefaultContexts, &out.DefaultContexts
+		*out = make([]ContextMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.JobMutation != nil {
+		in, out := &in.JobMutation, &out.JobMutation
+		*out = new(JobMutationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfigSpec.
+func (in *KubeTaskConfigSpec) DeepCopy() *KubeTaskConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeTaskConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeTaskConfigStatus) DeepCopyInto(out *KubeTaskConfigStatus) {
+	*out = *in
+	if in.Effective != nil {
+		in, out := &in.Effective, &out.Effective
+		*out = new(EffectiveKubeTaskConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -448,141 +1706,485 @@ func (in *CronTaskStatus) DeepCopyInto(out *CronTaskStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronTaskStatus.
-func (in *CronTaskStatus) DeepCopy() *CronTaskStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfigStatus.
+func (in *KubeTaskConfigStatus) DeepCopy() *KubeTaskConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CronTaskStatus)
+	out := new(KubeTaskConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSource) DeepCopyInto(out *FileSource) {
+func (in *KubernetesContext) DeepCopyInto(out *KubernetesContext) {
 	*out = *in
-	if in.Inline != nil {
-		in, out := &in.Inline, &out.Inline
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesContext.
+func (in *KubernetesContext) DeepCopy() *KubernetesContext {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIContext) DeepCopyInto(out *OCIContext) {
+	*out = *in
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIContext.
+func (in *OCIContext) DeepCopy() *OCIContext {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreContext) DeepCopyInto(out *ObjectStoreContext) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreContext.
+func (in *ObjectStoreContext) DeepCopy() *ObjectStoreContext {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodScheduling) DeepCopyInto(out *PodScheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodScheduling.
+func (in *PodScheduling) DeepCopy() *PodScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(PodScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptConfig) DeepCopyInto(out *PromptConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptConfig.
+func (in *PromptConfig) DeepCopy() *PromptConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuietHours) DeepCopyInto(out *QuietHours) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuietHours.
+func (in *QuietHours) DeepCopy() *QuietHours {
+	if in == nil {
+		return nil
+	}
+	out := new(QuietHours)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.BackoffSeconds != nil {
+		in, out := &in.BackoffSeconds, &out.BackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]RetryOn, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimePolicyConfig) DeepCopyInto(out *RuntimePolicyConfig) {
+	*out = *in
+	if in.RuntimeClassRanking != nil {
+		in, out := &in.RuntimeClassRanking, &out.RuntimeClassRanking
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimePolicyConfig.
+func (in *RuntimePolicyConfig) DeepCopy() *RuntimePolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimePolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
 		*out = new(string)
 		**out = **in
 	}
-	if in.ConfigMapKeyRef != nil {
-		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
-		*out = new(ConfigMapKeySelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Task) DeepCopyInto(out *Task) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
+func (in *Task) DeepCopy() *Task {
+	if in == nil {
+		return nil
+	}
+	out := new(Task)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Task) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskChanges) DeepCopyInto(out *TaskChanges) {
+	*out = *in
+	if in.PullRequests != nil {
+		in, out := &in.PullRequests, &out.PullRequests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Commits != nil {
+		in, out := &in.Commits, &out.Commits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedFiles != nil {
+		in, out := &in.ChangedFiles, &out.ChangedFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskChanges.
+func (in *TaskChanges) DeepCopy() *TaskChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskExecutionStatus) DeepCopyInto(out *TaskExecutionStatus) {
+	*out = *in
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PodScheduledTime != nil {
+		in, out := &in.PodScheduledTime, &out.PodScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AgentStartTime != nil {
+		in, out := &in.AgentStartTime, &out.AgentStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AgentImage != nil {
+		in, out := &in.AgentImage, &out.AgentImage
+		*out = new(AgentImageStatus)
+		**out = **in
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = new(TaskChanges)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ConfigMapRef != nil {
-		in, out := &in.ConfigMapRef, &out.ConfigMapRef
-		*out = new(ConfigMapReference)
+	if in.Result != nil {
+		in, out := &in.Result, &out.Result
+		*out = new(TaskResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Comparison != nil {
+		in, out := &in.Comparison, &out.Comparison
+		*out = make([]ComparisonResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(TaskProgress)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FailureArtifacts != nil {
+		in, out := &in.FailureArtifacts, &out.FailureArtifacts
+		*out = make([]FailureArtifact, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContextSources != nil {
+		in, out := &in.ContextSources, &out.ContextSources
+		*out = make([]ContextSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PeakUsage != nil {
+		in, out := &in.PeakUsage, &out.PeakUsage
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSource.
-func (in *FileSource) DeepCopy() *FileSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionStatus.
+func (in *TaskExecutionStatus) DeepCopy() *TaskExecutionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FileSource)
+	out := new(TaskExecutionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitContext) DeepCopyInto(out *GitContext) {
+func (in *TaskLifecycleConfig) DeepCopyInto(out *TaskLifecycleConfig) {
 	*out = *in
-	if in.Depth != nil {
-		in, out := &in.Depth, &out.Depth
-		*out = new(int)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
 		**out = **in
 	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(GitSecretReference)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]TaskLifecycleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QuietHours != nil {
+		in, out := &in.QuietHours, &out.QuietHours
+		*out = make([]QuietHours, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultTimeoutSeconds != nil {
+		in, out := &in.DefaultTimeoutSeconds, &out.DefaultTimeoutSeconds
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitContext.
-func (in *GitContext) DeepCopy() *GitContext {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskLifecycleConfig.
+func (in *TaskLifecycleConfig) DeepCopy() *TaskLifecycleConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GitContext)
+	out := new(TaskLifecycleConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitSecretReference) DeepCopyInto(out *GitSecretReference) {
+func (in *TaskLifecycleRule) DeepCopyInto(out *TaskLifecycleRule) {
 	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSecretReference.
-func (in *GitSecretReference) DeepCopy() *GitSecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskLifecycleRule.
+func (in *TaskLifecycleRule) DeepCopy() *TaskLifecycleRule {
 	if in == nil {
 		return nil
 	}
-	out := new(GitSecretReference)
+	out := new(TaskLifecycleRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HumanInTheLoop) DeepCopyInto(out *HumanInTheLoop) {
+func (in *TaskList) DeepCopyInto(out *TaskList) {
 	*out = *in
-	if in.KeepAliveSeconds != nil {
-		in, out := &in.KeepAliveSeconds, &out.KeepAliveSeconds
-		*out = new(int32)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Task, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HumanInTheLoop.
-func (in *HumanInTheLoop) DeepCopy() *HumanInTheLoop {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskList.
+func (in *TaskList) DeepCopy() *TaskList {
 	if in == nil {
 		return nil
 	}
-	out := new(HumanInTheLoop)
+	out := new(TaskList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InlineContext) DeepCopyInto(out *InlineContext) {
+func (in *TaskProgress) DeepCopyInto(out *TaskProgress) {
 	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	in.UpdateTime.DeepCopyInto(&out.UpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InlineContext.
-func (in *InlineContext) DeepCopy() *InlineContext {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskProgress.
+func (in *TaskProgress) DeepCopy() *TaskProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(InlineContext)
+	out := new(TaskProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeTaskConfig) DeepCopyInto(out *KubeTaskConfig) {
+func (in *TaskQuota) DeepCopyInto(out *TaskQuota) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfig.
-func (in *KubeTaskConfig) DeepCopy() *KubeTaskConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQuota.
+func (in *TaskQuota) DeepCopy() *TaskQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeTaskConfig)
+	out := new(TaskQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeTaskConfig) DeepCopyObject() runtime.Object {
+func (in *TaskQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -590,31 +2192,31 @@ func (in *KubeTaskConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeTaskConfigList) DeepCopyInto(out *KubeTaskConfigList) {
+func (in *TaskQuotaList) DeepCopyInto(out *TaskQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KubeTaskConfig, len(*in))
+		*out = make([]TaskQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfigList.
-func (in *KubeTaskConfigList) DeepCopy() *KubeTaskConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQuotaList.
+func (in *TaskQuotaList) DeepCopy() *TaskQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeTaskConfigList)
+	out := new(TaskQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeTaskConfigList) DeepCopyObject() runtime.Object {
+func (in *TaskQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -622,188 +2224,130 @@ func (in *KubeTaskConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeTaskConfigSpec) DeepCopyInto(out *KubeTaskConfigSpec) {
+func (in *TaskQuotaSpec) DeepCopyInto(out *TaskQuotaSpec) {
 	*out = *in
-	if in.TaskLifecycle != nil {
-		in, out := &in.TaskLifecycle, &out.TaskLifecycle
-		*out = new(TaskLifecycleConfig)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxConcurrentTasks != nil {
+		in, out := &in.MaxConcurrentTasks, &out.MaxConcurrentTasks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTasksPerDay != nil {
+		in, out := &in.MaxTasksPerDay, &out.MaxTasksPerDay
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRuntimeHoursPerDay != nil {
+		in, out := &in.MaxRuntimeHoursPerDay, &out.MaxRuntimeHoursPerDay
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeTaskConfigSpec.
-func (in *KubeTaskConfigSpec) DeepCopy() *KubeTaskConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQuotaSpec.
+func (in *TaskQuotaSpec) DeepCopy() *TaskQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeTaskConfigSpec)
+	out := new(TaskQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodScheduling) DeepCopyInto(out *PodScheduling) {
+func (in *TaskQuotaStatus) DeepCopyInto(out *TaskQuotaStatus) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out.Used = in.Used
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodScheduling.
-func (in *PodScheduling) DeepCopy() *PodScheduling {
-	if in == nil {
-		return nil
-	}
-	out := new(PodScheduling)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretReference) DeepCopyInto(out *SecretReference) {
-	*out = *in
-	if in.Key != nil {
-		in, out := &in.Key, &out.Key
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQuotaStatus.
+func (in *TaskQuotaStatus) DeepCopy() *TaskQuotaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(TaskQuotaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Task) DeepCopyInto(out *Task) {
+func (in *TaskQuotaUsage) DeepCopyInto(out *TaskQuotaUsage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.RuntimePerDay = in.RuntimePerDay
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
-func (in *Task) DeepCopy() *Task {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskQuotaUsage.
+func (in *TaskQuotaUsage) DeepCopy() *TaskQuotaUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(Task)
+	out := new(TaskQuotaUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Task) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskExecutionStatus) DeepCopyInto(out *TaskExecutionStatus) {
+func (in *TaskReplicas) DeepCopyInto(out *TaskReplicas) {
 	*out = *in
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
-		*out = (*in).DeepCopy()
-	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskExecutionStatus.
-func (in *TaskExecutionStatus) DeepCopy() *TaskExecutionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskReplicas.
+func (in *TaskReplicas) DeepCopy() *TaskReplicas {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskExecutionStatus)
+	out := new(TaskReplicas)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskLifecycleConfig) DeepCopyInto(out *TaskLifecycleConfig) {
+func (in *TaskResult) DeepCopyInto(out *TaskResult) {
 	*out = *in
-	if in.TTLSecondsAfterFinished != nil {
-		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
 		*out = new(int32)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskLifecycleConfig.
-func (in *TaskLifecycleConfig) DeepCopy() *TaskLifecycleConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(TaskLifecycleConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskList) DeepCopyInto(out *TaskList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Task, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskList.
-func (in *TaskList) DeepCopy() *TaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResult.
+func (in *TaskResult) DeepCopy() *TaskResult {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskList)
+	out := new(TaskResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TaskList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
@@ -812,16 +2356,61 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DescriptionFrom != nil {
+		in, out := &in.DescriptionFrom, &out.DescriptionFrom
+		*out = new(DescriptionSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Contexts != nil {
 		in, out := &in.Contexts, &out.Contexts
 		*out = make([]ContextMount, len(*in))
 		copy(*out, *in)
 	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]WorkspaceFile, len(*in))
+		copy(*out, *in)
+	}
 	if in.HumanInTheLoop != nil {
 		in, out := &in.HumanInTheLoop, &out.HumanInTheLoop
 		*out = new(HumanInTheLoop)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(TaskReplicas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunAfter != nil {
+		in, out := &in.RunAfter, &out.RunAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpec.
@@ -850,3 +2439,43 @@ func (in *TaskTemplateSpec) DeepCopy() *TaskTemplateSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLContext) DeepCopyInto(out *URLContext) {
+	*out = *in
+	if in.HeadersSecretRef != nil {
+		in, out := &in.HeadersSecretRef, &out.HeadersSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLContext.
+func (in *URLContext) DeepCopy() *URLContext {
+	if in == nil {
+		return nil
+	}
+	out := new(URLContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceFile) DeepCopyInto(out *WorkspaceFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceFile.
+func (in *WorkspaceFile) DeepCopy() *WorkspaceFile {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceFile)
+	in.DeepCopyInto(out)
+	return out
+}
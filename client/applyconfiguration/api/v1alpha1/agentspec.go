@@ -0,0 +1,243 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// AgentSpecApplyConfiguration represents a declarative configuration of the AgentSpec type for use
+// with apply.
+type AgentSpecApplyConfiguration struct {
+	AgentImage           *string                                   `json:"agentImage,omitempty"`
+	WorkspaceDir         *string                                   `json:"workspaceDir,omitempty"`
+	ContainerName        *string                                   `json:"containerName,omitempty"`
+	Command              []string                                  `json:"command,omitempty"`
+	Contexts             []ContextMountApplyConfiguration          `json:"contexts,omitempty"`
+	Credentials          []CredentialApplyConfiguration            `json:"credentials,omitempty"`
+	EnvFrom              []v1.EnvFromSource                        `json:"envFrom,omitempty"`
+	PodSpec              *AgentPodSpecApplyConfiguration           `json:"podSpec,omitempty"`
+	ServiceAccountName   *string                                   `json:"serviceAccountName,omitempty"`
+	Preflight            *AgentPreflightApplyConfiguration         `json:"preflight,omitempty"`
+	Canary               *AgentCanaryApplyConfiguration            `json:"canary,omitempty"`
+	FailureSnapshot      *FailureSnapshotApplyConfiguration        `json:"failureSnapshot,omitempty"`
+	Cancellation         *AgentCancellationApplyConfiguration      `json:"cancellation,omitempty"`
+	StuckDetection       *AgentStuckDetectionApplyConfiguration    `json:"stuckDetection,omitempty"`
+	Sizing               *AgentSizingApplyConfiguration            `json:"sizing,omitempty"`
+	Retention            *AgentRetentionApplyConfiguration         `json:"retention,omitempty"`
+	CredentialExpiry     *CredentialExpiryPolicyApplyConfiguration `json:"credentialExpiry,omitempty"`
+	RBAC                 *AgentRBACApplyConfiguration              `json:"rbac,omitempty"`
+	SecurityProfile      *apiv1alpha1.SecurityProfile              `json:"securityProfile,omitempty"`
+	ContextCompression   *ContextCompressionApplyConfiguration     `json:"contextCompression,omitempty"`
+	Monitoring           *AgentMonitoringApplyConfiguration        `json:"monitoring,omitempty"`
+	Expose               *AgentExposeApplyConfiguration            `json:"expose,omitempty"`
+	JobTemplateOverrides *runtime.RawExtension                     `json:"jobTemplateOverrides,omitempty"`
+}
+
+// AgentSpecApplyConfiguration constructs a declarative configuration of the AgentSpec type for use with
+// apply.
+func AgentSpec() *AgentSpecApplyConfiguration {
+	return &AgentSpecApplyConfiguration{}
+}
+
+// WithAgentImage sets the AgentImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AgentImage field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithAgentImage(value string) *AgentSpecApplyConfiguration {
+	b.AgentImage = &value
+	return b
+}
+
+// WithWorkspaceDir sets the WorkspaceDir field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WorkspaceDir field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithWorkspaceDir(value string) *AgentSpecApplyConfiguration {
+	b.WorkspaceDir = &value
+	return b
+}
+
+// WithContainerName sets the ContainerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ContainerName field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithContainerName(value string) *AgentSpecApplyConfiguration {
+	b.ContainerName = &value
+	return b
+}
+
+// WithCommand adds the given value to the Command field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Command field.
+func (b *AgentSpecApplyConfiguration) WithCommand(values ...string) *AgentSpecApplyConfiguration {
+	for i := range values {
+		b.Command = append(b.Command, values[i])
+	}
+	return b
+}
+
+// WithContexts adds the given value to the Contexts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Contexts field.
+func (b *AgentSpecApplyConfiguration) WithContexts(values ...*ContextMountApplyConfiguration) *AgentSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithContexts")
+		}
+		b.Contexts = append(b.Contexts, *values[i])
+	}
+	return b
+}
+
+// WithCredentials adds the given value to the Credentials field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Credentials field.
+func (b *AgentSpecApplyConfiguration) WithCredentials(values ...*CredentialApplyConfiguration) *AgentSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCredentials")
+		}
+		b.Credentials = append(b.Credentials, *values[i])
+	}
+	return b
+}
+
+// WithEnvFrom adds the given value to the EnvFrom field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the EnvFrom field.
+func (b *AgentSpecApplyConfiguration) WithEnvFrom(values ...v1.EnvFromSource) *AgentSpecApplyConfiguration {
+	for i := range values {
+		b.EnvFrom = append(b.EnvFrom, values[i])
+	}
+	return b
+}
+
+// WithPodSpec sets the PodSpec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSpec field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithPodSpec(value *AgentPodSpecApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.PodSpec = value
+	return b
+}
+
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithServiceAccountName(value string) *AgentSpecApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
+
+// WithPreflight sets the Preflight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Preflight field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithPreflight(value *AgentPreflightApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Preflight = value
+	return b
+}
+
+// WithCanary sets the Canary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Canary field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithCanary(value *AgentCanaryApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Canary = value
+	return b
+}
+
+// WithFailureSnapshot sets the FailureSnapshot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureSnapshot field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithFailureSnapshot(value *FailureSnapshotApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.FailureSnapshot = value
+	return b
+}
+
+// WithCancellation sets the Cancellation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cancellation field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithCancellation(value *AgentCancellationApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Cancellation = value
+	return b
+}
+
+// WithStuckDetection sets the StuckDetection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StuckDetection field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithStuckDetection(value *AgentStuckDetectionApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.StuckDetection = value
+	return b
+}
+
+// WithSizing sets the Sizing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sizing field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithSizing(value *AgentSizingApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Sizing = value
+	return b
+}
+
+// WithRetention sets the Retention field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Retention field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithRetention(value *AgentRetentionApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Retention = value
+	return b
+}
+
+// WithCredentialExpiry sets the CredentialExpiry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CredentialExpiry field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithCredentialExpiry(value *CredentialExpiryPolicyApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.CredentialExpiry = value
+	return b
+}
+
+// WithRBAC sets the RBAC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RBAC field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithRBAC(value *AgentRBACApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.RBAC = value
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithSecurityProfile(value apiv1alpha1.SecurityProfile) *AgentSpecApplyConfiguration {
+	b.SecurityProfile = &value
+	return b
+}
+
+// WithContextCompression sets the ContextCompression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ContextCompression field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithContextCompression(value *ContextCompressionApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.ContextCompression = value
+	return b
+}
+
+// WithMonitoring sets the Monitoring field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Monitoring field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithMonitoring(value *AgentMonitoringApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Monitoring = value
+	return b
+}
+
+// WithExpose sets the Expose field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expose field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithExpose(value *AgentExposeApplyConfiguration) *AgentSpecApplyConfiguration {
+	b.Expose = value
+	return b
+}
+
+// WithJobTemplateOverrides sets the JobTemplateOverrides field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobTemplateOverrides field is set to the value of the last call.
+func (b *AgentSpecApplyConfiguration) WithJobTemplateOverrides(value runtime.RawExtension) *AgentSpecApplyConfiguration {
+	b.JobTemplateOverrides = &value
+	return b
+}
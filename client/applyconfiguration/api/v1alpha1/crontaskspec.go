@@ -0,0 +1,74 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// CronTaskSpecApplyConfiguration represents a declarative configuration of the CronTaskSpec type for use
+// with apply.
+type CronTaskSpecApplyConfiguration struct {
+	Schedule                    *string                             `json:"schedule,omitempty"`
+	ConcurrencyPolicy           *apiv1alpha1.ConcurrencyPolicy      `json:"concurrencyPolicy,omitempty"`
+	Suspend                     *bool                               `json:"suspend,omitempty"`
+	SuccessfulTasksHistoryLimit *int32                              `json:"successfulTasksHistoryLimit,omitempty"`
+	FailedTasksHistoryLimit     *int32                              `json:"failedTasksHistoryLimit,omitempty"`
+	TaskTemplate                *TaskTemplateSpecApplyConfiguration `json:"taskTemplate,omitempty"`
+}
+
+// CronTaskSpecApplyConfiguration constructs a declarative configuration of the CronTaskSpec type for use with
+// apply.
+func CronTaskSpec() *CronTaskSpecApplyConfiguration {
+	return &CronTaskSpecApplyConfiguration{}
+}
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *CronTaskSpecApplyConfiguration) WithSchedule(value string) *CronTaskSpecApplyConfiguration {
+	b.Schedule = &value
+	return b
+}
+
+// WithConcurrencyPolicy sets the ConcurrencyPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConcurrencyPolicy field is set to the value of the last call.
+func (b *CronTaskSpecApplyConfiguration) WithConcurrencyPolicy(value apiv1alpha1.ConcurrencyPolicy) *CronTaskSpecApplyConfiguration {
+	b.ConcurrencyPolicy = &value
+	return b
+}
+
+// WithSuspend sets the Suspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Suspend field is set to the value of the last call.
+func (b *CronTaskSpecApplyConfiguration) WithSuspend(value bool) *CronTaskSpecApplyConfiguration {
+	b.Suspend = &value
+	return b
+}
+
+// WithSuccessfulTasksHistoryLimit sets the SuccessfulTasksHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SuccessfulTasksHistoryLimit field is set to the value of the last call.
+func (b *CronTaskSpecApplyConfiguration) WithSuccessfulTasksHistoryLimit(value int32) *CronTaskSpecApplyConfiguration {
+	b.SuccessfulTasksHistoryLimit = &value
+	return b
+}
+
+// WithFailedTasksHistoryLimit sets the FailedTasksHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedTasksHistoryLimit field is set to the value of the last call.
+func (b *CronTaskSpecApplyConfiguration) WithFailedTasksHistoryLimit(value int32) *CronTaskSpecApplyConfiguration {
+	b.FailedTasksHistoryLimit = &value
+	return b
+}
+
+// WithTaskTemplate sets the TaskTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TaskTemplate field is set to the value of the last call.
+func (b *CronTaskSpecApplyConfiguration) WithTaskTemplate(value *TaskTemplateSpecApplyConfiguration) *CronTaskSpecApplyConfiguration {
+	b.TaskTemplate = value
+	return b
+}
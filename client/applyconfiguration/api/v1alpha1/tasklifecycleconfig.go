@@ -0,0 +1,75 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// TaskLifecycleConfigApplyConfiguration represents a declarative configuration of the TaskLifecycleConfig type for use
+// with apply.
+type TaskLifecycleConfigApplyConfiguration struct {
+	TTLSecondsAfterFinished *int32                                `json:"ttlSecondsAfterFinished,omitempty"`
+	Rules                   []TaskLifecycleRuleApplyConfiguration `json:"rules,omitempty"`
+	QuietHours              []QuietHoursApplyConfiguration        `json:"quietHours,omitempty"`
+	LateJobChanges          *apiv1alpha1.LateJobChangePolicy      `json:"lateJobChanges,omitempty"`
+	DefaultTimeoutSeconds   *int64                                `json:"defaultTimeoutSeconds,omitempty"`
+}
+
+// TaskLifecycleConfigApplyConfiguration constructs a declarative configuration of the TaskLifecycleConfig type for use with
+// apply.
+func TaskLifecycleConfig() *TaskLifecycleConfigApplyConfiguration {
+	return &TaskLifecycleConfigApplyConfiguration{}
+}
+
+// WithTTLSecondsAfterFinished sets the TTLSecondsAfterFinished field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTLSecondsAfterFinished field is set to the value of the last call.
+func (b *TaskLifecycleConfigApplyConfiguration) WithTTLSecondsAfterFinished(value int32) *TaskLifecycleConfigApplyConfiguration {
+	b.TTLSecondsAfterFinished = &value
+	return b
+}
+
+// WithRules adds the given value to the Rules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Rules field.
+func (b *TaskLifecycleConfigApplyConfiguration) WithRules(values ...*TaskLifecycleRuleApplyConfiguration) *TaskLifecycleConfigApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRules")
+		}
+		b.Rules = append(b.Rules, *values[i])
+	}
+	return b
+}
+
+// WithQuietHours adds the given value to the QuietHours field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the QuietHours field.
+func (b *TaskLifecycleConfigApplyConfiguration) WithQuietHours(values ...*QuietHoursApplyConfiguration) *TaskLifecycleConfigApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithQuietHours")
+		}
+		b.QuietHours = append(b.QuietHours, *values[i])
+	}
+	return b
+}
+
+// WithLateJobChanges sets the LateJobChanges field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LateJobChanges field is set to the value of the last call.
+func (b *TaskLifecycleConfigApplyConfiguration) WithLateJobChanges(value apiv1alpha1.LateJobChangePolicy) *TaskLifecycleConfigApplyConfiguration {
+	b.LateJobChanges = &value
+	return b
+}
+
+// WithDefaultTimeoutSeconds sets the DefaultTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTimeoutSeconds field is set to the value of the last call.
+func (b *TaskLifecycleConfigApplyConfiguration) WithDefaultTimeoutSeconds(value int64) *TaskLifecycleConfigApplyConfiguration {
+	b.DefaultTimeoutSeconds = &value
+	return b
+}
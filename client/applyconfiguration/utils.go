@@ -0,0 +1,188 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	internal "github.com/kubetask/kubetask/client/applyconfiguration/internal"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	testing "k8s.io/client-go/testing"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=kubetask.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Agent"):
+		return &apiv1alpha1.AgentApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentCanary"):
+		return &apiv1alpha1.AgentCanaryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentCanaryStatus"):
+		return &apiv1alpha1.AgentCanaryStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentCancellation"):
+		return &apiv1alpha1.AgentCancellationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentDefaultsConfig"):
+		return &apiv1alpha1.AgentDefaultsConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentEval"):
+		return &apiv1alpha1.AgentEvalApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentEvalResult"):
+		return &apiv1alpha1.AgentEvalResultApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentEvalSpec"):
+		return &apiv1alpha1.AgentEvalSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentEvalStatus"):
+		return &apiv1alpha1.AgentEvalStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentExpose"):
+		return &apiv1alpha1.AgentExposeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentImageStatus"):
+		return &apiv1alpha1.AgentImageStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentMonitoring"):
+		return &apiv1alpha1.AgentMonitoringApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentPodSpec"):
+		return &apiv1alpha1.AgentPodSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentPreflight"):
+		return &apiv1alpha1.AgentPreflightApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentPreflightStatus"):
+		return &apiv1alpha1.AgentPreflightStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentRBAC"):
+		return &apiv1alpha1.AgentRBACApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentRetention"):
+		return &apiv1alpha1.AgentRetentionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentSizing"):
+		return &apiv1alpha1.AgentSizingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentSizingStatus"):
+		return &apiv1alpha1.AgentSizingStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentSpec"):
+		return &apiv1alpha1.AgentSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentStatus"):
+		return &apiv1alpha1.AgentStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AgentStuckDetection"):
+		return &apiv1alpha1.AgentStuckDetectionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ComparisonResult"):
+		return &apiv1alpha1.ComparisonResultApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapContext"):
+		return &apiv1alpha1.ConfigMapContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Context"):
+		return &apiv1alpha1.ContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ContextCacheConfig"):
+		return &apiv1alpha1.ContextCacheConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ContextCompression"):
+		return &apiv1alpha1.ContextCompressionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ContextFreshness"):
+		return &apiv1alpha1.ContextFreshnessApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ContextMount"):
+		return &apiv1alpha1.ContextMountApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ContextSource"):
+		return &apiv1alpha1.ContextSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ContextSpec"):
+		return &apiv1alpha1.ContextSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Credential"):
+		return &apiv1alpha1.CredentialApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CredentialExpiryPolicy"):
+		return &apiv1alpha1.CredentialExpiryPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CronTask"):
+		return &apiv1alpha1.CronTaskApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CronTaskSpec"):
+		return &apiv1alpha1.CronTaskSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CronTaskStatus"):
+		return &apiv1alpha1.CronTaskStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DescriptionSource"):
+		return &apiv1alpha1.DescriptionSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EffectiveKubeTaskConfig"):
+		return &apiv1alpha1.EffectiveKubeTaskConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EvalCase"):
+		return &apiv1alpha1.EvalCaseApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EvalCaseResult"):
+		return &apiv1alpha1.EvalCaseResultApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EvalVerifier"):
+		return &apiv1alpha1.EvalVerifierApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExposeHTTPRoute"):
+		return &apiv1alpha1.ExposeHTTPRouteApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExposeIngress"):
+		return &apiv1alpha1.ExposeIngressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExposeParentRef"):
+		return &apiv1alpha1.ExposeParentRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FailureArtifact"):
+		return &apiv1alpha1.FailureArtifactApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FailureSnapshot"):
+		return &apiv1alpha1.FailureSnapshotApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitContext"):
+		return &apiv1alpha1.GitContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitSecretReference"):
+		return &apiv1alpha1.GitSecretReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HumanInTheLoop"):
+		return &apiv1alpha1.HumanInTheLoopApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InlineContext"):
+		return &apiv1alpha1.InlineContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("JobMutationConfig"):
+		return &apiv1alpha1.JobMutationConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("JobMutationWebhook"):
+		return &apiv1alpha1.JobMutationWebhookApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KubernetesContext"):
+		return &apiv1alpha1.KubernetesContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KubeTaskConfig"):
+		return &apiv1alpha1.KubeTaskConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KubeTaskConfigSpec"):
+		return &apiv1alpha1.KubeTaskConfigSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("KubeTaskConfigStatus"):
+		return &apiv1alpha1.KubeTaskConfigStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ObjectStoreContext"):
+		return &apiv1alpha1.ObjectStoreContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OCIContext"):
+		return &apiv1alpha1.OCIContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PodScheduling"):
+		return &apiv1alpha1.PodSchedulingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PromptConfig"):
+		return &apiv1alpha1.PromptConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("QuietHours"):
+		return &apiv1alpha1.QuietHoursApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RetryPolicy"):
+		return &apiv1alpha1.RetryPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RuntimePolicyConfig"):
+		return &apiv1alpha1.RuntimePolicyConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SecretReference"):
+		return &apiv1alpha1.SecretReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Task"):
+		return &apiv1alpha1.TaskApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskChanges"):
+		return &apiv1alpha1.TaskChangesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskExecutionStatus"):
+		return &apiv1alpha1.TaskExecutionStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskLifecycleConfig"):
+		return &apiv1alpha1.TaskLifecycleConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskLifecycleRule"):
+		return &apiv1alpha1.TaskLifecycleRuleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskProgress"):
+		return &apiv1alpha1.TaskProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskQuota"):
+		return &apiv1alpha1.TaskQuotaApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskQuotaSpec"):
+		return &apiv1alpha1.TaskQuotaSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskQuotaStatus"):
+		return &apiv1alpha1.TaskQuotaStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskQuotaUsage"):
+		return &apiv1alpha1.TaskQuotaUsageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskReplicas"):
+		return &apiv1alpha1.TaskReplicasApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskResult"):
+		return &apiv1alpha1.TaskResultApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskSpec"):
+		return &apiv1alpha1.TaskSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskTemplateSpec"):
+		return &apiv1alpha1.TaskTemplateSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("URLContext"):
+		return &apiv1alpha1.URLContextApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WorkspaceFile"):
+		return &apiv1alpha1.WorkspaceFileApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) *testing.TypeConverter {
+	return &testing.TypeConverter{Scheme: scheme, TypeResolver: internal.Parser()}
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// AgentsGetter has a method to return a AgentInterface.
+// A group's client should implement this interface.
+type AgentsGetter interface {
+	Agents(namespace string) AgentInterface
+}
+
+// AgentInterface has methods to work with Agent resources.
+type AgentInterface interface {
+	Create(ctx context.Context, agent *apiv1alpha1.Agent, opts v1.CreateOptions) (*apiv1alpha1.Agent, error)
+	Update(ctx context.Context, agent *apiv1alpha1.Agent, opts v1.UpdateOptions) (*apiv1alpha1.Agent, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, agent *apiv1alpha1.Agent, opts v1.UpdateOptions) (*apiv1alpha1.Agent, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.Agent, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.AgentList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.Agent, err error)
+	Apply(ctx context.Context, agent *applyconfigurationapiv1alpha1.AgentApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.Agent, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, agent *applyconfigurationapiv1alpha1.AgentApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.Agent, err error)
+	AgentExpansion
+}
+
+// agents implements AgentInterface
+type agents struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.Agent, *apiv1alpha1.AgentList, *applyconfigurationapiv1alpha1.AgentApplyConfiguration]
+}
+
+// newAgents returns a Agents
+func newAgents(c *KubetaskV1alpha1Client, namespace string) *agents {
+	return &agents{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.Agent, *apiv1alpha1.AgentList, *applyconfigurationapiv1alpha1.AgentApplyConfiguration](
+			"agents",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.Agent { return &apiv1alpha1.Agent{} },
+			func() *apiv1alpha1.AgentList { return &apiv1alpha1.AgentList{} },
+		),
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// AgentEvalsGetter has a method to return a AgentEvalInterface.
+// A group's client should implement this interface.
+type AgentEvalsGetter interface {
+	AgentEvals(namespace string) AgentEvalInterface
+}
+
+// AgentEvalInterface has methods to work with AgentEval resources.
+type AgentEvalInterface interface {
+	Create(ctx context.Context, agentEval *apiv1alpha1.AgentEval, opts v1.CreateOptions) (*apiv1alpha1.AgentEval, error)
+	Update(ctx context.Context, agentEval *apiv1alpha1.AgentEval, opts v1.UpdateOptions) (*apiv1alpha1.AgentEval, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, agentEval *apiv1alpha1.AgentEval, opts v1.UpdateOptions) (*apiv1alpha1.AgentEval, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.AgentEval, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.AgentEvalList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.AgentEval, err error)
+	Apply(ctx context.Context, agentEval *applyconfigurationapiv1alpha1.AgentEvalApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.AgentEval, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, agentEval *applyconfigurationapiv1alpha1.AgentEvalApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.AgentEval, err error)
+	AgentEvalExpansion
+}
+
+// agentEvals implements AgentEvalInterface
+type agentEvals struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.AgentEval, *apiv1alpha1.AgentEvalList, *applyconfigurationapiv1alpha1.AgentEvalApplyConfiguration]
+}
+
+// newAgentEvals returns a AgentEvals
+func newAgentEvals(c *KubetaskV1alpha1Client, namespace string) *agentEvals {
+	return &agentEvals{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.AgentEval, *apiv1alpha1.AgentEvalList, *applyconfigurationapiv1alpha1.AgentEvalApplyConfiguration](
+			"agentevals",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.AgentEval { return &apiv1alpha1.AgentEval{} },
+			func() *apiv1alpha1.AgentEvalList { return &apiv1alpha1.AgentEvalList{} },
+		),
+	}
+}
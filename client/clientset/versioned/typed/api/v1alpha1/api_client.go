@@ -0,0 +1,117 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	http "net/http"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type KubetaskV1alpha1Interface interface {
+	RESTClient() rest.Interface
+	AgentsGetter
+	AgentEvalsGetter
+	ContextsGetter
+	CronTasksGetter
+	KubeTaskConfigsGetter
+	TasksGetter
+	TaskQuotasGetter
+}
+
+// KubetaskV1alpha1Client is used to interact with features provided by the kubetask.io group.
+type KubetaskV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+func (c *KubetaskV1alpha1Client) Agents(namespace string) AgentInterface {
+	return newAgents(c, namespace)
+}
+
+func (c *KubetaskV1alpha1Client) AgentEvals(namespace string) AgentEvalInterface {
+	return newAgentEvals(c, namespace)
+}
+
+func (c *KubetaskV1alpha1Client) Contexts(namespace string) ContextInterface {
+	return newContexts(c, namespace)
+}
+
+func (c *KubetaskV1alpha1Client) CronTasks(namespace string) CronTaskInterface {
+	return newCronTasks(c, namespace)
+}
+
+func (c *KubetaskV1alpha1Client) KubeTaskConfigs(namespace string) KubeTaskConfigInterface {
+	return newKubeTaskConfigs(c, namespace)
+}
+
+func (c *KubetaskV1alpha1Client) Tasks(namespace string) TaskInterface {
+	return newTasks(c, namespace)
+}
+
+func (c *KubetaskV1alpha1Client) TaskQuotas(namespace string) TaskQuotaInterface {
+	return newTaskQuotas(c, namespace)
+}
+
+// NewForConfig creates a new KubetaskV1alpha1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*KubetaskV1alpha1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new KubetaskV1alpha1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*KubetaskV1alpha1Client, error) {
+	config := *c
+	setConfigDefaults(&config)
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &KubetaskV1alpha1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new KubetaskV1alpha1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *KubetaskV1alpha1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new KubetaskV1alpha1Client for the given RESTClient.
+func New(c rest.Interface) *KubetaskV1alpha1Client {
+	return &KubetaskV1alpha1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) {
+	gv := apiv1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *KubetaskV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
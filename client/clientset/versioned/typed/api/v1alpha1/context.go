@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ContextsGetter has a method to return a ContextInterface.
+// A group's client should implement this interface.
+type ContextsGetter interface {
+	Contexts(namespace string) ContextInterface
+}
+
+// ContextInterface has methods to work with Context resources.
+type ContextInterface interface {
+	Create(ctx context.Context, context *apiv1alpha1.Context, opts v1.CreateOptions) (*apiv1alpha1.Context, error)
+	Update(ctx context.Context, context *apiv1alpha1.Context, opts v1.UpdateOptions) (*apiv1alpha1.Context, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.Context, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.ContextList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.Context, err error)
+	Apply(ctx context.Context, context *applyconfigurationapiv1alpha1.ContextApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.Context, err error)
+	ContextExpansion
+}
+
+// contexts implements ContextInterface
+type contexts struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.Context, *apiv1alpha1.ContextList, *applyconfigurationapiv1alpha1.ContextApplyConfiguration]
+}
+
+// newContexts returns a Contexts
+func newContexts(c *KubetaskV1alpha1Client, namespace string) *contexts {
+	return &contexts{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.Context, *apiv1alpha1.ContextList, *applyconfigurationapiv1alpha1.ContextApplyConfiguration](
+			"contexts",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.Context { return &apiv1alpha1.Context{} },
+			func() *apiv1alpha1.ContextList { return &apiv1alpha1.ContextList{} },
+		),
+	}
+}
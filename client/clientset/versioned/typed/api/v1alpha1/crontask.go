@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// CronTasksGetter has a method to return a CronTaskInterface.
+// A group's client should implement this interface.
+type CronTasksGetter interface {
+	CronTasks(namespace string) CronTaskInterface
+}
+
+// CronTaskInterface has methods to work with CronTask resources.
+type CronTaskInterface interface {
+	Create(ctx context.Context, cronTask *apiv1alpha1.CronTask, opts v1.CreateOptions) (*apiv1alpha1.CronTask, error)
+	Update(ctx context.Context, cronTask *apiv1alpha1.CronTask, opts v1.UpdateOptions) (*apiv1alpha1.CronTask, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, cronTask *apiv1alpha1.CronTask, opts v1.UpdateOptions) (*apiv1alpha1.CronTask, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.CronTask, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.CronTaskList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.CronTask, err error)
+	Apply(ctx context.Context, cronTask *applyconfigurationapiv1alpha1.CronTaskApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.CronTask, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, cronTask *applyconfigurationapiv1alpha1.CronTaskApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.CronTask, err error)
+	CronTaskExpansion
+}
+
+// cronTasks implements CronTaskInterface
+type cronTasks struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.CronTask, *apiv1alpha1.CronTaskList, *applyconfigurationapiv1alpha1.CronTaskApplyConfiguration]
+}
+
+// newCronTasks returns a CronTasks
+func newCronTasks(c *KubetaskV1alpha1Client, namespace string) *cronTasks {
+	return &cronTasks{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.CronTask, *apiv1alpha1.CronTaskList, *applyconfigurationapiv1alpha1.CronTaskApplyConfiguration](
+			"crontasks",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.CronTask { return &apiv1alpha1.CronTask{} },
+			func() *apiv1alpha1.CronTaskList { return &apiv1alpha1.CronTaskList{} },
+		),
+	}
+}
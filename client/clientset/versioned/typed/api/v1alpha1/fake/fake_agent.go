@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAgents implements AgentInterface
+type FakeAgents struct {
+	Fake *FakeKubetaskV1alpha1
+	ns   string
+}
+
+var agentsResource = v1alpha1.SchemeGroupVersion.WithResource("agents")
+
+var agentsKind = v1alpha1.SchemeGroupVersion.WithKind("Agent")
+
+// Get takes name of the agent, and returns the corresponding agent object, and an error if there is any.
+func (c *FakeAgents) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Agent, err error) {
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(agentsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
+
+// List takes label and field selectors, and returns the list of Agents that match those selectors.
+func (c *FakeAgents) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.AgentList, err error) {
+	emptyResult := &v1alpha1.AgentList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(agentsResource, agentsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.AgentList{ListMeta: obj.(*v1alpha1.AgentList).ListMeta}
+	for _, item := range obj.(*v1alpha1.AgentList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested agents.
+func (c *FakeAgents) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(agentsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a agent and creates it.  Returns the server's representation of the agent, and an error, if there is any.
+func (c *FakeAgents) Create(ctx context.Context, agent *v1alpha1.Agent, opts v1.CreateOptions) (result *v1alpha1.Agent, err error) {
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(agentsResource, c.ns, agent, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
+
+// Update takes the representation of a agent and updates it. Returns the server's representation of the agent, and an error, if there is any.
+func (c *FakeAgents) Update(ctx context.Context, agent *v1alpha1.Agent, opts v1.UpdateOptions) (result *v1alpha1.Agent, err error) {
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(agentsResource, c.ns, agent, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAgents) UpdateStatus(ctx context.Context, agent *v1alpha1.Agent, opts v1.UpdateOptions) (result *v1alpha1.Agent, err error) {
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(agentsResource, "status", c.ns, agent, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
+
+// Delete takes name of the agent and deletes it. Returns an error if one occurs.
+func (c *FakeAgents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(agentsResource, c.ns, name, opts), &v1alpha1.Agent{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAgents) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(agentsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.AgentList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched agent.
+func (c *FakeAgents) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Agent, err error) {
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(agentsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied agent.
+func (c *FakeAgents) Apply(ctx context.Context, agent *apiv1alpha1.AgentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Agent, err error) {
+	if agent == nil {
+		return nil, fmt.Errorf("agent provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(agent)
+	if err != nil {
+		return nil, err
+	}
+	name := agent.Name
+	if name == nil {
+		return nil, fmt.Errorf("agent.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(agentsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeAgents) ApplyStatus(ctx context.Context, agent *apiv1alpha1.AgentApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Agent, err error) {
+	if agent == nil {
+		return nil, fmt.Errorf("agent provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(agent)
+	if err != nil {
+		return nil, err
+	}
+	name := agent.Name
+	if name == nil {
+		return nil, fmt.Errorf("agent.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.Agent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(agentsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Agent), err
+}
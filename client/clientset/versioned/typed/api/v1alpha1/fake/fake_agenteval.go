@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeAgentEvals implements AgentEvalInterface
+type FakeAgentEvals struct {
+	Fake *FakeKubetaskV1alpha1
+	ns   string
+}
+
+var agentevalsResource = v1alpha1.SchemeGroupVersion.WithResource("agentevals")
+
+var agentevalsKind = v1alpha1.SchemeGroupVersion.WithKind("AgentEval")
+
+// Get takes name of the agentEval, and returns the corresponding agentEval object, and an error if there is any.
+func (c *FakeAgentEvals) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.AgentEval, err error) {
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(agentevalsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
+
+// List takes label and field selectors, and returns the list of AgentEvals that match those selectors.
+func (c *FakeAgentEvals) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.AgentEvalList, err error) {
+	emptyResult := &v1alpha1.AgentEvalList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(agentevalsResource, agentevalsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.AgentEvalList{ListMeta: obj.(*v1alpha1.AgentEvalList).ListMeta}
+	for _, item := range obj.(*v1alpha1.AgentEvalList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested agentevals.
+func (c *FakeAgentEvals) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(agentevalsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a agentEval and creates it.  Returns the server's representation of the agentEval, and an error, if there is any.
+func (c *FakeAgentEvals) Create(ctx context.Context, agentEval *v1alpha1.AgentEval, opts v1.CreateOptions) (result *v1alpha1.AgentEval, err error) {
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(agentevalsResource, c.ns, agentEval, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
+
+// Update takes the representation of a agentEval and updates it. Returns the server's representation of the agentEval, and an error, if there is any.
+func (c *FakeAgentEvals) Update(ctx context.Context, agentEval *v1alpha1.AgentEval, opts v1.UpdateOptions) (result *v1alpha1.AgentEval, err error) {
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(agentevalsResource, c.ns, agentEval, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeAgentEvals) UpdateStatus(ctx context.Context, agentEval *v1alpha1.AgentEval, opts v1.UpdateOptions) (result *v1alpha1.AgentEval, err error) {
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(agentevalsResource, "status", c.ns, agentEval, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
+
+// Delete takes name of the agentEval and deletes it. Returns an error if one occurs.
+func (c *FakeAgentEvals) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(agentevalsResource, c.ns, name, opts), &v1alpha1.AgentEval{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeAgentEvals) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(agentevalsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.AgentEvalList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched agentEval.
+func (c *FakeAgentEvals) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.AgentEval, err error) {
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(agentevalsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied agentEval.
+func (c *FakeAgentEvals) Apply(ctx context.Context, agentEval *apiv1alpha1.AgentEvalApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.AgentEval, err error) {
+	if agentEval == nil {
+		return nil, fmt.Errorf("agentEval provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(agentEval)
+	if err != nil {
+		return nil, err
+	}
+	name := agentEval.Name
+	if name == nil {
+		return nil, fmt.Errorf("agentEval.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(agentevalsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeAgentEvals) ApplyStatus(ctx context.Context, agentEval *apiv1alpha1.AgentEvalApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.AgentEval, err error) {
+	if agentEval == nil {
+		return nil, fmt.Errorf("agentEval provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(agentEval)
+	if err != nil {
+		return nil, err
+	}
+	name := agentEval.Name
+	if name == nil {
+		return nil, fmt.Errorf("agentEval.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.AgentEval{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(agentevalsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.AgentEval), err
+}
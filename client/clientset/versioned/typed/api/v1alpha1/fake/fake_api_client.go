@@ -0,0 +1,50 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/kubetask/kubetask/client/clientset/versioned/typed/api/v1alpha1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeKubetaskV1alpha1 struct {
+	*testing.Fake
+}
+
+func (c *FakeKubetaskV1alpha1) Agents(namespace string) v1alpha1.AgentInterface {
+	return &FakeAgents{c, namespace}
+}
+
+func (c *FakeKubetaskV1alpha1) AgentEvals(namespace string) v1alpha1.AgentEvalInterface {
+	return &FakeAgentEvals{c, namespace}
+}
+
+func (c *FakeKubetaskV1alpha1) Contexts(namespace string) v1alpha1.ContextInterface {
+	return &FakeContexts{c, namespace}
+}
+
+func (c *FakeKubetaskV1alpha1) CronTasks(namespace string) v1alpha1.CronTaskInterface {
+	return &FakeCronTasks{c, namespace}
+}
+
+func (c *FakeKubetaskV1alpha1) KubeTaskConfigs(namespace string) v1alpha1.KubeTaskConfigInterface {
+	return &FakeKubeTaskConfigs{c, namespace}
+}
+
+func (c *FakeKubetaskV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
+	return &FakeTasks{c, namespace}
+}
+
+func (c *FakeKubetaskV1alpha1) TaskQuotas(namespace string) v1alpha1.TaskQuotaInterface {
+	return &FakeTaskQuotas{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeKubetaskV1alpha1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
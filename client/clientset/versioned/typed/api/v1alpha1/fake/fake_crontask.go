@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCronTasks implements CronTaskInterface
+type FakeCronTasks struct {
+	Fake *FakeKubetaskV1alpha1
+	ns   string
+}
+
+var crontasksResource = v1alpha1.SchemeGroupVersion.WithResource("crontasks")
+
+var crontasksKind = v1alpha1.SchemeGroupVersion.WithKind("CronTask")
+
+// Get takes name of the cronTask, and returns the corresponding cronTask object, and an error if there is any.
+func (c *FakeCronTasks) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.CronTask, err error) {
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(crontasksResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
+
+// List takes label and field selectors, and returns the list of CronTasks that match those selectors.
+func (c *FakeCronTasks) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.CronTaskList, err error) {
+	emptyResult := &v1alpha1.CronTaskList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(crontasksResource, crontasksKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.CronTaskList{ListMeta: obj.(*v1alpha1.CronTaskList).ListMeta}
+	for _, item := range obj.(*v1alpha1.CronTaskList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested crontasks.
+func (c *FakeCronTasks) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(crontasksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cronTask and creates it.  Returns the server's representation of the cronTask, and an error, if there is any.
+func (c *FakeCronTasks) Create(ctx context.Context, cronTask *v1alpha1.CronTask, opts v1.CreateOptions) (result *v1alpha1.CronTask, err error) {
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(crontasksResource, c.ns, cronTask, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
+
+// Update takes the representation of a cronTask and updates it. Returns the server's representation of the cronTask, and an error, if there is any.
+func (c *FakeCronTasks) Update(ctx context.Context, cronTask *v1alpha1.CronTask, opts v1.UpdateOptions) (result *v1alpha1.CronTask, err error) {
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(crontasksResource, c.ns, cronTask, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeCronTasks) UpdateStatus(ctx context.Context, cronTask *v1alpha1.CronTask, opts v1.UpdateOptions) (result *v1alpha1.CronTask, err error) {
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(crontasksResource, "status", c.ns, cronTask, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
+
+// Delete takes name of the cronTask and deletes it. Returns an error if one occurs.
+func (c *FakeCronTasks) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(crontasksResource, c.ns, name, opts), &v1alpha1.CronTask{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCronTasks) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(crontasksResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.CronTaskList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cronTask.
+func (c *FakeCronTasks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.CronTask, err error) {
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(crontasksResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied cronTask.
+func (c *FakeCronTasks) Apply(ctx context.Context, cronTask *apiv1alpha1.CronTaskApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.CronTask, err error) {
+	if cronTask == nil {
+		return nil, fmt.Errorf("cronTask provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(cronTask)
+	if err != nil {
+		return nil, err
+	}
+	name := cronTask.Name
+	if name == nil {
+		return nil, fmt.Errorf("cronTask.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(crontasksResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeCronTasks) ApplyStatus(ctx context.Context, cronTask *apiv1alpha1.CronTaskApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.CronTask, err error) {
+	if cronTask == nil {
+		return nil, fmt.Errorf("cronTask provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(cronTask)
+	if err != nil {
+		return nil, err
+	}
+	name := cronTask.Name
+	if name == nil {
+		return nil, fmt.Errorf("cronTask.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.CronTask{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(crontasksResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.CronTask), err
+}
@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKubeTaskConfigs implements KubeTaskConfigInterface
+type FakeKubeTaskConfigs struct {
+	Fake *FakeKubetaskV1alpha1
+	ns   string
+}
+
+var kubetaskconfigsResource = v1alpha1.SchemeGroupVersion.WithResource("kubetaskconfigs")
+
+var kubetaskconfigsKind = v1alpha1.SchemeGroupVersion.WithKind("KubeTaskConfig")
+
+// Get takes name of the kubeTaskConfig, and returns the corresponding kubeTaskConfig object, and an error if there is any.
+func (c *FakeKubeTaskConfigs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KubeTaskConfig, err error) {
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(kubetaskconfigsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
+
+// List takes label and field selectors, and returns the list of KubeTaskConfigs that match those selectors.
+func (c *FakeKubeTaskConfigs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KubeTaskConfigList, err error) {
+	emptyResult := &v1alpha1.KubeTaskConfigList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(kubetaskconfigsResource, kubetaskconfigsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KubeTaskConfigList{ListMeta: obj.(*v1alpha1.KubeTaskConfigList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KubeTaskConfigList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested kubetaskconfigs.
+func (c *FakeKubeTaskConfigs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(kubetaskconfigsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a kubeTaskConfig and creates it.  Returns the server's representation of the kubeTaskConfig, and an error, if there is any.
+func (c *FakeKubeTaskConfigs) Create(ctx context.Context, kubeTaskConfig *v1alpha1.KubeTaskConfig, opts v1.CreateOptions) (result *v1alpha1.KubeTaskConfig, err error) {
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(kubetaskconfigsResource, c.ns, kubeTaskConfig, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
+
+// Update takes the representation of a kubeTaskConfig and updates it. Returns the server's representation of the kubeTaskConfig, and an error, if there is any.
+func (c *FakeKubeTaskConfigs) Update(ctx context.Context, kubeTaskConfig *v1alpha1.KubeTaskConfig, opts v1.UpdateOptions) (result *v1alpha1.KubeTaskConfig, err error) {
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(kubetaskconfigsResource, c.ns, kubeTaskConfig, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKubeTaskConfigs) UpdateStatus(ctx context.Context, kubeTaskConfig *v1alpha1.KubeTaskConfig, opts v1.UpdateOptions) (result *v1alpha1.KubeTaskConfig, err error) {
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(kubetaskconfigsResource, "status", c.ns, kubeTaskConfig, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
+
+// Delete takes name of the kubeTaskConfig and deletes it. Returns an error if one occurs.
+func (c *FakeKubeTaskConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(kubetaskconfigsResource, c.ns, name, opts), &v1alpha1.KubeTaskConfig{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKubeTaskConfigs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(kubetaskconfigsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KubeTaskConfigList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched kubeTaskConfig.
+func (c *FakeKubeTaskConfigs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KubeTaskConfig, err error) {
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(kubetaskconfigsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied kubeTaskConfig.
+func (c *FakeKubeTaskConfigs) Apply(ctx context.Context, kubeTaskConfig *apiv1alpha1.KubeTaskConfigApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.KubeTaskConfig, err error) {
+	if kubeTaskConfig == nil {
+		return nil, fmt.Errorf("kubeTaskConfig provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(kubeTaskConfig)
+	if err != nil {
+		return nil, err
+	}
+	name := kubeTaskConfig.Name
+	if name == nil {
+		return nil, fmt.Errorf("kubeTaskConfig.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(kubetaskconfigsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeKubeTaskConfigs) ApplyStatus(ctx context.Context, kubeTaskConfig *apiv1alpha1.KubeTaskConfigApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.KubeTaskConfig, err error) {
+	if kubeTaskConfig == nil {
+		return nil, fmt.Errorf("kubeTaskConfig provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(kubeTaskConfig)
+	if err != nil {
+		return nil, err
+	}
+	name := kubeTaskConfig.Name
+	if name == nil {
+		return nil, fmt.Errorf("kubeTaskConfig.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.KubeTaskConfig{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(kubetaskconfigsResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.KubeTaskConfig), err
+}
@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTasks implements TaskInterface
+type FakeTasks struct {
+	Fake *FakeKubetaskV1alpha1
+	ns   string
+}
+
+var tasksResource = v1alpha1.SchemeGroupVersion.WithResource("tasks")
+
+var tasksKind = v1alpha1.SchemeGroupVersion.WithKind("Task")
+
+// Get takes name of the task, and returns the corresponding task object, and an error if there is any.
+func (c *FakeTasks) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Task, err error) {
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(tasksResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
+
+// List takes label and field selectors, and returns the list of Tasks that match those selectors.
+func (c *FakeTasks) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TaskList, err error) {
+	emptyResult := &v1alpha1.TaskList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(tasksResource, tasksKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.TaskList{ListMeta: obj.(*v1alpha1.TaskList).ListMeta}
+	for _, item := range obj.(*v1alpha1.TaskList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested tasks.
+func (c *FakeTasks) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(tasksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a task and creates it.  Returns the server's representation of the task, and an error, if there is any.
+func (c *FakeTasks) Create(ctx context.Context, task *v1alpha1.Task, opts v1.CreateOptions) (result *v1alpha1.Task, err error) {
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(tasksResource, c.ns, task, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
+
+// Update takes the representation of a task and updates it. Returns the server's representation of the task, and an error, if there is any.
+func (c *FakeTasks) Update(ctx context.Context, task *v1alpha1.Task, opts v1.UpdateOptions) (result *v1alpha1.Task, err error) {
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(tasksResource, c.ns, task, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeTasks) UpdateStatus(ctx context.Context, task *v1alpha1.Task, opts v1.UpdateOptions) (result *v1alpha1.Task, err error) {
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(tasksResource, "status", c.ns, task, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
+
+// Delete takes name of the task and deletes it. Returns an error if one occurs.
+func (c *FakeTasks) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(tasksResource, c.ns, name, opts), &v1alpha1.Task{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTasks) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(tasksResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.TaskList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched task.
+func (c *FakeTasks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Task, err error) {
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(tasksResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied task.
+func (c *FakeTasks) Apply(ctx context.Context, task *apiv1alpha1.TaskApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Task, err error) {
+	if task == nil {
+		return nil, fmt.Errorf("task provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	name := task.Name
+	if name == nil {
+		return nil, fmt.Errorf("task.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(tasksResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeTasks) ApplyStatus(ctx context.Context, task *apiv1alpha1.TaskApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.Task, err error) {
+	if task == nil {
+		return nil, fmt.Errorf("task provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	name := task.Name
+	if name == nil {
+		return nil, fmt.Errorf("task.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.Task{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(tasksResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.Task), err
+}
@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTaskQuotas implements TaskQuotaInterface
+type FakeTaskQuotas struct {
+	Fake *FakeKubetaskV1alpha1
+	ns   string
+}
+
+var taskquotasResource = v1alpha1.SchemeGroupVersion.WithResource("taskquotas")
+
+var taskquotasKind = v1alpha1.SchemeGroupVersion.WithKind("TaskQuota")
+
+// Get takes name of the taskQuota, and returns the corresponding taskQuota object, and an error if there is any.
+func (c *FakeTaskQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TaskQuota, err error) {
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(taskquotasResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
+
+// List takes label and field selectors, and returns the list of TaskQuotas that match those selectors.
+func (c *FakeTaskQuotas) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TaskQuotaList, err error) {
+	emptyResult := &v1alpha1.TaskQuotaList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(taskquotasResource, taskquotasKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.TaskQuotaList{ListMeta: obj.(*v1alpha1.TaskQuotaList).ListMeta}
+	for _, item := range obj.(*v1alpha1.TaskQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested taskquotas.
+func (c *FakeTaskQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(taskquotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a taskQuota and creates it.  Returns the server's representation of the taskQuota, and an error, if there is any.
+func (c *FakeTaskQuotas) Create(ctx context.Context, taskQuota *v1alpha1.TaskQuota, opts v1.CreateOptions) (result *v1alpha1.TaskQuota, err error) {
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(taskquotasResource, c.ns, taskQuota, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
+
+// Update takes the representation of a taskQuota and updates it. Returns the server's representation of the taskQuota, and an error, if there is any.
+func (c *FakeTaskQuotas) Update(ctx context.Context, taskQuota *v1alpha1.TaskQuota, opts v1.UpdateOptions) (result *v1alpha1.TaskQuota, err error) {
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(taskquotasResource, c.ns, taskQuota, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeTaskQuotas) UpdateStatus(ctx context.Context, taskQuota *v1alpha1.TaskQuota, opts v1.UpdateOptions) (result *v1alpha1.TaskQuota, err error) {
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(taskquotasResource, "status", c.ns, taskQuota, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
+
+// Delete takes name of the taskQuota and deletes it. Returns an error if one occurs.
+func (c *FakeTaskQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(taskquotasResource, c.ns, name, opts), &v1alpha1.TaskQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTaskQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(taskquotasResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.TaskQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched taskQuota.
+func (c *FakeTaskQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TaskQuota, err error) {
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(taskquotasResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied taskQuota.
+func (c *FakeTaskQuotas) Apply(ctx context.Context, taskQuota *apiv1alpha1.TaskQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.TaskQuota, err error) {
+	if taskQuota == nil {
+		return nil, fmt.Errorf("taskQuota provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(taskQuota)
+	if err != nil {
+		return nil, err
+	}
+	name := taskQuota.Name
+	if name == nil {
+		return nil, fmt.Errorf("taskQuota.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(taskquotasResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions()), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeTaskQuotas) ApplyStatus(ctx context.Context, taskQuota *apiv1alpha1.TaskQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.TaskQuota, err error) {
+	if taskQuota == nil {
+		return nil, fmt.Errorf("taskQuota provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(taskQuota)
+	if err != nil {
+		return nil, err
+	}
+	name := taskQuota.Name
+	if name == nil {
+		return nil, fmt.Errorf("taskQuota.Name must be provided to Apply")
+	}
+	emptyResult := &v1alpha1.TaskQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(taskquotasResource, c.ns, *name, types.ApplyPatchType, data, opts.ToPatchOptions(), "status"), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1alpha1.TaskQuota), err
+}
@@ -0,0 +1,19 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+type AgentExpansion interface{}
+
+type AgentEvalExpansion interface{}
+
+type ContextExpansion interface{}
+
+type CronTaskExpansion interface{}
+
+type KubeTaskConfigExpansion interface{}
+
+type TaskExpansion interface{}
+
+type TaskQuotaExpansion interface{}
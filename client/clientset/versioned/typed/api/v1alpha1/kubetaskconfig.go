@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// KubeTaskConfigsGetter has a method to return a KubeTaskConfigInterface.
+// A group's client should implement this interface.
+type KubeTaskConfigsGetter interface {
+	KubeTaskConfigs(namespace string) KubeTaskConfigInterface
+}
+
+// KubeTaskConfigInterface has methods to work with KubeTaskConfig resources.
+type KubeTaskConfigInterface interface {
+	Create(ctx context.Context, kubeTaskConfig *apiv1alpha1.KubeTaskConfig, opts v1.CreateOptions) (*apiv1alpha1.KubeTaskConfig, error)
+	Update(ctx context.Context, kubeTaskConfig *apiv1alpha1.KubeTaskConfig, opts v1.UpdateOptions) (*apiv1alpha1.KubeTaskConfig, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, kubeTaskConfig *apiv1alpha1.KubeTaskConfig, opts v1.UpdateOptions) (*apiv1alpha1.KubeTaskConfig, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.KubeTaskConfig, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.KubeTaskConfigList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.KubeTaskConfig, err error)
+	Apply(ctx context.Context, kubeTaskConfig *applyconfigurationapiv1alpha1.KubeTaskConfigApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.KubeTaskConfig, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, kubeTaskConfig *applyconfigurationapiv1alpha1.KubeTaskConfigApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.KubeTaskConfig, err error)
+	KubeTaskConfigExpansion
+}
+
+// kubeTaskConfigs implements KubeTaskConfigInterface
+type kubeTaskConfigs struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.KubeTaskConfig, *apiv1alpha1.KubeTaskConfigList, *applyconfigurationapiv1alpha1.KubeTaskConfigApplyConfiguration]
+}
+
+// newKubeTaskConfigs returns a KubeTaskConfigs
+func newKubeTaskConfigs(c *KubetaskV1alpha1Client, namespace string) *kubeTaskConfigs {
+	return &kubeTaskConfigs{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.KubeTaskConfig, *apiv1alpha1.KubeTaskConfigList, *applyconfigurationapiv1alpha1.KubeTaskConfigApplyConfiguration](
+			"kubetaskconfigs",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.KubeTaskConfig { return &apiv1alpha1.KubeTaskConfig{} },
+			func() *apiv1alpha1.KubeTaskConfigList { return &apiv1alpha1.KubeTaskConfigList{} },
+		),
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// TasksGetter has a method to return a TaskInterface.
+// A group's client should implement this interface.
+type TasksGetter interface {
+	Tasks(namespace string) TaskInterface
+}
+
+// TaskInterface has methods to work with Task resources.
+type TaskInterface interface {
+	Create(ctx context.Context, task *apiv1alpha1.Task, opts v1.CreateOptions) (*apiv1alpha1.Task, error)
+	Update(ctx context.Context, task *apiv1alpha1.Task, opts v1.UpdateOptions) (*apiv1alpha1.Task, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, task *apiv1alpha1.Task, opts v1.UpdateOptions) (*apiv1alpha1.Task, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.Task, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.TaskList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.Task, err error)
+	Apply(ctx context.Context, task *applyconfigurationapiv1alpha1.TaskApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.Task, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, task *applyconfigurationapiv1alpha1.TaskApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.Task, err error)
+	TaskExpansion
+}
+
+// tasks implements TaskInterface
+type tasks struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.Task, *apiv1alpha1.TaskList, *applyconfigurationapiv1alpha1.TaskApplyConfiguration]
+}
+
+// newTasks returns a Tasks
+func newTasks(c *KubetaskV1alpha1Client, namespace string) *tasks {
+	return &tasks{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.Task, *apiv1alpha1.TaskList, *applyconfigurationapiv1alpha1.TaskApplyConfiguration](
+			"tasks",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.Task { return &apiv1alpha1.Task{} },
+			func() *apiv1alpha1.TaskList { return &apiv1alpha1.TaskList{} },
+		),
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/kubetask/kubetask/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/kubetask/kubetask/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// TaskQuotasGetter has a method to return a TaskQuotaInterface.
+// A group's client should implement this interface.
+type TaskQuotasGetter interface {
+	TaskQuotas(namespace string) TaskQuotaInterface
+}
+
+// TaskQuotaInterface has methods to work with TaskQuota resources.
+type TaskQuotaInterface interface {
+	Create(ctx context.Context, taskQuota *apiv1alpha1.TaskQuota, opts v1.CreateOptions) (*apiv1alpha1.TaskQuota, error)
+	Update(ctx context.Context, taskQuota *apiv1alpha1.TaskQuota, opts v1.UpdateOptions) (*apiv1alpha1.TaskQuota, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, taskQuota *apiv1alpha1.TaskQuota, opts v1.UpdateOptions) (*apiv1alpha1.TaskQuota, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.TaskQuota, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.TaskQuotaList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.TaskQuota, err error)
+	Apply(ctx context.Context, taskQuota *applyconfigurationapiv1alpha1.TaskQuotaApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.TaskQuota, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, taskQuota *applyconfigurationapiv1alpha1.TaskQuotaApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.TaskQuota, err error)
+	TaskQuotaExpansion
+}
+
+// taskQuotas implements TaskQuotaInterface
+type taskQuotas struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.TaskQuota, *apiv1alpha1.TaskQuotaList, *applyconfigurationapiv1alpha1.TaskQuotaApplyConfiguration]
+}
+
+// newTaskQuotas returns a TaskQuotas
+func newTaskQuotas(c *KubetaskV1alpha1Client, namespace string) *taskQuotas {
+	return &taskQuotas{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.TaskQuota, *apiv1alpha1.TaskQuotaList, *applyconfigurationapiv1alpha1.TaskQuotaApplyConfiguration](
+			"taskquotas",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.TaskQuota { return &apiv1alpha1.TaskQuota{} },
+			func() *apiv1alpha1.TaskQuotaList { return &apiv1alpha1.TaskQuotaList{} },
+		),
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	kubetaskapiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	versioned "github.com/kubetask/kubetask/client/clientset/versioned"
+	internalinterfaces "github.com/kubetask/kubetask/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AgentEvalInformer provides access to a shared informer and lister for
+// AgentEvals.
+type AgentEvalInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.AgentEvalLister
+}
+
+type agentEvalInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAgentEvalInformer constructs a new informer for AgentEval type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAgentEvalInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAgentEvalInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAgentEvalInformer constructs a new informer for AgentEval type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAgentEvalInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubetaskV1alpha1().AgentEvals(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubetaskV1alpha1().AgentEvals(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&kubetaskapiv1alpha1.AgentEval{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *agentEvalInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAgentEvalInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *agentEvalInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&kubetaskapiv1alpha1.AgentEval{}, f.defaultInformer)
+}
+
+func (f *agentEvalInformer) Lister() apiv1alpha1.AgentEvalLister {
+	return apiv1alpha1.NewAgentEvalLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,76 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	kubetaskapiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	versioned "github.com/kubetask/kubetask/client/clientset/versioned"
+	internalinterfaces "github.com/kubetask/kubetask/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/kubetask/kubetask/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// KubeTaskConfigInformer provides access to a shared informer and lister for
+// KubeTaskConfigs.
+type KubeTaskConfigInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.KubeTaskConfigLister
+}
+
+type kubeTaskConfigInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewKubeTaskConfigInformer constructs a new informer for KubeTaskConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewKubeTaskConfigInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredKubeTaskConfigInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredKubeTaskConfigInformer constructs a new informer for KubeTaskConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredKubeTaskConfigInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubetaskV1alpha1().KubeTaskConfigs(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.KubetaskV1alpha1().KubeTaskConfigs(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&kubetaskapiv1alpha1.KubeTaskConfig{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *kubeTaskConfigInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredKubeTaskConfigInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *kubeTaskConfigInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&kubetaskapiv1alpha1.KubeTaskConfig{}, f.defaultInformer)
+}
+
+func (f *kubeTaskConfigInformer) Lister() apiv1alpha1.KubeTaskConfigLister {
+	return apiv1alpha1.NewKubeTaskConfigLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AgentLister helps list Agents.
+// All objects returned here must be treated as read-only.
+type AgentLister interface {
+	// List lists all Agents in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.Agent, err error)
+	// Agents returns an object that can list and get Agents.
+	Agents(namespace string) AgentNamespaceLister
+	AgentListerExpansion
+}
+
+// agentLister implements the AgentLister interface.
+type agentLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.Agent]
+}
+
+// NewAgentLister returns a new AgentLister.
+func NewAgentLister(indexer cache.Indexer) AgentLister {
+	return &agentLister{listers.New[*apiv1alpha1.Agent](indexer, apiv1alpha1.Resource("agent"))}
+}
+
+// Agents returns an object that can list and get Agents.
+func (s *agentLister) Agents(namespace string) AgentNamespaceLister {
+	return agentNamespaceLister{listers.NewNamespaced[*apiv1alpha1.Agent](s.ResourceIndexer, namespace)}
+}
+
+// AgentNamespaceLister helps list and get Agents.
+// All objects returned here must be treated as read-only.
+type AgentNamespaceLister interface {
+	// List lists all Agents in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.Agent, err error)
+	// Get retrieves the Agent from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.Agent, error)
+	AgentNamespaceListerExpansion
+}
+
+// agentNamespaceLister implements the AgentNamespaceLister
+// interface.
+type agentNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.Agent]
+}
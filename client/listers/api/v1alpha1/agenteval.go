@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AgentEvalLister helps list AgentEvals.
+// All objects returned here must be treated as read-only.
+type AgentEvalLister interface {
+	// List lists all AgentEvals in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.AgentEval, err error)
+	// AgentEvals returns an object that can list and get AgentEvals.
+	AgentEvals(namespace string) AgentEvalNamespaceLister
+	AgentEvalListerExpansion
+}
+
+// agentEvalLister implements the AgentEvalLister interface.
+type agentEvalLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.AgentEval]
+}
+
+// NewAgentEvalLister returns a new AgentEvalLister.
+func NewAgentEvalLister(indexer cache.Indexer) AgentEvalLister {
+	return &agentEvalLister{listers.New[*apiv1alpha1.AgentEval](indexer, apiv1alpha1.Resource("agenteval"))}
+}
+
+// AgentEvals returns an object that can list and get AgentEvals.
+func (s *agentEvalLister) AgentEvals(namespace string) AgentEvalNamespaceLister {
+	return agentEvalNamespaceLister{listers.NewNamespaced[*apiv1alpha1.AgentEval](s.ResourceIndexer, namespace)}
+}
+
+// AgentEvalNamespaceLister helps list and get AgentEvals.
+// All objects returned here must be treated as read-only.
+type AgentEvalNamespaceLister interface {
+	// List lists all AgentEvals in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.AgentEval, err error)
+	// Get retrieves the AgentEval from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.AgentEval, error)
+	AgentEvalNamespaceListerExpansion
+}
+
+// agentEvalNamespaceLister implements the AgentEvalNamespaceLister
+// interface.
+type agentEvalNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.AgentEval]
+}
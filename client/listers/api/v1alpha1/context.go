@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ContextLister helps list Contexts.
+// All objects returned here must be treated as read-only.
+type ContextLister interface {
+	// List lists all Contexts in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.Context, err error)
+	// Contexts returns an object that can list and get Contexts.
+	Contexts(namespace string) ContextNamespaceLister
+	ContextListerExpansion
+}
+
+// contextLister implements the ContextLister interface.
+type contextLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.Context]
+}
+
+// NewContextLister returns a new ContextLister.
+func NewContextLister(indexer cache.Indexer) ContextLister {
+	return &contextLister{listers.New[*apiv1alpha1.Context](indexer, apiv1alpha1.Resource("context"))}
+}
+
+// Contexts returns an object that can list and get Contexts.
+func (s *contextLister) Contexts(namespace string) ContextNamespaceLister {
+	return contextNamespaceLister{listers.NewNamespaced[*apiv1alpha1.Context](s.ResourceIndexer, namespace)}
+}
+
+// ContextNamespaceLister helps list and get Contexts.
+// All objects returned here must be treated as read-only.
+type ContextNamespaceLister interface {
+	// List lists all Contexts in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.Context, err error)
+	// Get retrieves the Context from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.Context, error)
+	ContextNamespaceListerExpansion
+}
+
+// contextNamespaceLister implements the ContextNamespaceLister
+// interface.
+type contextNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.Context]
+}
@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// CronTaskLister helps list CronTasks.
+// All objects returned here must be treated as read-only.
+type CronTaskLister interface {
+	// List lists all CronTasks in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.CronTask, err error)
+	// CronTasks returns an object that can list and get CronTasks.
+	CronTasks(namespace string) CronTaskNamespaceLister
+	CronTaskListerExpansion
+}
+
+// cronTaskLister implements the CronTaskLister interface.
+type cronTaskLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.CronTask]
+}
+
+// NewCronTaskLister returns a new CronTaskLister.
+func NewCronTaskLister(indexer cache.Indexer) CronTaskLister {
+	return &cronTaskLister{listers.New[*apiv1alpha1.CronTask](indexer, apiv1alpha1.Resource("crontask"))}
+}
+
+// CronTasks returns an object that can list and get CronTasks.
+func (s *cronTaskLister) CronTasks(namespace string) CronTaskNamespaceLister {
+	return cronTaskNamespaceLister{listers.NewNamespaced[*apiv1alpha1.CronTask](s.ResourceIndexer, namespace)}
+}
+
+// CronTaskNamespaceLister helps list and get CronTasks.
+// All objects returned here must be treated as read-only.
+type CronTaskNamespaceLister interface {
+	// List lists all CronTasks in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.CronTask, err error)
+	// Get retrieves the CronTask from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.CronTask, error)
+	CronTaskNamespaceListerExpansion
+}
+
+// cronTaskNamespaceLister implements the CronTaskNamespaceLister
+// interface.
+type cronTaskNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.CronTask]
+}
@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// KubeTaskConfigLister helps list KubeTaskConfigs.
+// All objects returned here must be treated as read-only.
+type KubeTaskConfigLister interface {
+	// List lists all KubeTaskConfigs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.KubeTaskConfig, err error)
+	// KubeTaskConfigs returns an object that can list and get KubeTaskConfigs.
+	KubeTaskConfigs(namespace string) KubeTaskConfigNamespaceLister
+	KubeTaskConfigListerExpansion
+}
+
+// kubeTaskConfigLister implements the KubeTaskConfigLister interface.
+type kubeTaskConfigLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.KubeTaskConfig]
+}
+
+// NewKubeTaskConfigLister returns a new KubeTaskConfigLister.
+func NewKubeTaskConfigLister(indexer cache.Indexer) KubeTaskConfigLister {
+	return &kubeTaskConfigLister{listers.New[*apiv1alpha1.KubeTaskConfig](indexer, apiv1alpha1.Resource("kubetaskconfig"))}
+}
+
+// KubeTaskConfigs returns an object that can list and get KubeTaskConfigs.
+func (s *kubeTaskConfigLister) KubeTaskConfigs(namespace string) KubeTaskConfigNamespaceLister {
+	return kubeTaskConfigNamespaceLister{listers.NewNamespaced[*apiv1alpha1.KubeTaskConfig](s.ResourceIndexer, namespace)}
+}
+
+// KubeTaskConfigNamespaceLister helps list and get KubeTaskConfigs.
+// All objects returned here must be treated as read-only.
+type KubeTaskConfigNamespaceLister interface {
+	// List lists all KubeTaskConfigs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.KubeTaskConfig, err error)
+	// Get retrieves the KubeTaskConfig from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.KubeTaskConfig, error)
+	KubeTaskConfigNamespaceListerExpansion
+}
+
+// kubeTaskConfigNamespaceLister implements the KubeTaskConfigNamespaceLister
+// interface.
+type kubeTaskConfigNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.KubeTaskConfig]
+}
@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// TaskLister helps list Tasks.
+// All objects returned here must be treated as read-only.
+type TaskLister interface {
+	// List lists all Tasks in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.Task, err error)
+	// Tasks returns an object that can list and get Tasks.
+	Tasks(namespace string) TaskNamespaceLister
+	TaskListerExpansion
+}
+
+// taskLister implements the TaskLister interface.
+type taskLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.Task]
+}
+
+// NewTaskLister returns a new TaskLister.
+func NewTaskLister(indexer cache.Indexer) TaskLister {
+	return &taskLister{listers.New[*apiv1alpha1.Task](indexer, apiv1alpha1.Resource("task"))}
+}
+
+// Tasks returns an object that can list and get Tasks.
+func (s *taskLister) Tasks(namespace string) TaskNamespaceLister {
+	return taskNamespaceLister{listers.NewNamespaced[*apiv1alpha1.Task](s.ResourceIndexer, namespace)}
+}
+
+// TaskNamespaceLister helps list and get Tasks.
+// All objects returned here must be treated as read-only.
+type TaskNamespaceLister interface {
+	// List lists all Tasks in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.Task, err error)
+	// Get retrieves the Task from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.Task, error)
+	TaskNamespaceListerExpansion
+}
+
+// taskNamespaceLister implements the TaskNamespaceLister
+// interface.
+type taskNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.Task]
+}
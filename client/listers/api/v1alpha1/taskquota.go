@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// TaskQuotaLister helps list TaskQuotas.
+// All objects returned here must be treated as read-only.
+type TaskQuotaLister interface {
+	// List lists all TaskQuotas in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.TaskQuota, err error)
+	// TaskQuotas returns an object that can list and get TaskQuotas.
+	TaskQuotas(namespace string) TaskQuotaNamespaceLister
+	TaskQuotaListerExpansion
+}
+
+// taskQuotaLister implements the TaskQuotaLister interface.
+type taskQuotaLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.TaskQuota]
+}
+
+// NewTaskQuotaLister returns a new TaskQuotaLister.
+func NewTaskQuotaLister(indexer cache.Indexer) TaskQuotaLister {
+	return &taskQuotaLister{listers.New[*apiv1alpha1.TaskQuota](indexer, apiv1alpha1.Resource("taskquota"))}
+}
+
+// TaskQuotas returns an object that can list and get TaskQuotas.
+func (s *taskQuotaLister) TaskQuotas(namespace string) TaskQuotaNamespaceLister {
+	return taskQuotaNamespaceLister{listers.NewNamespaced[*apiv1alpha1.TaskQuota](s.ResourceIndexer, namespace)}
+}
+
+// TaskQuotaNamespaceLister helps list and get TaskQuotas.
+// All objects returned here must be treated as read-only.
+type TaskQuotaNamespaceLister interface {
+	// List lists all TaskQuotas in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.TaskQuota, err error)
+	// Get retrieves the TaskQuota from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.TaskQuota, error)
+	TaskQuotaNamespaceListerExpansion
+}
+
+// taskQuotaNamespaceLister implements the TaskQuotaNamespaceLister
+// interface.
+type taskQuotaNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.TaskQuota]
+}
@@ -2,15 +2,26 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -18,6 +29,13 @@ import (
 
 	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
 	"github.com/kubetask/kubetask/internal/controller"
+	"github.com/kubetask/kubetask/internal/images"
+	"github.com/kubetask/kubetask/internal/issuetracker"
+	"github.com/kubetask/kubetask/internal/resultapi"
+	"github.com/kubetask/kubetask/internal/results"
+	"github.com/kubetask/kubetask/internal/slack"
+	kubetaskwebhook "github.com/kubetask/kubetask/internal/webhook"
+	"github.com/kubetask/kubetask/pkg/build"
 )
 
 var (
@@ -34,18 +52,144 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var pprofAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks bool
+	var deletionProtection string
+	var contextMountAllowedRoots string
+	var leaderElectionNamespace string
+	var leaseDuration time.Duration
+	var renewDeadline time.Duration
+	var retryPeriod time.Duration
+	var gracefulShutdownTimeout time.Duration
+	var defaultAgentImage string
+	var gitSyncImage string
+	var objectStoreImage string
+	var orasImage string
+	var imageRegistry string
+	var verifyImages bool
+	var imagePullConfig string
+	var taskPreamble string
+	var logReconcileDiffs bool
+	var runningTaskResyncInterval time.Duration
+	var contextScan bool
+	var contextScanWebhookURL string
+	var contextConcurrency int
+	var jobCreationQPS float64
+	var jobCreationBurst int
+	var urlContextAllowedHosts string
+	var resultAPIAddr string
+	var resultAPIURL string
+	var resultsDir string
+	var resultsAddr string
+	var resultsRetention time.Duration
+	var slackAddr string
+	var slackNamespace string
+	var jiraURL string
+	var jiraSuccessTransition string
+	var jiraFailureTransition string
+	var githubIssues bool
+	var githubAPIURL string
+	var githubCloseIssues bool
+	var mirrorExternalRuns string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "",
+		"The address the pprof debug endpoint binds to (e.g. \":8082\"). Disabled if empty.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"Namespace in which the leader election Lease is created. Defaults to the controller's namespace.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"Duration that non-leader candidates will wait before attempting to acquire leadership.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"Duration that the acting leader will retry refreshing leadership before giving up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"Duration the leader election clients should wait between tries of actions.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"Maximum time to wait for in-flight reconciles to finish before the manager exits.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", false,
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, admission webhooks are served. Requires TLS certificates in the webhook server's cert directory.")
+	flag.StringVar(&deletionProtection, "deletion-protection", string(kubetaskwebhook.DeletionProtectionDeny),
+		"How the webhook handles deleting an Agent or Context used by unfinished Tasks: Deny or Warn.")
+	flag.StringVar(&contextMountAllowedRoots, "context-mount-allowed-roots", "",
+		"Comma-separated directories, besides the Agent's workspace directory, that the webhook lets Tasks mount Contexts into.")
+	flag.StringVar(&defaultAgentImage, "default-agent-image", controller.DefaultAgentImage,
+		"Agent image used when neither the Agent nor the namespace's KubeTaskConfig sets one.")
+	flag.StringVar(&gitSyncImage, "git-sync-image", build.DefaultGitSyncImage,
+		"Image of the init containers that clone Git contexts.")
+	flag.StringVar(&objectStoreImage, "object-store-image", build.DefaultObjectStoreImage,
+		"rclone image of the init containers that download ObjectStore contexts.")
+	flag.StringVar(&orasImage, "oras-image", build.DefaultORASImage,
+		"oras image of the init containers that pull OCI contexts.")
+	flag.StringVar(&imageRegistry, "image-registry", "",
+		"Registry mirror, such as mirror.example.com/upstream, replacing the registry of the default agent, "+
+			"git-sync, object store and oras images for air-gapped clusters. Disabled if empty.")
+	flag.BoolVar(&verifyImages, "verify-images", false,
+		"If set, the controller checks at startup that the default agent, git-sync, object store and oras images "+
+			"can be pulled from their registry, and exits if not.")
+	flag.StringVar(&imagePullConfig, "image-pull-config", "",
+		"Path of a .dockerconfigjson file with the registry credentials used by --verify-images.")
+	flag.StringVar(&taskPreamble, "task-preamble", "",
+		"Instructions prepended to the task.md of every Task, before the namespace's KubeTaskConfig prompt.")
+	flag.BoolVar(&logReconcileDiffs, "log-reconcile-diffs", false,
+		"If set, the controller logs the difference between the existing and desired Job, ConfigMap "+
+			"or NetworkPolicy of a Task whenever it updates one, for debugging.")
+	flag.BoolVar(&contextScan, "context-scan", true,
+		"If set, Task descriptions and contexts are scanned for secrets-looking strings and prompt-injection patterns.")
+	flag.StringVar(&contextScanWebhookURL, "context-scan-webhook-url", "",
+		"URL of an additional context scanner that Task descriptions and contexts are POSTed to. Disabled if empty.")
+	flag.DurationVar(&runningTaskResyncInterval, "running-task-resync-interval", controller.DefaultRunningTaskResyncInterval,
+		"How often running Tasks are re-synced with their Job in case an event was missed. 0 disables it.")
+	flag.IntVar(&contextConcurrency, "context-resolution-concurrency", controller.DefaultContextConcurrency,
+		"Maximum number of Contexts resolved in parallel for a single Task.")
+	flag.Float64Var(&jobCreationQPS, "job-creation-qps", controller.DefaultJobCreationQPS,
+		"Tasks per second whose ConfigMap, NetworkPolicy and Job may be created once the burst is used up. 0 disables the limit.")
+	flag.IntVar(&jobCreationBurst, "job-creation-burst", controller.DefaultJobCreationBurst,
+		"Tasks whose ConfigMap, NetworkPolicy and Job may be created at once.")
+	flag.StringVar(&urlContextAllowedHosts, "url-context-allowed-hosts", "",
+		"Comma-separated hosts, such as wiki.example.com or *.example.com, that URL Contexts may be fetched from. Any public host if empty. "+
+			"Loopback, private and link-local addresses are only fetched from hosts listed by exact name.")
+	flag.StringVar(&resultAPIAddr, "result-api-bind-address", "",
+		"The address the result API binds to (e.g. \":8091\"). Disabled if empty. Requires --result-api-url.")
+	flag.StringVar(&resultAPIURL, "result-api-url", "",
+		"URL agents reach the result API at (e.g. \"http://kubetask-result-api.kubetask-system.svc\").")
+	flag.StringVar(&resultsDir, "results-dir", "",
+		"Directory the outcome of finished Tasks is kept in beyond their TTL, typically on a PersistentVolume. Disabled if empty.")
+	flag.StringVar(&resultsAddr, "results-bind-address", "",
+		"The address the results query API binds to (e.g. \":8092\"). Requires --results-dir. "+
+			"Set KUBETASK_RESULTS_TOKEN to require a bearer token.")
+	flag.DurationVar(&resultsRetention, "results-retention", 90*24*time.Hour,
+		"How long the results of finished Tasks are kept. 0 keeps them forever.")
+	flag.StringVar(&slackAddr, "slack-bind-address", "",
+		"The address the Slack slash command endpoint binds to (e.g. \":8090\"). Disabled if empty. "+
+			"Requires the SLACK_SIGNING_SECRET and SLACK_BOT_TOKEN environment variables.")
+	flag.StringVar(&slackNamespace, "slack-namespace", "default",
+		"Namespace in which Slack slash commands look up CronTask templates and create Tasks.")
+	flag.StringVar(&jiraURL, "jira-url", "",
+		"Jira site URL for writing Task results back to the issue in the kubetask.io/jira-issue annotation. "+
+			"Disabled if empty. Requires the JIRA_USER and JIRA_API_TOKEN environment variables.")
+	flag.StringVar(&jiraSuccessTransition, "jira-success-transition", "",
+		"Jira transition applied when a Task completes (e.g. \"Done\"). No transition if empty.")
+	flag.StringVar(&jiraFailureTransition, "jira-failure-transition", "",
+		"Jira transition applied when a Task fails. No transition if empty.")
+	flag.BoolVar(&githubIssues, "github-issue-write-back", false,
+		"If set, Task results are written back to the issue in the kubetask.io/github-issue annotation. "+
+			"Requires the GITHUB_TOKEN environment variable.")
+	flag.StringVar(&githubAPIURL, "github-api-url", issuetracker.DefaultGitHubAPIURL,
+		"GitHub REST API base URL used for issue write-back.")
+	flag.BoolVar(&githubCloseIssues, "github-close-issues", false,
+		"If set, GitHub issues are closed as completed when their Task completes.")
+	flag.StringVar(&mirrorExternalRuns, "mirror-external-runs", "",
+		"Comma-separated workflow engines (argo, tekton) whose runs labeled kubetask.io/task are mirrored "+
+			"into Task records. The engine's CRDs must be installed. Disabled if empty.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -54,6 +198,34 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// Point the images the controller injects at the mirror, and check that the
+	// mirror serves them before any Task needs them
+	injectedImages := images.Set{
+		Agent:       defaultAgentImage,
+		GitSync:     gitSyncImage,
+		ObjectStore: objectStoreImage,
+		ORAS:        orasImage,
+	}.WithRegistry(imageRegistry)
+	defaultAgentImage, gitSyncImage = injectedImages.Agent, injectedImages.GitSync
+	objectStoreImage, orasImage = injectedImages.ObjectStore, injectedImages.ORAS
+	if verifyImages {
+		verifier := &images.Verifier{Client: &http.Client{Timeout: 30 * time.Second}}
+		if imagePullConfig != "" {
+			auths, err := images.LoadDockerConfig(imagePullConfig)
+			if err != nil {
+				setupLog.Error(err, "unable to load image pull config")
+				os.Exit(1)
+			}
+			verifier.Auths = auths
+		}
+		if err := verifier.Verify(context.Background(), injectedImages.List()); err != nil {
+			setupLog.Error(err, "images cannot be pulled")
+			os.Exit(1)
+		}
+		setupLog.Info("verified images", "agent", defaultAgentImage, "gitSync", gitSyncImage,
+			"objectStore", objectStoreImage, "oras", orasImage)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancelation and
@@ -74,39 +246,137 @@ func main() {
 		TLSOpts: tlsOpts,
 	})
 
+	managedBySelector := labels.SelectorFromSet(labels.Set{controller.ManagedByLabelKey: controller.ManagedByLabelValue})
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		// Only cache agent Pods; the Task controller watches them to surface
+		// scheduling, image pull and OOM problems on Task status.
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Pod{}: {Label: labels.SelectorFromSet(labels.Set{"app": "kubetask"})},
+				// Only cache the ServiceAccounts and bindings managed for Agents with rbac
+				&corev1.ServiceAccount{}:     {Label: managedBySelector},
+				&rbacv1.RoleBinding{}:        {Label: managedBySelector},
+				&rbacv1.ClusterRoleBinding{}: {Label: managedBySelector},
+			},
+		},
+		// Credentials are validated against Secrets read directly from the API server,
+		// so the controller does not keep every Secret of the cluster in memory.
+		// Image pull events of agent Pods are likewise read on demand.
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{
+				&corev1.Secret{}, &corev1.Event{}, &networkingv1.NetworkPolicy{}, &corev1.Service{}, &networkingv1.Ingress{},
+			}},
+		},
 		Metrics: metricsserver.Options{
 			BindAddress:   metricsAddr,
 			SecureServing: secureMetrics,
 			TLSOpts:       tlsOpts,
 		},
-		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "kubetask.io",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		WebhookServer:           webhookServer,
+		HealthProbeBindAddress:  probeAddr,
+		PprofBindAddress:        pprofAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        "kubetask.io",
+		LeaderElectionNamespace: leaderElectionNamespace,
+		LeaseDuration:           &leaseDuration,
+		RenewDeadline:           &renewDeadline,
+		RetryPeriod:             &retryPeriod,
+		// LeaderElectionReleaseOnCancel makes the leader step down voluntarily when
+		// the Manager ends, so a replacement replica can take over immediately during
+		// rolling upgrades instead of waiting for the lease to expire. This is safe
+		// because the program exits right after the manager stops.
+		LeaderElectionReleaseOnCancel: true,
+		// GracefulShutdownTimeout bounds how long the manager waits for in-flight
+		// reconciles to drain on SIGTERM before releasing the lease.
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	var contextScanners []controller.ContextScanner
+	if contextScan {
+		contextScanners = append(contextScanners, &controller.PatternScanner{})
+	}
+	if contextScanWebhookURL != "" {
+		contextScanners = append(contextScanners, &controller.WebhookScanner{URL: contextScanWebhookURL})
+	}
+
+	var resultStore *results.FileStore
+	if resultsDir != "" {
+		if resultStore, err = results.OpenFileStore(resultsDir); err != nil {
+			setupLog.Error(err, "unable to open results store", "dir", resultsDir)
+			os.Exit(1)
+		}
+		if resultsRetention > 0 {
+			if err = mgr.Add(&results.Pruner{Store: resultStore, Retention: resultsRetention}); err != nil {
+				setupLog.Error(err, "unable to add results pruner")
+				os.Exit(1)
+			}
+		}
+	}
+
+	var allowedURLHosts []string
+	for _, host := range strings.Split(urlContextAllowedHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			allowedURLHosts = append(allowedURLHosts, host)
+		}
+	}
 	if err = (&controller.TaskReconciler{
+		Client:                    mgr.GetClient(),
+		Scheme:                    mgr.GetScheme(),
+		DefaultAgentImage:         defaultAgentImage,
+		GitSyncImage:              gitSyncImage,
+		ObjectStoreImage:          objectStoreImage,
+		ORASImage:                 orasImage,
+		LogDiffs:                  logReconcileDiffs,
+		ContextConcurrency:        contextConcurrency,
+		JobCreationQPS:            jobCreationQPS,
+		JobCreationBurst:          jobCreationBurst,
+		URLContextAllowedHosts:    allowedURLHosts,
+		ResultAPIURL:              resultAPIURL,
+		TaskPreamble:              taskPreamble,
+		ContextScanners:           contextScanners,
+		RunningTaskResyncInterval: runningTaskResyncInterval,
+		Results:                   resultStore,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Task")
+		os.Exit(1)
+	}
+
+	if err = (&controller.AgentReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		DefaultAgentImage: defaultAgentImage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Agent")
+		os.Exit(1)
+	}
+
+	if err = (&controller.KubeTaskConfigReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		DefaultAgentImage: defaultAgentImage,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KubeTaskConfig")
+		os.Exit(1)
+	}
+
+	if err = (&controller.AgentEvalReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Task")
+		setupLog.Error(err, "unable to create controller", "controller", "AgentEval")
+		os.Exit(1)
+	}
+
+	if err = (&controller.TaskQuotaReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TaskQuota")
 		os.Exit(1)
 	}
 
@@ -118,6 +388,168 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controller.CatalogReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Catalog")
+		os.Exit(1)
+	}
+
+	if err = mgr.Add(&controller.StuckTaskDetector{Client: mgr.GetClient()}); err != nil {
+		setupLog.Error(err, "unable to add stuck Task detector")
+		os.Exit(1)
+	}
+
+	if err = mgr.Add(&controller.SizingRecommender{Client: mgr.GetClient()}); err != nil {
+		setupLog.Error(err, "unable to add sizing recommender")
+		os.Exit(1)
+	}
+
+	if err = controller.SetupUsageMetrics(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to set up usage metrics")
+		os.Exit(1)
+	}
+
+	if enableWebhooks {
+		var allowedMountRoots []string
+		for _, root := range strings.Split(contextMountAllowedRoots, ",") {
+			if root = strings.TrimSpace(root); root != "" {
+				allowedMountRoots = append(allowedMountRoots, root)
+			}
+		}
+		// The controller records the Task deletions it makes itself, so the webhook
+		// skips them. Without the identity, they are recorded twice.
+		whoami := &authenticationv1.SelfSubjectReview{}
+		if err = mgr.GetClient().Create(context.Background(), whoami); err != nil {
+			setupLog.Error(err, "unable to determine the controller identity, Task deletions by the controller are audited twice")
+		}
+		if err = kubetaskwebhook.SetupTaskWebhookWithManager(mgr, allowedMountRoots, whoami.Status.UserInfo.Username); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Task")
+			os.Exit(1)
+		}
+		if err = kubetaskwebhook.SetupDeletionProtectionWebhookWithManager(mgr, kubetaskwebhook.DeletionProtection(deletionProtection)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DeletionProtection")
+			os.Exit(1)
+		}
+		if err = kubetaskwebhook.SetupCronTaskWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CronTask")
+			os.Exit(1)
+		}
+	}
+
+	if (resultAPIAddr == "") != (resultAPIURL == "") {
+		setupLog.Error(nil, "--result-api-bind-address and --result-api-url must be set together")
+		os.Exit(1)
+	}
+	if resultAPIAddr != "" {
+		if err := mgr.Add(&resultapi.Server{
+			Addr:    resultAPIAddr,
+			Handler: &resultapi.Handler{Client: mgr.GetClient()},
+		}); err != nil {
+			setupLog.Error(err, "unable to add result API server")
+			os.Exit(1)
+		}
+	}
+
+	if resultsAddr != "" {
+		if resultStore == nil {
+			setupLog.Error(nil, "--results-bind-address requires --results-dir")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&results.Server{
+			Addr:    resultsAddr,
+			Handler: &results.Handler{Store: resultStore, Token: os.Getenv("KUBETASK_RESULTS_TOKEN")},
+		}); err != nil {
+			setupLog.Error(err, "unable to add results server")
+			os.Exit(1)
+		}
+	}
+
+	if slackAddr != "" {
+		signingSecret, botToken := os.Getenv("SLACK_SIGNING_SECRET"), os.Getenv("SLACK_BOT_TOKEN")
+		if signingSecret == "" || botToken == "" {
+			setupLog.Error(nil, "SLACK_SIGNING_SECRET and SLACK_BOT_TOKEN must be set when --slack-bind-address is set")
+			os.Exit(1)
+		}
+		slackClient := slack.NewClient(botToken)
+		if err := mgr.Add(&slack.Server{
+			Addr: slackAddr,
+			Handler: &slack.CommandHandler{
+				Client:        mgr.GetClient(),
+				Slack:         slackClient,
+				SigningSecret: []byte(signingSecret),
+				Namespace:     slackNamespace,
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to add Slack server")
+			os.Exit(1)
+		}
+		if err := (&slack.Notifier{
+			Client: mgr.GetClient(),
+			Slack:  slackClient,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SlackNotifier")
+			os.Exit(1)
+		}
+	}
+
+	var trackers []issuetracker.Tracker
+	if jiraURL != "" {
+		jiraUser, jiraToken := os.Getenv("JIRA_USER"), os.Getenv("JIRA_API_TOKEN")
+		if jiraUser == "" || jiraToken == "" {
+			setupLog.Error(nil, "JIRA_USER and JIRA_API_TOKEN must be set when --jira-url is set")
+			os.Exit(1)
+		}
+		trackers = append(trackers, &issuetracker.Jira{
+			BaseURL:           jiraURL,
+			User:              jiraUser,
+			APIToken:          jiraToken,
+			SuccessTransition: jiraSuccessTransition,
+			FailureTransition: jiraFailureTransition,
+		})
+	}
+	if githubIssues {
+		githubToken := os.Getenv("GITHUB_TOKEN")
+		if githubToken == "" {
+			setupLog.Error(nil, "GITHUB_TOKEN must be set when --github-issue-write-back is set")
+			os.Exit(1)
+		}
+		trackers = append(trackers, &issuetracker.GitHub{
+			BaseURL:        githubAPIURL,
+			Token:          githubToken,
+			CloseOnSuccess: githubCloseIssues,
+		})
+	}
+	if len(trackers) > 0 {
+		if err := (&issuetracker.Reporter{
+			Client:   mgr.GetClient(),
+			Trackers: trackers,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IssueReporter")
+			os.Exit(1)
+		}
+	}
+
+	for _, name := range strings.Split(mirrorExternalRuns, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		engine, ok := controller.ExternalEngines[name]
+		if !ok {
+			setupLog.Error(nil, "unknown workflow engine in --mirror-external-runs", "engine", name)
+			os.Exit(1)
+		}
+		if err := (&controller.ExternalRunReconciler{
+			Client: mgr.GetClient(),
+			Engine: engine,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ExternalRun", "engine", name)
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
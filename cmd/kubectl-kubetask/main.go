@@ -0,0 +1,324 @@
+// Copyright Contributors to the KubeTask project
+
+// kubectl-kubetask is a kubectl plugin for KubeTask. Install it on your PATH and
+// run it as "kubectl kubetask".
+//
+// Usage:
+//
+//	kubectl kubetask who-uses context|agent|secret/NAME [-n namespace] [-A]
+//	kubectl kubetask bundle export [-n namespace] [-A] [-l selector] > bundle.yaml
+//	kubectl kubetask bundle import [-n namespace] FILE
+//	kubectl kubetask catalog [-n namespace]
+//
+// who-uses lists the Agents, Tasks, CronTasks, and AgentEvals that reference a
+// Context, Agent, or credential Secret, directly or through an Agent or Context,
+// so owners can judge the impact of editing or deleting a shared resource.
+//
+// bundle export writes Tasks, with the context files they ran with and their
+// results, as a portable YAML bundle. bundle import replays the Tasks of a bundle
+// in the current cluster, in their recorded namespaces or the one given with -n.
+//
+// catalog lists the Agents, Contexts, and CronTask templates available in a
+// namespace, as published by the controller in the kubetask-catalog ConfigMap.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/bundle"
+	"github.com/kubetask/kubetask/internal/catalog"
+	"github.com/kubetask/kubetask/internal/usage"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kubetaskv1alpha1.AddToScheme(scheme))
+}
+
+func usageText(w io.Writer) {
+	fmt.Fprintf(w, "Usage: kubectl kubetask who-uses context|agent|secret/NAME [-n namespace] [-A]\n")
+	fmt.Fprintf(w, "       kubectl kubetask bundle export [-n namespace] [-A] [-l selector]\n")
+	fmt.Fprintf(w, "       kubectl kubetask bundle import [-n namespace] FILE\n")
+	fmt.Fprintf(w, "       kubectl kubetask catalog [-n namespace]\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usageText(os.Stderr)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "who-uses":
+		err = whoUses(os.Args[2:])
+	case "bundle":
+		err = bundleCommand(os.Args[2:])
+	case "catalog":
+		err = catalogCommand(os.Args[2:])
+	default:
+		usageText(os.Stderr)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "kubectl-kubetask: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// newFlagSet returns the flags of a command, with -n/--namespace bound to namespace
+func newFlagSet(name string, namespace *string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.StringVar(namespace, "n", "", "Namespace of the resource. Defaults to the current kubeconfig namespace.")
+	flags.StringVar(namespace, "namespace", "", "Namespace of the resource. Defaults to the current kubeconfig namespace.")
+	flags.Usage = func() {
+		usageText(flags.Output())
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+func whoUses(args []string) error {
+	var namespace string
+	var allNamespaces bool
+	flags := newFlagSet("who-uses", &namespace)
+	flags.BoolVar(&allNamespaces, "A", false, "Also search other namespaces, which may reference the Context.")
+	if err := flags.Parse(args); err != nil {
+		os.Exit(2)
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	return run(flags.Arg(0), namespace, allNamespaces)
+}
+
+func run(arg, namespace string, allNamespaces bool) error {
+	namespace, err := currentNamespace(namespace)
+	if err != nil {
+		return err
+	}
+	target, err := usage.ParseTarget(arg, namespace)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	references, err := usage.Find(context.Background(), c, target, allNamespaces)
+	if err != nil {
+		return err
+	}
+	if len(references) == 0 {
+		fmt.Printf("No resources use %s/%s in namespace %s.\n", target.Kind, target.Name, target.Namespace)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tVIA")
+	for _, ref := range references {
+		via := ref.Via
+		if via == "" {
+			via = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ref.Kind, ref.Namespace, ref.Name, via)
+	}
+	return w.Flush()
+}
+
+func bundleCommand(args []string) error {
+	if len(args) == 0 {
+		usageText(os.Stderr)
+		os.Exit(2)
+	}
+
+	var namespace string
+	switch args[0] {
+	case "export":
+		var allNamespaces bool
+		var selector string
+		flags := newFlagSet("bundle export", &namespace)
+		flags.BoolVar(&allNamespaces, "A", false, "Export Tasks of all namespaces.")
+		flags.StringVar(&selector, "l", "", "Label selector of the Tasks to export.")
+		if err := flags.Parse(args[1:]); err != nil {
+			os.Exit(2)
+		}
+		if flags.NArg() != 0 {
+			flags.Usage()
+			os.Exit(2)
+		}
+		return exportBundle(namespace, allNamespaces, selector)
+	case "import":
+		flags := newFlagSet("bundle import", &namespace)
+		if err := flags.Parse(args[1:]); err != nil {
+			os.Exit(2)
+		}
+		if flags.NArg() != 1 {
+			flags.Usage()
+			os.Exit(2)
+		}
+		return importBundle(flags.Arg(0), namespace)
+	default:
+		usageText(os.Stderr)
+		os.Exit(2)
+	}
+	return nil
+}
+
+func exportBundle(namespace string, allNamespaces bool, selector string) error {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+	if allNamespaces {
+		namespace = ""
+	} else if namespace, err = currentNamespace(namespace); err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	b, err := bundle.Collect(context.Background(), c, namespace, sel)
+	if err != nil {
+		return err
+	}
+	return bundle.Encode(os.Stdout, b)
+}
+
+// importBundle replays a bundle. Unlike other commands, an empty namespace keeps the
+// namespaces recorded in the bundle rather than using the kubeconfig namespace.
+func importBundle(file, namespace string) error {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	b, err := bundle.Decode(r)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	result, err := bundle.Replay(context.Background(), c, b, bundle.ReplayOptions{Namespace: namespace})
+	for _, key := range result.Created {
+		fmt.Printf("task/%s created\n", key)
+	}
+	for _, key := range result.Skipped {
+		fmt.Printf("task/%s skipped, it already exists\n", key)
+	}
+	return err
+}
+
+func catalogCommand(args []string) error {
+	var namespace string
+	flags := newFlagSet("catalog", &namespace)
+	if err := flags.Parse(args); err != nil {
+		os.Exit(2)
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	namespace, err := currentNamespace(namespace)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: catalog.ConfigMapName}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			fmt.Printf("No Agents, Contexts, or CronTasks in namespace %s.\n", namespace)
+			return nil
+		}
+		return err
+	}
+	cat, err := catalog.FromConfigMap(cm)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tDETAILS\tDESCRIPTION")
+	for _, agent := range cat.Agents {
+		details := orDash(agent.Image)
+		if agent.Preflight != "" {
+			details += ", preflight " + string(agent.Preflight)
+		}
+		fmt.Fprintf(w, "Agent\t%s\t%s\t%s\n", agent.Name, details, orDash(agent.Description))
+	}
+	for _, contextCR := range cat.Contexts {
+		fmt.Fprintf(w, "Context\t%s\t%s\t%s\n", contextCR.Name, contextCR.Type, orDash(contextCR.Description))
+	}
+	for _, template := range cat.Templates {
+		details := fmt.Sprintf("agent %s, schedule %q", template.Agent, template.Schedule)
+		if template.Suspended {
+			details += ", suspended"
+		}
+		if len(template.Parameters) > 0 {
+			details += ", parameters " + strings.Join(template.Parameters, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", template.Kind, template.Name, details, orDash(template.Description))
+	}
+	return w.Flush()
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// currentNamespace returns namespace, or the namespace of the current kubeconfig context if it is empty
+func currentNamespace(namespace string) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+	ns, _, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).Namespace()
+	return ns, err
+}
+
+func newClient() (client.Client, error) {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
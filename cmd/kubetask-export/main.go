@@ -0,0 +1,98 @@
+// Copyright Contributors to the KubeTask project
+
+// kubetask-export renders a KubeTask Task into an Argo Workflow or Tekton PipelineRun.
+//
+// Usage:
+//
+//	kubetask-export --format=argo|tekton|plan [--task=name] [--namespace=ns] FILE...
+//
+// FILEs hold the Task together with its Agent, Contexts, and any ConfigMaps or
+// KubeTaskConfig they reference; "-" reads from stdin. The rendered resources are
+// written to stdout as YAML, ready for kubectl create.
+//
+// --format=plan is a dry run for batches of Tasks: it resolves every Task in the
+// input (or the one selected with --task) and lists its Agent, image, labels, and
+// the checksums of its merged context files, without rendering or creating anything.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubetask/kubetask/internal/controller"
+	"github.com/kubetask/kubetask/internal/export"
+)
+
+func main() {
+	var format string
+	var taskName string
+	var namespace string
+	var defaultAgentImage string
+	flag.StringVar(&format, "format", string(export.FormatArgo), "Target engine: argo or tekton, or plan to list the Tasks that would run.")
+	flag.StringVar(&taskName, "task", "", "Name of the Task to export. Required if the input holds several Tasks.")
+	flag.StringVar(&namespace, "namespace", "default", "Namespace for manifests that do not set one.")
+	flag.StringVar(&defaultAgentImage, "default-agent-image", controller.DefaultAgentImage,
+		"Agent image used when neither the Agent nor KubeTaskConfig sets one.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] FILE...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Args(), namespace, export.Options{
+		Format:            export.Format(format),
+		TaskName:          taskName,
+		DefaultAgentImage: defaultAgentImage,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "kubetask-export: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(files []string, namespace string, opts export.Options) error {
+	var objects []client.Object
+	for _, file := range files {
+		objs, err := decodeFile(file, namespace)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		objects = append(objects, objs...)
+	}
+
+	if opts.Format == export.FormatPlan {
+		planned, err := export.Plan(context.Background(), objects, opts)
+		if err != nil {
+			return err
+		}
+		return export.EncodePlan(os.Stdout, planned)
+	}
+
+	out, err := export.Export(context.Background(), objects, opts)
+	if err != nil {
+		return err
+	}
+	return export.Encode(os.Stdout, out)
+}
+
+func decodeFile(file, namespace string) ([]client.Object, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return export.Decode(r, namespace)
+}
@@ -0,0 +1,142 @@
+// Copyright Contributors to the KubeTask project
+
+// loadgen creates Tasks in bulk against a cluster running the KubeTask controller,
+// such as envtest or kind, and reports how fast the controller started them and how
+// many API requests and reconciles it needed, failing when a budget is exceeded.
+//
+// Usage:
+//
+//	loadgen [--tasks=1000] [--concurrency=20] [--namespace=ns] [--agent=name]
+//	        [--metrics-url=http://localhost:8080/metrics] [--budget-start-p99=30s] ...
+//
+// The Tasks are labeled kubetask.io/loadgen-run=<run ID> and deleted when the run
+// ends, unless --cleanup=false. Use an Agent whose image exits immediately, and a
+// TaskQuota if the cluster cannot run that many Pods at once.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/loadgen"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kubetaskv1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	opts := loadgen.Options{}
+	budget := loadgen.Budget{}
+	var timeout time.Duration
+	var metricsURL, metricsToken, output string
+	var qps float64
+	var burst int
+	var cleanup bool
+	flag.StringVar(&opts.Namespace, "namespace", "default", "Namespace the Tasks are created in.")
+	flag.IntVar(&opts.Tasks, "tasks", 1000, "Number of Tasks to create.")
+	flag.IntVar(&opts.Concurrency, "concurrency", 20, "Number of Tasks created in parallel.")
+	flag.StringVar(&opts.AgentRef, "agent", "", "Agent the Tasks run with. Defaults to the namespace's default Agent.")
+	flag.StringVar(&opts.RunID, "run-id", strconv.FormatInt(time.Now().Unix(), 36), "ID of the run, used in Task names and labels.")
+	flag.DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the controller to start the Tasks.")
+	flag.StringVar(&metricsURL, "metrics-url", "", "Metrics endpoint of the controller, to measure its API requests and reconciles.")
+	flag.StringVar(&metricsToken, "metrics-token", "", "Bearer token for a secure metrics endpoint.")
+	flag.Float64Var(&qps, "qps", 200, "Client-side QPS limit for creating Tasks.")
+	flag.IntVar(&burst, "burst", 400, "Client-side burst for creating Tasks.")
+	flag.BoolVar(&cleanup, "cleanup", true, "Delete the Tasks of the run when it ends.")
+	flag.StringVar(&output, "output", "text", "Report format: text or json.")
+	flag.DurationVar(&budget.StartP99, "budget-start-p99", 0, "Fail if the p99 start latency exceeds this.")
+	flag.Float64Var(&budget.APIRequestsPerTask, "budget-api-requests-per-task", 0, "Fail if the controller sends more API requests per Task. Requires --metrics-url.")
+	flag.DurationVar(&budget.ReconcileP99, "budget-reconcile-p99", 0, "Fail if the p99 Task reconcile time exceeds this. Requires --metrics-url.")
+	flag.Parse()
+
+	if output != "text" && output != "json" {
+		fmt.Fprintf(os.Stderr, "loadgen: invalid --output %q, must be text or json\n", output)
+		os.Exit(2)
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		os.Exit(1)
+	}
+	config.QPS = float32(qps)
+	config.Burst = burst
+	c, err := client.NewWithWatch(config, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	var metrics *loadgen.MetricsSource
+	if metricsURL != "" {
+		metrics = &loadgen.MetricsSource{URL: metricsURL, Token: metricsToken}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Fprintf(os.Stderr, "loadgen: creating %d Tasks in %s (run %s)\n", opts.Tasks, opts.Namespace, opts.RunID)
+	report, err := loadgen.Run(runCtx, c, opts, metrics)
+	if cleanup {
+		if cleanupErr := loadgen.Cleanup(context.WithoutCancel(ctx), c, opts.Namespace, opts.RunID); cleanupErr != nil {
+			fmt.Fprintf(os.Stderr, "loadgen: unable to delete the Tasks of run %s: %v\n", opts.RunID, cleanupErr)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(report)
+	} else {
+		printReport(os.Stdout, report)
+	}
+
+	if violations := budget.Check(report); len(violations) > 0 {
+		for _, v := range violations {
+			fmt.Fprintf(os.Stderr, "loadgen: budget exceeded: %s\n", v)
+		}
+		os.Exit(1)
+	}
+}
+
+// printReport writes a human-readable report
+func printReport(w io.Writer, r loadgen.Report) {
+	fmt.Fprintf(w, "Run:            %s\n", r.RunID)
+	fmt.Fprintf(w, "Tasks:          %d created, %d failed to create, %d started\n", r.Created, r.CreateErrors, r.Started)
+	fmt.Fprintf(w, "Duration:       %s (%.1f Tasks/s created)\n", r.Duration.Round(time.Millisecond), r.CreateRate)
+	fmt.Fprintf(w, "Start latency:  p50 %s, p90 %s, p99 %s, max %s\n",
+		r.StartLatency.P50.Round(time.Millisecond), r.StartLatency.P90.Round(time.Millisecond),
+		r.StartLatency.P99.Round(time.Millisecond), r.StartLatency.Max.Round(time.Millisecond))
+	if s := r.Controller; s != nil {
+		perTask := 0.0
+		if r.Created > 0 {
+			perTask = s.APIRequests / float64(r.Created)
+		}
+		fmt.Fprintf(w, "API requests:   %.0f (%.1f/s, %.1f per Task)\n", s.APIRequests, s.APIQPS, perTask)
+		fmt.Fprintf(w, "Reconciles:     %.0f (mean %s, p99 <= %s)\n", s.Reconciles, s.ReconcileMean.Round(time.Microsecond), s.ReconcileP99)
+	}
+}
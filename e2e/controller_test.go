@@ -0,0 +1,48 @@
+// Copyright Contributors to the KubeTask project
+
+package e2e
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// controllerPprofPort is the pprof port the e2e deployment enables on the controller
+// (see the e2e-deploy target in the Makefile)
+const controllerPprofPort = "8082"
+
+var _ = Describe("Controller E2E Tests", func() {
+
+	Context("Controller with pprof enabled", func() {
+		It("should serve the pprof debug endpoint", func() {
+			By("Finding a running controller Pod")
+			var podName string
+			Eventually(func() string {
+				pods := &corev1.PodList{}
+				if err := k8sClient.List(ctx, pods, client.InNamespace("kubetask-system"), client.MatchingLabels{
+					"app.kubernetes.io/name":      "kubetask",
+					"app.kubernetes.io/component": "controller",
+				}); err != nil {
+					return ""
+				}
+				for _, pod := range pods.Items {
+					if pod.Status.Phase == corev1.PodRunning {
+						podName = pod.Name
+						return podName
+					}
+				}
+				return ""
+			}, timeout, interval).ShouldNot(BeEmpty())
+
+			By("Requesting the pprof index through the API server proxy")
+			Eventually(func() (string, error) {
+				body, err := clientset.CoreV1().Pods("kubetask-system").
+					ProxyGet("http", podName, controllerPprofPort, "/debug/pprof/", nil).
+					DoRaw(ctx)
+				return string(body), err
+			}, timeout, interval).Should(ContainSubstring("goroutine"))
+		})
+	})
+})
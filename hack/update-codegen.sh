@@ -6,6 +6,61 @@ set -o errexit
 set -o nounset
 set -o pipefail
 
-# Client generation is not currently used.
-# If typed clients are needed in the future, use controller-gen or code-generator.
-echo "Skipping client generation (not currently used)"
+SCRIPT_ROOT=$(dirname "${BASH_SOURCE[0]}")/..
+cd "${SCRIPT_ROOT}"
+
+# Generates the typed clientset, listers, informers and apply configurations
+# for api/v1alpha1 into client/, for integrations that do not use controller-runtime.
+MODULE="github.com/kubetask/kubetask"
+OUTPUT_PKG="${MODULE}/client"
+OUTPUT_DIR="client"
+BOILERPLATE="hack/boilerplate.txt"
+
+LOCALBIN="$(pwd)/bin"
+CODEGEN_VERSION="${CODEGEN_VERSION:-v0.31.2}"
+
+# Ensure the generators are installed
+for gen in applyconfiguration-gen client-gen lister-gen informer-gen; do
+    if [[ ! -x "${LOCALBIN}/${gen}" ]]; then
+        echo "Installing ${gen}..."
+        mkdir -p "${LOCALBIN}"
+        GOBIN="${LOCALBIN}" go install "k8s.io/code-generator/cmd/${gen}@${CODEGEN_VERSION}"
+    fi
+done
+
+rm -rf "${OUTPUT_DIR}"
+
+echo "Generating apply configurations..."
+"${LOCALBIN}/applyconfiguration-gen" \
+    --go-header-file "${BOILERPLATE}" \
+    --output-dir "${OUTPUT_DIR}/applyconfiguration" \
+    --output-pkg "${OUTPUT_PKG}/applyconfiguration" \
+    ./api/v1alpha1
+
+echo "Generating clientset..."
+"${LOCALBIN}/client-gen" \
+    --go-header-file "${BOILERPLATE}" \
+    --clientset-name versioned \
+    --input-base "" \
+    --input "${MODULE}/api/v1alpha1" \
+    --apply-configuration-package "${OUTPUT_PKG}/applyconfiguration" \
+    --output-dir "${OUTPUT_DIR}/clientset" \
+    --output-pkg "${OUTPUT_PKG}/clientset"
+
+echo "Generating listers..."
+"${LOCALBIN}/lister-gen" \
+    --go-header-file "${BOILERPLATE}" \
+    --output-dir "${OUTPUT_DIR}/listers" \
+    --output-pkg "${OUTPUT_PKG}/listers" \
+    ./api/v1alpha1
+
+echo "Generating informers..."
+"${LOCALBIN}/informer-gen" \
+    --go-header-file "${BOILERPLATE}" \
+    --versioned-clientset-package "${OUTPUT_PKG}/clientset/versioned" \
+    --listers-package "${OUTPUT_PKG}/listers" \
+    --output-dir "${OUTPUT_DIR}/informers" \
+    --output-pkg "${OUTPUT_PKG}/informers" \
+    ./api/v1alpha1
+
+echo "Client generation complete"
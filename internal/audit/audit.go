@@ -0,0 +1,128 @@
+// Copyright Contributors to the KubeTask project
+
+// Package audit emits a structured, append-only audit stream of Task lifecycle actions.
+//
+// Each entry is written as a single structured log line on the "audit" logger, so it can be
+// shipped to any log sink (Loki, Elasticsearch, Cloud Logging) and filtered by logger name.
+// Entries are never updated or removed by KubeTask, which makes the stream suitable for
+// compliance reviews of agents that modify production systems.
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AnnotationCreatedBy records the identity of the user that created a Task.
+	// It is set by the Task admission webhook and cannot be changed afterwards.
+	AnnotationCreatedBy = "kubetask.io/created-by"
+
+	// AnnotationApprovedBy records the identity of the user that approved a Task.
+	// The Task admission webhook rejects it on create and only admits it being set
+	// once, to the requesting user. It is copied into audit entries.
+	AnnotationApprovedBy = "kubetask.io/approved-by"
+
+	// LabelCreatedBy carries the creator of a Task as a label, so Tasks and the
+	// objects they own can be selected by creator. See CreatedByLabelValue.
+	LabelCreatedBy = "kubetask.io/created-by"
+
+	// ControllerActor is the actor recorded for actions performed by the controller itself.
+	ControllerActor = "system:kubetask-controller"
+)
+
+// Action is the kind of lifecycle action recorded in the audit stream.
+type Action string
+
+const (
+	// ActionCreated is recorded when a Task is admitted by the API server. Dry runs
+	// are not recorded.
+	ActionCreated Action = "Created"
+	// ActionStarted is recorded when the controller creates the Task's Job.
+	ActionStarted Action = "Started"
+	// ActionCompleted is recorded when the Task's Job finishes successfully.
+	ActionCompleted Action = "Completed"
+	// ActionFailed is recorded when the Task's Job fails.
+	ActionFailed Action = "Failed"
+	// ActionCancelled is recorded when a Task is deleted before it finished. Each
+	// deletion is recorded once: by the Task admission webhook for users, and by the
+	// controller for its own deletions.
+	ActionCancelled Action = "Cancelled"
+	// ActionDeleted is recorded when a finished Task is deleted.
+	ActionDeleted Action = "Deleted"
+	// ActionExpired is recorded when the controller deletes a Task after its TTL.
+	ActionExpired Action = "Expired"
+)
+
+// Entry is a single audit record.
+type Entry struct {
+	// Action is the lifecycle action being recorded.
+	Action Action
+	// Namespace and Name identify the Task.
+	Namespace string
+	Name      string
+	// Actor is the identity that performed the action.
+	Actor string
+	// Creator and Approver are copied from the Task annotations.
+	Creator  string
+	Approver string
+	// Phase is the Task phase at the time of the action.
+	Phase kubetaskv1alpha1.TaskPhase
+	// Message carries optional human-readable details.
+	Message string
+}
+
+// Sink is the logger that audit entries are written to.
+// It can be replaced in tests or to route entries to a dedicated logger.
+var Sink logr.Logger = ctrl.Log.WithName("audit")
+
+// ForTask builds an audit Entry for the given Task, copying identity annotations.
+func ForTask(task *kubetaskv1alpha1.Task, action Action, actor string) Entry {
+	return Entry{
+		Action:    action,
+		Namespace: task.Namespace,
+		Name:      task.Name,
+		Actor:     actor,
+		Creator:   task.Annotations[AnnotationCreatedBy],
+		Approver:  task.Annotations[AnnotationApprovedBy],
+		Phase:     task.Status.Phase,
+	}
+}
+
+// Record writes an entry to the audit stream.
+func Record(e Entry) {
+	Sink.Info("audit",
+		"action", e.Action,
+		"namespace", e.Namespace,
+		"task", e.Name,
+		"actor", e.Actor,
+		"creator", e.Creator,
+		"approver", e.Approver,
+		"phase", e.Phase,
+		"message", e.Message,
+		"timestamp", time.Now().UTC().Format(time.RFC3339),
+	)
+}
+
+// CreatedByLabelValue converts a username into a valid label value. Characters that
+// are not allowed in label values, such as '@' and ':', become '_', and the value is
+// cut to 63 characters, so the created-by annotation remains the exact identity.
+func CreatedByLabelValue(username string) string {
+	value := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, username)
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return strings.Trim(value, "-._")
+}
@@ -0,0 +1,220 @@
+// Copyright Contributors to the KubeTask project
+
+// Package bundle moves Tasks between clusters and namespaces as portable YAML
+// bundles. A bundle records the spec of each Task together with the context files
+// it ran with and its results, and replays the Tasks elsewhere. It is used by the
+// kubectl-kubetask plugin.
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/controller"
+)
+
+const (
+	// APIVersion and Kind identify a bundle document
+	APIVersion = "kubetask.io/v1alpha1"
+	Kind       = "TaskBundle"
+
+	// ReplayedFromAnnotation records the "namespace/name" of the Task a replayed Task was created from
+	ReplayedFromAnnotation = "kubetask.io/replayed-from"
+)
+
+// lastAppliedAnnotation is set by kubectl apply and describes the source object, not the replay
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Bundle is a portable set of Tasks
+type Bundle struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Tasks in namespace and name order
+	Tasks []Entry `json:"tasks"`
+}
+
+// Entry is a Task recorded in a bundle
+type Entry struct {
+	// Name and Namespace of the Task in the source cluster
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Labels and Annotations of the Task
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Spec of the Task, which is replayed
+	Spec kubetaskv1alpha1.TaskSpec `json:"spec"`
+
+	// Contexts maps the keys of the Task's context ConfigMap to their content: task.md
+	// and the merged context files as they were resolved when the Task started.
+	// Empty if the Task had not started or its ConfigMap was already deleted.
+	Contexts map[string]string `json:"contexts,omitempty"`
+
+	// Status of the Task when the bundle was created, with its phase, timings and
+	// results. It is kept for reference and not restored on replay.
+	Status kubetaskv1alpha1.TaskExecutionStatus `json:"status,omitempty"`
+}
+
+// Collect records the Tasks in namespace that match selector, or in all namespaces
+// if namespace is empty. Child Tasks of comparisons are skipped, as replaying their
+// parent creates them again.
+func Collect(ctx context.Context, c client.Reader, namespace string, selector labels.Selector) (*Bundle, error) {
+	var taskList kubetaskv1alpha1.TaskList
+	if err := c.List(ctx, &taskList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("unable to list Tasks: %w", err)
+	}
+
+	b := &Bundle{APIVersion: APIVersion, Kind: Kind, Tasks: []Entry{}}
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if _, ok := task.Labels[controller.ComparisonLabelKey]; ok {
+			continue
+		}
+		contexts, err := taskContexts(ctx, c, task)
+		if err != nil {
+			return nil, err
+		}
+		annotations := task.Annotations
+		if _, ok := annotations[lastAppliedAnnotation]; ok {
+			annotations = copyMap(annotations)
+			delete(annotations, lastAppliedAnnotation)
+		}
+		b.Tasks = append(b.Tasks, Entry{
+			Name:        task.Name,
+			Namespace:   task.Namespace,
+			Labels:      task.Labels,
+			Annotations: annotations,
+			Spec:        task.Spec,
+			Contexts:    contexts,
+			Status:      task.Status,
+		})
+	}
+
+	sort.Slice(b.Tasks, func(i, j int) bool {
+		if b.Tasks[i].Namespace != b.Tasks[j].Namespace {
+			return b.Tasks[i].Namespace < b.Tasks[j].Namespace
+		}
+		return b.Tasks[i].Name < b.Tasks[j].Name
+	})
+	return b, nil
+}
+
+// taskContexts returns the data of the Task's context ConfigMap, or nil if it does not exist.
+// Tasks started before context ConfigMaps were named after their content do not
+// record the name, and use <task>-context.
+func taskContexts(ctx context.Context, c client.Reader, task *kubetaskv1alpha1.Task) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	name := task.Status.ContextConfigMap
+	if name == "" {
+		name = task.Name + controller.ContextConfigMapSuffix
+	}
+	key := client.ObjectKey{Namespace: task.Namespace, Name: name}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get context ConfigMap of Task %q: %w", task.Name, err)
+	}
+	if !metav1.IsControlledBy(cm, task) {
+		return nil, nil
+	}
+	return cm.Data, nil
+}
+
+// ReplayOptions configures Replay
+type ReplayOptions struct {
+	// Namespace creates all Tasks in this namespace. If empty, each Task is
+	// created in the namespace it was recorded in.
+	Namespace string
+}
+
+// ReplayResult lists the outcome of Replay as "namespace/name" keys
+type ReplayResult struct {
+	// Created Tasks
+	Created []string
+
+	// Skipped Tasks, because a Task of the same name already exists
+	Skipped []string
+}
+
+// Replay creates the Tasks of a bundle again, so they run in the target cluster or
+// namespace. The Agents and Contexts they reference must exist there; recorded
+// contexts and status are not restored. Replayed Tasks carry ReplayedFromAnnotation.
+func Replay(ctx context.Context, c client.Client, b *Bundle, opts ReplayOptions) (ReplayResult, error) {
+	var result ReplayResult
+	for _, entry := range b.Tasks {
+		namespace := entry.Namespace
+		if opts.Namespace != "" {
+			namespace = opts.Namespace
+		}
+		annotations := copyMap(entry.Annotations)
+		annotations[ReplayedFromAnnotation] = entry.Namespace + "/" + entry.Name
+		task := &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        entry.Name,
+				Namespace:   namespace,
+				Labels:      entry.Labels,
+				Annotations: annotations,
+			},
+			Spec: *entry.Spec.DeepCopy(),
+		}
+
+		key := namespace + "/" + entry.Name
+		if err := c.Create(ctx, task); err != nil {
+			if errors.IsAlreadyExists(err) {
+				result.Skipped = append(result.Skipped, key)
+				continue
+			}
+			return result, fmt.Errorf("unable to create Task %s: %w", key, err)
+		}
+		result.Created = append(result.Created, key)
+	}
+	return result, nil
+}
+
+// Encode writes a bundle as YAML
+func Encode(w io.Writer, b *Bundle) error {
+	data, err := yaml.Marshal(b)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Decode reads a bundle written by Encode
+func Decode(r io.Reader) (*Bundle, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	b := &Bundle{}
+	if err := yaml.UnmarshalStrict(data, b); err != nil {
+		return nil, err
+	}
+	if b.APIVersion != APIVersion || b.Kind != Kind {
+		return nil, fmt.Errorf("not a Task bundle: apiVersion %q, kind %q, want %q, %q", b.APIVersion, b.Kind, APIVersion, Kind)
+	}
+	return b, nil
+}
+
+// copyMap returns a copy of m that is never nil
+func copyMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
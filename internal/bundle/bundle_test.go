@@ -0,0 +1,171 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/controller"
+)
+
+func newTestClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestCollect(t *testing.T) {
+	description := "Fix the flaky test"
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fix",
+			Namespace:   "team",
+			UID:         "fix-uid",
+			Labels:      map[string]string{"repo": "service-a"},
+			Annotations: map[string]string{lastAppliedAnnotation: "{}", "owner": "sre"},
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{Description: &description, AgentRef: "claude"},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:   kubetaskv1alpha1.TaskPhaseCompleted,
+			Changes: &kubetaskv1alpha1.TaskChanges{PullRequests: []string{"https://example.com/pr/1"}},
+		},
+	}
+	isController := true
+	contextConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fix" + controller.ContextConfigMapSuffix,
+			Namespace: "team",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kubetaskv1alpha1.GroupVersion.String(),
+				Kind:       "Task",
+				Name:       "fix",
+				UID:        "fix-uid",
+				Controller: &isController,
+			}},
+		},
+		Data: map[string]string{"workspace-task.md": description},
+	}
+	c := newTestClient(t,
+		task,
+		contextConfigMap,
+		&kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "team", Labels: map[string]string{"repo": "service-b"}}},
+		&kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "compare-a", Namespace: "team", Labels: map[string]string{controller.ComparisonLabelKey: "compare"}}},
+		&kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+	)
+
+	b, err := Collect(context.Background(), c, "team", labels.Everything())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	var names []string
+	for _, entry := range b.Tasks {
+		names = append(names, entry.Name)
+	}
+	if want := []string{"audit", "fix"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Collect() Tasks = %v, want %v", names, want)
+	}
+
+	fix := b.Tasks[1]
+	if !reflect.DeepEqual(fix.Contexts, contextConfigMap.Data) {
+		t.Errorf("Contexts = %v, want %v", fix.Contexts, contextConfigMap.Data)
+	}
+	if want := map[string]string{"owner": "sre"}; !reflect.DeepEqual(fix.Annotations, want) {
+		t.Errorf("Annotations = %v, want %v", fix.Annotations, want)
+	}
+	if fix.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted || fix.Status.Changes == nil {
+		t.Errorf("Status = %+v, want the recorded results", fix.Status)
+	}
+
+	selected, err := Collect(context.Background(), c, "", labels.SelectorFromSet(labels.Set{"repo": "service-b"}))
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(selected.Tasks) != 1 || selected.Tasks[0].Name != "audit" {
+		t.Errorf("Collect() with selector = %+v, want only audit", selected.Tasks)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	description := "Update dependencies"
+	b := &Bundle{APIVersion: APIVersion, Kind: Kind, Tasks: []Entry{{
+		Name:      "deps",
+		Namespace: "team",
+		Spec:      kubetaskv1alpha1.TaskSpec{Description: &description},
+		Contexts:  map[string]string{"workspace-task.md": description},
+		Status:    kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseFailed},
+	}}}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, b); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, b) {
+		t.Errorf("Decode() = %+v, want %+v", got, b)
+	}
+
+	if _, err := Decode(strings.NewReader("apiVersion: v1\nkind: ConfigMap\n")); err == nil {
+		t.Error("Decode() of a ConfigMap succeeded, want an error")
+	}
+}
+
+func TestReplay(t *testing.T) {
+	description := "Update dependencies"
+	b := &Bundle{APIVersion: APIVersion, Kind: Kind, Tasks: []Entry{
+		{
+			Name:      "deps",
+			Namespace: "team",
+			Labels:    map[string]string{"repo": "service-a"},
+			Spec:      kubetaskv1alpha1.TaskSpec{Description: &description, AgentRef: "claude"},
+			Status:    kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseCompleted},
+		},
+		{Name: "existing", Namespace: "team"},
+	}}
+	c := newTestClient(t, &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "staging"}})
+
+	result, err := Replay(context.Background(), c, b, ReplayOptions{Namespace: "staging"})
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	want := ReplayResult{Created: []string{"staging/deps"}, Skipped: []string{"staging/existing"}}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("Replay() = %+v, want %+v", result, want)
+	}
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "staging", Name: "deps"}, task); err != nil {
+		t.Fatal(err)
+	}
+	if task.Spec.AgentRef != "claude" || task.Labels["repo"] != "service-a" {
+		t.Errorf("replayed Task = %+v, want the recorded spec and labels", task)
+	}
+	if got := task.Annotations[ReplayedFromAnnotation]; got != "team/deps" {
+		t.Errorf("%s = %q, want %q", ReplayedFromAnnotation, got, "team/deps")
+	}
+	if task.Status.Phase != "" {
+		t.Errorf("Status.Phase = %q, want the status not restored", task.Status.Phase)
+	}
+}
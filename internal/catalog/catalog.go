@@ -0,0 +1,174 @@
+// Copyright Contributors to the KubeTask project
+
+// Package catalog describes the Agents, Contexts, and Task templates available in
+// a namespace, so users can discover what they can run without reading every
+// resource. The controller publishes the catalog of each namespace in a ConfigMap,
+// which the kubectl-kubetask plugin and dashboards read.
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap holding the catalog of a namespace
+	ConfigMapName = "kubetask-catalog"
+
+	// DataKey is the ConfigMap key holding the catalog as YAML
+	DataKey = "catalog.yaml"
+
+	// DescriptionAnnotation describes an Agent, Context, or CronTask in the catalog
+	DescriptionAnnotation = "kubetask.io/description"
+
+	// CronTaskKind is the Kind of templates defined by a CronTask
+	CronTaskKind = "CronTask"
+)
+
+// Catalog lists the resources available in a namespace, each sorted by name
+type Catalog struct {
+	Agents    []Agent    `json:"agents,omitempty"`
+	Contexts  []Context  `json:"contexts,omitempty"`
+	Templates []Template `json:"templates,omitempty"`
+}
+
+// Agent is an Agent Tasks can run with
+type Agent struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Image is the agent image, empty when it defaults from KubeTaskConfig or the controller
+	Image string `json:"image,omitempty"`
+
+	// Preflight is the phase of the Agent's last pre-flight check, if it has one
+	Preflight kubetaskv1alpha1.PreflightPhase `json:"preflight,omitempty"`
+
+	// Contexts are the names of the Contexts the Agent mounts into every Task
+	Contexts []string `json:"contexts,omitempty"`
+}
+
+// Context is a Context Tasks can mount
+type Context struct {
+	Name        string                       `json:"name"`
+	Description string                       `json:"description,omitempty"`
+	Type        kubetaskv1alpha1.ContextType `json:"type"`
+}
+
+// Template is a Task template defined in the namespace
+type Template struct {
+	// Kind of the resource defining the template, currently always CronTask
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Agent the Tasks run with
+	Agent string `json:"agent"`
+
+	// Schedule of a CronTask, and whether it is suspended
+	Schedule  string `json:"schedule,omitempty"`
+	Suspended bool   `json:"suspended,omitempty"`
+
+	// Parameters are the Task labels the template's files refer to as .Labels.<name>,
+	// which each Task created from the template may set differently
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+// labelParameter matches a label referenced by a workspace file template
+var labelParameter = regexp.MustCompile(`\.Labels\.([A-Za-z_][A-Za-z0-9_]*)|index\s+\.Labels\s+"([^"]+)"`)
+
+// Build returns the catalog of the given resources, which should all be in one namespace
+func Build(agents []kubetaskv1alpha1.Agent, contexts []kubetaskv1alpha1.Context, cronTasks []kubetaskv1alpha1.CronTask) *Catalog {
+	c := &Catalog{}
+	for _, agent := range agents {
+		entry := Agent{
+			Name:        agent.Name,
+			Description: agent.Annotations[DescriptionAnnotation],
+			Image:       agent.Spec.AgentImage,
+		}
+		if agent.Status.Preflight != nil {
+			entry.Preflight = agent.Status.Preflight.Phase
+		}
+		for _, mount := range agent.Spec.Contexts {
+			entry.Contexts = append(entry.Contexts, mount.Name)
+		}
+		c.Agents = append(c.Agents, entry)
+	}
+	for _, contextCR := range contexts {
+		c.Contexts = append(c.Contexts, Context{
+			Name:        contextCR.Name,
+			Description: contextCR.Annotations[DescriptionAnnotation],
+			Type:        contextCR.Spec.Type,
+		})
+	}
+	for _, cronTask := range cronTasks {
+		spec := cronTask.Spec.TaskTemplate.Spec
+		agent := spec.AgentRef
+		if agent == "" {
+			agent = "default"
+		}
+		c.Templates = append(c.Templates, Template{
+			Kind:        CronTaskKind,
+			Name:        cronTask.Name,
+			Description: cronTask.Annotations[DescriptionAnnotation],
+			Agent:       agent,
+			Schedule:    cronTask.Spec.Schedule,
+			Suspended:   cronTask.Spec.Suspend != nil && *cronTask.Spec.Suspend,
+			Parameters:  templateParameters(spec.Files),
+		})
+	}
+
+	sort.Slice(c.Agents, func(i, j int) bool { return c.Agents[i].Name < c.Agents[j].Name })
+	sort.Slice(c.Contexts, func(i, j int) bool { return c.Contexts[i].Name < c.Contexts[j].Name })
+	sort.Slice(c.Templates, func(i, j int) bool { return c.Templates[i].Name < c.Templates[j].Name })
+	return c
+}
+
+// templateParameters returns the sorted labels referenced by the templates of files
+func templateParameters(files []kubetaskv1alpha1.WorkspaceFile) []string {
+	seen := map[string]bool{}
+	var params []string
+	for _, file := range files {
+		for _, match := range labelParameter.FindAllStringSubmatch(file.Template, -1) {
+			name := match[1] + match[2]
+			if !seen[name] {
+				seen[name] = true
+				params = append(params, name)
+			}
+		}
+	}
+	sort.Strings(params)
+	return params
+}
+
+// Empty reports whether the catalog lists nothing
+func (c *Catalog) Empty() bool {
+	return len(c.Agents) == 0 && len(c.Contexts) == 0 && len(c.Templates) == 0
+}
+
+// Marshal returns the catalog as YAML, the content of DataKey
+func (c *Catalog) Marshal() (string, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FromConfigMap reads the catalog published in a ConfigMap
+func FromConfigMap(cm *corev1.ConfigMap) (*Catalog, error) {
+	data, ok := cm.Data[DataKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s/%s has no key %q", cm.Namespace, cm.Name, DataKey)
+	}
+	c := &Catalog{}
+	if err := yaml.Unmarshal([]byte(data), c); err != nil {
+		return nil, fmt.Errorf("invalid catalog in ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return c, nil
+}
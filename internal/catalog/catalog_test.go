@@ -0,0 +1,94 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestBuild(t *testing.T) {
+	suspend := true
+	agents := []kubetaskv1alpha1.Agent{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gemini"},
+			Spec:       kubetaskv1alpha1.AgentSpec{AgentImage: "quay.io/kubetask/gemini:v1"},
+			Status: kubetaskv1alpha1.AgentStatus{
+				Preflight: &kubetaskv1alpha1.AgentPreflightStatus{Phase: kubetaskv1alpha1.PreflightPhasePassed},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "claude", Annotations: map[string]string{DescriptionAnnotation: "Coding agent"}},
+			Spec:       kubetaskv1alpha1.AgentSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "standards"}}},
+		},
+	}
+	contexts := []kubetaskv1alpha1.Context{{
+		ObjectMeta: metav1.ObjectMeta{Name: "standards", Annotations: map[string]string{DescriptionAnnotation: "Coding standards"}},
+		Spec:       kubetaskv1alpha1.ContextSpec{Type: kubetaskv1alpha1.ContextTypeGit},
+	}}
+	cronTasks := []kubetaskv1alpha1.CronTask{{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-lint", Annotations: map[string]string{DescriptionAnnotation: "Lint every repo"}},
+		Spec: kubetaskv1alpha1.CronTaskSpec{
+			Schedule: "0 2 * * *",
+			Suspend:  &suspend,
+			TaskTemplate: kubetaskv1alpha1.TaskTemplateSpec{Spec: kubetaskv1alpha1.TaskSpec{
+				Files: []kubetaskv1alpha1.WorkspaceFile{
+					{Path: "plan.md", Template: `Lint {{ .Labels.repo }} on {{ index .Labels "kubetask.io/branch" }}`},
+					{Path: "config.json", Template: `{"repo": "{{ .Labels.repo }}"}`},
+					{Path: "notes.md", Content: "{{ .Labels.ignored }}"},
+				},
+			}},
+		},
+	}}
+
+	got := Build(agents, contexts, cronTasks)
+	want := &Catalog{
+		Agents: []Agent{
+			{Name: "claude", Description: "Coding agent", Contexts: []string{"standards"}},
+			{Name: "gemini", Image: "quay.io/kubetask/gemini:v1", Preflight: kubetaskv1alpha1.PreflightPhasePassed},
+		},
+		Contexts: []Context{{Name: "standards", Description: "Coding standards", Type: kubetaskv1alpha1.ContextTypeGit}},
+		Templates: []Template{{
+			Kind:        CronTaskKind,
+			Name:        "nightly-lint",
+			Description: "Lint every repo",
+			Agent:       "default",
+			Schedule:    "0 2 * * *",
+			Suspended:   true,
+			Parameters:  []string{"kubetask.io/branch", "repo"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Build() = %+v, want %+v", got, want)
+	}
+	if !Build(nil, nil, nil).Empty() {
+		t.Error("Build() of nothing is not Empty()")
+	}
+}
+
+func TestFromConfigMap(t *testing.T) {
+	c := &Catalog{Contexts: []Context{{Name: "standards", Type: kubetaskv1alpha1.ContextTypeInline}}}
+	data, err := c.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	got, err := FromConfigMap(&corev1.ConfigMap{Data: map[string]string{DataKey: data}})
+	if err != nil {
+		t.Fatalf("FromConfigMap() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("FromConfigMap() = %+v, want %+v", got, c)
+	}
+
+	if _, err := FromConfigMap(&corev1.ConfigMap{}); err == nil {
+		t.Error("FromConfigMap() without the catalog key succeeded, want an error")
+	}
+}
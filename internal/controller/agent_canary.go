@@ -0,0 +1,210 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+const (
+	// AgentLabelKey is the label key recording the Agent a canary rollout Job ran with
+	AgentLabelKey = build.AgentLabelKey
+
+	// CanaryTrackLabelKey is the label key recording whether a Job ran the canary or stable image
+	CanaryTrackLabelKey = build.CanaryTrackLabelKey
+
+	// CanaryRolloutLabelKey is the label key identifying the rollout a Job belongs to
+	CanaryRolloutLabelKey = build.CanaryRolloutLabelKey
+
+	// CanaryTrackCanary marks Jobs that ran the image being rolled out
+	CanaryTrackCanary = "canary"
+
+	// CanaryTrackStable marks Jobs that ran the last promoted image during a rollout
+	CanaryTrackStable = "stable"
+)
+
+// imageHash derives a short, label-safe identifier from an image reference
+func imageHash(image string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(image))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// canaryBucket deterministically maps a Task to a bucket in [0, 100)
+func canaryBucket(uid types.UID) int32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(uid))
+	return int32(h.Sum32() % 100)
+}
+
+// routeCanary picks the image for a new Task while the Agent is mid-rollout.
+// cfg.AgentImage must hold the Agent's spec image; it is replaced with the stable image
+// for Tasks that are not routed to the canary, and rollout labels are recorded in cfg.
+func routeCanary(agent *kubetaskv1alpha1.Agent, task *kubetaskv1alpha1.Task, cfg *agentConfig) {
+	policy := agent.Spec.Canary
+	cs := agent.Status.Canary
+	if policy == nil || !policy.Enabled || cs == nil || cs.StableImage == "" || cfg.AgentImage == cs.StableImage {
+		return
+	}
+
+	if cs.Phase == kubetaskv1alpha1.CanaryPhaseProgressing && cs.CanaryImage == cfg.AgentImage {
+		cfg.CanaryRollout = imageHash(cs.CanaryImage)
+		if canaryBucket(task.UID) < policy.Weight {
+			cfg.CanaryTrack = CanaryTrackCanary
+			return
+		}
+		cfg.CanaryTrack = CanaryTrackStable
+	}
+
+	// Rolled back, or the Agent controller has not observed the new image yet
+	cfg.AgentImage = cs.StableImage
+}
+
+// reconcileCanary tracks AgentImage changes and promotes or rolls back canary rollouts.
+// It returns true if the Agent status was modified.
+// defaultImage is the image used when the Agent does not set spec.agentImage.
+func (r *AgentReconciler) reconcileCanary(ctx context.Context, agent *kubetaskv1alpha1.Agent, defaultImage string) (bool, error) {
+	log := log.FromContext(ctx)
+
+	policy := agent.Spec.Canary
+	if policy == nil || !policy.Enabled {
+		if agent.Status.Canary == nil {
+			return false, nil
+		}
+		agent.Status.Canary = nil
+		return true, nil
+	}
+
+	image := defaultImage
+	if agent.Spec.AgentImage != "" {
+		image = agent.Spec.AgentImage
+	}
+
+	cs := agent.Status.Canary
+	switch {
+	case cs == nil:
+		// First observation, the current image is the stable baseline
+		agent.Status.Canary = &kubetaskv1alpha1.AgentCanaryStatus{
+			Phase:       kubetaskv1alpha1.CanaryPhasePromoted,
+			StableImage: image,
+			Message:     "Canary rollouts enabled",
+		}
+		return true, nil
+
+	case image == cs.StableImage:
+		if cs.Phase != kubetaskv1alpha1.CanaryPhaseProgressing {
+			return false, nil
+		}
+		// AgentImage was reverted mid-rollout
+		log.Info("canary rollout aborted", "agent", agent.Name, "image", cs.CanaryImage)
+		agent.Status.Canary = &kubetaskv1alpha1.AgentCanaryStatus{
+			Phase:       kubetaskv1alpha1.CanaryPhasePromoted,
+			StableImage: cs.StableImage,
+			Message:     fmt.Sprintf("Rollout of %s aborted, agentImage reverted to the stable image", cs.CanaryImage),
+		}
+		return true, nil
+
+	case image != cs.CanaryImage:
+		// New image, start a rollout from the last promoted image
+		log.Info("starting canary rollout", "agent", agent.Name, "stable", cs.StableImage, "canary", image)
+		agent.Status.Canary = &kubetaskv1alpha1.AgentCanaryStatus{
+			Phase:       kubetaskv1alpha1.CanaryPhaseProgressing,
+			StableImage: cs.StableImage,
+			CanaryImage: image,
+			Message:     fmt.Sprintf("Routing %d%% of new Tasks to %s", policy.Weight, image),
+		}
+		return true, nil
+
+	case cs.Phase != kubetaskv1alpha1.CanaryPhaseProgressing:
+		// This image was already rolled back; a new agentImage starts another rollout
+		return false, nil
+	}
+
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.InNamespace(agent.Namespace), client.MatchingLabels{
+		AgentLabelKey:         agent.Name,
+		CanaryRolloutLabelKey: imageHash(image),
+	}); err != nil {
+		return false, err
+	}
+
+	updated := cs.DeepCopy()
+	updated.CanaryTasks, updated.CanaryFailures, updated.StableTasks, updated.StableFailures = 0, 0, 0, 0
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		failed := job.Status.Failed > 0 || isJobFailed(job)
+		if job.Status.Succeeded == 0 && !failed {
+			continue
+		}
+		switch job.Labels[CanaryTrackLabelKey] {
+		case CanaryTrackCanary:
+			updated.CanaryTasks++
+			if failed {
+				updated.CanaryFailures++
+			}
+		case CanaryTrackStable:
+			updated.StableTasks++
+			if failed {
+				updated.StableFailures++
+			}
+		}
+	}
+
+	updated.Phase, updated.Message = evaluateCanary(policy, updated)
+	switch updated.Phase {
+	case kubetaskv1alpha1.CanaryPhasePromoted:
+		updated.StableImage = updated.CanaryImage
+		log.Info("canary rollout promoted", "agent", agent.Name, "image", updated.CanaryImage)
+	case kubetaskv1alpha1.CanaryPhaseRolledBack:
+		log.Info("canary rollout rolled back", "agent", agent.Name, "image", updated.CanaryImage, "reason", updated.Message)
+	}
+
+	if *updated == *cs {
+		return false, nil
+	}
+	agent.Status.Canary = updated
+	return true, nil
+}
+
+// evaluateCanary decides the outcome of a rollout from the finished Task counts
+func evaluateCanary(policy *kubetaskv1alpha1.AgentCanary, cs *kubetaskv1alpha1.AgentCanaryStatus) (kubetaskv1alpha1.CanaryPhase, string) {
+	if cs.CanaryTasks < policy.MinTasks {
+		return kubetaskv1alpha1.CanaryPhaseProgressing,
+			fmt.Sprintf("%d/%d canary Tasks finished", cs.CanaryTasks, policy.MinTasks)
+	}
+
+	canaryRate := cs.CanaryFailures * 100 / cs.CanaryTasks
+	var stableRate int32
+	if cs.StableTasks > 0 {
+		stableRate = cs.StableFailures * 100 / cs.StableTasks
+	}
+
+	if canaryRate > stableRate+policy.MaxFailureRateIncrease {
+		return kubetaskv1alpha1.CanaryPhaseRolledBack,
+			fmt.Sprintf("Canary failure rate %d%% exceeds stable failure rate %d%% by more than %d points", canaryRate, stableRate, policy.MaxFailureRateIncrease)
+	}
+	return kubetaskv1alpha1.CanaryPhasePromoted,
+		fmt.Sprintf("Canary failure rate %d%% within %d points of stable failure rate %d%%", canaryRate, policy.MaxFailureRateIncrease, stableRate)
+}
+
+// canaryJobToAgent maps a canary rollout Job to the Agent it was routed by
+func canaryJobToAgent(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[CanaryRolloutLabelKey] == "" || labels[AgentLabelKey] == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: labels[AgentLabelKey], Namespace: obj.GetNamespace()},
+	}}
+}
@@ -0,0 +1,142 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestEvaluateCanary(t *testing.T) {
+	policy := &kubetaskv1alpha1.AgentCanary{
+		Enabled:                true,
+		Weight:                 10,
+		MinTasks:               10,
+		MaxFailureRateIncrease: 10,
+	}
+
+	tests := []struct {
+		name      string
+		status    kubetaskv1alpha1.AgentCanaryStatus
+		wantPhase kubetaskv1alpha1.CanaryPhase
+	}{
+		{
+			name:      "not enough canary tasks",
+			status:    kubetaskv1alpha1.AgentCanaryStatus{CanaryTasks: 9, CanaryFailures: 9},
+			wantPhase: kubetaskv1alpha1.CanaryPhaseProgressing,
+		},
+		{
+			name:      "canary as good as stable",
+			status:    kubetaskv1alpha1.AgentCanaryStatus{CanaryTasks: 10, CanaryFailures: 1, StableTasks: 90, StableFailures: 9},
+			wantPhase: kubetaskv1alpha1.CanaryPhasePromoted,
+		},
+		{
+			name:      "canary worse within tolerance",
+			status:    kubetaskv1alpha1.AgentCanaryStatus{CanaryTasks: 10, CanaryFailures: 2, StableTasks: 90, StableFailures: 9},
+			wantPhase: kubetaskv1alpha1.CanaryPhasePromoted,
+		},
+		{
+			name:      "canary worse beyond tolerance",
+			status:    kubetaskv1alpha1.AgentCanaryStatus{CanaryTasks: 10, CanaryFailures: 3, StableTasks: 90, StableFailures: 9},
+			wantPhase: kubetaskv1alpha1.CanaryPhaseRolledBack,
+		},
+		{
+			name:      "no stable tasks finished",
+			status:    kubetaskv1alpha1.AgentCanaryStatus{CanaryTasks: 10, CanaryFailures: 5},
+			wantPhase: kubetaskv1alpha1.CanaryPhaseRolledBack,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, msg := evaluateCanary(policy, &tt.status)
+			if phase != tt.wantPhase {
+				t.Errorf("evaluateCanary() phase = %q, want %q (message: %s)", phase, tt.wantPhase, msg)
+			}
+		})
+	}
+}
+
+func TestRouteCanary(t *testing.T) {
+	newAgent := func(phase kubetaskv1alpha1.CanaryPhase) *kubetaskv1alpha1.Agent {
+		return &kubetaskv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "gemini", Namespace: "default"},
+			Spec: kubetaskv1alpha1.AgentSpec{
+				AgentImage: "agent:v2",
+				Canary:     &kubetaskv1alpha1.AgentCanary{Enabled: true, Weight: 30},
+			},
+			Status: kubetaskv1alpha1.AgentStatus{
+				Canary: &kubetaskv1alpha1.AgentCanaryStatus{
+					Phase:       phase,
+					StableImage: "agent:v1",
+					CanaryImage: "agent:v2",
+				},
+			},
+		}
+	}
+
+	t.Run("progressing rollout splits Tasks by weight", func(t *testing.T) {
+		agent := newAgent(kubetaskv1alpha1.CanaryPhaseProgressing)
+
+		var canary int
+		const total = 1000
+		for i := 0; i < total; i++ {
+			task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("task-%d", i))}}
+			cfg := agentConfig{Config: build.Config{AgentImage: "agent:v2", AgentName: "gemini"}}
+			routeCanary(agent, task, &cfg)
+
+			switch cfg.CanaryTrack {
+			case CanaryTrackCanary:
+				canary++
+				if cfg.AgentImage != "agent:v2" {
+					t.Fatalf("canary Task image = %q, want %q", cfg.AgentImage, "agent:v2")
+				}
+			case CanaryTrackStable:
+				if cfg.AgentImage != "agent:v1" {
+					t.Fatalf("stable Task image = %q, want %q", cfg.AgentImage, "agent:v1")
+				}
+			default:
+				t.Fatalf("canaryTrack = %q, want canary or stable", cfg.CanaryTrack)
+			}
+			if cfg.CanaryRollout != imageHash("agent:v2") {
+				t.Fatalf("canaryRollout = %q, want %q", cfg.CanaryRollout, imageHash("agent:v2"))
+			}
+		}
+
+		// Allow some slack for hash distribution
+		if canary < total*20/100 || canary > total*40/100 {
+			t.Errorf("%d/%d Tasks routed to canary, want about 30%%", canary, total)
+		}
+	})
+
+	t.Run("rolled back rollout uses stable image", func(t *testing.T) {
+		agent := newAgent(kubetaskv1alpha1.CanaryPhaseRolledBack)
+		task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{UID: "task"}}
+		cfg := agentConfig{Config: build.Config{AgentImage: "agent:v2"}}
+		routeCanary(agent, task, &cfg)
+
+		if cfg.AgentImage != "agent:v1" || cfg.CanaryTrack != "" {
+			t.Errorf("routeCanary() = (%q, %q), want (%q, \"\")", cfg.AgentImage, cfg.CanaryTrack, "agent:v1")
+		}
+	})
+
+	t.Run("promoted rollout uses spec image", func(t *testing.T) {
+		agent := newAgent(kubetaskv1alpha1.CanaryPhasePromoted)
+		agent.Status.Canary.StableImage = "agent:v2"
+		task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{UID: "task"}}
+		cfg := agentConfig{Config: build.Config{AgentImage: "agent:v2"}}
+		routeCanary(agent, task, &cfg)
+
+		if cfg.AgentImage != "agent:v2" || cfg.CanaryTrack != "" {
+			t.Errorf("routeCanary() = (%q, %q), want (%q, \"\")", cfg.AgentImage, cfg.CanaryTrack, "agent:v2")
+		}
+	})
+}
@@ -0,0 +1,239 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AgentPreflightLabelKey is the label key used to identify pre-flight Jobs of an Agent
+	AgentPreflightLabelKey = "kubetask.io/agent-preflight"
+
+	// DefaultPreflightActiveDeadlineSeconds is the default deadline for pre-flight Jobs
+	DefaultPreflightActiveDeadlineSeconds int64 = 120
+
+	// PreflightPendingRequeueInterval is how often Pending Tasks re-check their Agent's pre-flight status
+	PreflightPendingRequeueInterval = 10 * time.Second
+)
+
+// errAgentPreflightPending is returned when an Agent's pre-flight check has not finished yet
+var errAgentPreflightPending = stderrors.New("agent pre-flight check has not completed")
+
+// AgentReconciler reconciles an Agent object
+type AgentReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// DefaultAgentImage is used when neither the Agent nor KubeTaskConfig sets an image
+	DefaultAgentImage string
+
+	// Clock is used for status timestamps. Defaults to the real clock.
+	Clock Clock
+}
+
+// +kubebuilder:rbac:groups=kubetask.io,resources=agents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kubetask.io,resources=agents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;clusterrolebindings,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=kubetask-agent-read-only-namespace;kubetask-agent-deploy-namespace;kubetask-agent-read-only-cluster
+
+// Reconcile manages the Agent's ServiceAccount and PodMonitor, runs its pre-flight check and canary rollouts, and publishes the results to Agent status
+func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	agent := &kubetaskv1alpha1.Agent{}
+	if err := r.Get(ctx, req.NamespacedName, agent); err != nil {
+		if errors.IsNotFound(err) {
+			// ClusterRoleBindings cannot be owned by an Agent, so remove them here
+			return ctrl.Result{}, r.deleteRBAC(ctx, req.Namespace, req.Name, nil)
+		}
+		log.Error(err, "unable to fetch Agent")
+		return ctrl.Result{}, err
+	}
+
+	rbacChanged, err := r.reconcileRBAC(ctx, agent)
+	if err != nil {
+		log.Error(err, "unable to reconcile Agent RBAC")
+		return ctrl.Result{}, err
+	}
+	if rbacChanged {
+		if err := r.Status().Update(ctx, agent); err != nil {
+			log.Error(err, "unable to update Agent RBAC status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	monitoringChanged, err := r.reconcileMonitoring(ctx, agent)
+	if err != nil {
+		log.Error(err, "unable to reconcile Agent PodMonitor")
+		return ctrl.Result{}, err
+	}
+	if monitoringChanged {
+		if err := r.Status().Update(ctx, agent); err != nil {
+			log.Error(err, "unable to update Agent monitoring status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	defaultImage, err := newKubeTaskConfigAccessor(r.Client).defaultAgentImage(ctx, agent.Namespace, r.DefaultAgentImage)
+	if err != nil {
+		log.Error(err, "unable to get KubeTaskConfig")
+		return ctrl.Result{}, err
+	}
+
+	// Track image rollouts before the pre-flight check, which may return early
+	changed, err := r.reconcileCanary(ctx, agent, defaultImage)
+	if err != nil {
+		log.Error(err, "unable to reconcile canary rollout")
+		return ctrl.Result{}, err
+	}
+	if changed {
+		if err := r.Status().Update(ctx, agent); err != nil {
+			log.Error(err, "unable to update Agent canary status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Nothing to do if pre-flight is not enabled
+	if agent.Spec.Preflight == nil || !agent.Spec.Preflight.Enabled {
+		if agent.Status.Preflight != nil {
+			agent.Status.Preflight = nil
+			meta.RemoveStatusCondition(&agent.Status.Conditions, "PreflightPassed")
+			return ctrl.Result{}, r.Status().Update(ctx, agent)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	cfg, err := buildAgentConfig(agent, defaultImage)
+	if err != nil {
+		if ps := agent.Status.Preflight; ps != nil && ps.ObservedGeneration == agent.Generation &&
+			ps.Phase == kubetaskv1alpha1.PreflightPhaseFailed {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.setPreflightResult(ctx, agent, "", "", kubetaskv1alpha1.PreflightPhaseFailed, "InvalidAgent", err.Error())
+	}
+
+	// Already finished for this generation and image
+	if ps := agent.Status.Preflight; ps != nil && ps.ObservedGeneration == agent.Generation && ps.Image == cfg.AgentImage &&
+		(ps.Phase == kubetaskv1alpha1.PreflightPhasePassed || ps.Phase == kubetaskv1alpha1.PreflightPhaseFailed) {
+		return ctrl.Result{}, nil
+	}
+
+	// One Job per Agent generation and image, so spec changes and namespace
+	// default image changes trigger a new check
+	jobName := fmt.Sprintf("%s-preflight-%d-%s", agent.Name, agent.Generation, imageHash(cfg.AgentImage))
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: jobName, Namespace: agent.Namespace}, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		job = buildPreflightJob(agent, jobName, cfg)
+		if err := r.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+			log.Error(err, "unable to create pre-flight Job", "job", jobName)
+			return ctrl.Result{}, err
+		}
+		log.Info("started Agent pre-flight check", "job", jobName, "image", cfg.AgentImage)
+		return ctrl.Result{}, r.setPreflightResult(ctx, agent, cfg.AgentImage, jobName, kubetaskv1alpha1.PreflightPhaseRunning,
+			"PreflightRunning", fmt.Sprintf("Pre-flight Job %s is running", jobName))
+	}
+
+	switch {
+	case job.Status.Succeeded > 0:
+		log.Info("Agent pre-flight check passed", "job", jobName)
+		return ctrl.Result{}, r.setPreflightResult(ctx, agent, cfg.AgentImage, jobName, kubetaskv1alpha1.PreflightPhasePassed,
+			"PreflightPassed", "Agent image satisfies the execution environment contract")
+	case job.Status.Failed > 0 || isJobFailed(job):
+		log.Info("Agent pre-flight check failed", "job", jobName)
+		return ctrl.Result{}, r.setPreflightResult(ctx, agent, cfg.AgentImage, jobName, kubetaskv1alpha1.PreflightPhaseFailed,
+			"PreflightFailed", fmt.Sprintf("Pre-flight Job %s failed, see its logs for the failing check", jobName))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// setPreflightResult records the pre-flight result in Agent status
+func (r *AgentReconciler) setPreflightResult(ctx context.Context, agent *kubetaskv1alpha1.Agent, image, jobName string, phase kubetaskv1alpha1.PreflightPhase, reason, message string) error {
+	status := &kubetaskv1alpha1.AgentPreflightStatus{
+		Phase:              phase,
+		ObservedGeneration: agent.Generation,
+		Image:              image,
+		JobName:            jobName,
+		Message:            message,
+	}
+	if phase != kubetaskv1alpha1.PreflightPhaseRunning {
+		now := metav1.NewTime(clockNow(r.Clock))
+		status.LastCheckTime = &now
+	}
+	agent.Status.Preflight = status
+
+	conditionStatus := metav1.ConditionFalse
+	if phase == kubetaskv1alpha1.PreflightPhasePassed {
+		conditionStatus = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&agent.Status.Conditions, metav1.Condition{
+		Type:               "PreflightPassed",
+		Status:             conditionStatus,
+		ObservedGeneration: agent.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+
+	return r.Status().Update(ctx, agent)
+}
+
+// checkAgentPreflight reports whether Tasks may run with the given Agent.
+// It returns errAgentPreflightPending while the check for the current spec is still
+// running, and a descriptive error if the check failed.
+func checkAgentPreflight(agent *kubetaskv1alpha1.Agent, image string) error {
+	if agent.Spec.Preflight == nil || !agent.Spec.Preflight.Enabled {
+		return nil
+	}
+
+	ps := agent.Status.Preflight
+	if ps == nil || ps.ObservedGeneration != agent.Generation || ps.Image != image {
+		return errAgentPreflightPending
+	}
+
+	switch ps.Phase {
+	case kubetaskv1alpha1.PreflightPhasePassed:
+		return nil
+	case kubetaskv1alpha1.PreflightPhaseFailed:
+		return fmt.Errorf("Agent %q failed its pre-flight check: %s", agent.Name, ps.Message)
+	default:
+		return errAgentPreflightPending
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubetaskv1alpha1.Agent{}).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(canaryJobToAgent)).
+		Watches(&rbacv1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(clusterRoleBindingToAgent)).
+		Complete(r)
+}
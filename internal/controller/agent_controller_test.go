@@ -0,0 +1,106 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build integration
+
+// See suite_test.go for explanation of the "integration" build tag pattern.
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+var _ = Describe("AgentController", func() {
+	const (
+		agentNamespace = "default"
+	)
+
+	Context("When creating an Agent with preflight enabled", func() {
+		It("Should hold Tasks until the pre-flight check passes", func() {
+			agentName := "test-agent-preflight"
+			taskName := "test-task-preflight"
+			description := "# Preflight test"
+
+			By("Creating Agent with preflight")
+			agent := &kubetaskv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      agentName,
+					Namespace: agentNamespace,
+				},
+				Spec: kubetaskv1alpha1.AgentSpec{
+					ServiceAccountName: "test-agent",
+					Preflight: &kubetaskv1alpha1.AgentPreflight{
+						Enabled:    true,
+						CLICommand: []string{"gemini", "--version"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, agent)).Should(Succeed())
+
+			By("Checking pre-flight Job is created")
+			agentLookupKey := types.NamespacedName{Name: agentName, Namespace: agentNamespace}
+			createdAgent := &kubetaskv1alpha1.Agent{}
+			Eventually(func() kubetaskv1alpha1.PreflightPhase {
+				if err := k8sClient.Get(ctx, agentLookupKey, createdAgent); err != nil || createdAgent.Status.Preflight == nil {
+					return ""
+				}
+				return createdAgent.Status.Preflight.Phase
+			}, timeout, interval).Should(Equal(kubetaskv1alpha1.PreflightPhaseRunning))
+
+			preflightJob := &batchv1.Job{}
+			preflightJobKey := types.NamespacedName{Name: createdAgent.Status.Preflight.JobName, Namespace: agentNamespace}
+			Expect(k8sClient.Get(ctx, preflightJobKey, preflightJob)).Should(Succeed())
+
+			By("Creating Task while pre-flight is running")
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      taskName,
+					Namespace: agentNamespace,
+				},
+				Spec: kubetaskv1alpha1.TaskSpec{
+					AgentRef:    agentName,
+					Description: &description,
+				},
+			}
+			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
+
+			taskLookupKey := types.NamespacedName{Name: taskName, Namespace: agentNamespace}
+			Eventually(func() kubetaskv1alpha1.TaskPhase {
+				updatedTask := &kubetaskv1alpha1.Task{}
+				if err := k8sClient.Get(ctx, taskLookupKey, updatedTask); err != nil {
+					return ""
+				}
+				return updatedTask.Status.Phase
+			}, timeout, interval).Should(Equal(kubetaskv1alpha1.TaskPhasePending))
+
+			By("Simulating pre-flight Job success")
+			preflightJob.Status.Succeeded = 1
+			Expect(k8sClient.Status().Update(ctx, preflightJob)).Should(Succeed())
+
+			Eventually(func() kubetaskv1alpha1.PreflightPhase {
+				if err := k8sClient.Get(ctx, agentLookupKey, createdAgent); err != nil || createdAgent.Status.Preflight == nil {
+					return ""
+				}
+				return createdAgent.Status.Preflight.Phase
+			}, timeout, interval).Should(Equal(kubetaskv1alpha1.PreflightPhasePassed))
+
+			By("Checking Task starts after pre-flight passes")
+			jobLookupKey := types.NamespacedName{Name: fmt.Sprintf("%s-job", taskName), Namespace: agentNamespace}
+			Eventually(func() bool {
+				return k8sClient.Get(ctx, jobLookupKey, &batchv1.Job{}) == nil
+			}, timeout*3, interval).Should(BeTrue())
+
+			By("Cleaning up")
+			Expect(k8sClient.Delete(ctx, task)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, agent)).Should(Succeed())
+		})
+	})
+})
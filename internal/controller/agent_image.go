@@ -0,0 +1,48 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// recordAgentImage records the image the agent container of the Pod runs, once the
+// container runtime has resolved it. It returns whether the status changed.
+func recordAgentImage(task *kubetaskv1alpha1.Task, pod *corev1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != agentContainerName(pod) || cs.ImageID == "" {
+			continue
+		}
+		image := &kubetaskv1alpha1.AgentImageStatus{
+			Image:   cs.Image,
+			ImageID: cs.ImageID,
+			Digest:  imageDigest(cs.ImageID),
+		}
+		if task.Status.AgentImage != nil && *task.Status.AgentImage == *image {
+			return false
+		}
+		task.Status.AgentImage = image
+		return true
+	}
+	return false
+}
+
+// imageDigest returns the sha256 digest of a container status imageID, such as
+// "quay.io/org/agent@sha256:..." (containerd) or "docker-pullable://org/agent@sha256:..."
+// (Docker), or "" if it has none
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		imageID = imageID[i+1:]
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+	return ""
+}
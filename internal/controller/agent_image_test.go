@@ -0,0 +1,67 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestImageDigest(t *testing.T) {
+	digest := "sha256:4bcd6bd3a1ea5bcf4e1f3ae0a3cbdf0f1d5e3d9e0c7b3c6a8d9f1e2a3b4c5d6e"
+	tests := map[string]string{
+		"quay.io/kubetask/kubetask-agent-claude@" + digest:                   digest,
+		"docker-pullable://quay.io/kubetask/kubetask-agent-claude@" + digest: digest,
+		digest:                     digest,
+		"docker://" + digest:       "",
+		"quay.io/kubetask/agent:1": "",
+		"":                         "",
+	}
+	for imageID, want := range tests {
+		if got := imageDigest(imageID); got != want {
+			t.Errorf("imageDigest(%q) = %q, want %q", imageID, got, want)
+		}
+	}
+}
+
+func TestRecordAgentImage(t *testing.T) {
+	pod := func(imageID string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "fix-job-abc"},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "sidecar", Image: "envoy:1", ImageID: "envoy@sha256:1111"},
+				{Name: DefaultAgentContainerName, Image: "quay.io/kubetask/agent:latest", ImageID: imageID},
+			}},
+		}
+	}
+	task := &kubetaskv1alpha1.Task{}
+
+	if recordAgentImage(task, nil) || recordAgentImage(task, pod("")) {
+		t.Fatal("recorded an image before the runtime resolved it")
+	}
+	if !recordAgentImage(task, pod("quay.io/kubetask/agent@sha256:aaaa")) {
+		t.Fatal("recordAgentImage() = false, want the image recorded")
+	}
+	want := kubetaskv1alpha1.AgentImageStatus{
+		Image:   "quay.io/kubetask/agent:latest",
+		ImageID: "quay.io/kubetask/agent@sha256:aaaa",
+		Digest:  "sha256:aaaa",
+	}
+	if *task.Status.AgentImage != want {
+		t.Errorf("AgentImage = %+v, want %+v", *task.Status.AgentImage, want)
+	}
+	if recordAgentImage(task, pod("quay.io/kubetask/agent@sha256:aaaa")) {
+		t.Error("recordAgentImage() = true for an unchanged image")
+	}
+
+	// A retried Pod pulled the tag after it moved
+	if !recordAgentImage(task, pod("quay.io/kubetask/agent@sha256:bbbb")) || task.Status.AgentImage.Digest != "sha256:bbbb" {
+		t.Errorf("AgentImage = %+v, want the digest of the retried Pod", task.Status.AgentImage)
+	}
+}
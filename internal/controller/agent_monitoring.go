@@ -0,0 +1,157 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// MonitoringReadyConditionType reports whether the PodMonitor of an Agent with
+// monitoring is in place
+const MonitoringReadyConditionType = "MonitoringReady"
+
+// podMonitorGVK is the Prometheus Operator's PodMonitor. It is handled as
+// unstructured, so the controller neither depends on the operator's API module
+// nor fails to start in clusters without it.
+var podMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;update;delete
+
+// podMonitorName returns the name of the PodMonitor of an Agent
+func podMonitorName(agent string) string {
+	return AgentRBACClusterRolePrefix + agent
+}
+
+// buildPodMonitor returns the PodMonitor scraping the metrics port of the Pods of
+// a monitored Agent. Samples are labeled with the Task and Agent of the Pod.
+func buildPodMonitor(agent *kubetaskv1alpha1.Agent) *unstructured.Unstructured {
+	monitoring := agent.Spec.Monitoring
+	endpoint := map[string]any{
+		"port": build.MetricsPortName,
+		"path": build.MetricsPath(monitoring),
+	}
+	if monitoring.Interval != "" {
+		endpoint["interval"] = monitoring.Interval
+	}
+
+	labels := map[string]string{}
+	maps.Copy(labels, monitoring.Labels)
+	maps.Copy(labels, agentRBACLabels(agent.Namespace, agent.Name))
+
+	podMonitor := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"selector": map[string]any{
+				"matchLabels": map[string]any{
+					"app":         "kubetask",
+					AgentLabelKey: agent.Name,
+				},
+			},
+			"podMetricsEndpoints": []any{endpoint},
+			"podTargetLabels":     []any{build.TaskLabelKey, AgentLabelKey},
+		},
+	}}
+	podMonitor.SetGroupVersionKind(podMonitorGVK)
+	podMonitor.SetName(podMonitorName(agent.Name))
+	podMonitor.SetNamespace(agent.Namespace)
+	podMonitor.SetLabels(labels)
+	podMonitor.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: kubetaskv1alpha1.GroupVersion.String(),
+		Kind:       "Agent",
+		Name:       agent.Name,
+		UID:        agent.UID,
+		Controller: boolPtr(true),
+	}})
+	return podMonitor
+}
+
+// reconcileMonitoring creates or updates the PodMonitor of an Agent with
+// monitoring, and deletes it once monitoring is disabled. It returns whether the
+// MonitoringReady condition changed. PodMonitors are not watched, so changes made
+// to them by hand are reverted on the Agent's next reconcile.
+func (r *AgentReconciler) reconcileMonitoring(ctx context.Context, agent *kubetaskv1alpha1.Agent) (bool, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(podMonitorGVK)
+	err := r.Get(ctx, types.NamespacedName{Name: podMonitorName(agent.Name), Namespace: agent.Namespace}, existing)
+	crdMissing := meta.IsNoMatchError(err)
+	if err != nil && !errors.IsNotFound(err) && !crdMissing {
+		return false, err
+	}
+	found := err == nil
+
+	if agent.Spec.Monitoring == nil || !agent.Spec.Monitoring.Enabled {
+		if found && metav1.IsControlledBy(existing, agent) {
+			if err := r.Delete(ctx, existing); err != nil && !errors.IsNotFound(err) {
+				return false, err
+			}
+			log.FromContext(ctx).Info("deleted Agent PodMonitor", "podMonitor", existing.GetName())
+		}
+		return meta.RemoveStatusCondition(&agent.Status.Conditions, MonitoringReadyConditionType), nil
+	}
+	if crdMissing {
+		return setMonitoringReadyCondition(agent, metav1.ConditionFalse, "PodMonitorCRDMissing",
+			"The PodMonitor CRD of the Prometheus Operator is not installed"), nil
+	}
+
+	desired := buildPodMonitor(agent)
+	switch {
+	case !found:
+		if err := r.Create(ctx, desired); err != nil {
+			return false, err
+		}
+		log.FromContext(ctx).Info("created Agent PodMonitor", "podMonitor", desired.GetName())
+	case !metav1.IsControlledBy(existing, agent):
+		return setMonitoringReadyCondition(agent, metav1.ConditionFalse, "PodMonitorConflict",
+			fmt.Sprintf("PodMonitor %q was not created for this Agent", existing.GetName())), nil
+	case !equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) ||
+		!maps.Equal(existing.GetLabels(), desired.GetLabels()):
+		existing.Object["spec"] = desired.Object["spec"]
+		existing.SetLabels(desired.GetLabels())
+		if err := r.Update(ctx, existing); err != nil {
+			return false, err
+		}
+	}
+	return setMonitoringReadyCondition(agent, metav1.ConditionTrue, "PodMonitorReady", fmt.Sprintf(
+		"PodMonitor %q scrapes port %d at %s of the Agent's Pods",
+		desired.GetName(), build.MetricsPort(agent.Spec.Monitoring), build.MetricsPath(agent.Spec.Monitoring))), nil
+}
+
+// allowMetricsIngress lets Prometheus reach the metrics port of a monitored
+// Agent's Pods through the NetworkPolicy of their security profile, which
+// otherwise denies all ingress
+func allowMetricsIngress(policy *networkingv1.NetworkPolicy, monitoring *kubetaskv1alpha1.AgentMonitoring) {
+	if monitoring == nil {
+		return
+	}
+	protocol, port := corev1.ProtocolTCP, intstr.FromInt32(build.MetricsPort(monitoring))
+	policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &port}},
+	})
+}
+
+// setMonitoringReadyCondition reports the state of the Agent's PodMonitor
+func setMonitoringReadyCondition(agent *kubetaskv1alpha1.Agent, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&agent.Status.Conditions, metav1.Condition{
+		Type:               MonitoringReadyConditionType,
+		Status:             status,
+		ObservedGeneration: agent.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
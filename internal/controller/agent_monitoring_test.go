@@ -0,0 +1,122 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestReconcileMonitoring(t *testing.T) {
+	port := int32(8000)
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team", UID: types.UID("agent-uid")},
+		Spec: kubetaskv1alpha1.AgentSpec{Monitoring: &kubetaskv1alpha1.AgentMonitoring{
+			Enabled: true, Port: &port, Interval: "30s", Labels: map[string]string{"release": "prometheus"},
+		}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(agent).Build()
+	r := &AgentReconciler{Client: c}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "kubetask-agent-claude", Namespace: "team"}
+	getPodMonitor := func() (*unstructured.Unstructured, error) {
+		podMonitor := &unstructured.Unstructured{}
+		podMonitor.SetGroupVersionKind(podMonitorGVK)
+		return podMonitor, c.Get(ctx, key, podMonitor)
+	}
+
+	if _, err := r.reconcileMonitoring(ctx, agent); err != nil {
+		t.Fatalf("reconcileMonitoring() error = %v", err)
+	}
+	podMonitor, err := getPodMonitor()
+	if err != nil {
+		t.Fatalf("PodMonitor not created: %v", err)
+	}
+	if podMonitor.GetLabels()["release"] != "prometheus" || !metav1.IsControlledBy(podMonitor, agent) {
+		t.Errorf("PodMonitor labels = %v, owners = %v, want the Agent's labels and owner", podMonitor.GetLabels(), podMonitor.GetOwnerReferences())
+	}
+	selector, _, _ := unstructured.NestedStringMap(podMonitor.Object, "spec", "selector", "matchLabels")
+	endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints")
+	if selector[AgentLabelKey] != "claude" || len(endpoints) != 1 {
+		t.Fatalf("PodMonitor spec = %v, want the Agent's Pods selected", podMonitor.Object["spec"])
+	}
+	if endpoint := endpoints[0].(map[string]any); endpoint["port"] != "metrics" || endpoint["path"] != "/metrics" || endpoint["interval"] != "30s" {
+		t.Errorf("endpoint = %v, want the metrics port scraped every 30s", endpoint)
+	}
+	ready := meta.FindStatusCondition(agent.Status.Conditions, MonitoringReadyConditionType)
+	if ready == nil || ready.Status != metav1.ConditionTrue || ready.Message != `PodMonitor "kubetask-agent-claude" scrapes port 8000 at /metrics of the Agent's Pods` {
+		t.Errorf("MonitoringReady = %+v, want True", ready)
+	}
+
+	// Spec changes are applied to the PodMonitor
+	agent.Spec.Monitoring.Path = "/stats"
+	if _, err := r.reconcileMonitoring(ctx, agent); err != nil {
+		t.Fatalf("reconcileMonitoring() error = %v", err)
+	}
+	if podMonitor, err = getPodMonitor(); err != nil {
+		t.Fatal(err)
+	}
+	if endpoints, _, _ := unstructured.NestedSlice(podMonitor.Object, "spec", "podMetricsEndpoints"); endpoints[0].(map[string]any)["path"] != "/stats" {
+		t.Errorf("endpoints = %v, want the new path", endpoints)
+	}
+
+	// Disabling monitoring deletes the PodMonitor
+	agent.Spec.Monitoring.Enabled = false
+	if changed, err := r.reconcileMonitoring(ctx, agent); err != nil || !changed {
+		t.Fatalf("reconcileMonitoring() = %v, %v, want the condition removed", changed, err)
+	}
+	if _, err := getPodMonitor(); !errors.IsNotFound(err) {
+		t.Errorf("PodMonitor after disabling: %v, want it deleted", err)
+	}
+}
+
+func TestReconcileMonitoring_CRDMissing(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team"},
+		Spec:       kubetaskv1alpha1.AgentSpec{Monitoring: &kubetaskv1alpha1.AgentMonitoring{Enabled: true}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(agent).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*unstructured.Unstructured); ok {
+				return &meta.NoKindMatchError{GroupKind: podMonitorGVK.GroupKind(), SearchedVersions: []string{"v1"}}
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	r := &AgentReconciler{Client: c}
+
+	if _, err := r.reconcileMonitoring(context.Background(), agent); err != nil {
+		t.Fatalf("reconcileMonitoring() error = %v", err)
+	}
+	ready := meta.FindStatusCondition(agent.Status.Conditions, MonitoringReadyConditionType)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "PodMonitorCRDMissing" {
+		t.Errorf("MonitoringReady = %+v, want PodMonitorCRDMissing", ready)
+	}
+}
+
+func TestAllowMetricsIngress(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"}}
+	policy := buildNetworkPolicy(task, kubetaskv1alpha1.SecurityProfileRestricted)
+	allowMetricsIngress(policy, nil)
+	if len(policy.Spec.Ingress) != 0 {
+		t.Fatalf("ingress = %v, want none without monitoring", policy.Spec.Ingress)
+	}
+
+	allowMetricsIngress(policy, &kubetaskv1alpha1.AgentMonitoring{Enabled: true})
+	if len(policy.Spec.Ingress) != 1 || len(policy.Spec.Ingress[0].Ports) != 1 || policy.Spec.Ingress[0].Ports[0].Port.IntValue() != 9090 {
+		t.Errorf("ingress = %+v, want the default metrics port allowed", policy.Spec.Ingress)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AgentRBACClusterRolePrefix prefixes the names of the preset ClusterRoles
+	// installed with the controller, e.g. kubetask-agent-read-only-cluster
+	AgentRBACClusterRolePrefix = "kubetask-agent-"
+
+	// AgentNamespaceLabelKey records the namespace of the Agent a cluster-scoped
+	// ClusterRoleBinding was created for
+	AgentNamespaceLabelKey = "kubetask.io/agent-namespace"
+
+	// ManagedByLabelKey and ManagedByLabelValue mark the ServiceAccounts and bindings
+	// the controller manages for Agents. Only objects with this label are cached.
+	ManagedByLabelKey   = "app.kubernetes.io/managed-by"
+	ManagedByLabelValue = "kubetask"
+
+	// RBACReadyConditionType reports whether the Agent's ServiceAccount and bindings are in place
+	RBACReadyConditionType = "RBACReady"
+)
+
+// rbacClusterScoped reports whether a preset is bound cluster-wide
+func rbacClusterScoped(preset kubetaskv1alpha1.AgentRBACPreset) bool {
+	return preset == kubetaskv1alpha1.AgentRBACPresetReadOnlyCluster
+}
+
+// agentRBACLabels returns the labels of the objects managed for an Agent
+func agentRBACLabels(namespace, name string) map[string]string {
+	return map[string]string{
+		ManagedByLabelKey:      ManagedByLabelValue,
+		AgentLabelKey:          name,
+		AgentNamespaceLabelKey: namespace,
+	}
+}
+
+// roleBindingName returns the name of the RoleBinding granting a namespace preset.
+// Agent names may contain dots but presets do not, so names are unique.
+func roleBindingName(agent *kubetaskv1alpha1.Agent, preset kubetaskv1alpha1.AgentRBACPreset) string {
+	return AgentRBACClusterRolePrefix + agent.Name + "." + string(preset)
+}
+
+// clusterRoleBindingName returns the name of the ClusterRoleBinding granting a
+// cluster preset. Namespaces cannot contain dots, so names are unique.
+func clusterRoleBindingName(agent *kubetaskv1alpha1.Agent, preset kubetaskv1alpha1.AgentRBACPreset) string {
+	return AgentRBACClusterRolePrefix + agent.Namespace + "." + agent.Name + "." + string(preset)
+}
+
+// reconcileRBAC creates the ServiceAccount and bindings requested by the Agent's
+// rbac and deletes those no longer requested. It returns whether the RBACReady
+// condition changed.
+func (r *AgentReconciler) reconcileRBAC(ctx context.Context, agent *kubetaskv1alpha1.Agent) (bool, error) {
+	if agent.Spec.RBAC == nil {
+		if err := r.deleteRBAC(ctx, agent.Namespace, agent.Name, nil); err != nil {
+			return false, err
+		}
+		if err := r.deleteServiceAccounts(ctx, agent); err != nil {
+			return false, err
+		}
+		return meta.RemoveStatusCondition(&agent.Status.Conditions, RBACReadyConditionType), nil
+	}
+
+	labels := agentRBACLabels(agent.Namespace, agent.Name)
+	owner := metav1.OwnerReference{
+		APIVersion: kubetaskv1alpha1.GroupVersion.String(),
+		Kind:       "Agent",
+		Name:       agent.Name,
+		UID:        agent.UID,
+		Controller: boolPtr(true),
+	}
+
+	sa := &corev1.ServiceAccount{}
+	err := r.Get(ctx, types.NamespacedName{Name: agent.Spec.ServiceAccountName, Namespace: agent.Namespace}, sa)
+	switch {
+	case errors.IsNotFound(err):
+		sa = &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name:            agent.Spec.ServiceAccountName,
+			Namespace:       agent.Namespace,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{owner},
+		}}
+		if err := r.Create(ctx, sa); err != nil {
+			// Only managed ServiceAccounts are cached, so a hand-made one shows up here
+			if errors.IsAlreadyExists(err) {
+				return setRBACReadyCondition(agent, "ServiceAccountConflict", fmt.Sprintf(
+					"ServiceAccount %q was not created for this Agent; choose another serviceAccountName or remove rbac",
+					agent.Spec.ServiceAccountName)), nil
+			}
+			return false, err
+		}
+		log.FromContext(ctx).Info("created Agent ServiceAccount", "serviceAccount", sa.Name)
+	case err != nil:
+		return false, err
+	case !metav1.IsControlledBy(sa, agent):
+		return setRBACReadyCondition(agent, "ServiceAccountConflict", fmt.Sprintf(
+			"ServiceAccount %q was not created for this Agent; choose another serviceAccountName or remove rbac", sa.Name)), nil
+	}
+	// serviceAccountName may have changed
+	if err := r.deleteServiceAccounts(ctx, agent); err != nil {
+		return false, err
+	}
+
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: sa.Name, Namespace: agent.Namespace}}
+	var keep []string
+	for _, preset := range agent.Spec.RBAC.Presets {
+		roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: AgentRBACClusterRolePrefix + string(preset)}
+		if rbacClusterScoped(preset) {
+			binding := &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRoleBindingName(agent, preset), Labels: labels},
+				RoleRef:    roleRef,
+				Subjects:   subjects,
+			}
+			if err := r.applyBinding(ctx, binding, &rbacv1.ClusterRoleBinding{}); err != nil {
+				return false, err
+			}
+			keep = append(keep, binding.Name)
+			continue
+		}
+		binding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            roleBindingName(agent, preset),
+				Namespace:       agent.Namespace,
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{owner},
+			},
+			RoleRef:  roleRef,
+			Subjects: subjects,
+		}
+		if err := r.applyBinding(ctx, binding, &rbacv1.RoleBinding{}); err != nil {
+			return false, err
+		}
+		keep = append(keep, binding.Name)
+	}
+	if err := r.deleteRBAC(ctx, agent.Namespace, agent.Name, keep); err != nil {
+		return false, err
+	}
+
+	presets := make([]string, 0, len(agent.Spec.RBAC.Presets))
+	for _, preset := range agent.Spec.RBAC.Presets {
+		presets = append(presets, string(preset))
+	}
+	message := fmt.Sprintf("ServiceAccount %q has no preset permissions", sa.Name)
+	if len(presets) > 0 {
+		message = fmt.Sprintf("ServiceAccount %q is bound to %s", sa.Name, strings.Join(presets, ", "))
+	}
+	return meta.SetStatusCondition(&agent.Status.Conditions, metav1.Condition{
+		Type:               RBACReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: agent.Generation,
+		Reason:             "Bound",
+		Message:            message,
+	}), nil
+}
+
+// setRBACReadyCondition reports that the Agent's RBAC could not be set up
+func setRBACReadyCondition(agent *kubetaskv1alpha1.Agent, reason, message string) bool {
+	return meta.SetStatusCondition(&agent.Status.Conditions, metav1.Condition{
+		Type:               RBACReadyConditionType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: agent.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// applyBinding creates a RoleBinding or ClusterRoleBinding, or updates its
+// subjects. The roleRef of a binding is immutable, but it is derived from the
+// binding's name and never changes.
+func (r *AgentReconciler) applyBinding(ctx context.Context, desired, existing client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		return r.Create(ctx, desired)
+	}
+
+	var same bool
+	switch d := desired.(type) {
+	case *rbacv1.RoleBinding:
+		e := existing.(*rbacv1.RoleBinding)
+		same = equality.Semantic.DeepEqual(e.Subjects, d.Subjects)
+		e.Subjects = d.Subjects
+	case *rbacv1.ClusterRoleBinding:
+		e := existing.(*rbacv1.ClusterRoleBinding)
+		same = equality.Semantic.DeepEqual(e.Subjects, d.Subjects)
+		e.Subjects = d.Subjects
+	}
+	if same {
+		return nil
+	}
+	return r.Update(ctx, existing)
+}
+
+// deleteRBAC deletes the bindings managed for an Agent, except those named in keep
+func (r *AgentReconciler) deleteRBAC(ctx context.Context, namespace, name string, keep []string) error {
+	selector := client.MatchingLabels(agentRBACLabels(namespace, name))
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := r.List(ctx, roleBindings, client.InNamespace(namespace), selector); err != nil {
+		return err
+	}
+	for i := range roleBindings.Items {
+		if !slices.Contains(keep, roleBindings.Items[i].Name) {
+			if err := r.Delete(ctx, &roleBindings.Items[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := r.List(ctx, clusterRoleBindings, selector); err != nil {
+		return err
+	}
+	for i := range clusterRoleBindings.Items {
+		if !slices.Contains(keep, clusterRoleBindings.Items[i].Name) {
+			if err := r.Delete(ctx, &clusterRoleBindings.Items[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteServiceAccounts deletes the ServiceAccounts created for the Agent other
+// than the one it currently uses with rbac
+func (r *AgentReconciler) deleteServiceAccounts(ctx context.Context, agent *kubetaskv1alpha1.Agent) error {
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := r.List(ctx, serviceAccounts, client.InNamespace(agent.Namespace),
+		client.MatchingLabels(agentRBACLabels(agent.Namespace, agent.Name))); err != nil {
+		return err
+	}
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		if !metav1.IsControlledBy(sa, agent) || (agent.Spec.RBAC != nil && sa.Name == agent.Spec.ServiceAccountName) {
+			continue
+		}
+		if err := r.Delete(ctx, sa); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clusterRoleBindingToAgent enqueues the Agent a managed ClusterRoleBinding was
+// created for, so bindings of deleted Agents are removed, including Agents deleted
+// while the controller was down. Namespaced objects are garbage collected through
+// their owner reference instead.
+func clusterRoleBindingToAgent(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels[ManagedByLabelKey] != ManagedByLabelValue || labels[AgentLabelKey] == "" || labels[AgentNamespaceLabelKey] == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: labels[AgentLabelKey], Namespace: labels[AgentNamespaceLabelKey]},
+	}}
+}
@@ -0,0 +1,149 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestReconcileRBAC(t *testing.T) {
+	scheme := newTestScheme(t)
+	if err := rbacv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "team", UID: types.UID("agent-uid")},
+		Spec: kubetaskv1alpha1.AgentSpec{
+			ServiceAccountName: "deploy-bot",
+			RBAC: &kubetaskv1alpha1.AgentRBAC{Presets: []kubetaskv1alpha1.AgentRBACPreset{
+				kubetaskv1alpha1.AgentRBACPresetReadOnlyCluster,
+				kubetaskv1alpha1.AgentRBACPresetDeployNamespace,
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		agent,
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "hand-made", Namespace: "team"}},
+	).WithStatusSubresource(agent).Build()
+	r := &AgentReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+
+	exists := func(obj client.Object, key types.NamespacedName) bool {
+		t.Helper()
+		if err := c.Get(ctx, key, obj); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				t.Fatal(err)
+			}
+			return false
+		}
+		return true
+	}
+	crbKey := types.NamespacedName{Name: "kubetask-agent-team.deployer.read-only-cluster"}
+	deployKey := types.NamespacedName{Name: "kubetask-agent-deployer.deploy-namespace", Namespace: "team"}
+	readKey := types.NamespacedName{Name: "kubetask-agent-deployer.read-only-namespace", Namespace: "team"}
+	saKey := types.NamespacedName{Name: "deploy-bot", Namespace: "team"}
+
+	// Creates the ServiceAccount and a binding per preset
+	if _, err := r.reconcileRBAC(ctx, agent); err != nil {
+		t.Fatalf("reconcileRBAC() error = %v", err)
+	}
+	sa := &corev1.ServiceAccount{}
+	if !exists(sa, saKey) || !metav1.IsControlledBy(sa, agent) {
+		t.Fatalf("ServiceAccount deploy-bot should be created and owned by the Agent")
+	}
+	crb := &rbacv1.ClusterRoleBinding{}
+	if !exists(crb, crbKey) {
+		t.Fatalf("ClusterRoleBinding %s not found", crbKey.Name)
+	}
+	if crb.RoleRef.Name != "kubetask-agent-read-only-cluster" || len(crb.Subjects) != 1 || crb.Subjects[0].Name != "deploy-bot" {
+		t.Errorf("ClusterRoleBinding = %+v, want read-only-cluster bound to deploy-bot", crb)
+	}
+	if !exists(&rbacv1.RoleBinding{}, deployKey) {
+		t.Errorf("RoleBinding %s not found", deployKey.Name)
+	}
+	if !meta.IsStatusConditionTrue(agent.Status.Conditions, RBACReadyConditionType) {
+		t.Errorf("RBACReady should be True, conditions = %+v", agent.Status.Conditions)
+	}
+
+	// Bindings of removed presets are deleted
+	agent.Spec.RBAC.Presets = []kubetaskv1alpha1.AgentRBACPreset{kubetaskv1alpha1.AgentRBACPresetReadOnlyNamespace}
+	if _, err := r.reconcileRBAC(ctx, agent); err != nil {
+		t.Fatalf("reconcileRBAC() error = %v", err)
+	}
+	if exists(&rbacv1.ClusterRoleBinding{}, crbKey) || exists(&rbacv1.RoleBinding{}, deployKey) {
+		t.Errorf("bindings of removed presets should be deleted")
+	}
+	if !exists(&rbacv1.RoleBinding{}, readKey) {
+		t.Errorf("RoleBinding %s not found", readKey.Name)
+	}
+
+	// A ServiceAccount not created for the Agent is not taken over
+	agent.Spec.ServiceAccountName = "hand-made"
+	if _, err := r.reconcileRBAC(ctx, agent); err != nil {
+		t.Fatalf("reconcileRBAC() error = %v", err)
+	}
+	condition := meta.FindStatusCondition(agent.Status.Conditions, RBACReadyConditionType)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "ServiceAccountConflict" {
+		t.Errorf("RBACReady = %+v, want False with reason ServiceAccountConflict", condition)
+	}
+
+	// Removing rbac deletes everything created for the Agent
+	agent.Spec.ServiceAccountName = "deploy-bot"
+	agent.Spec.RBAC = nil
+	if _, err := r.reconcileRBAC(ctx, agent); err != nil {
+		t.Fatalf("reconcileRBAC() error = %v", err)
+	}
+	if exists(&corev1.ServiceAccount{}, saKey) || exists(&rbacv1.RoleBinding{}, readKey) {
+		t.Errorf("ServiceAccount and bindings should be deleted when rbac is removed")
+	}
+	if !exists(&corev1.ServiceAccount{}, types.NamespacedName{Name: "hand-made", Namespace: "team"}) {
+		t.Errorf("hand-made ServiceAccount should be kept")
+	}
+	if meta.FindStatusCondition(agent.Status.Conditions, RBACReadyConditionType) != nil {
+		t.Errorf("RBACReady should be removed")
+	}
+}
+
+func TestAgentDeletionRemovesClusterRoleBindings(t *testing.T) {
+	scheme := newTestScheme(t)
+	if err := rbacv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:   "kubetask-agent-team.gone.read-only-cluster",
+		Labels: agentRBACLabels("team", "gone"),
+	}}
+	other := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:   "kubetask-agent-other.gone.read-only-cluster",
+		Labels: agentRBACLabels("other", "gone"),
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(binding, other).Build()
+	r := &AgentReconciler{Client: c, Scheme: scheme}
+
+	requests := clusterRoleBindingToAgent(context.Background(), binding)
+	if len(requests) != 1 || requests[0].NamespacedName != (types.NamespacedName{Name: "gone", Namespace: "team"}) {
+		t.Fatalf("clusterRoleBindingToAgent() = %v, want team/gone", requests)
+	}
+	if _, err := r.Reconcile(context.Background(), requests[0]); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(binding), &rbacv1.ClusterRoleBinding{}); client.IgnoreNotFound(err) != nil || err == nil {
+		t.Errorf("ClusterRoleBinding of the deleted Agent should be removed, err = %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(other), &rbacv1.ClusterRoleBinding{}); err != nil {
+		t.Errorf("ClusterRoleBinding of another Agent should be kept, err = %v", err)
+	}
+}
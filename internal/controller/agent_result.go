@@ -0,0 +1,182 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// Agents report results as "Key: value" trailer lines in the agent container's
+// termination message (/dev/termination-log). Repeat a key to report several values.
+const (
+	// TrailerPullRequest reports the URL of a pull request the agent created or updated
+	TrailerPullRequest = "KubeTask-Pull-Request"
+
+	// TrailerCommit reports the SHA of a commit the agent pushed
+	TrailerCommit = "KubeTask-Commit"
+
+	// TrailerChangedFile reports a repository path the agent modified
+	TrailerChangedFile = "KubeTask-Changed-File"
+
+	// TrailerOutput reports a named output of the Task as "name=value"
+	TrailerOutput = "KubeTask-Output"
+
+	// TrailerSnapshot reports the path of a failure snapshot within the snapshot claim.
+	// It is written by the command wrapper, not by agents.
+	TrailerSnapshot = build.TrailerSnapshot
+)
+
+// commitSHAPattern matches abbreviated or full SHA-1 and SHA-256 commit IDs
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// outputNamePattern matches the names of Task outputs
+var outputNamePattern = regexp.MustCompile(`^[A-Za-z0-9][-._A-Za-z0-9]{0,62}$`)
+
+// agentTerminationMessage returns the termination message of the agent container, if it terminated
+func agentTerminationMessage(pod *corev1.Pod) string {
+	if pod == nil {
+		return ""
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == agentContainerName(pod) && cs.State.Terminated != nil {
+			return cs.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+// taskResult builds the Task result from the terminated agent container of pod, or nil
+// if it has not terminated. Outputs with invalid names are dropped; when an output is
+// reported several times, the last value wins.
+func taskResult(pod *corev1.Pod) *kubetaskv1alpha1.TaskResult {
+	if pod == nil {
+		return nil
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != agentContainerName(pod) || cs.State.Terminated == nil {
+			continue
+		}
+		terminated := cs.State.Terminated
+		exitCode := terminated.ExitCode
+		result := &kubetaskv1alpha1.TaskResult{
+			Summary:  stripTrailers(terminated.Message),
+			ExitCode: &exitCode,
+		}
+		for _, v := range parseTrailers(terminated.Message)[TrailerOutput] {
+			name, value, ok := strings.Cut(v, "=")
+			if !ok || !outputNamePattern.MatchString(name) {
+				continue
+			}
+			if result.Outputs == nil {
+				result.Outputs = map[string]string{}
+			}
+			result.Outputs[name] = value
+		}
+		return result
+	}
+	return nil
+}
+
+// isTrailer reports whether a termination message line is a KubeTask trailer
+func parseTrailer(line string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(strings.TrimSpace(line), ": ")
+	if !ok || !strings.HasPrefix(key, "KubeTask-") {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+// stripTrailers removes trailer lines from a termination message, leaving the human-readable part
+func stripTrailers(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if _, _, ok := parseTrailer(line); !ok {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// parseTrailers extracts "Key: value" lines from a termination message.
+// Lines that are not trailers and duplicate values are ignored.
+func parseTrailers(message string) map[string][]string {
+	trailers := map[string][]string{}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(message, "\n") {
+		key, value, ok := parseTrailer(line)
+		if !ok || value == "" || seen[key+"\x00"+value] {
+			continue
+		}
+		seen[key+"\x00"+value] = true
+		trailers[key] = append(trailers[key], value)
+	}
+	return trailers
+}
+
+// taskChanges builds the Task changes from the agent's trailers, or nil if none were reported.
+// Malformed pull request URLs and commit SHAs are dropped.
+func taskChanges(trailers map[string][]string) *kubetaskv1alpha1.TaskChanges {
+	changes := &kubetaskv1alpha1.TaskChanges{}
+	for _, v := range trailers[TrailerPullRequest] {
+		if u, err := url.Parse(v); err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != "" {
+			changes.PullRequests = append(changes.PullRequests, v)
+		}
+	}
+	for _, v := range trailers[TrailerCommit] {
+		if v = strings.ToLower(v); commitSHAPattern.MatchString(v) {
+			changes.Commits = append(changes.Commits, v)
+		}
+	}
+	changes.ChangedFiles = append(changes.ChangedFiles, trailers[TrailerChangedFile]...)
+
+	if len(changes.PullRequests) == 0 && len(changes.Commits) == 0 && len(changes.ChangedFiles) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// ReportedChanges builds the Task changes an agent pushed to the result API, applying the
+// same validation as trailers, or nil if none were reported
+func ReportedChanges(pullRequests, commits, changedFiles []string) *kubetaskv1alpha1.TaskChanges {
+	return taskChanges(map[string][]string{
+		TrailerPullRequest: pullRequests,
+		TrailerCommit:      commits,
+		TrailerChangedFile: changedFiles,
+	})
+}
+
+// MergeTaskChanges adds the changes in b to a, dropping duplicates. Agents may push
+// changes while running and report them again in the termination message.
+func MergeTaskChanges(a, b *kubetaskv1alpha1.TaskChanges) *kubetaskv1alpha1.TaskChanges {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	merge := func(values, more []string) []string {
+		seen := make(map[string]bool, len(values))
+		for _, v := range values {
+			seen[v] = true
+		}
+		for _, v := range more {
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+	merged := a.DeepCopy()
+	merged.PullRequests = merge(merged.PullRequests, b.PullRequests)
+	merged.Commits = merge(merged.Commits, b.Commits)
+	merged.ChangedFiles = merge(merged.ChangedFiles, b.ChangedFiles)
+	return merged
+}
@@ -0,0 +1,183 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/agentsdk"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestTaskChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *kubetaskv1alpha1.TaskChanges
+	}{
+		{
+			name:    "no trailers",
+			message: "agent exited",
+		},
+		{
+			name: "all trailers",
+			message: `Opened a pull request
+KubeTask-Pull-Request: https://github.com/kubetask/kubetask/pull/42
+KubeTask-Commit: 3F2A9C1D
+KubeTask-Changed-File: go.mod
+KubeTask-Changed-File: go.sum
+KubeTask-Changed-File: go.mod
+`,
+			want: &kubetaskv1alpha1.TaskChanges{
+				PullRequests: []string{"https://github.com/kubetask/kubetask/pull/42"},
+				Commits:      []string{"3f2a9c1d"},
+				ChangedFiles: []string{"go.mod", "go.sum"},
+			},
+		},
+		{
+			name: "malformed values dropped",
+			message: `KubeTask-Pull-Request: not a url
+KubeTask-Commit: main
+KubeTask-Changed-File: README.md`,
+			want: &kubetaskv1alpha1.TaskChanges{
+				ChangedFiles: []string{"README.md"},
+			},
+		},
+		{
+			name:    "snapshot trailer is not a change",
+			message: "KubeTask-Snapshot: task/uid.tar.gz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskChanges(parseTrailers(tt.message))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskChanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentPodCondition_StripsTrailers(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: DefaultAgentContainerName,
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   "Error",
+						Message:  "tests failed\nKubeTask-Commit: 3f2a9c1d\n",
+					},
+				},
+			}},
+		},
+	}
+
+	cond := agentPodCondition(pod)
+	want := "container agent terminated with exit code 1: tests failed"
+	if cond.Message != want {
+		t.Errorf("agentPodCondition().Message = %q, want %q", cond.Message, want)
+	}
+}
+
+func TestTaskResult(t *testing.T) {
+	terminated := func(exitCode int32, message string) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  DefaultAgentContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Message: message}},
+		}}}}
+	}
+	exitCode := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want *kubetaskv1alpha1.TaskResult
+	}{
+		{name: "no Pod"},
+		{
+			name: "agent still running",
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: DefaultAgentContainerName, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}}}},
+		},
+		{
+			name: "summary and outputs",
+			pod: terminated(0, `Bumped 3 dependencies
+KubeTask-Commit: 3f2a9c1d
+KubeTask-Output: version=1.2.0
+KubeTask-Output: report=https://ci.example.com/runs/7?a=b
+KubeTask-Output: version=1.2.1
+`),
+			want: &kubetaskv1alpha1.TaskResult{
+				Summary:  "Bumped 3 dependencies",
+				ExitCode: exitCode(0),
+				Outputs:  map[string]string{"version": "1.2.1", "report": "https://ci.example.com/runs/7?a=b"},
+			},
+		},
+		{
+			name: "invalid outputs dropped",
+			pod:  terminated(2, "KubeTask-Output: no value\nKubeTask-Output: bad name=1\nKubeTask-Output: empty="),
+			want: &kubetaskv1alpha1.TaskResult{
+				ExitCode: exitCode(2),
+				Outputs:  map[string]string{"empty": ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskResult(tt.pod); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeTaskChanges(t *testing.T) {
+	pushed := ReportedChanges([]string{"https://github.com/org/repo/pull/1", "not-a-url"}, []string{"ABC1234"}, nil)
+	reported := &kubetaskv1alpha1.TaskChanges{
+		PullRequests: []string{"https://github.com/org/repo/pull/1"},
+		Commits:      []string{"def5678"},
+		ChangedFiles: []string{"main.go"},
+	}
+
+	want := &kubetaskv1alpha1.TaskChanges{
+		PullRequests: []string{"https://github.com/org/repo/pull/1"},
+		Commits:      []string{"abc1234", "def5678"},
+		ChangedFiles: []string{"main.go"},
+	}
+	if got := MergeTaskChanges(pushed, reported); !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeTaskChanges() = %+v, want %+v", got, want)
+	}
+	if got := MergeTaskChanges(nil, reported); got != reported {
+		t.Errorf("MergeTaskChanges(nil, b) = %+v, want b", got)
+	}
+	if got := MergeTaskChanges(pushed, nil); got != pushed {
+		t.Errorf("MergeTaskChanges(a, nil) = %+v, want a", got)
+	}
+}
+
+// The agent SDK cannot import the controller, so it repeats its conventions
+func TestAgentSDKConventions(t *testing.T) {
+	for sdk, controller := range map[string]string{
+		agentsdk.TrailerPullRequest:     TrailerPullRequest,
+		agentsdk.TrailerCommit:          TrailerCommit,
+		agentsdk.TrailerChangedFile:     TrailerChangedFile,
+		agentsdk.TrailerOutput:          TrailerOutput,
+		agentsdk.ContextUpdatedFileName: build.ContextRefreshSentinel,
+		agentsdk.CancellationFileName:   build.CancellationFile,
+		agentsdk.DefaultWorkspaceDir:    DefaultWorkspaceDir,
+	} {
+		if sdk != controller {
+			t.Errorf("agentsdk uses %q, the controller %q", sdk, controller)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+// agentRetention returns the retention settings of a Task's Agent, or nil if the
+// Agent sets none or no longer exists
+func (r *TaskReconciler) agentRetention(ctx context.Context, task *kubetaskv1alpha1.Task) (*kubetaskv1alpha1.AgentRetention, error) {
+	agent := &kubetaskv1alpha1.Agent{}
+	if err := r.Get(ctx, types.NamespacedName{Name: taskAgentName(task), Namespace: task.Namespace}, agent); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return agent.Spec.Retention, nil
+}
+
+// enforceAgentHistoryLimits deletes the oldest finished Tasks of the Agent of task,
+// in task's phase, beyond the Agent's history limit for that phase. It reports
+// whether task itself was deleted.
+func (r *TaskReconciler) enforceAgentHistoryLimits(ctx context.Context, task *kubetaskv1alpha1.Task, retention *kubetaskv1alpha1.AgentRetention) (bool, error) {
+	log := log.FromContext(ctx)
+
+	var limit *int32
+	switch {
+	case retention == nil:
+	case task.Status.Phase == kubetaskv1alpha1.TaskPhaseCompleted:
+		limit = retention.SuccessfulTasksHistoryLimit
+	case task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed:
+		limit = retention.FailedTasksHistoryLimit
+	}
+	if limit == nil {
+		return false, nil
+	}
+
+	taskList := &kubetaskv1alpha1.TaskList{}
+	if err := r.List(ctx, taskList, client.InNamespace(task.Namespace)); err != nil {
+		return false, err
+	}
+	agentName := taskAgentName(task)
+	var history []*kubetaskv1alpha1.Task
+	for i := range taskList.Items {
+		t := &taskList.Items[i]
+		if t.Status.Phase != task.Status.Phase || taskAgentName(t) != agentName || !t.DeletionTimestamp.IsZero() || isComparison(t) {
+			continue
+		}
+		if _, ok := t.Annotations[ExternalRunAnnotation]; ok {
+			continue
+		}
+		history = append(history, t)
+	}
+	if len(history) <= int(*limit) {
+		return false, nil
+	}
+
+	// Oldest first
+	sort.Slice(history, func(i, j int) bool {
+		if !history[i].CreationTimestamp.Equal(&history[j].CreationTimestamp) {
+			return history[i].CreationTimestamp.Before(&history[j].CreationTimestamp)
+		}
+		return history[i].Name < history[j].Name
+	})
+	deletedSelf := false
+	for _, old := range history[:len(history)-int(*limit)] {
+		// Keep the outcome of Tasks deleted before their TTL, like those deleted by it
+		if err := r.recordResult(ctx, old); err != nil {
+			return deletedSelf, err
+		}
+		log.V(1).Info("deleting task exceeding the history limit of its Agent", "task", old.Name, "agent", agentName, "limit", *limit)
+		err := r.Delete(ctx, old, client.Preconditions{UID: &old.UID})
+		if errors.IsNotFound(err) || errors.IsConflict(err) {
+			continue
+		}
+		taskCleanupDeletions.WithLabelValues(old.Namespace, cleanupReasonHistory, cleanupResult(err)).Inc()
+		if err != nil {
+			return deletedSelf, err
+		}
+		entry := audit.ForTask(old, audit.ActionDeleted, audit.ControllerActor)
+		entry.Message = "exceeded the history limit of Agent " + agentName
+		audit.Record(entry)
+		if old.UID == task.UID {
+			deletedSelf = true
+		}
+	}
+	return deletedSelf, nil
+}
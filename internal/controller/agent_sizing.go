@@ -0,0 +1,340 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+const (
+	// SizingSampleInterval is how often the usage of running agent containers is sampled
+	SizingSampleInterval = time.Minute
+
+	// DefaultSizingPercentile is the percentile of peak usage recommended by default
+	DefaultSizingPercentile int32 = 90
+
+	// minSizingSamples is the number of finished Tasks with usage samples needed
+	// before a recommendation is published
+	minSizingSamples = 5
+
+	// sizingMarginPercent is added to the percentile of peak usage
+	sizingMarginPercent = 15
+)
+
+// sizedResources are the resources recommendations are made for
+var sizedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// podMetricsListGVK is the kind listed from the metrics API
+var podMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// PodMetricsLister returns the current usage of the containers of agent Pods in a
+// namespace, by Pod name and container name
+type PodMetricsLister interface {
+	ListPodMetrics(ctx context.Context, namespace string) (map[string]map[string]corev1.ResourceList, error)
+}
+
+// SizingRecommender periodically samples the usage of the agent containers of Agents
+// with spec.sizing into their Tasks' status.peakUsage, and publishes the recommended
+// requests computed from the peaks of finished Tasks in the Agent's status.sizing
+type SizingRecommender struct {
+	client.Client
+
+	// Metrics reports Pod usage. Defaults to reading the metrics.k8s.io API.
+	Metrics PodMetricsLister
+
+	// Interval between samples. Defaults to SizingSampleInterval.
+	Interval time.Duration
+
+	// Clock is used for status timestamps. Defaults to the real clock.
+	Clock Clock
+}
+
+var _ manager.LeaderElectionRunnable = &SizingRecommender{}
+
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// NeedLeaderElection returns true so only one replica updates Tasks and Agents
+func (s *SizingRecommender) NeedLeaderElection() bool {
+	return true
+}
+
+// Start samples until the context is cancelled
+func (s *SizingRecommender) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("sizing")
+
+	interval := s.Interval
+	if interval <= 0 {
+		interval = SizingSampleInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.Sweep(ctx); err != nil {
+				log.Error(err, "unable to update sizing recommendations")
+			}
+		}
+	}
+}
+
+// sizedAgent is an Agent with spec.sizing and its Tasks
+type sizedAgent struct {
+	agent    *kubetaskv1alpha1.Agent
+	running  []*kubetaskv1alpha1.Task
+	finished []corev1.ResourceList
+}
+
+// Sweep samples the usage of running Tasks and updates the recommendations
+func (s *SizingRecommender) Sweep(ctx context.Context) error {
+	log := log.FromContext(ctx)
+
+	agentList := &kubetaskv1alpha1.AgentList{}
+	if err := s.List(ctx, agentList); err != nil {
+		return err
+	}
+	agents := map[types.NamespacedName]*sizedAgent{}
+	for i := range agentList.Items {
+		agent := &agentList.Items[i]
+		if agent.Spec.Sizing != nil {
+			agents[client.ObjectKeyFromObject(agent)] = &sizedAgent{agent: agent}
+		}
+	}
+	recommendedRequests.Reset()
+	if len(agents) == 0 {
+		return nil
+	}
+
+	taskList := &kubetaskv1alpha1.TaskList{}
+	if err := s.List(ctx, taskList); err != nil {
+		return err
+	}
+	namespaces := map[string]bool{}
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		group := agents[types.NamespacedName{Namespace: task.Namespace, Name: taskAgentName(task)}]
+		if group == nil || isComparison(task) {
+			continue
+		}
+		switch task.Status.Phase {
+		case kubetaskv1alpha1.TaskPhaseRunning:
+			if task.Status.PodName != "" {
+				group.running = append(group.running, task)
+				namespaces[task.Namespace] = true
+			}
+		case kubetaskv1alpha1.TaskPhaseCompleted, kubetaskv1alpha1.TaskPhaseFailed:
+			if len(task.Status.PeakUsage) > 0 {
+				group.finished = append(group.finished, task.Status.PeakUsage)
+			}
+		}
+	}
+
+	// Record the peak usage of running Tasks
+	usage := map[string]map[string]map[string]corev1.ResourceList{}
+	for namespace := range namespaces {
+		metrics, err := s.metrics().ListPodMetrics(ctx, namespace)
+		if err != nil {
+			// Without the metrics API, recommendations are still kept up to date
+			log.Error(err, "unable to read Pod metrics", "namespace", namespace)
+			continue
+		}
+		usage[namespace] = metrics
+	}
+	for _, group := range agents {
+		containerName := group.agent.Spec.ContainerName
+		if containerName == "" {
+			containerName = build.DefaultAgentContainerName
+		}
+		for _, task := range group.running {
+			current, ok := usage[task.Namespace][task.Status.PodName][containerName]
+			if !ok {
+				continue
+			}
+			peak, changed := peakUsage(task.Status.PeakUsage, current)
+			if !changed {
+				continue
+			}
+			task.Status.PeakUsage = peak
+			if err := s.Status().Update(ctx, task); err != nil {
+				// Conflicts resolve themselves on the next sample
+				log.Error(err, "unable to record peak usage", "task", task.Name, "namespace", task.Namespace)
+			}
+		}
+	}
+
+	// Publish the recommendations
+	for key, group := range agents {
+		percentile := DefaultSizingPercentile
+		if group.agent.Spec.Sizing.Percentile != nil {
+			percentile = *group.agent.Spec.Sizing.Percentile
+		}
+		recommended, ok := recommendRequests(group.finished, percentile)
+		if !ok {
+			continue
+		}
+		for name, quantity := range recommended {
+			recommendedRequests.WithLabelValues(key.Namespace, key.Name, string(name)).Set(quantity.AsApproximateFloat64())
+		}
+		if err := s.updateSizingStatus(ctx, group.agent, recommended, len(group.finished)); err != nil {
+			log.Error(err, "unable to update sizing status", "agent", key.Name, "namespace", key.Namespace)
+		}
+	}
+	return nil
+}
+
+// updateSizingStatus records the recommendation in the Agent's status if it changed
+func (s *SizingRecommender) updateSizingStatus(ctx context.Context, agent *kubetaskv1alpha1.Agent, recommended corev1.ResourceList, samples int) error {
+	status := agent.Status.Sizing
+	if status != nil && equality.Semantic.DeepEqual(status.Recommended, recommended) && status.Samples == int32(samples) {
+		return nil
+	}
+	lastUpdateTime := metav1.NewTime(clockNow(s.Clock))
+	if status != nil && equality.Semantic.DeepEqual(status.Recommended, recommended) {
+		lastUpdateTime = *status.LastUpdateTime
+	}
+	agent.Status.Sizing = &kubetaskv1alpha1.AgentSizingStatus{
+		Recommended:    recommended,
+		Samples:        int32(samples),
+		LastUpdateTime: &lastUpdateTime,
+	}
+	return s.Status().Update(ctx, agent)
+}
+
+func (s *SizingRecommender) metrics() PodMetricsLister {
+	if s.Metrics != nil {
+		return s.Metrics
+	}
+	return &apiPodMetrics{Reader: s.Client}
+}
+
+// peakUsage returns the per-resource maximum of peak and current, and whether it
+// differs from peak
+func peakUsage(peak, current corev1.ResourceList) (corev1.ResourceList, bool) {
+	result := peak.DeepCopy()
+	if result == nil {
+		result = corev1.ResourceList{}
+	}
+	changed := false
+	for _, name := range sizedResources {
+		quantity, ok := current[name]
+		if !ok {
+			continue
+		}
+		if existing, ok := result[name]; !ok || quantity.Cmp(existing) > 0 {
+			result[name] = quantity
+			changed = true
+		}
+	}
+	return result, changed
+}
+
+// recommendRequests returns the nearest-rank percentile of the peak usage of each
+// resource plus the margin, rounded up to whole millicores and mebibytes. ok is false
+// with fewer than minSizingSamples peaks.
+func recommendRequests(peaks []corev1.ResourceList, percentile int32) (corev1.ResourceList, bool) {
+	if len(peaks) < minSizingSamples {
+		return nil, false
+	}
+	recommended := corev1.ResourceList{}
+	for _, name := range sizedResources {
+		var values []int64
+		for _, peak := range peaks {
+			if quantity, ok := peak[name]; ok {
+				if name == corev1.ResourceCPU {
+					values = append(values, quantity.MilliValue())
+				} else {
+					values = append(values, quantity.Value())
+				}
+			}
+		}
+		if len(values) < minSizingSamples {
+			continue
+		}
+		slices.Sort(values)
+		rank := int(math.Ceil(float64(percentile) / 100 * float64(len(values))))
+		value := values[max(rank, 1)-1] * (100 + sizingMarginPercent) / 100
+		if name == corev1.ResourceCPU {
+			recommended[name] = *resource.NewMilliQuantity(max(value, 1), resource.DecimalSI)
+		} else {
+			const mebibyte = 1 << 20
+			recommended[name] = *resource.NewQuantity((value+mebibyte-1)/mebibyte*mebibyte, resource.BinarySI)
+		}
+	}
+	return recommended, len(recommended) > 0
+}
+
+// sizedRequests returns the requests applied to the agent container of an Agent with
+// sizing.autoApply: the recommendation within minAllowed and maxAllowed, or nil
+func sizedRequests(agent *kubetaskv1alpha1.Agent) corev1.ResourceList {
+	sizing := agent.Spec.Sizing
+	if sizing == nil || !sizing.AutoApply || agent.Status.Sizing == nil || len(agent.Status.Sizing.Recommended) == 0 {
+		return nil
+	}
+	requests := corev1.ResourceList{}
+	for name, quantity := range agent.Status.Sizing.Recommended {
+		if minimum, ok := sizing.MinAllowed[name]; ok && quantity.Cmp(minimum) < 0 {
+			quantity = minimum
+		}
+		if maximum, ok := sizing.MaxAllowed[name]; ok && quantity.Cmp(maximum) > 0 {
+			quantity = maximum
+		}
+		requests[name] = quantity
+	}
+	return requests
+}
+
+// apiPodMetrics reads Pod usage from the metrics.k8s.io API
+type apiPodMetrics struct {
+	client.Reader
+}
+
+// ListPodMetrics lists the PodMetrics of the agent Pods in namespace
+func (m *apiPodMetrics) ListPodMetrics(ctx context.Context, namespace string) (map[string]map[string]corev1.ResourceList, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(podMetricsListGVK)
+	if err := m.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{"app": "kubetask"}); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]corev1.ResourceList, len(list.Items))
+	for _, item := range list.Items {
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		byName := make(map[string]corev1.ResourceList, len(containers))
+		for _, c := range containers {
+			container, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(container, "name")
+			usage, _, _ := unstructured.NestedStringMap(container, "usage")
+			resources := corev1.ResourceList{}
+			for resourceName, value := range usage {
+				if quantity, err := resource.ParseQuantity(value); err == nil {
+					resources[corev1.ResourceName(resourceName)] = quantity
+				}
+			}
+			byName[name] = resources
+		}
+		result[item.GetName()] = byName
+	}
+	return result, nil
+}
@@ -0,0 +1,166 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func resourceUsage(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestRecommendRequests(t *testing.T) {
+	peaks := []corev1.ResourceList{
+		resourceUsage("100m", "100Mi"), resourceUsage("200m", "200Mi"), resourceUsage("300m", "300Mi"),
+		resourceUsage("400m", "400Mi"), resourceUsage("1", "1Gi"),
+	}
+
+	got, ok := recommendRequests(peaks, 80)
+	if !ok {
+		t.Fatal("recommendRequests() ok = false, want true")
+	}
+	// The 80th percentile of five samples is the fourth, plus 15%
+	if cpu := got[corev1.ResourceCPU]; cpu.MilliValue() != 460 {
+		t.Errorf("cpu = %s, want 460m", cpu.String())
+	}
+	if memory := got[corev1.ResourceMemory]; memory.Value() != 460<<20 {
+		t.Errorf("memory = %s, want 460Mi", memory.String())
+	}
+
+	got, _ = recommendRequests(peaks, 100)
+	if memory := got[corev1.ResourceMemory]; memory.Value() != 1178<<20 {
+		t.Errorf("memory at 100th percentile = %s, want rounded up to 1178Mi", memory.String())
+	}
+
+	if _, ok := recommendRequests(peaks[:4], 90); ok {
+		t.Error("recommendRequests() with four samples ok = true, want false")
+	}
+}
+
+func TestPeakUsage(t *testing.T) {
+	peak, changed := peakUsage(nil, resourceUsage("100m", "100Mi"))
+	if !changed || len(peak) != 2 {
+		t.Fatalf("peakUsage(nil) = %v, %v, want both resources recorded", peak, changed)
+	}
+	peak, changed = peakUsage(peak, resourceUsage("50m", "200Mi"))
+	if !changed {
+		t.Error("peakUsage() changed = false for higher memory")
+	}
+	if cpu := peak[corev1.ResourceCPU]; cpu.MilliValue() != 100 {
+		t.Errorf("cpu = %s, want 100m kept", cpu.String())
+	}
+	if _, changed := peakUsage(peak, resourceUsage("50m", "150Mi")); changed {
+		t.Error("peakUsage() changed = true for lower usage")
+	}
+}
+
+func TestSizedRequests(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{
+		Spec: kubetaskv1alpha1.AgentSpec{Sizing: &kubetaskv1alpha1.AgentSizing{
+			MinAllowed: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}},
+		Status: kubetaskv1alpha1.AgentStatus{Sizing: &kubetaskv1alpha1.AgentSizingStatus{
+			Recommended: resourceUsage("100m", "4Gi"),
+		}},
+	}
+	if got := sizedRequests(agent); got != nil {
+		t.Errorf("sizedRequests() without autoApply = %v, want nil", got)
+	}
+
+	agent.Spec.Sizing.AutoApply = true
+	got := sizedRequests(agent)
+	if cpu := got[corev1.ResourceCPU]; cpu.String() != "250m" {
+		t.Errorf("cpu = %s, want raised to minAllowed 250m", cpu.String())
+	}
+	if memory := got[corev1.ResourceMemory]; memory.String() != "1Gi" {
+		t.Errorf("memory = %s, want lowered to maxAllowed 1Gi", memory.String())
+	}
+}
+
+// fakePodMetrics reports fixed usage by namespace, Pod and container
+type fakePodMetrics map[string]map[string]map[string]corev1.ResourceList
+
+func (f fakePodMetrics) ListPodMetrics(_ context.Context, namespace string) (map[string]map[string]corev1.ResourceList, error) {
+	return f[namespace], nil
+}
+
+func TestSizingRecommender_Sweep(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "sized", Namespace: "default"},
+		Spec:       kubetaskv1alpha1.AgentSpec{Sizing: &kubetaskv1alpha1.AgentSizing{}},
+	}
+	objs := []client.Object{agent}
+	for i := range 5 {
+		objs = append(objs, &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("done-%d", i), Namespace: "default"},
+			Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: "sized"},
+			Status: kubetaskv1alpha1.TaskExecutionStatus{
+				Phase:     kubetaskv1alpha1.TaskPhaseCompleted,
+				PeakUsage: resourceUsage(fmt.Sprintf("%dm", 100*(i+1)), "100Mi"),
+			},
+		})
+	}
+	running := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"},
+		Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: "sized"},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:     kubetaskv1alpha1.TaskPhaseRunning,
+			PodName:   "running-pod",
+			PeakUsage: resourceUsage("300m", "50Mi"),
+		},
+	}
+	objs = append(objs, running)
+
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}, &kubetaskv1alpha1.Agent{}).Build()
+	s := &SizingRecommender{
+		Client: k8sClient,
+		Metrics: fakePodMetrics{"default": {"running-pod": {
+			"agent":   resourceUsage("200m", "80Mi"),
+			"sidecar": resourceUsage("4", "4Gi"),
+		}}},
+	}
+	if err := s.Sweep(context.Background()); err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "running", Namespace: "default"}, task); err != nil {
+		t.Fatal(err)
+	}
+	if cpu, memory := task.Status.PeakUsage[corev1.ResourceCPU], task.Status.PeakUsage[corev1.ResourceMemory]; cpu.String() != "300m" || memory.String() != "80Mi" {
+		t.Errorf("running Task peak usage = %s, %s, want 300m, 80Mi", cpu.String(), memory.String())
+	}
+
+	got := &kubetaskv1alpha1.Agent{}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "sized", Namespace: "default"}, got); err != nil {
+		t.Fatal(err)
+	}
+	sizing := got.Status.Sizing
+	if sizing == nil || sizing.Samples != 5 || sizing.LastUpdateTime == nil {
+		t.Fatalf("Agent sizing status = %+v, want five samples", sizing)
+	}
+	// The 90th percentile of 100m..500m is 500m, plus 15%
+	if cpu := sizing.Recommended[corev1.ResourceCPU]; cpu.MilliValue() != 575 {
+		t.Errorf("recommended cpu = %s, want 575m", cpu.String())
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AgentEvalLabelKey is the label key used to identify Tasks and Jobs created by an AgentEval
+	AgentEvalLabelKey = "kubetask.io/agent-eval"
+
+	// EvalCaseLabelKey is the label key recording the AgentEval case a Task or Job belongs to
+	EvalCaseLabelKey = "kubetask.io/eval-case"
+)
+
+// AgentEvalReconciler reconciles an AgentEval object
+type AgentEvalReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Clock is used for status timestamps. Defaults to the real clock.
+	Clock Clock
+}
+
+// +kubebuilder:rbac:groups=kubetask.io,resources=agentevals,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kubetask.io,resources=agentevals/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kubetask.io,resources=tasks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs every case for every Agent and scores the results
+func (r *AgentEvalReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	eval := &kubetaskv1alpha1.AgentEval{}
+	if err := r.Get(ctx, req.NamespacedName, eval); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch AgentEval")
+		return ctrl.Result{}, err
+	}
+
+	// Results are final once every case has finished
+	if eval.Status.Phase == kubetaskv1alpha1.EvalPhaseCompleted {
+		return ctrl.Result{}, nil
+	}
+
+	var caseResults []kubetaskv1alpha1.EvalCaseResult
+	for i, agentRef := range eval.Spec.AgentRefs {
+		for _, evalCase := range eval.Spec.Cases {
+			result, err := r.reconcileCase(ctx, eval, i, agentRef, evalCase)
+			if err != nil {
+				log.Error(err, "unable to reconcile eval case", "agent", agentRef, "case", evalCase.Name)
+				return ctrl.Result{}, err
+			}
+			caseResults = append(caseResults, result)
+		}
+	}
+
+	eval.Status.Cases = caseResults
+	eval.Status.Results = summarizeEvalResults(eval.Spec.AgentRefs, caseResults)
+
+	if evalFinished(caseResults) {
+		eval.Status.Phase = kubetaskv1alpha1.EvalPhaseCompleted
+		now := metav1.NewTime(clockNow(r.Clock))
+		eval.Status.CompletionTime = &now
+		meta.SetStatusCondition(&eval.Status.Conditions, metav1.Condition{
+			Type:    "Completed",
+			Status:  metav1.ConditionTrue,
+			Reason:  "AllCasesFinished",
+			Message: formatEvalResults(eval.Status.Results),
+		})
+		log.Info("AgentEval completed", "results", formatEvalResults(eval.Status.Results))
+	} else {
+		eval.Status.Phase = kubetaskv1alpha1.EvalPhaseRunning
+	}
+
+	if err := r.Status().Update(ctx, eval); err != nil {
+		log.Error(err, "unable to update AgentEval status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileCase ensures the Task (and verifier Job) for one case exist and reports the outcome
+func (r *AgentEvalReconciler) reconcileCase(ctx context.Context, eval *kubetaskv1alpha1.AgentEval, agentIndex int, agentRef string, evalCase kubetaskv1alpha1.EvalCase) (kubetaskv1alpha1.EvalCaseResult, error) {
+	taskName := fmt.Sprintf("%s-%d-%s", eval.Name, agentIndex, evalCase.Name)
+	result := kubetaskv1alpha1.EvalCaseResult{
+		AgentRef: agentRef,
+		Case:     evalCase.Name,
+		TaskName: taskName,
+		Outcome:  kubetaskv1alpha1.EvalCaseOutcomePending,
+	}
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := r.Get(ctx, types.NamespacedName{Name: taskName, Namespace: eval.Namespace}, task); err != nil {
+		if !errors.IsNotFound(err) {
+			return result, err
+		}
+		task = buildEvalTask(eval, taskName, agentRef, evalCase)
+		if err := r.Create(ctx, task); err != nil && !errors.IsAlreadyExists(err) {
+			return result, err
+		}
+		return result, nil
+	}
+
+	switch task.Status.Phase {
+	case kubetaskv1alpha1.TaskPhaseFailed:
+		result.Outcome = kubetaskv1alpha1.EvalCaseOutcomeFailed
+		result.Message = "Task failed"
+		return result, nil
+	case kubetaskv1alpha1.TaskPhaseCompleted:
+	default:
+		return result, nil
+	}
+
+	// Task completed; without a verifier that counts as passed
+	if evalCase.Verifier == nil {
+		result.Outcome = kubetaskv1alpha1.EvalCaseOutcomePassed
+		return result, nil
+	}
+
+	jobName := taskName + "-verify"
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: jobName, Namespace: eval.Namespace}, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return result, err
+		}
+		job = buildVerifierJob(eval, jobName, agentRef, evalCase.Name, taskName, evalCase.Verifier)
+		if err := r.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+			return result, err
+		}
+		return result, nil
+	}
+
+	if job.Status.Succeeded > 0 {
+		result.Outcome = kubetaskv1alpha1.EvalCaseOutcomePassed
+	} else if job.Status.Failed > 0 || isJobFailed(job) {
+		result.Outcome = kubetaskv1alpha1.EvalCaseOutcomeFailed
+		result.Message = fmt.Sprintf("verifier Job %s failed", jobName)
+	}
+	return result, nil
+}
+
+// buildEvalTask creates the Task for one case, pinned to the Agent under evaluation
+func buildEvalTask(eval *kubetaskv1alpha1.AgentEval, taskName, agentRef string, evalCase kubetaskv1alpha1.EvalCase) *kubetaskv1alpha1.Task {
+	spec := *evalCase.Task.DeepCopy()
+	spec.AgentRef = agentRef
+
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskName,
+			Namespace: eval.Namespace,
+			Labels: map[string]string{
+				AgentEvalLabelKey: eval.Name,
+				EvalCaseLabelKey:  evalCase.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: eval.APIVersion,
+					Kind:       eval.Kind,
+					Name:       eval.Name,
+					UID:        eval.UID,
+					Controller: boolPtr(true),
+				},
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// summarizeEvalResults computes per-Agent pass rates, in AgentRefs order
+func summarizeEvalResults(agentRefs []string, cases []kubetaskv1alpha1.EvalCaseResult) []kubetaskv1alpha1.AgentEvalResult {
+	results := make([]kubetaskv1alpha1.AgentEvalResult, len(agentRefs))
+	for i, agentRef := range agentRefs {
+		result := kubetaskv1alpha1.AgentEvalResult{AgentRef: agentRef}
+		for _, c := range cases {
+			if c.AgentRef != agentRef {
+				continue
+			}
+			result.Total++
+			switch c.Outcome {
+			case kubetaskv1alpha1.EvalCaseOutcomePassed:
+				result.Passed++
+			case kubetaskv1alpha1.EvalCaseOutcomeFailed:
+				result.Failed++
+			}
+		}
+		if result.Total > 0 {
+			result.PassRatePercent = result.Passed * 100 / result.Total
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// evalFinished reports whether every case has a final outcome
+func evalFinished(cases []kubetaskv1alpha1.EvalCaseResult) bool {
+	for _, c := range cases {
+		if c.Outcome == kubetaskv1alpha1.EvalCaseOutcomePending {
+			return false
+		}
+	}
+	return true
+}
+
+// formatEvalResults renders pass rates for conditions and logs, e.g. "gemini: 4/5 (80%)"
+func formatEvalResults(results []kubetaskv1alpha1.AgentEvalResult) string {
+	var msg string
+	for i, res := range results {
+		if i > 0 {
+			msg += ", "
+		}
+		msg += fmt.Sprintf("%s: %d/%d (%d%%)", res.AgentRef, res.Passed, res.Total, res.PassRatePercent)
+	}
+	return msg
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *AgentEvalReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubetaskv1alpha1.AgentEval{}).
+		Owns(&kubetaskv1alpha1.Task{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
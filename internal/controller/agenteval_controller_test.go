@@ -0,0 +1,97 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build integration
+
+// See suite_test.go for explanation of the "integration" build tag pattern.
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+var _ = Describe("AgentEvalController", func() {
+	const (
+		evalNamespace = "default"
+	)
+
+	Context("When creating an AgentEval with a verifier", func() {
+		It("Should run the case Task, then the verifier, and report the pass rate", func() {
+			evalName := "test-eval"
+			taskName := "test-eval-0-smoke"
+			description := "# Eval test"
+
+			By("Creating AgentEval")
+			eval := &kubetaskv1alpha1.AgentEval{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      evalName,
+					Namespace: evalNamespace,
+				},
+				Spec: kubetaskv1alpha1.AgentEvalSpec{
+					AgentRefs: []string{"default"},
+					Cases: []kubetaskv1alpha1.EvalCase{
+						{
+							Name: "smoke",
+							Task: kubetaskv1alpha1.TaskSpec{Description: &description},
+							Verifier: &kubetaskv1alpha1.EvalVerifier{
+								Image:   "busybox",
+								Command: []string{"true"},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, eval)).Should(Succeed())
+
+			By("Checking the case Task is created for the Agent")
+			taskLookupKey := types.NamespacedName{Name: taskName, Namespace: evalNamespace}
+			task := &kubetaskv1alpha1.Task{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, taskLookupKey, task)
+			}, timeout, interval).Should(Succeed())
+			Expect(task.Spec.AgentRef).Should(Equal("default"))
+			Expect(task.Labels[AgentEvalLabelKey]).Should(Equal(evalName))
+
+			By("Simulating Task completion")
+			Eventually(func() error {
+				if err := k8sClient.Get(ctx, taskLookupKey, task); err != nil {
+					return err
+				}
+				task.Status.Phase = kubetaskv1alpha1.TaskPhaseCompleted
+				return k8sClient.Status().Update(ctx, task)
+			}, timeout, interval).Should(Succeed())
+
+			By("Checking the verifier Job is created")
+			verifyJob := &batchv1.Job{}
+			verifyJobKey := types.NamespacedName{Name: taskName + "-verify", Namespace: evalNamespace}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, verifyJobKey, verifyJob)
+			}, timeout, interval).Should(Succeed())
+
+			By("Simulating verifier success")
+			verifyJob.Status.Succeeded = 1
+			Expect(k8sClient.Status().Update(ctx, verifyJob)).Should(Succeed())
+
+			evalLookupKey := types.NamespacedName{Name: evalName, Namespace: evalNamespace}
+			updatedEval := &kubetaskv1alpha1.AgentEval{}
+			Eventually(func() kubetaskv1alpha1.EvalPhase {
+				if err := k8sClient.Get(ctx, evalLookupKey, updatedEval); err != nil {
+					return ""
+				}
+				return updatedEval.Status.Phase
+			}, timeout, interval).Should(Equal(kubetaskv1alpha1.EvalPhaseCompleted))
+
+			Expect(updatedEval.Status.Results).Should(HaveLen(1))
+			Expect(updatedEval.Status.Results[0].PassRatePercent).Should(Equal(int32(100)))
+
+			By("Cleaning up")
+			Expect(k8sClient.Delete(ctx, eval)).Should(Succeed())
+		})
+	})
+})
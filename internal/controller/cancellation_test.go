@@ -0,0 +1,50 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestBuildAgentConfig_Cancellation(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name         string
+		command      []string
+		cancellation *kubetaskv1alpha1.AgentCancellation
+		wantErr      bool
+	}{
+		{name: "grace period only", cancellation: &kubetaskv1alpha1.AgentCancellation{GracePeriodSeconds: int64Ptr(300)}},
+		{name: "signal delay within the default grace period", command: []string{"claude"},
+			cancellation: &kubetaskv1alpha1.AgentCancellation{SignalDelaySeconds: int64Ptr(20)}},
+		{name: "signal delay without command",
+			cancellation: &kubetaskv1alpha1.AgentCancellation{SignalDelaySeconds: int64Ptr(20)}, wantErr: true},
+		{name: "signal delay beyond the grace period", command: []string{"claude"},
+			cancellation: &kubetaskv1alpha1.AgentCancellation{GracePeriodSeconds: int64Ptr(60), SignalDelaySeconds: int64Ptr(60)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &kubetaskv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: kubetaskv1alpha1.AgentSpec{
+					ServiceAccountName: "test-sa",
+					Command:            tt.command,
+					Cancellation:       tt.cancellation,
+				},
+			}
+			cfg, err := buildAgentConfig(agent, DefaultAgentImage)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildAgentConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Cancellation != tt.cancellation {
+				t.Errorf("cancellation = %+v, want the Agent's", cfg.Cancellation)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/catalog"
+)
+
+// CatalogReconciler publishes the catalog of the Agents, Contexts, and CronTasks of
+// each namespace in the kubetask-catalog ConfigMap. Requests are keyed by the
+// namespace and the ConfigMap name.
+type CatalogReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=kubetask.io,resources=agents;contexts;crontasks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+
+// Reconcile rebuilds the catalog of a namespace. The ConfigMap is deleted once the
+// namespace has nothing to list.
+func (r *CatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	agentList := &kubetaskv1alpha1.AgentList{}
+	if err := r.List(ctx, agentList, client.InNamespace(req.Namespace)); err != nil {
+		log.Error(err, "unable to list Agents")
+		return ctrl.Result{}, err
+	}
+	contextList := &kubetaskv1alpha1.ContextList{}
+	if err := r.List(ctx, contextList, client.InNamespace(req.Namespace)); err != nil {
+		log.Error(err, "unable to list Contexts")
+		return ctrl.Result{}, err
+	}
+	cronTaskList := &kubetaskv1alpha1.CronTaskList{}
+	if err := r.List(ctx, cronTaskList, client.InNamespace(req.Namespace)); err != nil {
+		log.Error(err, "unable to list CronTasks")
+		return ctrl.Result{}, err
+	}
+	c := catalog.Build(agentList.Items, contextList.Items, cronTaskList.Items)
+
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, req.NamespacedName, cm)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "unable to get catalog ConfigMap")
+		return ctrl.Result{}, err
+	}
+	exists := err == nil
+	if exists && cm.Labels[ManagedByLabelKey] != ManagedByLabelValue {
+		log.Info("ConfigMap is not managed by KubeTask, not publishing the catalog", "configMap", req.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if c.Empty() {
+		if exists {
+			return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, cm))
+		}
+		return ctrl.Result{}, nil
+	}
+
+	data, err := c.Marshal()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !exists {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      req.Name,
+				Namespace: req.Namespace,
+				Labels:    map[string]string{ManagedByLabelKey: ManagedByLabelValue},
+			},
+			Data: map[string]string{catalog.DataKey: data},
+		}
+		if err := r.Create(ctx, cm); err != nil {
+			log.Error(err, "unable to create catalog ConfigMap")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+	if cm.Data[catalog.DataKey] == data {
+		return ctrl.Result{}, nil
+	}
+	cm.Data = map[string]string{catalog.DataKey: data}
+	if err := r.Update(ctx, cm); err != nil {
+		log.Error(err, "unable to update catalog ConfigMap")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *CatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isCatalog := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetName() == catalog.ConfigMapName
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("catalog").
+		Watches(&kubetaskv1alpha1.Agent{}, handler.EnqueueRequestsFromMapFunc(namespaceCatalog)).
+		Watches(&kubetaskv1alpha1.Context{}, handler.EnqueueRequestsFromMapFunc(namespaceCatalog)).
+		Watches(&kubetaskv1alpha1.CronTask{}, handler.EnqueueRequestsFromMapFunc(namespaceCatalog)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(namespaceCatalog), builder.WithPredicates(isCatalog)).
+		Complete(r)
+}
+
+// namespaceCatalog enqueues the catalog of the namespace of a changed resource
+func namespaceCatalog(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: catalog.ConfigMapName}}}
+}
@@ -0,0 +1,94 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/catalog"
+)
+
+var catalogKey = types.NamespacedName{Namespace: "team", Name: catalog.ConfigMapName}
+
+func reconcileCatalog(t *testing.T, c client.Client) *corev1.ConfigMap {
+	t.Helper()
+	if _, err := (&CatalogReconciler{Client: c}).Reconcile(context.Background(), ctrl.Request{NamespacedName: catalogKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), catalogKey, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		t.Fatal(err)
+	}
+	return cm
+}
+
+func TestCatalogReconciler(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team"}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		agent,
+		&kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"}},
+	).Build()
+
+	cm := reconcileCatalog(t, c)
+	if cm == nil {
+		t.Fatal("catalog ConfigMap not created")
+	}
+	got, err := catalog.FromConfigMap(cm)
+	if err != nil {
+		t.Fatalf("FromConfigMap() error = %v", err)
+	}
+	if len(got.Agents) != 1 || got.Agents[0].Name != "claude" {
+		t.Errorf("catalog Agents = %+v, want only claude", got.Agents)
+	}
+
+	contextCR := &kubetaskv1alpha1.Context{
+		ObjectMeta: metav1.ObjectMeta{Name: "standards", Namespace: "team"},
+		Spec:       kubetaskv1alpha1.ContextSpec{Type: kubetaskv1alpha1.ContextTypeInline},
+	}
+	if err := c.Create(context.Background(), contextCR); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := catalog.FromConfigMap(reconcileCatalog(t, c)); len(got.Contexts) != 1 {
+		t.Errorf("catalog Contexts = %+v, want the new Context", got.Contexts)
+	}
+
+	for _, obj := range []client.Object{agent, contextCR} {
+		if err := c.Delete(context.Background(), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cm := reconcileCatalog(t, c); cm != nil {
+		t.Errorf("catalog ConfigMap = %+v, want it deleted once the namespace is empty", cm.Data)
+	}
+}
+
+func TestCatalogReconciler_UnmanagedConfigMap(t *testing.T) {
+	unmanaged := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: catalog.ConfigMapName, Namespace: "team"},
+		Data:       map[string]string{"owner": "someone else"},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		unmanaged,
+		&kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team"}},
+	).Build()
+
+	cm := reconcileCatalog(t, c)
+	if cm == nil || cm.Data["owner"] != "someone else" || cm.Data[catalog.DataKey] != "" {
+		t.Errorf("ConfigMap = %+v, want the unmanaged ConfigMap left alone", cm)
+	}
+}
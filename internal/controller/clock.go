@@ -0,0 +1,23 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import "time"
+
+// Clock interface for time operations, allows mocking in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock implements Clock using the real time
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// clockNow returns the current time of c, or the real time if c is nil
+func clockNow(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
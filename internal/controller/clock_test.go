@@ -0,0 +1,29 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+// staticClock is a Clock that always returns the same time
+type staticClock struct {
+	now time.Time
+}
+
+func (c staticClock) Now() time.Time { return c.now }
+
+func TestClockNow(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := clockNow(staticClock{now: at}); !got.Equal(at) {
+		t.Errorf("clockNow() = %s, want %s", got, at)
+	}
+
+	before := time.Now()
+	if got := clockNow(nil); got.Before(before) {
+		t.Errorf("clockNow(nil) = %s, want the real time", got)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// kubeTaskConfigAccessor reads the per-namespace KubeTaskConfig.
+// Backed by the manager's informer cache, lookups do not hit the API server.
+type kubeTaskConfigAccessor struct {
+	reader client.Reader
+}
+
+// newKubeTaskConfigAccessor creates an accessor that reads from the given reader,
+// normally the manager's cache
+func newKubeTaskConfigAccessor(reader client.Reader) *kubeTaskConfigAccessor {
+	return &kubeTaskConfigAccessor{reader: reader}
+}
+
+// get returns the KubeTaskConfig named "default" in the namespace, or nil if there is none
+func (a *kubeTaskConfigAccessor) get(ctx context.Context, namespace string) (*kubetaskv1alpha1.KubeTaskConfig, error) {
+	config := &kubetaskv1alpha1.KubeTaskConfig{}
+	configKey := types.NamespacedName{Name: DefaultKubeTaskConfigName, Namespace: namespace}
+	if err := a.reader.Get(ctx, configKey, config); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// taskLifecycle returns the TaskLifecycle settings for the namespace, or nil if unset
+func (a *kubeTaskConfigAccessor) taskLifecycle(ctx context.Context, namespace string) (*kubetaskv1alpha1.TaskLifecycleConfig, error) {
+	config, err := a.get(ctx, namespace)
+	if err != nil || config == nil {
+		return nil, err
+	}
+	return config.Spec.TaskLifecycle, nil
+}
+
+// defaultAgentImage returns the image for Agents in the namespace that do not set one.
+// KubeTaskConfig agentDefaults take precedence over fallback (the controller flag).
+func (a *kubeTaskConfigAccessor) defaultAgentImage(ctx context.Context, namespace, fallback string) (string, error) {
+	config, err := a.get(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	return resolveDefaultAgentImage(config, fallback), nil
+}
+
+// resolveDefaultAgentImage picks the default agent image from a (possibly nil)
+// KubeTaskConfig, the controller-level fallback, and the built-in default, in that order
+func resolveDefaultAgentImage(config *kubetaskv1alpha1.KubeTaskConfig, fallback string) string {
+	if config != nil && config.Spec.AgentDefaults != nil && config.Spec.AgentDefaults.AgentImage != "" {
+		return config.Spec.AgentDefaults.AgentImage
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return DefaultAgentImage
+}
+
+// buildPreamble joins the cluster-wide preamble and the prompt of a (possibly nil)
+// KubeTaskConfig into the text prepended to task.md, or "" if there is none
+func buildPreamble(clusterPreamble string, config *kubetaskv1alpha1.KubeTaskConfig) string {
+	var parts []string
+	if p := strings.TrimSpace(clusterPreamble); p != "" {
+		parts = append(parts, p)
+	}
+	if config != nil && config.Spec.Prompt != nil {
+		if language := strings.TrimSpace(config.Spec.Prompt.Language); language != "" {
+			parts = append(parts, fmt.Sprintf("Respond in %s.", language))
+		}
+		if p := strings.TrimSpace(config.Spec.Prompt.Preamble); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// enqueueFinishedTasksForConfig returns an event handler that requeues finished and pending
+// Tasks in the namespace of a changed "default" KubeTaskConfig, so new settings such as TTL
+// rules and quiet hours take effect without waiting for the next scheduled requeue.
+func enqueueFinishedTasksForConfig(reader client.Reader) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetName() != DefaultKubeTaskConfigName {
+			return nil
+		}
+
+		taskList := &kubetaskv1alpha1.TaskList{}
+		if err := reader.List(ctx, taskList, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list Tasks for KubeTaskConfig change", "namespace", obj.GetNamespace())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, task := range taskList.Items {
+			if task.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted &&
+				task.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed &&
+				task.Status.Phase != kubetaskv1alpha1.TaskPhasePending {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: task.Name, Namespace: task.Namespace},
+			})
+		}
+		return requests
+	})
+}
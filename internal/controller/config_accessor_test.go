@@ -0,0 +1,167 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newTestScheme(t testing.TB) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	return scheme
+}
+
+func TestKubeTaskConfigAccessor_TaskLifecycle(t *testing.T) {
+	ttl := int32(60)
+	config := &kubetaskv1alpha1.KubeTaskConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultKubeTaskConfigName, Namespace: "team-a"},
+		Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+			TaskLifecycle: &kubetaskv1alpha1.TaskLifecycleConfig{TTLSecondsAfterFinished: &ttl},
+		},
+	}
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(config).Build()
+	accessor := newKubeTaskConfigAccessor(reader)
+
+	lifecycle, err := accessor.taskLifecycle(context.Background(), "team-a")
+	if err != nil {
+		t.Fatalf("taskLifecycle() error = %v", err)
+	}
+	if lifecycle == nil || *lifecycle.TTLSecondsAfterFinished != 60 {
+		t.Errorf("taskLifecycle() = %+v, want TTL 60", lifecycle)
+	}
+
+	lifecycle, err = accessor.taskLifecycle(context.Background(), "team-b")
+	if err != nil {
+		t.Fatalf("taskLifecycle() for namespace without config error = %v", err)
+	}
+	if lifecycle != nil {
+		t.Errorf("taskLifecycle() for namespace without config = %+v, want nil", lifecycle)
+	}
+}
+
+func TestEnqueueFinishedTasksForConfig(t *testing.T) {
+	newTask := func(name, namespace string, phase kubetaskv1alpha1.TaskPhase) *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: phase},
+		}
+	}
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		newTask("completed", "team-a", kubetaskv1alpha1.TaskPhaseCompleted),
+		newTask("failed", "team-a", kubetaskv1alpha1.TaskPhaseFailed),
+		newTask("running", "team-a", kubetaskv1alpha1.TaskPhaseRunning),
+		newTask("pending", "team-a", kubetaskv1alpha1.TaskPhasePending),
+		newTask("other-namespace", "team-b", kubetaskv1alpha1.TaskPhaseCompleted),
+	).Build()
+	h := enqueueFinishedTasksForConfig(reader)
+
+	tests := []struct {
+		name       string
+		configName string
+		want       int
+	}{
+		{name: "default config requeues finished and pending Tasks", configName: DefaultKubeTaskConfigName, want: 3},
+		{name: "non-default config is ignored", configName: "other", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+
+			config := &kubetaskv1alpha1.KubeTaskConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.configName, Namespace: "team-a"},
+			}
+			h.Update(context.Background(), event.UpdateEvent{ObjectOld: config, ObjectNew: config}, q)
+
+			if q.Len() != tt.want {
+				t.Errorf("enqueued %d requests, want %d", q.Len(), tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDefaultAgentImage(t *testing.T) {
+	withImage := &kubetaskv1alpha1.KubeTaskConfig{
+		Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+			AgentDefaults: &kubetaskv1alpha1.AgentDefaultsConfig{AgentImage: "claude:v1"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   *kubetaskv1alpha1.KubeTaskConfig
+		fallback string
+		want     string
+	}{
+		{name: "built-in default", config: nil, fallback: "", want: DefaultAgentImage},
+		{name: "controller flag", config: nil, fallback: "codex:v1", want: "codex:v1"},
+		{name: "config without agent defaults", config: &kubetaskv1alpha1.KubeTaskConfig{}, fallback: "codex:v1", want: "codex:v1"},
+		{name: "KubeTaskConfig overrides flag", config: withImage, fallback: "codex:v1", want: "claude:v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveDefaultAgentImage(tt.config, tt.fallback); got != tt.want {
+				t.Errorf("resolveDefaultAgentImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPreamble(t *testing.T) {
+	withPrompt := &kubetaskv1alpha1.KubeTaskConfig{
+		Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+			Prompt: &kubetaskv1alpha1.PromptConfig{
+				Language: "Japanese",
+				Preamble: "Never push to main.\n",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		cluster string
+		config  *kubetaskv1alpha1.KubeTaskConfig
+		want    string
+	}{
+		{name: "none", config: nil, want: ""},
+		{name: "config without prompt", config: &kubetaskv1alpha1.KubeTaskConfig{}, want: ""},
+		{name: "cluster only", cluster: "Follow the security policy.", want: "Follow the security policy."},
+		{name: "namespace only", config: withPrompt, want: "Respond in Japanese.\n\nNever push to main."},
+		{
+			name:    "cluster before namespace",
+			cluster: "Follow the security policy.",
+			config:  withPrompt,
+			want:    "Follow the security policy.\n\nRespond in Japanese.\n\nNever push to main.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildPreamble(tt.cluster, tt.config); got != tt.want {
+				t.Errorf("buildPreamble() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestBuildAgentConfig_ContextCompression(t *testing.T) {
+	zero := int32(0)
+	custom := int32(4096)
+	tests := []struct {
+		name        string
+		compression *kubetaskv1alpha1.ContextCompression
+		want        int
+	}{
+		{name: "disabled", want: 0},
+		{name: "default minimum", compression: &kubetaskv1alpha1.ContextCompression{}, want: build.DefaultContextCompressionMinSize},
+		{name: "custom minimum", compression: &kubetaskv1alpha1.ContextCompression{MinSizeBytes: &custom}, want: 4096},
+		{name: "compress everything", compression: &kubetaskv1alpha1.ContextCompression{MinSizeBytes: &zero}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &kubetaskv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+				Spec: kubetaskv1alpha1.AgentSpec{
+					ServiceAccountName: "sa",
+					ContextCompression: tt.compression,
+				},
+			}
+			cfg, err := buildAgentConfig(agent, DefaultAgentImage)
+			if err != nil {
+				t.Fatalf("buildAgentConfig() error = %v", err)
+			}
+			if cfg.ContextCompressionMinSize != tt.want {
+				t.Errorf("contextCompressionMinSize = %d, want %d", cfg.ContextCompressionMinSize, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+const (
+	// ContextsFreshConditionType reports whether the Context sources of a Task are
+	// within their freshness limits
+	ContextsFreshConditionType = "ContextsFresh"
+
+	// Kinds of objects recorded in Task.status.contextSources
+	contextSourceKindConfigMap = "ConfigMap"
+	contextSourceKindContext   = "Context"
+)
+
+// freshnessViolation is a Context source that is too old or changed after resolution
+type freshnessViolation struct {
+	policy  kubetaskv1alpha1.FreshnessPolicy
+	reason  string
+	message string
+}
+
+// contextSources records the source objects of the namespace, Agent and Task Contexts
+// that have a freshness policy, and reports the sources that are older than their maxAge
+func (r *TaskReconciler) contextSources(ctx context.Context, task *kubetaskv1alpha1.Task, cfg agentConfig) ([]kubetaskv1alpha1.ContextSource, []freshnessViolation, error) {
+	now := metav1.NewTime(clockNow(r.Clock))
+
+	var sources []kubetaskv1alpha1.ContextSource
+	var violations []freshnessViolation
+	for _, taskRef := range taskContextRefs(task, cfg) {
+		ref := taskRef.ref
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = task.Namespace
+		}
+		contextCR := &kubetaskv1alpha1.Context{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, contextCR); err != nil {
+			if ref.Optional && errors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("Context %q not found in namespace %q: %w", ref.Name, namespace, err)
+		}
+		freshness := contextCR.Spec.Freshness
+		if freshness == nil {
+			continue
+		}
+		kind, obj, err := r.contextSourceObject(ctx, contextCR)
+		if err != nil {
+			return nil, nil, err
+		}
+		if obj == nil {
+			continue
+		}
+
+		source := kubetaskv1alpha1.ContextSource{
+			Context:         ref.Name,
+			Namespace:       namespace,
+			Kind:            kind,
+			Name:            obj.GetName(),
+			ResourceVersion: obj.GetResourceVersion(),
+			ModifiedTime:    lastModified(obj),
+			ResolvedTime:    now,
+		}
+		sources = append(sources, source)
+
+		if freshness.MaxAge != nil && source.ModifiedTime != nil {
+			if age := now.Sub(source.ModifiedTime.Time); age > freshness.MaxAge.Duration {
+				violations = append(violations, freshnessViolation{
+					policy: freshness.Policy,
+					reason: "SourceTooOld",
+					message: fmt.Sprintf("%s %q of Context %q was last modified %s ago, more than maxAge %s",
+						kind, source.Name, ref.Name, age.Round(time.Second), freshness.MaxAge.Duration),
+				})
+			}
+		}
+	}
+	return sources, violations, nil
+}
+
+// contextSourceObject returns the object holding the content of a Context, or nil if
+// the Context type does not support freshness checks or its optional ConfigMap is missing
+func (r *TaskReconciler) contextSourceObject(ctx context.Context, contextCR *kubetaskv1alpha1.Context) (string, client.Object, error) {
+	switch contextCR.Spec.Type {
+	case kubetaskv1alpha1.ContextTypeInline:
+		return contextSourceKindContext, contextCR, nil
+	case kubetaskv1alpha1.ContextTypeConfigMap:
+		if contextCR.Spec.ConfigMap == nil {
+			return "", nil, nil
+		}
+		cm := &corev1.ConfigMap{}
+		key := types.NamespacedName{Name: contextCR.Spec.ConfigMap.Name, Namespace: contextCR.Namespace}
+		if err := r.Get(ctx, key, cm); err != nil {
+			if errors.IsNotFound(err) {
+				return "", nil, nil
+			}
+			return "", nil, err
+		}
+		return contextSourceKindConfigMap, cm, nil
+	}
+	return "", nil, nil
+}
+
+// changedContextSources reports the recorded sources of a Task that were modified or
+// deleted after the Task resolved them
+func (r *TaskReconciler) changedContextSources(ctx context.Context, task *kubetaskv1alpha1.Task) ([]freshnessViolation, error) {
+	var violations []freshnessViolation
+	for _, source := range task.Status.ContextSources {
+		contextCR := &kubetaskv1alpha1.Context{}
+		if err := r.Get(ctx, types.NamespacedName{Name: source.Context, Namespace: source.Namespace}, contextCR); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if contextCR.Spec.Freshness == nil {
+			continue
+		}
+		_, obj, err := r.contextSourceObject(ctx, contextCR)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case obj == nil || obj.GetName() != source.Name:
+			violations = append(violations, freshnessViolation{
+				policy:  contextCR.Spec.Freshness.Policy,
+				reason:  "SourceChanged",
+				message: fmt.Sprintf("%s %q of Context %q was removed after the Task resolved it", source.Kind, source.Name, source.Context),
+			})
+		case obj.GetResourceVersion() != source.ResourceVersion:
+			violations = append(violations, freshnessViolation{
+				policy:  contextCR.Spec.Freshness.Policy,
+				reason:  "SourceChanged",
+				message: fmt.Sprintf("%s %q of Context %q changed after the Task resolved it", source.Kind, source.Name, source.Context),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// setContextsFreshCondition records the violations on the Task and reports whether
+// the condition changed and whether any violation fails the Task
+func setContextsFreshCondition(task *kubetaskv1alpha1.Task, violations []freshnessViolation) (changed, fail bool) {
+	condition := metav1.Condition{
+		Type:    ContextsFreshConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SourcesFresh",
+		Message: fmt.Sprintf("%d Context sources are within their freshness limits", len(task.Status.ContextSources)),
+	}
+	if len(violations) > 0 {
+		messages := make([]string, 0, len(violations))
+		for _, v := range violations {
+			messages = append(messages, v.message)
+			fail = fail || v.policy == kubetaskv1alpha1.FreshnessPolicyFail
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = violations[0].reason
+		condition.Message = strings.Join(messages, "; ")
+	}
+	return meta.SetStatusCondition(&task.Status.Conditions, condition), fail
+}
+
+// checkContextSources fails or warns about a running Task whose Context sources
+// changed after they were resolved
+func (r *TaskReconciler) checkContextSources(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	log := log.FromContext(ctx)
+
+	violations, err := r.changedContextSources(ctx, task)
+	if err != nil || len(violations) == 0 {
+		// Keep the condition set at resolution, which may warn about maxAge
+		return err
+	}
+	changed, fail := setContextsFreshCondition(task, violations)
+	if !fail {
+		if changed {
+			return r.Status().Update(ctx, task)
+		}
+		return nil
+	}
+
+	// Stop the agent, it is working from outdated content
+	if task.Status.JobName != "" {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: task.Status.JobName, Namespace: task.Namespace}}
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+	now := metav1.NewTime(clockNow(r.Clock))
+	task.Status.CompletionTime = &now
+	meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  "ContextChanged",
+		Message: meta.FindStatusCondition(task.Status.Conditions, ContextsFreshConditionType).Message,
+	})
+	log.Info("task failed, context source changed", "job", task.Status.JobName)
+	if err := r.Status().Update(ctx, task); err != nil {
+		return err
+	}
+	audit.Record(audit.ForTask(task, audit.ActionFailed, audit.ControllerActor))
+	return nil
+}
+
+// lastModified returns the latest managedFields time of an object, falling back to
+// its creation time
+func lastModified(obj client.Object) *metav1.Time {
+	modified := obj.GetCreationTimestamp()
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(modified.Time) {
+			modified = *entry.Time
+		}
+	}
+	if modified.IsZero() {
+		return nil
+	}
+	return &modified
+}
+
+// contextSourceToTasks maps a ConfigMap or Context to the running Tasks that recorded
+// it as a Context source. Tasks may reference Contexts in other namespaces.
+func contextSourceToTasks(c client.Reader, kind string) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		tasks := &kubetaskv1alpha1.TaskList{}
+		if err := c.List(ctx, tasks); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list Tasks for Context source", "kind", kind, "name", obj.GetName())
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range tasks.Items {
+			task := &tasks.Items[i]
+			if task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+				continue
+			}
+			for _, source := range task.Status.ContextSources {
+				if source.Kind == kind && source.Name == obj.GetName() && source.Namespace == obj.GetNamespace() {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{Name: task.Name, Namespace: task.Namespace},
+					})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
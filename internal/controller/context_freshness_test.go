@@ -0,0 +1,177 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newFreshnessObjects(policy kubetaskv1alpha1.FreshnessPolicy) []client.Object {
+	modified := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	return []client.Object{
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:      kubetaskv1alpha1.ContextTypeConfigMap,
+				ConfigMap: &kubetaskv1alpha1.ConfigMapContext{Name: "security-policy", Key: "policy.md"},
+				Freshness: &kubetaskv1alpha1.ContextFreshness{
+					MaxAge: &metav1.Duration{Duration: 24 * time.Hour},
+					Policy: policy,
+				},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "security-policy",
+				Namespace:     "default",
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Time: &modified}},
+			},
+			Data: map[string]string{"policy.md": "Rotate keys every 90 days"},
+		},
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "guide", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:      kubetaskv1alpha1.ContextTypeInline,
+				Inline:    &kubetaskv1alpha1.InlineContext{Content: "Use conventional commits"},
+				Freshness: &kubetaskv1alpha1.ContextFreshness{Policy: policy},
+			},
+		},
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "untracked", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:   kubetaskv1alpha1.ContextTypeInline,
+				Inline: &kubetaskv1alpha1.InlineContext{Content: "No freshness policy"},
+			},
+		},
+	}
+}
+
+func newFreshnessTask() *kubetaskv1alpha1.Task {
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			Contexts: []kubetaskv1alpha1.ContextMount{{Name: "guide"}, {Name: "untracked"}},
+		},
+	}
+}
+
+func TestContextSources(t *testing.T) {
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newFreshnessObjects("")...).Build()}
+	cfg := agentConfig{contexts: []kubetaskv1alpha1.ContextMount{{Name: "policy", MountPath: "/workspace/policy.md"}}}
+	task := newFreshnessTask()
+
+	sources, violations, err := r.contextSources(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("contextSources() error = %v", err)
+	}
+	if len(sources) != 2 || sources[0].Kind != "ConfigMap" || sources[0].Name != "security-policy" ||
+		sources[1].Kind != "Context" || sources[1].Name != "guide" {
+		t.Fatalf("sources = %+v, want the policy ConfigMap and the guide Context", sources)
+	}
+	if sources[0].ResourceVersion == "" || sources[0].ModifiedTime == nil {
+		t.Errorf("source = %+v, want resourceVersion and modifiedTime", sources[0])
+	}
+	if len(violations) != 1 || violations[0].reason != "SourceTooOld" || !strings.Contains(violations[0].message, "security-policy") {
+		t.Errorf("violations = %+v, want the ConfigMap older than maxAge", violations)
+	}
+
+	task.Status.ContextSources = sources
+	if _, fail := setContextsFreshCondition(task, violations); fail {
+		t.Errorf("setContextsFreshCondition() fail = true, want a warning with the default policy")
+	}
+	if condition := meta.FindStatusCondition(task.Status.Conditions, ContextsFreshConditionType); condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Errorf("ContextsFresh condition = %+v, want False", condition)
+	}
+}
+
+func TestCheckContextSources(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    kubetaskv1alpha1.FreshnessPolicy
+		update    bool
+		wantPhase kubetaskv1alpha1.TaskPhase
+		wantFresh metav1.ConditionStatus
+	}{
+		{name: "unchanged sources", policy: kubetaskv1alpha1.FreshnessPolicyFail, wantPhase: kubetaskv1alpha1.TaskPhaseRunning},
+		{name: "changed source warns", update: true, wantPhase: kubetaskv1alpha1.TaskPhaseRunning, wantFresh: metav1.ConditionFalse},
+		{name: "changed source fails", policy: kubetaskv1alpha1.FreshnessPolicyFail, update: true, wantPhase: kubetaskv1alpha1.TaskPhaseFailed, wantFresh: metav1.ConditionFalse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := newFreshnessTask()
+			objects := append(newFreshnessObjects(tt.policy), task)
+			k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(objects...).
+				WithStatusSubresource(task).Build()
+			r := &TaskReconciler{Client: k8sClient}
+
+			sources, _, err := r.contextSources(context.Background(), task, agentConfig{})
+			if err != nil {
+				t.Fatalf("contextSources() error = %v", err)
+			}
+			task.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
+			task.Status.ContextSources = sources
+
+			if tt.update {
+				guide := &kubetaskv1alpha1.Context{}
+				if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "guide", Namespace: "default"}, guide); err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				guide.Spec.Inline.Content = "Use gitmoji"
+				if err := k8sClient.Update(context.Background(), guide); err != nil {
+					t.Fatalf("Update() error = %v", err)
+				}
+			}
+
+			if err := r.checkContextSources(context.Background(), task); err != nil {
+				t.Fatalf("checkContextSources() error = %v", err)
+			}
+			if task.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %q, want %q", task.Status.Phase, tt.wantPhase)
+			}
+			condition := meta.FindStatusCondition(task.Status.Conditions, ContextsFreshConditionType)
+			if tt.wantFresh == "" {
+				if condition != nil {
+					t.Errorf("ContextsFresh condition = %+v, want none", condition)
+				}
+			} else if condition == nil || condition.Status != tt.wantFresh || condition.Reason != "SourceChanged" {
+				t.Errorf("ContextsFresh condition = %+v, want %s/SourceChanged", condition, tt.wantFresh)
+			}
+		})
+	}
+}
+
+func TestContextSourceToTasks(t *testing.T) {
+	running := newFreshnessTask()
+	running.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
+	running.Status.ContextSources = []kubetaskv1alpha1.ContextSource{
+		{Context: "policy", Namespace: "shared", Kind: "ConfigMap", Name: "security-policy"},
+	}
+	finished := running.DeepCopy()
+	finished.Name = "finished"
+	finished.Status.Phase = kubetaskv1alpha1.TaskPhaseCompleted
+
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(running, finished).Build()
+	mapFunc := contextSourceToTasks(k8sClient, "ConfigMap")
+
+	requests := mapFunc(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "security-policy", Namespace: "shared"}})
+	if len(requests) != 1 || requests[0].Name != "test-task" {
+		t.Errorf("requests = %v, want only the running Task", requests)
+	}
+	if requests := mapFunc(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "security-policy", Namespace: "default"}}); len(requests) != 0 {
+		t.Errorf("requests = %v, want none for a ConfigMap in another namespace", requests)
+	}
+}
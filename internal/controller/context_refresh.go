@@ -0,0 +1,76 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// ContextRefreshInterval is how often running Tasks re-resolve their contexts, which
+// picks up changes to referenced ConfigMaps that are not watched
+const ContextRefreshInterval = 30 * time.Second
+
+// refreshContexts re-resolves the contexts of a running Task and updates its context
+// ConfigMap when the content changed
+func (r *TaskReconciler) refreshContexts(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	log := log.FromContext(ctx)
+
+	cfg, err := r.resolveAgentConfig(ctx, task, true)
+	if err != nil {
+		return err
+	}
+	desired, _, _, _, _, err := r.processAllContexts(ctx, task, cfg)
+	if err != nil || desired == nil {
+		return err
+	}
+
+	current := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, current); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if equality.Semantic.DeepEqual(current.Data, desired.Data) && equality.Semantic.DeepEqual(current.BinaryData, desired.BinaryData) {
+		return nil
+	}
+	existing := current.DeepCopy()
+	current.Data = desired.Data
+	current.BinaryData = desired.BinaryData
+	r.logDiff(ctx, "update", "ConfigMap", current.Name, existing, current, false)
+	if err := r.Update(ctx, current); err != nil {
+		return err
+	}
+	log.Info("refreshed Task contexts", "configMap", current.Name)
+	return nil
+}
+
+// contextToRefreshingTasks maps a Context to the running Tasks in its namespace that
+// refresh their contexts
+func contextToRefreshingTasks(c client.Reader) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		tasks := &kubetaskv1alpha1.TaskList{}
+		if err := c.List(ctx, tasks, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list Tasks for Context", "context", obj.GetName())
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range tasks.Items {
+			task := &tasks.Items[i]
+			if task.Status.Phase == kubetaskv1alpha1.TaskPhaseRunning && build.ContextRefreshEnabled(task) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: task.Name, Namespace: task.Namespace},
+				})
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newRefreshingTask() *kubetaskv1alpha1.Task {
+	description := "Fix the flaky test"
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			Description: &description,
+			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{
+				Enabled:         true,
+				RefreshContexts: true,
+			},
+		},
+	}
+}
+
+func TestRefreshContexts(t *testing.T) {
+	task := newRefreshingTask()
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+		Spec:       kubetaskv1alpha1.AgentSpec{ServiceAccountName: "test-sa", Command: []string{"gemini"}},
+	}
+	stale := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task-context", Namespace: "default"},
+		Data:       map[string]string{"workspace-task.md": "Fix the test"},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(task, agent, stale).Build()
+	r := &TaskReconciler{Client: k8sClient}
+
+	if err := r.refreshContexts(context.Background(), task); err != nil {
+		t.Fatalf("refreshContexts() error = %v", err)
+	}
+
+	got := &corev1.ConfigMap{}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "test-task-context", Namespace: "default"}, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Data["workspace-task.md"] != "Fix the flaky test" {
+		t.Errorf("task.md = %q, want the updated description", got.Data["workspace-task.md"])
+	}
+}
+
+func TestContextToRefreshingTasks(t *testing.T) {
+	refreshing := newRefreshingTask()
+	refreshing.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
+	finished := newRefreshingTask()
+	finished.Name = "finished"
+	finished.Status.Phase = kubetaskv1alpha1.TaskPhaseCompleted
+	plain := newRefreshingTask()
+	plain.Name = "plain"
+	plain.Spec.HumanInTheLoop = nil
+	plain.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
+
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(refreshing, finished, plain).Build()
+	requests := contextToRefreshingTasks(k8sClient)(context.Background(), &kubetaskv1alpha1.Context{
+		ObjectMeta: metav1.ObjectMeta{Name: "guide", Namespace: "default"},
+	})
+	if len(requests) != 1 || requests[0].Name != "test-task" {
+		t.Errorf("requests = %v, want only the running refreshing Task", requests)
+	}
+}
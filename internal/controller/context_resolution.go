@@ -0,0 +1,149 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+const (
+	// DefaultContextConcurrency is how many Contexts of a Task are resolved in parallel
+	DefaultContextConcurrency = 8
+
+	// ContextsResolvedConditionType reports whether the Contexts of a Task were resolved
+	ContextsResolvedConditionType = "ContextsResolved"
+)
+
+// contextRefResult is a resolved Context reference; exactly one field is set unless
+// the Context resolved to nothing
+type contextRefResult struct {
+	rc  *build.ResolvedContext
+	dm  *build.DirMount
+	gm  *build.GitMount
+	om  *build.ObjectStoreMount
+	oci *build.OCIMount
+}
+
+// initContainerMounts are the Contexts of a Task that init containers download
+// into the Pod, rather than the controller into the context ConfigMap
+type initContainerMounts struct {
+	objectStore []build.ObjectStoreMount
+	oci         []build.OCIMount
+}
+
+// apply adds the mounts to the configuration the Task's Job is built from
+func (m initContainerMounts) apply(cfg *build.Config) {
+	cfg.ObjectStoreMounts = m.objectStore
+	cfg.OCIMounts = m.oci
+}
+
+// contextRef is a Context reference of a Task and the resource it was set on
+type contextRef struct {
+	owner string
+	ref   kubetaskv1alpha1.ContextMount
+}
+
+// taskContextRefs returns the Context references of a Task from lowest to highest
+// priority: the namespace's default contexts, then the Agent's, then the Task's.
+// Default contexts that the Agent or Task reference themselves are left out, so
+// they are included once, with the mount path chosen there.
+func taskContextRefs(task *kubetaskv1alpha1.Task, cfg agentConfig) []contextRef {
+	referenced := map[types.NamespacedName]bool{}
+	key := func(ref kubetaskv1alpha1.ContextMount) types.NamespacedName {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = task.Namespace
+		}
+		return types.NamespacedName{Name: ref.Name, Namespace: namespace}
+	}
+	for _, ref := range slices.Concat(cfg.contexts, task.Spec.Contexts) {
+		referenced[key(ref)] = true
+	}
+
+	refs := make([]contextRef, 0, len(cfg.defaultContexts)+len(cfg.contexts)+len(task.Spec.Contexts))
+	for _, ref := range cfg.defaultContexts {
+		if !referenced[key(ref)] {
+			refs = append(refs, contextRef{owner: "KubeTaskConfig", ref: ref})
+		}
+	}
+	for _, ref := range cfg.contexts {
+		refs = append(refs, contextRef{owner: "Agent", ref: ref})
+	}
+	for _, ref := range task.Spec.Contexts {
+		refs = append(refs, contextRef{owner: "Task", ref: ref})
+	}
+	return refs
+}
+
+// resolveContextRefs resolves the namespace, Agent and Task Contexts with bounded
+// concurrency. Results keep the order of taskContextRefs. Every failed reference
+// is reported, not only the first one. Optional references that fail are skipped
+// and returned as warnings instead.
+func (r *TaskReconciler) resolveContextRefs(ctx context.Context, task *kubetaskv1alpha1.Task, cfg agentConfig) ([]contextRefResult, []string, error) {
+	refs := taskContextRefs(task, cfg)
+
+	concurrency := r.ContextConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultContextConcurrency
+	}
+
+	results := make([]contextRefResult, len(refs))
+	errs := make([]error, len(refs))
+	warnings := make([]string, len(refs))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, ref := range refs {
+		g.Go(func() error {
+			result, err := r.resolveContextRef(ctx, ref.ref, task.Namespace, cfg.WorkspaceDir)
+			if err != nil {
+				err = fmt.Errorf("failed to resolve %s context %q: %w", ref.owner, ref.ref.Name, err)
+				if ref.ref.Optional {
+					warnings[i] = err.Error()
+				} else {
+					errs[i] = err
+				}
+				return nil
+			}
+			results[i] = result
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var skipped []string
+	for _, warning := range warnings {
+		if warning != "" {
+			skipped = append(skipped, warning)
+		}
+	}
+	return results, skipped, stderrors.Join(errs...)
+}
+
+// setContextsResolvedCondition records on the Task that its Contexts were resolved,
+// warning about optional Contexts that were skipped
+func setContextsResolvedCondition(task *kubetaskv1alpha1.Task, skipped []string) {
+	condition := metav1.Condition{
+		Type:    ContextsResolvedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllContextsResolved",
+		Message: "All Contexts were resolved",
+	}
+	if len(skipped) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "OptionalContextsSkipped"
+		condition.Message = strings.Join(skipped, "; ")
+	}
+	meta.SetStatusCondition(&task.Status.Conditions, condition)
+}
@@ -0,0 +1,211 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// ContextResolver resolves the content of the Contexts of one type. The built-in
+// resolvers handle Inline, ConfigMap, Git, Kubernetes, URL, ObjectStore, and OCI
+// Contexts; set TaskReconciler.ContextResolvers to add in-house sources, or to
+// replace a built-in one. The type must also be accepted by the
+// ContextType enum of the Context CRD.
+type ContextResolver interface {
+	// ResolveContext returns the content of a Context for a Task. Errors fail the
+	// Task, unless the referencing ContextMount is optional.
+	ResolveContext(ctx context.Context, req ContextResolveRequest) (ContextResolution, error)
+}
+
+// ContextResolveRequest describes a Context referenced by a Task or its Agent
+type ContextResolveRequest struct {
+	// Client reads objects in the cluster, such as ConfigMaps the Context refers to
+	Client client.Reader
+
+	// Context being resolved. Resolvers for custom types usually read their
+	// settings from its annotations.
+	Context *kubetaskv1alpha1.Context
+
+	// MountPath of the ContextMount; empty if the content is appended to task.md
+	MountPath string
+
+	// WorkspaceDir of the Agent the Task runs with
+	WorkspaceDir string
+}
+
+// ContextResolution is the resolved content of a Context. Content is written to
+// the mount path, or appended to task.md without one. Only built-in resolvers can
+// mount a whole ConfigMap directory, or clone a Git repository, download objects
+// or pull an OCI artifact in an init container instead.
+type ContextResolution struct {
+	Content string
+
+	dir         *build.DirMount
+	git         *build.GitMount
+	objectStore *build.ObjectStoreMount
+	oci         *build.OCIMount
+}
+
+// ContextResolverFunc adapts a function to a ContextResolver
+type ContextResolverFunc func(ctx context.Context, req ContextResolveRequest) (ContextResolution, error)
+
+// ResolveContext implements ContextResolver
+func (f ContextResolverFunc) ResolveContext(ctx context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	return f(ctx, req)
+}
+
+// contextResolver returns the resolver for a Context type, preferring the
+// resolvers registered in r.ContextResolvers over the built-in ones
+func (r *TaskReconciler) contextResolver(contextType kubetaskv1alpha1.ContextType) (ContextResolver, error) {
+	if resolver, ok := r.ContextResolvers[contextType]; ok {
+		return resolver, nil
+	}
+	builtin := map[kubetaskv1alpha1.ContextType]ContextResolver{
+		kubetaskv1alpha1.ContextTypeInline:      ContextResolverFunc(resolveInlineContext),
+		kubetaskv1alpha1.ContextTypeConfigMap:   ContextResolverFunc(r.resolveConfigMapContext),
+		kubetaskv1alpha1.ContextTypeGit:         ContextResolverFunc(resolveGitContext),
+		kubetaskv1alpha1.ContextTypeKubernetes:  ContextResolverFunc(r.resolveKubernetesContextSpec),
+		kubetaskv1alpha1.ContextTypeURL:         ContextResolverFunc(r.resolveURLContext),
+		kubetaskv1alpha1.ContextTypeObjectStore: ContextResolverFunc(r.resolveObjectStoreContext),
+		kubetaskv1alpha1.ContextTypeOCI:         ContextResolverFunc(resolveOCIContext),
+	}
+	if resolver, ok := builtin[contextType]; ok {
+		return resolver, nil
+	}
+	return nil, fmt.Errorf("unknown context type: %s", contextType)
+}
+
+// resolveInlineContext returns the inline content of a Context
+func resolveInlineContext(_ context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	inline := req.Context.Spec.Inline
+	if inline == nil {
+		return ContextResolution{}, nil
+	}
+	if len(inline.BinaryContent) > 0 {
+		if inline.Content != "" {
+			return ContextResolution{}, fmt.Errorf("Context %q sets both inline content and binaryContent", req.Context.Name)
+		}
+		// Binary content travels as raw bytes and is routed to binaryData in processAllContexts
+		return ContextResolution{Content: string(inline.BinaryContent)}, nil
+	}
+	return ContextResolution{Content: inline.Content}, nil
+}
+
+// resolveConfigMapContext returns a key or all keys of a ConfigMap, or mounts the
+// whole ConfigMap as a directory when no key but a mount path is set
+func (r *TaskReconciler) resolveConfigMapContext(ctx context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	cm := req.Context.Spec.ConfigMap
+	if cm == nil {
+		return ContextResolution{}, nil
+	}
+	namespace := req.Context.Namespace
+
+	// If Key is specified, return the content
+	if cm.Key != "" {
+		content, err := r.getConfigMapKey(ctx, namespace, cm.Name, cm.Key, cm.Optional)
+		return ContextResolution{Content: content}, err
+	}
+
+	// If Key is not specified but mountPath is, return a directory mount
+	if req.MountPath != "" {
+		optional := false
+		if cm.Optional != nil {
+			optional = *cm.Optional
+		}
+		return ContextResolution{dir: &build.DirMount{
+			Path:          req.MountPath,
+			ConfigMapName: cm.Name,
+			Optional:      optional,
+		}}, nil
+	}
+
+	// If Key is not specified and mountPath is empty, aggregate all keys to task.md
+	content, err := r.getConfigMapAllKeys(ctx, namespace, cm.Name, cm.Optional)
+	return ContextResolution{Content: content}, err
+}
+
+// resolveGitContext returns the Git repository to clone for a Context
+func resolveGitContext(_ context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	git := req.Context.Spec.Git
+	if git == nil {
+		return ContextResolution{}, nil
+	}
+	name := req.Context.Name
+
+	// Determine mount path: use specified path or default to ${WORKSPACE_DIR}/git-<context-name>/
+	mountPath := req.MountPath
+	if mountPath == "" {
+		mountPath = build.WorkspacePath(req.WorkspaceDir, "git-"+name)
+	}
+
+	// Determine clone depth: default to 1 (shallow clone)
+	depth := 1
+	if git.Depth != nil && *git.Depth > 0 {
+		depth = *git.Depth
+	}
+
+	// Determine ref: default to HEAD
+	ref := git.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	// Get secret name if specified
+	secretName := ""
+	if git.SecretRef != nil {
+		secretName = git.SecretRef.Name
+	}
+
+	return ContextResolution{git: &build.GitMount{
+		ContextName: name,
+		Repository:  git.Repository,
+		Ref:         ref,
+		RepoPath:    git.Path,
+		MountPath:   mountPath,
+		Depth:       depth,
+		SecretName:  secretName,
+	}}, nil
+}
+
+// resolveOCIContext returns the OCI artifact to pull for a Context
+func resolveOCIContext(_ context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	oci := req.Context.Spec.OCI
+	if oci == nil {
+		return ContextResolution{}, nil
+	}
+	name := req.Context.Name
+
+	// Determine mount path: use specified path or default to ${WORKSPACE_DIR}/oci-<context-name>/
+	mountPath := req.MountPath
+	if mountPath == "" {
+		mountPath = build.WorkspacePath(req.WorkspaceDir, "oci-"+name)
+	}
+
+	pullSecret := ""
+	if oci.PullSecretRef != nil {
+		pullSecret = oci.PullSecretRef.Name
+	}
+
+	return ContextResolution{oci: &build.OCIMount{
+		ContextName:    name,
+		Image:          oci.Image,
+		Path:           oci.Path,
+		MountPath:      mountPath,
+		PullSecretName: pullSecret,
+	}}, nil
+}
+
+// resolveKubernetesContextSpec returns the live objects selected by a Kubernetes Context
+func (r *TaskReconciler) resolveKubernetesContextSpec(ctx context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	if req.Context.Spec.Kubernetes == nil {
+		return ContextResolution{}, nil
+	}
+	content, err := r.resolveKubernetesContext(ctx, req.Context.Namespace, req.Context.Spec.Kubernetes)
+	return ContextResolution{Content: content}, err
+}
@@ -0,0 +1,174 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestResolveContextRef_CustomResolver(t *testing.T) {
+	const httpType kubetaskv1alpha1.ContextType = "HTTP"
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "runbook",
+				Namespace:   "default",
+				Annotations: map[string]string{"example.com/url": "https://wiki.example.com/runbook"},
+			},
+			Spec: kubetaskv1alpha1.ContextSpec{Type: httpType},
+		},
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "notes", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:   kubetaskv1alpha1.ContextTypeInline,
+				Inline: &kubetaskv1alpha1.InlineContext{Content: "original"},
+			},
+		},
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "artifact", Namespace: "default"},
+			Spec:       kubetaskv1alpha1.ContextSpec{Type: "Helm"},
+		},
+	).Build()
+
+	var got ContextResolveRequest
+	r := &TaskReconciler{
+		Client: c,
+		ContextResolvers: map[kubetaskv1alpha1.ContextType]ContextResolver{
+			httpType: ContextResolverFunc(func(_ context.Context, req ContextResolveRequest) (ContextResolution, error) {
+				got = req
+				return ContextResolution{Content: "fetched " + req.Context.Annotations["example.com/url"]}, nil
+			}),
+			kubetaskv1alpha1.ContextTypeInline: ContextResolverFunc(func(_ context.Context, req ContextResolveRequest) (ContextResolution, error) {
+				return ContextResolution{Content: strings.ToUpper(req.Context.Spec.Inline.Content)}, nil
+			}),
+		},
+	}
+
+	t.Run("additional type", func(t *testing.T) {
+		result, err := r.resolveContextRef(context.Background(), kubetaskv1alpha1.ContextMount{Name: "runbook", MountPath: "/workspace/runbook.md"}, "default", "/workspace")
+		if err != nil {
+			t.Fatalf("resolveContextRef() error = %v", err)
+		}
+		if rc := result.rc; rc == nil || rc.Content != "fetched https://wiki.example.com/runbook" || rc.MountPath != "/workspace/runbook.md" {
+			t.Errorf("resolveContextRef() = %+v, want the custom resolver's content", rc)
+		}
+		if got.Client == nil || got.WorkspaceDir != "/workspace" || got.MountPath != "/workspace/runbook.md" {
+			t.Errorf("ContextResolveRequest = %+v, want the client, workspace dir and mount path", got)
+		}
+	})
+
+	t.Run("replaced built-in type", func(t *testing.T) {
+		result, err := r.resolveContextRef(context.Background(), kubetaskv1alpha1.ContextMount{Name: "notes"}, "default", "/workspace")
+		if err != nil {
+			t.Fatalf("resolveContextRef() error = %v", err)
+		}
+		if result.rc == nil || result.rc.Content != "ORIGINAL" {
+			t.Errorf("resolveContextRef() = %+v, want the replacing resolver's content", result.rc)
+		}
+	})
+
+	t.Run("unregistered type", func(t *testing.T) {
+		_, err := r.resolveContextRef(context.Background(), kubetaskv1alpha1.ContextMount{Name: "artifact"}, "default", "/workspace")
+		if err == nil || !strings.Contains(err.Error(), "unknown context type: Helm") {
+			t.Errorf("resolveContextRef() error = %v, want unknown context type", err)
+		}
+	})
+}
+
+func TestResolveContextRef_Git(t *testing.T) {
+	depth := 10
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "guides", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type: kubetaskv1alpha1.ContextTypeGit,
+				Git: &kubetaskv1alpha1.GitContext{
+					Repository: "https://github.com/org/guides.git",
+					Ref:        "v2",
+					Path:       "docs/go",
+					Depth:      &depth,
+					SecretRef:  &kubetaskv1alpha1.GitSecretReference{Name: "git-credentials"},
+				},
+			},
+		},
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type: kubetaskv1alpha1.ContextTypeGit,
+				Git:  &kubetaskv1alpha1.GitContext{Repository: "https://github.com/org/repo.git"},
+			},
+		},
+	).Build()
+	r := &TaskReconciler{Client: c}
+
+	result, err := r.resolveContextRef(context.Background(), kubetaskv1alpha1.ContextMount{Name: "guides", MountPath: "/workspace/guides"}, "default", "/workspace")
+	if err != nil {
+		t.Fatalf("resolveContextRef() error = %v", err)
+	}
+	if result.rc != nil || result.dm != nil || result.gm == nil {
+		t.Fatalf("resolveContextRef() = %+v, want only a Git mount", result)
+	}
+	want := build.GitMount{
+		ContextName: "guides",
+		Repository:  "https://github.com/org/guides.git",
+		Ref:         "v2",
+		RepoPath:    "docs/go",
+		MountPath:   "/workspace/guides",
+		Depth:       10,
+		SecretName:  "git-credentials",
+	}
+	if *result.gm != want {
+		t.Errorf("Git mount = %+v, want %+v", *result.gm, want)
+	}
+
+	result, err = r.resolveContextRef(context.Background(), kubetaskv1alpha1.ContextMount{Name: "repo"}, "default", "/workspace")
+	if err != nil {
+		t.Fatalf("resolveContextRef() error = %v", err)
+	}
+	if gm := result.gm; gm == nil || gm.Ref != "HEAD" || gm.Depth != 1 || gm.MountPath != "/workspace/git-repo" || gm.SecretName != "" {
+		t.Errorf("Git mount with defaults = %+v, want HEAD, depth 1, mounted at /workspace/git-repo", gm)
+	}
+}
+
+func TestResolveContextRef_OCI(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "review-prompts", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type: kubetaskv1alpha1.ContextTypeOCI,
+				OCI: &kubetaskv1alpha1.OCIContext{
+					Image:         "registry.example.com/prompts/review:v3",
+					Path:          "prompts",
+					PullSecretRef: &corev1.LocalObjectReference{Name: "registry-credentials"},
+				},
+			},
+		},
+	).Build()
+	r := &TaskReconciler{Client: c}
+
+	result, err := r.resolveContextRef(context.Background(), kubetaskv1alpha1.ContextMount{Name: "review-prompts"}, "default", "/workspace")
+	if err != nil {
+		t.Fatalf("resolveContextRef() error = %v", err)
+	}
+	want := build.OCIMount{
+		ContextName:    "review-prompts",
+		Image:          "registry.example.com/prompts/review:v3",
+		Path:           "prompts",
+		MountPath:      "/workspace/oci-review-prompts",
+		PullSecretName: "registry-credentials",
+	}
+	if result.oci == nil || *result.oci != want {
+		t.Errorf("resolveContextRef() = %+v, want OCI mount %+v", result, want)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// ContextsScannedConditionType reports whether scanning the resolved contexts of a
+// Task found secrets-looking strings or prompt-injection patterns
+const ContextsScannedConditionType = "ContextsScanned"
+
+// maxReportedFindings bounds the findings listed in the condition message
+const maxReportedFindings = 10
+
+// ContextFinding is a problem a ContextScanner found in context content.
+// Messages must not quote the matched text, since it may be a secret.
+type ContextFinding struct {
+	// Rule identifies the check that matched, e.g. "aws-access-key"
+	Rule string `json:"rule"`
+
+	// Message describes the finding
+	Message string `json:"message,omitempty"`
+}
+
+// ContextScanner inspects resolved context content before it is written to the
+// context ConfigMap. Findings are surfaced as warnings and do not block the Task.
+type ContextScanner interface {
+	// Scan returns the findings in content. source names the content,
+	// e.g. `Context "guides"` or "description".
+	Scan(ctx context.Context, source, content string) ([]ContextFinding, error)
+}
+
+// ContextScanRule is a named regular expression checked by PatternScanner
+type ContextScanRule struct {
+	Name    string
+	Message string
+	Pattern *regexp.Regexp
+}
+
+// DefaultContextScanRules detect common credential formats and prompt-injection phrases
+var DefaultContextScanRules = []ContextScanRule{
+	{
+		Name:    "private-key",
+		Message: "looks like a private key",
+		Pattern: regexp.MustCompile(`-----BEGIN ((RSA|EC|DSA|OPENSSH|PGP) )?PRIVATE KEY( BLOCK)?-----`),
+	},
+	{
+		Name:    "aws-access-key",
+		Message: "looks like an AWS access key ID",
+		Pattern: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		Name:    "github-token",
+		Message: "looks like a GitHub token",
+		Pattern: regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`),
+	},
+	{
+		Name:    "slack-token",
+		Message: "looks like a Slack token",
+		Pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+	},
+	{
+		Name:    "api-key",
+		Message: "looks like an API key",
+		Pattern: regexp.MustCompile(`\b(sk-[A-Za-z0-9_-]{32,}|AIza[0-9A-Za-z_-]{35})\b`),
+	},
+	{
+		Name:    "ignore-instructions",
+		Message: "asks the agent to ignore its instructions",
+		Pattern: regexp.MustCompile(`(?i)\b(ignore|disregard|forget)\s+(all\s+)?(the\s+)?(previous|prior|above|earlier)\s+(instructions|prompts|rules)`),
+	},
+	{
+		Name:    "role-override",
+		Message: "tries to redefine the agent's role or system prompt",
+		Pattern: regexp.MustCompile(`(?i)(\byou are now\b|\bnew system prompt\b|<\s*/?\s*system\s*>)`),
+	},
+	{
+		Name:    "context-tag-escape",
+		Message: "contains a closing </context> tag that would end its section of task.md",
+		Pattern: regexp.MustCompile(`</context>`),
+	},
+}
+
+// PatternScanner reports every rule whose pattern matches the content
+type PatternScanner struct {
+	// Rules are checked in order, DefaultContextScanRules if nil
+	Rules []ContextScanRule
+}
+
+var _ ContextScanner = &PatternScanner{}
+
+// Scan implements ContextScanner
+func (s *PatternScanner) Scan(_ context.Context, _, content string) ([]ContextFinding, error) {
+	rules := s.Rules
+	if rules == nil {
+		rules = DefaultContextScanRules
+	}
+	var findings []ContextFinding
+	for _, rule := range rules {
+		if n := len(rule.Pattern.FindAllStringIndex(content, -1)); n > 0 {
+			message := rule.Message
+			if n > 1 {
+				message = fmt.Sprintf("%s (%d matches)", message, n)
+			}
+			findings = append(findings, ContextFinding{Rule: rule.Name, Message: message})
+		}
+	}
+	return findings, nil
+}
+
+// WebhookScanner delegates scanning to an HTTP endpoint. It POSTs
+// {"source": ..., "content": ...} and expects {"findings": [{"rule": ..., "message": ...}]}.
+type WebhookScanner struct {
+	// URL of the scanning endpoint
+	URL string
+
+	// HTTPClient is used for requests, a client with a 10s timeout if nil
+	HTTPClient *http.Client
+}
+
+var _ ContextScanner = &WebhookScanner{}
+
+// defaultScanHTTPClient is used by webhook scanners that do not set an HTTP client
+var defaultScanHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// Scan implements ContextScanner
+func (s *WebhookScanner) Scan(ctx context.Context, source, content string) ([]ContextFinding, error) {
+	body, err := json.Marshal(map[string]string{"source": source, "content": content})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultScanHTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("context scan webhook returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	var out struct {
+		Findings []ContextFinding `json:"findings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decoding context scan webhook response: %w", err)
+	}
+	return out.Findings, nil
+}
+
+// scanContexts runs the reconciler's scanners over the description and the text
+// contexts and records the result in the ContextsScanned condition. Failing
+// scanners are reported on the condition instead of blocking the Task.
+func (r *TaskReconciler) scanContexts(ctx context.Context, task *kubetaskv1alpha1.Task, description string, resolved []build.ResolvedContext) {
+	if len(r.ContextScanners) == 0 {
+		return
+	}
+
+	type source struct{ name, content string }
+	var sources []source
+	if description != "" {
+		sources = append(sources, source{name: "description", content: description})
+	}
+	for _, rc := range resolved {
+		if !utf8.ValidString(rc.Content) {
+			continue // binary files are mounted as is
+		}
+		name := fmt.Sprintf("Context %q", rc.Name)
+		if rc.Type == workspaceFileType {
+			name = fmt.Sprintf("file %q", rc.Name)
+		}
+		sources = append(sources, source{name: name, content: rc.Content})
+	}
+
+	var findings, failures, rules []string
+	for _, src := range sources {
+		for _, scanner := range r.ContextScanners {
+			found, err := scanner.Scan(ctx, src.name, src.content)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", src.name, err))
+				continue
+			}
+			for _, f := range found {
+				rules = append(rules, f.Rule)
+				findings = append(findings, fmt.Sprintf("%s: %s (%s)", src.name, f.Message, f.Rule))
+			}
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:    ContextsScannedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "NoFindings",
+		Message: fmt.Sprintf("%d sources scanned", len(sources)),
+	}
+	if len(failures) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ScanFailed"
+		condition.Message = joinFindings(append(failures, findings...))
+	} else if len(findings) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "FindingsDetected"
+		condition.Message = joinFindings(findings)
+	}
+	// Count findings once, not on every retry of a pending Task
+	if meta.SetStatusCondition(&task.Status.Conditions, condition) {
+		for _, rule := range rules {
+			contextScanFindings.WithLabelValues(task.Namespace, rule).Inc()
+		}
+	}
+}
+
+// joinFindings joins findings for a condition message, listing at most maxReportedFindings
+func joinFindings(findings []string) string {
+	if len(findings) <= maxReportedFindings {
+		return strings.Join(findings, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(findings[:maxReportedFindings], "; "), len(findings)-maxReportedFindings)
+}
@@ -0,0 +1,61 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+const (
+	// CredentialExpiresAtAnnotation on a credential's Secret holds the RFC 3339
+	// time the credential expires, checked by the Agent's credentialExpiry policy
+	CredentialExpiresAtAnnotation = "kubetask.io/expires-at"
+
+	// ReloadCredentialsAnnotation on Task Pods lists, comma-separated, the mount
+	// paths of credentials with reloadOnChange. The kubelet updates these files when
+	// the Secret is rotated, so agents should re-read them rather than cache them.
+	ReloadCredentialsAnnotation = build.ReloadCredentialsAnnotation
+)
+
+// checkCredentialExpiry returns a message naming the first credential that expires
+// within the Agent's credentialMinValidity of now, or "" if all remain valid long enough.
+// Secrets without the expires-at annotation never expire.
+func (r *TaskReconciler) checkCredentialExpiry(ctx context.Context, task *kubetaskv1alpha1.Task, cfg agentConfig, now time.Time) (string, error) {
+	deadline := now.Add(*cfg.credentialMinValidity)
+	checked := map[string]bool{}
+	for _, cred := range cfg.Credentials {
+		if checked[cred.SecretRef.Name] {
+			continue
+		}
+		checked[cred.SecretRef.Name] = true
+
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: cred.SecretRef.Name, Namespace: task.Namespace}, secret); err != nil {
+			return "", err
+		}
+		value, ok := secret.Annotations[CredentialExpiresAtAnnotation]
+		if !ok {
+			continue
+		}
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Sprintf("credential %q: Secret %q has invalid %s annotation %q", cred.Name, secret.Name, CredentialExpiresAtAnnotation, value), nil
+		}
+		if expiresAt.Before(now) {
+			return fmt.Sprintf("credential %q expired at %s", cred.Name, expiresAt.Format(time.RFC3339)), nil
+		}
+		if expiresAt.Before(deadline) {
+			return fmt.Sprintf("credential %q expires at %s, less than the required %s after the Task starts",
+				cred.Name, expiresAt.Format(time.RFC3339), *cfg.credentialMinValidity), nil
+		}
+	}
+	return "", nil
+}
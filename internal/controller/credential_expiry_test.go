@@ -0,0 +1,71 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestCheckCredentialExpiry(t *testing.T) {
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	secret := func(name, expiresAt string) *corev1.Secret {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		if expiresAt != "" {
+			s.Annotations = map[string]string{CredentialExpiresAtAnnotation: expiresAt}
+		}
+		return s
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(
+		secret("static", ""),
+		secret("expired", "2026-05-04T11:00:00Z"),
+		secret("soon", "2026-05-04T13:00:00Z"),
+		secret("later", "2026-05-05T12:00:00Z"),
+		secret("invalid", "tomorrow"),
+	).Build()
+	r := &TaskReconciler{Client: c}
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"}}
+
+	tests := []struct {
+		name        string
+		secrets     []string
+		minValidity time.Duration
+		want        string
+	}{
+		{name: "no annotation", secrets: []string{"static"}, minValidity: 24 * time.Hour},
+		{name: "valid long enough", secrets: []string{"static", "later"}, minValidity: 2 * time.Hour},
+		{name: "expires during the Task", secrets: []string{"later", "soon"}, minValidity: 2 * time.Hour, want: `credential "soon" expires at 2026-05-04T13:00:00Z`},
+		{name: "expires after start without minValidity", secrets: []string{"soon"}},
+		{name: "already expired", secrets: []string{"expired"}, want: `credential "expired" expired at`},
+		{name: "invalid annotation", secrets: []string{"invalid"}, want: "invalid kubetask.io/expires-at annotation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := agentConfig{credentialMinValidity: &tt.minValidity}
+			for _, name := range tt.secrets {
+				cfg.Credentials = append(cfg.Credentials, kubetaskv1alpha1.Credential{
+					Name:      name,
+					SecretRef: kubetaskv1alpha1.SecretReference{Name: name},
+				})
+			}
+
+			got, err := r.checkCredentialExpiry(context.Background(), task, cfg, now)
+			if err != nil {
+				t.Fatalf("checkCredentialExpiry() error = %v", err)
+			}
+			if tt.want == "" && got != "" || !strings.Contains(got, tt.want) {
+				t.Errorf("checkCredentialExpiry() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
 )
 
 const (
@@ -44,16 +45,6 @@ type CronTaskReconciler struct {
 	Clock  // for testing
 }
 
-// Clock interface for time operations, allows mocking in tests
-type Clock interface {
-	Now() time.Time
-}
-
-// realClock implements Clock using the real time
-type realClock struct{}
-
-func (realClock) Now() time.Time { return time.Now() }
-
 // +kubebuilder:rbac:groups=kubetask.io,resources=crontasks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kubetask.io,resources=crontasks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kubetask.io,resources=crontasks/finalizers,verbs=update
@@ -156,6 +147,16 @@ func (r *CronTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		log.V(1).Info("missed scheduled runs", "count", missedRuns)
 	}
 
+	// A previous reconcile may have created the Task of this run but failed to
+	// update the status; don't let the concurrency policy skip or replace it
+	if scheduledTime != nil {
+		if taskName := scheduledTaskName(cronTask, *scheduledTime); hasTask(childTasks, taskName) {
+			log.V(1).Info("Task of the scheduled run already exists", "task", taskName)
+			recordScheduledTask(cronTask, taskName, *scheduledTime)
+			scheduledTime = nil
+		}
+	}
+
 	// Check if we need to create a new Task
 	if scheduledTime != nil {
 		// Handle concurrency policy
@@ -174,10 +175,16 @@ func (r *CronTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 				// Delete all active tasks
 				for _, task := range activeTasks {
 					log.Info("deleting active task due to Replace policy", "task", task.Name)
-					if err := r.Delete(ctx, task); err != nil && !errors.IsNotFound(err) {
+					if err := r.Delete(ctx, task); err != nil {
+						if errors.IsNotFound(err) {
+							continue
+						}
 						log.Error(err, "unable to delete active task", "task", task.Name)
 						return ctrl.Result{}, err
 					}
+					entry := audit.ForTask(task, audit.ActionCancelled, audit.ControllerActor)
+					entry.Message = "replaced by a new run of CronTask " + cronTask.Name
+					audit.Record(entry)
 				}
 				// Clear active references
 				cronTask.Status.Active = nil
@@ -194,17 +201,7 @@ func (r *CronTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 
 		log.Info("created Task", "task", task.Name, "scheduledTime", scheduledTime)
-
-		// Update last schedule time
-		cronTask.Status.LastScheduleTime = &metav1.Time{Time: *scheduledTime}
-
-		// Update condition
-		meta.SetStatusCondition(&cronTask.Status.Conditions, metav1.Condition{
-			Type:    "Scheduled",
-			Status:  metav1.ConditionTrue,
-			Reason:  "TaskCreated",
-			Message: fmt.Sprintf("Created Task %s", task.Name),
-		})
+		recordScheduledTask(cronTask, task.Name, *scheduledTime)
 	}
 
 	// Update status
@@ -279,10 +276,35 @@ func (r *CronTaskReconciler) requeueForNextSchedule(_ *kubetaskv1alpha1.CronTask
 	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
+// scheduledTaskName returns the name of the Task of a scheduled run, unique per run
+func scheduledTaskName(cronTask *kubetaskv1alpha1.CronTask, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", cronTask.Name, scheduledTime.Unix())
+}
+
+// hasTask reports whether tasks include one with the given name
+func hasTask(tasks []kubetaskv1alpha1.Task, name string) bool {
+	for i := range tasks {
+		if tasks[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recordScheduledTask records in the CronTask's status that the Task of a run was created
+func recordScheduledTask(cronTask *kubetaskv1alpha1.CronTask, taskName string, scheduledTime time.Time) {
+	cronTask.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	meta.SetStatusCondition(&cronTask.Status.Conditions, metav1.Condition{
+		Type:    "Scheduled",
+		Status:  metav1.ConditionTrue,
+		Reason:  "TaskCreated",
+		Message: fmt.Sprintf("Created Task %s", taskName),
+	})
+}
+
 // createTask creates a new Task from the CronTask template
 func (r *CronTaskReconciler) createTask(ctx context.Context, cronTask *kubetaskv1alpha1.CronTask, scheduledTime time.Time) (*kubetaskv1alpha1.Task, error) {
-	// Generate unique task name using timestamp
-	taskName := fmt.Sprintf("%s-%d", cronTask.Name, scheduledTime.Unix())
+	taskName := scheduledTaskName(cronTask, scheduledTime)
 
 	// Create Task from template
 	task := &kubetaskv1alpha1.Task{
@@ -319,16 +341,32 @@ func (r *CronTaskReconciler) createTask(ctx context.Context, cronTask *kubetaskv
 	}
 
 	if err := r.Create(ctx, task); err != nil {
-		return nil, err
+		if !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		// Created by a previous reconcile the cache has not seen yet
+		existing := &kubetaskv1alpha1.Task{}
+		if err := r.Get(ctx, types.NamespacedName{Name: taskName, Namespace: cronTask.Namespace}, existing); err != nil {
+			return nil, err
+		}
+		if !metav1.IsControlledBy(existing, cronTask) {
+			return nil, fmt.Errorf("Task %q already exists and is not owned by CronTask %q", taskName, cronTask.Name)
+		}
+		return existing, nil
 	}
 
 	return task, nil
 }
 
 // cleanupTasks removes old tasks based on history limits
-func (r *CronTaskReconciler) cleanupTasks(ctx context.Context, cronTask *kubetaskv1alpha1.CronTask, successfulTasks, failedTasks []*kubetaskv1alpha1.Task) error {
+func (r *CronTaskReconciler) cleanupTasks(ctx context.Context, cronTask *kubetaskv1alpha1.CronTask, successfulTasks, failedTasks []*kubetaskv1alpha1.Task) (err error) {
 	log := log.FromContext(ctx)
 
+	start := time.Now()
+	defer func() {
+		cronTaskHistoryCleanupDuration.WithLabelValues(cronTask.Namespace, cleanupResult(err)).Observe(time.Since(start).Seconds())
+	}()
+
 	successLimit := DefaultSuccessfulTasksHistoryLimit
 	if cronTask.Spec.SuccessfulTasksHistoryLimit != nil {
 		successLimit = *cronTask.Spec.SuccessfulTasksHistoryLimit
@@ -351,7 +389,7 @@ func (r *CronTaskReconciler) cleanupTasks(ctx context.Context, cronTask *kubetas
 	for i := 0; i < len(successfulTasks)-int(successLimit); i++ {
 		task := successfulTasks[i]
 		log.V(1).Info("deleting old successful task", "task", task.Name)
-		if err := r.Delete(ctx, task); err != nil && !errors.IsNotFound(err) {
+		if err := r.deleteHistoryTask(ctx, task); err != nil {
 			return err
 		}
 	}
@@ -361,7 +399,7 @@ func (r *CronTaskReconciler) cleanupTasks(ctx context.Context, cronTask *kubetas
 	for i := 0; i < len(failedTasks)-int(failedLimit); i++ {
 		task := failedTasks[i]
 		log.V(1).Info("deleting old failed task", "task", task.Name)
-		if err := r.Delete(ctx, task); err != nil && !errors.IsNotFound(err) {
+		if err := r.deleteHistoryTask(ctx, task); err != nil {
 			return err
 		}
 	}
@@ -369,6 +407,22 @@ func (r *CronTaskReconciler) cleanupTasks(ctx context.Context, cronTask *kubetas
 	return nil
 }
 
+// deleteHistoryTask deletes a Task exceeding the history limits of its CronTask
+func (r *CronTaskReconciler) deleteHistoryTask(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	err := r.Delete(ctx, task)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	taskCleanupDeletions.WithLabelValues(task.Namespace, cleanupReasonHistory, cleanupResult(err)).Inc()
+	if err != nil {
+		return err
+	}
+	entry := audit.ForTask(task, audit.ActionDeleted, audit.ControllerActor)
+	entry.Message = "exceeded the history limit of its CronTask"
+	audit.Record(entry)
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager
 func (r *CronTaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Index Tasks by the CronTask label for efficient lookup
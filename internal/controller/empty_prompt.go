@@ -0,0 +1,45 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// PromptProvidedConditionType reports whether a Task gives its agent any instructions
+// in ${WORKSPACE_DIR}/task.md
+const PromptProvidedConditionType = "PromptProvided"
+
+// setPromptProvidedCondition records whether task.md is written for the Task. A Task
+// whose description and descriptionFrom resolve to nothing and that has no contexts
+// without a mountPath still starts, but without task.md; the condition is False then,
+// so that the empty prompt shows up on the Task instead of only in the agent's output.
+func setPromptProvidedCondition(task *kubetaskv1alpha1.Task, description string, resolved []build.ResolvedContext) {
+	condition := metav1.Condition{
+		Type:    PromptProvidedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "TaskMdWritten",
+		Message: "task.md holds the Task's instructions",
+	}
+	if description == "" && !hasTaskMdContext(resolved) {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "EmptyPrompt"
+		condition.Message = "The Task has no description and no contexts for task.md; the agent starts without task.md"
+	}
+	meta.SetStatusCondition(&task.Status.Conditions, condition)
+}
+
+// hasTaskMdContext reports whether any resolved context is appended to task.md
+// rather than mounted at a path of its own
+func hasTaskMdContext(resolved []build.ResolvedContext) bool {
+	for _, rc := range resolved {
+		if rc.MountPath == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestProcessAllContexts_PromptProvided(t *testing.T) {
+	guide := &kubetaskv1alpha1.Context{
+		ObjectMeta: metav1.ObjectMeta{Name: "guide", Namespace: "default"},
+		Spec: kubetaskv1alpha1.ContextSpec{
+			Type:   kubetaskv1alpha1.ContextTypeInline,
+			Inline: &kubetaskv1alpha1.InlineContext{Content: "Use gofmt."},
+		},
+	}
+	emptyDescription := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prompts", Namespace: "default"},
+		Data:       map[string]string{"fix": ""},
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(guide, emptyDescription).Build()}
+	cfg := agentConfig{Config: build.Config{WorkspaceDir: "/workspace"}}
+
+	tests := []struct {
+		name       string
+		spec       kubetaskv1alpha1.TaskSpec
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "description",
+			spec:       kubetaskv1alpha1.TaskSpec{Description: stringPtr("Fix the flaky test")},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "TaskMdWritten",
+		},
+		{
+			name:       "context appended to task.md",
+			spec:       kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "guide"}}},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "TaskMdWritten",
+		},
+		{
+			name:       "only a mounted context",
+			spec:       kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "guide", MountPath: "/workspace/guide.md"}}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "EmptyPrompt",
+		},
+		{
+			name: "empty descriptionFrom",
+			spec: kubetaskv1alpha1.TaskSpec{DescriptionFrom: &kubetaskv1alpha1.DescriptionSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"},
+					Key:                  "fix",
+				},
+			}},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "EmptyPrompt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			cm, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+			if err != nil {
+				t.Fatalf("processAllContexts() error = %v", err)
+			}
+			cond := meta.FindStatusCondition(task.Status.Conditions, PromptProvidedConditionType)
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Fatalf("PromptProvided = %+v, want %s/%s", cond, tt.wantStatus, tt.wantReason)
+			}
+			hasTaskMd := false
+			if cm != nil {
+				_, hasTaskMd = cm.Data["workspace-task.md"]
+			}
+			if hasTaskMd != (tt.wantStatus == metav1.ConditionTrue) {
+				t.Errorf("task.md written = %v, want %v", hasTaskMd, tt.wantStatus == metav1.ConditionTrue)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// ExternalRunAnnotation marks a Task as the record of a workflow executed by another
+	// engine, as "<engine>/<name>". The Task controller does not run or clean up such Tasks;
+	// their status is mirrored from the workflow, and they are deleted along with it.
+	ExternalRunAnnotation = "kubetask.io/external-run"
+
+	// ExternalRunResultName is the Argo Workflow output parameter or Tekton PipelineRun
+	// result holding the agent's KubeTask-* trailers, mirrored into Task status.changes
+	ExternalRunResultName = "kubetask-changes"
+)
+
+// ExternalEngine describes a workflow engine whose runs are mirrored into Tasks
+type ExternalEngine struct {
+	// Name identifies the engine in flags and the external-run annotation
+	Name string
+
+	// Kind is the workflow resource mirrored into Tasks
+	Kind schema.GroupVersionKind
+
+	// status reads the Task status from a workflow
+	status func(run *unstructured.Unstructured) externalRunStatus
+}
+
+var (
+	// ArgoWorkflows mirrors Argo Workflows
+	ArgoWorkflows = ExternalEngine{
+		Name:   "argo",
+		Kind:   schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"},
+		status: argoRunStatus,
+	}
+
+	// TektonPipelines mirrors Tekton PipelineRuns
+	TektonPipelines = ExternalEngine{
+		Name:   "tekton",
+		Kind:   schema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "PipelineRun"},
+		status: tektonRunStatus,
+	}
+
+	// ExternalEngines lists the supported engines by name
+	ExternalEngines = map[string]ExternalEngine{
+		ArgoWorkflows.Name:   ArgoWorkflows,
+		TektonPipelines.Name: TektonPipelines,
+	}
+)
+
+// externalRunStatus is the engine-independent state of a workflow
+type externalRunStatus struct {
+	phase          kubetaskv1alpha1.TaskPhase
+	startTime      *metav1.Time
+	completionTime *metav1.Time
+	message        string
+	result         string
+}
+
+// ExternalRunReconciler mirrors workflows labeled with kubetask.io/task into Task
+// records, so agent activity is visible through the Task API regardless of the
+// engine that executed it. kubetask-export sets the label on the resources it renders.
+type ExternalRunReconciler struct {
+	client.Client
+
+	// Engine is the workflow engine whose runs are mirrored
+	Engine ExternalEngine
+}
+
+// +kubebuilder:rbac:groups=argoproj.io,resources=workflows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=tekton.dev,resources=pipelineruns,verbs=get;list;watch
+
+// Reconcile creates or updates the Task record of a workflow
+func (r *ExternalRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	run := &unstructured.Unstructured{}
+	run.SetGroupVersionKind(r.Engine.Kind)
+	if err := r.Get(ctx, req.NamespacedName, run); err != nil {
+		// The Task record is garbage collected with the workflow
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	source := run.GetLabels()[TaskLabelKey]
+	if source == "" {
+		return ctrl.Result{}, nil
+	}
+	externalRun := r.Engine.Name + "/" + run.GetName()
+
+	task := &kubetaskv1alpha1.Task{}
+	err := r.Get(ctx, types.NamespacedName{Name: run.GetName(), Namespace: run.GetNamespace()}, task)
+	if errors.IsNotFound(err) {
+		task, err = r.createTaskRecord(ctx, run, source, externalRun)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if task.Annotations[ExternalRunAnnotation] != externalRun {
+		// A Task run by KubeTask itself has the same name, leave it alone
+		log.Info("skipping workflow, a Task with the same name exists", "task", task.Name)
+		return ctrl.Result{}, nil
+	}
+
+	status := r.Engine.status(run)
+	updated := task.Status.DeepCopy()
+	updated.Phase = status.phase
+	updated.StartTime = status.startTime
+	updated.CompletionTime = status.completionTime
+	if status.result != "" {
+		updated.Changes = taskChanges(parseTrailers(status.result))
+	}
+	switch status.phase {
+	case kubetaskv1alpha1.TaskPhaseCompleted:
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionTrue,
+			Reason:  "ExternalRunSucceeded",
+			Message: fmt.Sprintf("%s %s succeeded", r.Engine.Kind.Kind, run.GetName()),
+		})
+	case kubetaskv1alpha1.TaskPhaseFailed:
+		message := fmt.Sprintf("%s %s failed", r.Engine.Kind.Kind, run.GetName())
+		if status.message != "" {
+			message += ": " + status.message
+		}
+		meta.SetStatusCondition(&updated.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "ExternalRunFailed",
+			Message: message,
+		})
+	}
+
+	if equality.Semantic.DeepEqual(&task.Status, updated) {
+		return ctrl.Result{}, nil
+	}
+	task.Status = *updated
+	if err := r.Status().Update(ctx, task); err != nil {
+		log.Error(err, "unable to update Task status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// createTaskRecord creates the Task mirroring a workflow, owned by the workflow
+func (r *ExternalRunReconciler) createTaskRecord(ctx context.Context, run *unstructured.Unstructured, source, externalRun string) (*kubetaskv1alpha1.Task, error) {
+	labels := map[string]string{}
+	for k, v := range run.GetLabels() {
+		if k != TaskLabelKey {
+			labels[k] = v
+		}
+	}
+	description := fmt.Sprintf("Task %s executed as %s %s.", source, r.Engine.Kind.Kind, run.GetName())
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        run.GetName(),
+			Namespace:   run.GetNamespace(),
+			Labels:      labels,
+			Annotations: map[string]string{ExternalRunAnnotation: externalRun},
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{Description: &description},
+	}
+	if err := controllerutil.SetOwnerReference(run, task, r.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, task); err != nil {
+		return nil, err
+	}
+	log.FromContext(ctx).Info("created Task record for external run", "task", task.Name, "run", externalRun)
+	return task, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. The engine's CRDs must
+// be installed in the cluster.
+func (r *ExternalRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	run := &unstructured.Unstructured{}
+	run.SetGroupVersionKind(r.Engine.Kind)
+	labeled := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetLabels()[TaskLabelKey] != ""
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("externalrun-"+r.Engine.Name).
+		For(run, builder.WithPredicates(labeled)).
+		Complete(r)
+}
+
+// argoRunStatus reads the status of an Argo Workflow
+func argoRunStatus(run *unstructured.Unstructured) externalRunStatus {
+	phase, _, _ := unstructured.NestedString(run.Object, "status", "phase")
+	status := externalRunStatus{
+		startTime:      nestedTime(run, "status", "startedAt"),
+		completionTime: nestedTime(run, "status", "finishedAt"),
+	}
+	status.message, _, _ = unstructured.NestedString(run.Object, "status", "message")
+
+	switch phase {
+	case "Running":
+		status.phase = kubetaskv1alpha1.TaskPhaseRunning
+	case "Succeeded":
+		status.phase = kubetaskv1alpha1.TaskPhaseCompleted
+	case "Failed", "Error":
+		status.phase = kubetaskv1alpha1.TaskPhaseFailed
+	default:
+		status.phase = kubetaskv1alpha1.TaskPhasePending
+	}
+
+	parameters, _, _ := unstructured.NestedSlice(run.Object, "status", "outputs", "parameters")
+	status.result = namedValue(parameters, ExternalRunResultName)
+	return status
+}
+
+// tektonRunStatus reads the status of a Tekton PipelineRun
+func tektonRunStatus(run *unstructured.Unstructured) externalRunStatus {
+	status := externalRunStatus{
+		phase:          kubetaskv1alpha1.TaskPhasePending,
+		startTime:      nestedTime(run, "status", "startTime"),
+		completionTime: nestedTime(run, "status", "completionTime"),
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(run.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]any)
+		if !ok || condition["type"] != "Succeeded" {
+			continue
+		}
+		status.message, _ = condition["message"].(string)
+		switch condition["status"] {
+		case "True":
+			status.phase = kubetaskv1alpha1.TaskPhaseCompleted
+		case "False":
+			status.phase = kubetaskv1alpha1.TaskPhaseFailed
+		default:
+			if condition["reason"] != "PipelineRunPending" {
+				status.phase = kubetaskv1alpha1.TaskPhaseRunning
+			}
+		}
+	}
+
+	results, _, _ := unstructured.NestedSlice(run.Object, "status", "results")
+	status.result = namedValue(results, ExternalRunResultName)
+	return status
+}
+
+// nestedTime parses an RFC 3339 timestamp field, nil if unset or invalid
+func nestedTime(obj *unstructured.Unstructured, fields ...string) *metav1.Time {
+	value, _, _ := unstructured.NestedString(obj.Object, fields...)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+// namedValue returns the string value of the {name, value} entry with the given name
+func namedValue(entries []any, name string) string {
+	for _, e := range entries {
+		entry, ok := e.(map[string]any)
+		if !ok || entry["name"] != name {
+			continue
+		}
+		value, _ := entry["value"].(string)
+		return strings.TrimSpace(value)
+	}
+	return ""
+}
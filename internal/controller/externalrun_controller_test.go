@@ -0,0 +1,152 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newWorkflow(name string, status map[string]any) *unstructured.Unstructured {
+	run := &unstructured.Unstructured{Object: map[string]any{"status": status}}
+	run.SetGroupVersionKind(ArgoWorkflows.Kind)
+	run.SetName(name)
+	run.SetNamespace("default")
+	run.SetUID(types.UID(name + "-uid"))
+	run.SetLabels(map[string]string{TaskLabelKey: "fix-bug", "team": "a"})
+	return run
+}
+
+func TestExternalRunReconciler_MirrorsWorkflow(t *testing.T) {
+	run := newWorkflow("fix-bug-x2k4p", map[string]any{
+		"phase":      "Succeeded",
+		"startedAt":  "2026-01-02T10:00:00Z",
+		"finishedAt": "2026-01-02T10:05:00Z",
+		"outputs": map[string]any{"parameters": []any{
+			map[string]any{"name": ExternalRunResultName, "value": "KubeTask-Pull-Request: https://github.com/org/repo/pull/7\n"},
+		}},
+	})
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(run).WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+	r := &ExternalRunReconciler{Client: k8sClient, Engine: ArgoWorkflows}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "fix-bug-x2k4p", Namespace: "default"}}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(context.Background(), req.NamespacedName, task); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if task.Annotations[ExternalRunAnnotation] != "argo/fix-bug-x2k4p" {
+		t.Errorf("external-run annotation = %q", task.Annotations[ExternalRunAnnotation])
+	}
+	if _, ok := task.Labels[TaskLabelKey]; ok || task.Labels["team"] != "a" {
+		t.Errorf("labels = %v, want workflow labels without %s", task.Labels, TaskLabelKey)
+	}
+	if len(task.OwnerReferences) != 1 || task.OwnerReferences[0].Kind != "Workflow" {
+		t.Errorf("owner references = %v, want the Workflow", task.OwnerReferences)
+	}
+	if task.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted {
+		t.Errorf("phase = %q, want Completed", task.Status.Phase)
+	}
+	if task.Status.CompletionTime == nil || task.Status.CompletionTime.Sub(task.Status.StartTime.Time).Minutes() != 5 {
+		t.Errorf("start/completion = %v/%v, want 5 minutes apart", task.Status.StartTime, task.Status.CompletionTime)
+	}
+	if task.Status.Changes == nil || len(task.Status.Changes.PullRequests) != 1 {
+		t.Errorf("changes = %+v, want the reported pull request", task.Status.Changes)
+	}
+}
+
+func TestExternalRunReconciler_SkipsKubeTaskTasks(t *testing.T) {
+	existing := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix-bug-x2k4p", Namespace: "default"},
+		Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning},
+	}
+	run := newWorkflow("fix-bug-x2k4p", map[string]any{"phase": "Failed"})
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(run, existing).WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+	r := &ExternalRunReconciler{Client: k8sClient, Engine: ArgoWorkflows}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "fix-bug-x2k4p", Namespace: "default"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(context.Background(), req.NamespacedName, task); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+		t.Errorf("phase = %q, want the existing Task untouched", task.Status.Phase)
+	}
+}
+
+func TestTektonRunStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition map[string]any
+		want      kubetaskv1alpha1.TaskPhase
+	}{
+		{"no condition", nil, kubetaskv1alpha1.TaskPhasePending},
+		{"pending", map[string]any{"type": "Succeeded", "status": "Unknown", "reason": "PipelineRunPending"}, kubetaskv1alpha1.TaskPhasePending},
+		{"running", map[string]any{"type": "Succeeded", "status": "Unknown", "reason": "Running"}, kubetaskv1alpha1.TaskPhaseRunning},
+		{"succeeded", map[string]any{"type": "Succeeded", "status": "True"}, kubetaskv1alpha1.TaskPhaseCompleted},
+		{"failed", map[string]any{"type": "Succeeded", "status": "False", "message": "step agent failed"}, kubetaskv1alpha1.TaskPhaseFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := map[string]any{
+				"results": []any{map[string]any{"name": ExternalRunResultName, "value": "KubeTask-Commit: abc1234"}},
+			}
+			if tt.condition != nil {
+				status["conditions"] = []any{tt.condition}
+			}
+			got := tektonRunStatus(&unstructured.Unstructured{Object: map[string]any{"status": status}})
+			if got.phase != tt.want {
+				t.Errorf("phase = %q, want %q", got.phase, tt.want)
+			}
+			if got.result != "KubeTask-Commit: abc1234" {
+				t.Errorf("result = %q, want the kubetask-changes result", got.result)
+			}
+		})
+	}
+}
+
+func TestTaskReconciler_IgnoresExternalRunTasks(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fix-bug-x2k4p",
+			Namespace:   "default",
+			Annotations: map[string]string{ExternalRunAnnotation: "tekton/fix-bug-x2k4p"},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithObjects(task).WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+	r := &TaskReconciler{Client: k8sClient}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: task.Name, Namespace: task.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(context.Background(), req.NamespacedName, got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Status.Phase != "" {
+		t.Errorf("phase = %q, want the Task left for the external run mirror", got.Status.Phase)
+	}
+}
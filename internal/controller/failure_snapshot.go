@@ -0,0 +1,42 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// FailureSnapshotMountPath is where the snapshot claim is mounted in the agent container
+const FailureSnapshotMountPath = build.FailureSnapshotMountPath
+
+// failureArtifacts returns the workspace snapshots reported by the agent container
+// of a failed Job's Pod, or nil if none was captured.
+func failureArtifacts(job *batchv1.Job, pod *corev1.Pod) []kubetaskv1alpha1.FailureArtifact {
+	if pod == nil {
+		return nil
+	}
+
+	var claimName string
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.Name == build.FailureSnapshotVolumeName && v.PersistentVolumeClaim != nil {
+			claimName = v.PersistentVolumeClaim.ClaimName
+		}
+	}
+	if claimName == "" {
+		return nil
+	}
+
+	snapshots := parseTrailers(agentTerminationMessage(pod))[TrailerSnapshot]
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return []kubetaskv1alpha1.FailureArtifact{{
+		Type:      kubetaskv1alpha1.FailureArtifactTypeTarball,
+		ClaimName: claimName,
+		Path:      snapshots[len(snapshots)-1],
+	}}
+}
@@ -0,0 +1,115 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestBuildAgentConfig_FailureSnapshotRequiresCommand(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "gemini"},
+		Spec: kubetaskv1alpha1.AgentSpec{
+			ServiceAccountName: "test-sa",
+			FailureSnapshot:    &kubetaskv1alpha1.FailureSnapshot{Enabled: true, ClaimName: "post-mortems"},
+		},
+	}
+
+	if _, err := buildAgentConfig(agent, DefaultAgentImage); err == nil {
+		t.Errorf("buildAgentConfig() error = nil, want error for failureSnapshot without command")
+	}
+
+	agent.Spec.Command = []string{"gemini"}
+	cfg, err := buildAgentConfig(agent, DefaultAgentImage)
+	if err != nil {
+		t.Fatalf("buildAgentConfig() error = %v", err)
+	}
+	if cfg.FailureSnapshot == nil || cfg.FailureSnapshot.ClaimName != "post-mortems" {
+		t.Errorf("failureSnapshot = %+v, want claim post-mortems", cfg.FailureSnapshot)
+	}
+}
+
+func TestFailureArtifacts(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+	cfg := build.Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Command:            []string{"gemini"},
+		FailureSnapshot:    &kubetaskv1alpha1.FailureSnapshot{Enabled: true, ClaimName: "post-mortems"},
+	}
+	snapshotJob := build.Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	cfg.FailureSnapshot = nil
+	plainJob := build.Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	terminatedPod := func(message string) *corev1.Pod {
+		return &corev1.Pod{
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: DefaultAgentContainerName,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: message},
+					},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		pod   *corev1.Pod
+		plain bool
+		want  []kubetaskv1alpha1.FailureArtifact
+	}{
+		{
+			name: "snapshot reported",
+			pod:  terminatedPod("KubeTask-Snapshot: test-task/test-uid.tar.gz\n"),
+			want: []kubetaskv1alpha1.FailureArtifact{{
+				Type:      kubetaskv1alpha1.FailureArtifactTypeTarball,
+				ClaimName: "post-mortems",
+				Path:      "test-task/test-uid.tar.gz",
+			}},
+		},
+		{
+			name: "snapshot not captured",
+			pod:  terminatedPod(""),
+		},
+		{
+			name: "no pod",
+		},
+		{
+			name:  "snapshot disabled",
+			pod:   terminatedPod("KubeTask-Snapshot: test-task/test-uid.tar.gz"),
+			plain: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := snapshotJob
+			if tt.plain {
+				job = plainJob
+			}
+			got := failureArtifacts(job, tt.pod)
+			if len(got) != len(tt.want) {
+				t.Fatalf("failureArtifacts() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("failureArtifacts()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/faultinject"
+)
+
+// maxFaultReconciles bounds the reconciles a controller gets to recover from injected faults
+const maxFaultReconciles = 10
+
+// reconcileThroughFaults reconciles until a reconcile succeeds without requeueing
+// for an error, as the work queue would, and returns how many reconciles it took
+func reconcileThroughFaults(t *testing.T, reconcile func() error) int {
+	t.Helper()
+	for i := 1; i <= maxFaultReconciles; i++ {
+		if err := reconcile(); err == nil {
+			return i
+		}
+	}
+	t.Fatalf("still failing after %d reconciles", maxFaultReconciles)
+	return 0
+}
+
+func TestInitializeTask_Faults(t *testing.T) {
+	tests := []struct {
+		name   string
+		faults []faultinject.Fault
+	}{
+		{
+			name:   "conflicting status updates",
+			faults: []faultinject.Fault{{Operation: faultinject.OpStatusUpdate, Object: &kubetaskv1alpha1.Task{}, Times: 2, Err: faultinject.Conflict()}},
+		},
+		{
+			name:   "lost status update response",
+			faults: []faultinject.Fault{{Operation: faultinject.OpStatusUpdate, Object: &kubetaskv1alpha1.Task{}, Times: 1, Err: faultinject.ServerTimeout(), AfterCall: true}},
+		},
+		{
+			name:   "lost Job create response",
+			faults: []faultinject.Fault{{Operation: faultinject.OpCreate, Object: &batchv1.Job{}, Times: 1, Err: faultinject.ServerTimeout(), AfterCall: true}},
+		},
+		{
+			name:   "lost ConfigMap create response",
+			faults: []faultinject.Fault{{Operation: faultinject.OpCreate, Object: &corev1.ConfigMap{}, Times: 1, Err: faultinject.ServerTimeout(), AfterCall: true}},
+		},
+		{
+			name:   "Agent lookup times out",
+			faults: []faultinject.Fault{{Operation: faultinject.OpGet, Object: &kubetaskv1alpha1.Agent{}, Times: 2, Err: faultinject.ServerTimeout()}},
+		},
+		{
+			name:   "API server outage",
+			faults: []faultinject.Fault{{Times: 6, Err: faultinject.Unavailable()}},
+		},
+		{
+			name:   "slow API server",
+			faults: []faultinject.Fault{{Delay: time.Millisecond}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, task := newInitTestObjects()
+			injector := faultinject.New(tt.faults...)
+			c := newInitTestClient(t, injector.Funcs(), agent, task)
+
+			r := &TaskReconciler{Client: c}
+			reconcileThroughFaults(t, func() error {
+				_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: initTaskKey})
+				return err
+			})
+			if injector.Injected() == 0 {
+				t.Fatal("no fault was injected")
+			}
+
+			injector.SetEnabled(false)
+			started, err := reconcileInitTask(t, c)
+			if err != nil {
+				t.Fatalf("Reconcile() after the faults error = %v", err)
+			}
+			assertTaskStarted(t, c, started)
+			if started.Status.Attempts != 1 {
+				t.Errorf("attempts = %d, want 1", started.Status.Attempts)
+			}
+			for _, conditionType := range []string{AgentResolvedConditionType, JobCreatedConditionType} {
+				if !meta.IsStatusConditionTrue(started.Status.Conditions, conditionType) {
+					t.Errorf("%s = %+v, want True", conditionType, meta.FindStatusCondition(started.Status.Conditions, conditionType))
+				}
+			}
+			configMap := &corev1.ConfigMap{}
+			if err := c.Get(context.Background(), types.NamespacedName{Name: started.Status.ContextConfigMap, Namespace: "default"}, configMap); err != nil {
+				t.Errorf("context ConfigMap: %v", err)
+			}
+		})
+	}
+}
+
+func TestCronTaskReconcile_Faults(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	now := run.Add(30 * time.Second)
+
+	tests := []struct {
+		name   string
+		policy kubetaskv1alpha1.ConcurrencyPolicy
+		faults []faultinject.Fault
+	}{
+		{
+			name:   "status update fails after the Task was created",
+			policy: kubetaskv1alpha1.AllowConcurrent,
+			faults: []faultinject.Fault{{Operation: faultinject.OpStatusUpdate, Object: &kubetaskv1alpha1.CronTask{}, Times: 1, Err: faultinject.Conflict()}},
+		},
+		{
+			name:   "status update fails with the Replace policy",
+			policy: kubetaskv1alpha1.ReplaceConcurrent,
+			faults: []faultinject.Fault{{Operation: faultinject.OpStatusUpdate, Object: &kubetaskv1alpha1.CronTask{}, Times: 1, Err: faultinject.Conflict()}},
+		},
+		{
+			name:   "status update fails with the Forbid policy",
+			policy: kubetaskv1alpha1.ForbidConcurrent,
+			faults: []faultinject.Fault{{Operation: faultinject.OpStatusUpdate, Object: &kubetaskv1alpha1.CronTask{}, Times: 1, Err: faultinject.Conflict()}},
+		},
+		{
+			name:   "lost Task create response",
+			policy: kubetaskv1alpha1.AllowConcurrent,
+			faults: []faultinject.Fault{{Operation: faultinject.OpCreate, Object: &kubetaskv1alpha1.Task{}, Times: 1, Err: faultinject.ServerTimeout(), AfterCall: true}},
+		},
+		{
+			name:   "Task list unavailable",
+			policy: kubetaskv1alpha1.AllowConcurrent,
+			faults: []faultinject.Fault{{Operation: faultinject.OpList, Object: &kubetaskv1alpha1.TaskList{}, Times: 2, Err: faultinject.Unavailable()}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronTask := &kubetaskv1alpha1.CronTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default", UID: "crontask-uid", CreationTimestamp: metav1.NewTime(created)},
+				Spec: kubetaskv1alpha1.CronTaskSpec{
+					Schedule:          "0 2 * * *",
+					ConcurrencyPolicy: tt.policy,
+					TaskTemplate: kubetaskv1alpha1.TaskTemplateSpec{Spec: kubetaskv1alpha1.TaskSpec{
+						Description: stringPtr("Triage new issues"),
+					}},
+				},
+			}
+			injector := faultinject.New(tt.faults...)
+			c := fake.NewClientBuilder().
+				WithScheme(newTestScheme(t)).
+				WithObjects(cronTask).
+				WithStatusSubresource(&kubetaskv1alpha1.CronTask{}).
+				WithInterceptorFuncs(injector.Funcs()).
+				Build()
+			r := &CronTaskReconciler{Client: c, Clock: staticClock{now: now}}
+			key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+
+			reconcileThroughFaults(t, func() error {
+				_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+				return err
+			})
+			if injector.Injected() == 0 {
+				t.Fatal("no fault was injected")
+			}
+
+			tasks := &kubetaskv1alpha1.TaskList{}
+			if err := c.List(context.Background(), tasks, client.InNamespace("default")); err != nil {
+				t.Fatal(err)
+			}
+			if len(tasks.Items) != 1 || tasks.Items[0].Name != scheduledTaskName(cronTask, run) {
+				t.Errorf("Tasks = %v, want exactly the Task of the 2am run", taskNamesOf(tasks.Items))
+			}
+			updated := &kubetaskv1alpha1.CronTask{}
+			if err := c.Get(context.Background(), key, updated); err != nil {
+				t.Fatal(err)
+			}
+			if updated.Status.LastScheduleTime == nil || !updated.Status.LastScheduleTime.Time.Equal(run) {
+				t.Errorf("lastScheduleTime = %v, want the 2am run", updated.Status.LastScheduleTime)
+			}
+		})
+	}
+}
+
+func taskNamesOf(tasks []kubetaskv1alpha1.Task) []string {
+	names := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+	return names
+}
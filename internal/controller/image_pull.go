@@ -0,0 +1,242 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// ImagePulledConditionType is the Task condition reporting whether the images
+	// of the agent Pod were pulled
+	ImagePulledConditionType = "ImagePulled"
+
+	// SlowImagePullThreshold is how long an image may be pulling before the
+	// ImagePulled condition reports it as slow
+	SlowImagePullThreshold = 2 * time.Minute
+
+	// ImagePullCheckInterval is how often Tasks whose agent has not started
+	// re-check their image pulls, which do not update the Pod while in progress
+	ImagePullCheckInterval = 30 * time.Second
+
+	// eventInvolvedObjectUIDField selects the events of an object
+	eventInvolvedObjectUIDField = "involvedObject.uid"
+)
+
+// podImagePullFailureReasons are container waiting reasons caused by image pulls
+var podImagePullFailureReasons = map[string]bool{
+	"ImagePullBackOff": true,
+	"ErrImagePull":     true,
+	"InvalidImageName": true,
+}
+
+// containerPull is the image pull of a container, as reported by kubelet events
+type containerPull struct {
+	image   string
+	pulling time.Time
+	pulled  time.Time
+	failure string
+}
+
+// reconcileImagePull sets the ImagePulled condition from the agent Pod and its
+// events. Events are only read while a pull failed or is slow, and once when the
+// agent starts to measure the pulls. It returns whether the condition changed.
+func (r *TaskReconciler) reconcileImagePull(ctx context.Context, task *kubetaskv1alpha1.Task, pod *corev1.Pod) (bool, error) {
+	if pod == nil {
+		return false, nil
+	}
+	current := meta.FindStatusCondition(task.Status.Conditions, ImagePulledConditionType)
+	if current != nil && current.Status == metav1.ConditionTrue {
+		return false, nil
+	}
+
+	now := clockNow(r.Clock)
+	failing := imagePullFailingContainers(pod)
+	started := task.Status.AgentStartTime != nil
+	slow := task.Status.PodScheduledTime != nil && now.Sub(task.Status.PodScheduledTime.Time) > SlowImagePullThreshold
+	if len(failing) == 0 && !started && !slow {
+		return false, nil
+	}
+
+	pulls, err := r.podImagePulls(ctx, pod)
+	if err != nil {
+		return false, err
+	}
+
+	cond := metav1.Condition{Type: ImagePulledConditionType, Status: metav1.ConditionFalse}
+	switch {
+	case len(failing) > 0:
+		cs := failing[0]
+		cond.Reason = "ImagePullFailed"
+		message := cs.State.Waiting.Message
+		// The backoff message omits the registry error; the last failed pull has it
+		if pull := pulls[containerFieldPath(pod, cs.Name)]; pull != nil && pull.failure != "" {
+			message = pull.failure
+		}
+		cond.Message = fmt.Sprintf("container %s: %s", cs.Name, message)
+		if current == nil || current.Reason != cond.Reason {
+			imagePullFailures.WithLabelValues(task.Namespace, taskAgentName(task)).Inc()
+		}
+	case started:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "ImagesPulled"
+		cond.Message = "Images of the agent Pod are present"
+		var longest time.Duration
+		for _, pull := range pulls {
+			if pull.pulling.IsZero() || pull.pulled.IsZero() {
+				continue
+			}
+			d := max(pull.pulled.Sub(pull.pulling), 0)
+			imagePullDuration.WithLabelValues(task.Namespace, taskAgentName(task)).Observe(d.Seconds())
+			longest = max(longest, d)
+		}
+		if longest > 0 {
+			cond.Message = fmt.Sprintf("Images of the agent Pod pulled, the longest in %s", longest.Round(time.Second))
+		}
+	default:
+		pull := slowestPendingPull(pulls, now)
+		if pull == nil {
+			return false, nil
+		}
+		cond.Reason = "ImagePullSlow"
+		cond.Message = fmt.Sprintf("Pulling image %s for %s", pull.image, now.Sub(pull.pulling).Round(time.Second))
+	}
+	return meta.SetStatusCondition(&task.Status.Conditions, cond), nil
+}
+
+// imagePullPending reports whether the Task should re-check its image pulls
+func imagePullPending(task *kubetaskv1alpha1.Task) bool {
+	if cond := meta.FindStatusCondition(task.Status.Conditions, ImagePulledConditionType); cond != nil {
+		return cond.Status == metav1.ConditionFalse
+	}
+	return task.Status.PodScheduledTime != nil && task.Status.AgentStartTime == nil
+}
+
+// imagePullFailingContainers returns the containers of a Pod waiting on a failed image pull
+func imagePullFailingContainers(pod *corev1.Pod) []corev1.ContainerStatus {
+	var failing []corev1.ContainerStatus
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if w := cs.State.Waiting; w != nil && podImagePullFailureReasons[w.Reason] {
+			failing = append(failing, cs)
+		}
+	}
+	return failing
+}
+
+// containerFieldPath returns the event fieldPath of a container of the Pod
+func containerFieldPath(pod *corev1.Pod, name string) string {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == name {
+			return fmt.Sprintf("spec.initContainers{%s}", name)
+		}
+	}
+	return fmt.Sprintf("spec.containers{%s}", name)
+}
+
+// podImagePulls reads the kubelet image pull events of a Pod, keyed by container fieldPath.
+// Events are read from the API server, as the controller does not cache them.
+func (r *TaskReconciler) podImagePulls(ctx context.Context, pod *corev1.Pod) (map[string]*containerPull, error) {
+	events := &corev1.EventList{}
+	if err := r.List(ctx, events, client.InNamespace(pod.Namespace),
+		client.MatchingFields{eventInvolvedObjectUIDField: string(pod.UID)}); err != nil {
+		return nil, err
+	}
+	return imagePulls(events.Items), nil
+}
+
+// imagePulls summarizes the image pull events of a Pod, keyed by container fieldPath
+func imagePulls(events []corev1.Event) map[string]*containerPull {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(&events[i]).Before(eventTime(&events[j]))
+	})
+
+	pulls := map[string]*containerPull{}
+	for i := range events {
+		e := &events[i]
+		if e.InvolvedObject.FieldPath == "" {
+			continue
+		}
+		pull := pulls[e.InvolvedObject.FieldPath]
+		if pull == nil {
+			pull = &containerPull{}
+		}
+		switch {
+		case e.Reason == "Pulling":
+			if pull.pulling.IsZero() {
+				pull.pulling = firstEventTime(e)
+			}
+			pull.image = quotedImage(e.Message)
+		case e.Reason == "Pulled" && strings.HasPrefix(e.Message, "Successfully pulled"):
+			pull.pulled = eventTime(e)
+		case e.Reason == "Failed" && strings.HasPrefix(e.Message, "Failed to pull image"):
+			pull.failure = e.Message
+		default:
+			continue
+		}
+		pulls[e.InvolvedObject.FieldPath] = pull
+	}
+	return pulls
+}
+
+// slowestPendingPull returns the pull that started longest ago and has not
+// finished, if it exceeds SlowImagePullThreshold at now
+func slowestPendingPull(pulls map[string]*containerPull, now time.Time) *containerPull {
+	var slowest *containerPull
+	for _, pull := range pulls {
+		if pull.pulling.IsZero() || !pull.pulled.IsZero() || now.Sub(pull.pulling) <= SlowImagePullThreshold {
+			continue
+		}
+		if slowest == nil || pull.pulling.Before(slowest.pulling) {
+			slowest = pull
+		}
+	}
+	return slowest
+}
+
+// quotedImage returns the image in a kubelet message like `Pulling image "busybox"`
+func quotedImage(message string) string {
+	start := strings.Index(message, `"`)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(message[start+1:], `"`)
+	if end < 0 {
+		return ""
+	}
+	return message[start : start+end+2]
+}
+
+// eventTime returns when an event last occurred
+func eventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.FirstTimestamp.Time
+}
+
+// firstEventTime returns when an event first occurred
+func firstEventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.LastTimestamp.Time
+}
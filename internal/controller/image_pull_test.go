@@ -0,0 +1,204 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const registryError = `Failed to pull image "registry.example.com/agent:v2": rpc error: code = Unknown desc = failed to resolve reference: unexpected status from HEAD request: 401 Unauthorized`
+
+func pullEvent(name, fieldPath, reason, message string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: "default",
+			Name:      "task-pod",
+			UID:       types.UID("pod-uid"),
+			FieldPath: fieldPath,
+		},
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: metav1.NewTime(at),
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
+
+func imagePullTestPod(statuses ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-pod", Namespace: "default", UID: types.UID("pod-uid")},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "git-sync-repo"}},
+			Containers:     []corev1.Container{{Name: "agent"}},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: statuses},
+	}
+}
+
+func newImagePullReconciler(t *testing.T, events ...client.Object) *TaskReconciler {
+	t.Helper()
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithIndex(&corev1.Event{}, eventInvolvedObjectUIDField, func(obj client.Object) []string {
+			return []string{string(obj.(*corev1.Event).InvolvedObject.UID)}
+		}).
+		WithObjects(events...).
+		Build()
+	return &TaskReconciler{Client: c}
+}
+
+func TestImagePulls(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	events := []corev1.Event{
+		*pullEvent("b", "spec.containers{agent}", "Pulled", `Successfully pulled image "agent:v1" in 42s (42s including waiting)`, start.Add(42*time.Second)),
+		*pullEvent("a", "spec.containers{agent}", "Pulling", `Pulling image "agent:v1"`, start),
+		*pullEvent("c", "spec.initContainers{git-sync-repo}", "Failed", registryError, start),
+		*pullEvent("d", "spec.initContainers{git-sync-repo}", "Failed", "Error: ImagePullBackOff", start.Add(time.Second)),
+		*pullEvent("e", "", "Scheduled", "Successfully assigned default/task-pod", start),
+	}
+
+	pulls := imagePulls(events)
+	if len(pulls) != 2 {
+		t.Fatalf("expected pulls of 2 containers, got %d", len(pulls))
+	}
+	agent := pulls["spec.containers{agent}"]
+	if agent.image != `"agent:v1"` {
+		t.Errorf("image = %s, want \"agent:v1\"", agent.image)
+	}
+	if got := agent.pulled.Sub(agent.pulling); got != 42*time.Second {
+		t.Errorf("pull duration = %s, want 42s", got)
+	}
+	if got := pulls["spec.initContainers{git-sync-repo}"].failure; got != registryError {
+		t.Errorf("failure = %q, want the registry error", got)
+	}
+}
+
+func TestReconcileImagePull_Failed(t *testing.T) {
+	r := newImagePullReconciler(t,
+		pullEvent("failed", "spec.containers{agent}", "Failed", registryError, time.Now().Add(-time.Minute)))
+	pod := imagePullTestPod(corev1.ContainerStatus{
+		Name: "agent",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason:  "ImagePullBackOff",
+			Message: `Back-off pulling image "registry.example.com/agent:v2"`,
+		}},
+	})
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"}}
+
+	changed, err := r.reconcileImagePull(context.Background(), task, pod)
+	if err != nil {
+		t.Fatalf("reconcileImagePull() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("expected the condition to change")
+	}
+	cond := meta.FindStatusCondition(task.Status.Conditions, ImagePulledConditionType)
+	if cond.Status != metav1.ConditionFalse || cond.Reason != "ImagePullFailed" {
+		t.Errorf("condition = %s/%s, want False/ImagePullFailed", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "401 Unauthorized") || !strings.HasPrefix(cond.Message, "container agent: ") {
+		t.Errorf("message %q does not carry the registry error", cond.Message)
+	}
+	if !imagePullPending(task) {
+		t.Error("expected failed pulls to be re-checked")
+	}
+}
+
+func TestReconcileImagePull_Slow(t *testing.T) {
+	scheduled := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	r := newImagePullReconciler(t,
+		pullEvent("pulling", "spec.containers{agent}", "Pulling", `Pulling image "agent:huge"`, scheduled.Time))
+	pod := imagePullTestPod(corev1.ContainerStatus{
+		Name:  "agent",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+	})
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"},
+		Status:     kubetaskv1alpha1.TaskExecutionStatus{PodScheduledTime: &scheduled},
+	}
+
+	if !imagePullPending(task) {
+		t.Error("expected a scheduled Pod whose agent has not started to be re-checked")
+	}
+	if _, err := r.reconcileImagePull(context.Background(), task, pod); err != nil {
+		t.Fatalf("reconcileImagePull() error = %v", err)
+	}
+	cond := meta.FindStatusCondition(task.Status.Conditions, ImagePulledConditionType)
+	if cond == nil || cond.Reason != "ImagePullSlow" {
+		t.Fatalf("condition = %+v, want reason ImagePullSlow", cond)
+	}
+	if !strings.HasPrefix(cond.Message, `Pulling image "agent:huge" for 5m`) {
+		t.Errorf("message = %q", cond.Message)
+	}
+}
+
+func TestReconcileImagePull_NotSlowYet(t *testing.T) {
+	scheduled := metav1.NewTime(time.Now().Add(-30 * time.Second))
+	r := newImagePullReconciler(t)
+	pod := imagePullTestPod(corev1.ContainerStatus{
+		Name:  "agent",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+	})
+	task := &kubetaskv1alpha1.Task{Status: kubetaskv1alpha1.TaskExecutionStatus{PodScheduledTime: &scheduled}}
+
+	changed, err := r.reconcileImagePull(context.Background(), task, pod)
+	if err != nil || changed {
+		t.Errorf("reconcileImagePull() = %v, %v, want no change", changed, err)
+	}
+}
+
+func TestReconcileImagePull_Started(t *testing.T) {
+	start := time.Now().Add(-3 * time.Minute)
+	r := newImagePullReconciler(t,
+		pullEvent("pulling", "spec.containers{agent}", "Pulling", `Pulling image "agent:v1"`, start),
+		pullEvent("pulled", "spec.containers{agent}", "Pulled", `Successfully pulled image "agent:v1" in 1m30s`, start.Add(90*time.Second)))
+	pod := imagePullTestPod(corev1.ContainerStatus{
+		Name:  "agent",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	})
+	started := metav1.Now()
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			AgentStartTime: &started,
+			Conditions: []metav1.Condition{{
+				Type: ImagePulledConditionType, Status: metav1.ConditionFalse, Reason: "ImagePullSlow",
+			}},
+		},
+	}
+
+	if _, err := r.reconcileImagePull(context.Background(), task, pod); err != nil {
+		t.Fatalf("reconcileImagePull() error = %v", err)
+	}
+	cond := meta.FindStatusCondition(task.Status.Conditions, ImagePulledConditionType)
+	if cond.Status != metav1.ConditionTrue || cond.Reason != "ImagesPulled" {
+		t.Errorf("condition = %s/%s, want True/ImagesPulled", cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "1m30s") {
+		t.Errorf("message %q does not report the pull duration", cond.Message)
+	}
+	if imagePullPending(task) {
+		t.Error("expected no re-check once images are pulled")
+	}
+
+	// Events are not read again once the images were pulled
+	changed, err := (&TaskReconciler{}).reconcileImagePull(context.Background(), task, pod)
+	if err != nil || changed {
+		t.Errorf("reconcileImagePull() = %v, %v, want no change", changed, err)
+	}
+}
@@ -4,66 +4,33 @@ package controller
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
+	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
 )
 
-// agentConfig holds the resolved configuration from Agent
+// agentConfig holds the resolved configuration from Agent: what the Job is built
+// from, plus the settings the controller checks before creating it
 type agentConfig struct {
-	agentImage         string
-	command            []string
-	workspaceDir       string
-	contexts           []kubetaskv1alpha1.ContextMount
-	credentials        []kubetaskv1alpha1.Credential
-	podSpec            *kubetaskv1alpha1.AgentPodSpec
-	serviceAccountName string
-}
-
-// fileMount represents a file to be mounted at a specific path
-type fileMount struct {
-	filePath string
-}
+	build.Config
 
-// dirMount represents a directory to be mounted from a ConfigMap
-type dirMount struct {
-	dirPath       string
-	configMapName string
-	optional      bool
-}
+	contexts []kubetaskv1alpha1.ContextMount
 
-// gitMount represents a Git repository to be cloned and mounted
-type gitMount struct {
-	contextName string // Context name (for volume naming)
-	repository  string // Git repository URL
-	ref         string // Git reference (branch, tag, or commit SHA)
-	repoPath    string // Path within the repository to mount
-	mountPath   string // Where to mount in the container
-	depth       int    // Clone depth (1 = shallow, 0 = full)
-	secretName  string // Optional secret name for authentication
-}
+	// defaultContexts are the namespace's KubeTaskConfig default contexts
+	defaultContexts []kubetaskv1alpha1.ContextMount
 
-// resolvedContext holds a resolved context with its content and metadata
-type resolvedContext struct {
-	name      string // Context name (for XML tag)
-	namespace string // Context namespace (for XML tag)
-	ctxType   string // Context type (for XML tag)
-	content   string // Resolved content
-	mountPath string // Mount path (empty = append to task.md)
-}
+	// credentialMinValidity is how long credentials must remain valid when the
+	// Task starts, set when the Agent configures credentialExpiry
+	credentialMinValidity *time.Duration
 
-// sanitizeConfigMapKey converts a file path to a valid ConfigMap key.
-// ConfigMap keys must be alphanumeric, '-', '_', or '.'.
-func sanitizeConfigMapKey(filePath string) string {
-	// Remove leading slash and replace remaining slashes with dashes
-	key := strings.TrimPrefix(filePath, "/")
-	key = strings.ReplaceAll(key, "/", "-")
-	return key
+	// jobMutationWebhooks are the namespace's KubeTaskConfig job mutation webhooks
+	jobMutationWebhooks []kubetaskv1alpha1.JobMutationWebhook
 }
 
 // boolPtr returns a pointer to the given bool value
@@ -71,343 +38,167 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
-const (
-	// DefaultGitSyncImage is the default git-sync container image
-	DefaultGitSyncImage = "registry.k8s.io/git-sync/git-sync:v4.4.0"
-)
-
-// buildGitSyncInitContainer creates an init container that clones a Git repository using git-sync.
-func buildGitSyncInitContainer(gm gitMount, volumeName string, index int) corev1.Container {
-	// Set default depth to 1 (shallow clone) if not specified
-	depth := gm.depth
-	if depth <= 0 {
-		depth = 1
-	}
-
-	// Set default ref to HEAD if not specified
-	ref := gm.ref
-	if ref == "" {
-		ref = "HEAD"
-	}
+// shellQuote quotes a single argument for safe use in a POSIX shell script
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
 
-	envVars := []corev1.EnvVar{
-		{Name: "GITSYNC_REPO", Value: gm.repository},
-		{Name: "GITSYNC_REF", Value: ref},
-		{Name: "GITSYNC_ONE_TIME", Value: "true"},
-		{Name: "GITSYNC_DEPTH", Value: strconv.Itoa(depth)},
-		{Name: "GITSYNC_ROOT", Value: "/git"},
-		{Name: "GITSYNC_LINK", Value: "repo"},
+// buildPreflightScript builds the shell script that validates the agent execution contract.
+// Each check prints a "preflight:" line so failures are easy to find in the Job logs.
+func buildPreflightScript(preflight *kubetaskv1alpha1.AgentPreflight) string {
+	lines := []string{
+		`mkdir -p "$WORKSPACE_DIR" && touch "$WORKSPACE_DIR/.kubetask-preflight" || { echo "preflight: WORKSPACE_DIR $WORKSPACE_DIR is not writable"; exit 1; }`,
+		`echo "preflight: WORKSPACE_DIR $WORKSPACE_DIR is writable"`,
 	}
 
-	volumeMounts := []corev1.VolumeMount{
-		{Name: volumeName, MountPath: "/git"},
+	if len(preflight.CLICommand) > 0 {
+		quoted := make([]string, len(preflight.CLICommand))
+		for i, arg := range preflight.CLICommand {
+			quoted[i] = shellQuote(arg)
+		}
+		cmd := strings.Join(quoted, " ")
+		lines = append(lines,
+			fmt.Sprintf(`%s >/dev/null 2>&1 || { echo "preflight: CLI command failed:" %s; exit 1; }`, cmd, cmd),
+			fmt.Sprintf(`echo "preflight: CLI command succeeded:" %s`, cmd),
+		)
 	}
 
-	// Add secret volume mount for authentication if specified
-	if gm.secretName != "" {
-		// Mount the secret and configure git-sync to use it
-		// git-sync supports GITSYNC_USERNAME/GITSYNC_PASSWORD for HTTPS
-		// and GITSYNC_SSH_KEY_FILE for SSH
-		envVars = append(envVars,
-			corev1.EnvVar{
-				Name: "GITSYNC_USERNAME",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{Name: gm.secretName},
-						Key:                  "username",
-						Optional:             boolPtr(true),
-					},
-				},
-			},
-			corev1.EnvVar{
-				Name: "GITSYNC_PASSWORD",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{Name: gm.secretName},
-						Key:                  "password",
-						Optional:             boolPtr(true),
-					},
-				},
-			},
+	if preflight.Endpoint != "" {
+		endpoint := shellQuote(preflight.Endpoint)
+		lines = append(lines,
+			fmt.Sprintf(`if command -v curl >/dev/null 2>&1; then curl -sS -o /dev/null --max-time 10 %s; elif command -v wget >/dev/null 2>&1; then wget -q -T 10 -O /dev/null %s || [ $? -eq 8 ]; else echo "preflight: neither curl nor wget is available"; false; fi || { echo "preflight: endpoint is not reachable:" %s; exit 1; }`,
+				endpoint, endpoint, endpoint),
+			fmt.Sprintf(`echo "preflight: endpoint is reachable:" %s`, endpoint),
 		)
 	}
 
-	return corev1.Container{
-		Name:            fmt.Sprintf("git-sync-%d", index),
-		Image:           DefaultGitSyncImage,
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		Env:             envVars,
-		VolumeMounts:    volumeMounts,
-	}
+	return strings.Join(lines, "\n")
 }
 
-// buildJob creates a Job object for the task with context mounts
-func buildJob(task *kubetaskv1alpha1.Task, jobName string, cfg agentConfig, contextConfigMap *corev1.ConfigMap, fileMounts []fileMount, dirMounts []dirMount, gitMounts []gitMount) *batchv1.Job {
-	var volumes []corev1.Volume
-	var volumeMounts []corev1.VolumeMount
-	var envVars []corev1.EnvVar
-	var initContainers []corev1.Container
-
-	// Base environment variables
-	envVars = append(envVars,
-		corev1.EnvVar{Name: "TASK_NAME", Value: task.Name},
-		corev1.EnvVar{Name: "TASK_NAMESPACE", Value: task.Namespace},
-		corev1.EnvVar{Name: "WORKSPACE_DIR", Value: cfg.workspaceDir},
-	)
-
-	// Add human-in-the-loop keep-alive environment variable if enabled
-	if task.Spec.HumanInTheLoop != nil && task.Spec.HumanInTheLoop.Enabled {
-		keepAliveSeconds := DefaultKeepAliveSeconds
-		if task.Spec.HumanInTheLoop.KeepAliveSeconds != nil {
-			keepAliveSeconds = *task.Spec.HumanInTheLoop.KeepAliveSeconds
-		}
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  EnvHumanInTheLoopKeepAlive,
-			Value: strconv.Itoa(int(keepAliveSeconds)),
-		})
+// buildPreflightJob creates the validation Job for an Agent's pre-flight check
+func buildPreflightJob(agent *kubetaskv1alpha1.Agent, jobName string, cfg agentConfig) *batchv1.Job {
+	var activeDeadlineSeconds int64 = DefaultPreflightActiveDeadlineSeconds
+	if agent.Spec.Preflight.ActiveDeadlineSeconds != nil {
+		activeDeadlineSeconds = *agent.Spec.Preflight.ActiveDeadlineSeconds
 	}
+	var backoffLimit int32
 
-	// envFromSources collects secretRef entries for mounting entire secrets
-	var envFromSources []corev1.EnvFromSource
-
-	// Add credentials (secrets as env vars or file mounts)
-	for i, cred := range cfg.credentials {
-		// Check if Key is specified - determines mounting behavior
-		if cred.SecretRef.Key == nil || *cred.SecretRef.Key == "" {
-			// No key specified: mount entire secret as environment variables
-			// When mounting entire secret, Env and MountPath are ignored
-			envFromSources = append(envFromSources, corev1.EnvFromSource{
-				SecretRef: &corev1.SecretEnvSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: cred.SecretRef.Name,
-					},
-				},
-			})
-			continue
-		}
-
-		// Key is specified: use the existing single-key mounting behavior
-		// Add as environment variable if Env is specified
-		if cred.Env != nil && *cred.Env != "" {
-			envVars = append(envVars, corev1.EnvVar{
-				Name: *cred.Env,
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: cred.SecretRef.Name,
-						},
-						Key: *cred.SecretRef.Key,
-					},
-				},
-			})
-		}
-
-		// Add as file mount if MountPath is specified
-		if cred.MountPath != nil && *cred.MountPath != "" {
-			volumeName := fmt.Sprintf("credential-%d", i)
-
-			// Default file mode is 0600 (read/write for owner only)
-			var fileMode int32 = 0600
-			if cred.FileMode != nil {
-				fileMode = *cred.FileMode
-			}
-
-			volumes = append(volumes, corev1.Volume{
-				Name: volumeName,
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: cred.SecretRef.Name,
-						Items: []corev1.KeyToPath{
-							{
-								Key:  *cred.SecretRef.Key,
-								Path: "secret-file",
-								Mode: &fileMode,
-							},
-						},
-						DefaultMode: &fileMode,
-					},
-				},
-			})
-			volumeMounts = append(volumeMounts, corev1.VolumeMount{
-				Name:      volumeName,
-				MountPath: *cred.MountPath,
-				SubPath:   "secret-file",
-			})
-		}
+	labels := map[string]string{
+		"app":                  "kubetask",
+		AgentPreflightLabelKey: agent.Name,
 	}
 
-	// Add context ConfigMap volume if it exists (for aggregated content)
-	if contextConfigMap != nil {
-		volumes = append(volumes, corev1.Volume{
-			Name: "context-files",
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: contextConfigMap.Name,
-					},
+	podSpec := corev1.PodSpec{
+		ServiceAccountName: cfg.ServiceAccountName,
+		RestartPolicy:      corev1.RestartPolicyNever,
+		Containers: []corev1.Container{
+			{
+				Name:            "preflight",
+				Image:           cfg.AgentImage,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"sh", "-c", buildPreflightScript(agent.Spec.Preflight)},
+				Env: []corev1.EnvVar{
+					{Name: "WORKSPACE_DIR", Value: cfg.WorkspaceDir},
 				},
+				// Proxy settings from envFrom bundles affect endpoint reachability
+				EnvFrom: cfg.EnvFrom,
 			},
-		})
+		},
+	}
 
-		// Add volume mounts for each file path
-		for _, mount := range fileMounts {
-			configMapKey := sanitizeConfigMapKey(mount.filePath)
-			volumeMounts = append(volumeMounts, corev1.VolumeMount{
-				Name:      "context-files",
-				MountPath: mount.filePath,
-				SubPath:   configMapKey,
-			})
+	// Validate under the same scheduling and runtime constraints as real Tasks
+	if cfg.PodSpec != nil {
+		if cfg.PodSpec.Scheduling != nil {
+			podSpec.NodeSelector = cfg.PodSpec.Scheduling.NodeSelector
+			podSpec.Tolerations = cfg.PodSpec.Scheduling.Tolerations
+			podSpec.Affinity = cfg.PodSpec.Scheduling.Affinity
 		}
+		podSpec.RuntimeClassName = cfg.PodSpec.RuntimeClassName
+		if cfg.PodSpec.DNSPolicy != nil {
+			podSpec.DNSPolicy = *cfg.PodSpec.DNSPolicy
+		}
+		podSpec.DNSConfig = cfg.PodSpec.DNSConfig
+		podSpec.HostAliases = cfg.PodSpec.HostAliases
 	}
+	build.ApplySecurityProfile(&podSpec, cfg.SecurityProfile, cfg.MountToken)
 
-	// Add directory mounts (ConfigMapRef - entire ConfigMap as a directory)
-	for i, dm := range dirMounts {
-		volumeName := fmt.Sprintf("dir-mount-%d", i)
-		volumes = append(volumes, corev1.Volume{
-			Name: volumeName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: dm.configMapName,
-					},
-					Optional: &dm.optional,
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: agent.Namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: agent.APIVersion,
+					Kind:       agent.Kind,
+					Name:       agent.Name,
+					UID:        agent.UID,
+					Controller: boolPtr(true),
 				},
 			},
-		})
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      volumeName,
-			MountPath: dm.dirPath,
-		})
-	}
-
-	// Add Git context mounts (using git-sync init containers)
-	for i, gm := range gitMounts {
-		volumeName := fmt.Sprintf("git-context-%d", i)
-
-		// Add emptyDir volume for git content
-		volumes = append(volumes, corev1.Volume{
-			Name: volumeName,
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
 			},
-		})
-
-		// Build init container for git-sync
-		initContainers = append(initContainers, buildGitSyncInitContainer(gm, volumeName, i))
-
-		// Add volume mount to agent container
-		// If repoPath is specified, use subPath to mount only that path
-		subPath := "repo"
-		if gm.repoPath != "" {
-			subPath = "repo/" + strings.TrimPrefix(gm.repoPath, "/")
-		}
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      volumeName,
-			MountPath: gm.mountPath,
-			SubPath:   subPath,
-		})
-	}
-
-	// Build pod labels - start with base labels
-	podLabels := map[string]string{
-		"app":              "kubetask",
-		"kubetask.io/task": task.Name,
-	}
-
-	// Add custom pod labels from Agent.PodSpec
-	if cfg.podSpec != nil {
-		for k, v := range cfg.podSpec.Labels {
-			podLabels[k] = v
-		}
-	}
-
-	// Build agent container
-	agentContainer := corev1.Container{
-		Name:            "agent",
-		Image:           cfg.agentImage,
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		Env:             envVars,
-		EnvFrom:         envFromSources,
-		VolumeMounts:    volumeMounts,
-	}
-
-	// Apply command if specified
-	if len(cfg.command) > 0 {
-		// If humanInTheLoop is enabled on the Task, wrap the command with sleep
-		if task.Spec.HumanInTheLoop != nil && task.Spec.HumanInTheLoop.Enabled {
-			keepAliveSeconds := DefaultKeepAliveSeconds
-			if task.Spec.HumanInTheLoop.KeepAliveSeconds != nil {
-				keepAliveSeconds = *task.Spec.HumanInTheLoop.KeepAliveSeconds
-			}
-
-			// Build the wrapped command that runs original command then sleeps
-			// Format: sh -c 'original_command; EXIT_CODE=$?; echo "Human-in-the-loop: keeping container alive..."; sleep N; exit $EXIT_CODE'
-			originalCmd := strings.Join(cfg.command, " ")
-			wrappedScript := fmt.Sprintf(
-				`%s; EXIT_CODE=$?; echo "Human-in-the-loop: keeping container alive for %d seconds. Use 'kubectl exec' to access."; sleep %d; exit $EXIT_CODE`,
-				originalCmd, keepAliveSeconds, keepAliveSeconds,
-			)
-			agentContainer.Command = []string{"sh", "-c", wrappedScript}
-		} else {
-			// No humanInTheLoop on Task, use command as-is
-			agentContainer.Command = cfg.command
-		}
-	}
-
-	// Build PodSpec with scheduling configuration
-	podSpec := corev1.PodSpec{
-		ServiceAccountName: cfg.serviceAccountName,
-		InitContainers:     initContainers,
-		Containers:         []corev1.Container{agentContainer},
-		Volumes:            volumes,
-		RestartPolicy:      corev1.RestartPolicyNever,
+		},
 	}
+}
 
-	// Apply PodSpec configuration if specified
-	if cfg.podSpec != nil {
-		// Apply scheduling configuration
-		if cfg.podSpec.Scheduling != nil {
-			if cfg.podSpec.Scheduling.NodeSelector != nil {
-				podSpec.NodeSelector = cfg.podSpec.Scheduling.NodeSelector
-			}
-			if cfg.podSpec.Scheduling.Tolerations != nil {
-				podSpec.Tolerations = cfg.podSpec.Scheduling.Tolerations
-			}
-			if cfg.podSpec.Scheduling.Affinity != nil {
-				podSpec.Affinity = cfg.podSpec.Scheduling.Affinity
-			}
-		}
+// buildVerifierJob creates the Job that scores an AgentEval case after its Task completes
+func buildVerifierJob(eval *kubetaskv1alpha1.AgentEval, jobName, agentRef, caseName, taskName string, verifier *kubetaskv1alpha1.EvalVerifier) *batchv1.Job {
+	var backoffLimit int32
 
-		// Apply runtime class if specified (for gVisor, Kata, etc.)
-		if cfg.podSpec.RuntimeClassName != nil {
-			podSpec.RuntimeClassName = cfg.podSpec.RuntimeClassName
-		}
+	labels := map[string]string{
+		"app":             "kubetask",
+		AgentEvalLabelKey: eval.Name,
+		EvalCaseLabelKey:  caseName,
 	}
 
 	return &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      jobName,
-			Namespace: task.Namespace,
-			Labels: map[string]string{
-				"app":              "kubetask",
-				"kubetask.io/task": task.Name,
-			},
+			Namespace: eval.Namespace,
+			Labels:    labels,
 			OwnerReferences: []metav1.OwnerReference{
 				{
-					APIVersion: task.APIVersion,
-					Kind:       task.Kind,
-					Name:       task.Name,
-					UID:        task.UID,
+					APIVersion: eval.APIVersion,
+					Kind:       eval.Kind,
+					Name:       eval.Name,
+					UID:        eval.UID,
 					Controller: boolPtr(true),
 				},
 			},
 		},
 		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: podLabels,
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: verifier.ServiceAccountName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:            "verifier",
+							Image:           verifier.Image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         verifier.Command,
+							Env: []corev1.EnvVar{
+								{Name: "TASK_NAME", Value: taskName},
+								{Name: "TASK_NAMESPACE", Value: eval.Namespace},
+								{Name: "AGENT_NAME", Value: agentRef},
+								{Name: "EVAL_CASE", Value: caseName},
+							},
+						},
+					},
 				},
-				Spec: podSpec,
 			},
 		},
 	}
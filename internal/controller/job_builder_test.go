@@ -5,849 +5,237 @@
 package controller
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
 )
 
-func TestSanitizeConfigMapKey(t *testing.T) {
-	tests := []struct {
-		name     string
-		filePath string
-		want     string
-	}{
-		{
-			name:     "simple path",
-			filePath: "/workspace/task.md",
-			want:     "workspace-task.md",
-		},
-		{
-			name:     "nested path",
-			filePath: "/workspace/guides/standards.md",
-			want:     "workspace-guides-standards.md",
-		},
-		{
-			name:     "deeply nested path",
-			filePath: "/home/agent/.config/settings.json",
-			want:     "home-agent-.config-settings.json",
-		},
-		{
-			name:     "no leading slash",
-			filePath: "workspace/task.md",
-			want:     "workspace-task.md",
-		},
-		{
-			name:     "single file",
-			filePath: "/task.md",
-			want:     "task.md",
-		},
-		{
-			name:     "empty string",
-			filePath: "",
-			want:     "",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := sanitizeConfigMapKey(tt.filePath)
-			if got != tt.want {
-				t.Errorf("sanitizeConfigMapKey(%q) = %q, want %q", tt.filePath, got, tt.want)
-			}
-		})
-	}
-}
-
-func TestBoolPtr(t *testing.T) {
-	trueVal := boolPtr(true)
-	if trueVal == nil || *trueVal != true {
-		t.Errorf("boolPtr(true) = %v, want *true", trueVal)
-	}
-
-	falseVal := boolPtr(false)
-	if falseVal == nil || *falseVal != false {
-		t.Errorf("boolPtr(false) = %v, want *false", falseVal)
-	}
-}
-
-func TestBuildJob_BasicTask(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
-	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-	}
-
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, nil)
-
-	// Verify job metadata
-	if job.Name != "test-task-job" {
-		t.Errorf("Job.Name = %q, want %q", job.Name, "test-task-job")
-	}
-	if job.Namespace != "default" {
-		t.Errorf("Job.Namespace = %q, want %q", job.Namespace, "default")
-	}
-
-	// Verify labels
-	if job.Labels["app"] != "kubetask" {
-		t.Errorf("Job.Labels[app] = %q, want %q", job.Labels["app"], "kubetask")
-	}
-	if job.Labels["kubetask.io/task"] != "test-task" {
-		t.Errorf("Job.Labels[kubetask.io/task] = %q, want %q", job.Labels["kubetask.io/task"], "test-task")
-	}
-
-	// Verify owner reference
-	if len(job.OwnerReferences) != 1 {
-		t.Fatalf("len(Job.OwnerReferences) = %d, want 1", len(job.OwnerReferences))
-	}
-	ownerRef := job.OwnerReferences[0]
-	if ownerRef.Name != "test-task" {
-		t.Errorf("OwnerReference.Name = %q, want %q", ownerRef.Name, "test-task")
-	}
-	if ownerRef.Controller == nil || *ownerRef.Controller != true {
-		t.Errorf("OwnerReference.Controller = %v, want true", ownerRef.Controller)
-	}
-
-	// Verify container
-	if len(job.Spec.Template.Spec.Containers) != 1 {
-		t.Fatalf("len(Containers) = %d, want 1", len(job.Spec.Template.Spec.Containers))
-	}
-	container := job.Spec.Template.Spec.Containers[0]
-	if container.Name != "agent" {
-		t.Errorf("Container.Name = %q, want %q", container.Name, "agent")
-	}
-	if container.Image != "test-agent:v1.0.0" {
-		t.Errorf("Container.Image = %q, want %q", container.Image, "test-agent:v1.0.0")
-	}
-
-	// Verify environment variables
-	envMap := make(map[string]string)
-	for _, env := range container.Env {
-		envMap[env.Name] = env.Value
-	}
-	if envMap["TASK_NAME"] != "test-task" {
-		t.Errorf("Env[TASK_NAME] = %q, want %q", envMap["TASK_NAME"], "test-task")
-	}
-	if envMap["TASK_NAMESPACE"] != "default" {
-		t.Errorf("Env[TASK_NAMESPACE] = %q, want %q", envMap["TASK_NAMESPACE"], "default")
-	}
-	if envMap["WORKSPACE_DIR"] != "/workspace" {
-		t.Errorf("Env[WORKSPACE_DIR] = %q, want %q", envMap["WORKSPACE_DIR"], "/workspace")
-	}
-
-	// Verify service account
-	if job.Spec.Template.Spec.ServiceAccountName != "test-sa" {
-		t.Errorf("ServiceAccountName = %q, want %q", job.Spec.Template.Spec.ServiceAccountName, "test-sa")
-	}
-
-	// Verify restart policy
-	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
-		t.Errorf("RestartPolicy = %q, want %q", job.Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyNever)
-	}
-}
-
 // stringPtr returns a pointer to the given string value
 func stringPtr(s string) *string {
 	return &s
 }
 
-func TestBuildJob_WithCredentials(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
+func TestBuildPreflightJob(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
-	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
-
-	envName := "API_TOKEN"
-	mountPath := "/home/agent/.ssh/id_rsa"
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-		credentials: []kubetaskv1alpha1.Credential{
-			{
-				Name: "api-token",
-				SecretRef: kubetaskv1alpha1.SecretReference{
-					Name: "my-secret",
-					Key:  stringPtr("token"),
-				},
-				Env: &envName,
-			},
-			{
-				Name: "ssh-key",
-				SecretRef: kubetaskv1alpha1.SecretReference{
-					Name: "ssh-secret",
-					Key:  stringPtr("private-key"),
-				},
-				MountPath: &mountPath,
+			Name:       "gemini",
+			Namespace:  "default",
+			UID:        types.UID("agent-uid"),
+			Generation: 2,
+		},
+		Spec: kubetaskv1alpha1.AgentSpec{
+			Preflight: &kubetaskv1alpha1.AgentPreflight{
+				Enabled:    true,
+				CLICommand: []string{"gemini", "--version"},
+				Endpoint:   "https://generativelanguage.googleapis.com",
 			},
 		},
 	}
+	agent.APIVersion = "kubetask.io/v1alpha1"
+	agent.Kind = "Agent"
 
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, nil)
-
-	container := job.Spec.Template.Spec.Containers[0]
-
-	// Verify env credential
-	var foundEnvCred bool
-	for _, env := range container.Env {
-		if env.Name == "API_TOKEN" {
-			foundEnvCred = true
-			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
-				t.Errorf("API_TOKEN env should have SecretKeyRef")
-			} else {
-				if env.ValueFrom.SecretKeyRef.Name != "my-secret" {
-					t.Errorf("SecretKeyRef.Name = %q, want %q", env.ValueFrom.SecretKeyRef.Name, "my-secret")
-				}
-				if env.ValueFrom.SecretKeyRef.Key != "token" {
-					t.Errorf("SecretKeyRef.Key = %q, want %q", env.ValueFrom.SecretKeyRef.Key, "token")
-				}
-			}
-		}
-	}
-	if !foundEnvCred {
-		t.Errorf("API_TOKEN env not found")
-	}
+	cfg := agentConfig{Config: build.Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/work",
+		ServiceAccountName: "test-sa",
+	}}
 
-	// Verify mount credential
-	var foundMountCred bool
-	for _, mount := range container.VolumeMounts {
-		if mount.MountPath == "/home/agent/.ssh/id_rsa" {
-			foundMountCred = true
-		}
-	}
-	if !foundMountCred {
-		t.Errorf("SSH key mount not found at /home/agent/.ssh/id_rsa")
-	}
+	job := buildPreflightJob(agent, "gemini-preflight-2", cfg)
 
-	// Verify volume exists
-	var foundVolume bool
-	for _, vol := range job.Spec.Template.Spec.Volumes {
-		if vol.Secret != nil && vol.Secret.SecretName == "ssh-secret" {
-			foundVolume = true
-		}
+	// Verify ownership and labels
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Kind != "Agent" {
+		t.Fatalf("OwnerReferences = %v, want a single Agent owner", job.OwnerReferences)
 	}
-	if !foundVolume {
-		t.Errorf("Secret volume for ssh-secret not found")
+	if job.Labels[AgentPreflightLabelKey] != "gemini" {
+		t.Errorf("Job.Labels[%s] = %q, want %q", AgentPreflightLabelKey, job.Labels[AgentPreflightLabelKey], "gemini")
 	}
-}
 
-func TestBuildJob_WithEntireSecretCredential(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
+	// Verify the Job fails fast and is bounded in time
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 0 {
+		t.Errorf("BackoffLimit = %v, want 0", job.Spec.BackoffLimit)
 	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-		credentials: []kubetaskv1alpha1.Credential{
-			{
-				// No Key specified - mount entire secret as env vars
-				Name: "api-keys",
-				SecretRef: kubetaskv1alpha1.SecretReference{
-					Name: "api-credentials",
-					// Key is nil - entire secret should be mounted
-				},
-			},
-		},
-	}
-
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, nil)
-
-	container := job.Spec.Template.Spec.Containers[0]
-
-	// Verify envFrom is set with secretRef
-	if len(container.EnvFrom) != 1 {
-		t.Fatalf("Expected 1 envFrom entry, got %d", len(container.EnvFrom))
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != DefaultPreflightActiveDeadlineSeconds {
+		t.Errorf("ActiveDeadlineSeconds = %v, want %d", job.Spec.ActiveDeadlineSeconds, DefaultPreflightActiveDeadlineSeconds)
 	}
 
-	envFrom := container.EnvFrom[0]
-	if envFrom.SecretRef == nil {
-		t.Errorf("EnvFrom.SecretRef should not be nil")
-	} else {
-		if envFrom.SecretRef.Name != "api-credentials" {
-			t.Errorf("EnvFrom.SecretRef.Name = %q, want %q", envFrom.SecretRef.Name, "api-credentials")
-		}
+	podSpec := job.Spec.Template.Spec
+	if podSpec.ServiceAccountName != "test-sa" {
+		t.Errorf("ServiceAccountName = %q, want %q", podSpec.ServiceAccountName, "test-sa")
 	}
-}
 
-func TestBuildJob_WithMixedCredentials(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
+	container := podSpec.Containers[0]
+	if container.Image != "test-agent:v1.0.0" {
+		t.Errorf("Container.Image = %q, want %q", container.Image, "test-agent:v1.0.0")
 	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
-
-	envName := "GITHUB_TOKEN"
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-		credentials: []kubetaskv1alpha1.Credential{
-			{
-				// Entire secret mount (no key)
-				Name: "all-api-keys",
-				SecretRef: kubetaskv1alpha1.SecretReference{
-					Name: "api-credentials",
-				},
-			},
-			{
-				// Single key mount with env rename
-				Name: "github-token",
-				SecretRef: kubetaskv1alpha1.SecretReference{
-					Name: "github-secret",
-					Key:  stringPtr("token"),
-				},
-				Env: &envName,
-			},
-		},
+	if len(container.Env) != 1 || container.Env[0].Name != "WORKSPACE_DIR" || container.Env[0].Value != "/work" {
+		t.Errorf("Container.Env = %v, want WORKSPACE_DIR=/work", container.Env)
 	}
 
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, nil)
-
-	container := job.Spec.Template.Spec.Containers[0]
-
-	// Verify envFrom has 1 entry (entire secret)
-	if len(container.EnvFrom) != 1 {
-		t.Fatalf("Expected 1 envFrom entry, got %d", len(container.EnvFrom))
+	// Verify the script covers every check
+	if len(container.Command) != 3 || container.Command[0] != "sh" {
+		t.Fatalf("Container.Command = %v, want sh -c <script>", container.Command)
 	}
-	if container.EnvFrom[0].SecretRef.Name != "api-credentials" {
-		t.Errorf("EnvFrom.SecretRef.Name = %q, want %q", container.EnvFrom[0].SecretRef.Name, "api-credentials")
-	}
-
-	// Verify env has GITHUB_TOKEN from single key mount
-	var foundGithubToken bool
-	for _, env := range container.Env {
-		if env.Name == "GITHUB_TOKEN" {
-			foundGithubToken = true
-			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
-				t.Errorf("GITHUB_TOKEN env should have SecretKeyRef")
-			} else {
-				if env.ValueFrom.SecretKeyRef.Name != "github-secret" {
-					t.Errorf("SecretKeyRef.Name = %q, want %q", env.ValueFrom.SecretKeyRef.Name, "github-secret")
-				}
-				if env.ValueFrom.SecretKeyRef.Key != "token" {
-					t.Errorf("SecretKeyRef.Key = %q, want %q", env.ValueFrom.SecretKeyRef.Key, "token")
-				}
-			}
+	script := container.Command[2]
+	for _, want := range []string{
+		`"$WORKSPACE_DIR"`,
+		"'gemini' '--version'",
+		"'https://generativelanguage.googleapis.com'",
+	} {
+		if !contains(script, want) {
+			t.Errorf("pre-flight script missing %q:\n%s", want, script)
 		}
 	}
-	if !foundGithubToken {
-		t.Errorf("GITHUB_TOKEN env not found")
-	}
 }
 
-func TestBuildJob_WithHumanInTheLoop(t *testing.T) {
-	keepAlive := int32(1800)
-	task := &kubetaskv1alpha1.Task{
+func TestBuildVerifierJob(t *testing.T) {
+	eval := &kubetaskv1alpha1.AgentEval{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
+			Name:      "gemini-upgrade",
 			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
-		Spec: kubetaskv1alpha1.TaskSpec{
-			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{
-				Enabled:          true,
-				KeepAliveSeconds: &keepAlive,
-			},
+			UID:       types.UID("eval-uid"),
 		},
 	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
+	eval.APIVersion = "kubetask.io/v1alpha1"
+	eval.Kind = "AgentEval"
 
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-		command:            []string{"sh", "-c", "echo hello"},
+	verifier := &kubetaskv1alpha1.EvalVerifier{
+		Image:              "verifier:v1",
+		Command:            []string{"sh", "-c", "./verify.sh"},
+		ServiceAccountName: "verifier-sa",
 	}
 
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, nil)
-
-	container := job.Spec.Template.Spec.Containers[0]
-
-	// Verify command is wrapped
-	if len(container.Command) != 3 {
-		t.Fatalf("len(Command) = %d, want 3", len(container.Command))
-	}
-	if container.Command[0] != "sh" {
-		t.Errorf("Command[0] = %q, want %q", container.Command[0], "sh")
-	}
-	if container.Command[1] != "-c" {
-		t.Errorf("Command[1] = %q, want %q", container.Command[1], "-c")
-	}
-
-	// Verify wrapped script contains sleep
-	script := container.Command[2]
-	if !contains(script, "sleep 1800") {
-		t.Errorf("Command script should contain 'sleep 1800', got: %s", script)
-	}
-	if !contains(script, "Human-in-the-loop") {
-		t.Errorf("Command script should contain 'Human-in-the-loop', got: %s", script)
-	}
-	if !contains(script, "sh -c echo hello") {
-		t.Errorf("Command script should contain original command 'sh -c echo hello', got: %s", script)
-	}
+	job := buildVerifierJob(eval, "gemini-upgrade-0-fix-lint-verify", "gemini", "fix-lint", "gemini-upgrade-0-fix-lint", verifier)
 
-	// Verify keep-alive env var
-	var foundKeepAliveEnv bool
-	for _, env := range container.Env {
-		if env.Name == EnvHumanInTheLoopKeepAlive {
-			foundKeepAliveEnv = true
-			if env.Value != "1800" {
-				t.Errorf("KUBETASK_KEEP_ALIVE_SECONDS = %q, want %q", env.Value, "1800")
-			}
-		}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Kind != "AgentEval" {
+		t.Fatalf("OwnerReferences = %v, want a single AgentEval owner", job.OwnerReferences)
 	}
-	if !foundKeepAliveEnv {
-		t.Errorf("KUBETASK_KEEP_ALIVE_SECONDS env not found")
+	if job.Labels[AgentEvalLabelKey] != "gemini-upgrade" || job.Labels[EvalCaseLabelKey] != "fix-lint" {
+		t.Errorf("Job.Labels = %v, want eval and case labels", job.Labels)
 	}
-}
-
-func TestBuildJob_WithPodScheduling(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 0 {
+		t.Errorf("BackoffLimit = %v, want 0", job.Spec.BackoffLimit)
 	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
-
-	runtimeClass := "gvisor"
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-		podSpec: &kubetaskv1alpha1.AgentPodSpec{
-			Labels: map[string]string{
-				"custom-label": "custom-value",
-			},
-			Scheduling: &kubetaskv1alpha1.PodScheduling{
-				NodeSelector: map[string]string{
-					"node-type": "gpu",
-				},
-				Tolerations: []corev1.Toleration{
-					{
-						Key:      "dedicated",
-						Operator: corev1.TolerationOpEqual,
-						Value:    "ai-workload",
-						Effect:   corev1.TaintEffectNoSchedule,
-					},
-				},
-			},
-			RuntimeClassName: &runtimeClass,
-		},
-	}
-
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, nil)
 
 	podSpec := job.Spec.Template.Spec
-
-	// Verify node selector
-	if podSpec.NodeSelector["node-type"] != "gpu" {
-		t.Errorf("NodeSelector[node-type] = %q, want %q", podSpec.NodeSelector["node-type"], "gpu")
+	if podSpec.ServiceAccountName != "verifier-sa" {
+		t.Errorf("ServiceAccountName = %q, want %q", podSpec.ServiceAccountName, "verifier-sa")
 	}
 
-	// Verify tolerations
-	if len(podSpec.Tolerations) != 1 {
-		t.Fatalf("len(Tolerations) = %d, want 1", len(podSpec.Tolerations))
-	}
-	if podSpec.Tolerations[0].Key != "dedicated" {
-		t.Errorf("Tolerations[0].Key = %q, want %q", podSpec.Tolerations[0].Key, "dedicated")
-	}
-
-	// Verify runtime class
-	if podSpec.RuntimeClassName == nil || *podSpec.RuntimeClassName != "gvisor" {
-		t.Errorf("RuntimeClassName = %v, want %q", podSpec.RuntimeClassName, "gvisor")
+	container := podSpec.Containers[0]
+	if container.Image != "verifier:v1" {
+		t.Errorf("Container.Image = %q, want %q", container.Image, "verifier:v1")
 	}
 
-	// Verify custom label on pod template
-	podLabels := job.Spec.Template.ObjectMeta.Labels
-	if podLabels["custom-label"] != "custom-value" {
-		t.Errorf("PodLabels[custom-label] = %q, want %q", podLabels["custom-label"], "custom-value")
+	envMap := make(map[string]string)
+	for _, env := range container.Env {
+		envMap[env.Name] = env.Value
 	}
-	// Verify base labels are still present
-	if podLabels["app"] != "kubetask" {
-		t.Errorf("PodLabels[app] = %q, want %q", podLabels["app"], "kubetask")
+	for name, want := range map[string]string{
+		"TASK_NAME":      "gemini-upgrade-0-fix-lint",
+		"TASK_NAMESPACE": "default",
+		"AGENT_NAME":     "gemini",
+		"EVAL_CASE":      "fix-lint",
+	} {
+		if envMap[name] != want {
+			t.Errorf("Env %s = %q, want %q", name, envMap[name], want)
+		}
 	}
 }
 
-func TestBuildJob_WithContextConfigMap(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
+func TestSummarizeEvalResults(t *testing.T) {
+	cases := []kubetaskv1alpha1.EvalCaseResult{
+		{AgentRef: "stable", Case: "a", Outcome: kubetaskv1alpha1.EvalCaseOutcomePassed},
+		{AgentRef: "stable", Case: "b", Outcome: kubetaskv1alpha1.EvalCaseOutcomeFailed},
+		{AgentRef: "stable", Case: "c", Outcome: kubetaskv1alpha1.EvalCaseOutcomePassed},
+		{AgentRef: "candidate", Case: "a", Outcome: kubetaskv1alpha1.EvalCaseOutcomePassed},
+		{AgentRef: "candidate", Case: "b", Outcome: kubetaskv1alpha1.EvalCaseOutcomePending},
+		{AgentRef: "candidate", Case: "c", Outcome: kubetaskv1alpha1.EvalCaseOutcomePassed},
 	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
 
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-	}
+	results := summarizeEvalResults([]string{"stable", "candidate"}, cases)
 
-	contextConfigMap := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task-context",
-			Namespace: "default",
-		},
-		Data: map[string]string{
-			"workspace-task.md": "# Test Task",
-		},
+	want := []kubetaskv1alpha1.AgentEvalResult{
+		{AgentRef: "stable", Passed: 2, Failed: 1, Total: 3, PassRatePercent: 66},
+		{AgentRef: "candidate", Passed: 2, Failed: 0, Total: 3, PassRatePercent: 66},
 	}
-
-	fileMounts := []fileMount{
-		{filePath: "/workspace/task.md"},
+	if len(results) != len(want) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(want))
 	}
-
-	job := buildJob(task, "test-task-job", cfg, contextConfigMap, fileMounts, nil, nil)
-
-	// Verify context-files volume exists
-	var foundContextVolume bool
-	for _, vol := range job.Spec.Template.Spec.Volumes {
-		if vol.Name == "context-files" && vol.ConfigMap != nil {
-			foundContextVolume = true
-			if vol.ConfigMap.Name != "test-task-context" {
-				t.Errorf("context-files volume ConfigMap.Name = %q, want %q", vol.ConfigMap.Name, "test-task-context")
-			}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("results[%d] = %+v, want %+v", i, results[i], want[i])
 		}
 	}
-	if !foundContextVolume {
-		t.Errorf("context-files volume not found")
-	}
 
-	// Verify volume mount exists
-	container := job.Spec.Template.Spec.Containers[0]
-	var foundMount bool
-	for _, mount := range container.VolumeMounts {
-		if mount.MountPath == "/workspace/task.md" {
-			foundMount = true
-			if mount.SubPath != "workspace-task.md" {
-				t.Errorf("VolumeMount.SubPath = %q, want %q", mount.SubPath, "workspace-task.md")
-			}
-		}
+	if evalFinished(cases) {
+		t.Errorf("evalFinished() = true with a pending case, want false")
 	}
-	if !foundMount {
-		t.Errorf("Volume mount for /workspace/task.md not found")
+	if got := formatEvalResults(results[:1]); got != "stable: 2/3 (66%)" {
+		t.Errorf("formatEvalResults() = %q, want %q", got, "stable: 2/3 (66%)")
 	}
 }
 
-func TestBuildJob_WithDirMounts(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       types.UID("test-uid"),
-		},
-	}
-	task.APIVersion = "kubetask.io/v1alpha1"
-	task.Kind = "Task"
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-	}
-
-	dirMounts := []dirMount{
-		{
-			dirPath:       "/workspace/guides",
-			configMapName: "guides-configmap",
-			optional:      true,
-		},
-	}
-
-	job := buildJob(task, "test-task-job", cfg, nil, nil, dirMounts, nil)
-
-	// Verify dir-mount volume exists
-	var foundDirVolume bool
-	for _, vol := range job.Spec.Template.Spec.Volumes {
-		if vol.Name == "dir-mount-0" && vol.ConfigMap != nil {
-			foundDirVolume = true
-			if vol.ConfigMap.Name != "guides-configmap" {
-				t.Errorf("dir-mount-0 volume ConfigMap.Name = %q, want %q", vol.ConfigMap.Name, "guides-configmap")
-			}
-			if vol.ConfigMap.Optional == nil || *vol.ConfigMap.Optional != true {
-				t.Errorf("dir-mount-0 volume ConfigMap.Optional = %v, want true", vol.ConfigMap.Optional)
-			}
-		}
-	}
-	if !foundDirVolume {
-		t.Errorf("dir-mount-0 volume not found")
-	}
-
-	// Verify volume mount exists
-	container := job.Spec.Template.Spec.Containers[0]
-	var foundMount bool
-	for _, mount := range container.VolumeMounts {
-		if mount.MountPath == "/workspace/guides" {
-			foundMount = true
-		}
-	}
-	if !foundMount {
-		t.Errorf("Volume mount for /workspace/guides not found")
-	}
+func contains(s, substr string) bool {
+	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
 
-func TestBuildJob_WithGitMounts(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       "test-uid",
-		},
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "kubetask.io/v1alpha1",
-			Kind:       "Task",
-		},
-	}
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
-	}
-
-	gitMounts := []gitMount{
-		{
-			contextName: "my-context",
-			repository:  "https://github.com/org/repo.git",
-			ref:         "main",
-			repoPath:    ".claude/",
-			mountPath:   "/workspace/.claude",
-			depth:       1,
-			secretName:  "",
-		},
-	}
-
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
-
-	// Verify init container exists
-	if len(job.Spec.Template.Spec.InitContainers) != 1 {
-		t.Fatalf("Expected 1 init container, got %d", len(job.Spec.Template.Spec.InitContainers))
-	}
-
-	initContainer := job.Spec.Template.Spec.InitContainers[0]
-	if initContainer.Name != "git-sync-0" {
-		t.Errorf("Init container name = %q, want %q", initContainer.Name, "git-sync-0")
-	}
-	if initContainer.Image != DefaultGitSyncImage {
-		t.Errorf("Init container image = %q, want %q", initContainer.Image, DefaultGitSyncImage)
-	}
-
-	// Verify environment variables
-	envMap := make(map[string]string)
-	for _, env := range initContainer.Env {
-		envMap[env.Name] = env.Value
-	}
-	if envMap["GITSYNC_REPO"] != "https://github.com/org/repo.git" {
-		t.Errorf("GITSYNC_REPO = %q, want %q", envMap["GITSYNC_REPO"], "https://github.com/org/repo.git")
-	}
-	if envMap["GITSYNC_REF"] != "main" {
-		t.Errorf("GITSYNC_REF = %q, want %q", envMap["GITSYNC_REF"], "main")
-	}
-	if envMap["GITSYNC_ONE_TIME"] != "true" {
-		t.Errorf("GITSYNC_ONE_TIME = %q, want %q", envMap["GITSYNC_ONE_TIME"], "true")
-	}
-	if envMap["GITSYNC_DEPTH"] != "1" {
-		t.Errorf("GITSYNC_DEPTH = %q, want %q", envMap["GITSYNC_DEPTH"], "1")
-	}
-
-	// Verify emptyDir volume exists
-	var foundGitVolume bool
-	for _, vol := range job.Spec.Template.Spec.Volumes {
-		if vol.Name == "git-context-0" && vol.EmptyDir != nil {
-			foundGitVolume = true
-		}
-	}
-	if !foundGitVolume {
-		t.Errorf("git-context-0 emptyDir volume not found")
-	}
-
-	// Verify volume mount in agent container with correct subPath
-	container := job.Spec.Template.Spec.Containers[0]
-	var foundMount bool
-	for _, mount := range container.VolumeMounts {
-		if mount.MountPath == "/workspace/.claude" && mount.Name == "git-context-0" {
-			foundMount = true
-			expectedSubPath := "repo/.claude/"
-			if mount.SubPath != expectedSubPath {
-				t.Errorf("Volume mount SubPath = %q, want %q", mount.SubPath, expectedSubPath)
-			}
+func containsHelper(s, substr string) bool {
+	for i := 0; i <= len(s)-len(substr); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
 		}
 	}
-	if !foundMount {
-		t.Errorf("Volume mount for /workspace/.claude not found")
-	}
+	return false
 }
 
-func TestBuildJob_WithGitMountsAndAuth(t *testing.T) {
-	task := &kubetaskv1alpha1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-task",
-			Namespace: "default",
-			UID:       "test-uid",
-		},
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "kubetask.io/v1alpha1",
-			Kind:       "Task",
-		},
+func TestBuildAgentConfig_ReservedContainerName(t *testing.T) {
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       kubetaskv1alpha1.AgentSpec{ServiceAccountName: "test-sa", ContainerName: "git-sync-0"},
 	}
-
-	cfg := agentConfig{
-		agentImage:         "test-agent:v1.0.0",
-		workspaceDir:       "/workspace",
-		serviceAccountName: "test-sa",
+	if _, err := buildAgentConfig(agent, DefaultAgentImage); err == nil {
+		t.Errorf("buildAgentConfig() error = nil, want error for a containerName used by init containers")
 	}
-
-	gitMounts := []gitMount{
-		{
-			contextName: "private-repo",
-			repository:  "https://github.com/org/private-repo.git",
-			ref:         "v1.0.0",
-			repoPath:    "",
-			mountPath:   "/workspace/git-private-repo",
-			depth:       1,
-			secretName:  "git-credentials",
-		},
-	}
-
-	job := buildJob(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
-
-	// Verify init container has auth env vars
-	initContainer := job.Spec.Template.Spec.InitContainers[0]
-	var foundUsername, foundPassword bool
-	for _, env := range initContainer.Env {
-		if env.Name == "GITSYNC_USERNAME" && env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
-			if env.ValueFrom.SecretKeyRef.Name == "git-credentials" && env.ValueFrom.SecretKeyRef.Key == "username" {
-				foundUsername = true
-			}
-		}
-		if env.Name == "GITSYNC_PASSWORD" && env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
-			if env.ValueFrom.SecretKeyRef.Name == "git-credentials" && env.ValueFrom.SecretKeyRef.Key == "password" {
-				foundPassword = true
-			}
-		}
-	}
-	if !foundUsername {
-		t.Errorf("GITSYNC_USERNAME env var with secret reference not found")
-	}
-	if !foundPassword {
-		t.Errorf("GITSYNC_PASSWORD env var with secret reference not found")
-	}
-
-	// Verify volume mount without subPath (entire repo)
-	container := job.Spec.Template.Spec.Containers[0]
-	var foundMount bool
-	for _, mount := range container.VolumeMounts {
-		if mount.MountPath == "/workspace/git-private-repo" && mount.Name == "git-context-0" {
-			foundMount = true
-			if mount.SubPath != "repo" {
-				t.Errorf("Volume mount SubPath = %q, want %q", mount.SubPath, "repo")
-			}
-		}
-	}
-	if !foundMount {
-		t.Errorf("Volume mount for /workspace/git-private-repo not found")
+	agent.Spec.ContainerName = "coder"
+	if cfg, err := buildAgentConfig(agent, DefaultAgentImage); err != nil || cfg.ContainerName != "coder" {
+		t.Errorf("buildAgentConfig() = %q, %v, want containerName coder", cfg.ContainerName, err)
 	}
 }
 
-func TestBuildGitSyncInitContainer(t *testing.T) {
-	gm := gitMount{
-		contextName: "test-context",
-		repository:  "https://github.com/test/repo.git",
-		ref:         "develop",
-		repoPath:    "docs/",
-		mountPath:   "/workspace/docs",
-		depth:       5,
-		secretName:  "",
-	}
-
-	container := buildGitSyncInitContainer(gm, "git-vol-0", 0)
-
-	if container.Name != "git-sync-0" {
-		t.Errorf("Container name = %q, want %q", container.Name, "git-sync-0")
-	}
-
-	if container.Image != DefaultGitSyncImage {
-		t.Errorf("Container image = %q, want %q", container.Image, DefaultGitSyncImage)
+func TestGetAgentConfig_RestartPolicyWithRetryPolicy(t *testing.T) {
+	onFailure := corev1.RestartPolicyOnFailure
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+		Spec: kubetaskv1alpha1.AgentSpec{
+			ServiceAccountName: "test-sa",
+			PodSpec:            &kubetaskv1alpha1.AgentPodSpec{RestartPolicy: &onFailure},
+		},
 	}
+	r := &TaskReconciler{Client: newInitTestClient(t, interceptor.Funcs{}, agent)}
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"}}
 
-	// Check env vars
-	envMap := make(map[string]string)
-	for _, env := range container.Env {
-		if env.Value != "" {
-			envMap[env.Name] = env.Value
-		}
+	// OnFailure alone is bounded by the Job's backoffLimit
+	if _, err := r.getAgentConfig(context.Background(), task); err != nil {
+		t.Errorf("getAgentConfig() error = %v for OnFailure without a retryPolicy", err)
 	}
 
-	if envMap["GITSYNC_REPO"] != "https://github.com/test/repo.git" {
-		t.Errorf("GITSYNC_REPO = %q, want %q", envMap["GITSYNC_REPO"], "https://github.com/test/repo.git")
-	}
-	if envMap["GITSYNC_REF"] != "develop" {
-		t.Errorf("GITSYNC_REF = %q, want %q", envMap["GITSYNC_REF"], "develop")
-	}
-	if envMap["GITSYNC_DEPTH"] != "5" {
-		t.Errorf("GITSYNC_DEPTH = %q, want %q", envMap["GITSYNC_DEPTH"], "5")
+	task.Spec.RetryPolicy = &kubetaskv1alpha1.RetryPolicy{MaxRetries: 2}
+	_, err := r.getAgentConfig(context.Background(), task)
+	if err == nil || !strings.Contains(err.Error(), "cannot be combined with the Task's retryPolicy") {
+		t.Errorf("getAgentConfig() error = %v, want OnFailure with a retryPolicy rejected", err)
 	}
 
-	// Verify volume mount
-	if len(container.VolumeMounts) != 1 {
-		t.Fatalf("Expected 1 volume mount, got %d", len(container.VolumeMounts))
-	}
-	if container.VolumeMounts[0].Name != "git-vol-0" {
-		t.Errorf("Volume mount name = %q, want %q", container.VolumeMounts[0].Name, "git-vol-0")
+	never := corev1.RestartPolicyNever
+	agent.Spec.PodSpec.RestartPolicy = &never
+	if err := checkRestartPolicy(agent, task); err != nil {
+		t.Errorf("checkRestartPolicy() error = %v for Never with a retryPolicy", err)
 	}
-	if container.VolumeMounts[0].MountPath != "/git" {
-		t.Errorf("Volume mount path = %q, want %q", container.VolumeMounts[0].MountPath, "/git")
-	}
-}
-
-// contains checks if a string contains a substring
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
-}
-
-func containsHelper(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
 }
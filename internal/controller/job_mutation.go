@@ -0,0 +1,129 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// JobMutator adjusts the Job built for a Task before it is created, e.g. to add
+// organization-specific labels, tolerations or sidecars. Mutators change job in
+// place; its name, namespace and owner references are restored afterwards.
+type JobMutator interface {
+	MutateJob(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job) error
+}
+
+// JobMutatorFunc adapts a function to a JobMutator
+type JobMutatorFunc func(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job) error
+
+// MutateJob implements JobMutator
+func (f JobMutatorFunc) MutateJob(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job) error {
+	return f(ctx, task, job)
+}
+
+// WebhookJobMutator delegates mutation to an HTTP endpoint. It POSTs
+// {"task": ..., "job": ...} and expects {"job": ...} with the mutated Job.
+type WebhookJobMutator struct {
+	// URL of the mutation endpoint
+	URL string
+
+	// HTTPClient is used for requests, a client with a 10s timeout if nil
+	HTTPClient *http.Client
+}
+
+var _ JobMutator = &WebhookJobMutator{}
+
+// defaultJobMutationHTTPClient is used by webhook mutators that do not set an HTTP client
+var defaultJobMutationHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// MutateJob implements JobMutator
+func (m *WebhookJobMutator) MutateJob(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job) error {
+	body, err := json.Marshal(map[string]interface{}{"task": task, "job": job})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := m.HTTPClient
+	if httpClient == nil {
+		httpClient = defaultJobMutationHTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("job mutation webhook returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	var out struct {
+		Job *batchv1.Job `json:"job"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("decoding job mutation webhook response: %w", err)
+	}
+	if out.Job == nil {
+		return fmt.Errorf("job mutation webhook response has no job")
+	}
+	*job = *out.Job
+	return nil
+}
+
+// mutateJob runs the reconciler's mutators and then the namespace's webhooks over
+// job. Webhooks with the Ignore failure policy are skipped when they fail; any
+// other failure is returned and leaves job unchanged.
+func (r *TaskReconciler) mutateJob(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job, webhooks []kubetaskv1alpha1.JobMutationWebhook) error {
+	if len(r.JobMutators) == 0 && len(webhooks) == 0 {
+		return nil
+	}
+
+	mutated := job.DeepCopy()
+	for i, mutator := range r.JobMutators {
+		if err := applyJobMutator(ctx, task, mutated, mutator); err != nil {
+			return fmt.Errorf("job mutator %d failed: %w", i, err)
+		}
+	}
+	for _, webhook := range webhooks {
+		candidate := mutated.DeepCopy()
+		err := applyJobMutator(ctx, task, candidate, &WebhookJobMutator{URL: webhook.URL})
+		if err == nil {
+			mutated = candidate
+			continue
+		}
+		if webhook.FailurePolicy == kubetaskv1alpha1.JobMutationFailurePolicyIgnore {
+			log.FromContext(ctx).Error(err, "ignoring failed job mutation webhook", "webhook", webhook.Name)
+			continue
+		}
+		return fmt.Errorf("job mutation webhook %q failed: %w", webhook.Name, err)
+	}
+	*job = *mutated
+	return nil
+}
+
+// applyJobMutator runs mutator over job, keeping the fields the controller
+// identifies the Job by
+func applyJobMutator(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job, mutator JobMutator) error {
+	name, namespace, owners := job.Name, job.Namespace, job.OwnerReferences
+	if err := mutator.MutateJob(ctx, task, job); err != nil {
+		return err
+	}
+	job.Name, job.Namespace, job.OwnerReferences = name, namespace, owners
+	return nil
+}
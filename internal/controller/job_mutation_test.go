@@ -0,0 +1,190 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// newMutationWebhook serves a job mutation webhook that adds a toleration and
+// tries to rename the Job
+func newMutationWebhook(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var in struct {
+			Task *kubetaskv1alpha1.Task `json:"task"`
+			Job  *batchv1.Job           `json:"job"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&in); err != nil || in.Task == nil || in.Job == nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		in.Job.Name = "renamed"
+		in.Job.Spec.Template.Spec.Tolerations = append(in.Job.Spec.Template.Spec.Tolerations,
+			corev1.Toleration{Key: "team", Value: in.Task.Namespace, Effect: corev1.TaintEffectNoSchedule})
+		json.NewEncoder(w).Encode(map[string]interface{}{"job": in.Job})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMutateJob(t *testing.T) {
+	webhook := newMutationWebhook(t)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "mutator overloaded", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"}}
+	newJob := func() *batchv1.Job {
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:            "fix-job",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Task", Name: "fix", UID: "task-uid"}},
+		}}
+	}
+	var order []string
+	r := &TaskReconciler{JobMutators: []JobMutator{
+		JobMutatorFunc(func(_ context.Context, _ *kubetaskv1alpha1.Task, job *batchv1.Job) error {
+			order = append(order, "first")
+			job.Labels = map[string]string{"cost-center": "platform"}
+			return nil
+		}),
+		JobMutatorFunc(func(_ context.Context, _ *kubetaskv1alpha1.Task, job *batchv1.Job) error {
+			order = append(order, "second:"+job.Labels["cost-center"])
+			job.Namespace = "elsewhere"
+			job.OwnerReferences = nil
+			return nil
+		}),
+	}}
+
+	t.Run("mutators then webhooks", func(t *testing.T) {
+		job := newJob()
+		err := r.mutateJob(context.Background(), task, job, []kubetaskv1alpha1.JobMutationWebhook{{Name: "tolerations", URL: webhook.URL}})
+		if err != nil {
+			t.Fatalf("mutateJob() error = %v", err)
+		}
+		if strings.Join(order, ",") != "first,second:platform" {
+			t.Errorf("mutators ran as %v", order)
+		}
+		if job.Labels["cost-center"] != "platform" || len(job.Spec.Template.Spec.Tolerations) != 1 {
+			t.Errorf("job = %+v, want the label and toleration", job)
+		}
+		if job.Name != "fix-job" || job.Namespace != "default" || len(job.OwnerReferences) != 1 {
+			t.Errorf("job identity = %s/%s owners %v, want it kept", job.Namespace, job.Name, job.OwnerReferences)
+		}
+	})
+
+	t.Run("ignored webhook failure", func(t *testing.T) {
+		job := newJob()
+		err := r.mutateJob(context.Background(), task, job, []kubetaskv1alpha1.JobMutationWebhook{
+			{Name: "optional", URL: failing.URL, FailurePolicy: kubetaskv1alpha1.JobMutationFailurePolicyIgnore},
+			{Name: "tolerations", URL: webhook.URL},
+		})
+		if err != nil {
+			t.Fatalf("mutateJob() error = %v", err)
+		}
+		if len(job.Spec.Template.Spec.Tolerations) != 1 {
+			t.Errorf("tolerations = %v, want the second webhook applied", job.Spec.Template.Spec.Tolerations)
+		}
+	})
+
+	t.Run("failed webhook", func(t *testing.T) {
+		job := newJob()
+		err := r.mutateJob(context.Background(), task, job, []kubetaskv1alpha1.JobMutationWebhook{
+			{Name: "required", URL: failing.URL, FailurePolicy: kubetaskv1alpha1.JobMutationFailurePolicyFail},
+		})
+		if err == nil || !strings.Contains(err.Error(), `webhook "required" failed: job mutation webhook returned HTTP 503: mutator overloaded`) {
+			t.Errorf("mutateJob() error = %v, want the webhook failure", err)
+		}
+		if job.Labels != nil {
+			t.Errorf("labels = %v, want the Job unchanged", job.Labels)
+		}
+	})
+}
+
+func TestInitializeTask_JobMutationWebhook(t *testing.T) {
+	webhook := newMutationWebhook(t)
+	agent, task := newInitTestObjects()
+	config := &kubetaskv1alpha1.KubeTaskConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+		Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+			JobMutation: &kubetaskv1alpha1.JobMutationConfig{
+				Webhooks: []kubetaskv1alpha1.JobMutationWebhook{{Name: "required", URL: "http://127.0.0.1:1/mutate"}},
+			},
+		},
+	}
+	c := newInitTestClient(t, interceptor.Funcs{}, agent, task, config)
+	r := &TaskReconciler{Client: c}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: initTaskKey}); err == nil {
+		t.Fatal("Reconcile() succeeded, want the webhook failure")
+	}
+	held := &kubetaskv1alpha1.Task{}
+	if err := c.Get(context.Background(), initTaskKey, held); err != nil {
+		t.Fatal(err)
+	}
+	if ready := meta.FindStatusCondition(held.Status.Conditions, "Ready"); ready == nil || ready.Reason != "JobMutationFailed" {
+		t.Errorf("Ready = %+v, want JobMutationFailed", ready)
+	}
+	jobKey := types.NamespacedName{Name: "fix-job", Namespace: "default"}
+	if err := c.Get(context.Background(), jobKey, &batchv1.Job{}); err == nil {
+		t.Error("Job was created despite the failed webhook")
+	}
+
+	config.Spec.JobMutation.Webhooks[0].URL = webhook.URL
+	if err := c.Update(context.Background(), config); err != nil {
+		t.Fatal(err)
+	}
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+	job := &batchv1.Job{}
+	if err := c.Get(context.Background(), jobKey, job); err != nil {
+		t.Fatal(err)
+	}
+	if tolerations := job.Spec.Template.Spec.Tolerations; len(tolerations) != 1 || tolerations[0].Value != "default" {
+		t.Errorf("tolerations = %v, want the webhook's toleration", tolerations)
+	}
+}
+
+func TestInitializeTask_InvalidJobTemplateOverrides(t *testing.T) {
+	agent, task := newInitTestObjects()
+	agent.Spec.JobTemplateOverrides = &runtime.RawExtension{Raw: []byte(`{"spec": {"template": {"spec": {"hostNetwork": true}}}}`)}
+	c := newInitTestClient(t, interceptor.Funcs{}, agent, task)
+
+	if _, err := reconcileInitTask(t, c); err == nil {
+		t.Fatal("Reconcile() succeeded, want the overrides rejected")
+	}
+	held := &kubetaskv1alpha1.Task{}
+	if err := c.Get(context.Background(), initTaskKey, held); err != nil {
+		t.Fatal(err)
+	}
+	ready := meta.FindStatusCondition(held.Status.Conditions, "Ready")
+	if ready == nil || ready.Reason != "InvalidJobTemplateOverrides" || !strings.Contains(ready.Message, "hostNetwork") {
+		t.Errorf("Ready = %+v, want InvalidJobTemplateOverrides", ready)
+	}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "fix-job", Namespace: "default"}, &batchv1.Job{}); err == nil {
+		t.Error("Job was created despite the invalid overrides")
+	}
+}
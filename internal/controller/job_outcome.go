@@ -0,0 +1,145 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// JobOutcomeConsistentConditionType is set to False when a Job reports conflicting
+// outcomes, or changes its outcome after the Task finished
+const JobOutcomeConsistentConditionType = "JobOutcomeConsistent"
+
+// jobOutcome is the result of a Job as the Task sees it
+type jobOutcome struct {
+	// phase is Completed, Failed, or empty while the Job is running
+	phase kubetaskv1alpha1.TaskPhase
+
+	// conflict describes conflicting terminal conditions, if any
+	conflict string
+}
+
+// jobOutcomeOf decides the outcome of a Job. Terminal conditions take precedence
+// over pod counts, and a Failed condition over a Complete one. Without terminal
+// conditions a succeeded pod wins over failed ones, which are earlier attempts.
+func jobOutcomeOf(job *batchv1.Job) jobOutcome {
+	var complete, failed bool
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			complete = true
+		case batchv1.JobFailed:
+			failed = true
+		}
+	}
+
+	switch {
+	case complete && failed:
+		return jobOutcome{
+			phase:    kubetaskv1alpha1.TaskPhaseFailed,
+			conflict: fmt.Sprintf("Job %s has both Complete and Failed conditions (%d succeeded, %d failed pods); treating it as failed", job.Name, job.Status.Succeeded, job.Status.Failed),
+		}
+	case failed:
+		return jobOutcome{phase: kubetaskv1alpha1.TaskPhaseFailed}
+	case complete, job.Status.Succeeded > 0:
+		return jobOutcome{phase: kubetaskv1alpha1.TaskPhaseCompleted}
+	case job.Status.Failed > 0:
+		return jobOutcome{phase: kubetaskv1alpha1.TaskPhaseFailed}
+	}
+	return jobOutcome{}
+}
+
+// reconcileLateJobChanges compares the Job of a finished Task with the Task's phase.
+// A changed outcome sets JobOutcomeConsistent to False and, with the Update policy,
+// moves the Task to the Job's new outcome.
+func (r *TaskReconciler) reconcileLateJobChanges(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	log := log.FromContext(ctx)
+
+	// Only Tasks whose phase came from their Job; Tasks failed by the controller,
+	// e.g. cancelled or with stale contexts, keep their phase
+	ready := meta.FindStatusCondition(task.Status.Conditions, "Ready")
+	if task.Status.JobName == "" || ready == nil || (ready.Reason != "JobSucceeded" && ready.Reason != "JobFailed" && ready.Reason != "DeadlineExceeded") {
+		return nil
+	}
+	config, err := r.kubeTaskConfig().get(ctx, task.Namespace)
+	if err != nil {
+		return err
+	}
+	policy := kubetaskv1alpha1.LateJobChangeRecord
+	if config != nil && config.Spec.TaskLifecycle != nil && config.Spec.TaskLifecycle.LateJobChanges != "" {
+		policy = config.Spec.TaskLifecycle.LateJobChanges
+	}
+	if policy == kubetaskv1alpha1.LateJobChangeIgnore {
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: task.Status.JobName, Namespace: task.Namespace}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	outcome := jobOutcomeOf(job)
+	if outcome.phase == "" || outcome.phase == task.Status.Phase {
+		if outcome.conflict == "" || !setJobOutcomeConflict(task, "ConflictingJobConditions", outcome.conflict) {
+			return nil
+		}
+		return r.Status().Update(ctx, task)
+	}
+
+	message := fmt.Sprintf("Job %s reported %s after the Task was %s", job.Name, outcome.phase, task.Status.Phase)
+	if outcome.conflict != "" {
+		message += ": " + outcome.conflict
+	}
+	changed := setJobOutcomeConflict(task, "JobOutcomeChanged", message)
+	if policy == kubetaskv1alpha1.LateJobChangeUpdate {
+		log.Info("Job outcome changed after the Task finished, updating the Task", "job", job.Name, "from", task.Status.Phase, "to", outcome.phase)
+		task.Status.Phase = outcome.phase
+		ready := metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionTrue,
+			Reason:  "JobSucceeded",
+			Message: fmt.Sprintf("Job %s succeeded", job.Name),
+		}
+		if outcome.phase == kubetaskv1alpha1.TaskPhaseFailed {
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = "JobFailed"
+			ready.Message = fmt.Sprintf("Job %s failed after it succeeded", job.Name)
+		}
+		meta.SetStatusCondition(&task.Status.Conditions, ready)
+		changed = true
+	} else if changed {
+		log.Info("Job outcome changed after the Task finished", "job", job.Name, "phase", task.Status.Phase, "jobOutcome", outcome.phase)
+	}
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, task)
+}
+
+// setJobOutcomeConflict sets the JobOutcomeConsistent condition to False and
+// reports whether it changed
+func setJobOutcomeConflict(task *kubetaskv1alpha1.Task, reason, message string) bool {
+	return meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    JobOutcomeConsistentConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func jobWith(succeeded, failed int32, conditions ...batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-job", Namespace: "default"},
+		Status:     batchv1.JobStatus{Succeeded: succeeded, Failed: failed},
+	}
+	for _, c := range conditions {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: c, Status: corev1.ConditionTrue})
+	}
+	return job
+}
+
+func TestJobOutcomeOf(t *testing.T) {
+	tests := []struct {
+		name         string
+		job          *batchv1.Job
+		want         kubetaskv1alpha1.TaskPhase
+		wantConflict bool
+	}{
+		{name: "running", job: jobWith(0, 0), want: ""},
+		{name: "succeeded", job: jobWith(1, 0), want: kubetaskv1alpha1.TaskPhaseCompleted},
+		{name: "failed", job: jobWith(0, 1), want: kubetaskv1alpha1.TaskPhaseFailed},
+		{name: "succeeded after a failed attempt", job: jobWith(1, 2), want: kubetaskv1alpha1.TaskPhaseCompleted},
+		{name: "failed condition wins over succeeded count", job: jobWith(1, 1, batchv1.JobFailed), want: kubetaskv1alpha1.TaskPhaseFailed},
+		{name: "complete condition", job: jobWith(1, 1, batchv1.JobComplete), want: kubetaskv1alpha1.TaskPhaseCompleted},
+		{
+			name:         "conflicting conditions",
+			job:          jobWith(1, 1, batchv1.JobComplete, batchv1.JobFailed),
+			want:         kubetaskv1alpha1.TaskPhaseFailed,
+			wantConflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := jobOutcomeOf(tt.job)
+			if got.phase != tt.want {
+				t.Errorf("phase = %q, want %q", got.phase, tt.want)
+			}
+			if (got.conflict != "") != tt.wantConflict {
+				t.Errorf("conflict = %q, want conflict %v", got.conflict, tt.wantConflict)
+			}
+		})
+	}
+}
+
+func finishedTask(phase kubetaskv1alpha1.TaskPhase, readyReason string) *kubetaskv1alpha1.Task {
+	status := metav1.ConditionTrue
+	if phase == kubetaskv1alpha1.TaskPhaseFailed {
+		status = metav1.ConditionFalse
+	}
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", Namespace: "default"},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:   phase,
+			JobName: "task-job",
+			Conditions: []metav1.Condition{{
+				Type: "Ready", Status: status, Reason: readyReason, LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func TestReconcileLateJobChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     kubetaskv1alpha1.LateJobChangePolicy
+		task       *kubetaskv1alpha1.Task
+		job        *batchv1.Job
+		wantPhase  kubetaskv1alpha1.TaskPhase
+		wantReason string
+	}{
+		{
+			name:      "unchanged",
+			task:      finishedTask(kubetaskv1alpha1.TaskPhaseCompleted, "JobSucceeded"),
+			job:       jobWith(1, 0, batchv1.JobComplete),
+			wantPhase: kubetaskv1alpha1.TaskPhaseCompleted,
+		},
+		{
+			name:       "recorded by default",
+			task:       finishedTask(kubetaskv1alpha1.TaskPhaseCompleted, "JobSucceeded"),
+			job:        jobWith(1, 1, batchv1.JobFailed),
+			wantPhase:  kubetaskv1alpha1.TaskPhaseCompleted,
+			wantReason: "JobOutcomeChanged",
+		},
+		{
+			name:       "updated",
+			policy:     kubetaskv1alpha1.LateJobChangeUpdate,
+			task:       finishedTask(kubetaskv1alpha1.TaskPhaseCompleted, "JobSucceeded"),
+			job:        jobWith(1, 1, batchv1.JobComplete, batchv1.JobFailed),
+			wantPhase:  kubetaskv1alpha1.TaskPhaseFailed,
+			wantReason: "JobOutcomeChanged",
+		},
+		{
+			name:      "ignored",
+			policy:    kubetaskv1alpha1.LateJobChangeIgnore,
+			task:      finishedTask(kubetaskv1alpha1.TaskPhaseCompleted, "JobSucceeded"),
+			job:       jobWith(1, 1, batchv1.JobFailed),
+			wantPhase: kubetaskv1alpha1.TaskPhaseCompleted,
+		},
+		{
+			name:       "conflicting conditions on an unchanged outcome",
+			task:       finishedTask(kubetaskv1alpha1.TaskPhaseFailed, "JobFailed"),
+			job:        jobWith(1, 1, batchv1.JobComplete, batchv1.JobFailed),
+			wantPhase:  kubetaskv1alpha1.TaskPhaseFailed,
+			wantReason: "ConflictingJobConditions",
+		},
+		{
+			name:      "failed by the controller",
+			policy:    kubetaskv1alpha1.LateJobChangeUpdate,
+			task:      finishedTask(kubetaskv1alpha1.TaskPhaseFailed, "Cancelled"),
+			job:       jobWith(1, 0, batchv1.JobComplete),
+			wantPhase: kubetaskv1alpha1.TaskPhaseFailed,
+		},
+	}
+
+	scheme := newTestScheme(t)
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []client.Object{tt.task, tt.job}
+			if tt.policy != "" {
+				objects = append(objects, &kubetaskv1alpha1.KubeTaskConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: DefaultKubeTaskConfigName, Namespace: "default"},
+					Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+						TaskLifecycle: &kubetaskv1alpha1.TaskLifecycleConfig{LateJobChanges: tt.policy},
+					},
+				})
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&kubetaskv1alpha1.Task{}).
+				Build()
+			r := &TaskReconciler{Client: c}
+
+			if err := r.reconcileLateJobChanges(context.Background(), tt.task); err != nil {
+				t.Fatalf("reconcileLateJobChanges() error = %v", err)
+			}
+
+			updated := &kubetaskv1alpha1.Task{}
+			if err := c.Get(context.Background(), types.NamespacedName{Name: "task", Namespace: "default"}, updated); err != nil {
+				t.Fatal(err)
+			}
+			if updated.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %s, want %s", updated.Status.Phase, tt.wantPhase)
+			}
+			cond := meta.FindStatusCondition(updated.Status.Conditions, JobOutcomeConsistentConditionType)
+			if tt.wantReason == "" {
+				if cond != nil {
+					t.Errorf("unexpected condition %+v", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != tt.wantReason {
+				t.Fatalf("condition = %+v, want False/%s", cond, tt.wantReason)
+			}
+			if !strings.Contains(cond.Message, "task-job") {
+				t.Errorf("message %q does not name the Job", cond.Message)
+			}
+		})
+	}
+}
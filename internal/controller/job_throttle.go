@@ -0,0 +1,131 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// DefaultJobCreationQPS is how many Tasks per second may start, i.e. have their
+	// ConfigMap, NetworkPolicy and Job created, once the burst is used up
+	DefaultJobCreationQPS = 20
+
+	// DefaultJobCreationBurst is how many Tasks may start at once
+	DefaultJobCreationBurst = 100
+
+	// staleReservationAge is how long a reservation is kept after it became ready
+	// for a Task that did not come back to use it, e.g. because it was deleted
+	staleReservationAge = time.Minute
+
+	// defaultThrottledRetryDelay is the delay before retrying a create the API server
+	// throttled without suggesting one
+	defaultThrottledRetryDelay = 5 * time.Second
+)
+
+// jobCreationThrottle spaces out the creation of the objects of starting Tasks with
+// a token bucket, so a burst of Tasks admitted at once, such as a large batch or a
+// released queue, does not run into API server throttling. Tasks that have to wait
+// keep their reservation and are requeued instead of blocking a reconcile worker, so
+// they start in the order they reserved.
+type jobCreationThrottle struct {
+	limiter *rate.Limiter
+
+	mu           sync.Mutex
+	reservations map[types.UID]*rate.Reservation
+}
+
+// newJobCreationThrottle returns a throttle allowing qps Task starts per second with
+// the given burst, or nil if qps is not positive
+func newJobCreationThrottle(qps float64, burst int) *jobCreationThrottle {
+	if qps <= 0 {
+		return nil
+	}
+	return &jobCreationThrottle{
+		limiter:      rate.NewLimiter(rate.Limit(qps), max(burst, 1)),
+		reservations: map[types.UID]*rate.Reservation{},
+	}
+}
+
+// wait returns how long the Task has to wait at now before creating its objects,
+// reserving a token for it on its first call. Once it returns zero, the token is
+// used and the next call reserves a new one.
+func (t *jobCreationThrottle) wait(task types.UID, now time.Time) time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reservation, ok := t.reservations[task]
+	if !ok {
+		t.pruneLocked(now)
+		reservation = t.limiter.ReserveN(now, 1)
+	}
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		t.reservations[task] = reservation
+	} else {
+		delete(t.reservations, task)
+	}
+	return delay
+}
+
+// pruneLocked drops the reservations of Tasks that did not use them long after they
+// became ready. Their tokens are spent.
+func (t *jobCreationThrottle) pruneLocked(now time.Time) {
+	for uid, reservation := range t.reservations {
+		if reservation.DelayFrom(now.Add(-staleReservationAge)) == 0 {
+			delete(t.reservations, uid)
+		}
+	}
+}
+
+// jobCreationDelay returns how long a starting Task has to wait for the job creation
+// rate limit before creating its objects
+func (r *TaskReconciler) jobCreationDelay(ctx context.Context, task *kubetaskv1alpha1.Task) time.Duration {
+	r.jobThrottleOnce.Do(func() {
+		r.jobThrottle = newJobCreationThrottle(r.JobCreationQPS, r.JobCreationBurst)
+	})
+	delay := r.jobThrottle.wait(task.UID, clockNow(r.Clock))
+	if delay > 0 {
+		log.FromContext(ctx).V(1).Info("job creation rate limited", "delay", delay)
+		jobCreationThrottled.WithLabelValues(task.Namespace, throttleSourceLimiter).Inc()
+	}
+	return delay
+}
+
+// retryThrottled returns the result requeueing a Task after the delay the API server
+// asked for, if err is a 429 Too Many Requests, so throttling does not surface as
+// reconcile errors and their backoff
+func retryThrottled(ctx context.Context, task *kubetaskv1alpha1.Task, err error) (ctrl.Result, bool) {
+	delay, ok := throttledRetryDelay(err)
+	if !ok {
+		return ctrl.Result{}, false
+	}
+	log.FromContext(ctx).Info("API server throttled Task start, retrying", "delay", delay, "reason", err.Error())
+	jobCreationThrottled.WithLabelValues(task.Namespace, throttleSourceAPIServer).Inc()
+	return ctrl.Result{RequeueAfter: delay}, true
+}
+
+// throttledRetryDelay returns the delay the API server asked for when it rejected a
+// request with 429 Too Many Requests, e.g. by API Priority and Fairness
+func throttledRetryDelay(err error) (time.Duration, bool) {
+	if !errors.IsTooManyRequests(err) {
+		return 0, false
+	}
+	if seconds, ok := errors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return defaultThrottledRetryDelay, true
+}
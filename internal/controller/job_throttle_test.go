@@ -0,0 +1,136 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestJobCreationThrottle(t *testing.T) {
+	now := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	throttle := newJobCreationThrottle(1, 2)
+
+	for _, step := range []struct {
+		task types.UID
+		at   time.Duration
+		want time.Duration
+	}{
+		{task: "a", want: 0},
+		{task: "b", want: 0},
+		{task: "c", want: time.Second},
+		{task: "d", want: 2 * time.Second},
+		// A waiting Task keeps its place instead of reserving again
+		{task: "c", at: 500 * time.Millisecond, want: 500 * time.Millisecond},
+		{task: "c", at: time.Second, want: 0},
+		{task: "d", at: time.Second, want: time.Second},
+		{task: "e", at: time.Second, want: 2 * time.Second},
+	} {
+		if got := throttle.wait(step.task, now.Add(step.at)); got != step.want {
+			t.Errorf("wait(%s) at +%s = %s, want %s", step.task, step.at, got, step.want)
+		}
+	}
+
+	// Reservations of Tasks that never came back are dropped
+	throttle.wait("f", now.Add(10*time.Minute))
+	if len(throttle.reservations) != 0 {
+		t.Errorf("reservations = %v, want the stale ones pruned", throttle.reservations)
+	}
+
+	if got := newJobCreationThrottle(0, 10).wait("a", now); got != 0 {
+		t.Errorf("disabled wait() = %s, want 0", got)
+	}
+}
+
+func TestThrottledRetryDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "suggested delay", err: errors.NewTooManyRequests("too many requests", 7), want: 7 * time.Second, wantOK: true},
+		{name: "no suggested delay", err: errors.NewTooManyRequestsError("storage is (re)initializing"), want: defaultThrottledRetryDelay, wantOK: true},
+		{name: "wrapped", err: fmt.Errorf("create Job: %w", errors.NewTooManyRequests("", 2)), want: 2 * time.Second, wantOK: true},
+		{name: "other error", err: errors.NewServiceUnavailable("down")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := throttledRetryDelay(tt.err)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("throttledRetryDelay() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestInitializeTask_JobCreationRateLimited(t *testing.T) {
+	agent, first := newInitTestObjects()
+	_, second := newInitTestObjects()
+	second.Name, second.UID = "fix-2", "task-uid-2"
+	c := newInitTestClient(t, interceptor.Funcs{}, agent, first, second)
+	now := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	r := &TaskReconciler{Client: c, JobCreationQPS: 2, JobCreationBurst: 1, Clock: staticClock{now: now}}
+	ctx := context.Background()
+
+	if result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: initTaskKey}); err != nil || result.RequeueAfter != 0 {
+		t.Fatalf("Reconcile() = %+v, %v, want the first Task started", result, err)
+	}
+	secondKey := client.ObjectKeyFromObject(second)
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: secondKey})
+	if err != nil || result.RequeueAfter != 500*time.Millisecond {
+		t.Fatalf("Reconcile() = %+v, %v, want the second Task requeued for 500ms", result, err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "fix-2-job", Namespace: "default"}, &batchv1.Job{}); !errors.IsNotFound(err) {
+		t.Errorf("Job of the rate limited Task: %v, want it not created yet", err)
+	}
+
+	r.Clock = staticClock{now: now.Add(500 * time.Millisecond)}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: secondKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	started := &kubetaskv1alpha1.Task{}
+	if err := c.Get(ctx, secondKey, started); err != nil {
+		t.Fatal(err)
+	}
+	if started.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+		t.Errorf("phase = %s, want Running once the rate limit allows", started.Status.Phase)
+	}
+}
+
+func TestInitializeTask_APIServerThrottling(t *testing.T) {
+	throttled := failOnce()
+	agent, task := newInitTestObjects()
+	c := newInitTestClient(t, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*batchv1.Job); ok && throttled() {
+				return errors.NewTooManyRequests("the server has received too many requests", 3)
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}, agent, task)
+	r := &TaskReconciler{Client: c}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: initTaskKey})
+	if err != nil || result.RequeueAfter != 3*time.Second {
+		t.Fatalf("Reconcile() = %+v, %v, want a requeue after the suggested 3s", result, err)
+	}
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+}
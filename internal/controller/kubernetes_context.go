@@ -0,0 +1,104 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// MaxKubernetesContextObjects caps the objects a Kubernetes context may select, keeping
+// the context ConfigMap well below its 1 MiB limit
+const MaxKubernetesContextObjects = 50
+
+// resolveKubernetesContext reads the selected objects from the Context's namespace and
+// renders them as YAML or through the JSONPath template
+func (r *TaskReconciler) resolveKubernetesContext(ctx context.Context, namespace string, spec *kubetaskv1alpha1.KubernetesContext) (string, error) {
+	gv, err := schema.ParseGroupVersion(spec.APIVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid apiVersion %q: %w", spec.APIVersion, err)
+	}
+	gvk := gv.WithKind(spec.Kind)
+	// The controller can read Secrets; Contexts must not expose them to Tasks
+	if gvk.Group == "" && gvk.Kind == "Secret" {
+		return "", fmt.Errorf("Secrets cannot be used as Kubernetes context, use Agent credentials instead")
+	}
+
+	var data any
+	var objects []unstructured.Unstructured
+	if spec.Name != "" {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := r.Get(ctx, types.NamespacedName{Name: spec.Name, Namespace: namespace}, obj); err != nil {
+			return "", fmt.Errorf("unable to get %s %q: %w", spec.Kind, spec.Name, err)
+		}
+		objects = []unstructured.Unstructured{*obj}
+		data = obj.Object
+	} else {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(spec.Kind + "List"))
+		opts := []client.ListOption{client.InNamespace(namespace), client.Limit(MaxKubernetesContextObjects + 1)}
+		if spec.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(spec.LabelSelector)
+			if err != nil {
+				return "", fmt.Errorf("invalid labelSelector: %w", err)
+			}
+			opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+		}
+		if err := r.List(ctx, list, opts...); err != nil {
+			return "", fmt.Errorf("unable to list %s: %w", spec.Kind, err)
+		}
+		if len(list.Items) > MaxKubernetesContextObjects {
+			return "", fmt.Errorf("more than %d %s objects selected, narrow the labelSelector", MaxKubernetesContextObjects, spec.Kind)
+		}
+		objects = list.Items
+		items := make([]any, 0, len(objects))
+		for i := range objects {
+			items = append(items, objects[i].Object)
+		}
+		data = map[string]any{"apiVersion": "v1", "kind": "List", "items": items}
+	}
+
+	for i := range objects {
+		unstructured.RemoveNestedField(objects[i].Object, "metadata", "managedFields")
+	}
+
+	if spec.JSONPath != "" {
+		return renderJSONPath(spec.JSONPath, data)
+	}
+
+	docs := make([]string, 0, len(objects))
+	for i := range objects {
+		out, err := yaml.Marshal(objects[i].Object)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, strings.TrimSuffix(string(out), "\n"))
+	}
+	return strings.Join(docs, "\n---\n"), nil
+}
+
+// renderJSONPath executes a kubectl-style JSONPath template
+func renderJSONPath(template string, data any) (string, error) {
+	jp := jsonpath.New("context").AllowMissingKeys(true)
+	if err := jp.Parse(template); err != nil {
+		return "", fmt.Errorf("invalid jsonPath %q: %w", template, err)
+	}
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to execute jsonPath %q: %w", template, err)
+	}
+	return buf.String(), nil
+}
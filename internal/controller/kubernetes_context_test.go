@@ -0,0 +1,105 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newDeployment(name, namespace string, labels map[string]string, available string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"status": map[string]any{
+			"conditions": []any{map[string]any{"type": "Available", "status": available}},
+		},
+	}}
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	obj.SetLabels(labels)
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+	return obj
+}
+
+func TestResolveKubernetesContext(t *testing.T) {
+	objects := []client.Object{
+		newDeployment("api", "default", map[string]string{"tier": "backend"}, "False"),
+		newDeployment("worker", "default", map[string]string{"tier": "backend"}, "True"),
+		newDeployment("web", "default", map[string]string{"tier": "frontend"}, "True"),
+		newDeployment("other-team", "team-b", map[string]string{"tier": "backend"}, "False"),
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(objects...).Build()}
+	backend := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}
+
+	tests := []struct {
+		name    string
+		spec    kubetaskv1alpha1.KubernetesContext
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name:    "YAML dump of selected objects in the Context namespace",
+			spec:    kubetaskv1alpha1.KubernetesContext{APIVersion: "apps/v1", Kind: "Deployment", LabelSelector: backend},
+			want:    []string{"name: api", "\n---\n", "name: worker"},
+			notWant: []string{"name: web", "other-team", "managedFields"},
+		},
+		{
+			name: "JSONPath over the selected list",
+			spec: kubetaskv1alpha1.KubernetesContext{
+				APIVersion: "apps/v1", Kind: "Deployment", LabelSelector: backend,
+				JSONPath: `{range .items[*]}{.metadata.name}={.status.conditions[0].status};{end}`,
+			},
+			want: []string{"api=False;worker=True;"},
+		},
+		{
+			name: "JSONPath over a single object",
+			spec: kubetaskv1alpha1.KubernetesContext{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", JSONPath: "{.metadata.labels.tier}"},
+			want: []string{"frontend"},
+		},
+		{
+			name:    "missing object",
+			spec:    kubetaskv1alpha1.KubernetesContext{APIVersion: "apps/v1", Kind: "Deployment", Name: "other-team"},
+			wantErr: true,
+		},
+		{
+			name:    "Secrets are rejected",
+			spec:    kubetaskv1alpha1.KubernetesContext{APIVersion: "v1", Kind: "Secret", Name: "token"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSONPath",
+			spec:    kubetaskv1alpha1.KubernetesContext{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", JSONPath: "{.metadata"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.resolveKubernetesContext(context.Background(), "default", &tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveKubernetesContext() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("resolveKubernetesContext() = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("resolveKubernetesContext() = %q, must not contain %q", got, notWant)
+				}
+			}
+		})
+	}
+}
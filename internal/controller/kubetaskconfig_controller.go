@@ -0,0 +1,116 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// DefaultKubeTaskConfigName is the name of the KubeTaskConfig controllers read in each namespace
+const DefaultKubeTaskConfigName = "default"
+
+// KubeTaskConfigReconciler reconciles a KubeTaskConfig object
+type KubeTaskConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// DefaultAgentImage is the controller-level default agent image, reported in status
+	// when the KubeTaskConfig does not override it
+	DefaultAgentImage string
+}
+
+// +kubebuilder:rbac:groups=kubetask.io,resources=kubetaskconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kubetask.io,resources=kubetaskconfigs/status,verbs=get;update;patch
+
+// Reconcile validates a KubeTaskConfig and publishes the effective settings to its status
+func (r *KubeTaskConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	config := &kubetaskv1alpha1.KubeTaskConfig{}
+	if err := r.Get(ctx, req.NamespacedName, config); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch KubeTaskConfig")
+		return ctrl.Result{}, err
+	}
+
+	status := config.Status.DeepCopy()
+	status.ObservedGeneration = config.Generation
+
+	lifecycle, err := effectiveTaskLifecycle(config.Spec.TaskLifecycle)
+	if policyErr := validateRuntimePolicy(config.Spec.RuntimePolicy); policyErr != nil {
+		err = stderrors.Join(err, policyErr)
+	}
+	status.Effective = &kubetaskv1alpha1.EffectiveKubeTaskConfig{
+		TaskLifecycle: lifecycle,
+		AgentDefaults: &kubetaskv1alpha1.AgentDefaultsConfig{
+			AgentImage: resolveDefaultAgentImage(config, r.DefaultAgentImage),
+		},
+		RuntimePolicy:   config.Spec.RuntimePolicy.DeepCopy(),
+		ContextCache:    config.Spec.ContextCache.DeepCopy(),
+		Prompt:          config.Spec.Prompt.DeepCopy(),
+		DefaultContexts: slices.Clone(config.Spec.DefaultContexts),
+		JobMutation:     config.Spec.JobMutation.DeepCopy(),
+	}
+
+	valid := metav1.Condition{
+		Type:               "Valid",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: config.Generation,
+		Reason:             "Validated",
+		Message:            "All settings are valid",
+	}
+	if err != nil {
+		valid.Status = metav1.ConditionFalse
+		valid.Reason = "InvalidSettings"
+		valid.Message = fmt.Sprintf("Invalid settings are ignored: %v", err)
+	}
+	meta.SetStatusCondition(&status.Conditions, valid)
+
+	active := metav1.Condition{
+		Type:               "Active",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: config.Generation,
+		Reason:             "DefaultConfig",
+		Message:            "Controllers use this configuration for the namespace",
+	}
+	if config.Name != DefaultKubeTaskConfigName {
+		active.Status = metav1.ConditionFalse
+		active.Reason = "NotDefault"
+		active.Message = fmt.Sprintf("Controllers only read the KubeTaskConfig named %q", DefaultKubeTaskConfigName)
+	}
+	meta.SetStatusCondition(&status.Conditions, active)
+
+	if equality.Semantic.DeepEqual(status, &config.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	config.Status = *status
+	if err := r.Status().Update(ctx, config); err != nil {
+		log.Error(err, "unable to update KubeTaskConfig status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *KubeTaskConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubetaskv1alpha1.KubeTaskConfig{}).
+		Complete(r)
+}
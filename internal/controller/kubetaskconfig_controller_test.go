@@ -0,0 +1,56 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build integration
+
+// See suite_test.go for explanation of the "integration" build tag pattern.
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+var _ = Describe("KubeTaskConfigController", func() {
+	const (
+		configNamespace = "default"
+	)
+
+	Context("When creating a KubeTaskConfig", func() {
+		It("Should publish the effective configuration in status", func() {
+			configName := "test-config-status"
+
+			By("Creating KubeTaskConfig without TTL")
+			config := &kubetaskv1alpha1.KubeTaskConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      configName,
+					Namespace: configNamespace,
+				},
+				Spec: kubetaskv1alpha1.KubeTaskConfigSpec{},
+			}
+			Expect(k8sClient.Create(ctx, config)).Should(Succeed())
+
+			By("Checking status reports defaults and that the config is not active")
+			configLookupKey := types.NamespacedName{Name: configName, Namespace: configNamespace}
+			updatedConfig := &kubetaskv1alpha1.KubeTaskConfig{}
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, configLookupKey, updatedConfig); err != nil {
+					return false
+				}
+				return updatedConfig.Status.Effective != nil
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(*updatedConfig.Status.Effective.TaskLifecycle.TTLSecondsAfterFinished).Should(Equal(DefaultTTLSecondsAfterFinished))
+			Expect(meta.IsStatusConditionTrue(updatedConfig.Status.Conditions, "Valid")).Should(BeTrue())
+			Expect(meta.IsStatusConditionFalse(updatedConfig.Status.Conditions, "Active")).Should(BeTrue())
+
+			By("Cleaning up")
+			Expect(k8sClient.Delete(ctx, config)).Should(Succeed())
+		})
+	})
+})
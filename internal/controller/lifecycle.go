@@ -0,0 +1,150 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// resolveTTLSecondsAfterFinished returns the TTL for a Task with the given labels: that
+// of the first matching rule, else agentTTL, the TTL of the Task's Agent if it sets
+// one, else the KubeTaskConfig TTL. Rules with invalid selectors are skipped and
+// reported in the returned error.
+func resolveTTLSecondsAfterFinished(lifecycle *kubetaskv1alpha1.TaskLifecycleConfig, taskLabels map[string]string, agentTTL *int32) (int32, error) {
+	if lifecycle == nil {
+		if agentTTL != nil {
+			return *agentTTL, nil
+		}
+		return DefaultTTLSecondsAfterFinished, nil
+	}
+
+	var errs []error
+	for i, rule := range lifecycle.Rules {
+		selector, err := metav1.LabelSelectorAsSelector(&rule.Selector)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: %w", i, err))
+			continue
+		}
+		if selector.Matches(labels.Set(taskLabels)) {
+			return rule.TTLSecondsAfterFinished, stderrors.Join(errs...)
+		}
+	}
+
+	if agentTTL != nil {
+		return *agentTTL, stderrors.Join(errs...)
+	}
+	if lifecycle.TTLSecondsAfterFinished != nil {
+		return *lifecycle.TTLSecondsAfterFinished, stderrors.Join(errs...)
+	}
+	return DefaultTTLSecondsAfterFinished, stderrors.Join(errs...)
+}
+
+// effectiveTaskLifecycle applies defaults to a TaskLifecycleConfig and drops rules with
+// invalid selectors, returning the configuration the Task controller will actually use.
+func effectiveTaskLifecycle(lifecycle *kubetaskv1alpha1.TaskLifecycleConfig) (*kubetaskv1alpha1.TaskLifecycleConfig, error) {
+	ttl := DefaultTTLSecondsAfterFinished
+	effective := &kubetaskv1alpha1.TaskLifecycleConfig{
+		TTLSecondsAfterFinished: &ttl,
+		LateJobChanges:          kubetaskv1alpha1.LateJobChangeRecord,
+	}
+	if lifecycle == nil {
+		return effective, nil
+	}
+
+	if lifecycle.TTLSecondsAfterFinished != nil {
+		ttl = *lifecycle.TTLSecondsAfterFinished
+	}
+	if lifecycle.LateJobChanges != "" {
+		effective.LateJobChanges = lifecycle.LateJobChanges
+	}
+
+	var errs []error
+	for i, rule := range lifecycle.Rules {
+		if _, err := metav1.LabelSelectorAsSelector(&rule.Selector); err != nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: %w", i, err))
+			continue
+		}
+		effective.Rules = append(effective.Rules, *rule.DeepCopy())
+	}
+	for i, window := range lifecycle.QuietHours {
+		if _, err := parseQuietHours(window); err != nil {
+			errs = append(errs, fmt.Errorf("quietHours[%d]: %w", i, err))
+			continue
+		}
+		effective.QuietHours = append(effective.QuietHours, window)
+	}
+	return effective, stderrors.Join(errs...)
+}
+
+// parseQuietHours parses the schedule of a quiet hours window in its time zone
+func parseQuietHours(window kubetaskv1alpha1.QuietHours) (cron.Schedule, error) {
+	if window.Duration.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+	spec := window.Schedule
+	if window.TimeZone != "" {
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid timeZone %q: %w", window.TimeZone, err)
+		}
+		spec = "CRON_TZ=" + window.TimeZone + " " + spec
+	}
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", window.Schedule, err)
+	}
+	return schedule, nil
+}
+
+// maxQuietPeriod bounds how far ahead overlapping quiet hours windows are merged, so
+// back-to-back windows that never end are re-evaluated periodically
+const maxQuietPeriod = 7 * 24 * time.Hour
+
+// quietHoursEnd reports whether now falls in one of the quiet hours windows, and when
+// the quiet period ends. Windows that overlap or touch are merged. Invalid windows are
+// skipped and reported in the returned error.
+func quietHoursEnd(lifecycle *kubetaskv1alpha1.TaskLifecycleConfig, now time.Time) (time.Time, bool, error) {
+	if lifecycle == nil {
+		return time.Time{}, false, nil
+	}
+
+	type window struct {
+		schedule cron.Schedule
+		duration time.Duration
+	}
+	var windows []window
+	var errs []error
+	for i, w := range lifecycle.QuietHours {
+		schedule, err := parseQuietHours(w)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("quietHours[%d]: %w", i, err))
+			continue
+		}
+		windows = append(windows, window{schedule: schedule, duration: w.Duration.Duration})
+	}
+
+	// Every start within the last duration opens a window that is still open; starts
+	// before the current end extend the quiet period
+	end := now
+	limit := now.Add(maxQuietPeriod)
+	for extended := true; extended && end.Before(limit); {
+		extended = false
+		for _, w := range windows {
+			// Next returns the zero time for schedules that never fire
+			for start := w.schedule.Next(now.Add(-w.duration)); !start.IsZero() && !start.After(end) && end.Before(limit); start = w.schedule.Next(start) {
+				if windowEnd := start.Add(w.duration); windowEnd.After(end) {
+					end, extended = windowEnd, true
+				}
+			}
+		}
+	}
+	return end, end.After(now), stderrors.Join(errs...)
+}
@@ -0,0 +1,263 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestResolveTTLSecondsAfterFinished(t *testing.T) {
+	namespaceTTL := int32(3600)
+	lifecycle := &kubetaskv1alpha1.TaskLifecycleConfig{
+		TTLSecondsAfterFinished: &namespaceTTL,
+		Rules: []kubetaskv1alpha1.TaskLifecycleRule{
+			{
+				Selector:                metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}},
+				TTLSecondsAfterFinished: 2592000,
+			},
+			{
+				Selector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: CronTaskLabelKey, Operator: metav1.LabelSelectorOpExists},
+				}},
+				TTLSecondsAfterFinished: 86400,
+			},
+		},
+	}
+
+	ttl := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name      string
+		lifecycle *kubetaskv1alpha1.TaskLifecycleConfig
+		labels    map[string]string
+		agentTTL  *int32
+		want      int32
+	}{
+		{
+			name:      "no lifecycle config",
+			lifecycle: nil,
+			labels:    map[string]string{"team": "sre"},
+			want:      DefaultTTLSecondsAfterFinished,
+		},
+		{
+			name:      "matches first rule",
+			lifecycle: lifecycle,
+			labels:    map[string]string{"team": "sre"},
+			want:      2592000,
+		},
+		{
+			name:      "first matching rule wins",
+			lifecycle: lifecycle,
+			labels:    map[string]string{"team": "sre", CronTaskLabelKey: "nightly"},
+			want:      2592000,
+		},
+		{
+			name:      "matches second rule",
+			lifecycle: lifecycle,
+			labels:    map[string]string{CronTaskLabelKey: "nightly"},
+			want:      86400,
+		},
+		{
+			name:      "no rule matches",
+			lifecycle: lifecycle,
+			labels:    map[string]string{"team": "platform"},
+			want:      3600,
+		},
+		{
+			name:      "rules without namespace TTL",
+			lifecycle: &kubetaskv1alpha1.TaskLifecycleConfig{Rules: lifecycle.Rules},
+			labels:    nil,
+			want:      DefaultTTLSecondsAfterFinished,
+		},
+		{
+			name:      "agent TTL overrides namespace TTL",
+			lifecycle: lifecycle,
+			labels:    map[string]string{"team": "platform"},
+			agentTTL:  ttl(600),
+			want:      600,
+		},
+		{
+			name:      "matching rule overrides agent TTL",
+			lifecycle: lifecycle,
+			labels:    map[string]string{"team": "sre"},
+			agentTTL:  ttl(600),
+			want:      2592000,
+		},
+		{
+			name:     "agent TTL without lifecycle config",
+			agentTTL: ttl(0),
+			want:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTTLSecondsAfterFinished(tt.lifecycle, tt.labels, tt.agentTTL)
+			if err != nil {
+				t.Fatalf("resolveTTLSecondsAfterFinished() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveTTLSecondsAfterFinished() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTTLSecondsAfterFinished_InvalidSelector(t *testing.T) {
+	lifecycle := &kubetaskv1alpha1.TaskLifecycleConfig{
+		Rules: []kubetaskv1alpha1.TaskLifecycleRule{
+			{
+				Selector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Bogus"},
+				}},
+				TTLSecondsAfterFinished: 60,
+			},
+			{
+				Selector:                metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}},
+				TTLSecondsAfterFinished: 120,
+			},
+		},
+	}
+
+	got, err := resolveTTLSecondsAfterFinished(lifecycle, map[string]string{"team": "sre"}, nil)
+	if err == nil {
+		t.Errorf("resolveTTLSecondsAfterFinished() error = nil, want error for invalid selector")
+	}
+	if got != 120 {
+		t.Errorf("resolveTTLSecondsAfterFinished() = %d, want %d", got, 120)
+	}
+}
+
+func TestEffectiveTaskLifecycle(t *testing.T) {
+	t.Run("nil config uses defaults", func(t *testing.T) {
+		effective, err := effectiveTaskLifecycle(nil)
+		if err != nil {
+			t.Fatalf("effectiveTaskLifecycle() error = %v", err)
+		}
+		if effective.TTLSecondsAfterFinished == nil || *effective.TTLSecondsAfterFinished != DefaultTTLSecondsAfterFinished {
+			t.Errorf("TTLSecondsAfterFinished = %v, want %d", effective.TTLSecondsAfterFinished, DefaultTTLSecondsAfterFinished)
+		}
+	})
+
+	t.Run("invalid rules are dropped", func(t *testing.T) {
+		ttl := int32(0)
+		lifecycle := &kubetaskv1alpha1.TaskLifecycleConfig{
+			TTLSecondsAfterFinished: &ttl,
+			Rules: []kubetaskv1alpha1.TaskLifecycleRule{
+				{
+					Selector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: "Bogus"},
+					}},
+					TTLSecondsAfterFinished: 60,
+				},
+				{
+					Selector:                metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}},
+					TTLSecondsAfterFinished: 120,
+				},
+			},
+		}
+
+		effective, err := effectiveTaskLifecycle(lifecycle)
+		if err == nil {
+			t.Errorf("effectiveTaskLifecycle() error = nil, want error for invalid selector")
+		}
+		if *effective.TTLSecondsAfterFinished != 0 {
+			t.Errorf("TTLSecondsAfterFinished = %d, want 0", *effective.TTLSecondsAfterFinished)
+		}
+		if len(effective.Rules) != 1 || effective.Rules[0].TTLSecondsAfterFinished != 120 {
+			t.Errorf("Rules = %+v, want only the valid rule", effective.Rules)
+		}
+	})
+
+	t.Run("invalid quiet hours are dropped", func(t *testing.T) {
+		lifecycle := &kubetaskv1alpha1.TaskLifecycleConfig{
+			QuietHours: []kubetaskv1alpha1.QuietHours{
+				{Schedule: "0 20 * * *"},
+				{Schedule: "0 20 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+				{Schedule: "0 20 * * 1-5", Duration: metav1.Duration{Duration: 12 * time.Hour}},
+			},
+		}
+
+		effective, err := effectiveTaskLifecycle(lifecycle)
+		if err == nil {
+			t.Errorf("effectiveTaskLifecycle() error = nil, want error for invalid quiet hours")
+		}
+		if len(effective.QuietHours) != 1 || effective.QuietHours[0].Schedule != "0 20 * * 1-5" {
+			t.Errorf("QuietHours = %+v, want only the valid window", effective.QuietHours)
+		}
+	})
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	hours := func(d time.Duration) metav1.Duration { return metav1.Duration{Duration: d} }
+	at := func(value string) time.Time {
+		ts, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	nightly := kubetaskv1alpha1.QuietHours{Schedule: "0 20 * * *", Duration: hours(12 * time.Hour)}
+
+	tests := []struct {
+		name       string
+		quietHours []kubetaskv1alpha1.QuietHours
+		now        string
+		wantQuiet  bool
+		wantEnd    string
+		wantErr    bool
+	}{
+		{name: "no quiet hours", now: "2026-03-02T23:00:00Z"},
+		{name: "before the window", quietHours: []kubetaskv1alpha1.QuietHours{nightly}, now: "2026-03-02T19:59:00Z"},
+		{name: "window crossing midnight", quietHours: []kubetaskv1alpha1.QuietHours{nightly}, now: "2026-03-03T02:00:00Z",
+			wantQuiet: true, wantEnd: "2026-03-03T08:00:00Z"},
+		{name: "window start is quiet", quietHours: []kubetaskv1alpha1.QuietHours{nightly}, now: "2026-03-02T20:00:00Z",
+			wantQuiet: true, wantEnd: "2026-03-03T08:00:00Z"},
+		{name: "window end is not quiet", quietHours: []kubetaskv1alpha1.QuietHours{nightly}, now: "2026-03-03T08:00:00Z"},
+		{name: "time zone", quietHours: []kubetaskv1alpha1.QuietHours{
+			{Schedule: "0 20 * * *", Duration: hours(12 * time.Hour), TimeZone: "Asia/Tokyo"},
+		}, now: "2026-03-02T12:00:00Z", wantQuiet: true, wantEnd: "2026-03-02T23:00:00Z"},
+		{name: "touching windows are merged", quietHours: []kubetaskv1alpha1.QuietHours{
+			nightly,
+			{Schedule: "0 8 * * 6", Duration: hours(36 * time.Hour)}, // Saturday 08:00 to Sunday 20:00
+		}, now: "2026-03-07T02:00:00Z", wantQuiet: true, wantEnd: "2026-03-09T08:00:00Z"},
+		{name: "invalid window is skipped", quietHours: []kubetaskv1alpha1.QuietHours{
+			{Schedule: "not a schedule", Duration: hours(time.Hour)},
+			nightly,
+		}, now: "2026-03-02T21:00:00Z", wantQuiet: true, wantEnd: "2026-03-03T08:00:00Z", wantErr: true},
+		{name: "invalid time zone", quietHours: []kubetaskv1alpha1.QuietHours{
+			{Schedule: "0 20 * * *", Duration: hours(time.Hour), TimeZone: "Mars/Olympus"},
+		}, now: "2026-03-02T20:30:00Z", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lifecycle := &kubetaskv1alpha1.TaskLifecycleConfig{QuietHours: tt.quietHours}
+			end, quiet, err := quietHoursEnd(lifecycle, at(tt.now))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("quietHoursEnd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if quiet != tt.wantQuiet {
+				t.Fatalf("quietHoursEnd() quiet = %v, want %v", quiet, tt.wantQuiet)
+			}
+			if quiet && !end.Equal(at(tt.wantEnd)) {
+				t.Errorf("quietHoursEnd() end = %s, want %s", end.UTC().Format(time.RFC3339), tt.wantEnd)
+			}
+		})
+	}
+
+	// Back-to-back windows that never end are bounded
+	endless := &kubetaskv1alpha1.TaskLifecycleConfig{QuietHours: []kubetaskv1alpha1.QuietHours{
+		{Schedule: "0 * * * *", Duration: hours(time.Hour)},
+	}}
+	now := at("2026-03-02T12:30:00Z")
+	if end, quiet, _ := quietHoursEnd(endless, now); !quiet || end.Sub(now) > maxQuietPeriod+time.Hour {
+		t.Errorf("quietHoursEnd() = %s, %v, want a bounded quiet period", end, quiet)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// stuckTasks is the number of running Tasks that exceeded their Agent's stuck detection bounds
+	stuckTasks = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubetask_stuck_tasks",
+		Help: "Number of running Tasks that exceeded their Agent's stuck detection bounds",
+	}, []string{"namespace", "agent"})
+
+	// stuckTasksCancelled counts Tasks cancelled by stuck detection
+	stuckTasksCancelled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubetask_stuck_tasks_cancelled_total",
+		Help: "Total number of Tasks cancelled by stuck detection",
+	}, []string{"namespace", "agent"})
+
+	// taskQueueDuration is the time from Task creation until its Job is created
+	taskQueueDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_task_queue_duration_seconds",
+		Help:    "Time from Task creation until the Task is admitted and its Job created",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"namespace", "agent"})
+
+	// taskSchedulingDuration is the time from Job creation until the agent Pod is bound to a node
+	taskSchedulingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_task_scheduling_duration_seconds",
+		Help:    "Time from Job creation until the agent Pod is scheduled",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"namespace", "agent"})
+
+	// taskStartupDuration is the time from scheduling until the agent container runs,
+	// which covers image pulls and context init containers
+	taskStartupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_task_startup_duration_seconds",
+		Help:    "Time from agent Pod scheduling until the agent container starts",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"namespace", "agent"})
+
+	// taskRunDuration is the time the agent container ran until the Task finished
+	taskRunDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_task_run_duration_seconds",
+		Help:    "Time from agent container start until the Task finishes",
+		Buckets: prometheus.ExponentialBuckets(10, 2, 14),
+	}, []string{"namespace", "agent", "phase"})
+
+	// imagePullDuration is how long kubelet took to pull the images of agent Pods
+	imagePullDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_image_pull_duration_seconds",
+		Help:    "Time kubelet took to pull an image of an agent Pod",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"namespace", "agent"})
+
+	// imagePullFailures counts agent Pods whose image pulls failed
+	imagePullFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubetask_image_pull_failures_total",
+		Help: "Total number of Tasks whose agent Pod failed to pull an image",
+	}, []string{"namespace", "agent"})
+
+	// contextScanFindings counts the findings of context scanners by rule
+	contextScanFindings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubetask_context_scan_findings_total",
+		Help: "Total number of secrets-looking strings and prompt-injection patterns found in Task contexts",
+	}, []string{"namespace", "rule"})
+
+	// taskCleanupDeletions counts the Tasks deleted automatically, by what deleted
+	// them (ttl, history) and whether the deletion succeeded
+	taskCleanupDeletions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubetask_task_cleanup_deletions_total",
+		Help: "Total number of automatic Task deletions by TTL cleanup and CronTask history limits",
+	}, []string{"namespace", "reason", "result"})
+
+	// taskTTLDeletionDelay is how long after its TTL expired a Task was deleted
+	taskTTLDeletionDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_task_ttl_deletion_delay_seconds",
+		Help:    "Time from TTL expiry until the expired Task is deleted",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"namespace"})
+
+	// cronTaskHistoryCleanupDuration is how long pruning the history of a CronTask took
+	cronTaskHistoryCleanupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kubetask_crontask_history_cleanup_duration_seconds",
+		Help:    "Time taken to delete the Tasks exceeding a CronTask's history limits",
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 8),
+	}, []string{"namespace", "result"})
+
+	// recommendedRequests is the agent container requests recommended by Agent sizing
+	recommendedRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubetask_agent_recommended_requests",
+		Help: "Agent container requests recommended from the peak usage of finished Tasks, in cores or bytes",
+	}, []string{"namespace", "agent", "resource"})
+
+	// taskRetries counts failed Task attempts that are retried by a retry policy
+	taskRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubetask_task_retries_total",
+		Help: "Total number of failed Task attempts retried by the Task's retry policy",
+	}, []string{"namespace", "agent", "failure"})
+
+	// jobCreationThrottled counts Task starts delayed by the job creation throttle or by
+	// API server throttling
+	jobCreationThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubetask_job_creation_throttled_total",
+		Help: "Total number of Task starts delayed by the job creation rate limit or API server throttling",
+	}, []string{"namespace", "source"})
+)
+
+const (
+	// throttleSourceLimiter labels Task starts delayed by the job creation rate limit
+	throttleSourceLimiter = "limiter"
+
+	// throttleSourceAPIServer labels Task starts the API server rejected with 429
+	throttleSourceAPIServer = "apiserver"
+)
+
+const (
+	// cleanupReasonTTL labels deletions by TTL cleanup
+	cleanupReasonTTL = "ttl"
+
+	// cleanupReasonHistory labels deletions by CronTask history limits
+	cleanupReasonHistory = "history"
+)
+
+// cleanupResult is the result label of a cleanup operation that returned err
+func cleanupResult(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+func init() {
+	metrics.Registry.MustRegister(stuckTasks, stuckTasksCancelled,
+		taskQueueDuration, taskSchedulingDuration, taskStartupDuration, taskRunDuration,
+		imagePullDuration, imagePullFailures, contextScanFindings,
+		taskCleanupDeletions, taskTTLDeletionDelay, cronTaskHistoryCleanupDuration,
+		recommendedRequests, taskRetries, jobCreationThrottled)
+}
@@ -0,0 +1,202 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// objectStoreResolution returns where the objects of a Context are downloaded:
+// in the controller for a single key, in an init container otherwise
+func objectStoreResolution(spec *kubetaskv1alpha1.ObjectStoreContext) kubetaskv1alpha1.ObjectStoreResolution {
+	if spec.Resolution != "" {
+		return spec.Resolution
+	}
+	if spec.Key != "" {
+		return kubetaskv1alpha1.ObjectStoreResolutionController
+	}
+	return kubetaskv1alpha1.ObjectStoreResolutionInitContainer
+}
+
+// resolveObjectStoreContext downloads the object of an ObjectStore Context, or
+// returns the objects for an init container to download into the Pod
+func (r *TaskReconciler) resolveObjectStoreContext(ctx context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	spec := req.Context.Spec.ObjectStore
+	if spec == nil {
+		return ContextResolution{}, nil
+	}
+	name := req.Context.Name
+	secretName := ""
+	if spec.CredentialsSecretRef != nil {
+		secretName = spec.CredentialsSecretRef.Name
+	}
+
+	if objectStoreResolution(spec) == kubetaskv1alpha1.ObjectStoreResolutionInitContainer {
+		// Default mount path: ${WORKSPACE_DIR}/objects-<context-name>/, or a file in it for a key
+		mountPath := req.MountPath
+		if mountPath == "" {
+			mountPath = build.WorkspacePath(req.WorkspaceDir, "objects-"+name)
+			if spec.Key != "" {
+				mountPath = path.Join(mountPath, path.Base(spec.Key))
+			}
+		}
+		return ContextResolution{objectStore: &build.ObjectStoreMount{
+			ContextName: name,
+			MountPath:   mountPath,
+			Provider:    spec.Provider,
+			Bucket:      spec.Bucket,
+			Key:         spec.Key,
+			Prefix:      spec.Prefix,
+			Region:      spec.Region,
+			Endpoint:    spec.Endpoint,
+			SecretName:  secretName,
+		}}, nil
+	}
+
+	if spec.Key == "" {
+		return ContextResolution{}, fmt.Errorf("Context %q downloads a prefix, which requires InitContainer resolution", name)
+	}
+	var credentials map[string][]byte
+	if secretName != "" {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: req.Context.Namespace}, secret); err != nil {
+			return ContextResolution{}, fmt.Errorf("get credentials Secret of Context %q: %w", name, err)
+		}
+		credentials = secret.Data
+	}
+	httpReq, err := objectStoreRequest(ctx, spec, credentials, clockNow(r.Clock))
+	if err != nil {
+		return ContextResolution{}, fmt.Errorf("Context %q: %w", name, err)
+	}
+	content, err := r.doContextRequest(httpReq, objectStoreURI(spec))
+	if err != nil {
+		return ContextResolution{}, fmt.Errorf("fetch Context %q: %w", name, err)
+	}
+	return ContextResolution{Content: content}, nil
+}
+
+// objectStoreRequest returns the GET request of the object of spec. S3 and GCS
+// requests are signed with AWS Signature Version 4 when credentials are given,
+// Azure requests carry the SAS token.
+func objectStoreRequest(ctx context.Context, spec *kubetaskv1alpha1.ObjectStoreContext, credentials map[string][]byte, now time.Time) (*http.Request, error) {
+	endpoint, region := spec.Endpoint, spec.Region
+	switch spec.Provider {
+	case kubetaskv1alpha1.ObjectStoreProviderGCS:
+		if endpoint == "" {
+			endpoint = build.GCSEndpoint
+		}
+		region = "auto"
+	case kubetaskv1alpha1.ObjectStoreProviderS3:
+		if region == "" {
+			region = build.DefaultS3Region
+		}
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+		}
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("%s object store requires an endpoint", spec.Provider)
+	}
+
+	// Buckets are addressed in the path, which every provider and S3-compatible store supports
+	target, err := url.Parse(strings.TrimSuffix(endpoint, "/") + "/" + s3Escape(spec.Bucket) + "/" + s3Escape(strings.TrimPrefix(spec.Key, "/")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	if spec.Provider == kubetaskv1alpha1.ObjectStoreProviderAzure && credentials != nil {
+		token, ok := credentials["sasToken"]
+		if !ok {
+			return nil, fmt.Errorf("credentials Secret has no sasToken key")
+		}
+		target.RawQuery = strings.TrimPrefix(string(token), "?")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Provider != kubetaskv1alpha1.ObjectStoreProviderAzure && credentials != nil {
+		accessKey, secretKey := string(credentials["accessKeyID"]), string(credentials["secretAccessKey"])
+		if accessKey == "" || secretKey == "" {
+			return nil, fmt.Errorf("credentials Secret needs the accessKeyID and secretAccessKey keys")
+		}
+		signV4(req, accessKey, secretKey, string(credentials["sessionToken"]), region, now)
+	}
+	return req, nil
+}
+
+// objectStoreURI names the object of spec in errors, e.g. s3://bucket/key
+func objectStoreURI(spec *kubetaskv1alpha1.ObjectStoreContext) string {
+	scheme := map[kubetaskv1alpha1.ObjectStoreProvider]string{
+		kubetaskv1alpha1.ObjectStoreProviderS3:    "s3",
+		kubetaskv1alpha1.ObjectStoreProviderGCS:   "gs",
+		kubetaskv1alpha1.ObjectStoreProviderAzure: "azure",
+	}[spec.Provider]
+	return scheme + "://" + spec.Bucket + "/" + strings.TrimPrefix(spec.Key, "/")
+}
+
+// signV4 signs a GET request for the s3 service with AWS Signature Version 4,
+// leaving the payload unsigned
+func signV4(req *http.Request, accessKey, secretKey, sessionToken, region string, now time.Time) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", amzDate)
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+		signedHeaders += ";x-amz-security-token"
+		canonicalHeaders += "x-amz-security-token:" + sessionToken + "\n"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.RawQuery, canonicalHeaders, signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Escape percent-encodes an object key for a request path as Signature Version
+// 4 expects: everything but unreserved characters and slashes
+func s3Escape(key string) string {
+	var b strings.Builder
+	for _, c := range []byte(key) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
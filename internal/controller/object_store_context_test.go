@@ -0,0 +1,157 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestResolveObjectStoreContext(t *testing.T) {
+	const guide = "# Style guide"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.EscapedPath() {
+		case "/docs/guides/style%20guide.md":
+			auth := req.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260101/eu-west-1/s3/aws4_request, ") ||
+				req.Header.Get("X-Amz-Date") != "20260101T020000Z" {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(guide))
+		case "/prompts/review.md":
+			if req.URL.Query().Get("sig") != "secret" {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(guide))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "s3-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"accessKeyID": []byte("AKIDEXAMPLE"), "secretAccessKey": []byte("secret")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "azure-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"sasToken": []byte("?sv=2024-01-01&sig=secret")},
+		},
+	}
+	builder := fake.NewClientBuilder().WithScheme(newTestScheme(t))
+	for _, secret := range secrets {
+		builder = builder.WithObjects(secret)
+	}
+	r := &TaskReconciler{
+		Client:               builder.Build(),
+		URLContextHTTPClient: server.Client(),
+		Clock:                staticClock{now: time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)},
+	}
+	resolve := func(spec kubetaskv1alpha1.ObjectStoreContext) (ContextResolution, error) {
+		t.Helper()
+		return r.resolveObjectStoreContext(context.Background(), ContextResolveRequest{
+			Context: &kubetaskv1alpha1.Context{
+				ObjectMeta: metav1.ObjectMeta{Name: "corpus", Namespace: "default"},
+				Spec:       kubetaskv1alpha1.ContextSpec{Type: kubetaskv1alpha1.ContextTypeObjectStore, ObjectStore: &spec},
+			},
+			WorkspaceDir: "/workspace",
+		})
+	}
+	s3Credentials := &corev1.LocalObjectReference{Name: "s3-credentials"}
+
+	t.Run("controller", func(t *testing.T) {
+		resolution, err := resolve(kubetaskv1alpha1.ObjectStoreContext{
+			Provider: kubetaskv1alpha1.ObjectStoreProviderS3, Bucket: "docs", Key: "guides/style guide.md",
+			Region: "eu-west-1", Endpoint: server.URL, CredentialsSecretRef: s3Credentials,
+		})
+		if err != nil || resolution.Content != guide {
+			t.Errorf("S3 resolution = %q, %v, want the signed object", resolution.Content, err)
+		}
+
+		resolution, err = resolve(kubetaskv1alpha1.ObjectStoreContext{
+			Provider: kubetaskv1alpha1.ObjectStoreProviderAzure, Bucket: "prompts", Key: "review.md",
+			Endpoint: server.URL, CredentialsSecretRef: &corev1.LocalObjectReference{Name: "azure-credentials"},
+		})
+		if err != nil || resolution.Content != guide {
+			t.Errorf("Azure resolution = %q, %v, want the object with the SAS token", resolution.Content, err)
+		}
+	})
+
+	t.Run("init container", func(t *testing.T) {
+		resolution, err := resolve(kubetaskv1alpha1.ObjectStoreContext{
+			Provider: kubetaskv1alpha1.ObjectStoreProviderGCS, Bucket: "corpora", Prefix: "legal/",
+		})
+		if err != nil || resolution.objectStore == nil || resolution.objectStore.MountPath != "/workspace/objects-corpus" {
+			t.Fatalf("prefix resolution = %+v, %v, want an init container mount at the default path", resolution.objectStore, err)
+		}
+
+		resolution, err = resolve(kubetaskv1alpha1.ObjectStoreContext{
+			Provider: kubetaskv1alpha1.ObjectStoreProviderS3, Bucket: "models", Key: "v1/weights.bin",
+			Resolution: kubetaskv1alpha1.ObjectStoreResolutionInitContainer, CredentialsSecretRef: s3Credentials,
+		})
+		if err != nil || resolution.objectStore == nil || resolution.objectStore.MountPath != "/workspace/objects-corpus/weights.bin" ||
+			resolution.objectStore.SecretName != "s3-credentials" {
+			t.Errorf("key resolution = %+v, %v, want a file mount with the credentials", resolution.objectStore, err)
+		}
+	})
+
+	for _, tt := range []struct {
+		name string
+		spec kubetaskv1alpha1.ObjectStoreContext
+		want string
+	}{
+		{
+			name: "wrong region",
+			spec: kubetaskv1alpha1.ObjectStoreContext{
+				Provider: kubetaskv1alpha1.ObjectStoreProviderS3, Bucket: "docs", Key: "guides/style guide.md", Endpoint: server.URL,
+				CredentialsSecretRef: s3Credentials,
+			},
+			want: "GET s3://docs/guides/style guide.md returned 403 Forbidden",
+		},
+		{
+			name: "SAS token kept out of errors",
+			spec: kubetaskv1alpha1.ObjectStoreContext{
+				Provider: kubetaskv1alpha1.ObjectStoreProviderAzure, Bucket: "prompts", Key: "missing.md", Endpoint: server.URL,
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "azure-credentials"},
+			},
+			want: "GET azure://prompts/missing.md returned 404 Not Found",
+		},
+		{
+			name: "prefix resolved by the controller",
+			spec: kubetaskv1alpha1.ObjectStoreContext{
+				Provider: kubetaskv1alpha1.ObjectStoreProviderS3, Bucket: "docs", Prefix: "guides/",
+				Resolution: kubetaskv1alpha1.ObjectStoreResolutionController,
+			},
+			want: "requires InitContainer resolution",
+		},
+		{
+			name: "missing credentials Secret",
+			spec: kubetaskv1alpha1.ObjectStoreContext{
+				Provider: kubetaskv1alpha1.ObjectStoreProviderS3, Bucket: "docs", Key: "guide.md",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "missing"},
+			},
+			want: "not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := resolve(tt.spec); err == nil || !strings.Contains(err.Error(), tt.want) || strings.Contains(err.Error(), "sig=") {
+				t.Errorf("resolveObjectStoreContext() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// diffOptions compare API objects for diff logging. Unexported fields, e.g. of
+// resource.Quantity, would otherwise make cmp panic; types with an Equal method
+// are still compared with it.
+var diffOptions = cmp.Options{cmp.Exporter(func(reflect.Type) bool { return true })}
+
+// logDiff logs what differs between the existing and desired state of an object the
+// controller is about to change, when LogDiffs is set. action says what happens to the
+// object: "update", or "keep" for an existing Job whose template cannot be changed.
+// With ignoreUnset, fields unset in desired are left out, so that the defaults the
+// API server filled into existing do not show up as differences.
+func (r *TaskReconciler) logDiff(ctx context.Context, action, kind, name string, existing, desired any, ignoreUnset bool) {
+	if !r.LogDiffs {
+		return
+	}
+	opts := diffOptions
+	if ignoreUnset {
+		opts = append(cmp.Options{diff.IgnoreUnset()}, opts...)
+	}
+	d := cmp.Diff(existing, desired, opts)
+	if d == "" {
+		return
+	}
+	log.FromContext(ctx).Info("reconcile diff", "action", action, "kind", kind, "name", name, "diff", d)
+}
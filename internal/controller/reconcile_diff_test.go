@@ -0,0 +1,76 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestTaskReconciler_LogDiffs(t *testing.T) {
+	_, task := newInitTestObjects()
+	owner := metav1.OwnerReference{APIVersion: "kubetask.io/v1alpha1", Kind: "Task", Name: "fix", UID: "task-uid", Controller: boolPtr(true)}
+	existingConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix-context", Namespace: "default", OwnerReferences: []metav1.OwnerReference{owner}},
+		Data:       map[string]string{"workspace-task.md": "Fix the test"},
+	}
+	existingJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix-job", Namespace: "default", OwnerReferences: []metav1.OwnerReference{owner}},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "agent", Image: "agent:v1", TerminationMessagePath: "/dev/termination-log"}},
+		}}},
+	}
+	c := newInitTestClient(t, interceptor.Funcs{}, existingConfigMap, existingJob)
+
+	var logs []string
+	ctx := log.IntoContext(context.Background(), funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{}))
+
+	for _, logDiffs := range []bool{false, true} {
+		logs = nil
+		r := &TaskReconciler{Client: c, LogDiffs: logDiffs}
+		desiredConfigMap := existingConfigMap.DeepCopy()
+		desiredConfigMap.ResourceVersion = ""
+		desiredConfigMap.Data = map[string]string{"workspace-task.md": fmt.Sprintf("Fix the flaky test, take %t", logDiffs)}
+		if err := r.applyContextConfigMap(ctx, task, desiredConfigMap); err != nil {
+			t.Fatalf("applyContextConfigMap() error = %v", err)
+		}
+		desiredJob := existingJob.DeepCopy()
+		desiredJob.ResourceVersion = ""
+		desiredJob.Spec.Template.Spec.Containers[0].Image = "agent:v2"
+		desiredJob.Spec.Template.Spec.Containers[0].TerminationMessagePath = ""
+		if err := r.createJob(ctx, task, desiredJob); err != nil {
+			t.Fatalf("createJob() error = %v", err)
+		}
+
+		if !logDiffs {
+			if len(logs) != 0 {
+				t.Errorf("logs = %v, want none without LogDiffs", logs)
+			}
+			continue
+		}
+		all := strings.Join(logs, "\n")
+		if len(logs) != 2 || !strings.Contains(all, `"action"="update" "kind"="ConfigMap" "name"="fix-context"`) ||
+			!strings.Contains(all, `"action"="keep" "kind"="Job" "name"="fix-job"`) {
+			t.Fatalf("logs = %v, want a ConfigMap update and a kept Job", logs)
+		}
+		if !strings.Contains(all, "Fix the flaky test, take true") || !strings.Contains(all, "agent:v2") {
+			t.Errorf("logs = %v, want the changed task.md and image", logs)
+		}
+		if strings.Contains(all, "termination-log") {
+			t.Errorf("logs = %v, want fields the controller does not set left out of the Job diff", logs)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// RenderTask builds the Job and context ConfigMap the controller would create for
+// a Task, without creating them. c must serve the Task's Agent, Contexts, referenced
+// ConfigMaps and KubeTaskConfig; a fake client built from manifests works for
+// rendering outside the cluster. The Agent's job template overrides are applied,
+// but its pre-flight status is not checked and job mutators and webhooks are not.
+// The returned ConfigMap is nil when the Task has no aggregated context.
+func RenderTask(ctx context.Context, c client.Client, task *kubetaskv1alpha1.Task, defaultAgentImage string) (*batchv1.Job, *corev1.ConfigMap, error) {
+	r := &TaskReconciler{Client: c, DefaultAgentImage: defaultAgentImage}
+
+	cfg, err := r.resolveAgentConfig(ctx, task, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contextConfigMap, fileMounts, dirMounts, gitMounts, initMounts, err := r.processAllContexts(ctx, task, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to process contexts: %w", err)
+	}
+
+	initMounts.apply(&cfg.Config)
+	job := build.Job(task, fmt.Sprintf("%s-job", task.Name), cfg.Config, contextConfigMap, fileMounts, dirMounts, gitMounts)
+	if err := build.ApplyJobTemplateOverrides(job, cfg.Config); err != nil {
+		return nil, nil, err
+	}
+	return job, contextConfigMap, nil
+}
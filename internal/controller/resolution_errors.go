@@ -0,0 +1,138 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// CredentialsResolvedConditionType reports whether the Secrets of the Agent's credentials exist
+const CredentialsResolvedConditionType = "CredentialsResolved"
+
+// validateCredentials checks that the Secrets and keys referenced by the Agent's credentials
+// exist, so a Task reports every broken credential before its pod fails on the first one.
+// Optional credentials whose Secret or key is missing are left out of the returned
+// credentials and described in skipped instead.
+func (r *TaskReconciler) validateCredentials(ctx context.Context, task *kubetaskv1alpha1.Task, cfg agentConfig) (available []kubetaskv1alpha1.Credential, skipped []string, err error) {
+	var errs []error
+	for _, cred := range cfg.Credentials {
+		secret := &corev1.Secret{}
+		var missing error
+		if err := r.Get(ctx, types.NamespacedName{Name: cred.SecretRef.Name, Namespace: task.Namespace}, secret); err != nil {
+			if !errors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("credential %q: %w", cred.Name, err))
+				continue
+			}
+			missing = fmt.Errorf("Secret %q not found", cred.SecretRef.Name)
+		} else if key := cred.SecretRef.Key; key != nil {
+			if _, ok := secret.Data[*key]; !ok {
+				missing = fmt.Errorf("Secret %q has no key %q", cred.SecretRef.Name, *key)
+			}
+		}
+
+		switch {
+		case missing == nil:
+			available = append(available, cred)
+		case cred.Optional:
+			skipped = append(skipped, fmt.Sprintf("credential %q skipped: %v", cred.Name, missing))
+		default:
+			errs = append(errs, fmt.Errorf("credential %q: %w", cred.Name, missing))
+		}
+	}
+	return available, skipped, stderrors.Join(errs...)
+}
+
+// setCredentialsResolvedCondition records the resolved credentials of a Task, and
+// the optional credentials that were skipped
+func setCredentialsResolvedCondition(task *kubetaskv1alpha1.Task, resolved int, skipped []string) {
+	condition := metav1.Condition{
+		Type:    CredentialsResolvedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllCredentialsResolved",
+		Message: fmt.Sprintf("%d credentials resolved", resolved),
+	}
+	if len(skipped) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "OptionalCredentialsSkipped"
+		condition.Message = fmt.Sprintf("%d credentials resolved; %s", resolved, strings.Join(skipped, "; "))
+	}
+	meta.SetStatusCondition(&task.Status.Conditions, condition)
+}
+
+// setResolutionErrorConditions reports all context and credential errors of a Task:
+// each kind on its own condition, and together in one readable Ready message
+func setResolutionErrorConditions(task *kubetaskv1alpha1.Task, contextErr, credentialErr error) bool {
+	changed := false
+	var parts []string
+	reason := ""
+	for _, c := range []struct {
+		conditionType string
+		reason        string
+		noun          string
+		err           error
+	}{
+		{ContextsResolvedConditionType, "ContextError", "Context", contextErr},
+		{CredentialsResolvedConditionType, "CredentialError", "credential", credentialErr},
+	} {
+		if c.err == nil {
+			continue
+		}
+		messages := errorMessages(c.err)
+		message := strings.Join(messages, "; ")
+		changed = meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    c.conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  c.reason,
+			Message: message,
+		}) || changed
+		noun := c.noun
+		if len(messages) > 1 {
+			noun += "s"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s failed (%s)", len(messages), noun, message))
+		if reason == "" {
+			reason = c.reason
+		}
+	}
+	if len(parts) == 0 {
+		return changed
+	}
+	return meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: strings.Join(parts, "; "),
+	}) || changed
+}
+
+// errorMessages flattens errors combined with errors.Join into their messages
+func errorMessages(err error) []string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var messages []string
+		for _, e := range joined.Unwrap() {
+			messages = append(messages, errorMessages(e)...)
+		}
+		return messages
+	}
+	return []string{err.Error()}
+}
+
+// transientAPIError reports whether err is a failure of the API server, such as a
+// timeout or overload, rather than a problem with the requested objects. Reconciles
+// failing with one are retried instead of failing the Task.
+func transientAPIError(err error) bool {
+	return errors.IsServerTimeout(err) || errors.IsTimeout(err) || errors.IsServiceUnavailable(err) ||
+		errors.IsTooManyRequests(err) || errors.IsInternalError(err) || errors.IsUnexpectedServerError(err) ||
+		stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled)
+}
@@ -0,0 +1,113 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("ghp_test")},
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(secret).Build()}
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+
+	cfg := agentConfig{Config: build.Config{Credentials: []kubetaskv1alpha1.Credential{
+		{Name: "github-token", SecretRef: kubetaskv1alpha1.SecretReference{Name: "github", Key: stringPtr("token")}},
+		{Name: "github-env", SecretRef: kubetaskv1alpha1.SecretReference{Name: "github"}},
+	}}}
+	if _, _, err := r.validateCredentials(context.Background(), task, cfg); err != nil {
+		t.Errorf("validateCredentials() error = %v, want nil", err)
+	}
+
+	cfg.Credentials = append(cfg.Credentials,
+		kubetaskv1alpha1.Credential{Name: "ssh-key", SecretRef: kubetaskv1alpha1.SecretReference{Name: "github", Key: stringPtr("id_rsa")}},
+		kubetaskv1alpha1.Credential{Name: "anthropic", SecretRef: kubetaskv1alpha1.SecretReference{Name: "ai-keys"}},
+	)
+	_, _, err := r.validateCredentials(context.Background(), task, cfg)
+	if got := errorMessages(err); len(got) != 2 ||
+		!strings.Contains(got[0], `credential "ssh-key": Secret "github" has no key "id_rsa"`) ||
+		!strings.Contains(got[1], `credential "anthropic": Secret "ai-keys" not found`) {
+		t.Errorf("validateCredentials() errors = %q, want both broken credentials", got)
+	}
+}
+
+func TestValidateCredentials_Optional(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("ghp_test")},
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(secret).Build()}
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+
+	cfg := agentConfig{Config: build.Config{Credentials: []kubetaskv1alpha1.Credential{
+		{Name: "github-token", SecretRef: kubetaskv1alpha1.SecretReference{Name: "github", Key: stringPtr("token")}},
+		{Name: "jira", SecretRef: kubetaskv1alpha1.SecretReference{Name: "jira"}, Optional: true},
+		{Name: "ssh-key", SecretRef: kubetaskv1alpha1.SecretReference{Name: "github", Key: stringPtr("id_rsa")}, Optional: true},
+	}}}
+	available, skipped, err := r.validateCredentials(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("validateCredentials() error = %v, want nil", err)
+	}
+	if len(available) != 1 || available[0].Name != "github-token" {
+		t.Errorf("available = %v, want only github-token", available)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("skipped = %q, want jira and ssh-key", skipped)
+	}
+
+	setCredentialsResolvedCondition(task, len(available), skipped)
+	cond := meta.FindStatusCondition(task.Status.Conditions, CredentialsResolvedConditionType)
+	wantMessage := `1 credentials resolved; credential "jira" skipped: Secret "jira" not found; credential "ssh-key" skipped: Secret "github" has no key "id_rsa"`
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "OptionalCredentialsSkipped" || cond.Message != wantMessage {
+		t.Errorf("CredentialsResolved condition = %+v, want message %q", cond, wantMessage)
+	}
+}
+
+func TestSetResolutionErrorConditions(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{}
+	contextErr := stderrors.Join(stderrors.New(`Task context "a" not found`), stderrors.New(`Task context "b" not found`))
+	credentialErr := stderrors.Join(stderrors.New(`credential "github" failed`))
+
+	if !setResolutionErrorConditions(task, contextErr, credentialErr) {
+		t.Fatal("setResolutionErrorConditions() = false, want conditions to change")
+	}
+	ready := meta.FindStatusCondition(task.Status.Conditions, "Ready")
+	wantReady := `2 Contexts failed (Task context "a" not found; Task context "b" not found); 1 credential failed (credential "github" failed)`
+	if ready == nil || ready.Reason != "ContextError" || ready.Message != wantReady {
+		t.Errorf("Ready condition = %+v, want message %q", ready, wantReady)
+	}
+	for _, conditionType := range []string{ContextsResolvedConditionType, CredentialsResolvedConditionType} {
+		if c := meta.FindStatusCondition(task.Status.Conditions, conditionType); c == nil || c.Status != metav1.ConditionFalse {
+			t.Errorf("%s condition = %+v, want False", conditionType, c)
+		}
+	}
+	if setResolutionErrorConditions(task, contextErr, credentialErr) {
+		t.Error("setResolutionErrorConditions() = true for the same errors, want no change")
+	}
+
+	// Only credentials are broken
+	task = &kubetaskv1alpha1.Task{}
+	setResolutionErrorConditions(task, nil, credentialErr)
+	if ready := meta.FindStatusCondition(task.Status.Conditions, "Ready"); ready == nil || ready.Reason != "CredentialError" {
+		t.Errorf("Ready condition = %+v, want reason CredentialError", ready)
+	}
+	if c := meta.FindStatusCondition(task.Status.Conditions, ContextsResolvedConditionType); c != nil {
+		t.Errorf("ContextsResolved condition = %+v, want none", c)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// createResultTokenSecret creates the Task's result token Secret. A Secret created by
+// an earlier, interrupted reconcile is kept, since its token is random. A Secret the
+// Task does not control, e.g. one created in advance to plant a known token, is refused.
+func (r *TaskReconciler) createResultTokenSecret(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	secret, err := buildResultTokenSecret(task)
+	if err != nil {
+		return err
+	}
+	err = r.Create(ctx, secret)
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+	existing := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, existing); err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, task) {
+		return fmt.Errorf("Secret %q already exists and is not owned by Task %q", secret.Name, task.Name)
+	}
+	return nil
+}
+
+// buildResultTokenSecret creates a Secret with a random token the agent presents to the
+// result API. The token only grants access to its own Task and is deleted with it.
+func buildResultTokenSecret(task *kubetaskv1alpha1.Task) (*corev1.Secret, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      build.ResultTokenSecretName(task.Name),
+			Namespace: task.Namespace,
+			Labels:    build.TaskResourceLabels(task),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: task.APIVersion,
+					Kind:       task.Kind,
+					Name:       task.Name,
+					UID:        task.UID,
+					Controller: boolPtr(true),
+				},
+			},
+		},
+		Data: map[string][]byte{build.ResultTokenKey: []byte(hex.EncodeToString(token))},
+	}, nil
+}
@@ -0,0 +1,105 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestBuildResultTokenSecret(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+
+	secret, err := buildResultTokenSecret(task)
+	if err != nil {
+		t.Fatalf("buildResultTokenSecret() error = %v", err)
+	}
+	if secret.Name != "test-task-result-token" || secret.Namespace != "default" {
+		t.Errorf("Secret = %s/%s, want default/test-task-result-token", secret.Namespace, secret.Name)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != "test-uid" {
+		t.Errorf("OwnerReferences = %+v, want the Task", secret.OwnerReferences)
+	}
+	if len(secret.Data[build.ResultTokenKey]) != 64 {
+		t.Errorf("token = %q, want 32 random bytes hex-encoded", secret.Data[build.ResultTokenKey])
+	}
+	other, err := buildResultTokenSecret(task)
+	if err != nil {
+		t.Fatalf("buildResultTokenSecret() error = %v", err)
+	}
+	if string(other.Data[build.ResultTokenKey]) == string(secret.Data[build.ResultTokenKey]) {
+		t.Errorf("tokens must be random")
+	}
+}
+
+func TestCreateResultTokenSecret(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+	existing := func(ownerUID types.UID) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-task-result-token", Namespace: "default"},
+			Data:       map[string][]byte{build.ResultTokenKey: []byte("planted")},
+		}
+		if ownerUID != "" {
+			secret.OwnerReferences = []metav1.OwnerReference{
+				{Kind: "Task", Name: "test-task", UID: ownerUID, Controller: boolPtr(true)},
+			}
+		}
+		return secret
+	}
+
+	tests := []struct {
+		name      string
+		existing  *corev1.Secret
+		wantErr   bool
+		wantToken string
+	}{
+		{name: "new Secret"},
+		{name: "Secret of the Task", existing: existing("test-uid"), wantToken: "planted"},
+		{name: "Secret of a deleted Task", existing: existing("old-uid"), wantErr: true},
+		{name: "Secret without owner", existing: existing(""), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []client.Object
+			if tt.existing != nil {
+				objects = append(objects, tt.existing)
+			}
+			r := &TaskReconciler{Client: newInitTestClient(t, interceptor.Funcs{}, objects...)}
+
+			err := r.createResultTokenSecret(context.Background(), task)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "not owned by Task") {
+					t.Fatalf("createResultTokenSecret() error = %v, want not owned by Task", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("createResultTokenSecret() error = %v", err)
+			}
+			secret := &corev1.Secret{}
+			if err := r.Get(context.Background(), types.NamespacedName{Name: "test-task-result-token", Namespace: "default"}, secret); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if tt.wantToken != "" && string(secret.Data[build.ResultTokenKey]) != tt.wantToken {
+				t.Errorf("token = %q, want the existing %q", secret.Data[build.ResultTokenKey], tt.wantToken)
+			}
+		})
+	}
+}
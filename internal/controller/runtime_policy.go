@@ -0,0 +1,58 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"fmt"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// runtimeClassRank returns the isolation rank of a RuntimeClass in the ranking.
+// The cluster default runtime ("") ranks 0, listed classes rank from 1 upwards,
+// and unlisted classes return -1.
+func runtimeClassRank(ranking []string, runtimeClassName string) int {
+	if runtimeClassName == "" {
+		return 0
+	}
+	for i, name := range ranking {
+		if name == runtimeClassName {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// validateRuntimePolicy checks that a runtime policy is internally consistent
+func validateRuntimePolicy(policy *kubetaskv1alpha1.RuntimePolicyConfig) error {
+	if policy == nil || policy.MinimumRuntimeClassName == "" {
+		return nil
+	}
+	if runtimeClassRank(policy.RuntimeClassRanking, policy.MinimumRuntimeClassName) < 1 {
+		return fmt.Errorf("runtimePolicy: minimumRuntimeClassName %q is not listed in runtimeClassRanking",
+			policy.MinimumRuntimeClassName)
+	}
+	return nil
+}
+
+// enforceRuntimePolicy returns an error if runtimeClassName is weaker than the policy's minimum.
+// An invalid policy is enforced conservatively: no RuntimeClass satisfies it.
+func enforceRuntimePolicy(policy *kubetaskv1alpha1.RuntimePolicyConfig, runtimeClassName string) error {
+	if policy == nil || policy.MinimumRuntimeClassName == "" {
+		return nil
+	}
+	if err := validateRuntimePolicy(policy); err != nil {
+		return fmt.Errorf("KubeTaskConfig has an invalid runtime policy: %w", err)
+	}
+
+	minimum := runtimeClassRank(policy.RuntimeClassRanking, policy.MinimumRuntimeClassName)
+	if runtimeClassRank(policy.RuntimeClassRanking, runtimeClassName) < minimum {
+		effective := "the cluster default runtime"
+		if runtimeClassName != "" {
+			effective = fmt.Sprintf("RuntimeClass %q", runtimeClassName)
+		}
+		return fmt.Errorf("RuntimeClass %q is required at minimum by KubeTaskConfig, but the Task would run with %s",
+			policy.MinimumRuntimeClassName, effective)
+	}
+	return nil
+}
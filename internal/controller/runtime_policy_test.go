@@ -0,0 +1,62 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestEnforceRuntimePolicy(t *testing.T) {
+	policy := &kubetaskv1alpha1.RuntimePolicyConfig{
+		RuntimeClassRanking:     []string{"kata", "gvisor"},
+		MinimumRuntimeClassName: "kata",
+	}
+
+	tests := []struct {
+		name             string
+		policy           *kubetaskv1alpha1.RuntimePolicyConfig
+		runtimeClassName string
+		wantErr          bool
+	}{
+		{name: "no policy", policy: nil, runtimeClassName: "", wantErr: false},
+		{name: "policy without minimum", policy: &kubetaskv1alpha1.RuntimePolicyConfig{RuntimeClassRanking: []string{"gvisor"}}, runtimeClassName: "", wantErr: false},
+		{name: "cluster default below minimum", policy: policy, runtimeClassName: "", wantErr: true},
+		{name: "equal to minimum", policy: policy, runtimeClassName: "kata", wantErr: false},
+		{name: "stronger than minimum", policy: policy, runtimeClassName: "gvisor", wantErr: false},
+		{name: "unlisted runtime class", policy: policy, runtimeClassName: "runc", wantErr: true},
+		{
+			name:             "invalid policy rejects everything",
+			policy:           &kubetaskv1alpha1.RuntimePolicyConfig{RuntimeClassRanking: []string{"gvisor"}, MinimumRuntimeClassName: "kata"},
+			runtimeClassName: "gvisor",
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := enforceRuntimePolicy(tt.policy, tt.runtimeClassName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("enforceRuntimePolicy(%q) error = %v, wantErr %v", tt.runtimeClassName, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRuntimePolicy(t *testing.T) {
+	valid := &kubetaskv1alpha1.RuntimePolicyConfig{
+		RuntimeClassRanking:     []string{"kata", "gvisor"},
+		MinimumRuntimeClassName: "gvisor",
+	}
+	if err := validateRuntimePolicy(valid); err != nil {
+		t.Errorf("validateRuntimePolicy() error = %v, want nil", err)
+	}
+
+	invalid := &kubetaskv1alpha1.RuntimePolicyConfig{MinimumRuntimeClassName: "gvisor"}
+	if err := validateRuntimePolicy(invalid); err == nil {
+		t.Errorf("validateRuntimePolicy() with unlisted minimum error = nil, want error")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// SecurityProfileLabelKey records the security profile of an agent Pod
+const SecurityProfileLabelKey = build.SecurityProfileLabelKey
+
+// restrictedEgressPorts are the ports agent pods with the restricted profile may
+// connect to: DNS, HTTPS for model APIs and Git, and SSH for Git
+var restrictedEgressPorts = []struct {
+	protocol corev1.Protocol
+	port     int32
+}{
+	{corev1.ProtocolUDP, 53},
+	{corev1.ProtocolTCP, 53},
+	{corev1.ProtocolTCP, 443},
+	{corev1.ProtocolTCP, 22},
+}
+
+// buildNetworkPolicy returns the NetworkPolicy isolating the Pod of a Task under
+// a security profile, or nil if the profile does not restrict the network.
+// NetworkPolicies are additive, so users can allow more traffic with their own.
+func buildNetworkPolicy(task *kubetaskv1alpha1.Task, profile kubetaskv1alpha1.SecurityProfile) *networkingv1.NetworkPolicy {
+	var spec networkingv1.NetworkPolicySpec
+	switch profile {
+	case kubetaskv1alpha1.SecurityProfileRestricted:
+		ports := make([]networkingv1.NetworkPolicyPort, 0, len(restrictedEgressPorts))
+		for _, p := range restrictedEgressPorts {
+			port := intstr.FromInt32(p.port)
+			ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &p.protocol, Port: &port})
+		}
+		spec.Egress = []networkingv1.NetworkPolicyEgressRule{{Ports: ports}}
+		spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}
+	case kubetaskv1alpha1.SecurityProfileBaseline:
+		spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	default:
+		return nil
+	}
+	spec.PodSelector = metav1.LabelSelector{MatchLabels: map[string]string{TaskLabelKey: task.Name}}
+
+	labels := build.TaskResourceLabels(task)
+	labels[SecurityProfileLabelKey] = string(profile)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      task.Name + "-agent",
+			Namespace: task.Namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: task.APIVersion,
+					Kind:       task.Kind,
+					Name:       task.Name,
+					UID:        task.UID,
+					Controller: boolPtr(true),
+				},
+			},
+		},
+		Spec: spec,
+	}
+}
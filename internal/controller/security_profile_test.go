@@ -0,0 +1,77 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func securityProfileTestTask() *kubetaskv1alpha1.Task {
+	return &kubetaskv1alpha1.Task{
+		TypeMeta: metav1.TypeMeta{APIVersion: "kubetask.io/v1alpha1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+}
+
+func TestBuildNetworkPolicy(t *testing.T) {
+	task := securityProfileTestTask()
+
+	if policy := buildNetworkPolicy(task, ""); policy != nil {
+		t.Errorf("expected no NetworkPolicy without a profile")
+	}
+	if policy := buildNetworkPolicy(task, kubetaskv1alpha1.SecurityProfilePrivilegedCI); policy != nil {
+		t.Errorf("expected no NetworkPolicy for privileged-ci")
+	}
+
+	baseline := buildNetworkPolicy(task, kubetaskv1alpha1.SecurityProfileBaseline)
+	if baseline == nil {
+		t.Fatal("expected NetworkPolicy for baseline")
+	}
+	if len(baseline.Spec.PolicyTypes) != 1 || baseline.Spec.PolicyTypes[0] != networkingv1.PolicyTypeIngress {
+		t.Errorf("baseline policyTypes = %v, want [Ingress]", baseline.Spec.PolicyTypes)
+	}
+	if len(baseline.Spec.Ingress) != 0 {
+		t.Errorf("baseline should deny all ingress, got %v", baseline.Spec.Ingress)
+	}
+
+	restricted := buildNetworkPolicy(task, kubetaskv1alpha1.SecurityProfileRestricted)
+	if restricted == nil {
+		t.Fatal("expected NetworkPolicy for restricted")
+	}
+	if restricted.Name != "test-task-agent" || restricted.Namespace != "default" {
+		t.Errorf("NetworkPolicy = %s/%s, want default/test-task-agent", restricted.Namespace, restricted.Name)
+	}
+	if got := restricted.Spec.PodSelector.MatchLabels[TaskLabelKey]; got != task.Name {
+		t.Errorf("podSelector %s = %q, want %q", TaskLabelKey, got, task.Name)
+	}
+	if len(restricted.Spec.PolicyTypes) != 2 {
+		t.Errorf("restricted policyTypes = %v, want [Ingress Egress]", restricted.Spec.PolicyTypes)
+	}
+	if len(restricted.Spec.Egress) != 1 {
+		t.Fatalf("expected one egress rule, got %d", len(restricted.Spec.Egress))
+	}
+	ports := map[string]bool{}
+	for _, p := range restricted.Spec.Egress[0].Ports {
+		ports[string(*p.Protocol)+"/"+p.Port.String()] = true
+	}
+	for _, want := range []string{"UDP/53", "TCP/53", "TCP/443", "TCP/22"} {
+		if !ports[want] {
+			t.Errorf("egress port %s not allowed, got %v", want, ports)
+		}
+	}
+	if len(restricted.OwnerReferences) != 1 || restricted.OwnerReferences[0].UID != task.UID {
+		t.Errorf("NetworkPolicy should be owned by the Task, got %v", restricted.OwnerReferences)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+const (
+	// StuckConditionType is set on running Tasks that exceeded their Agent's stuck detection bounds
+	StuckConditionType = "Stuck"
+
+	// StuckTaskSweepInterval is how often running Tasks are checked
+	StuckTaskSweepInterval = time.Minute
+
+	// minExpectedDurationSamples is the number of completed Tasks needed before
+	// expectedDurationPercentile is applied
+	minExpectedDurationSamples = 10
+)
+
+// StuckTaskDetector periodically flags running Tasks whose Agent configures
+// stuckDetection and that have run longer than expected
+type StuckTaskDetector struct {
+	client.Client
+
+	// Interval between sweeps. Defaults to StuckTaskSweepInterval.
+	Interval time.Duration
+
+	// Clock is used for run durations and status timestamps. Defaults to the
+	// real clock.
+	Clock Clock
+}
+
+var _ manager.LeaderElectionRunnable = &StuckTaskDetector{}
+
+// NeedLeaderElection returns true so only one replica updates Tasks
+func (d *StuckTaskDetector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sweeps until the context is cancelled
+func (d *StuckTaskDetector) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("stuck-tasks")
+
+	interval := d.Interval
+	if interval <= 0 {
+		interval = StuckTaskSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.Sweep(ctx, clockNow(d.Clock)); err != nil {
+				log.Error(err, "unable to check for stuck Tasks")
+			}
+		}
+	}
+}
+
+// agentTasks groups the Tasks of one Agent
+type agentTasks struct {
+	running   []*kubetaskv1alpha1.Task
+	durations []time.Duration
+}
+
+// Sweep checks every running Task against its Agent's stuck detection bounds
+func (d *StuckTaskDetector) Sweep(ctx context.Context, now time.Time) error {
+	log := log.FromContext(ctx)
+
+	taskList := &kubetaskv1alpha1.TaskList{}
+	if err := d.List(ctx, taskList); err != nil {
+		return err
+	}
+
+	agents := map[types.NamespacedName]*agentTasks{}
+	for i := range taskList.Items {
+		task := &taskList.Items[i]
+		if isComparison(task) || task.Status.StartTime == nil {
+			continue
+		}
+		if _, ok := task.Annotations[ExternalRunAnnotation]; ok {
+			continue
+		}
+		key := types.NamespacedName{Namespace: task.Namespace, Name: taskAgentName(task)}
+		group := agents[key]
+		if group == nil {
+			group = &agentTasks{}
+			agents[key] = group
+		}
+		switch task.Status.Phase {
+		case kubetaskv1alpha1.TaskPhaseRunning:
+			if !isSuspended(task) {
+				group.running = append(group.running, task)
+			}
+		case kubetaskv1alpha1.TaskPhaseCompleted:
+			if task.Status.CompletionTime != nil {
+				group.durations = append(group.durations, task.Status.CompletionTime.Sub(task.Status.StartTime.Time))
+			}
+		}
+	}
+
+	stuckTasks.Reset()
+	for key, group := range agents {
+		if len(group.running) == 0 {
+			continue
+		}
+		agent := &kubetaskv1alpha1.Agent{}
+		if err := d.Get(ctx, key, agent); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		detection := agent.Spec.StuckDetection
+		if detection == nil {
+			continue
+		}
+		threshold, bound, ok := stuckThreshold(detection, group.durations)
+		if !ok {
+			continue
+		}
+
+		for _, task := range group.running {
+			if now.Sub(task.Status.StartTime.Time) <= threshold {
+				continue
+			}
+			stuckTasks.WithLabelValues(key.Namespace, key.Name).Inc()
+			if err := d.handleStuckTask(ctx, task, detection.Action, bound); err != nil {
+				// Conflicts resolve themselves on the next sweep
+				log.Error(err, "unable to handle stuck Task", "task", task.Name, "namespace", task.Namespace)
+			}
+		}
+	}
+	return nil
+}
+
+// taskAgentName returns the name of the Agent a Task runs with
+func taskAgentName(task *kubetaskv1alpha1.Task) string {
+	if task.Spec.AgentRef != "" {
+		return task.Spec.AgentRef
+	}
+	return "default"
+}
+
+// stuckThreshold returns how long a Task may run and a description of the bound.
+// ok is false if neither bound applies.
+func stuckThreshold(detection *kubetaskv1alpha1.AgentStuckDetection, durations []time.Duration) (threshold time.Duration, bound string, ok bool) {
+	if detection.MaxDuration != nil && detection.MaxDuration.Duration > 0 {
+		threshold, bound, ok = detection.MaxDuration.Duration, fmt.Sprintf("maxDuration (%s)", detection.MaxDuration.Duration), true
+	}
+	if detection.ExpectedDurationPercentile != nil && len(durations) >= minExpectedDurationSamples {
+		expected := durationPercentile(durations, *detection.ExpectedDurationPercentile)
+		if !ok || expected < threshold {
+			threshold, ok = expected, true
+			bound = fmt.Sprintf("p%d of %d completed Tasks (%s)", *detection.ExpectedDurationPercentile, len(durations), expected)
+		}
+	}
+	return threshold, bound, ok
+}
+
+// durationPercentile returns the nearest-rank percentile of durations
+func durationPercentile(durations []time.Duration, percentile int32) time.Duration {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(float64(percentile) / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1].Truncate(time.Second)
+}
+
+// handleStuckTask sets the Stuck condition and, for the Cancel action, stops the
+// agent and fails the Task
+func (d *StuckTaskDetector) handleStuckTask(ctx context.Context, task *kubetaskv1alpha1.Task, action kubetaskv1alpha1.StuckAction, bound string) error {
+	log := log.FromContext(ctx)
+
+	message := "Running longer than " + bound
+	if action != kubetaskv1alpha1.StuckActionCancel {
+		if meta.IsStatusConditionTrue(task.Status.Conditions, StuckConditionType) {
+			return nil
+		}
+		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    StuckConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "DurationExceeded",
+			Message: message,
+		})
+		log.Info("task is stuck", "task", task.Name, "namespace", task.Namespace, "bound", bound)
+		return d.Status().Update(ctx, task)
+	}
+
+	if task.Status.JobName != "" {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: task.Status.JobName, Namespace: task.Namespace}}
+		if err := d.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+	now := metav1.NewTime(clockNow(d.Clock))
+	task.Status.CompletionTime = &now
+	meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    StuckConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "DurationExceeded",
+		Message: message,
+	})
+	meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  "Stuck",
+		Message: message + "; cancelled",
+	})
+	log.Info("cancelling stuck task", "task", task.Name, "namespace", task.Namespace, "job", task.Status.JobName, "bound", bound)
+	if err := d.Status().Update(ctx, task); err != nil {
+		return err
+	}
+	stuckTasksCancelled.WithLabelValues(task.Namespace, taskAgentName(task)).Inc()
+	audit.Record(audit.ForTask(task, audit.ActionFailed, audit.ControllerActor))
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestStuckThreshold(t *testing.T) {
+	minutes := func(values ...int) []time.Duration {
+		durations := make([]time.Duration, 0, len(values))
+		for _, v := range values {
+			durations = append(durations, time.Duration(v)*time.Minute)
+		}
+		return durations
+	}
+	history := minutes(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	percentile := func(p int32) *int32 { return &p }
+
+	tests := []struct {
+		name      string
+		detection kubetaskv1alpha1.AgentStuckDetection
+		durations []time.Duration
+		want      time.Duration
+		wantOK    bool
+	}{
+		{name: "no bounds", durations: history},
+		{name: "max duration", detection: kubetaskv1alpha1.AgentStuckDetection{
+			MaxDuration: &metav1.Duration{Duration: time.Hour},
+		}, want: time.Hour, wantOK: true},
+		{name: "percentile", detection: kubetaskv1alpha1.AgentStuckDetection{
+			ExpectedDurationPercentile: percentile(90),
+		}, durations: history, want: 9 * time.Minute, wantOK: true},
+		{name: "percentile needs enough history", detection: kubetaskv1alpha1.AgentStuckDetection{
+			ExpectedDurationPercentile: percentile(90),
+		}, durations: history[:9]},
+		{name: "percentile below max duration", detection: kubetaskv1alpha1.AgentStuckDetection{
+			MaxDuration:                &metav1.Duration{Duration: time.Hour},
+			ExpectedDurationPercentile: percentile(100),
+		}, durations: history, want: 10 * time.Minute, wantOK: true},
+		{name: "max duration below percentile", detection: kubetaskv1alpha1.AgentStuckDetection{
+			MaxDuration:                &metav1.Duration{Duration: 5 * time.Minute},
+			ExpectedDurationPercentile: percentile(100),
+		}, durations: history, want: 5 * time.Minute, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, bound, ok := stuckThreshold(&tt.detection, tt.durations)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("stuckThreshold() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if ok && bound == "" {
+				t.Error("stuckThreshold() bound is empty")
+			}
+		})
+	}
+}
+
+func TestStuckTaskDetector_Sweep(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	startedAgo := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+	newAgent := func(name string, action kubetaskv1alpha1.StuckAction) *kubetaskv1alpha1.Agent {
+		return &kubetaskv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: kubetaskv1alpha1.AgentSpec{StuckDetection: &kubetaskv1alpha1.AgentStuckDetection{
+				MaxDuration: &metav1.Duration{Duration: time.Hour},
+				Action:      action,
+			}},
+		}
+	}
+	newTask := func(name, agent string, started time.Duration) *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: agent},
+			Status: kubetaskv1alpha1.TaskExecutionStatus{
+				Phase:     kubetaskv1alpha1.TaskPhaseRunning,
+				JobName:   name + "-job",
+				StartTime: startedAgo(started),
+			},
+		}
+	}
+
+	scheme := newTestScheme(t)
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	alerted := newTask("alerted", "watcher", 2*time.Hour)
+	cancelled := newTask("cancelled", "canceller", 2*time.Hour)
+	healthy := newTask("healthy", "canceller", 30*time.Minute)
+	unwatched := newTask("unwatched", "default", 48*time.Hour)
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "cancelled-job", Namespace: "default"}}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			newAgent("watcher", kubetaskv1alpha1.StuckActionAlert),
+			newAgent("canceller", kubetaskv1alpha1.StuckActionCancel),
+			&kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			alerted, cancelled, healthy, unwatched, job,
+		).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+
+	d := &StuckTaskDetector{Client: k8sClient}
+	if err := d.Sweep(context.Background(), now); err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+
+	get := func(name string) *kubetaskv1alpha1.Task {
+		task := &kubetaskv1alpha1.Task{}
+		if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, task); err != nil {
+			t.Fatal(err)
+		}
+		return task
+	}
+
+	if task := get("alerted"); task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning ||
+		!meta.IsStatusConditionTrue(task.Status.Conditions, StuckConditionType) {
+		t.Errorf("alerted Task phase = %s, conditions = %+v, want Running and Stuck", task.Status.Phase, task.Status.Conditions)
+	}
+
+	task := get("cancelled")
+	if task.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed || task.Status.CompletionTime == nil {
+		t.Errorf("cancelled Task phase = %s, want Failed with a completion time", task.Status.Phase)
+	}
+	if ready := meta.FindStatusCondition(task.Status.Conditions, "Ready"); ready == nil || ready.Reason != "Stuck" {
+		t.Errorf("cancelled Task Ready condition = %+v, want reason Stuck", ready)
+	}
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(job), &batchv1.Job{}); !errors.IsNotFound(err) {
+		t.Errorf("Job of cancelled Task error = %v, want NotFound", err)
+	}
+
+	for _, name := range []string{"healthy", "unwatched"} {
+		if task := get(name); task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning ||
+			meta.FindStatusCondition(task.Status.Conditions, StuckConditionType) != nil {
+			t.Errorf("%s Task phase = %s, conditions = %+v, want Running without Stuck", name, task.Status.Phase, task.Status.Conditions)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/results"
+)
+
+const cleanupTestTTL = time.Hour
+
+var cleanupTestNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+var cleanupTaskKey = types.NamespacedName{Name: "done", Namespace: "default"}
+
+func newCleanupTestClient(t *testing.T, task *kubetaskv1alpha1.Task) client.Client {
+	t.Helper()
+	ttl := int32(cleanupTestTTL / time.Second)
+	config := &kubetaskv1alpha1.KubeTaskConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultKubeTaskConfigName, Namespace: "default"},
+		Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+			TaskLifecycle: &kubetaskv1alpha1.TaskLifecycleConfig{TTLSecondsAfterFinished: &ttl},
+		},
+	}
+	return fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(config, task).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}).
+		Build()
+}
+
+func cleanupTestReconciler(c client.Client) *TaskReconciler {
+	return &TaskReconciler{Client: c, Clock: staticClock{now: cleanupTestNow}}
+}
+
+func completedTask(completedAgo time.Duration) *kubetaskv1alpha1.Task {
+	completionTime := metav1.NewTime(cleanupTestNow.Add(-completedAgo))
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "default", UID: "done-uid"},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:          kubetaskv1alpha1.TaskPhaseCompleted,
+			CompletionTime: &completionTime,
+		},
+	}
+}
+
+func TestHandleTaskCleanup(t *testing.T) {
+	t.Run("expired", func(t *testing.T) {
+		task := completedTask(2 * cleanupTestTTL)
+		c := newCleanupTestClient(t, task)
+		deletions := taskCleanupDeletions.WithLabelValues("default", cleanupReasonTTL, "success")
+		before := testutil.ToFloat64(deletions)
+
+		result, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), task)
+		if err != nil || result.RequeueAfter != 0 {
+			t.Fatalf("handleTaskCleanup() = %+v, %v, want no requeue", result, err)
+		}
+		if err := c.Get(context.Background(), cleanupTaskKey, &kubetaskv1alpha1.Task{}); !errors.IsNotFound(err) {
+			t.Errorf("Get() error = %v, want the Task deleted", err)
+		}
+		if got := testutil.ToFloat64(deletions) - before; got != 1 {
+			t.Errorf("successful TTL deletions = %v, want 1", got)
+		}
+	})
+
+	t.Run("delete fails", func(t *testing.T) {
+		task := completedTask(2 * cleanupTestTTL)
+		c := interceptor.NewClient(newCleanupTestClient(t, task).(client.WithWatch), interceptor.Funcs{
+			Delete: func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+				return errors.NewServiceUnavailable("etcd is unavailable")
+			},
+		})
+		failures := taskCleanupDeletions.WithLabelValues("default", cleanupReasonTTL, "error")
+		before := testutil.ToFloat64(failures)
+
+		if _, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), task); err == nil {
+			t.Fatal("handleTaskCleanup() error = nil, want the delete error")
+		}
+		if got := testutil.ToFloat64(failures) - before; got != 1 {
+			t.Errorf("failed TTL deletions = %v, want 1", got)
+		}
+	})
+
+	t.Run("not yet expired", func(t *testing.T) {
+		task := completedTask(cleanupTestTTL / 2)
+		c := newCleanupTestClient(t, task)
+
+		result, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), task)
+		if err != nil {
+			t.Fatalf("handleTaskCleanup() error = %v", err)
+		}
+		if result.RequeueAfter != cleanupTestTTL/2 {
+			t.Errorf("RequeueAfter = %s, want the remaining half of the TTL", result.RequeueAfter)
+		}
+	})
+
+	t.Run("being deleted", func(t *testing.T) {
+		// Deletion is held by a finalizer of another controller
+		task := completedTask(100 * cleanupTestTTL)
+		task.Finalizers = []string{"backup.example.com/protect"}
+		task.DeletionTimestamp = &metav1.Time{Time: cleanupTestNow}
+		c := newCleanupTestClient(t, task)
+
+		result, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), task)
+		if err != nil || result.RequeueAfter != 0 {
+			t.Fatalf("handleTaskCleanup() = %+v, %v, want no requeue", result, err)
+		}
+		if err := c.Get(context.Background(), cleanupTaskKey, &kubetaskv1alpha1.Task{}); err != nil {
+			t.Errorf("Get() error = %v, want the Task kept until its finalizer is removed", err)
+		}
+	})
+
+	t.Run("recreated under the same name", func(t *testing.T) {
+		stale := completedTask(2 * cleanupTestTTL)
+		recreated := completedTask(0)
+		recreated.UID = "new-uid"
+		// The fake client ignores preconditions; reject them like the API server
+		c := interceptor.NewClient(newCleanupTestClient(t, recreated).(client.WithWatch), interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				deleteOpts := (&client.DeleteOptions{}).ApplyOptions(opts)
+				current := &kubetaskv1alpha1.Task{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+					return err
+				}
+				if deleteOpts.Preconditions == nil || deleteOpts.Preconditions.UID == nil || *deleteOpts.Preconditions.UID != current.UID {
+					return errors.NewConflict(kubetaskv1alpha1.GroupVersion.WithResource("tasks").GroupResource(), obj.GetName(), nil)
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		})
+
+		if _, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), stale); err != nil {
+			t.Fatalf("handleTaskCleanup() error = %v", err)
+		}
+		current := &kubetaskv1alpha1.Task{}
+		if err := c.Get(context.Background(), cleanupTaskKey, current); err != nil || current.UID != "new-uid" {
+			t.Errorf("Get() = %s, %v, want the recreated Task kept", current.UID, err)
+		}
+	})
+
+	t.Run("completion time in the future", func(t *testing.T) {
+		task := completedTask(-24 * time.Hour)
+		c := newCleanupTestClient(t, task)
+
+		result, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), task)
+		if err != nil {
+			t.Fatalf("handleTaskCleanup() error = %v", err)
+		}
+		if result.RequeueAfter != cleanupTestTTL {
+			t.Errorf("RequeueAfter = %s, want the full TTL", result.RequeueAfter)
+		}
+		updated := &kubetaskv1alpha1.Task{}
+		if err := c.Get(context.Background(), cleanupTaskKey, updated); err != nil {
+			t.Fatal(err)
+		}
+		if !updated.Status.CompletionTime.Time.Equal(cleanupTestNow) {
+			t.Errorf("CompletionTime = %s, want it reset to now", updated.Status.CompletionTime)
+		}
+	})
+}
+
+// failingResultStore rejects every record
+type failingResultStore struct{ results.Store }
+
+func (failingResultStore) Put(context.Context, results.Record) error {
+	return errors.NewServiceUnavailable("disk full")
+}
+
+func TestReconcile_RecordsResultBeforeTTLCleanup(t *testing.T) {
+	store, err := results.OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newCleanupTestClient(t, completedTask(2*cleanupTestTTL))
+	r := cleanupTestReconciler(c)
+	r.Results = failingResultStore{}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: cleanupTaskKey}); err == nil {
+		t.Fatal("Reconcile() error = nil, want the results store error")
+	}
+	if err := c.Get(context.Background(), cleanupTaskKey, &kubetaskv1alpha1.Task{}); err != nil {
+		t.Errorf("Get() error = %v, want the unrecorded Task kept", err)
+	}
+
+	r.Results = store
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: cleanupTaskKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := c.Get(context.Background(), cleanupTaskKey, &kubetaskv1alpha1.Task{}); !errors.IsNotFound(err) {
+		t.Errorf("Get() error = %v, want the recorded Task deleted", err)
+	}
+	record, err := store.Get(context.Background(), "default", "done-uid")
+	if err != nil || record.Phase != kubetaskv1alpha1.TaskPhaseCompleted || record.Agent != "default" {
+		t.Errorf("stored result = %+v, %v", record, err)
+	}
+}
+
+func TestHandleTaskCleanup_AgentRetention(t *testing.T) {
+	retentionAgent := func(retention kubetaskv1alpha1.AgentRetention) *kubetaskv1alpha1.Agent {
+		return &kubetaskv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+			Spec:       kubetaskv1alpha1.AgentSpec{Retention: &retention},
+		}
+	}
+	limit := func(v int32) *int32 { return &v }
+
+	t.Run("agent TTL overrides namespace TTL", func(t *testing.T) {
+		task := completedTask(2 * cleanupTestTTL)
+		c := newCleanupTestClient(t, task)
+		if err := c.Create(context.Background(), retentionAgent(kubetaskv1alpha1.AgentRetention{
+			TTLSecondsAfterFinished: limit(int32(3 * cleanupTestTTL / time.Second)),
+		})); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), task)
+		if err != nil || result.RequeueAfter != cleanupTestTTL {
+			t.Fatalf("handleTaskCleanup() = %+v, %v, want a requeue when the Agent TTL expires", result, err)
+		}
+	})
+
+	t.Run("history limit", func(t *testing.T) {
+		older := completedTask(time.Minute)
+		older.CreationTimestamp = metav1.NewTime(cleanupTestNow.Add(-2 * time.Hour))
+		newer := completedTask(time.Minute)
+		newer.Name, newer.UID = "newer", "newer-uid"
+		newer.CreationTimestamp = metav1.NewTime(cleanupTestNow.Add(-time.Hour))
+		failed := completedTask(time.Minute)
+		failed.Name, failed.UID = "failed", "failed-uid"
+		failed.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+		c := newCleanupTestClient(t, older)
+		for _, obj := range []client.Object{newer, failed, retentionAgent(kubetaskv1alpha1.AgentRetention{
+			SuccessfulTasksHistoryLimit: limit(1),
+			FailedTasksHistoryLimit:     limit(1),
+		})} {
+			if err := c.Create(context.Background(), obj); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := cleanupTestReconciler(c).handleTaskCleanup(context.Background(), newer); err != nil {
+			t.Fatalf("handleTaskCleanup() error = %v", err)
+		}
+		if err := c.Get(context.Background(), cleanupTaskKey, &kubetaskv1alpha1.Task{}); !errors.IsNotFound(err) {
+			t.Errorf("Get() older Task error = %v, want it deleted", err)
+		}
+		for _, name := range []string{"newer", "failed"} {
+			if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, &kubetaskv1alpha1.Task{}); err != nil {
+				t.Errorf("Get() %s Task error = %v, want it kept", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+// ComparisonLabelKey marks the child Tasks of a comparison parent with its name
+const ComparisonLabelKey = "kubetask.io/comparison"
+
+// isComparison reports whether a Task compares several Agents through child Tasks
+func isComparison(task *kubetaskv1alpha1.Task) bool {
+	return task.Spec.Replicas != nil && len(task.Spec.Replicas.Compare) > 0
+}
+
+// comparisonTaskName returns the name of the child Task running one compared Agent
+func comparisonTaskName(task *kubetaskv1alpha1.Task, agentRef string) string {
+	return fmt.Sprintf("%s-%s", task.Name, agentRef)
+}
+
+// reconcileComparison creates a child Task per compared Agent and reports their
+// results side by side. The parent finishes when every child has finished.
+func (r *TaskReconciler) reconcileComparison(ctx context.Context, task *kubetaskv1alpha1.Task) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	recorded := make(map[string]kubetaskv1alpha1.ComparisonResult, len(task.Status.Comparison))
+	for _, res := range task.Status.Comparison {
+		recorded[res.AgentRef] = res
+	}
+
+	results := make([]kubetaskv1alpha1.ComparisonResult, 0, len(task.Spec.Replicas.Compare))
+	for _, agentRef := range task.Spec.Replicas.Compare {
+		child := &kubetaskv1alpha1.Task{}
+		childName := comparisonTaskName(task, agentRef)
+		if err := r.Get(ctx, types.NamespacedName{Name: childName, Namespace: task.Namespace}, child); err != nil {
+			if !errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			// A finished child may already be cleaned up by its TTL; keep its result
+			if res, ok := recorded[agentRef]; ok && (res.Phase == kubetaskv1alpha1.TaskPhaseCompleted || res.Phase == kubetaskv1alpha1.TaskPhaseFailed) {
+				results = append(results, res)
+				continue
+			}
+			child = buildComparisonTask(task, childName, agentRef)
+			if err := r.Create(ctx, child); err != nil && !errors.IsAlreadyExists(err) {
+				log.Error(err, "unable to create comparison Task", "agent", agentRef)
+				return ctrl.Result{}, err
+			}
+			log.Info("created comparison Task", "task", childName, "agent", agentRef)
+		}
+		results = append(results, comparisonResult(child, agentRef))
+	}
+
+	previous := task.Status.DeepCopy()
+	task.Status.Comparison = results
+	if task.Status.StartTime == nil {
+		now := metav1.NewTime(clockNow(r.Clock))
+		task.Status.StartTime = &now
+	}
+
+	var completed, finished int
+	for _, res := range results {
+		switch res.Phase {
+		case kubetaskv1alpha1.TaskPhaseCompleted:
+			completed++
+			finished++
+		case kubetaskv1alpha1.TaskPhaseFailed:
+			finished++
+		}
+	}
+	condition := metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  "ComparisonRunning",
+		Message: fmt.Sprintf("%d/%d Agents finished: %s", finished, len(results), formatComparison(results)),
+	}
+	task.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
+	if finished == len(results) {
+		// A comparison succeeds if any Agent did; the per-Agent outcomes are in the status
+		task.Status.Phase = kubetaskv1alpha1.TaskPhaseCompleted
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ComparisonFinished"
+		if completed == 0 {
+			task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "AllAgentsFailed"
+		}
+		condition.Message = fmt.Sprintf("%d/%d Agents completed: %s", completed, len(results), formatComparison(results))
+		if task.Status.CompletionTime == nil {
+			now := metav1.NewTime(clockNow(r.Clock))
+			task.Status.CompletionTime = &now
+		}
+	}
+	meta.SetStatusCondition(&task.Status.Conditions, condition)
+
+	if equality.Semantic.DeepEqual(previous, &task.Status) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.Status().Update(ctx, task); err != nil {
+		return ctrl.Result{}, err
+	}
+	if previous.Phase == "" {
+		audit.Record(audit.ForTask(task, audit.ActionStarted, audit.ControllerActor))
+	}
+	switch {
+	case previous.Phase == task.Status.Phase:
+	case task.Status.Phase == kubetaskv1alpha1.TaskPhaseCompleted:
+		log.Info("comparison completed", "results", formatComparison(results))
+		audit.Record(audit.ForTask(task, audit.ActionCompleted, audit.ControllerActor))
+	case task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed:
+		log.Info("comparison failed, no Agent completed", "results", formatComparison(results))
+		audit.Record(audit.ForTask(task, audit.ActionFailed, audit.ControllerActor))
+	}
+	return ctrl.Result{}, nil
+}
+
+// buildComparisonTask creates the child Task running the parent's task against one Agent
+func buildComparisonTask(task *kubetaskv1alpha1.Task, name, agentRef string) *kubetaskv1alpha1.Task {
+	spec := *task.Spec.DeepCopy()
+	spec.AgentRef = agentRef
+	spec.Replicas = nil
+
+	labels := make(map[string]string, len(task.Labels)+1)
+	for k, v := range task.Labels {
+		labels[k] = v
+	}
+	labels[ComparisonLabelKey] = task.Name
+
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: task.Namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: task.APIVersion,
+					Kind:       task.Kind,
+					Name:       task.Name,
+					UID:        task.UID,
+					Controller: boolPtr(true),
+				},
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// comparisonResult summarizes a child Task for the parent's status
+func comparisonResult(child *kubetaskv1alpha1.Task, agentRef string) kubetaskv1alpha1.ComparisonResult {
+	result := kubetaskv1alpha1.ComparisonResult{
+		AgentRef: agentRef,
+		TaskName: child.Name,
+		Phase:    child.Status.Phase,
+		Changes:  child.Status.Changes,
+	}
+	if ready := meta.FindStatusCondition(child.Status.Conditions, "Ready"); ready != nil {
+		result.Message = ready.Message
+	}
+	if child.Status.StartTime != nil && child.Status.CompletionTime != nil {
+		result.Duration = &metav1.Duration{Duration: child.Status.CompletionTime.Sub(child.Status.StartTime.Time)}
+	}
+	return result
+}
+
+// formatComparison renders the per-Agent phases, e.g. "claude: Completed, gemini: Failed"
+func formatComparison(results []kubetaskv1alpha1.ComparisonResult) string {
+	parts := make([]string, 0, len(results))
+	for _, res := range results {
+		phase := res.Phase
+		if phase == "" {
+			phase = kubetaskv1alpha1.TaskPhasePending
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", res.AgentRef, phase))
+	}
+	return strings.Join(parts, ", ")
+}
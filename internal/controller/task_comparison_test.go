@@ -0,0 +1,140 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newComparisonTask() *kubetaskv1alpha1.Task {
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "upgrade",
+			Namespace: "default",
+			UID:       "parent-uid",
+			Labels:    map[string]string{"team": "platform"},
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			Description: stringPtr("Upgrade to Go 1.25"),
+			AgentRef:    "ignored",
+			Replicas:    &kubetaskv1alpha1.TaskReplicas{Compare: []string{"claude", "gemini"}},
+		},
+	}
+}
+
+func finishChild(t *testing.T, c client.Client, name string, phase kubetaskv1alpha1.TaskPhase) {
+	t.Helper()
+	child := &kubetaskv1alpha1.Task{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: "default"}, child); err != nil {
+		t.Fatalf("Get(%s) error = %v", name, err)
+	}
+	start := metav1.NewTime(time.Now().Add(-time.Minute))
+	end := metav1.Now()
+	child.Status.Phase = phase
+	child.Status.StartTime = &start
+	child.Status.CompletionTime = &end
+	child.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Done", Message: string(phase), LastTransitionTime: end}}
+	if phase == kubetaskv1alpha1.TaskPhaseCompleted {
+		child.Status.Changes = &kubetaskv1alpha1.TaskChanges{PullRequests: []string{"https://github.com/org/repo/pull/1"}}
+	}
+	if err := c.Status().Update(context.Background(), child); err != nil {
+		t.Fatalf("Status().Update(%s) error = %v", name, err)
+	}
+}
+
+func TestReconcileComparison(t *testing.T) {
+	tests := []struct {
+		name       string
+		phases     []kubetaskv1alpha1.TaskPhase
+		wantPhase  kubetaskv1alpha1.TaskPhase
+		wantReason string
+	}{
+		{name: "one Agent completed", phases: []kubetaskv1alpha1.TaskPhase{kubetaskv1alpha1.TaskPhaseCompleted, kubetaskv1alpha1.TaskPhaseFailed},
+			wantPhase: kubetaskv1alpha1.TaskPhaseCompleted, wantReason: "ComparisonFinished"},
+		{name: "all Agents failed", phases: []kubetaskv1alpha1.TaskPhase{kubetaskv1alpha1.TaskPhaseFailed, kubetaskv1alpha1.TaskPhaseFailed},
+			wantPhase: kubetaskv1alpha1.TaskPhaseFailed, wantReason: "AllAgentsFailed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := newComparisonTask()
+			k8sClient := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(task).
+				WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+			r := &TaskReconciler{Client: k8sClient}
+			key := types.NamespacedName{Name: "upgrade", Namespace: "default"}
+			reconcile := func() *kubetaskv1alpha1.Task {
+				t.Helper()
+				if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+				updated := &kubetaskv1alpha1.Task{}
+				if err := k8sClient.Get(context.Background(), key, updated); err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				return updated
+			}
+
+			parent := reconcile()
+			if parent.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning || len(parent.Status.Comparison) != 2 {
+				t.Fatalf("status = %+v, want Running with two comparison results", parent.Status)
+			}
+			if parent.Status.JobName != "" {
+				t.Errorf("JobName = %q, want no Job for a comparison parent", parent.Status.JobName)
+			}
+			child := &kubetaskv1alpha1.Task{}
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "upgrade-gemini", Namespace: "default"}, child); err != nil {
+				t.Fatalf("child Task was not created: %v", err)
+			}
+			if child.Spec.AgentRef != "gemini" || child.Spec.Replicas != nil || *child.Spec.Description != "Upgrade to Go 1.25" {
+				t.Errorf("child spec = %+v, want the parent's task pinned to gemini", child.Spec)
+			}
+			if child.Labels[ComparisonLabelKey] != "upgrade" || child.Labels["team"] != "platform" {
+				t.Errorf("child labels = %v, want the parent's labels and %s", child.Labels, ComparisonLabelKey)
+			}
+			if len(child.OwnerReferences) != 1 || child.OwnerReferences[0].UID != "parent-uid" {
+				t.Errorf("child OwnerReferences = %+v, want the parent", child.OwnerReferences)
+			}
+
+			finishChild(t, k8sClient, "upgrade-claude", tt.phases[0])
+			if parent = reconcile(); parent.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+				t.Errorf("phase = %q, want Running until every Agent finished", parent.Status.Phase)
+			}
+
+			// A finished child cleaned up by its TTL keeps its result
+			if err := k8sClient.Delete(context.Background(), &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "upgrade-claude", Namespace: "default"}}); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			finishChild(t, k8sClient, "upgrade-gemini", tt.phases[1])
+			parent = reconcile()
+			if parent.Status.Phase != tt.wantPhase || parent.Status.CompletionTime == nil {
+				t.Errorf("status = %+v, want %s", parent.Status, tt.wantPhase)
+			}
+			if ready := meta.FindStatusCondition(parent.Status.Conditions, "Ready"); ready == nil || ready.Reason != tt.wantReason {
+				t.Errorf("Ready condition = %+v, want reason %s", ready, tt.wantReason)
+			}
+			results := parent.Status.Comparison
+			if results[0].AgentRef != "claude" || results[0].Phase != tt.phases[0] || results[0].Duration == nil ||
+				results[1].AgentRef != "gemini" || results[1].Phase != tt.phases[1] {
+				t.Errorf("comparison = %+v, want both results in compare order", results)
+			}
+			if (results[0].Changes != nil) != (tt.phases[0] == kubetaskv1alpha1.TaskPhaseCompleted) {
+				t.Errorf("comparison changes = %+v", results[0].Changes)
+			}
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "upgrade-claude", Namespace: "default"}, child); err == nil {
+				t.Errorf("deleted child Task was recreated")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AgentResolvedConditionType reports whether the Agent of a Task was found and passed its pre-flight check
+	AgentResolvedConditionType = "AgentResolved"
+
+	// CredentialsMountedConditionType reports which credentials were mounted into the Job of a Task
+	CredentialsMountedConditionType = "CredentialsMounted"
+
+	// JobCreatedConditionType reports whether the Job of a Task was created
+	JobCreatedConditionType = "JobCreated"
+)
+
+// setAgentResolvedCondition records whether the Agent of the Task could be used.
+// reason is "AgentResolved" on success and the Ready reason otherwise.
+func setAgentResolvedCondition(task *kubetaskv1alpha1.Task, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    AgentResolvedConditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// setJobCreatedCondition records that the Job of the Task was created, or why it
+// was not, and reports whether the condition changed
+func setJobCreatedCondition(task *kubetaskv1alpha1.Task, jobName string, err error, reason string) bool {
+	condition := metav1.Condition{
+		Type:    JobCreatedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "JobCreated",
+		Message: fmt.Sprintf("Job %s created", jobName),
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = err.Error()
+	}
+	return meta.SetStatusCondition(&task.Status.Conditions, condition)
+}
+
+// setCredentialsMountedCondition records the credentials mounted into the Job of the
+// Task and how the agent receives each of them
+func setCredentialsMountedCondition(task *kubetaskv1alpha1.Task, credentials []kubetaskv1alpha1.Credential) {
+	condition := metav1.Condition{
+		Type:    CredentialsMountedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "NoCredentials",
+		Message: "The Agent has no credentials to mount",
+	}
+	if len(credentials) > 0 {
+		mounts := make([]string, 0, len(credentials))
+		for _, cred := range credentials {
+			mounts = append(mounts, fmt.Sprintf("%s (%s)", cred.Name, credentialTarget(cred)))
+		}
+		condition.Reason = "CredentialsMounted"
+		condition.Message = fmt.Sprintf("%d credentials mounted: %s", len(credentials), strings.Join(mounts, ", "))
+	}
+	meta.SetStatusCondition(&task.Status.Conditions, condition)
+}
+
+// credentialTarget describes where the agent finds a credential, the way build.Job
+// mounts it
+func credentialTarget(cred kubetaskv1alpha1.Credential) string {
+	if cred.SecretRef.Key == nil || *cred.SecretRef.Key == "" {
+		// An entire Secret is exposed as one variable per key
+		prefix := ""
+		if cred.EnvPrefix != nil {
+			prefix = *cred.EnvPrefix
+		}
+		return "env " + prefix + "*"
+	}
+	var targets []string
+	if cred.Env != nil && *cred.Env != "" {
+		targets = append(targets, "env "+*cred.Env)
+	}
+	if cred.MountPath != nil && *cred.MountPath != "" {
+		targets = append(targets, "file "+*cred.MountPath)
+	}
+	if len(targets) == 0 {
+		return "not exposed"
+	}
+	return strings.Join(targets, ", ")
+}
@@ -0,0 +1,126 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestInitializeTask_LifecycleConditions(t *testing.T) {
+	agent, task := newInitTestObjects()
+	agent.Spec.Credentials = []kubetaskv1alpha1.Credential{{
+		Name:      "github",
+		SecretRef: kubetaskv1alpha1.SecretReference{Name: "github", Key: stringPtr("token")},
+		Env:       stringPtr("GITHUB_TOKEN"),
+	}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("ghp_example")},
+	}
+	shouldFail := failOnce()
+	c := newInitTestClient(t, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*batchv1.Job); ok && shouldFail() {
+				return errInjected
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}, agent, task, secret)
+
+	assertCondition := func(task *kubetaskv1alpha1.Task, conditionType string, status metav1.ConditionStatus, reason string) {
+		t.Helper()
+		condition := meta.FindStatusCondition(task.Status.Conditions, conditionType)
+		if condition == nil || condition.Status != status || condition.Reason != reason {
+			t.Errorf("%s = %+v, want %s/%s", conditionType, condition, status, reason)
+		}
+	}
+
+	failed, err := reconcileInitTask(t, c)
+	if !stderrors.Is(err, errInjected) {
+		t.Fatalf("Reconcile() error = %v, want the injected failure", err)
+	}
+	assertCondition(failed, AgentResolvedConditionType, metav1.ConditionTrue, "AgentResolved")
+	assertCondition(failed, ContextsResolvedConditionType, metav1.ConditionTrue, "AllContextsResolved")
+	assertCondition(failed, CredentialsResolvedConditionType, metav1.ConditionTrue, "AllCredentialsResolved")
+	assertCondition(failed, JobCreatedConditionType, metav1.ConditionFalse, "JobCreateFailed")
+	if meta.FindStatusCondition(failed.Status.Conditions, CredentialsMountedConditionType) != nil {
+		t.Error("CredentialsMounted set before the Job was created")
+	}
+
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+	assertCondition(started, JobCreatedConditionType, metav1.ConditionTrue, "JobCreated")
+	assertCondition(started, CredentialsMountedConditionType, metav1.ConditionTrue, "CredentialsMounted")
+	if mounted := meta.FindStatusCondition(started.Status.Conditions, CredentialsMountedConditionType); mounted.Message != "1 credentials mounted: github (env GITHUB_TOKEN)" {
+		t.Errorf("CredentialsMounted message = %q", mounted.Message)
+	}
+}
+
+func TestInitializeTask_AgentResolvedFailure(t *testing.T) {
+	_, task := newInitTestObjects()
+	c := newInitTestClient(t, interceptor.Funcs{}, task)
+
+	failed, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	condition := meta.FindStatusCondition(failed.Status.Conditions, AgentResolvedConditionType)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "AgentError" {
+		t.Errorf("AgentResolved = %+v, want False/AgentError", condition)
+	}
+}
+
+func TestCredentialTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		cred kubetaskv1alpha1.Credential
+		want string
+	}{
+		{
+			name: "env and file",
+			cred: kubetaskv1alpha1.Credential{
+				SecretRef: kubetaskv1alpha1.SecretReference{Name: "ssh", Key: stringPtr("key")},
+				Env:       stringPtr("SSH_KEY"),
+				MountPath: stringPtr("/home/agent/.ssh/id_rsa"),
+			},
+			want: "env SSH_KEY, file /home/agent/.ssh/id_rsa",
+		},
+		{
+			name: "entire Secret",
+			cred: kubetaskv1alpha1.Credential{
+				SecretRef: kubetaskv1alpha1.SecretReference{Name: "azure"},
+				Env:       stringPtr("ignored"),
+				EnvPrefix: stringPtr("AZURE_"),
+			},
+			want: "env AZURE_*",
+		},
+		{
+			name: "key without target",
+			cred: kubetaskv1alpha1.Credential{SecretRef: kubetaskv1alpha1.SecretReference{Name: "unused", Key: stringPtr("key")}},
+			want: "not exposed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := credentialTarget(tt.cred); got != tt.want {
+				t.Errorf("credentialTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
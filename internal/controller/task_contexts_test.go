@@ -0,0 +1,351 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// pngHeader is not valid UTF-8 and must be stored in binaryData
+var pngHeader = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+func TestProcessAllContexts_BinaryContent(t *testing.T) {
+	objects := []client.Object{
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "logo", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:   kubetaskv1alpha1.ContextTypeInline,
+				Inline: &kubetaskv1alpha1.InlineContext{BinaryContent: pngHeader},
+			},
+		},
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "fixtures", Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:      kubetaskv1alpha1.ContextTypeConfigMap,
+				ConfigMap: &kubetaskv1alpha1.ConfigMapContext{Name: "fixtures", Key: "data.tar.gz"},
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "fixtures", Namespace: "default"},
+			BinaryData: map[string][]byte{"data.tar.gz": {0x1f, 0x8b, 0x08, 0x00}},
+		},
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(objects...).Build()}
+	cfg := agentConfig{Config: build.Config{WorkspaceDir: "/workspace"}}
+
+	t.Run("binary contexts are stored in binaryData", func(t *testing.T) {
+		description := "Update the logo"
+		task := &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+			Spec: kubetaskv1alpha1.TaskSpec{
+				Description: &description,
+				Contexts: []kubetaskv1alpha1.ContextMount{
+					{Name: "logo", MountPath: "/workspace/logo.png"},
+					{Name: "fixtures", MountPath: "/workspace/fixtures.tar.gz"},
+				},
+			},
+		}
+		cm, fileMounts, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+		if err != nil {
+			t.Fatalf("processAllContexts() error = %v", err)
+		}
+		if got := cm.BinaryData["workspace-logo.png"]; !bytes.Equal(got, pngHeader) {
+			t.Errorf("binaryData[workspace-logo.png] = %v, want %v", got, pngHeader)
+		}
+		if got := cm.BinaryData["workspace-fixtures.tar.gz"]; len(got) != 4 {
+			t.Errorf("binaryData[workspace-fixtures.tar.gz] = %v, want the ConfigMap binaryData key", got)
+		}
+		if _, ok := cm.Data["workspace-logo.png"]; ok {
+			t.Errorf("binary content must not be stored in data")
+		}
+		if cm.Data["workspace-task.md"] != description {
+			t.Errorf("task.md = %q, want the description", cm.Data["workspace-task.md"])
+		}
+		if len(fileMounts) != 3 {
+			t.Errorf("fileMounts = %v, want both binary files and task.md", fileMounts)
+		}
+	})
+
+	t.Run("binary context without mountPath", func(t *testing.T) {
+		task := &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+			Spec:       kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "logo"}}},
+		}
+		if _, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg); err == nil {
+			t.Errorf("processAllContexts() error = nil, want error for binary content in task.md")
+		}
+	})
+}
+
+func TestProcessAllContexts_ParallelResolution(t *testing.T) {
+	var objects []client.Object
+	var refs []kubetaskv1alpha1.ContextMount
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("guide-%02d", i)
+		objects = append(objects, &kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:   kubetaskv1alpha1.ContextTypeInline,
+				Inline: &kubetaskv1alpha1.InlineContext{Content: "Content of " + name},
+			},
+		})
+		refs = append(refs, kubetaskv1alpha1.ContextMount{Name: name})
+	}
+	r := &TaskReconciler{
+		Client:             fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(objects...).Build(),
+		ContextConcurrency: 3,
+	}
+	cfg := agentConfig{Config: build.Config{WorkspaceDir: "/workspace"}, contexts: refs[:5]}
+
+	t.Run("contexts keep their order", func(t *testing.T) {
+		task := &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+			Spec:       kubetaskv1alpha1.TaskSpec{Contexts: refs[5:]},
+		}
+		cm, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+		if err != nil {
+			t.Fatalf("processAllContexts() error = %v", err)
+		}
+		taskMd := cm.Data["workspace-task.md"]
+		last := -1
+		for i := 0; i < 20; i++ {
+			idx := strings.Index(taskMd, fmt.Sprintf("Content of guide-%02d", i))
+			if idx <= last {
+				t.Fatalf("guide-%02d is out of order in task.md:\n%s", i, taskMd)
+			}
+			last = idx
+		}
+	})
+
+	t.Run("every failed context is reported", func(t *testing.T) {
+		task := &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+			Spec: kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{
+				{Name: "missing-a"}, {Name: "guide-07"}, {Name: "missing-b"},
+			}},
+		}
+		_, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+		if err == nil {
+			t.Fatal("processAllContexts() error = nil, want missing contexts")
+		}
+		for _, want := range []string{`Task context "missing-a"`, `Task context "missing-b"`} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error = %v, want it to mention %s", err, want)
+			}
+		}
+	})
+}
+
+func TestProcessAllContexts_OptionalContexts(t *testing.T) {
+	guide := &kubetaskv1alpha1.Context{
+		ObjectMeta: metav1.ObjectMeta{Name: "guide", Namespace: "default"},
+		Spec: kubetaskv1alpha1.ContextSpec{
+			Type:   kubetaskv1alpha1.ContextTypeInline,
+			Inline: &kubetaskv1alpha1.InlineContext{Content: "Write tests"},
+		},
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(guide).Build()}
+	cfg := agentConfig{
+		Config:   build.Config{WorkspaceDir: "/workspace"},
+		contexts: []kubetaskv1alpha1.ContextMount{{Name: "org-defaults", Optional: true}},
+	}
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Spec:       kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "guide"}}},
+	}
+
+	cm, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("processAllContexts() error = %v, want the missing optional context to be skipped", err)
+	}
+	if !strings.Contains(cm.Data["workspace-task.md"], "Write tests") {
+		t.Errorf("task.md = %q, want the guide context", cm.Data["workspace-task.md"])
+	}
+	condition := meta.FindStatusCondition(task.Status.Conditions, ContextsResolvedConditionType)
+	if condition == nil || condition.Status != metav1.ConditionFalse || !strings.Contains(condition.Message, "org-defaults") {
+		t.Errorf("ContextsResolved condition = %+v, want a warning about org-defaults", condition)
+	}
+
+	// Required contexts still fail the resolution
+	cfg.contexts[0].Optional = false
+	if _, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg); err == nil {
+		t.Errorf("processAllContexts() error = nil, want an error for the required context")
+	}
+}
+
+func TestProcessAllContexts_DescriptionFrom(t *testing.T) {
+	objects := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "prompts", Namespace: "default"},
+			Data:       map[string]string{"migrate.md": "Migrate the service to the v2 API"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "prompts", Namespace: "default"},
+			Data:       map[string][]byte{"incident.md": []byte("Investigate the incident")},
+		},
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(objects...).Build()}
+	cfg := agentConfig{Config: build.Config{WorkspaceDir: "/workspace"}}
+	optional := true
+
+	tests := []struct {
+		name        string
+		from        *kubetaskv1alpha1.DescriptionSource
+		description string
+		want        string
+		wantErr     string
+	}{
+		{
+			name: "configMapKeyRef",
+			from: &kubetaskv1alpha1.DescriptionSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"}, Key: "migrate.md",
+			}},
+			want: "Migrate the service to the v2 API",
+		},
+		{
+			name: "secretKeyRef",
+			from: &kubetaskv1alpha1.DescriptionSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"}, Key: "incident.md",
+			}},
+			want: "Investigate the incident",
+		},
+		{
+			name: "inline description is appended",
+			from: &kubetaskv1alpha1.DescriptionSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"}, Key: "migrate.md",
+			}},
+			description: "Start with the billing service",
+			want:        "Migrate the service to the v2 API\n\nStart with the billing service",
+		},
+		{
+			name: "missing key",
+			from: &kubetaskv1alpha1.DescriptionSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"}, Key: "missing.md",
+			}},
+			wantErr: "descriptionFrom: key missing.md not found in Secret prompts",
+		},
+		{
+			name: "missing optional ConfigMap",
+			from: &kubetaskv1alpha1.DescriptionSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "absent"}, Key: "migrate.md", Optional: &optional,
+			}},
+			description: "Fallback instructions",
+			want:        "Fallback instructions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+				Spec:       kubetaskv1alpha1.TaskSpec{DescriptionFrom: tt.from},
+			}
+			if tt.description != "" {
+				task.Spec.Description = &tt.description
+			}
+
+			cm, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("processAllContexts() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("processAllContexts() error = %v", err)
+			}
+			if got := cm.Data["workspace-task.md"]; got != tt.want {
+				t.Errorf("task.md = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessAllContexts_Preamble(t *testing.T) {
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()}
+	cfg := agentConfig{Config: build.Config{WorkspaceDir: "/workspace", Preamble: "Respond in Japanese."}}
+	description := "Fix the failing test"
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Spec:       kubetaskv1alpha1.TaskSpec{Description: &description},
+	}
+
+	cm, _, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("processAllContexts() error = %v", err)
+	}
+	if got, want := cm.Data["workspace-task.md"], "Respond in Japanese.\n\nFix the failing test"; got != want {
+		t.Errorf("task.md = %q, want %q", got, want)
+	}
+
+	// A preamble alone does not create task.md
+	task.Spec.Description = nil
+	cm, fileMounts, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("processAllContexts() error = %v", err)
+	}
+	if cm != nil || len(fileMounts) != 0 {
+		t.Errorf("expected no task.md without a description or contexts, got %v", fileMounts)
+	}
+}
+
+func TestProcessAllContexts_DefaultContexts(t *testing.T) {
+	var objects []client.Object
+	for _, name := range []string{"security-policy", "coding-standards", "guide"} {
+		objects = append(objects, &kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: kubetaskv1alpha1.ContextSpec{
+				Type:   kubetaskv1alpha1.ContextTypeInline,
+				Inline: &kubetaskv1alpha1.InlineContext{Content: "Content of " + name},
+			},
+		})
+	}
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(objects...).Build()}
+	cfg := agentConfig{
+		Config:          build.Config{WorkspaceDir: "/workspace"},
+		defaultContexts: []kubetaskv1alpha1.ContextMount{{Name: "security-policy"}, {Name: "guide"}},
+		contexts:        []kubetaskv1alpha1.ContextMount{{Name: "coding-standards"}},
+	}
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Spec: kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{
+			{Name: "guide", MountPath: "/workspace/guide.md"},
+		}},
+	}
+
+	cm, fileMounts, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("processAllContexts() error = %v", err)
+	}
+	taskMd := cm.Data["workspace-task.md"]
+	policy, standards := strings.Index(taskMd, "Content of security-policy"), strings.Index(taskMd, "Content of coding-standards")
+	if policy < 0 || standards < policy {
+		t.Errorf("task.md = %q, want the default context before the Agent's", taskMd)
+	}
+	// The Task mounts the guide itself, so it is not also appended to task.md
+	if strings.Contains(taskMd, "Content of guide") || len(fileMounts) != 2 {
+		t.Errorf("task.md = %q, file mounts = %v, want the guide only at the Task's mount path", taskMd, fileMounts)
+	}
+
+	// Defaults are required unless marked optional, whichever Agent the Task uses
+	cfg.defaultContexts = append(cfg.defaultContexts, kubetaskv1alpha1.ContextMount{Name: "missing"})
+	_, _, _, _, _, err = r.processAllContexts(context.Background(), task, cfg)
+	if err == nil || !strings.Contains(err.Error(), `KubeTaskConfig context "missing"`) {
+		t.Errorf("processAllContexts() error = %v, want the missing default context", err)
+	}
+}
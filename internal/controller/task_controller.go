@@ -5,9 +5,12 @@ package controller
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -17,37 +20,135 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+	"github.com/kubetask/kubetask/internal/results"
+	"github.com/kubetask/kubetask/pkg/build"
 )
 
 const (
-	// DefaultAgentImage is the default agent container image
+	// DefaultAgentImage is the built-in agent container image, used when neither the
+	// Agent, KubeTaskConfig, nor the --default-agent-image flag sets one
 	DefaultAgentImage = "quay.io/kubetask/kubetask-agent-gemini:latest"
 
 	// DefaultWorkspaceDir is the default workspace directory for agent containers
-	DefaultWorkspaceDir = "/workspace"
+	DefaultWorkspaceDir = build.DefaultWorkspaceDir
 
 	// ContextConfigMapSuffix is the suffix for ConfigMap names created for context
-	ContextConfigMapSuffix = "-context"
+	ContextConfigMapSuffix = build.ContextConfigMapSuffix
 
 	// DefaultTTLSecondsAfterFinished is the default TTL for completed/failed tasks (7 days)
 	DefaultTTLSecondsAfterFinished int32 = 604800
 
+	// maxCompletionTimeSkew is how far in the future a Task's completion time may be
+	// before TTL cleanup treats it as written by a controller with a skewed clock
+	maxCompletionTimeSkew = time.Minute
+
 	// DefaultKeepAliveSeconds is the default keep-alive duration for human-in-the-loop (1 hour)
-	DefaultKeepAliveSeconds int32 = 3600
+	DefaultKeepAliveSeconds = build.DefaultKeepAliveSeconds
 
 	// EnvHumanInTheLoopKeepAlive is the environment variable name for keep-alive seconds
-	EnvHumanInTheLoopKeepAlive = "KUBETASK_KEEP_ALIVE_SECONDS"
+	EnvHumanInTheLoopKeepAlive = build.EnvHumanInTheLoopKeepAlive
+
+	// DefaultRunningTaskResyncInterval is how often running Tasks are re-synced with
+	// their Job, in case a Job or Pod event was missed
+	DefaultRunningTaskResyncInterval = 5 * time.Minute
 )
 
 // TaskReconciler reconciles a Task object
 type TaskReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// DefaultAgentImage is used when neither the Agent nor KubeTaskConfig sets an image
+	DefaultAgentImage string
+
+	// GitSyncImage clones Git contexts. Defaults to build.DefaultGitSyncImage.
+	GitSyncImage string
+
+	// ObjectStoreImage downloads ObjectStore contexts in init containers. Defaults
+	// to build.DefaultObjectStoreImage.
+	ObjectStoreImage string
+
+	// ORASImage pulls OCI contexts in init containers. Defaults to build.DefaultORASImage.
+	ORASImage string
+
+	// LogDiffs logs the difference between the existing and desired Job, ConfigMap
+	// or NetworkPolicy of a Task whenever the controller updates one, or keeps an
+	// existing Job that differs from the one it would create
+	LogDiffs bool
+
+	// ContextConcurrency bounds the Contexts resolved in parallel for a Task.
+	// Defaults to DefaultContextConcurrency.
+	ContextConcurrency int
+
+	// ResultAPIURL is the URL agents push progress and results to. If empty, the
+	// result API is not served and agents report through the termination message.
+	ResultAPIURL string
+
+	// TaskPreamble is prepended to the task.md of every Task in the cluster,
+	// before the namespace's KubeTaskConfig prompt
+	TaskPreamble string
+
+	// JobCreationQPS and JobCreationBurst bound how fast Tasks start, i.e. have their
+	// ConfigMap, NetworkPolicy and Job created, with a token bucket. Zero QPS disables
+	// the limit.
+	JobCreationQPS   float64
+	JobCreationBurst int
+
+	// RunningTaskResyncInterval is how often running Tasks are re-synced with their Job
+	// even without events, with 10% jitter. Zero disables the periodic re-sync.
+	RunningTaskResyncInterval time.Duration
+
+	// ContextScanners check the description and resolved contexts of new Tasks for
+	// secrets and prompt-injection patterns. Findings are reported, not enforced.
+	ContextScanners []ContextScanner
+
+	// URLContextHTTPClient fetches URL Contexts. If nil, a client with a 30s timeout
+	// is used that refuses loopback, private and link-local addresses, except of
+	// hosts listed by exact name in URLContextAllowedHosts.
+	URLContextHTTPClient *http.Client
+
+	// URLContextAllowedHosts restricts the hosts URL Contexts are fetched from, e.g.
+	// "wiki.example.com" or "*.example.com". Any public host is allowed if empty.
+	URLContextAllowedHosts []string
+
+	// ContextResolvers resolve Contexts of additional types, or replace the
+	// built-in resolver of a type
+	ContextResolvers map[kubetaskv1alpha1.ContextType]ContextResolver
+
+	// JobMutators adjust the Job of every Task before it is created, in order and
+	// before the namespace's KubeTaskConfig webhooks
+	JobMutators []JobMutator
+
+	// Results keeps the outcome of finished Tasks beyond their TTL, if set. A Task is
+	// only deleted by its TTL once it is recorded.
+	Results results.Store
+
+	// Clock is used for TTLs, quiet hours and status timestamps. Defaults to the
+	// real clock.
+	Clock Clock
+
+	// config reads KubeTaskConfig from the informer cache; set up in SetupWithManager
+	config *kubeTaskConfigAccessor
+
+	// jobThrottle applies JobCreationQPS and JobCreationBurst; set up on first use
+	jobThrottle     *jobCreationThrottle
+	jobThrottleOnce sync.Once
+
+	// urlContexts caches the content of URL Contexts with a cacheTTL
+	urlContexts urlContextCache
+
+	// guardedURLContextClient fetches URL Contexts without URLContextHTTPClient; set
+	// up on first use
+	guardedURLContextClient *http.Client
+	urlContextClientOnce    sync.Once
 }
 
 // +kubebuilder:rbac:groups=kubetask.io,resources=tasks,verbs=get;list;watch;create;update;patch;delete
@@ -57,8 +158,11 @@ type TaskReconciler struct {
 // +kubebuilder:rbac:groups=kubetask.io,resources=contexts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kubetask.io,resources=kubetaskconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;create;update
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -75,23 +179,84 @@ func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
-	// If new, initialize status and create Job
-	if task.Status.Phase == "" {
+	// Records of workflows run by other engines are maintained by ExternalRunReconciler
+	if _, ok := task.Annotations[ExternalRunAnnotation]; ok {
+		return ctrl.Result{}, nil
+	}
+
+	// Comparison parents run their task through a child Task per Agent
+	if isComparison(task) && task.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted &&
+		task.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed {
+		return r.reconcileComparison(ctx, task)
+	}
+
+	// If new (or waiting for its Agent), initialize status and create Job
+	if task.Status.Phase == "" || task.Status.Phase == kubetaskv1alpha1.TaskPhasePending {
 		return r.initializeTask(ctx, task)
 	}
 
-	// If completed/failed, check TTL for cleanup
+	// If completed/failed, check for late Job changes and TTL for cleanup
 	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseCompleted ||
 		task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed {
+		if err := r.reconcileLateJobChanges(ctx, task); err != nil {
+			log.Error(err, "unable to check the Job of a finished Task")
+			return ctrl.Result{}, err
+		}
+		if err := r.recordResult(ctx, task); err != nil {
+			log.Error(err, "unable to record the result of a finished Task")
+			return ctrl.Result{}, err
+		}
+		if err := r.removeExpose(ctx, task); err != nil {
+			log.Error(err, "unable to remove the web UI of a finished Task")
+			return ctrl.Result{}, err
+		}
 		return r.handleTaskCleanup(ctx, task)
 	}
 
+	// Recreate the Job of a Task whose previous attempt failed, once its backoff passed
+	if task.Status.NextRetryTime != nil {
+		return r.retryTask(ctx, task)
+	}
+
+	// Suspend or resume the Job as spec.suspend requires
+	if err := r.reconcileSuspend(ctx, task); err != nil {
+		log.Error(err, "unable to suspend or resume Job")
+		return ctrl.Result{}, err
+	}
+
 	// Update task status from Job status
 	if err := r.updateTaskStatusFromJob(ctx, task); err != nil {
 		log.Error(err, "unable to update task status")
 		return ctrl.Result{}, err
 	}
 
+	// Warn about or fail Tasks whose Context sources changed after resolution
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseRunning && len(task.Status.ContextSources) > 0 {
+		if err := r.checkContextSources(ctx, task); err != nil {
+			log.Error(err, "unable to check Task context sources")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Push updated Context content to human-in-the-loop sessions
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseRunning && build.ContextRefreshEnabled(task) {
+		if err := r.refreshContexts(ctx, task); err != nil {
+			log.Error(err, "unable to refresh Task contexts")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: ContextRefreshInterval}, nil
+	}
+
+	// Image pulls in progress do not update the Pod, so check on them periodically
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseRunning && imagePullPending(task) {
+		return ctrl.Result{RequeueAfter: ImagePullCheckInterval}, nil
+	}
+
+	// Re-sync running Tasks periodically, so they converge even if a Job event was missed
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseRunning && r.RunningTaskResyncInterval > 0 {
+		return ctrl.Result{RequeueAfter: wait.Jitter(r.RunningTaskResyncInterval, 0.1)}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -99,12 +264,87 @@ func (r *TaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 func (r *TaskReconciler) initializeTask(ctx context.Context, task *kubetaskv1alpha1.Task) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
+	// Hold new Tasks during the namespace's quiet hours
+	if end, quiet := r.quietHoursEnd(ctx, task); quiet {
+		message := fmt.Sprintf("Quiet hours until %s", end.UTC().Format(time.RFC3339))
+		ready := meta.FindStatusCondition(task.Status.Conditions, "Ready")
+		if task.Status.Phase != kubetaskv1alpha1.TaskPhasePending || ready == nil || ready.Message != message {
+			log.Info("holding task during quiet hours", "until", end)
+			task.Status.Phase = kubetaskv1alpha1.TaskPhasePending
+			meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "QuietHours",
+				Message: message,
+			})
+			if err := r.Status().Update(ctx, task); err != nil {
+				log.Error(err, "unable to update Task status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: end.Sub(clockNow(r.Clock))}, nil
+	}
+
+	// Hold suspended Tasks until they are resumed
+	if taskSuspended(task) {
+		changed := setSuspendedCondition(task, true, "Task is suspended and will start when resumed")
+		ready := meta.FindStatusCondition(task.Status.Conditions, "Ready")
+		if changed || task.Status.Phase != kubetaskv1alpha1.TaskPhasePending || ready == nil || ready.Reason != "Suspended" {
+			log.Info("holding suspended task")
+			task.Status.Phase = kubetaskv1alpha1.TaskPhasePending
+			meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "Suspended",
+				Message: "Task is suspended",
+			})
+			if err := r.Status().Update(ctx, task); err != nil {
+				log.Error(err, "unable to update Task status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil // Resuming the Task updates its spec and requeues it
+	}
+	setSuspendedCondition(task, false, "Task resumed")
+
+	// Hold Tasks ordered behind other Tasks until those complete
+	if len(task.Spec.RunAfter) > 0 {
+		if waiting, err := r.waitForRunAfter(ctx, task); waiting || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Get agent configuration
 	agentConfig, err := r.getAgentConfig(ctx, task)
+	if stderrors.Is(err, errAgentPreflightPending) {
+		// Agent is still being validated, keep the Task pending and check again later
+		log.V(1).Info("waiting for Agent pre-flight check", "agent", task.Spec.AgentRef)
+		if task.Status.Phase != kubetaskv1alpha1.TaskPhasePending {
+			task.Status.Phase = kubetaskv1alpha1.TaskPhasePending
+			setAgentResolvedCondition(task, metav1.ConditionUnknown, "AgentPreflightPending", err.Error())
+			meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "AgentPreflightPending",
+				Message: err.Error(),
+			})
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+				return ctrl.Result{}, updateErr
+			}
+		}
+		return ctrl.Result{RequeueAfter: PreflightPendingRequeueInterval}, nil
+	}
+	if transientAPIError(err) {
+		// The API server failed to answer; that says nothing about the Agent
+		log.Error(err, "unable to get Agent configuration, retrying")
+		return ctrl.Result{}, err
+	}
 	if err != nil {
 		log.Error(err, "unable to get Agent")
 		// Update task status to Failed
 		task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+		setAgentResolvedCondition(task, metav1.ConditionFalse, "AgentError", err.Error())
 		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
 			Type:    "Ready",
 			Status:  metav1.ConditionFalse,
@@ -117,64 +357,214 @@ func (r *TaskReconciler) initializeTask(ctx context.Context, task *kubetaskv1alp
 		}
 		return ctrl.Result{}, nil // Don't requeue, user needs to fix Agent
 	}
+	setAgentResolvedCondition(task, metav1.ConditionTrue, "AgentResolved", fmt.Sprintf("Agent %s resolved", taskAgentName(task)))
 
 	// Generate Job name
-	jobName := fmt.Sprintf("%s-job", task.Name)
+	jobName := retryJobName(task.Name, 1)
 
-	// Check if Job already exists
+	// A previous reconcile may have created the Job but failed to update the status
 	existingJob := &batchv1.Job{}
 	jobKey := types.NamespacedName{Name: jobName, Namespace: task.Namespace}
 	if err := r.Get(ctx, jobKey, existingJob); err == nil {
-		// Job already exists, update status
-		task.Status.JobName = jobName
-		task.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
-		now := metav1.Now()
-		task.Status.StartTime = &now
-		return ctrl.Result{}, r.Status().Update(ctx, task)
+		if err := checkJobOwner(task, existingJob); err != nil {
+			log.Error(err, "unable to start Task")
+			return ctrl.Result{}, err
+		}
+		task.Status.ContextConfigMap = build.JobContextConfigMap(existingJob)
+		return ctrl.Result{}, r.markTaskStarted(ctx, task, jobName, agentConfig)
+	} else if !errors.IsNotFound(err) {
+		log.Error(err, "unable to get Job", "job", jobName)
+		return ctrl.Result{}, err
+	}
+
+	// Space out the objects of Tasks starting at once
+	if delay := r.jobCreationDelay(ctx, task); delay > 0 {
+		return ctrl.Result{RequeueAfter: delay}, nil
 	}
 
 	// Process all contexts using priority-based resolution
 	// Priority (lowest to highest):
-	//   1. Agent.contexts (Agent-level Context CRD references)
-	//   2. Task.contexts (Task-specific Context CRD references)
-	//   3. Task.description (highest, becomes start of ${WORKSPACE_DIR}/task.md)
-	contextConfigMap, fileMounts, dirMounts, gitMounts, err := r.processAllContexts(ctx, task, agentConfig)
+	//   1. KubeTaskConfig.defaultContexts (namespace-wide Context CRD references)
+	//   2. Agent.contexts (Agent-level Context CRD references)
+	//   3. Task.contexts (Task-specific Context CRD references)
+	//   4. Task.description (highest, becomes start of ${WORKSPACE_DIR}/task.md)
+	contextConfigMap, fileMounts, dirMounts, gitMounts, initMounts, contextErr := r.processAllContexts(ctx, task, agentConfig)
+	credentials, skippedCredentials, credentialErr := r.validateCredentials(ctx, task, agentConfig)
+	if err := stderrors.Join(contextErr, credentialErr); err != nil {
+		log.Error(err, "unable to resolve contexts and credentials")
+		// Report every broken context and credential at once, then retry
+		if setResolutionErrorConditions(task, contextErr, credentialErr) {
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+			}
+		}
+		return ctrl.Result{}, err
+	}
+	// Optional credentials that are missing are left out of the Job
+	agentConfig.Credentials = credentials
+	setCredentialsResolvedCondition(task, len(credentials), skippedCredentials)
+
+	// Fail Tasks that would outlive their credentials instead of failing partway through
+	if agentConfig.credentialMinValidity != nil {
+		message, err := r.checkCredentialExpiry(ctx, task, agentConfig, clockNow(r.Clock))
+		if err != nil {
+			log.Error(err, "unable to check credential expiry")
+			return ctrl.Result{}, err
+		}
+		if message != "" {
+			log.Info("credentials expire too soon", "reason", message)
+			task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+			meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "CredentialExpiring",
+				Message: message,
+			})
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{}, nil // Don't requeue, the credential needs to be rotated
+		}
+	}
+
+	// Record the sources of Contexts with a freshness policy and check their age
+	sources, violations, err := r.contextSources(ctx, task, agentConfig)
 	if err != nil {
-		log.Error(err, "unable to process contexts")
+		log.Error(err, "unable to resolve context sources")
 		return ctrl.Result{}, err
 	}
+	task.Status.ContextSources = sources
+	if len(sources) > 0 {
+		if _, fail := setContextsFreshCondition(task, violations); fail {
+			condition := meta.FindStatusCondition(task.Status.Conditions, ContextsFreshConditionType)
+			log.Info("context sources are stale", "reason", condition.Reason)
+			task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+			meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "ContextStale",
+				Message: condition.Message,
+			})
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+				return ctrl.Result{}, updateErr
+			}
+			return ctrl.Result{}, nil // Don't requeue, the source needs to be updated
+		}
+	}
 
 	// Create ConfigMap if there's aggregated content
 	if contextConfigMap != nil {
-		if err := r.Create(ctx, contextConfigMap); err != nil {
-			if !errors.IsAlreadyExists(err) {
-				log.Error(err, "unable to create context ConfigMap")
-				return ctrl.Result{}, err
+		if err := r.applyContextConfigMap(ctx, task, contextConfigMap); err != nil {
+			if result, ok := retryThrottled(ctx, task, err); ok {
+				return result, nil
+			}
+			log.Error(err, "unable to apply context ConfigMap")
+			return ctrl.Result{}, err
+		}
+		task.Status.ContextConfigMap = contextConfigMap.Name
+	}
+
+	// Create the token the agent authenticates to the result API with
+	if r.ResultAPIURL != "" {
+		if err := r.createResultTokenSecret(ctx, task); err != nil {
+			log.Error(err, "unable to create result token Secret")
+			return ctrl.Result{}, err
+		}
+		agentConfig.ResultAPIURL = r.ResultAPIURL
+	}
+
+	// Isolate the agent Pod as its security profile requires
+	if policy := buildNetworkPolicy(task, agentConfig.SecurityProfile); policy != nil {
+		allowMetricsIngress(policy, agentConfig.Monitoring)
+		if build.ExposeEnabled(task, agentConfig.Expose) {
+			allowUIIngress(policy, agentConfig.Expose)
+		}
+		if err := r.applyNetworkPolicy(ctx, task, policy); err != nil {
+			if result, ok := retryThrottled(ctx, task, err); ok {
+				return result, nil
+			}
+			log.Error(err, "unable to apply NetworkPolicy")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Publish the web UI the agent serves during human-in-the-loop sessions
+	if build.ExposeEnabled(task, agentConfig.Expose) {
+		if err := r.applyExpose(ctx, task, agentConfig.Expose); err != nil {
+			if result, ok := retryThrottled(ctx, task, err); ok {
+				return result, nil
 			}
+			log.Error(err, "unable to expose agent web UI")
+			return ctrl.Result{}, err
 		}
 	}
 
-	// Create Job with agent configuration and context mounts
-	job := buildJob(task, jobName, agentConfig, contextConfigMap, fileMounts, dirMounts, gitMounts)
+	// Create Job with agent configuration and context mounts, patched with the
+	// Agent's job template overrides
+	initMounts.apply(&agentConfig.Config)
+	job := build.Job(task, jobName, agentConfig.Config, contextConfigMap, fileMounts, dirMounts, gitMounts)
+	reason := "InvalidJobTemplateOverrides"
+	buildErr := build.ApplyJobTemplateOverrides(job, agentConfig.Config)
+	if buildErr == nil {
+		// Let platform hooks adjust the Job, retrying while a required hook fails
+		reason = "JobMutationFailed"
+		buildErr = r.mutateJob(ctx, task, job, agentConfig.jobMutationWebhooks)
+	}
+	if err := buildErr; err != nil {
+		log.Error(err, "unable to build Job", "job", jobName, "reason", reason)
+		changed := setJobCreatedCondition(task, jobName, err, reason)
+		if meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: err.Error(),
+		}) || changed {
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+			}
+		}
+		return ctrl.Result{}, err
+	}
 
-	if err := r.Create(ctx, job); err != nil {
+	if err := r.createJob(ctx, task, job); err != nil {
+		if result, ok := retryThrottled(ctx, task, err); ok {
+			return result, nil
+		}
 		log.Error(err, "unable to create Job", "job", jobName)
+		if setJobCreatedCondition(task, jobName, err, "JobCreateFailed") {
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+			}
+		}
 		return ctrl.Result{}, err
 	}
+	setCredentialsMountedCondition(task, agentConfig.Credentials)
+
+	return ctrl.Result{}, r.markTaskStarted(ctx, task, jobName, agentConfig)
+}
+
+// markTaskStarted records that the Task's Job was created
+func (r *TaskReconciler) markTaskStarted(ctx context.Context, task *kubetaskv1alpha1.Task, jobName string, agentConfig agentConfig) error {
+	log := log.FromContext(ctx)
 
-	// Update status
 	task.Status.JobName = jobName
 	task.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
-	now := metav1.Now()
+	task.Status.Attempts = 1
+	setJobCreatedCondition(task, jobName, nil, "")
+	now := metav1.NewTime(clockNow(r.Clock))
 	task.Status.StartTime = &now
 
 	if err := r.Status().Update(ctx, task); err != nil {
 		log.Error(err, "unable to update Task status")
-		return ctrl.Result{}, err
+		return err
 	}
 
-	log.Info("initialized Task", "job", jobName, "image", agentConfig.agentImage)
-	return ctrl.Result{}, nil
+	observeTaskTimings(&kubetaskv1alpha1.TaskExecutionStatus{}, task)
+	log.Info("initialized Task", "job", jobName, "image", agentConfig.AgentImage)
+	audit.Record(audit.ForTask(task, audit.ActionStarted, audit.ControllerActor))
+	return nil
 }
 
 // updateTaskStatusFromJob syncs task status from Job status
@@ -196,30 +586,188 @@ func (r *TaskReconciler) updateTaskStatusFromJob(ctx context.Context, task *kube
 		return err
 	}
 
+	// Surface the agent Pod state (scheduling, image pulls, OOM kills) on the Task
+	pod, err := r.getAgentPod(ctx, task)
+	if err != nil {
+		return err
+	}
+	prev := task.Status.DeepCopy()
+	podCondition := agentPodCondition(pod)
+	podChanged := meta.SetStatusCondition(&task.Status.Conditions, podCondition)
+	if pod != nil && task.Status.PodName != pod.Name {
+		task.Status.PodName = pod.Name
+		podChanged = true
+	}
+	if recordPodTimings(task, pod) {
+		podChanged = true
+	}
+	if recordAgentImage(task, pod) {
+		podChanged = true
+	}
+	pullChanged, err := r.reconcileImagePull(ctx, task, pod)
+	if err != nil {
+		return err
+	}
+	podChanged = podChanged || pullChanged
+
 	// Check Job completion
-	if job.Status.Succeeded > 0 {
+	outcome := jobOutcomeOf(job)
+	if outcome.conflict != "" {
+		setJobOutcomeConflict(task, "ConflictingJobConditions", outcome.conflict)
+	}
+	if outcome.phase == kubetaskv1alpha1.TaskPhaseCompleted {
 		task.Status.Phase = kubetaskv1alpha1.TaskPhaseCompleted
-		now := metav1.Now()
+		now := metav1.NewTime(clockNow(r.Clock))
 		task.Status.CompletionTime = &now
+		task.Status.Changes = MergeTaskChanges(task.Status.Changes, taskChanges(parseTrailers(agentTerminationMessage(pod))))
+		if result := taskResult(pod); result != nil {
+			task.Status.Result = result
+		}
+		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionTrue,
+			Reason:  "JobSucceeded",
+			Message: fmt.Sprintf("Job %s succeeded", task.Status.JobName),
+		})
 		log.Info("task completed", "job", task.Status.JobName)
-		return r.Status().Update(ctx, task)
-	} else if job.Status.Failed > 0 {
+		if err := r.Status().Update(ctx, task); err != nil {
+			return err
+		}
+		observeTaskTimings(prev, task)
+		audit.Record(audit.ForTask(task, audit.ActionCompleted, audit.ControllerActor))
+		return nil
+	} else if outcome.phase == kubetaskv1alpha1.TaskPhaseFailed {
+		if retrying, err := r.scheduleRetry(ctx, task, job, pod, podCondition); retrying || err != nil {
+			return err
+		}
 		task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
-		now := metav1.Now()
+		now := metav1.NewTime(clockNow(r.Clock))
 		task.Status.CompletionTime = &now
-		log.Info("task failed", "job", task.Status.JobName)
-		return r.Status().Update(ctx, task)
+		reason := "JobFailed"
+		message := fmt.Sprintf("Job %s failed: %s", task.Status.JobName, podCondition.Message)
+		if jobDeadlineExceeded(job) {
+			reason = "DeadlineExceeded"
+			message = fmt.Sprintf("Job %s exceeded the timeout of %s", task.Status.JobName, jobTimeout(job))
+		}
+		if task.Status.Attempts > 1 {
+			message += fmt.Sprintf(" (after %d attempts)", task.Status.Attempts)
+		}
+		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		})
+		task.Status.Changes = MergeTaskChanges(task.Status.Changes, taskChanges(parseTrailers(agentTerminationMessage(pod))))
+		if result := taskResult(pod); result != nil {
+			task.Status.Result = result
+		}
+		task.Status.FailureArtifacts = failureArtifacts(job, pod)
+		log.Info("task failed", "job", task.Status.JobName, "reason", podCondition.Reason, "artifacts", len(task.Status.FailureArtifacts))
+		if err := r.Status().Update(ctx, task); err != nil {
+			return err
+		}
+		observeTaskTimings(prev, task)
+		audit.Record(audit.ForTask(task, audit.ActionFailed, audit.ControllerActor))
+		return nil
 	}
 
+	if podChanged {
+		log.V(1).Info("agent Pod state changed", "pod", task.Status.PodName, "reason", podCondition.Reason)
+		if err := r.Status().Update(ctx, task); err != nil {
+			return err
+		}
+		observeTaskTimings(prev, task)
+	}
+	return nil
+}
+
+// taskTimeoutSeconds returns the timeout of a Task: its own, else the default of the
+// namespace's KubeTaskConfig, if any
+func taskTimeoutSeconds(task *kubetaskv1alpha1.Task, config *kubetaskv1alpha1.KubeTaskConfig) *int64 {
+	if task.Spec.TimeoutSeconds != nil {
+		return task.Spec.TimeoutSeconds
+	}
+	if config != nil && config.Spec.TaskLifecycle != nil {
+		return config.Spec.TaskLifecycle.DefaultTimeoutSeconds
+	}
 	return nil
 }
 
-// handleTaskCleanup checks if a completed/failed task should be deleted based on TTL
+// jobDeadlineExceeded reports whether the Job failed because it ran past its
+// activeDeadlineSeconds, i.e. the Task's timeout
+func jobDeadlineExceeded(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue && c.Reason == batchv1.JobReasonDeadlineExceeded {
+			return true
+		}
+	}
+	return false
+}
+
+// jobTimeout returns the activeDeadlineSeconds of the Job as a duration
+func jobTimeout(job *batchv1.Job) time.Duration {
+	if job.Spec.ActiveDeadlineSeconds == nil {
+		return 0
+	}
+	return time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+}
+
+// isJobFailed reports whether the Job has a Failed condition.
+// With restartPolicy OnFailure, container restarts happen in place and the Job
+// may be marked Failed (backoff limit exceeded) before status.failed is updated.
+func isJobFailed(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// recordResult stores the outcome of a finished Task in the results store, if any.
+// The store skips records that did not change, so this runs on every reconcile of a
+// finished Task and picks up late changes to its status.
+func (r *TaskReconciler) recordResult(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	if r.Results == nil {
+		return nil
+	}
+	record, ok := results.FromTask(task, taskAgentName(task))
+	if !ok {
+		return nil
+	}
+	return r.Results.Put(ctx, record)
+}
+
+// handleTaskCleanup checks if a completed/failed task should be deleted based on TTL,
+// and applies the history limits of its Agent
 func (r *TaskReconciler) handleTaskCleanup(ctx context.Context, task *kubetaskv1alpha1.Task) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
+	// Delete the oldest finished Tasks of the Agent beyond its history limits,
+	// unless this one is already being deleted
+	retention, err := r.agentRetention(ctx, task)
+	if err != nil {
+		log.Error(err, "unable to get Agent retention")
+		return ctrl.Result{}, err
+	}
+	if task.DeletionTimestamp.IsZero() {
+		deleted, err := r.enforceAgentHistoryLimits(ctx, task, retention)
+		if err != nil {
+			log.Error(err, "unable to enforce the history limits of the Agent")
+			return ctrl.Result{}, err
+		}
+		if deleted {
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// Get TTL configuration
-	ttlSeconds := r.getTTLSecondsAfterFinished(ctx, task.Namespace)
+	var agentTTL *int32
+	if retention != nil {
+		agentTTL = retention.TTLSecondsAfterFinished
+	}
+	ttlSeconds := r.getTTLSecondsAfterFinished(ctx, task, agentTTL)
 
 	// TTL of 0 means no automatic cleanup
 	if ttlSeconds == 0 {
@@ -231,68 +779,133 @@ func (r *TaskReconciler) handleTaskCleanup(ctx context.Context, task *kubetaskv1
 		return ctrl.Result{}, nil
 	}
 
+	// A Task that is already being deleted, e.g. one whose deletion is held by a
+	// finalizer, is neither deleted again nor requeued. Removing the finalizer
+	// updates the Task, which triggers a reconcile if anything is left to do.
+	if !task.DeletionTimestamp.IsZero() {
+		log.V(1).Info("task is being deleted, skipping TTL cleanup", "task", task.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// A completion time in the future was written by a controller whose clock was
+	// ahead. Reset it to now, so the skew delays cleanup by at most one TTL.
+	now := clockNow(r.Clock)
+	if task.Status.CompletionTime.Time.After(now.Add(maxCompletionTimeSkew)) {
+		log.Info("completion time is in the future, resetting it to now", "task", task.Name, "completionTime", task.Status.CompletionTime.Time)
+		task.Status.CompletionTime = &metav1.Time{Time: now}
+		if err := r.Status().Update(ctx, task); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Calculate time since completion
 	completionTime := task.Status.CompletionTime.Time
 	ttlDuration := time.Duration(ttlSeconds) * time.Second
-	expirationTime := completionTime.Add(ttlDuration)
-	now := time.Now()
+	expiresIn := completionTime.Add(ttlDuration).Sub(now)
 
-	if now.After(expirationTime) {
-		// Task has expired, delete it
+	if expiresIn <= 0 {
+		// Task has expired, delete it. The UID precondition keeps a Task recreated
+		// under the same name from being deleted in its place.
 		log.Info("deleting expired task", "task", task.Name, "completedAt", completionTime, "ttl", ttlSeconds)
-		if err := r.Delete(ctx, task); err != nil {
-			if !errors.IsNotFound(err) {
-				log.Error(err, "unable to delete expired task")
-				return ctrl.Result{}, err
+		if err := r.Delete(ctx, task, client.Preconditions{UID: &task.UID}); err != nil {
+			if errors.IsNotFound(err) || errors.IsConflict(err) {
+				return ctrl.Result{}, nil
 			}
+			log.Error(err, "unable to delete expired task")
+			taskCleanupDeletions.WithLabelValues(task.Namespace, cleanupReasonTTL, cleanupResult(err)).Inc()
+			return ctrl.Result{}, err
 		}
+		taskCleanupDeletions.WithLabelValues(task.Namespace, cleanupReasonTTL, cleanupResult(nil)).Inc()
+		taskTTLDeletionDelay.WithLabelValues(task.Namespace).Observe(-expiresIn.Seconds())
+		audit.Record(audit.ForTask(task, audit.ActionExpired, audit.ControllerActor))
 		return ctrl.Result{}, nil
 	}
 
 	// Task not yet expired, requeue to check again at expiration time
-	requeueAfter := expirationTime.Sub(now)
-	log.V(1).Info("task not yet expired, requeueing", "task", task.Name, "requeueAfter", requeueAfter)
-	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	log.V(1).Info("task not yet expired, requeueing", "task", task.Name, "requeueAfter", expiresIn)
+	return ctrl.Result{RequeueAfter: expiresIn}, nil
 }
 
-// getTTLSecondsAfterFinished retrieves the TTL configuration from KubeTaskConfig.
-// It looks for config in the following order:
-// 1. KubeTaskConfig named "default" in the task's namespace
-// 2. Built-in default (7 days)
-func (r *TaskReconciler) getTTLSecondsAfterFinished(ctx context.Context, namespace string) int32 {
+// getTTLSecondsAfterFinished retrieves the TTL for a Task from KubeTaskConfig and
+// its Agent. It looks for config in the following order:
+// 1. First rule in the "default" KubeTaskConfig whose selector matches the Task labels
+// 2. agentTTL, the retention.ttlSecondsAfterFinished of the Task's Agent
+// 3. TTLSecondsAfterFinished of the "default" KubeTaskConfig in the task's namespace
+// 4. Built-in default (7 days)
+func (r *TaskReconciler) getTTLSecondsAfterFinished(ctx context.Context, task *kubetaskv1alpha1.Task, agentTTL *int32) int32 {
 	log := log.FromContext(ctx)
 
-	// Try to get KubeTaskConfig from the task's namespace
-	config := &kubetaskv1alpha1.KubeTaskConfig{}
-	configKey := types.NamespacedName{Name: "default", Namespace: namespace}
-
-	if err := r.Get(ctx, configKey, config); err != nil {
-		if !errors.IsNotFound(err) {
-			log.Error(err, "unable to get KubeTaskConfig, using default TTL")
+	lifecycle, err := r.kubeTaskConfig().taskLifecycle(ctx, task.Namespace)
+	if err != nil {
+		log.Error(err, "unable to get KubeTaskConfig, using default TTL")
+		if agentTTL != nil {
+			return *agentTTL
 		}
-		// Config not found, use built-in default
 		return DefaultTTLSecondsAfterFinished
 	}
 
-	// Config found, extract TTL
-	if config.Spec.TaskLifecycle != nil && config.Spec.TaskLifecycle.TTLSecondsAfterFinished != nil {
-		return *config.Spec.TaskLifecycle.TTLSecondsAfterFinished
+	ttl, err := resolveTTLSecondsAfterFinished(lifecycle, task.Labels, agentTTL)
+	if err != nil {
+		log.Error(err, "invalid TTL rule in KubeTaskConfig, skipping it")
+	}
+	return ttl
+}
+
+// quietHoursEnd reports whether the namespace is in quiet hours, and when they end
+func (r *TaskReconciler) quietHoursEnd(ctx context.Context, task *kubetaskv1alpha1.Task) (time.Time, bool) {
+	log := log.FromContext(ctx)
+
+	lifecycle, err := r.kubeTaskConfig().taskLifecycle(ctx, task.Namespace)
+	if err != nil {
+		log.Error(err, "unable to get KubeTaskConfig, ignoring quiet hours")
+		return time.Time{}, false
 	}
+	end, quiet, err := quietHoursEnd(lifecycle, clockNow(r.Clock))
+	if err != nil {
+		log.Error(err, "invalid quiet hours in KubeTaskConfig, skipping them")
+	}
+	return end, quiet
+}
 
-	return DefaultTTLSecondsAfterFinished
+// kubeTaskConfig returns the KubeTaskConfig accessor, falling back to the client
+// when the reconciler was not set up through SetupWithManager
+func (r *TaskReconciler) kubeTaskConfig() *kubeTaskConfigAccessor {
+	if r.config == nil {
+		return newKubeTaskConfigAccessor(r.Client)
+	}
+	return r.config
 }
 
 // SetupWithManager sets up the controller with the Manager
 func (r *TaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.config = newKubeTaskConfigAccessor(mgr.GetCache())
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &kubetaskv1alpha1.Task{}, taskRunAfterField, taskRunAfterKeys); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kubetaskv1alpha1.Task{}).
 		Owns(&batchv1.Job{}).
+		Owns(&kubetaskv1alpha1.Task{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(podToTask)).
+		Watches(&kubetaskv1alpha1.Task{}, handler.EnqueueRequestsFromMapFunc(taskToRunAfterDependents(mgr.GetCache()))).
+		Watches(&kubetaskv1alpha1.KubeTaskConfig{}, enqueueFinishedTasksForConfig(mgr.GetCache())).
+		Watches(&kubetaskv1alpha1.Context{}, handler.EnqueueRequestsFromMapFunc(contextToRefreshingTasks(mgr.GetCache()))).
+		Watches(&kubetaskv1alpha1.Context{}, handler.EnqueueRequestsFromMapFunc(contextSourceToTasks(mgr.GetCache(), contextSourceKindContext))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(contextSourceToTasks(mgr.GetCache(), contextSourceKindConfigMap))).
 		Complete(r)
 }
 
 // getAgentConfig retrieves the agent configuration from Agent.
 // Returns an error if Agent is not found or invalid.
 func (r *TaskReconciler) getAgentConfig(ctx context.Context, task *kubetaskv1alpha1.Task) (agentConfig, error) {
+	return r.resolveAgentConfig(ctx, task, false)
+}
+
+// resolveAgentConfig resolves the Agent configuration for a Task. skipPreflight
+// is set when rendering Tasks outside the cluster, where Agents have no status.
+func (r *TaskReconciler) resolveAgentConfig(ctx context.Context, task *kubetaskv1alpha1.Task, skipPreflight bool) (agentConfig, error) {
 	log := log.FromContext(ctx)
 
 	// Determine which Agent to use
@@ -310,151 +923,289 @@ func (r *TaskReconciler) getAgentConfig(ctx context.Context, task *kubetaskv1alp
 
 	if err := r.Get(ctx, agentKey, agent); err != nil {
 		log.Error(err, "unable to get Agent", "agent", agentName)
+		if !errors.IsNotFound(err) {
+			return agentConfig{}, fmt.Errorf("unable to get Agent %q: %w", agentName, err)
+		}
 		return agentConfig{}, fmt.Errorf("Agent %q not found in namespace %q: %w", agentName, task.Namespace, err)
 	}
 
+	config, err := r.kubeTaskConfig().get(ctx, task.Namespace)
+	if err != nil {
+		return agentConfig{}, fmt.Errorf("unable to get KubeTaskConfig: %w", err)
+	}
+
+	cfg, err := buildAgentConfig(agent, resolveDefaultAgentImage(config, r.DefaultAgentImage))
+	if err != nil {
+		return agentConfig{}, err
+	}
+	if err := checkRestartPolicy(agent, task); err != nil {
+		return agentConfig{}, err
+	}
+
+	// The Task's resources override the Agent's per resource when the Job is built
+	cfg.Resources = task.Spec.Resources
+	cfg.TimeoutSeconds = taskTimeoutSeconds(task, config)
+
+	// Apply the Task's RuntimeClass override and enforce the namespace's isolation floor
+	runtimeClassName := ""
+	if cfg.PodSpec != nil && cfg.PodSpec.RuntimeClassName != nil {
+		runtimeClassName = *cfg.PodSpec.RuntimeClassName
+	}
+	if task.Spec.RuntimeClassName != nil {
+		cfg.RuntimeClassName = task.Spec.RuntimeClassName
+		runtimeClassName = *task.Spec.RuntimeClassName
+	}
+	if config != nil {
+		if err := enforceRuntimePolicy(config.Spec.RuntimePolicy, runtimeClassName); err != nil {
+			return agentConfig{}, err
+		}
+		if config.Spec.ContextCache != nil {
+			cfg.ContextCacheClaim = config.Spec.ContextCache.ClaimName
+		}
+		if config.Spec.JobMutation != nil {
+			cfg.jobMutationWebhooks = config.Spec.JobMutation.Webhooks
+		}
+		cfg.defaultContexts = config.Spec.DefaultContexts
+	}
+	cfg.Preamble = buildPreamble(r.TaskPreamble, config)
+	cfg.GitSyncImage = r.GitSyncImage
+	cfg.ObjectStoreImage = r.ObjectStoreImage
+	cfg.ORASImage = r.ORASImage
+
+	// Split new Tasks between the stable and new image during a canary rollout
+	specImage := cfg.AgentImage
+	routeCanary(agent, task, &cfg)
+
+	// Hold or fail Tasks based on the Agent's pre-flight check. The check validates
+	// the current spec image, so Tasks routed to the stable image are not held.
+	if cfg.AgentImage == specImage && !skipPreflight {
+		if err := checkAgentPreflight(agent, cfg.AgentImage); err != nil {
+			return agentConfig{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// checkRestartPolicy rejects running a Task with a retryPolicy on an Agent with the
+// OnFailure restartPolicy. The Jobs of such Tasks have a backoffLimit of 0, so the
+// first in-place restart would fail the Job, and the retry policy bounds attempts.
+func checkRestartPolicy(agent *kubetaskv1alpha1.Agent, task *kubetaskv1alpha1.Task) error {
+	if task.Spec.RetryPolicy == nil || agent.Spec.PodSpec == nil || agent.Spec.PodSpec.RestartPolicy == nil {
+		return nil
+	}
+	if *agent.Spec.PodSpec.RestartPolicy == corev1.RestartPolicyOnFailure {
+		return fmt.Errorf("Agent %q has podSpec.restartPolicy OnFailure, which cannot be combined with the Task's retryPolicy: use restartPolicy Never or remove the retryPolicy", agent.Name)
+	}
+	return nil
+}
+
+// buildAgentConfig resolves defaults and validates an Agent's spec.
+// defaultImage is used when the Agent does not set spec.agentImage.
+func buildAgentConfig(agent *kubetaskv1alpha1.Agent, defaultImage string) (agentConfig, error) {
 	// Get agent image (optional, has default)
-	agentImage := DefaultAgentImage
+	agentImage := defaultImage
 	if agent.Spec.AgentImage != "" {
 		agentImage = agent.Spec.AgentImage
 	}
 
 	// Get workspace directory (optional, has default)
+	windows := build.TargetsWindows(agent.Spec.PodSpec)
 	workspaceDir := DefaultWorkspaceDir
+	if windows {
+		workspaceDir = build.DefaultWindowsWorkspaceDir
+	}
 	if agent.Spec.WorkspaceDir != "" {
 		workspaceDir = agent.Spec.WorkspaceDir
 	}
 
 	// ServiceAccountName is required
 	if agent.Spec.ServiceAccountName == "" {
-		return agentConfig{}, fmt.Errorf("Agent %q is missing required field serviceAccountName", agentName)
+		return agentConfig{}, fmt.Errorf("Agent %q is missing required field serviceAccountName", agent.Name)
+	}
+
+	// Job pods only support Never or OnFailure restart policies
+	if agent.Spec.PodSpec != nil && agent.Spec.PodSpec.RestartPolicy != nil {
+		switch *agent.Spec.PodSpec.RestartPolicy {
+		case corev1.RestartPolicyNever, corev1.RestartPolicyOnFailure:
+		default:
+			return agentConfig{}, fmt.Errorf("Agent %q has unsupported podSpec.restartPolicy %q: must be Never or OnFailure",
+				agent.Name, *agent.Spec.PodSpec.RestartPolicy)
+		}
+	}
+
+	// Archiving the workspace requires wrapping the agent command
+	var failureSnapshot *kubetaskv1alpha1.FailureSnapshot
+	if fs := agent.Spec.FailureSnapshot; fs != nil && fs.Enabled {
+		if len(agent.Spec.Command) == 0 {
+			return agentConfig{}, fmt.Errorf("Agent %q enables failureSnapshot but does not specify command", agent.Name)
+		}
+		if fs.ClaimName == "" {
+			return agentConfig{}, fmt.Errorf("Agent %q enables failureSnapshot but does not specify claimName", agent.Name)
+		}
+		failureSnapshot = fs
+	}
+
+	// The wrapper can only delay the signal within the grace period
+	if c := agent.Spec.Cancellation; c != nil && c.SignalDelaySeconds != nil && *c.SignalDelaySeconds > 0 {
+		if len(agent.Spec.Command) == 0 {
+			return agentConfig{}, fmt.Errorf("Agent %q sets cancellation.signalDelaySeconds but does not specify command", agent.Name)
+		}
+		gracePeriod := build.DefaultCancellationGracePeriodSeconds
+		if c.GracePeriodSeconds != nil {
+			gracePeriod = *c.GracePeriodSeconds
+		}
+		if *c.SignalDelaySeconds >= gracePeriod {
+			return agentConfig{}, fmt.Errorf("Agent %q has cancellation.signalDelaySeconds %d, must be less than the grace period of %d seconds",
+				agent.Name, *c.SignalDelaySeconds, gracePeriod)
+		}
+	}
+
+	// Windows images have no sh for the wrappers and init containers these features use
+	if windows {
+		switch {
+		case failureSnapshot != nil:
+			return agentConfig{}, fmt.Errorf("Agent %q targets Windows nodes, which do not support failureSnapshot", agent.Name)
+		case agent.Spec.Cancellation != nil && agent.Spec.Cancellation.SignalDelaySeconds != nil && *agent.Spec.Cancellation.SignalDelaySeconds > 0:
+			return agentConfig{}, fmt.Errorf("Agent %q targets Windows nodes, which do not support cancellation.signalDelaySeconds", agent.Name)
+		case agent.Spec.ContextCompression != nil:
+			return agentConfig{}, fmt.Errorf("Agent %q targets Windows nodes, which do not support contextCompression", agent.Name)
+		}
+	}
+
+	// The agent container shares its name space with the context init containers
+	if name := agent.Spec.ContainerName; strings.HasPrefix(name, "git-sync-") || strings.HasPrefix(name, "git-cache-") ||
+		name == build.ContextDecompressContainerName {
+		return agentConfig{}, fmt.Errorf("Agent %q has reserved containerName %q", agent.Name, name)
+	}
+
+	var credentialMinValidity *time.Duration
+	if policy := agent.Spec.CredentialExpiry; policy != nil {
+		var minValidity time.Duration
+		switch {
+		case policy.MinValidity != nil:
+			minValidity = policy.MinValidity.Duration
+		case agent.Spec.StuckDetection != nil && agent.Spec.StuckDetection.MaxDuration != nil:
+			minValidity = agent.Spec.StuckDetection.MaxDuration.Duration
+		}
+		credentialMinValidity = &minValidity
+	}
+
+	var contextCompressionMinSize int
+	if compression := agent.Spec.ContextCompression; compression != nil {
+		contextCompressionMinSize = build.DefaultContextCompressionMinSize
+		if compression.MinSizeBytes != nil {
+			// A minimum of 0 compresses every file
+			contextCompressionMinSize = max(int(*compression.MinSizeBytes), 1)
+		}
+	}
+
+	var monitoring *kubetaskv1alpha1.AgentMonitoring
+	if agent.Spec.Monitoring != nil && agent.Spec.Monitoring.Enabled {
+		monitoring = agent.Spec.Monitoring
+	}
+	var jobTemplateOverrides []byte
+	if agent.Spec.JobTemplateOverrides != nil {
+		jobTemplateOverrides = agent.Spec.JobTemplateOverrides.Raw
 	}
 
 	return agentConfig{
-		agentImage:         agentImage,
-		command:            agent.Spec.Command,
-		workspaceDir:       workspaceDir,
-		contexts:           agent.Spec.Contexts,
-		credentials:        agent.Spec.Credentials,
-		podSpec:            agent.Spec.PodSpec,
-		serviceAccountName: agent.Spec.ServiceAccountName,
+		Config: build.Config{
+			AgentImage:         agentImage,
+			ContainerName:      agent.Spec.ContainerName,
+			ResourceRequests:   sizedRequests(agent),
+			Command:            agent.Spec.Command,
+			WorkspaceDir:       workspaceDir,
+			Credentials:        agent.Spec.Credentials,
+			EnvFrom:            agent.Spec.EnvFrom,
+			PodSpec:            agent.Spec.PodSpec,
+			ServiceAccountName: agent.Spec.ServiceAccountName,
+			AgentName:          agent.Name,
+			FailureSnapshot:    failureSnapshot,
+			Cancellation:       agent.Spec.Cancellation,
+
+			SecurityProfile: agent.Spec.SecurityProfile,
+			MountToken:      agent.Spec.RBAC != nil,
+
+			Monitoring:           monitoring,
+			Expose:               agent.Spec.Expose,
+			JobTemplateOverrides: jobTemplateOverrides,
+
+			ContextCompressionMinSize: contextCompressionMinSize,
+		},
+		contexts: agent.Spec.Contexts,
+
+		credentialMinValidity: credentialMinValidity,
 	}, nil
 }
 
-// processAllContexts processes all contexts from Agent and Task, resolving Context CRs
-// and returning the ConfigMap, file mounts, directory mounts, and git mounts for the Job.
+// processAllContexts processes all contexts from the namespace, Agent and Task, resolving
+// Context CRs and returning the ConfigMap, file mounts, directory mounts, and git mounts
+// for the Job.
 //
 // Content order in task.md (top to bottom):
 //  1. Task.description (appears first in task.md)
-//  2. Agent.contexts (Agent-level Context CRD references)
-//  3. Task.contexts (Task-specific Context CRD references, appears last)
-func (r *TaskReconciler) processAllContexts(ctx context.Context, task *kubetaskv1alpha1.Task, cfg agentConfig) (*corev1.ConfigMap, []fileMount, []dirMount, []gitMount, error) {
-	var resolved []resolvedContext
-	var dirMounts []dirMount
-	var gitMounts []gitMount
-
-	// 1. Resolve Agent.contexts (appears after description in task.md)
-	for _, ref := range cfg.contexts {
-		rc, dm, gm, err := r.resolveContextRef(ctx, ref, task.Namespace, cfg.workspaceDir)
-		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("failed to resolve Agent context %q: %w", ref.Name, err)
-		}
-		if dm != nil {
-			dirMounts = append(dirMounts, *dm)
-		} else if gm != nil {
-			gitMounts = append(gitMounts, *gm)
-		} else if rc != nil {
-			resolved = append(resolved, *rc)
-		}
+//  2. KubeTaskConfig.defaultContexts (namespace-wide Context CRD references)
+//  3. Agent.contexts (Agent-level Context CRD references)
+//  4. Task.contexts (Task-specific Context CRD references, appears last)
+//
+// Task.files are not part of task.md; each becomes a file of its own in the workspace.
+//
+// The outcome is recorded in the Task's ContextsResolved condition, including optional
+// contexts that could not be resolved and were skipped, and whether task.md has any
+// content in its PromptProvided condition.
+func (r *TaskReconciler) processAllContexts(ctx context.Context, task *kubetaskv1alpha1.Task, cfg agentConfig) (*corev1.ConfigMap, []build.FileMount, []build.DirMount, []build.GitMount, initContainerMounts, error) {
+	var resolved []build.ResolvedContext
+	var dirMounts []build.DirMount
+	var gitMounts []build.GitMount
+	var initMounts initContainerMounts
+
+	// 1. Resolve KubeTaskConfig.defaultContexts and Agent.contexts (appear after
+	// description in task.md) and 2. Task.contexts (appear last in task.md)
+	// concurrently, keeping their order
+	results, skipped, err := r.resolveContextRefs(ctx, task, cfg)
+	if err != nil {
+		return nil, nil, nil, nil, initContainerMounts{}, err
 	}
-
-	// 2. Resolve Task.contexts (appears last in task.md)
-	for _, ref := range task.Spec.Contexts {
-		rc, dm, gm, err := r.resolveContextRef(ctx, ref, task.Namespace, cfg.workspaceDir)
-		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("failed to resolve Task context %q: %w", ref.Name, err)
-		}
-		if dm != nil {
-			dirMounts = append(dirMounts, *dm)
-		} else if gm != nil {
-			gitMounts = append(gitMounts, *gm)
-		} else if rc != nil {
-			resolved = append(resolved, *rc)
+	setContextsResolvedCondition(task, skipped)
+	for _, result := range results {
+		if result.dm != nil {
+			dirMounts = append(dirMounts, *result.dm)
+		} else if result.gm != nil {
+			gitMounts = append(gitMounts, *result.gm)
+		} else if result.om != nil {
+			initMounts.objectStore = append(initMounts.objectStore, *result.om)
+		} else if result.oci != nil {
+			initMounts.oci = append(initMounts.oci, *result.oci)
+		} else if result.rc != nil {
+			resolved = append(resolved, *result.rc)
 		}
 	}
 
 	// 3. Handle Task.description (highest priority, becomes ${WORKSPACE_DIR}/task.md)
-	var taskDescription string
-	if task.Spec.Description != nil && *task.Spec.Description != "" {
-		taskDescription = *task.Spec.Description
-	}
-
-	// Build the final content
-	// - Separate contexts with mountPath (independent files)
-	// - Contexts without mountPath are appended to task.md with XML tags
-	configMapData := make(map[string]string)
-	var fileMounts []fileMount
-
-	// Build task.md content: description + contexts without mountPath
-	var taskMdParts []string
-	if taskDescription != "" {
-		taskMdParts = append(taskMdParts, taskDescription)
-	}
-
-	for _, rc := range resolved {
-		if rc.mountPath != "" {
-			// Context has explicit mountPath - create separate file
-			configMapKey := sanitizeConfigMapKey(rc.mountPath)
-			configMapData[configMapKey] = rc.content
-			fileMounts = append(fileMounts, fileMount{filePath: rc.mountPath})
-		} else {
-			// No mountPath - append to task.md with XML tags
-			xmlTag := fmt.Sprintf("<context name=%q namespace=%q type=%q>\n%s\n</context>",
-				rc.name, rc.namespace, rc.ctxType, rc.content)
-			taskMdParts = append(taskMdParts, xmlTag)
-		}
-	}
-
-	// Create task.md if there's any content
-	// Mount at the configured workspace directory
-	taskMdPath := cfg.workspaceDir + "/task.md"
-	if len(taskMdParts) > 0 {
-		taskMdContent := strings.Join(taskMdParts, "\n\n")
-		configMapData["workspace-task.md"] = taskMdContent
-		fileMounts = append(fileMounts, fileMount{filePath: taskMdPath})
-	}
-
-	// Create ConfigMap if there's any content
-	var configMap *corev1.ConfigMap
-	if len(configMapData) > 0 {
-		configMapName := task.Name + ContextConfigMapSuffix
-		configMap = &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      configMapName,
-				Namespace: task.Namespace,
-				Labels: map[string]string{
-					"app":              "kubetask",
-					"kubetask.io/task": task.Name,
-				},
-				OwnerReferences: []metav1.OwnerReference{
-					{
-						APIVersion: task.APIVersion,
-						Kind:       task.Kind,
-						Name:       task.Name,
-						UID:        task.UID,
-						Controller: boolPtr(true),
-					},
-				},
-			},
-			Data: configMapData,
-		}
-	}
-
-	return configMap, fileMounts, dirMounts, gitMounts, nil
+	taskDescription, err := r.resolveDescription(ctx, task)
+	if err != nil {
+		return nil, nil, nil, nil, initContainerMounts{}, err
+	}
+
+	// 4. Add Task.files, which are written next to task.md
+	files, err := resolveWorkspaceFiles(task, taskDescription, cfg.WorkspaceDir, resolved)
+	if err != nil {
+		return nil, nil, nil, nil, initContainerMounts{}, err
+	}
+	resolved = append(resolved, files...)
+	r.scanContexts(ctx, task, taskDescription, resolved)
+	setPromptProvidedCondition(task, taskDescription, resolved)
+
+	configMap, fileMounts, err := build.ContextConfigMap(task, cfg.Config, taskDescription, resolved)
+	if err != nil {
+		return nil, nil, nil, nil, initContainerMounts{}, err
+	}
+	return configMap, fileMounts, dirMounts, gitMounts, initMounts, nil
 }
 
 // resolveContextRef resolves a ContextMount reference to a Context CR
-func (r *TaskReconciler) resolveContextRef(ctx context.Context, ref kubetaskv1alpha1.ContextMount, defaultNS, workspaceDir string) (*resolvedContext, *dirMount, *gitMount, error) {
+func (r *TaskReconciler) resolveContextRef(ctx context.Context, ref kubetaskv1alpha1.ContextMount, defaultNS, workspaceDir string) (contextRefResult, error) {
 	namespace := ref.Namespace
 	if namespace == "" {
 		namespace = defaultNS
@@ -463,114 +1214,35 @@ func (r *TaskReconciler) resolveContextRef(ctx context.Context, ref kubetaskv1al
 	// Fetch the Context CR
 	contextCR := &kubetaskv1alpha1.Context{}
 	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, contextCR); err != nil {
-		return nil, nil, nil, fmt.Errorf("Context %q not found in namespace %q: %w", ref.Name, namespace, err)
+		return contextRefResult{}, fmt.Errorf("Context %q not found in namespace %q: %w", ref.Name, namespace, err)
 	}
 
-	// Resolve content based on context type
-	content, dm, gm, err := r.resolveContextSpec(ctx, namespace, ref.Name, workspaceDir, &contextCR.Spec, ref.MountPath)
+	// Resolve content with the resolver registered for the context type
+	resolver, err := r.contextResolver(contextCR.Spec.Type)
 	if err != nil {
-		return nil, nil, nil, err
+		return contextRefResult{}, err
 	}
-
-	if dm != nil {
-		return nil, dm, nil, nil
+	resolution, err := resolver.ResolveContext(ctx, ContextResolveRequest{
+		Client:       r.Client,
+		Context:      contextCR,
+		MountPath:    ref.MountPath,
+		WorkspaceDir: workspaceDir,
+	})
+	if err != nil {
+		return contextRefResult{}, err
 	}
 
-	if gm != nil {
-		return nil, nil, gm, nil
+	if resolution.dir != nil || resolution.git != nil || resolution.objectStore != nil || resolution.oci != nil {
+		return contextRefResult{dm: resolution.dir, gm: resolution.git, om: resolution.objectStore, oci: resolution.oci}, nil
 	}
 
-	return &resolvedContext{
-		name:      ref.Name,
-		namespace: namespace,
-		ctxType:   string(contextCR.Spec.Type),
-		content:   content,
-		mountPath: ref.MountPath,
-	}, nil, nil, nil
-}
-
-// resolveContextSpec resolves content from a ContextSpec (used by Context CRD)
-// Returns: content string, dirMount pointer, gitMount pointer, error
-func (r *TaskReconciler) resolveContextSpec(ctx context.Context, namespace, name, workspaceDir string, spec *kubetaskv1alpha1.ContextSpec, mountPath string) (string, *dirMount, *gitMount, error) {
-	switch spec.Type {
-	case kubetaskv1alpha1.ContextTypeInline:
-		if spec.Inline == nil {
-			return "", nil, nil, nil
-		}
-		return spec.Inline.Content, nil, nil, nil
-
-	case kubetaskv1alpha1.ContextTypeConfigMap:
-		if spec.ConfigMap == nil {
-			return "", nil, nil, nil
-		}
-		cm := spec.ConfigMap
-
-		// If Key is specified, return the content
-		if cm.Key != "" {
-			content, err := r.getConfigMapKey(ctx, namespace, cm.Name, cm.Key, cm.Optional)
-			return content, nil, nil, err
-		}
-
-		// If Key is not specified but mountPath is, return a directory mount
-		if mountPath != "" {
-			optional := false
-			if cm.Optional != nil {
-				optional = *cm.Optional
-			}
-			return "", &dirMount{
-				dirPath:       mountPath,
-				configMapName: cm.Name,
-				optional:      optional,
-			}, nil, nil
-		}
-
-		// If Key is not specified and mountPath is empty, aggregate all keys to task.md
-		content, err := r.getConfigMapAllKeys(ctx, namespace, cm.Name, cm.Optional)
-		return content, nil, nil, err
-
-	case kubetaskv1alpha1.ContextTypeGit:
-		if spec.Git == nil {
-			return "", nil, nil, nil
-		}
-		git := spec.Git
-
-		// Determine mount path: use specified path or default to ${WORKSPACE_DIR}/git-<context-name>/
-		resolvedMountPath := mountPath
-		if resolvedMountPath == "" {
-			resolvedMountPath = workspaceDir + "/git-" + name
-		}
-
-		// Determine clone depth: default to 1 (shallow clone)
-		depth := 1
-		if git.Depth != nil && *git.Depth > 0 {
-			depth = *git.Depth
-		}
-
-		// Determine ref: default to HEAD
-		ref := git.Ref
-		if ref == "" {
-			ref = "HEAD"
-		}
-
-		// Get secret name if specified
-		secretName := ""
-		if git.SecretRef != nil {
-			secretName = git.SecretRef.Name
-		}
-
-		return "", nil, &gitMount{
-			contextName: name,
-			repository:  git.Repository,
-			ref:         ref,
-			repoPath:    git.Path,
-			mountPath:   resolvedMountPath,
-			depth:       depth,
-			secretName:  secretName,
-		}, nil
-
-	default:
-		return "", nil, nil, fmt.Errorf("unknown context type: %s", spec.Type)
-	}
+	return contextRefResult{rc: &build.ResolvedContext{
+		Name:      ref.Name,
+		Namespace: namespace,
+		Type:      string(contextCR.Spec.Type),
+		Content:   resolution.Content,
+		MountPath: ref.MountPath,
+	}}, nil
 }
 
 // getConfigMapKey retrieves a specific key from a ConfigMap
@@ -585,12 +1257,61 @@ func (r *TaskReconciler) getConfigMapKey(ctx context.Context, namespace, name, k
 	if content, ok := cm.Data[key]; ok {
 		return content, nil
 	}
+	if content, ok := cm.BinaryData[key]; ok {
+		return string(content), nil
+	}
 	if optional != nil && *optional {
 		return "", nil
 	}
 	return "", fmt.Errorf("key %s not found in ConfigMap %s", key, name)
 }
 
+// resolveDescription returns the Task's description, with the content of
+// descriptionFrom before the inline description
+func (r *TaskReconciler) resolveDescription(ctx context.Context, task *kubetaskv1alpha1.Task) (string, error) {
+	var parts []string
+	if from := task.Spec.DescriptionFrom; from != nil {
+		var content string
+		var err error
+		switch {
+		case from.ConfigMapKeyRef != nil:
+			ref := from.ConfigMapKeyRef
+			content, err = r.getConfigMapKey(ctx, task.Namespace, ref.Name, ref.Key, ref.Optional)
+		case from.SecretKeyRef != nil:
+			content, err = r.getSecretKey(ctx, task.Namespace, from.SecretKeyRef)
+		}
+		if err != nil {
+			return "", fmt.Errorf("descriptionFrom: %w", err)
+		}
+		if content != "" {
+			parts = append(parts, content)
+		}
+	}
+	if task.Spec.Description != nil && *task.Spec.Description != "" {
+		parts = append(parts, *task.Spec.Description)
+	}
+	return strings.Join(parts, "\n\n"), nil
+}
+
+// getSecretKey retrieves the content of a Secret key
+func (r *TaskReconciler) getSecretKey(ctx context.Context, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	optional := ref.Optional != nil && *ref.Optional
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		if optional && errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if content, ok := secret.Data[ref.Key]; ok {
+		return string(content), nil
+	}
+	if optional {
+		return "", nil
+	}
+	return "", fmt.Errorf("key %s not found in Secret %s", ref.Key, ref.Name)
+}
+
 // getConfigMapAllKeys retrieves all keys from a ConfigMap and formats them for aggregation
 func (r *TaskReconciler) getConfigMapAllKeys(ctx context.Context, namespace, name string, optional *bool) (string, error) {
 	cm := &corev1.ConfigMap{}
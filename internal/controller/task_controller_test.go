@@ -77,11 +77,9 @@ var _ = Describe("TaskController", func() {
 			Expect(createdTask.Status.StartTime).ShouldNot(BeNil())
 
 			By("Checking context ConfigMap is created")
-			configMapName := taskName + ContextConfigMapSuffix
-			configMapLookupKey := types.NamespacedName{Name: configMapName, Namespace: taskNamespace}
 			createdConfigMap := &corev1.ConfigMap{}
 			Eventually(func() bool {
-				return k8sClient.Get(ctx, configMapLookupKey, createdConfigMap) == nil
+				return getContextConfigMap(types.NamespacedName{Name: taskName, Namespace: taskNamespace}, createdConfigMap) == nil
 			}, timeout, interval).Should(BeTrue())
 			Expect(createdConfigMap.Data).Should(HaveKey("workspace-task.md"))
 			Expect(createdConfigMap.Data["workspace-task.md"]).Should(ContainSubstring(description))
@@ -475,11 +473,9 @@ var _ = Describe("TaskController", func() {
 			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
 
 			By("Checking context ConfigMap is created with resolved content")
-			contextConfigMapName := taskName + ContextConfigMapSuffix
-			contextConfigMapLookupKey := types.NamespacedName{Name: contextConfigMapName, Namespace: taskNamespace}
 			createdContextConfigMap := &corev1.ConfigMap{}
 			Eventually(func() bool {
-				return k8sClient.Get(ctx, contextConfigMapLookupKey, createdContextConfigMap) == nil
+				return getContextConfigMap(types.NamespacedName{Name: taskName, Namespace: taskNamespace}, createdContextConfigMap) == nil
 			}, timeout, interval).Should(BeTrue())
 
 			// Task.md should contain description
@@ -548,11 +544,9 @@ var _ = Describe("TaskController", func() {
 			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
 
 			By("Checking all ConfigMap keys are aggregated to task.md")
-			contextConfigMapName := taskName + ContextConfigMapSuffix
-			contextConfigMapLookupKey := types.NamespacedName{Name: contextConfigMapName, Namespace: taskNamespace}
 			createdContextConfigMap := &corev1.ConfigMap{}
 			Eventually(func() bool {
-				return k8sClient.Get(ctx, contextConfigMapLookupKey, createdContextConfigMap) == nil
+				return getContextConfigMap(types.NamespacedName{Name: taskName, Namespace: taskNamespace}, createdContextConfigMap) == nil
 			}, timeout, interval).Should(BeTrue())
 
 			taskMdContent := createdContextConfigMap.Data["workspace-task.md"]
@@ -616,11 +610,9 @@ var _ = Describe("TaskController", func() {
 			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
 
 			By("Checking context is appended to task.md with XML tags")
-			contextConfigMapName := taskName + ContextConfigMapSuffix
-			contextConfigMapLookupKey := types.NamespacedName{Name: contextConfigMapName, Namespace: taskNamespace}
 			createdContextConfigMap := &corev1.ConfigMap{}
 			Eventually(func() bool {
-				return k8sClient.Get(ctx, contextConfigMapLookupKey, createdContextConfigMap) == nil
+				return getContextConfigMap(types.NamespacedName{Name: taskName, Namespace: taskNamespace}, createdContextConfigMap) == nil
 			}, timeout, interval).Should(BeTrue())
 
 			taskMdContent := createdContextConfigMap.Data["workspace-task.md"]
@@ -713,11 +705,9 @@ var _ = Describe("TaskController", func() {
 			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
 
 			By("Checking context ConfigMap contains both contexts")
-			contextConfigMapName := taskName + ContextConfigMapSuffix
-			contextConfigMapLookupKey := types.NamespacedName{Name: contextConfigMapName, Namespace: taskNamespace}
 			createdContextConfigMap := &corev1.ConfigMap{}
 			Eventually(func() bool {
-				return k8sClient.Get(ctx, contextConfigMapLookupKey, createdContextConfigMap) == nil
+				return getContextConfigMap(types.NamespacedName{Name: taskName, Namespace: taskNamespace}, createdContextConfigMap) == nil
 			}, timeout, interval).Should(BeTrue())
 
 			taskMdContent := createdContextConfigMap.Data["workspace-task.md"]
@@ -1020,3 +1010,15 @@ var _ = Describe("TaskController", func() {
 		})
 	})
 })
+
+// getContextConfigMap gets the context ConfigMap recorded in the status of a Task
+func getContextConfigMap(taskKey types.NamespacedName, configMap *corev1.ConfigMap) error {
+	task := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(ctx, taskKey, task); err != nil {
+		return err
+	}
+	if task.Status.ContextConfigMap == "" {
+		return fmt.Errorf("Task %s has no context ConfigMap yet", taskKey)
+	}
+	return k8sClient.Get(ctx, types.NamespacedName{Name: task.Status.ContextConfigMap, Namespace: taskKey.Namespace}, configMap)
+}
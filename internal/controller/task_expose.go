@@ -0,0 +1,307 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+const (
+	// ExposedConditionType reports whether the web UI of a Task's agent is published
+	ExposedConditionType = "Exposed"
+
+	// exposeRemovedReason marks the Exposed condition of a finished Task whose
+	// Service and route were deleted
+	exposeRemovedReason = "TaskFinished"
+)
+
+// httpRouteGVK is the Gateway API HTTPRoute. Like PodMonitors, HTTPRoutes are
+// handled as unstructured, so clusters without the Gateway API CRDs still work.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;create;update;delete
+
+// exposeName returns the name of the Service and route of a Task's web UI. Task
+// names are DNS subdomains, which Service names are not: names with dots, a leading
+// digit or more than 63 characters are replaced by a "ui-" prefixed name ending in
+// a hash of the Task name.
+func exposeName(task *kubetaskv1alpha1.Task) string {
+	name := task.Name + "-ui"
+	if len(validation.IsDNS1035Label(name)) == 0 {
+		return name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(task.Name))
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	base := "ui-" + strings.ReplaceAll(task.Name, ".", "-")
+	if maxLen := validation.DNS1035LabelMaxLength - len(suffix); len(base) > maxLen {
+		base = base[:maxLen]
+	}
+	return strings.TrimRight(base, "-") + suffix
+}
+
+// exposeHost renders the host template of an Ingress or HTTPRoute for a Task
+func exposeHost(task *kubetaskv1alpha1.Task, hostTemplate string) (string, error) {
+	tmpl, err := template.New("host").Option("missingkey=error").Parse(hostTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid host template: %w", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, struct{ Name, Namespace string }{task.Name, task.Namespace}); err != nil {
+		return "", fmt.Errorf("invalid host template: %w", err)
+	}
+	host := b.String()
+	if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+		return "", fmt.Errorf("host %q is invalid: %s", host, strings.Join(errs, "; "))
+	}
+	return host, nil
+}
+
+// exposeObjectMeta returns the metadata of the Service and route of a Task's web UI
+func exposeObjectMeta(task *kubetaskv1alpha1.Task) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      exposeName(task),
+		Namespace: task.Namespace,
+		Labels:    build.TaskResourceLabels(task),
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: kubetaskv1alpha1.GroupVersion.String(),
+			Kind:       "Task",
+			Name:       task.Name,
+			UID:        task.UID,
+			Controller: boolPtr(true),
+		}},
+	}
+}
+
+// buildExposeService returns the Service selecting the agent Pod of a Task
+func buildExposeService(task *kubetaskv1alpha1.Task, expose *kubetaskv1alpha1.AgentExpose) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: exposeObjectMeta(task),
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "kubetask", TaskLabelKey: task.Name},
+			Ports: []corev1.ServicePort{{
+				Name:       build.UIPortName,
+				Protocol:   corev1.ProtocolTCP,
+				Port:       expose.Port,
+				TargetPort: intstr.FromString(build.UIPortName),
+			}},
+		},
+	}
+}
+
+// buildExposeIngress returns the Ingress routing host to the Service of a Task
+func buildExposeIngress(task *kubetaskv1alpha1.Task, spec *kubetaskv1alpha1.ExposeIngress, host string, port int32) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: exposeObjectMeta(task),
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.ClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: host,
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+							Name: exposeName(task),
+							Port: networkingv1.ServiceBackendPort{Number: port},
+						}},
+					}},
+				}},
+			}},
+		},
+	}
+	ingress.Annotations = spec.Annotations
+	if spec.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{host}, SecretName: spec.TLSSecretName}}
+	}
+	return ingress
+}
+
+// buildExposeHTTPRoute returns the HTTPRoute routing host to the Service of a Task
+func buildExposeHTTPRoute(task *kubetaskv1alpha1.Task, spec *kubetaskv1alpha1.ExposeHTTPRoute, host string, port int32) *unstructured.Unstructured {
+	parentRefs := make([]any, 0, len(spec.ParentRefs))
+	for _, ref := range spec.ParentRefs {
+		parentRef := map[string]any{"name": ref.Name}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	objectMeta := exposeObjectMeta(task)
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"parentRefs": parentRefs,
+			"hostnames":  []any{host},
+			"rules": []any{map[string]any{
+				"backendRefs": []any{map[string]any{"name": exposeName(task), "port": int64(port)}},
+			}},
+		},
+	}}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(objectMeta.Name)
+	route.SetNamespace(objectMeta.Namespace)
+	route.SetLabels(objectMeta.Labels)
+	route.SetAnnotations(spec.Annotations)
+	route.SetOwnerReferences(objectMeta.OwnerReferences)
+	return route
+}
+
+// applyExpose creates the Service and the Ingress or HTTPRoute of the web UI of a
+// Task, and records its URL and Exposed condition on the Task. An invalid host or
+// a missing HTTPRoute CRD leaves the Task with the Service only, reported in the
+// condition, rather than holding the Task.
+func (r *TaskReconciler) applyExpose(ctx context.Context, task *kubetaskv1alpha1.Task, expose *kubetaskv1alpha1.AgentExpose) error {
+	desired := buildExposeService(task, expose)
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	if err := r.applyOwned(ctx, task, "Service", service, func() {
+		service.Labels = desired.Labels
+		service.OwnerReferences = desired.OwnerReferences
+		service.Spec.Selector = desired.Spec.Selector
+		service.Spec.Ports = desired.Spec.Ports
+	}); err != nil {
+		return err
+	}
+
+	hostTemplate, scheme := "", "http"
+	switch {
+	case expose.Ingress != nil:
+		hostTemplate = expose.Ingress.Host
+		if expose.Ingress.TLSSecretName != "" {
+			scheme = "https"
+		}
+	case expose.HTTPRoute != nil:
+		hostTemplate, scheme = expose.HTTPRoute.Host, "https"
+		if expose.HTTPRoute.Scheme != "" {
+			scheme = expose.HTTPRoute.Scheme
+		}
+	default:
+		setExposedCondition(task, metav1.ConditionTrue, "ServiceOnly",
+			fmt.Sprintf("Service %q serves the web UI; the Agent configures no ingress or httpRoute", service.Name))
+		return nil
+	}
+	host, err := exposeHost(task, hostTemplate)
+	if err != nil {
+		setExposedCondition(task, metav1.ConditionFalse, "InvalidHost", err.Error())
+		return nil
+	}
+
+	if expose.Ingress != nil {
+		desired := buildExposeIngress(task, expose.Ingress, host, expose.Port)
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+		err = r.applyOwned(ctx, task, "Ingress", ingress, func() {
+			ingress.Labels = desired.Labels
+			ingress.Annotations = desired.Annotations
+			ingress.OwnerReferences = desired.OwnerReferences
+			ingress.Spec = desired.Spec
+		})
+	} else {
+		desired := buildExposeHTTPRoute(task, expose.HTTPRoute, host, expose.Port)
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteGVK)
+		route.SetName(desired.GetName())
+		route.SetNamespace(desired.GetNamespace())
+		err = r.applyOwned(ctx, task, "HTTPRoute", route, func() {
+			route.SetLabels(desired.GetLabels())
+			route.SetAnnotations(desired.GetAnnotations())
+			route.SetOwnerReferences(desired.GetOwnerReferences())
+			route.Object["spec"] = desired.Object["spec"]
+		})
+		if meta.IsNoMatchError(err) {
+			setExposedCondition(task, metav1.ConditionFalse, "HTTPRouteCRDMissing",
+				"The HTTPRoute CRD of the Gateway API is not installed")
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	task.Status.URL = scheme + "://" + host + "/"
+	setExposedCondition(task, metav1.ConditionTrue, "Exposed", "The web UI is served at "+task.Status.URL)
+	return nil
+}
+
+// removeExpose deletes the Service and route of the web UI of a finished Task, and
+// clears its URL. Tasks whose web UI was never exposed, or already removed, are
+// left alone.
+func (r *TaskReconciler) removeExpose(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	condition := meta.FindStatusCondition(task.Status.Conditions, ExposedConditionType)
+	if condition == nil || condition.Reason == exposeRemovedReason {
+		return nil
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	for _, obj := range []client.Object{&corev1.Service{}, &networkingv1.Ingress{}, route} {
+		if err := r.deleteExposed(ctx, task, obj); err != nil {
+			return err
+		}
+	}
+	log.FromContext(ctx).Info("removed agent web UI", "url", task.Status.URL)
+
+	task.Status.URL = ""
+	setExposedCondition(task, metav1.ConditionFalse, exposeRemovedReason, "The web UI was removed when the Task finished")
+	return r.Status().Update(ctx, task)
+}
+
+// deleteExposed deletes the web UI object of obj's kind of a Task, unless it is
+// missing or not controlled by the Task
+func (r *TaskReconciler) deleteExposed(ctx context.Context, task *kubetaskv1alpha1.Task, obj client.Object) error {
+	err := r.Get(ctx, types.NamespacedName{Name: exposeName(task), Namespace: task.Namespace}, obj)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(obj, task) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// allowUIIngress lets ingress controllers and Gateways reach the web UI port of
+// an exposed agent Pod through the NetworkPolicy of its security profile
+func allowUIIngress(policy *networkingv1.NetworkPolicy, expose *kubetaskv1alpha1.AgentExpose) {
+	protocol, port := corev1.ProtocolTCP, intstr.FromInt32(expose.Port)
+	policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &port}},
+	})
+}
+
+// setExposedCondition reports the state of the web UI of a Task
+func setExposedCondition(task *kubetaskv1alpha1.Task, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    ExposedConditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
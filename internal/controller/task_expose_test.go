@@ -0,0 +1,169 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestApplyExpose(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "review-ui", Namespace: "default"}
+	newTask := func() *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "review", Namespace: "default", UID: types.UID("task-uid")}}
+	}
+
+	t.Run("ingress", func(t *testing.T) {
+		task := newTask()
+		c := newInitTestClient(t, interceptor.Funcs{}, task)
+		r := &TaskReconciler{Client: c}
+		expose := &kubetaskv1alpha1.AgentExpose{Port: 8080, Ingress: &kubetaskv1alpha1.ExposeIngress{
+			Host: "{{ .Name }}.{{ .Namespace }}.review.example.com", TLSSecretName: "review-tls",
+		}}
+
+		if err := r.applyExpose(ctx, task, expose); err != nil {
+			t.Fatalf("applyExpose() error = %v", err)
+		}
+		if task.Status.URL != "https://review.default.review.example.com/" {
+			t.Errorf("URL = %q, want the rendered host over HTTPS", task.Status.URL)
+		}
+		service := &corev1.Service{}
+		if err := c.Get(ctx, key, service); err != nil || service.Spec.Selector[TaskLabelKey] != "review" || !metav1.IsControlledBy(service, task) {
+			t.Errorf("Service = %+v, %v, want one selecting the Task's Pod", service.Spec, err)
+		}
+		ingress := &networkingv1.Ingress{}
+		if err := c.Get(ctx, key, ingress); err != nil || ingress.Spec.Rules[0].Host != "review.default.review.example.com" ||
+			ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name != "review-ui" || len(ingress.Spec.TLS) != 1 {
+			t.Errorf("Ingress = %+v, %v, want the host routed to the Service with TLS", ingress.Spec, err)
+		}
+
+		// Finished Tasks remove the web UI once
+		if err := r.removeExpose(ctx, task); err != nil {
+			t.Fatalf("removeExpose() error = %v", err)
+		}
+		for _, obj := range []client.Object{&corev1.Service{}, &networkingv1.Ingress{}} {
+			if err := c.Get(ctx, key, obj); !errors.IsNotFound(err) {
+				t.Errorf("Get(%T) error = %v, want it deleted", obj, err)
+			}
+		}
+		condition := meta.FindStatusCondition(task.Status.Conditions, ExposedConditionType)
+		if task.Status.URL != "" || condition == nil || condition.Reason != exposeRemovedReason {
+			t.Errorf("URL = %q, Exposed = %+v, want the URL cleared", task.Status.URL, condition)
+		}
+	})
+
+	t.Run("httpRoute", func(t *testing.T) {
+		task := newTask()
+		c := newInitTestClient(t, interceptor.Funcs{})
+		r := &TaskReconciler{Client: c}
+		expose := &kubetaskv1alpha1.AgentExpose{Port: 8080, HTTPRoute: &kubetaskv1alpha1.ExposeHTTPRoute{
+			Host:       "{{ .Name }}.review.example.com",
+			ParentRefs: []kubetaskv1alpha1.ExposeParentRef{{Name: "public", Namespace: "gateways"}},
+		}}
+
+		if err := r.applyExpose(ctx, task, expose); err != nil {
+			t.Fatalf("applyExpose() error = %v", err)
+		}
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(httpRouteGVK)
+		if err := c.Get(ctx, key, route); err != nil {
+			t.Fatalf("HTTPRoute not created: %v", err)
+		}
+		hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+		if len(hostnames) != 1 || hostnames[0] != "review.review.example.com" || len(parentRefs) != 1 {
+			t.Errorf("HTTPRoute spec = %v, want the host attached to the Gateway", route.Object["spec"])
+		}
+		if task.Status.URL != "https://review.review.example.com/" {
+			t.Errorf("URL = %q, want HTTPS by default", task.Status.URL)
+		}
+	})
+
+	t.Run("invalid host", func(t *testing.T) {
+		task := newTask()
+		r := &TaskReconciler{Client: newInitTestClient(t, interceptor.Funcs{})}
+		expose := &kubetaskv1alpha1.AgentExpose{Port: 8080, Ingress: &kubetaskv1alpha1.ExposeIngress{Host: "{{ .Name }}_ui.example.com"}}
+
+		if err := r.applyExpose(ctx, task, expose); err != nil {
+			t.Fatalf("applyExpose() error = %v", err)
+		}
+		condition := meta.FindStatusCondition(task.Status.Conditions, ExposedConditionType)
+		if task.Status.URL != "" || condition == nil || condition.Reason != "InvalidHost" || !strings.Contains(condition.Message, "review_ui.example.com") {
+			t.Errorf("URL = %q, Exposed = %+v, want the invalid host reported", task.Status.URL, condition)
+		}
+	})
+
+	t.Run("Task name that is not a Service name", func(t *testing.T) {
+		task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "1.fix-login", Namespace: "default", UID: types.UID("task-uid")}}
+		c := newInitTestClient(t, interceptor.Funcs{}, task)
+		r := &TaskReconciler{Client: c}
+		expose := &kubetaskv1alpha1.AgentExpose{Port: 8080, Ingress: &kubetaskv1alpha1.ExposeIngress{Host: "{{ .Name }}.review.example.com"}}
+
+		if err := r.applyExpose(ctx, task, expose); err != nil {
+			t.Fatalf("applyExpose() error = %v", err)
+		}
+		name := exposeName(task)
+		if errs := validation.IsDNS1035Label(name); len(errs) > 0 || !strings.HasPrefix(name, "ui-1-fix-login-") {
+			t.Errorf("exposeName() = %q, want a valid Service name derived from the Task name: %v", name, errs)
+		}
+		ingress := &networkingv1.Ingress{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, ingress); err != nil ||
+			ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name != name {
+			t.Errorf("Ingress = %+v, %v, want it routed to the Service %s", ingress.Spec, err, name)
+		}
+		if task.Status.URL != "http://1.fix-login.review.example.com/" {
+			t.Errorf("URL = %q, want the rendered host", task.Status.URL)
+		}
+
+		// Long names are cut, and names differing only in dots do not collide
+		long := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 70)}}
+		dashed := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "1-fix-login"}}
+		if name := exposeName(long); len(validation.IsDNS1035Label(name)) > 0 {
+			t.Errorf("exposeName() = %q for a long Task name, want a valid Service name", name)
+		}
+		if exposeName(dashed) == name {
+			t.Errorf("exposeName() = %q for both 1.fix-login and 1-fix-login", name)
+		}
+	})
+
+	t.Run("HTTPRoute CRD missing", func(t *testing.T) {
+		task := newTask()
+		c := newInitTestClient(t, interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*unstructured.Unstructured); ok {
+					return &meta.NoKindMatchError{GroupKind: httpRouteGVK.GroupKind(), SearchedVersions: []string{"v1"}}
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+		r := &TaskReconciler{Client: c}
+		expose := &kubetaskv1alpha1.AgentExpose{Port: 8080, HTTPRoute: &kubetaskv1alpha1.ExposeHTTPRoute{
+			Host: "review.example.com", ParentRefs: []kubetaskv1alpha1.ExposeParentRef{{Name: "public"}},
+		}}
+
+		if err := r.applyExpose(ctx, task, expose); err != nil {
+			t.Fatalf("applyExpose() error = %v", err)
+		}
+		condition := meta.FindStatusCondition(task.Status.Conditions, ExposedConditionType)
+		if condition == nil || condition.Reason != "HTTPRouteCRDMissing" {
+			t.Errorf("Exposed = %+v, want HTTPRouteCRDMissing", condition)
+		}
+	})
+}
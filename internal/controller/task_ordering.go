@@ -0,0 +1,136 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+// taskRunAfterField indexes Tasks by the names of the Tasks in their spec.runAfter
+const taskRunAfterField = "spec.runAfter"
+
+// taskRunAfterKeys returns the taskRunAfterField values of a Task
+func taskRunAfterKeys(obj client.Object) []string {
+	return obj.(*kubetaskv1alpha1.Task).Spec.RunAfter
+}
+
+// runAfterState checks the Tasks listed in spec.runAfter. It returns the Tasks that
+// have not completed yet, including ones that do not exist yet, and the first
+// Task that failed, if any.
+func (r *TaskReconciler) runAfterState(ctx context.Context, task *kubetaskv1alpha1.Task) (waiting []string, failed string, err error) {
+	for _, name := range task.Spec.RunAfter {
+		if name == task.Name {
+			return nil, name, nil
+		}
+		previous := &kubetaskv1alpha1.Task{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: task.Namespace}, previous); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, "", err
+			}
+			waiting = append(waiting, name)
+			continue
+		}
+		switch previous.Status.Phase {
+		case kubetaskv1alpha1.TaskPhaseCompleted:
+		case kubetaskv1alpha1.TaskPhaseFailed:
+			return nil, name, nil
+		default:
+			waiting = append(waiting, name)
+		}
+	}
+	return waiting, "", nil
+}
+
+// waitForRunAfter keeps a Task Pending until the Tasks it runs after have
+// completed, and fails it if one of them failed. waiting is false once the Task may start.
+func (r *TaskReconciler) waitForRunAfter(ctx context.Context, task *kubetaskv1alpha1.Task) (waiting bool, err error) {
+	log := log.FromContext(ctx)
+
+	pending, failed, err := r.runAfterState(ctx, task)
+	if err != nil {
+		log.Error(err, "unable to check runAfter Tasks")
+		return true, err
+	}
+
+	if failed != "" {
+		message := fmt.Sprintf("Task %q in runAfter failed", failed)
+		if failed == task.Name {
+			message = "Task cannot run after itself"
+		}
+		log.Info("task failed, runAfter Task failed", "runAfter", failed)
+		task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+		now := metav1.NewTime(clockNow(r.Clock))
+		task.Status.CompletionTime = &now
+		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "RunAfterFailed",
+			Message: message,
+		})
+		if err := r.Status().Update(ctx, task); err != nil {
+			log.Error(err, "unable to update Task status")
+			return true, err
+		}
+		audit.Record(audit.ForTask(task, audit.ActionFailed, audit.ControllerActor))
+		return true, nil
+	}
+
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	// Tasks that finish or are created requeue this Task through taskToRunAfterDependents
+	message := "Waiting for Tasks: " + strings.Join(pending, ", ")
+	ready := meta.FindStatusCondition(task.Status.Conditions, "Ready")
+	if task.Status.Phase != kubetaskv1alpha1.TaskPhasePending || ready == nil || ready.Message != message {
+		task.Status.Phase = kubetaskv1alpha1.TaskPhasePending
+		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "WaitingForRunAfter",
+			Message: message,
+		})
+		if err := r.Status().Update(ctx, task); err != nil {
+			log.Error(err, "unable to update Task status")
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// taskToRunAfterDependents enqueues the Pending Tasks that list a changed Task in
+// spec.runAfter. reader must index Tasks by taskRunAfterField.
+func taskToRunAfterDependents(reader client.Reader) func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		taskList := &kubetaskv1alpha1.TaskList{}
+		if err := reader.List(ctx, taskList, client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{taskRunAfterField: obj.GetName()}); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list Tasks for runAfter", "namespace", obj.GetNamespace())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, task := range taskList.Items {
+			if task.Status.Phase != "" && task.Status.Phase != kubetaskv1alpha1.TaskPhasePending {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: task.Name, Namespace: task.Namespace},
+			})
+		}
+		return requests
+	}
+}
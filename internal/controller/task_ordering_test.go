@@ -0,0 +1,136 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestRunAfter(t *testing.T) {
+	newTask := func(name string, phase kubetaskv1alpha1.TaskPhase, runAfter ...string) *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       kubetaskv1alpha1.TaskSpec{Description: stringPtr("step"), RunAfter: runAfter},
+			Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		task        *kubetaskv1alpha1.Task
+		previous    []*kubetaskv1alpha1.Task
+		wantPhase   kubetaskv1alpha1.TaskPhase
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:        "previous Task not created yet",
+			task:        newTask("second", "", "first"),
+			wantPhase:   kubetaskv1alpha1.TaskPhasePending,
+			wantReason:  "WaitingForRunAfter",
+			wantMessage: "Waiting for Tasks: first",
+		},
+		{
+			name: "previous Tasks still running",
+			task: newTask("third", "", "first", "second"),
+			previous: []*kubetaskv1alpha1.Task{
+				newTask("first", kubetaskv1alpha1.TaskPhaseCompleted),
+				newTask("second", kubetaskv1alpha1.TaskPhaseRunning),
+			},
+			wantPhase:   kubetaskv1alpha1.TaskPhasePending,
+			wantReason:  "WaitingForRunAfter",
+			wantMessage: "Waiting for Tasks: second",
+		},
+		{
+			name:        "previous Task failed",
+			task:        newTask("second", kubetaskv1alpha1.TaskPhasePending, "first"),
+			previous:    []*kubetaskv1alpha1.Task{newTask("first", kubetaskv1alpha1.TaskPhaseFailed)},
+			wantPhase:   kubetaskv1alpha1.TaskPhaseFailed,
+			wantReason:  "RunAfterFailed",
+			wantMessage: `Task "first" in runAfter failed`,
+		},
+		{
+			name:        "runs after itself",
+			task:        newTask("loop", "", "loop"),
+			wantPhase:   kubetaskv1alpha1.TaskPhaseFailed,
+			wantReason:  "RunAfterFailed",
+			wantMessage: "Task cannot run after itself",
+		},
+		{
+			// The Task starts and fails later, on the missing default Agent
+			name:       "previous Task completed",
+			task:       newTask("second", kubetaskv1alpha1.TaskPhasePending, "first"),
+			previous:   []*kubetaskv1alpha1.Task{newTask("first", kubetaskv1alpha1.TaskPhaseCompleted)},
+			wantPhase:  kubetaskv1alpha1.TaskPhaseFailed,
+			wantReason: "AgentError",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(tt.task).
+				WithStatusSubresource(&kubetaskv1alpha1.Task{})
+			for _, previous := range tt.previous {
+				builder = builder.WithObjects(previous)
+			}
+			k8sClient := builder.Build()
+			r := &TaskReconciler{Client: k8sClient}
+
+			key := types.NamespacedName{Name: tt.task.Name, Namespace: "default"}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			task := &kubetaskv1alpha1.Task{}
+			if err := k8sClient.Get(context.Background(), key, task); err != nil {
+				t.Fatal(err)
+			}
+			if task.Status.Phase != tt.wantPhase {
+				t.Errorf("Phase = %s, want %s", task.Status.Phase, tt.wantPhase)
+			}
+			ready := meta.FindStatusCondition(task.Status.Conditions, "Ready")
+			if ready == nil || ready.Reason != tt.wantReason || (tt.wantMessage != "" && ready.Message != tt.wantMessage) {
+				t.Errorf("Ready condition = %+v, want reason %s and message %q", ready, tt.wantReason, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestTaskToRunAfterDependents(t *testing.T) {
+	newTask := func(name string, phase kubetaskv1alpha1.TaskPhase, runAfter ...string) *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       kubetaskv1alpha1.TaskSpec{RunAfter: runAfter},
+			Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: phase},
+		}
+	}
+	first := newTask("first", kubetaskv1alpha1.TaskPhaseCompleted)
+	reader := fake.NewClientBuilder().WithScheme(newTestScheme(t)).
+		WithIndex(&kubetaskv1alpha1.Task{}, taskRunAfterField, taskRunAfterKeys).
+		WithObjects(
+			first,
+			newTask("new", "", "first"),
+			newTask("pending", kubetaskv1alpha1.TaskPhasePending, "other", "first"),
+			newTask("running", kubetaskv1alpha1.TaskPhaseRunning, "first"),
+			newTask("unrelated", kubetaskv1alpha1.TaskPhasePending, "other"),
+		).Build()
+
+	requests := taskToRunAfterDependents(reader)(context.Background(), first)
+	got := map[string]bool{}
+	for _, req := range requests {
+		got[req.Name] = true
+	}
+	if len(got) != 2 || !got["new"] || !got["pending"] {
+		t.Errorf("taskToRunAfterDependents() = %v, want new and pending", requests)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// Client call budgets of the Task controller. Writes cost API server capacity and
+// reads cache lookups for every Task, so raise these only for calls a change needs,
+// and lower them when a change saves some.
+const (
+	// initializeTaskAPIBudget bounds the calls of the reconcile starting a Task
+	initializeTaskAPIBudget = 11
+
+	// runningTaskAPIBudget bounds the calls of a reconcile of a running Task whose
+	// Job and Pod did not change
+	runningTaskAPIBudget = 3
+)
+
+// apiCalls counts client calls by operation and kind, e.g. "Create Job"
+type apiCalls struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (a *apiCalls) add(op string, obj runtime.Object) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.counts == nil {
+		a.counts = map[string]int{}
+	}
+	a.counts[fmt.Sprintf("%s %T", op, obj)]++
+}
+
+// total returns the number of calls, and resets the counts
+func (a *apiCalls) total() (int, []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	total := 0
+	var calls []string
+	for _, call := range slices.Sorted(maps.Keys(a.counts)) {
+		total += a.counts[call]
+		calls = append(calls, fmt.Sprintf("%dx %s", a.counts[call], call))
+	}
+	a.counts = nil
+	return total, calls
+}
+
+// funcs returns interceptor functions counting the calls of a fake client
+func (a *apiCalls) funcs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			a.add("Get", obj)
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			a.add("List", list)
+			return c.List(ctx, list, opts...)
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			a.add("Create", obj)
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			a.add("Update", obj)
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			a.add("Patch", obj)
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			a.add("Delete", obj)
+			return c.Delete(ctx, obj, opts...)
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			a.add("Update "+subResource, obj)
+			return c.SubResource(subResource).Update(ctx, obj, opts...)
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			a.add("Patch "+subResource, obj)
+			return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+		},
+	}
+}
+
+func TestTaskReconcile_APIBudget(t *testing.T) {
+	calls := &apiCalls{}
+	agent, task := newInitTestObjects()
+	c := newInitTestClient(t, calls.funcs(), agent, task)
+	r := &TaskReconciler{Client: c}
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: initTaskKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if total, made := calls.total(); total > initializeTaskAPIBudget {
+		t.Errorf("starting a Task took %d client calls, budget %d: %v", total, initializeTaskAPIBudget, made)
+	}
+
+	// The first reconcile of the running Task records the state of its Pod
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: initTaskKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	calls.total()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: initTaskKey}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if total, made := calls.total(); total > runningTaskAPIBudget {
+		t.Errorf("reconciling a running Task took %d client calls, budget %d: %v", total, runningTaskAPIBudget, made)
+	}
+}
+
+// BenchmarkTaskReconcile_Initialize measures the reconcile starting a Task
+func BenchmarkTaskReconcile_Initialize(b *testing.B) {
+	agent, _ := newInitTestObjects()
+	c := newInitTestClient(b, interceptor.Funcs{}, agent)
+	r := &TaskReconciler{Client: c}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		b.StopTimer()
+		_, task := newInitTestObjects()
+		task.Name = fmt.Sprintf("fix-%d", i)
+		task.UID = types.UID(task.Name)
+		if err := c.Create(ctx, task); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(task)}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkTaskReconcile_Running measures the steady-state reconcile of one running
+// Task among many in its namespace
+func BenchmarkTaskReconcile_Running(b *testing.B) {
+	for _, tasks := range []int{100, 10000} {
+		b.Run(fmt.Sprintf("%d Tasks", tasks), func(b *testing.B) {
+			agent, _ := newInitTestObjects()
+			objects := []client.Object{agent}
+			for i := range tasks {
+				name := fmt.Sprintf("fix-%d", i)
+				start := metav1.Now()
+				objects = append(objects,
+					&kubetaskv1alpha1.Task{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+						Status: kubetaskv1alpha1.TaskExecutionStatus{
+							Phase:     kubetaskv1alpha1.TaskPhaseRunning,
+							JobName:   retryJobName(name, 1),
+							Attempts:  1,
+							StartTime: &start,
+						},
+					},
+					&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: retryJobName(name, 1), Namespace: "default"}},
+				)
+			}
+			c := newInitTestClient(b, interceptor.Funcs{}, objects...)
+			r := &TaskReconciler{Client: c}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("fix-%d", tasks/2), Namespace: "default"}}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := r.Reconcile(ctx, req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+const (
+	// TaskLabelKey is the label key identifying the Task that owns a Job and its Pods
+	TaskLabelKey = build.TaskLabelKey
+
+	// AgentPodConditionType is the Task condition reporting the state of the agent Pod
+	AgentPodConditionType = "AgentPodRunning"
+
+	// DefaultAgentContainerName is the name of the agent container in Task Pods
+	// when the Agent does not set containerName
+	DefaultAgentContainerName = build.DefaultAgentContainerName
+
+	// DefaultContainerAnnotation names the agent container on Task Pods. kubectl
+	// uses it as the default container for exec and logs.
+	DefaultContainerAnnotation = build.DefaultContainerAnnotation
+)
+
+// agentContainerName returns the name of the agent container of a Task Pod
+func agentContainerName(pod *corev1.Pod) string {
+	if name := pod.Annotations[DefaultContainerAnnotation]; name != "" {
+		return name
+	}
+	return DefaultAgentContainerName
+}
+
+// podWaitingFailureReasons are container waiting reasons that need user attention
+var podWaitingFailureReasons = map[string]bool{
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// getAgentPod returns the most recently created Pod of the Task's Job, or nil if there is none
+func (r *TaskReconciler) getAgentPod(ctx context.Context, task *kubetaskv1alpha1.Task) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(task.Namespace), client.MatchingLabels{
+		TaskLabelKey: task.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	var newest *corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if newest == nil || newest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			newest = pod
+		}
+	}
+	return newest, nil
+}
+
+// agentPodCondition summarizes the agent Pod state as a Task condition
+func agentPodCondition(pod *corev1.Pod) metav1.Condition {
+	cond := metav1.Condition{
+		Type:   AgentPodConditionType,
+		Status: metav1.ConditionFalse,
+	}
+
+	if pod == nil {
+		cond.Reason = "PodNotCreated"
+		cond.Message = "Waiting for the agent Pod to be created"
+		return cond
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse && c.Reason == corev1.PodReasonUnschedulable {
+			cond.Reason = corev1.PodReasonUnschedulable
+			cond.Message = c.Message
+			return cond
+		}
+	}
+
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if w := cs.State.Waiting; w != nil && podWaitingFailureReasons[w.Reason] {
+			cond.Reason = w.Reason
+			cond.Message = fmt.Sprintf("container %s: %s", cs.Name, w.Message)
+			return cond
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+			cond.Reason = "OOMKilled"
+			cond.Message = fmt.Sprintf("container %s was OOM killed (restarts: %d)", cs.Name, cs.RestartCount)
+			return cond
+		}
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != agentContainerName(pod) {
+			continue
+		}
+		switch {
+		case cs.State.Running != nil:
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = "Running"
+			cond.Message = fmt.Sprintf("Agent Pod %s is running", pod.Name)
+			return cond
+		case cs.State.Terminated != nil:
+			t := cs.State.Terminated
+			cond.Reason = t.Reason
+			if cond.Reason == "" {
+				cond.Reason = "Terminated"
+			}
+			cond.Message = fmt.Sprintf("container %s terminated with exit code %d", cs.Name, t.ExitCode)
+			if msg := stripTrailers(t.Message); msg != "" {
+				cond.Message += ": " + msg
+			}
+			return cond
+		}
+	}
+
+	cond.Reason = "PodPending"
+	cond.Message = fmt.Sprintf("Agent Pod %s is %s", pod.Name, pod.Status.Phase)
+	return cond
+}
+
+// podToTask maps a KubeTask Pod to the Task that owns its Job
+func podToTask(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if labels["app"] != "kubetask" || labels[TaskLabelKey] == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: labels[TaskLabelKey], Namespace: obj.GetNamespace()},
+	}}
+}
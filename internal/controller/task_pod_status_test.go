@@ -0,0 +1,169 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAgentPodCondition(t *testing.T) {
+	newPod := func(status corev1.PodStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "task-job-abcde", Namespace: "default"},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		pod        *corev1.Pod
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "no pod yet",
+			pod:        nil,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "PodNotCreated",
+		},
+		{
+			name: "unschedulable",
+			pod: newPod(corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available",
+				}},
+			}),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: corev1.PodReasonUnschedulable,
+		},
+		{
+			name: "image pull backoff",
+			pod: newPod(corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: DefaultAgentContainerName,
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image",
+					}},
+				}},
+			}),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ImagePullBackOff",
+		},
+		{
+			name: "init container crash loop",
+			pod: newPod(corev1.PodStatus{
+				Phase: corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{{
+					Name: "git-init-0",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason: "CrashLoopBackOff",
+					}},
+				}},
+			}),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "CrashLoopBackOff",
+		},
+		{
+			name: "running after OOM kill",
+			pod: newPod(corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:                 DefaultAgentContainerName,
+					RestartCount:         1,
+					State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+				}},
+			}),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "OOMKilled",
+		},
+		{
+			name: "running",
+			pod: newPod(corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  DefaultAgentContainerName,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}},
+			}),
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Running",
+		},
+		{
+			name: "terminated by OOM",
+			pod: newPod(corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  DefaultAgentContainerName,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+				}},
+			}),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "OOMKilled",
+		},
+		{
+			name: "custom agent container with a sidecar",
+			pod: func() *corev1.Pod {
+				pod := newPod(corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "istio-proxy", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+						{Name: "coder", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}},
+					},
+				})
+				pod.Annotations = map[string]string{DefaultContainerAnnotation: "coder"}
+				return pod
+			}(),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "Error",
+		},
+		{
+			name:       "pending without container status",
+			pod:        newPod(corev1.PodStatus{Phase: corev1.PodPending}),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "PodPending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := agentPodCondition(tt.pod)
+			if cond.Type != AgentPodConditionType {
+				t.Errorf("Type = %q, want %q", cond.Type, AgentPodConditionType)
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("agentPodCondition() = (%s, %s), want (%s, %s); message: %s",
+					cond.Status, cond.Reason, tt.wantStatus, tt.wantReason, cond.Message)
+			}
+		})
+	}
+}
+
+func TestPodToTask(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-task-job-abcde",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "kubetask", TaskLabelKey: "my-task"},
+	}}
+	requests := podToTask(context.Background(), pod)
+	if len(requests) != 1 || requests[0].Name != "my-task" || requests[0].Namespace != "default" {
+		t.Errorf("podToTask() = %v, want request for default/my-task", requests)
+	}
+
+	pod.Labels = map[string]string{"app": "other", TaskLabelKey: "my-task"}
+	if requests := podToTask(context.Background(), pod); len(requests) != 0 {
+		t.Errorf("podToTask() for foreign Pod = %v, want none", requests)
+	}
+}
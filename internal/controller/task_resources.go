@@ -0,0 +1,97 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// The objects a Task owns are created step by step, and a reconcile can stop after
+// any of them, e.g. when the Job cannot be created or the status update conflicts.
+// Their names and content are derived from the Task, so the next reconcile applies
+// the same objects again: existing objects the Task controls are updated to the
+// current content, and objects of the same name owned by anything else are an error.
+
+// applyContextConfigMap creates the context ConfigMap, or updates the one left by
+// an interrupted reconcile so the Job never mounts stale content. Immutable context
+// ConfigMaps are named after their content, so one left by an interrupted reconcile
+// already has the desired content, and only its labels may be updated.
+func (r *TaskReconciler) applyContextConfigMap(ctx context.Context, task *kubetaskv1alpha1.Task, desired *corev1.ConfigMap) error {
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	return r.applyOwned(ctx, task, "ConfigMap", configMap, func() {
+		configMap.Labels = desired.Labels
+		configMap.OwnerReferences = desired.OwnerReferences
+		configMap.Data = desired.Data
+		configMap.BinaryData = desired.BinaryData
+		configMap.Immutable = desired.Immutable
+	})
+}
+
+// applyNetworkPolicy creates the NetworkPolicy of the agent Pod, or updates the
+// one left by an interrupted reconcile
+func (r *TaskReconciler) applyNetworkPolicy(ctx context.Context, task *kubetaskv1alpha1.Task, desired *networkingv1.NetworkPolicy) error {
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	return r.applyOwned(ctx, task, "NetworkPolicy", policy, func() {
+		policy.Labels = desired.Labels
+		policy.OwnerReferences = desired.OwnerReferences
+		policy.Spec = desired.Spec
+	})
+}
+
+// applyOwned creates obj or updates it with mutate, refusing to touch an existing
+// object that the Task does not control
+func (r *TaskReconciler) applyOwned(ctx context.Context, task *kubetaskv1alpha1.Task, kind string, obj client.Object, mutate func()) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, task) {
+			return fmt.Errorf("%s %q already exists and is not owned by Task %q", kind, obj.GetName(), task.Name)
+		}
+		if obj.GetResourceVersion() == "" || !r.LogDiffs {
+			mutate()
+			return nil
+		}
+		existing := obj.DeepCopyObject()
+		mutate()
+		r.logDiff(ctx, "update", kind, obj.GetName(), existing, obj, false)
+		return nil
+	})
+	return err
+}
+
+// createJob creates the Task's Job. A Job created by an earlier, interrupted
+// reconcile is kept as is, since its template cannot be changed.
+func (r *TaskReconciler) createJob(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job) error {
+	err := r.Create(ctx, job)
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return err
+	}
+	existing := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existing); err != nil {
+		return err
+	}
+	if err := checkJobOwner(task, existing); err != nil {
+		return err
+	}
+	r.logDiff(ctx, "keep", "Job", job.Name, existing.Spec.Template, job.Spec.Template, true)
+	return nil
+}
+
+// checkJobOwner returns an error if the Job is not controlled by the Task, e.g. it
+// belongs to a deleted Task of the same name that is still being garbage collected
+func checkJobOwner(task *kubetaskv1alpha1.Task, job *batchv1.Job) error {
+	if !metav1.IsControlledBy(job, task) {
+		return fmt.Errorf("Job %q already exists and is not owned by Task %q", job.Name, task.Name)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+var errInjected = stderrors.New("injected failure")
+
+var initTaskKey = types.NamespacedName{Name: "fix", Namespace: "default"}
+
+func newInitTestObjects() (*kubetaskv1alpha1.Agent, *kubetaskv1alpha1.Task) {
+	agent := &kubetaskv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+		Spec: kubetaskv1alpha1.AgentSpec{
+			ServiceAccountName: "agent-sa",
+			SecurityProfile:    kubetaskv1alpha1.SecurityProfileRestricted,
+		},
+	}
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default", UID: "task-uid"},
+		Spec:       kubetaskv1alpha1.TaskSpec{Description: stringPtr("Fix the flaky test")},
+	}
+	return agent, task
+}
+
+func newInitTestClient(t testing.TB, funcs interceptor.Funcs, objects ...client.Object) client.WithWatch {
+	t.Helper()
+	scheme := newTestScheme(t)
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+// failOnce returns true the first time it is called
+func failOnce() func() bool {
+	failed := false
+	return func() bool {
+		if failed {
+			return false
+		}
+		failed = true
+		return true
+	}
+}
+
+func reconcileInitTask(t *testing.T, c client.Client) (*kubetaskv1alpha1.Task, error) {
+	t.Helper()
+	_, err := (&TaskReconciler{Client: c}).Reconcile(context.Background(), ctrl.Request{NamespacedName: initTaskKey})
+	task := &kubetaskv1alpha1.Task{}
+	if getErr := c.Get(context.Background(), initTaskKey, task); getErr != nil {
+		t.Fatal(getErr)
+	}
+	return task, err
+}
+
+func assertTaskStarted(t *testing.T, c client.Client, task *kubetaskv1alpha1.Task) {
+	t.Helper()
+	if task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning || task.Status.JobName != "fix-job" {
+		t.Errorf("status = %s/%s, want Running/fix-job", task.Status.Phase, task.Status.JobName)
+	}
+	jobs := &batchv1.JobList{}
+	if err := c.List(context.Background(), jobs); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 1 {
+		t.Errorf("expected exactly one Job, got %d", len(jobs.Items))
+	}
+}
+
+func TestInitializeTask_JobCreateFails(t *testing.T) {
+	agent, task := newInitTestObjects()
+	shouldFail := failOnce()
+	c := newInitTestClient(t, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if _, ok := obj.(*batchv1.Job); ok && shouldFail() {
+				return errInjected
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}, agent, task)
+
+	if _, err := reconcileInitTask(t, c); !stderrors.Is(err, errInjected) {
+		t.Fatalf("Reconcile() error = %v, want the injected failure", err)
+	}
+
+	// The description changes before the retry; the Job must mount the current one
+	updated := &kubetaskv1alpha1.Task{}
+	if err := c.Get(context.Background(), initTaskKey, updated); err != nil {
+		t.Fatal(err)
+	}
+	updated.Spec.Description = stringPtr("Fix the flaky test and the linter")
+	if err := c.Update(context.Background(), updated); err != nil {
+		t.Fatal(err)
+	}
+
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: started.Status.ContextConfigMap, Namespace: "default"}, configMap); err != nil {
+		t.Fatal(err)
+	}
+	if got := configMap.Data["workspace-task.md"]; got != "Fix the flaky test and the linter" {
+		t.Errorf("task.md = %q, want the current description", got)
+	}
+	job := &batchv1.Job{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "fix-job", Namespace: "default"}, job); err != nil {
+		t.Fatal(err)
+	}
+	if mounted := build.JobContextConfigMap(job); mounted != configMap.Name {
+		t.Errorf("Job mounts ConfigMap %q, want %q", mounted, configMap.Name)
+	}
+	policy := &networkingv1.NetworkPolicy{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "fix-agent", Namespace: "default"}, policy); err != nil {
+		t.Errorf("NetworkPolicy not found: %v", err)
+	}
+}
+
+func TestInitializeTask_StatusUpdateFails(t *testing.T) {
+	agent, task := newInitTestObjects()
+	shouldFail := failOnce()
+	c := newInitTestClient(t, interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			if task, ok := obj.(*kubetaskv1alpha1.Task); ok && task.Status.JobName != "" && shouldFail() {
+				return errInjected
+			}
+			return c.SubResource(subResource).Update(ctx, obj, opts...)
+		},
+	}, agent, task)
+
+	pending, err := reconcileInitTask(t, c)
+	if !stderrors.Is(err, errInjected) {
+		t.Fatalf("Reconcile() error = %v, want the injected failure", err)
+	}
+	if pending.Status.JobName != "" {
+		t.Fatalf("status was updated despite the failure: %+v", pending.Status)
+	}
+
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+}
+
+func TestInitializeTask_JobAlreadyCreated(t *testing.T) {
+	// The informer cache has not seen the Job created by the previous reconcile yet
+	agent, task := newInitTestObjects()
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:            "fix-job",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Task", Name: "fix", UID: "task-uid", Controller: boolPtr(true)}},
+	}}
+	stale := failOnce()
+	c := newInitTestClient(t, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*batchv1.Job); ok && stale() {
+				return c.Get(ctx, types.NamespacedName{Name: "missing", Namespace: key.Namespace}, obj, opts...)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}, agent, task, job)
+
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+}
+
+func TestInitializeTask_ForeignObjects(t *testing.T) {
+	agent, task := newInitTestObjects()
+	_, rendered, err := RenderTask(context.Background(), newInitTestClient(t, interceptor.Funcs{}, agent, task), task, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configMapName := rendered.Name
+
+	tests := []struct {
+		name    string
+		object  client.Object
+		wantErr string
+	}{
+		{
+			name:    "Job of a deleted Task",
+			object:  &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fix-job", Namespace: "default"}},
+			wantErr: `Job "fix-job" already exists and is not owned by Task "fix"`,
+		},
+		{
+			name:    "unrelated ConfigMap",
+			object:  &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: configMapName, Namespace: "default"}},
+			wantErr: `ConfigMap "` + configMapName + `" already exists and is not owned by Task "fix"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, task := newInitTestObjects()
+			c := newInitTestClient(t, interceptor.Funcs{}, agent, task, tt.object)
+
+			pending, err := reconcileInitTask(t, c)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Reconcile() error = %v, want %q", err, tt.wantErr)
+			}
+			if pending.Status.Phase == kubetaskv1alpha1.TaskPhaseRunning {
+				t.Error("Task must not start with objects it does not own")
+			}
+			if obj, ok := tt.object.(*corev1.ConfigMap); ok {
+				existing := &corev1.ConfigMap{}
+				if err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), existing); err != nil {
+					t.Fatal(err)
+				}
+				if len(existing.Data) != 0 {
+					t.Error("the unrelated ConfigMap was overwritten")
+				}
+			}
+		})
+	}
+}
+
+func TestReconcile_RunningTaskResync(t *testing.T) {
+	for _, interval := range []time.Duration{0, DefaultRunningTaskResyncInterval} {
+		_, task := newInitTestObjects()
+		task.Status = kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning, JobName: "fix-job"}
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fix-job", Namespace: "default"}}
+		c := newInitTestClient(t, interceptor.Funcs{}, task, job)
+		r := &TaskReconciler{Client: c, RunningTaskResyncInterval: interval}
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: initTaskKey})
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if result.RequeueAfter < interval || result.RequeueAfter > interval+interval/10 {
+			t.Errorf("interval %s: RequeueAfter = %s, want %s plus up to 10%% jitter", interval, result.RequeueAfter, interval)
+		}
+	}
+}
+
+func TestInitializeTask_OwnedObjectLabels(t *testing.T) {
+	agent, task := newInitTestObjects()
+	task.Labels = map[string]string{CronTaskLabelKey: "nightly", "kubetask.io/batch": "deps-2024-06", TaskLabelKey: "spoofed"}
+	c := newInitTestClient(t, interceptor.Funcs{}, agent, task)
+
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+
+	owned := []client.Object{
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fix-job"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: started.Status.ContextConfigMap}},
+		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "fix-agent"}},
+	}
+	for _, obj := range owned {
+		if err := c.Get(context.Background(), types.NamespacedName{Name: obj.GetName(), Namespace: "default"}, obj); err != nil {
+			t.Fatal(err)
+		}
+		labels := obj.GetLabels()
+		if labels[CronTaskLabelKey] != "nightly" || labels["kubetask.io/batch"] != "deps-2024-06" {
+			t.Errorf("%T %s labels = %v, want the Task's grouping labels", obj, obj.GetName(), labels)
+		}
+		if labels[TaskLabelKey] != "fix" || labels["app"] != "kubetask" {
+			t.Errorf("%T %s labels = %v, want the base labels", obj, obj.GetName(), labels)
+		}
+	}
+}
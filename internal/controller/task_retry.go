@@ -0,0 +1,222 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+const (
+	// DefaultRetryBackoff is the delay before the first retry of a Task whose
+	// retryPolicy sets no backoffSeconds
+	DefaultRetryBackoff = time.Minute
+
+	// MaxRetryBackoff bounds the delay between retries, which doubles with every retry
+	MaxRetryBackoff = time.Hour
+)
+
+// retryJobName returns the name of the Job of the given attempt of a Task. The first
+// attempt keeps the name Tasks without a retry policy use.
+func retryJobName(taskName string, attempt int32) string {
+	if attempt <= 1 {
+		return fmt.Sprintf("%s-job", taskName)
+	}
+	return fmt.Sprintf("%s-job-%d", taskName, attempt)
+}
+
+// jobFailed reports whether a Job has given up, with a Failed condition
+func jobFailed(job *batchv1.Job) bool {
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// jobFailureKind classifies the failure of a Job: Failed when the agent container
+// exited with an error of its own, Error when the Pod failed around it
+func jobFailureKind(job *batchv1.Job, pod *corev1.Pod) kubetaskv1alpha1.RetryOn {
+	if jobDeadlineExceeded(job) {
+		return kubetaskv1alpha1.RetryOnError
+	}
+	if pod == nil || pod.Status.Reason != "" {
+		// No Pod left, or one that was evicted or lost with its node
+		return kubetaskv1alpha1.RetryOnError
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != agentContainerName(pod) {
+			continue
+		}
+		if t := cs.State.Terminated; t != nil && t.ExitCode != 0 && t.Reason != "OOMKilled" {
+			return kubetaskv1alpha1.RetryOnFailed
+		}
+	}
+	return kubetaskv1alpha1.RetryOnError
+}
+
+// retryBackoff returns the delay before the retry following the given number of
+// earlier retries
+func retryBackoff(policy *kubetaskv1alpha1.RetryPolicy, retries int32) time.Duration {
+	backoff := DefaultRetryBackoff
+	if policy.BackoffSeconds != nil {
+		backoff = time.Duration(*policy.BackoffSeconds) * time.Second
+	}
+	for range retries {
+		if backoff >= MaxRetryBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	return min(backoff, MaxRetryBackoff)
+}
+
+// retriesOn reports whether the policy retries failures of the given kind
+func retriesOn(policy *kubetaskv1alpha1.RetryPolicy, kind kubetaskv1alpha1.RetryOn) bool {
+	return len(policy.RetryOn) == 0 || slices.Contains(policy.RetryOn, kind)
+}
+
+// scheduleRetry records when the failed Job of a Task is recreated, if its retry
+// policy allows another attempt, and reports whether it does. Until the Job has a
+// Failed condition it may still replace a failed Pod, so the Task keeps waiting for
+// it rather than starting a second agent next to it.
+func (r *TaskReconciler) scheduleRetry(ctx context.Context, task *kubetaskv1alpha1.Task, job *batchv1.Job, pod *corev1.Pod, podCondition metav1.Condition) (bool, error) {
+	policy := task.Spec.RetryPolicy
+	if policy == nil {
+		return false, nil
+	}
+	if !jobFailed(job) {
+		log.FromContext(ctx).V(1).Info("waiting for the Job to fail before retrying", "job", job.Name, "failedPods", job.Status.Failed)
+		return true, nil
+	}
+	kind := jobFailureKind(job, pod)
+	attempts := max(task.Status.Attempts, 1)
+	if !retriesOn(policy, kind) || attempts > policy.MaxRetries {
+		return false, nil
+	}
+
+	next := metav1.NewTime(clockNow(r.Clock).Add(retryBackoff(policy, attempts-1)))
+	task.Status.Attempts = attempts
+	task.Status.NextRetryTime = &next
+	meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:   "Ready",
+		Status: metav1.ConditionFalse,
+		Reason: "RetryScheduled",
+		Message: fmt.Sprintf("Job %s failed: %s; attempt %d of %d starts at %s",
+			job.Name, podCondition.Message, attempts+1, policy.MaxRetries+1, next.UTC().Format(time.RFC3339)),
+	})
+	if err := r.Status().Update(ctx, task); err != nil {
+		return true, err
+	}
+	taskRetries.WithLabelValues(task.Namespace, taskAgentName(task), string(kind)).Inc()
+	log.FromContext(ctx).Info("task attempt failed, retrying", "job", job.Name, "failure", kind, "attempt", attempts, "nextRetryTime", next.Time)
+	return true, nil
+}
+
+// retryTask recreates the Job of a Task once the backoff after its failed attempt
+// has passed. The new Job copies the failed one, so the attempt runs with the same
+// contexts and configuration; the failed Job is kept for its logs.
+func (r *TaskReconciler) retryTask(ctx context.Context, task *kubetaskv1alpha1.Task) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	now := clockNow(r.Clock)
+	if wait := task.Status.NextRetryTime.Sub(now); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	failed := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: task.Status.JobName, Namespace: task.Namespace}, failed); err != nil {
+		if !errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// Without the failed Job there is nothing to copy the attempt from
+		completion := metav1.NewTime(now)
+		task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+		task.Status.CompletionTime = &completion
+		task.Status.NextRetryTime = nil
+		meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionFalse,
+			Reason:  "RetryFailed",
+			Message: fmt.Sprintf("Job %s of the failed attempt no longer exists", task.Status.JobName),
+		})
+		if err := r.Status().Update(ctx, task); err != nil {
+			return ctrl.Result{}, err
+		}
+		audit.Record(audit.ForTask(task, audit.ActionFailed, audit.ControllerActor))
+		return ctrl.Result{}, nil
+	}
+
+	if delay := r.jobCreationDelay(ctx, task); delay > 0 {
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+	attempt := task.Status.Attempts + 1
+	job := retryJob(failed, retryJobName(task.Name, attempt))
+	if err := r.createJob(ctx, task, job); err != nil {
+		if result, ok := retryThrottled(ctx, task, err); ok {
+			return result, nil
+		}
+		log.Error(err, "unable to create Job", "job", job.Name)
+		if setJobCreatedCondition(task, job.Name, err, "JobCreateFailed") {
+			if updateErr := r.Status().Update(ctx, task); updateErr != nil {
+				log.Error(updateErr, "unable to update Task status")
+			}
+		}
+		return ctrl.Result{}, err
+	}
+
+	task.Status.Attempts = attempt
+	task.Status.JobName = job.Name
+	task.Status.NextRetryTime = nil
+	setJobCreatedCondition(task, job.Name, nil, "")
+	meta.SetStatusCondition(&task.Status.Conditions, metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  "Retrying",
+		Message: fmt.Sprintf("Attempt %d of %d is running in Job %s", attempt, task.Spec.RetryPolicy.MaxRetries+1, job.Name),
+	})
+	if err := r.Status().Update(ctx, task); err != nil {
+		log.Error(err, "unable to update Task status")
+		return ctrl.Result{}, err
+	}
+	log.Info("retrying task", "job", job.Name, "attempt", attempt)
+	return ctrl.Result{}, nil
+}
+
+// retryJob returns a copy of the failed Job under a new name, without the selector
+// and Pod labels the API server generated for the failed Job
+func retryJob(failed *batchv1.Job, name string) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       failed.Namespace,
+			Labels:          maps.Clone(failed.Labels),
+			Annotations:     maps.Clone(failed.Annotations),
+			OwnerReferences: slices.Clone(failed.OwnerReferences),
+		},
+		Spec: *failed.Spec.DeepCopy(),
+	}
+	job.Spec.Selector = nil
+	job.Spec.ManualSelector = nil
+	job.Spec.Suspend = nil
+	for _, key := range []string{batchv1.ControllerUidLabel, batchv1.JobNameLabel, "controller-uid", "job-name"} {
+		delete(job.Spec.Template.Labels, key)
+	}
+	return job
+}
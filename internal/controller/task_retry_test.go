@@ -0,0 +1,242 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	int32Ptr := func(v int32) *int32 { return &v }
+	tests := []struct {
+		name    string
+		policy  kubetaskv1alpha1.RetryPolicy
+		retries int32
+		want    time.Duration
+	}{
+		{name: "default", want: DefaultRetryBackoff},
+		{name: "first retry", policy: kubetaskv1alpha1.RetryPolicy{BackoffSeconds: int32Ptr(30)}, want: 30 * time.Second},
+		{name: "doubles", policy: kubetaskv1alpha1.RetryPolicy{BackoffSeconds: int32Ptr(30)}, retries: 2, want: 2 * time.Minute},
+		{name: "capped", policy: kubetaskv1alpha1.RetryPolicy{BackoffSeconds: int32Ptr(600)}, retries: 9, want: MaxRetryBackoff},
+		{name: "immediate", policy: kubetaskv1alpha1.RetryPolicy{BackoffSeconds: int32Ptr(0)}, retries: 3, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryBackoff(&tt.policy, tt.retries); got != tt.want {
+				t.Errorf("retryBackoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJobFailureKind(t *testing.T) {
+	terminated := func(reason string, exitCode int32) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  DefaultAgentContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode}},
+		}}}}
+	}
+	evicted := terminated("Error", 137)
+	evicted.Status.Reason = "Evicted"
+	deadline := jobWith(0, 1, batchv1.JobFailed)
+	deadline.Status.Conditions[0].Reason = batchv1.JobReasonDeadlineExceeded
+
+	tests := []struct {
+		name string
+		job  *batchv1.Job
+		pod  *corev1.Pod
+		want kubetaskv1alpha1.RetryOn
+	}{
+		{name: "agent exited with an error", job: jobWith(0, 1, batchv1.JobFailed), pod: terminated("Error", 1), want: kubetaskv1alpha1.RetryOnFailed},
+		{name: "OOM killed", job: jobWith(0, 1, batchv1.JobFailed), pod: terminated("OOMKilled", 137), want: kubetaskv1alpha1.RetryOnError},
+		{name: "evicted", job: jobWith(0, 1, batchv1.JobFailed), pod: evicted, want: kubetaskv1alpha1.RetryOnError},
+		{name: "deadline exceeded", job: deadline, pod: terminated("Error", 1), want: kubetaskv1alpha1.RetryOnError},
+		{name: "no Pod", job: jobWith(0, 1, batchv1.JobFailed), want: kubetaskv1alpha1.RetryOnError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobFailureKind(tt.job, tt.pod); got != tt.want {
+				t.Errorf("jobFailureKind() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile_RetriesFailedJob(t *testing.T) {
+	scheme := newTestScheme(t)
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	backoff := int32(30)
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default", UID: "task-uid"},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			RetryPolicy: &kubetaskv1alpha1.RetryPolicy{MaxRetries: 1, BackoffSeconds: &backoff},
+		},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:     kubetaskv1alpha1.TaskPhaseRunning,
+			JobName:   "fix-job",
+			Attempts:  1,
+			StartTime: &metav1.Time{Time: start},
+		},
+	}
+	isController := true
+	failedJob := func(name string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "default",
+				Labels:          map[string]string{TaskLabelKey: "fix"},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "kubetask.io/v1alpha1", Kind: "Task", Name: "fix", UID: "task-uid", Controller: &isController}},
+			},
+			Spec: batchv1.JobSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{batchv1.ControllerUidLabel: "uid-1"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{TaskLabelKey: "fix", batchv1.ControllerUidLabel: "uid-1", batchv1.JobNameLabel: name}},
+				},
+			},
+			Status: batchv1.JobStatus{
+				Failed:     1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix-job-abcde", Namespace: "default", Labels: map[string]string{TaskLabelKey: "fix"}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  DefaultAgentContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+		}}},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(task, failedJob("fix-job"), pod).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}, &batchv1.Job{}).
+		Build()
+
+	ctx := context.Background()
+	reconcileAt := func(now time.Time) (ctrl.Result, *kubetaskv1alpha1.Task) {
+		t.Helper()
+		r := &TaskReconciler{Client: c, Clock: staticClock{now: now}}
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "fix", Namespace: "default"}})
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		updated := &kubetaskv1alpha1.Task{}
+		if err := c.Get(ctx, types.NamespacedName{Name: "fix", Namespace: "default"}, updated); err != nil {
+			t.Fatal(err)
+		}
+		return result, updated
+	}
+
+	// The failed attempt schedules a retry instead of failing the Task
+	_, scheduled := reconcileAt(start.Add(time.Minute))
+	if scheduled.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning || scheduled.Status.NextRetryTime == nil ||
+		!scheduled.Status.NextRetryTime.Time.Equal(start.Add(time.Minute+30*time.Second)) {
+		t.Fatalf("status = %s, nextRetryTime %v, want Running with a retry in 30s", scheduled.Status.Phase, scheduled.Status.NextRetryTime)
+	}
+	if ready := meta.FindStatusCondition(scheduled.Status.Conditions, "Ready"); ready == nil || ready.Reason != "RetryScheduled" {
+		t.Errorf("Ready = %+v, want RetryScheduled", ready)
+	}
+
+	// The Job is not recreated before the backoff has passed
+	result, _ := reconcileAt(start.Add(time.Minute + 10*time.Second))
+	if result.RequeueAfter != 20*time.Second {
+		t.Errorf("RequeueAfter = %v, want 20s", result.RequeueAfter)
+	}
+
+	_, retried := reconcileAt(start.Add(2 * time.Minute))
+	if retried.Status.Attempts != 2 || retried.Status.JobName != "fix-job-2" || retried.Status.NextRetryTime != nil {
+		t.Fatalf("status = attempts %d, job %s, nextRetryTime %v, want the second attempt in fix-job-2",
+			retried.Status.Attempts, retried.Status.JobName, retried.Status.NextRetryTime)
+	}
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "fix-job-2", Namespace: "default"}, job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Spec.Selector != nil || job.Spec.Template.Labels[batchv1.ControllerUidLabel] != "" || job.Spec.Template.Labels[TaskLabelKey] != "fix" {
+		t.Errorf("retry Job selector = %v, template labels = %v, want the generated selector and labels removed", job.Spec.Selector, job.Spec.Template.Labels)
+	}
+	if !metav1.IsControlledBy(job, retried) {
+		t.Error("retry Job is not controlled by the Task")
+	}
+
+	// The retry fails as well and exhausts the policy
+	job.Status = failedJob("fix-job-2").Status
+	if err := c.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	_, failed := reconcileAt(start.Add(3 * time.Minute))
+	if failed.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed {
+		t.Fatalf("phase = %s, want Failed", failed.Status.Phase)
+	}
+	if ready := meta.FindStatusCondition(failed.Status.Conditions, "Ready"); ready == nil || !strings.HasSuffix(ready.Message, "(after 2 attempts)") {
+		t.Errorf("Ready = %+v, want the number of attempts", ready)
+	}
+	jobs := &batchv1.JobList{}
+	if err := c.List(ctx, jobs, client.InNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 2 {
+		t.Errorf("got %d Jobs, want the failed attempts kept", len(jobs.Items))
+	}
+}
+
+func TestScheduleRetry_RetryOn(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		Spec: kubetaskv1alpha1.TaskSpec{RetryPolicy: &kubetaskv1alpha1.RetryPolicy{
+			MaxRetries: 3,
+			RetryOn:    []kubetaskv1alpha1.RetryOn{kubetaskv1alpha1.RetryOnError},
+		}},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{Attempts: 1},
+	}
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		Name:  DefaultAgentContainerName,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 2}},
+	}}}}
+
+	// The agent's own failure is not retried when only errors are
+	r := &TaskReconciler{}
+	retrying, err := r.scheduleRetry(context.Background(), task, jobWith(0, 1, batchv1.JobFailed), pod, metav1.Condition{})
+	if err != nil || retrying {
+		t.Errorf("scheduleRetry() = %v, %v, want no retry", retrying, err)
+	}
+}
+
+func TestScheduleRetry_WaitsForFailedJob(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		Spec:   kubetaskv1alpha1.TaskSpec{RetryPolicy: &kubetaskv1alpha1.RetryPolicy{MaxRetries: 3}},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning, Attempts: 1},
+	}
+
+	// A failed Pod of a Job without a Failed condition may still be replaced by the
+	// Job, so neither a retry is scheduled nor the Task failed
+	r := &TaskReconciler{}
+	retrying, err := r.scheduleRetry(context.Background(), task, jobWith(0, 1), nil, metav1.Condition{})
+	if err != nil || !retrying {
+		t.Errorf("scheduleRetry() = %v, %v, want the Task kept waiting", retrying, err)
+	}
+	if task.Status.NextRetryTime != nil || task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+		t.Errorf("status = %s, nextRetryTime %v, want Running without a retry", task.Status.Phase, task.Status.NextRetryTime)
+	}
+}
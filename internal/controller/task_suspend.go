@@ -0,0 +1,86 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// SuspendedConditionType reports whether the Task is paused by spec.suspend
+const SuspendedConditionType = "Suspended"
+
+// taskSuspended reports whether the Task's spec asks to pause it
+func taskSuspended(task *kubetaskv1alpha1.Task) bool {
+	return task.Spec.Suspend != nil && *task.Spec.Suspend
+}
+
+// isSuspended reports whether the Task's Suspended condition is True
+func isSuspended(task *kubetaskv1alpha1.Task) bool {
+	return meta.IsStatusConditionTrue(task.Status.Conditions, SuspendedConditionType)
+}
+
+// setSuspendedCondition records that the Task was suspended or resumed and reports
+// whether the condition changed. Tasks that were never suspended get no condition.
+func setSuspendedCondition(task *kubetaskv1alpha1.Task, suspended bool, message string) bool {
+	if !suspended && meta.FindStatusCondition(task.Status.Conditions, SuspendedConditionType) == nil {
+		return false
+	}
+	condition := metav1.Condition{
+		Type:    SuspendedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "TaskSuspended",
+		Message: message,
+	}
+	if !suspended {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "TaskResumed"
+	}
+	return meta.SetStatusCondition(&task.Status.Conditions, condition)
+}
+
+// reconcileSuspend suspends or resumes the Job of a running Task as spec.suspend
+// requires. Suspending the Job deletes its Pod; resuming creates a new one.
+func (r *TaskReconciler) reconcileSuspend(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	log := log.FromContext(ctx)
+
+	suspend := taskSuspended(task)
+	if task.Status.JobName == "" || (!suspend && !isSuspended(task)) {
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: task.Status.JobName, Namespace: task.Namespace}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if jobSuspended := job.Spec.Suspend != nil && *job.Spec.Suspend; jobSuspended != suspend {
+		existing := job.DeepCopy()
+		job.Spec.Suspend = &suspend
+		r.logDiff(ctx, "update", "Job", job.Name, existing.Spec, job.Spec, false)
+		if err := r.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	message := fmt.Sprintf("Job %s resumed", job.Name)
+	if suspend {
+		message = fmt.Sprintf("Job %s is suspended and its Pod deleted", job.Name)
+	}
+	if !setSuspendedCondition(task, suspend, message) {
+		return nil
+	}
+	log.Info("changed Task suspension", "job", job.Name, "suspended", suspend)
+	return r.Status().Update(ctx, task)
+}
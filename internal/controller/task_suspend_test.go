@@ -0,0 +1,100 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func setTaskSuspend(t *testing.T, c client.Client, suspend bool) {
+	t.Helper()
+	task := &kubetaskv1alpha1.Task{}
+	if err := c.Get(context.Background(), initTaskKey, task); err != nil {
+		t.Fatal(err)
+	}
+	task.Spec.Suspend = &suspend
+	if err := c.Update(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSuspend_PendingTask(t *testing.T) {
+	agent, task := newInitTestObjects()
+	suspend := true
+	task.Spec.Suspend = &suspend
+	c := newInitTestClient(t, interceptor.Funcs{}, agent, task)
+
+	held, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if held.Status.Phase != kubetaskv1alpha1.TaskPhasePending || held.Status.JobName != "" {
+		t.Errorf("status = %s/%q, want Pending without a Job", held.Status.Phase, held.Status.JobName)
+	}
+	if !isSuspended(held) {
+		t.Error("expected the Suspended condition to be True")
+	}
+	if ready := meta.FindStatusCondition(held.Status.Conditions, "Ready"); ready == nil || ready.Reason != "Suspended" {
+		t.Errorf("Ready condition = %+v, want reason Suspended", ready)
+	}
+
+	setTaskSuspend(t, c, false)
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+	cond := meta.FindStatusCondition(started.Status.Conditions, SuspendedConditionType)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "TaskResumed" {
+		t.Errorf("Suspended condition = %+v, want False/TaskResumed", cond)
+	}
+}
+
+func TestSuspend_RunningTask(t *testing.T) {
+	_, task := newInitTestObjects()
+	task.Status = kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning, JobName: "fix-job"}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "fix-job", Namespace: "default"}}
+	c := newInitTestClient(t, interceptor.Funcs{}, task, job)
+	jobKey := types.NamespacedName{Name: "fix-job", Namespace: "default"}
+
+	// Tasks that were never suspended get no condition
+	running, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if meta.FindStatusCondition(running.Status.Conditions, SuspendedConditionType) != nil {
+		t.Error("unexpected Suspended condition")
+	}
+
+	for _, suspend := range []bool{true, false} {
+		setTaskSuspend(t, c, suspend)
+		updated, err := reconcileInitTask(t, c)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if err := c.Get(context.Background(), jobKey, job); err != nil {
+			t.Fatal(err)
+		}
+		if job.Spec.Suspend == nil || *job.Spec.Suspend != suspend {
+			t.Errorf("Job suspend = %v, want %v", job.Spec.Suspend, suspend)
+		}
+		if updated.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+			t.Errorf("phase = %s, want Running", updated.Status.Phase)
+		}
+		if isSuspended(updated) != suspend {
+			t.Errorf("Suspended condition = %v, want %v", isSuspended(updated), suspend)
+		}
+	}
+}
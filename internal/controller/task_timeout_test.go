@@ -0,0 +1,89 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestTaskTimeoutSeconds(t *testing.T) {
+	taskTimeout, defaultTimeout := int64(600), int64(3600)
+	config := &kubetaskv1alpha1.KubeTaskConfig{Spec: kubetaskv1alpha1.KubeTaskConfigSpec{
+		TaskLifecycle: &kubetaskv1alpha1.TaskLifecycleConfig{DefaultTimeoutSeconds: &defaultTimeout},
+	}}
+
+	tests := []struct {
+		name    string
+		timeout *int64
+		config  *kubetaskv1alpha1.KubeTaskConfig
+		want    *int64
+	}{
+		{name: "no timeout"},
+		{name: "no namespace default", timeout: &taskTimeout, config: &kubetaskv1alpha1.KubeTaskConfig{}, want: &taskTimeout},
+		{name: "namespace default", config: config, want: &defaultTimeout},
+		{name: "task timeout wins", timeout: &taskTimeout, config: config, want: &taskTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{Spec: kubetaskv1alpha1.TaskSpec{TimeoutSeconds: tt.timeout}}
+			got := taskTimeoutSeconds(task, tt.config)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("taskTimeoutSeconds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile_TaskTimeout(t *testing.T) {
+	agent, task := newInitTestObjects()
+	timeout := int64(600)
+	task.Spec.TimeoutSeconds = &timeout
+	c := newInitTestClient(t, interceptor.Funcs{}, agent, task)
+	ctx := context.Background()
+
+	started, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	assertTaskStarted(t, c, started)
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "fix-job", Namespace: "default"}, job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != timeout {
+		t.Fatalf("activeDeadlineSeconds = %v, want the Task's timeout", job.Spec.ActiveDeadlineSeconds)
+	}
+
+	// The Job controller stops the agent at the deadline
+	job.Status.Failed = 1
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: batchv1.JobReasonDeadlineExceeded,
+		LastTransitionTime: metav1.Now(),
+	}}
+	if err := c.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	failed, err := reconcileInitTask(t, c)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if failed.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed {
+		t.Fatalf("phase = %s, want Failed", failed.Status.Phase)
+	}
+	ready := meta.FindStatusCondition(failed.Status.Conditions, "Ready")
+	if ready == nil || ready.Reason != "DeadlineExceeded" || ready.Message != "Job fix-job exceeded the timeout of 10m0s" {
+		t.Errorf("Ready = %+v, want DeadlineExceeded with the timeout", ready)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// recordPodTimings records when the agent Pod was scheduled and when the agent
+// container started. Only the first Pod of a Task is recorded, so retries do not
+// hide the original latency. It returns whether the status changed.
+func recordPodTimings(task *kubetaskv1alpha1.Task, pod *corev1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	changed := false
+
+	if task.Status.PodScheduledTime == nil {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionTrue {
+				t := c.LastTransitionTime
+				task.Status.PodScheduledTime = &t
+				changed = true
+			}
+		}
+	}
+
+	if task.Status.AgentStartTime == nil {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != agentContainerName(pod) {
+				continue
+			}
+			if startedAt := containerStartTime(cs); startedAt != nil {
+				task.Status.AgentStartTime = startedAt
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// containerStartTime returns when a container first started, or nil if it has not
+func containerStartTime(cs corev1.ContainerStatus) *metav1.Time {
+	var startedAt metav1.Time
+	switch {
+	case cs.LastTerminationState.Terminated != nil:
+		startedAt = cs.LastTerminationState.Terminated.StartedAt
+	case cs.State.Running != nil:
+		startedAt = cs.State.Running.StartedAt
+	case cs.State.Terminated != nil:
+		startedAt = cs.State.Terminated.StartedAt
+	}
+	if startedAt.IsZero() {
+		return nil
+	}
+	return &startedAt
+}
+
+// observeTaskTimings exports the latency of each stage the Task completed since
+// prev was recorded. Call it once the status is persisted, so conflicting updates
+// are not observed twice.
+func observeTaskTimings(prev *kubetaskv1alpha1.TaskExecutionStatus, task *kubetaskv1alpha1.Task) {
+	status := &task.Status
+	agent := taskAgentName(task)
+
+	if prev.StartTime == nil && status.StartTime != nil {
+		taskQueueDuration.WithLabelValues(task.Namespace, agent).
+			Observe(stageSeconds(task.CreationTimestamp, *status.StartTime))
+	}
+	if prev.PodScheduledTime == nil && status.PodScheduledTime != nil && status.StartTime != nil {
+		taskSchedulingDuration.WithLabelValues(task.Namespace, agent).
+			Observe(stageSeconds(*status.StartTime, *status.PodScheduledTime))
+	}
+	if prev.AgentStartTime == nil && status.AgentStartTime != nil && status.PodScheduledTime != nil {
+		taskStartupDuration.WithLabelValues(task.Namespace, agent).
+			Observe(stageSeconds(*status.PodScheduledTime, *status.AgentStartTime))
+	}
+	if prev.CompletionTime == nil && status.CompletionTime != nil && status.AgentStartTime != nil {
+		taskRunDuration.WithLabelValues(task.Namespace, agent, string(status.Phase)).
+			Observe(stageSeconds(*status.AgentStartTime, *status.CompletionTime))
+	}
+}
+
+// stageSeconds returns the seconds between two timestamps. Timestamps come from
+// different clocks (API server, kubelet), so small negative skews count as zero.
+func stageSeconds(from, to metav1.Time) float64 {
+	return max(to.Sub(from.Time), time.Duration(0)).Seconds()
+}
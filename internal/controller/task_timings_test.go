@@ -0,0 +1,151 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestRecordPodTimings(t *testing.T) {
+	scheduled := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 5, 0, time.UTC))
+	started := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 40, 0, time.UTC))
+	restarted := metav1.NewTime(time.Date(2026, 1, 1, 10, 5, 0, 0, time.UTC))
+
+	scheduledCondition := corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: scheduled}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		wantScheduled *metav1.Time
+		wantStarted   *metav1.Time
+	}{
+		{
+			name: "no pod",
+		},
+		{
+			name: "unschedulable",
+			pod: &corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable},
+			}}},
+		},
+		{
+			name: "pulling image",
+			pod: &corev1.Pod{Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{scheduledCondition},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "agent",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+				}},
+			}},
+			wantScheduled: &scheduled,
+		},
+		{
+			name: "running",
+			pod: &corev1.Pod{Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{scheduledCondition},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "agent",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: started}},
+				}},
+			}},
+			wantScheduled: &scheduled,
+			wantStarted:   &started,
+		},
+		{
+			name: "restarted in place reports the first start",
+			pod: &corev1.Pod{Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{scheduledCondition},
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:                 "agent",
+					State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: restarted}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: started}},
+				}},
+			}},
+			wantScheduled: &scheduled,
+			wantStarted:   &started,
+		},
+		{
+			name: "finished before it was observed running",
+			pod: &corev1.Pod{Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{scheduledCondition},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:  "sidecar",
+						State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: restarted}},
+					},
+					{
+						Name:  "agent",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: started}},
+					},
+				},
+			}},
+			wantScheduled: &scheduled,
+			wantStarted:   &started,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{}
+			changed := recordPodTimings(task, tt.pod)
+
+			if changed != (tt.wantScheduled != nil || tt.wantStarted != nil) {
+				t.Errorf("changed = %v", changed)
+			}
+			if !timeEqual(task.Status.PodScheduledTime, tt.wantScheduled) {
+				t.Errorf("podScheduledTime = %v, want %v", task.Status.PodScheduledTime, tt.wantScheduled)
+			}
+			if !timeEqual(task.Status.AgentStartTime, tt.wantStarted) {
+				t.Errorf("agentStartTime = %v, want %v", task.Status.AgentStartTime, tt.wantStarted)
+			}
+		})
+	}
+}
+
+func TestRecordPodTimings_KeepsFirstPod(t *testing.T) {
+	first := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	task := &kubetaskv1alpha1.Task{Status: kubetaskv1alpha1.TaskExecutionStatus{
+		PodScheduledTime: &first,
+		AgentStartTime:   &first,
+	}}
+	retry := &corev1.Pod{Status: corev1.PodStatus{
+		Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}},
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "agent",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()}},
+		}},
+	}}
+
+	if recordPodTimings(task, retry) {
+		t.Error("expected no change for a retried Pod")
+	}
+	if !task.Status.PodScheduledTime.Equal(&first) || !task.Status.AgentStartTime.Equal(&first) {
+		t.Errorf("timings of the first Pod were overwritten: %v, %v", task.Status.PodScheduledTime, task.Status.AgentStartTime)
+	}
+}
+
+func TestStageSeconds(t *testing.T) {
+	from := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	if got := stageSeconds(from, metav1.NewTime(from.Add(90*time.Second))); got != 90 {
+		t.Errorf("stageSeconds = %v, want 90", got)
+	}
+	// Clock skew between the API server and kubelet
+	if got := stageSeconds(from, metav1.NewTime(from.Add(-time.Second))); got != 0 {
+		t.Errorf("stageSeconds with skew = %v, want 0", got)
+	}
+}
+
+func timeEqual(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b)
+}
@@ -0,0 +1,152 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/quota"
+)
+
+// taskQuotaResyncPeriod is how often consumption is recomputed while Tasks are
+// counted, since runtime accrues and daily usage ages out without Task events
+const taskQuotaResyncPeriod = time.Minute
+
+// TaskQuotaReconciler reports the consumption of TaskQuotas.
+// The limits themselves are enforced by the Task admission webhook.
+type TaskQuotaReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Clock is used for usage windows and status timestamps. Defaults to the
+	// real clock.
+	Clock Clock
+}
+
+// +kubebuilder:rbac:groups=kubetask.io,resources=taskquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kubetask.io,resources=taskquotas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kubetask.io,resources=tasks,verbs=get;list;watch
+
+// Reconcile computes the consumption of a TaskQuota and publishes it to its status
+func (r *TaskQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	taskQuota := &kubetaskv1alpha1.TaskQuota{}
+	if err := r.Get(ctx, req.NamespacedName, taskQuota); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch TaskQuota")
+		return ctrl.Result{}, err
+	}
+
+	status := taskQuota.Status.DeepCopy()
+	status.ObservedGeneration = taskQuota.Generation
+
+	selector, err := quota.Selector(taskQuota)
+	if err != nil {
+		status.Used = kubetaskv1alpha1.TaskQuotaUsage{}
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               "Exceeded",
+			Status:             metav1.ConditionUnknown,
+			ObservedGeneration: taskQuota.Generation,
+			Reason:             "InvalidSelector",
+			Message:            "Quota is not enforced: " + err.Error(),
+		})
+		return ctrl.Result{}, r.updateStatus(ctx, taskQuota, status)
+	}
+
+	taskList := &kubetaskv1alpha1.TaskList{}
+	if err := r.List(ctx, taskList, client.InNamespace(taskQuota.Namespace)); err != nil {
+		log.Error(err, "unable to list Tasks")
+		return ctrl.Result{}, err
+	}
+
+	status.Used = quota.Usage(selector, taskList.Items, clockNow(r.Clock))
+	if exceeded := quota.Exceeded(taskQuota.Spec, status.Used); len(exceeded) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               "Exceeded",
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: taskQuota.Generation,
+			Reason:             "LimitReached",
+			Message:            strings.Join(exceeded, "; "),
+		})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               "Exceeded",
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: taskQuota.Generation,
+			Reason:             "WithinLimits",
+			Message:            "New Tasks are admitted",
+		})
+	}
+
+	if err := r.updateStatus(ctx, taskQuota, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Nothing ages out of the window once no Task is counted
+	if status.Used == (kubetaskv1alpha1.TaskQuotaUsage{}) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: taskQuotaResyncPeriod}, nil
+}
+
+// updateStatus writes status if it changed, ignoring LastUpdateTime
+func (r *TaskQuotaReconciler) updateStatus(ctx context.Context, taskQuota *kubetaskv1alpha1.TaskQuota, status *kubetaskv1alpha1.TaskQuotaStatus) error {
+	status.LastUpdateTime = taskQuota.Status.LastUpdateTime
+	if equality.Semantic.DeepEqual(&taskQuota.Status, status) {
+		return nil
+	}
+
+	now := metav1.NewTime(clockNow(r.Clock))
+	status.LastUpdateTime = &now
+	taskQuota.Status = *status
+	if err := r.Status().Update(ctx, taskQuota); err != nil {
+		log.FromContext(ctx).Error(err, "unable to update TaskQuota status")
+		return err
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *TaskQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubetaskv1alpha1.TaskQuota{}).
+		Watches(&kubetaskv1alpha1.Task{}, handler.EnqueueRequestsFromMapFunc(taskToTaskQuotas(mgr.GetCache()))).
+		Complete(r)
+}
+
+// taskToTaskQuotas enqueues every TaskQuota in the namespace of a changed Task
+func taskToTaskQuotas(reader client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		quotaList := &kubetaskv1alpha1.TaskQuotaList{}
+		if err := reader.List(ctx, quotaList, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list TaskQuotas for Task change", "namespace", obj.GetNamespace())
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(quotaList.Items))
+		for _, taskQuota := range quotaList.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: taskQuota.Name, Namespace: taskQuota.Namespace},
+			})
+		}
+		return requests
+	}
+}
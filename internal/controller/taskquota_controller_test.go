@@ -0,0 +1,80 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build integration
+
+// See suite_test.go for explanation of the "integration" build tag pattern.
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+var _ = Describe("TaskQuotaController", func() {
+	const (
+		quotaNamespace = "default"
+	)
+
+	Context("When Tasks match a TaskQuota", func() {
+		It("Should report consumption and the Exceeded condition", func() {
+			quotaName := "test-quota-usage"
+			maxTasksPerDay := int32(1)
+
+			By("Creating a TaskQuota for one team")
+			taskQuota := &kubetaskv1alpha1.TaskQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      quotaName,
+					Namespace: quotaNamespace,
+				},
+				Spec: kubetaskv1alpha1.TaskQuotaSpec{
+					Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "quota-test"}},
+					MaxTasksPerDay: &maxTasksPerDay,
+				},
+			}
+			Expect(k8sClient.Create(ctx, taskQuota)).Should(Succeed())
+
+			quotaLookupKey := types.NamespacedName{Name: quotaName, Namespace: quotaNamespace}
+			updatedQuota := &kubetaskv1alpha1.TaskQuota{}
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, quotaLookupKey, updatedQuota); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionFalse(updatedQuota.Status.Conditions, "Exceeded")
+			}, timeout, interval).Should(BeTrue())
+			Expect(updatedQuota.Status.Used.TasksPerDay).Should(BeZero())
+
+			By("Creating a Task for the team")
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-quota-usage-task",
+					Namespace: quotaNamespace,
+					Labels:    map[string]string{"team": "quota-test"},
+				},
+				Spec: kubetaskv1alpha1.TaskSpec{
+					Description: stringPtr("Count me"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, task)).Should(Succeed())
+
+			By("Checking the quota reports the Task and is exhausted")
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, quotaLookupKey, updatedQuota); err != nil {
+					return false
+				}
+				return meta.IsStatusConditionTrue(updatedQuota.Status.Conditions, "Exceeded")
+			}, timeout, interval).Should(BeTrue())
+			Expect(updatedQuota.Status.Used.TasksPerDay).Should(Equal(int32(1)))
+			Expect(updatedQuota.Status.LastUpdateTime).ShouldNot(BeNil())
+
+			By("Cleaning up")
+			Expect(k8sClient.Delete(ctx, task)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, taskQuota)).Should(Succeed())
+		})
+	})
+})
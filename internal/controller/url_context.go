@@ -0,0 +1,293 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxURLContextBytes caps the content of a URL Context, leaving room for other
+// contexts in the 1 MiB context ConfigMap it is written to
+const maxURLContextBytes = 512 << 10
+
+// sharedAddressSpace is the carrier-grade NAT range 100.64.0.0/10, which some
+// clusters use for Pod and Service addresses
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicAddress reports whether URL Contexts may be fetched from ip: not loopback,
+// private, link-local (such as the cloud metadata endpoint 169.254.169.254),
+// shared, multicast or unspecified
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
+
+// hostListed reports whether host is one of the allowed hosts by its exact name,
+// not through a wildcard
+func hostListed(host string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if !strings.HasPrefix(pattern, "*") && strings.EqualFold(host, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// dialURLContext returns a dial function that resolves the host itself and refuses
+// to connect when it has a non-public address. The check runs on every connection,
+// so it covers redirects and DNS names pointing at cluster Services or the metadata
+// endpoint. Hosts listed by exact name in allowed may be internal, e.g. a wiki in
+// the cluster.
+func dialURLContext(dialer *net.Dialer, allowed []string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if hostListed(host, allowed) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			if !publicAddress(ip.IP) {
+				return nil, fmt.Errorf("%s has the non-public address %s; list it in --url-context-allowed-hosts to fetch URL Contexts from it", host, ip.IP)
+			}
+		}
+		// Connect to the checked addresses, so a second lookup cannot return others
+		var lastErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.IP.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
+
+// urlContextClient returns the HTTP client URL Contexts are fetched with: the
+// TaskReconciler's URLContextHTTPClient, else a client with a 30s timeout that only
+// connects to public addresses and hosts listed in URLContextAllowedHosts. It does
+// not use a proxy, whose address would be internal.
+func (r *TaskReconciler) urlContextClient() *http.Client {
+	if r.URLContextHTTPClient != nil {
+		return r.URLContextHTTPClient
+	}
+	r.urlContextClientOnce.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = nil
+		transport.DialContext = dialURLContext(&net.Dialer{Timeout: 30 * time.Second}, r.URLContextAllowedHosts)
+		r.guardedURLContextClient = &http.Client{Timeout: 30 * time.Second, Transport: transport}
+	})
+	return r.guardedURLContextClient
+}
+
+// urlContextCache keeps the content of URL Contexts with a CacheTTL, so a batch of
+// Tasks using the same Context fetches it once
+type urlContextCache struct {
+	mu      sync.Mutex
+	entries map[string]urlContextCacheEntry
+}
+
+type urlContextCacheEntry struct {
+	content string
+	expires time.Time
+}
+
+// get returns the cached content for key, if it has not expired at now
+func (c *urlContextCache) get(key string, now time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return "", false
+	}
+	return entry.content, true
+}
+
+// put caches content for key until now+ttl, dropping expired entries
+func (c *urlContextCache) put(key, content string, now time.Time, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]urlContextCacheEntry{}
+	}
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = urlContextCacheEntry{content: content, expires: now.Add(ttl)}
+}
+
+// urlContextCacheKey identifies the content of a URL within a namespace. The
+// request headers are part of the key, so content fetched with one set of
+// credentials is never served to a Context using other ones.
+func urlContextCacheKey(namespace, rawURL string, headers http.Header) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", namespace, rawURL)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s: %s\n", name, strings.Join(headers[name], ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hostAllowed reports whether URL Contexts may be fetched from host. An empty
+// list allows any host; "*.example.com" allows the subdomains of example.com.
+func hostAllowed(host string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+			if strings.HasSuffix(host, suffix) && host != strings.TrimPrefix(suffix, ".") {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURLContext fetches the content of a URL Context, verifying its checksum
+// and reusing cached content within the Context's CacheTTL
+func (r *TaskReconciler) resolveURLContext(ctx context.Context, req ContextResolveRequest) (ContextResolution, error) {
+	spec := req.Context.Spec.URL
+	if spec == nil {
+		return ContextResolution{}, nil
+	}
+	name, namespace := req.Context.Name, req.Context.Namespace
+
+	target, err := url.Parse(spec.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return ContextResolution{}, fmt.Errorf("Context %q has an invalid URL %q", name, spec.URL)
+	}
+	if !hostAllowed(target.Hostname(), r.URLContextAllowedHosts) {
+		return ContextResolution{}, fmt.Errorf("Context %q fetches from %s, which is not an allowed URL Context host", name, target.Hostname())
+	}
+
+	headers := http.Header{}
+	if spec.HeadersSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: spec.HeadersSecretRef.Name, Namespace: namespace}, secret); err != nil {
+			return ContextResolution{}, fmt.Errorf("get headers Secret of Context %q: %w", name, err)
+		}
+		for key, value := range secret.Data {
+			headers.Set(key, string(value))
+		}
+	}
+
+	now := clockNow(r.Clock)
+	key := urlContextCacheKey(namespace, spec.URL, headers)
+	content, cached := "", false
+	if spec.CacheTTL != nil && spec.CacheTTL.Duration > 0 {
+		content, cached = r.urlContexts.get(key, now)
+	}
+	if !cached {
+		if content, err = r.fetchURLContext(ctx, spec.URL, headers); err != nil {
+			return ContextResolution{}, fmt.Errorf("fetch Context %q: %w", name, err)
+		}
+	}
+
+	if spec.SHA256 != "" {
+		sum := sha256.Sum256([]byte(content))
+		if got := hex.EncodeToString(sum[:]); got != spec.SHA256 {
+			return ContextResolution{}, fmt.Errorf("content of Context %q has checksum %s, want %s", name, got, spec.SHA256)
+		}
+	}
+	if !cached && spec.CacheTTL != nil && spec.CacheTTL.Duration > 0 {
+		r.urlContexts.put(key, content, now, spec.CacheTTL.Duration)
+	}
+	return ContextResolution{Content: content}, nil
+}
+
+// fetchURLContext GETs rawURL with headers and returns the response body
+func (r *TaskReconciler) fetchURLContext(ctx context.Context, rawURL string, headers http.Header) (string, error) {
+	httpClient := r.urlContextClient()
+	if len(r.URLContextAllowedHosts) > 0 {
+		// Redirects must not lead to hosts that are not allowed either
+		restricted := *httpClient
+		checkRedirect := httpClient.CheckRedirect
+		restricted.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if !hostAllowed(req.URL.Hostname(), r.URLContextAllowedHosts) {
+				return fmt.Errorf("redirect to %s, which is not an allowed URL Context host", req.URL.Hostname())
+			}
+			if checkRedirect != nil {
+				return checkRedirect(req, via)
+			}
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return nil
+		}
+		httpClient = &restricted
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+	return readContextResponse(httpClient, req, rawURL)
+}
+
+// doContextRequest sends a request for the content of a Context with the client of
+// URL Contexts, so it reaches the same hosts, and returns the response body
+func (r *TaskReconciler) doContextRequest(req *http.Request, rawURL string) (string, error) {
+	return readContextResponse(r.urlContextClient(), req, rawURL)
+}
+
+// readContextResponse sends req and returns the response body, which must not
+// exceed maxURLContextBytes. Errors name the request by rawURL, so signed URLs
+// do not end up in Task conditions.
+func readContextResponse(httpClient *http.Client, req *http.Request, rawURL string) (string, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if stderrors.As(err, &urlErr) {
+			urlErr.URL = rawURL
+		}
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("GET %s returned %s", rawURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxURLContextBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read response of %s: %w", rawURL, err)
+	}
+	if len(body) > maxURLContextBytes {
+		return "", fmt.Errorf("response of %s exceeds %d bytes", rawURL, maxURLContextBytes)
+	}
+	return string(body), nil
+}
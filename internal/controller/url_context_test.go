@@ -0,0 +1,207 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestHostAllowed(t *testing.T) {
+	allowed := []string{"wiki.example.com", "*.storage.example.com"}
+	tests := []struct {
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{host: "169.254.169.254", want: true},
+		{host: "wiki.example.com", allowed: allowed, want: true},
+		{host: "WIKI.example.com", allowed: allowed, want: true},
+		{host: "bucket.storage.example.com", allowed: allowed, want: true},
+		{host: "storage.example.com", allowed: allowed},
+		{host: "evilstorage.example.com", allowed: allowed},
+		{host: "169.254.169.254", allowed: allowed},
+	}
+	for _, tt := range tests {
+		if got := hostAllowed(tt.host, tt.allowed); got != tt.want {
+			t.Errorf("hostAllowed(%q, %v) = %v, want %v", tt.host, tt.allowed, got, tt.want)
+		}
+	}
+}
+
+func TestResolveURLContext(t *testing.T) {
+	const runbook = "# Runbook\nRestart the pod."
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/runbook.md":
+			requests++
+			if req.Header.Get("Authorization") != "Bearer wiki-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(runbook))
+		case "/large.md":
+			_, _ = w.Write([]byte(strings.Repeat("x", maxURLContextBytes+1)))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "wiki-headers", Namespace: "default"},
+		Data:       map[string][]byte{"Authorization": []byte("Bearer wiki-token")},
+	}
+	now := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	r := &TaskReconciler{
+		Client:               fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(secret).Build(),
+		URLContextHTTPClient: server.Client(),
+		Clock:                staticClock{now: now},
+	}
+	resolve := func(spec kubetaskv1alpha1.URLContext) (string, error) {
+		t.Helper()
+		resolution, err := r.resolveURLContext(context.Background(), ContextResolveRequest{Context: &kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "runbook", Namespace: "default"},
+			Spec:       kubetaskv1alpha1.ContextSpec{Type: kubetaskv1alpha1.ContextTypeURL, URL: &spec},
+		}})
+		return resolution.Content, err
+	}
+	sum := sha256.Sum256([]byte(runbook))
+	headers := &corev1.LocalObjectReference{Name: "wiki-headers"}
+	cached := kubetaskv1alpha1.URLContext{
+		URL: server.URL + "/runbook.md", HeadersSecretRef: headers,
+		SHA256: hex.EncodeToString(sum[:]), CacheTTL: &metav1.Duration{Duration: time.Minute},
+	}
+
+	content, err := resolve(cached)
+	if err != nil || content != runbook {
+		t.Fatalf("resolveURLContext() = %q, %v, want the runbook", content, err)
+	}
+	if content, err = resolve(cached); err != nil || content != runbook || requests != 1 {
+		t.Errorf("resolveURLContext() = %q, %v after %d requests, want the cached runbook", content, err, requests)
+	}
+	r.Clock = staticClock{now: now.Add(time.Minute)}
+	if _, err = resolve(cached); err != nil || requests != 2 {
+		t.Errorf("resolveURLContext() error = %v after %d requests, want the runbook fetched again after the TTL", err, requests)
+	}
+
+	for _, tt := range []struct {
+		name string
+		spec kubetaskv1alpha1.URLContext
+		want string
+	}{
+		{name: "missing headers", spec: kubetaskv1alpha1.URLContext{URL: server.URL + "/runbook.md"}, want: "401 Unauthorized"},
+		{name: "not found", spec: kubetaskv1alpha1.URLContext{URL: server.URL + "/missing.md"}, want: "404 Not Found"},
+		{name: "too large", spec: kubetaskv1alpha1.URLContext{URL: server.URL + "/large.md"}, want: "exceeds"},
+		{
+			name: "checksum mismatch",
+			spec: kubetaskv1alpha1.URLContext{URL: server.URL + "/runbook.md", HeadersSecretRef: headers, SHA256: strings.Repeat("0", 64)},
+			want: "checksum",
+		},
+		{
+			name: "missing headers Secret",
+			spec: kubetaskv1alpha1.URLContext{URL: server.URL + "/runbook.md", HeadersSecretRef: &corev1.LocalObjectReference{Name: "missing"}},
+			want: "not found",
+		},
+		{name: "unsupported scheme", spec: kubetaskv1alpha1.URLContext{URL: "file:///etc/passwd"}, want: "invalid URL"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := resolve(tt.spec); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("resolveURLContext() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("host not allowed", func(t *testing.T) {
+		r.URLContextAllowedHosts = []string{"wiki.example.com"}
+		defer func() { r.URLContextAllowedHosts = nil }()
+		if _, err := resolve(cached); err == nil || !strings.Contains(err.Error(), "not an allowed URL Context host") {
+			t.Errorf("resolveURLContext() error = %v, want the host rejected", err)
+		}
+	})
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.96.0.1":       false,
+		"172.16.0.10":     false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00:ec2::254":   false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for address, want := range tests {
+		if got := publicAddress(net.ParseIP(address)); got != want {
+			t.Errorf("publicAddress(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
+
+func TestResolveURLContext_InternalAddresses(t *testing.T) {
+	const runbook = "# Runbook"
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(runbook))
+	}))
+	defer internal.Close()
+	_, port, _ := net.SplitHostPort(internal.Listener.Addr().String())
+	// A listed host redirecting to an internal one, which only a wildcard allows
+	redirect := httptest.NewServer(http.RedirectHandler("http://localhost:"+port+"/runbook.md", http.StatusFound))
+	defer redirect.Close()
+
+	resolve := func(allowed []string, rawURL string) (string, error) {
+		t.Helper()
+		// Without URLContextHTTPClient, the guarded client is used
+		r := &TaskReconciler{URLContextAllowedHosts: allowed}
+		spec := kubetaskv1alpha1.URLContext{URL: rawURL}
+		resolution, err := r.resolveURLContext(context.Background(), ContextResolveRequest{Context: &kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "runbook", Namespace: "default"},
+			Spec:       kubetaskv1alpha1.ContextSpec{Type: kubetaskv1alpha1.ContextTypeURL, URL: &spec},
+		}})
+		return resolution.Content, err
+	}
+
+	for _, tt := range []struct {
+		name    string
+		allowed []string
+		url     string
+	}{
+		{name: "loopback without allowed hosts", url: internal.URL + "/runbook.md"},
+		{name: "loopback by name", url: "http://localhost:" + port + "/runbook.md"},
+		{name: "loopback through a wildcard", allowed: []string{"*"}, url: internal.URL + "/runbook.md"},
+		{name: "redirect to loopback", allowed: []string{"127.0.0.1", "*"}, url: redirect.URL},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := resolve(tt.allowed, tt.url); err == nil || !strings.Contains(err.Error(), "non-public address") {
+				t.Errorf("resolveURLContext() error = %v, want the internal address refused", err)
+			}
+		})
+	}
+
+	// Hosts listed by exact name may be internal
+	if content, err := resolve([]string{"127.0.0.1"}, internal.URL+"/runbook.md"); err != nil || content != runbook {
+		t.Errorf("resolveURLContext() = %q, %v, want the runbook of the listed host", content, err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/usage"
+)
+
+const (
+	// TaskAgentIndex indexes Tasks by the "namespace/name" keys of the Agents they run with
+	TaskAgentIndex = "kubetask.io/agent"
+
+	// TaskContextIndex indexes Tasks by the "namespace/name" keys of the Contexts in spec.contexts
+	TaskContextIndex = "kubetask.io/context"
+
+	// usageCollectTimeout bounds the cache reads of one metrics scrape
+	usageCollectTimeout = 10 * time.Second
+)
+
+var (
+	contextTasksDesc = prometheus.NewDesc("kubetask_context_tasks",
+		"Number of Tasks that use a Context, directly or through their Agent",
+		[]string{"namespace", "context"}, nil)
+	agentTasksDesc = prometheus.NewDesc("kubetask_agent_tasks",
+		"Number of Tasks that run with an Agent",
+		[]string{"namespace", "agent"}, nil)
+	credentialTasksDesc = prometheus.NewDesc("kubetask_credential_tasks",
+		"Number of Tasks whose Agent exposes a Secret through credentials or envFrom",
+		[]string{"namespace", "secret"}, nil)
+)
+
+// SetupUsageMetrics indexes Tasks by the Agents and Contexts they reference and
+// registers metrics reporting how many Tasks use each Context, Agent, and credential Secret
+func SetupUsageMetrics(ctx context.Context, mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(ctx, &kubetaskv1alpha1.Task{}, TaskAgentIndex, taskAgentKeys); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &kubetaskv1alpha1.Task{}, TaskContextIndex, taskContextKeys); err != nil {
+		return err
+	}
+	return metrics.Registry.Register(&usageCollector{reader: mgr.GetCache()})
+}
+
+// taskAgentKeys returns the TaskAgentIndex values of a Task
+func taskAgentKeys(obj client.Object) []string {
+	task := obj.(*kubetaskv1alpha1.Task)
+	var keys []string
+	for _, name := range usage.TaskAgents(&task.Spec) {
+		keys = append(keys, usage.Key(task.Namespace, name))
+	}
+	return keys
+}
+
+// taskContextKeys returns the TaskContextIndex values of a Task
+func taskContextKeys(obj client.Object) []string {
+	task := obj.(*kubetaskv1alpha1.Task)
+	return usage.ContextKeys(task.Namespace, task.Spec.Contexts)
+}
+
+// usageCollector counts the Tasks referencing each Agent, Context, and credential
+// Secret from the cache when metrics are scraped
+type usageCollector struct {
+	reader client.Reader
+}
+
+var _ prometheus.Collector = &usageCollector{}
+
+// Describe sends the descriptors of the usage metrics
+func (c *usageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- contextTasksDesc
+	ch <- agentTasksDesc
+	ch <- credentialTasksDesc
+}
+
+// Collect sends the current usage counts
+func (c *usageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), usageCollectTimeout)
+	defer cancel()
+
+	counts, err := c.usageCounts(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to collect usage metrics")
+		return
+	}
+	for key, tasks := range counts.contexts {
+		ch <- prometheus.MustNewConstMetric(contextTasksDesc, prometheus.GaugeValue, float64(len(tasks)), key.Namespace, key.Name)
+	}
+	for key, tasks := range counts.agents {
+		ch <- prometheus.MustNewConstMetric(agentTasksDesc, prometheus.GaugeValue, float64(len(tasks)), key.Namespace, key.Name)
+	}
+	for key, tasks := range counts.secrets {
+		ch <- prometheus.MustNewConstMetric(credentialTasksDesc, prometheus.GaugeValue, float64(len(tasks)), key.Namespace, key.Name)
+	}
+}
+
+// taskSet is a set of Task keys
+type taskSet map[types.NamespacedName]struct{}
+
+func (s taskSet) add(other taskSet) {
+	for key := range other {
+		s[key] = struct{}{}
+	}
+}
+
+// usageCounts holds the Tasks using each Context, Agent, and Secret
+type usageCounts struct {
+	contexts map[types.NamespacedName]taskSet
+	agents   map[types.NamespacedName]taskSet
+	secrets  map[types.NamespacedName]taskSet
+}
+
+// usageCounts looks up the Tasks of every Agent and Context through the Task indexes
+func (c *usageCollector) usageCounts(ctx context.Context) (*usageCounts, error) {
+	agents := &kubetaskv1alpha1.AgentList{}
+	if err := c.reader.List(ctx, agents); err != nil {
+		return nil, err
+	}
+	contexts := &kubetaskv1alpha1.ContextList{}
+	if err := c.reader.List(ctx, contexts); err != nil {
+		return nil, err
+	}
+
+	counts := &usageCounts{
+		contexts: map[types.NamespacedName]taskSet{},
+		agents:   map[types.NamespacedName]taskSet{},
+		secrets:  map[types.NamespacedName]taskSet{},
+	}
+	tasksOf := func(index, key string) (taskSet, error) {
+		tasks := &kubetaskv1alpha1.TaskList{}
+		if err := c.reader.List(ctx, tasks, client.MatchingFields{index: key}); err != nil {
+			return nil, err
+		}
+		set := make(taskSet, len(tasks.Items))
+		for _, task := range tasks.Items {
+			set[types.NamespacedName{Namespace: task.Namespace, Name: task.Name}] = struct{}{}
+		}
+		return set, nil
+	}
+	setOf := func(m map[types.NamespacedName]taskSet, key types.NamespacedName) taskSet {
+		if m[key] == nil {
+			m[key] = taskSet{}
+		}
+		return m[key]
+	}
+
+	for i := range contexts.Items {
+		item := &contexts.Items[i]
+		tasks, err := tasksOf(TaskContextIndex, usage.Key(item.Namespace, item.Name))
+		if err != nil {
+			return nil, err
+		}
+		setOf(counts.contexts, types.NamespacedName{Namespace: item.Namespace, Name: item.Name}).add(tasks)
+	}
+
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		tasks, err := tasksOf(TaskAgentIndex, usage.Key(agent.Namespace, agent.Name))
+		if err != nil {
+			return nil, err
+		}
+		setOf(counts.agents, types.NamespacedName{Namespace: agent.Namespace, Name: agent.Name}).add(tasks)
+
+		// Agent contexts and credentials apply to every Task of the Agent
+		for _, key := range agent.Spec.Contexts {
+			ns := key.Namespace
+			if ns == "" {
+				ns = agent.Namespace
+			}
+			setOf(counts.contexts, types.NamespacedName{Namespace: ns, Name: key.Name}).add(tasks)
+		}
+		for _, secret := range usage.AgentSecrets(agent) {
+			setOf(counts.secrets, types.NamespacedName{Namespace: agent.Namespace, Name: secret}).add(tasks)
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestUsageCounts(t *testing.T) {
+	standards := kubetaskv1alpha1.ContextMount{Name: "standards"}
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithIndex(&kubetaskv1alpha1.Task{}, TaskAgentIndex, taskAgentKeys).
+		WithIndex(&kubetaskv1alpha1.Task{}, TaskContextIndex, taskContextKeys).
+		WithObjects(
+			&kubetaskv1alpha1.Context{ObjectMeta: metav1.ObjectMeta{Name: "standards", Namespace: "team"}},
+			&kubetaskv1alpha1.Context{ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "team"}},
+			&kubetaskv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team"},
+				Spec: kubetaskv1alpha1.AgentSpec{
+					Contexts:    []kubetaskv1alpha1.ContextMount{standards},
+					Credentials: []kubetaskv1alpha1.Credential{{Name: "api", SecretRef: kubetaskv1alpha1.SecretReference{Name: "api-key"}}},
+				},
+			},
+			&kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "team"}},
+			// Counted once for standards although it mounts it directly and through its Agent
+			&kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "both", Namespace: "team"},
+				Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: "claude", Contexts: []kubetaskv1alpha1.ContextMount{standards}},
+			},
+			&kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "via-agent", Namespace: "team"},
+				Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: "claude"},
+			},
+			&kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "team"}},
+		).
+		Build()
+
+	counts, err := (&usageCollector{reader: c}).usageCounts(context.Background())
+	if err != nil {
+		t.Fatalf("usageCounts() error = %v", err)
+	}
+
+	key := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "team", Name: name}
+	}
+	tests := []struct {
+		name string
+		got  taskSet
+		want int
+	}{
+		{name: "context standards", got: counts.contexts[key("standards")], want: 2},
+		{name: "context unused", got: counts.contexts[key("unused")], want: 0},
+		{name: "agent claude", got: counts.agents[key("claude")], want: 2},
+		{name: "agent default", got: counts.agents[key("default")], want: 1},
+		{name: "secret api-key", got: counts.secrets[key("api-key")], want: 2},
+	}
+	for _, tt := range tests {
+		if len(tt.got) != tt.want {
+			t.Errorf("%s: got %d Tasks, want %d", tt.name, len(tt.got), tt.want)
+		}
+	}
+	if _, ok := counts.contexts[key("unused")]; !ok {
+		t.Errorf("unused Context should be reported with zero Tasks")
+	}
+}
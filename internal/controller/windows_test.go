@@ -0,0 +1,65 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestBuildAgentConfig_Windows(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	windowsPodSpec := &kubetaskv1alpha1.AgentPodSpec{
+		Scheduling: &kubetaskv1alpha1.PodScheduling{NodeSelector: map[string]string{build.OSLabelKey: "windows"}},
+	}
+	tests := []struct {
+		name    string
+		spec    kubetaskv1alpha1.AgentSpec
+		wantDir string
+		wantErr string
+	}{
+		{name: "default workspace", spec: kubetaskv1alpha1.AgentSpec{}, wantDir: `C:\workspace`},
+		{name: "explicit workspace", spec: kubetaskv1alpha1.AgentSpec{WorkspaceDir: `D:\src`}, wantDir: `D:\src`},
+		{name: "grace period only", spec: kubetaskv1alpha1.AgentSpec{
+			Cancellation: &kubetaskv1alpha1.AgentCancellation{GracePeriodSeconds: int64Ptr(300)},
+		}, wantDir: `C:\workspace`},
+		{name: "failure snapshot", spec: kubetaskv1alpha1.AgentSpec{
+			Command:         []string{"agent.exe"},
+			FailureSnapshot: &kubetaskv1alpha1.FailureSnapshot{Enabled: true, ClaimName: "snapshots"},
+		}, wantErr: "failureSnapshot"},
+		{name: "signal delay", spec: kubetaskv1alpha1.AgentSpec{
+			Command:      []string{"agent.exe"},
+			Cancellation: &kubetaskv1alpha1.AgentCancellation{SignalDelaySeconds: int64Ptr(10)},
+		}, wantErr: "cancellation.signalDelaySeconds"},
+		{name: "context compression", spec: kubetaskv1alpha1.AgentSpec{
+			ContextCompression: &kubetaskv1alpha1.ContextCompression{},
+		}, wantErr: "contextCompression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "windows"}, Spec: tt.spec}
+			agent.Spec.ServiceAccountName = "test-sa"
+			agent.Spec.PodSpec = windowsPodSpec
+			cfg, err := buildAgentConfig(agent, DefaultAgentImage)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("buildAgentConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildAgentConfig() error = %v", err)
+			}
+			if cfg.WorkspaceDir != tt.wantDir {
+				t.Errorf("WorkspaceDir = %q, want %q", cfg.WorkspaceDir, tt.wantDir)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright Contributors to the KubeTask project
+
+package controller
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// workspaceFileType is the type of resolved spec.files entries, in place of a Context type
+const workspaceFileType = "File"
+
+// workspaceFileData is the data that spec.files templates are rendered with
+type workspaceFileData struct {
+	Name         string
+	Namespace    string
+	Labels       map[string]string
+	Annotations  map[string]string
+	Description  string
+	WorkspaceDir string
+}
+
+// resolveWorkspaceFiles renders the Task's spec.files into files under the workspace
+// directory. Files must stay inside the workspace and must not take the place of
+// task.md or of a Context mounted at the same path.
+func resolveWorkspaceFiles(task *kubetaskv1alpha1.Task, description, workspaceDir string, resolved []build.ResolvedContext) ([]build.ResolvedContext, error) {
+	if len(task.Spec.Files) == 0 {
+		return nil, nil
+	}
+
+	mounted := make(map[string]string, len(resolved))
+	for _, rc := range resolved {
+		if rc.MountPath != "" {
+			mounted[rc.MountPath] = fmt.Sprintf("Context %q", rc.Name)
+		}
+	}
+
+	data := workspaceFileData{
+		Name:         task.Name,
+		Namespace:    task.Namespace,
+		Labels:       task.Labels,
+		Annotations:  task.Annotations,
+		Description:  description,
+		WorkspaceDir: workspaceDir,
+	}
+
+	files := make([]build.ResolvedContext, 0, len(task.Spec.Files))
+	for _, file := range task.Spec.Files {
+		cleaned := path.Clean(file.Path)
+		if path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return nil, fmt.Errorf("file %q must be relative to the workspace directory", file.Path)
+		}
+		if cleaned == "task.md" {
+			return nil, fmt.Errorf("file %q would replace task.md", file.Path)
+		}
+		mountPath := workspaceDir + "/" + cleaned
+		if name, ok := mounted[mountPath]; ok {
+			return nil, fmt.Errorf("file %q conflicts with %s mounted at %s", file.Path, name, mountPath)
+		}
+		mounted[mountPath] = fmt.Sprintf("file %q", file.Path)
+
+		content := file.Content
+		if file.Template != "" {
+			rendered, err := renderWorkspaceFile(file.Path, file.Template, data)
+			if err != nil {
+				return nil, err
+			}
+			content = rendered
+		}
+
+		files = append(files, build.ResolvedContext{
+			Name:      file.Path,
+			Namespace: task.Namespace,
+			Type:      workspaceFileType,
+			Content:   content,
+			MountPath: mountPath,
+		})
+	}
+	return files, nil
+}
+
+// renderWorkspaceFile executes a spec.files template. Referring to a missing map
+// key is an error, so a typo does not silently produce an empty value.
+func renderWorkspaceFile(name, text string, data workspaceFileData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("file %q has an invalid template: %w", name, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("unable to render file %q: %w", name, err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,85 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func TestProcessAllContexts_WorkspaceFiles(t *testing.T) {
+	r := &TaskReconciler{Client: fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()}
+	cfg := agentConfig{Config: build.Config{WorkspaceDir: "/workspace"}}
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default", Labels: map[string]string{"team": "sre"}},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			Description: stringPtr("Fix the flaky test"),
+			Files: []kubetaskv1alpha1.WorkspaceFile{
+				{Path: "plan.md", Template: "# Plan for {{ .Name }} ({{ .Labels.team }})\n\n{{ .Description }}\n"},
+				{Path: ".agent/config.json", Content: `{"maxTurns": 20}`},
+			},
+		},
+	}
+
+	cm, fileMounts, _, _, _, err := r.processAllContexts(context.Background(), task, cfg)
+	if err != nil {
+		t.Fatalf("processAllContexts() error = %v", err)
+	}
+	wantData := map[string]string{
+		"workspace-task.md":            "Fix the flaky test",
+		"workspace-plan.md":            "# Plan for fix (sre)\n\nFix the flaky test\n",
+		"workspace-.agent-config.json": `{"maxTurns": 20}`,
+	}
+	for key, want := range wantData {
+		if got := cm.Data[key]; got != want {
+			t.Errorf("ConfigMap[%s] = %q, want %q", key, got, want)
+		}
+	}
+	paths := map[string]bool{}
+	for _, fm := range fileMounts {
+		paths[fm.Path] = true
+	}
+	for _, p := range []string{"/workspace/task.md", "/workspace/plan.md", "/workspace/.agent/config.json"} {
+		if !paths[p] {
+			t.Errorf("missing file mount %s in %v", p, fileMounts)
+		}
+	}
+}
+
+func TestResolveWorkspaceFiles_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    kubetaskv1alpha1.WorkspaceFile
+		wantErr string
+	}{
+		{name: "absolute path", file: kubetaskv1alpha1.WorkspaceFile{Path: "/etc/passwd", Content: "x"}, wantErr: "must be relative"},
+		{name: "outside the workspace", file: kubetaskv1alpha1.WorkspaceFile{Path: "notes/../../x", Content: "x"}, wantErr: "must be relative"},
+		{name: "task.md", file: kubetaskv1alpha1.WorkspaceFile{Path: "./task.md", Content: "x"}, wantErr: "would replace task.md"},
+		{name: "Context mount", file: kubetaskv1alpha1.WorkspaceFile{Path: "guide.md", Content: "x"}, wantErr: `conflicts with Context "guide"`},
+		{name: "invalid template", file: kubetaskv1alpha1.WorkspaceFile{Path: "plan.md", Template: "{{ .Name"}, wantErr: "invalid template"},
+		{name: "missing label", file: kubetaskv1alpha1.WorkspaceFile{Path: "plan.md", Template: "{{ .Labels.team }}"}, wantErr: "unable to render"},
+	}
+
+	resolved := []build.ResolvedContext{{Name: "guide", MountPath: "/workspace/guide.md"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"},
+				Spec:       kubetaskv1alpha1.TaskSpec{Files: []kubetaskv1alpha1.WorkspaceFile{tt.file}},
+			}
+			_, err := resolveWorkspaceFiles(task, "", "/workspace", resolved)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveWorkspaceFiles() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
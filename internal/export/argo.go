@@ -0,0 +1,68 @@
+// Copyright Contributors to the KubeTask project
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// ArgoWorkflow converts the Job built for a Task into an Argo Workflow with a single
+// container template. Init containers keep their order, and any additional
+// containers become sidecars of the agent container.
+func ArgoWorkflow(task *kubetaskv1alpha1.Task, job *batchv1.Job) (*unstructured.Unstructured, error) {
+	pod, err := toMap(&job.Spec.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+	containers, _ := pod["containers"].([]any)
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("job %q has no containers", job.Name)
+	}
+
+	template := map[string]any{
+		"name":      agentTemplateName,
+		"container": containers[0],
+	}
+	if metadata := podMetadata(job); len(metadata) > 0 {
+		template["metadata"] = metadata
+	}
+	copyFields(template, pod, "initContainers")
+	if len(containers) > 1 {
+		template["sidecars"] = containers[1:]
+	}
+
+	spec := map[string]any{
+		"entrypoint": agentTemplateName,
+		"templates":  []any{template},
+	}
+	copyFields(spec, pod, "serviceAccountName", "volumes", "nodeSelector", "tolerations",
+		"affinity", "securityContext", "dnsPolicy", "dnsConfig", "hostAliases")
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		spec["activeDeadlineSeconds"] = *job.Spec.ActiveDeadlineSeconds
+	}
+
+	// Workflows have no runtimeClassName or topologySpreadConstraints fields,
+	// so they are applied through a pod spec patch
+	patch := map[string]any{}
+	copyFields(patch, pod, "runtimeClassName", "topologySpreadConstraints")
+	if len(patch) > 0 {
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return nil, err
+		}
+		spec["podSpecPatch"] = string(data)
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Workflow",
+		"metadata":   workflowMetadata(task),
+		"spec":       spec,
+	}}, nil
+}
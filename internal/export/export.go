@@ -0,0 +1,187 @@
+// Copyright Contributors to the KubeTask project
+
+// Package export renders KubeTask Tasks into the workflow resources of other CI
+// engines, so teams can author Tasks and Agents in KubeTask terms while running
+// them on an existing Argo Workflows or Tekton installation.
+package export
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/controller"
+)
+
+// Format is a target workflow engine
+type Format string
+
+const (
+	// FormatArgo renders an Argo Workflow (argoproj.io/v1alpha1)
+	FormatArgo Format = "argo"
+
+	// FormatTekton renders a Tekton PipelineRun (tekton.dev/v1)
+	FormatTekton Format = "tekton"
+)
+
+// agentTemplateName names the single Argo template / Tekton pipeline task running the agent
+const agentTemplateName = "agent"
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kubetaskv1alpha1.AddToScheme(scheme))
+}
+
+// Options configures Export
+type Options struct {
+	// Format is the target workflow engine
+	Format Format
+
+	// TaskName selects the Task to export. It may be empty when objects contain
+	// exactly one Task.
+	TaskName string
+
+	// DefaultAgentImage is used when neither the Agent nor KubeTaskConfig sets an image
+	DefaultAgentImage string
+}
+
+// Export renders a Task into resources for the target engine. objects must contain
+// the Task along with its Agent, Contexts, and any ConfigMaps or KubeTaskConfig
+// they reference. The result holds the context ConfigMap (if the Task has one)
+// followed by the Workflow or PipelineRun.
+func Export(ctx context.Context, objects []client.Object, opts Options) ([]*unstructured.Unstructured, error) {
+	if opts.Format != FormatArgo && opts.Format != FormatTekton {
+		return nil, fmt.Errorf("unsupported format %q, must be %q or %q", opts.Format, FormatArgo, FormatTekton)
+	}
+
+	task, err := findTask(objects, opts.TaskName)
+	if err != nil {
+		return nil, err
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	job, contextConfigMap, err := controller.RenderTask(ctx, c, task, opts.DefaultAgentImage)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render Task %q: %w", task.Name, err)
+	}
+
+	var out []*unstructured.Unstructured
+	if contextConfigMap != nil {
+		cm, err := configMap(contextConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cm)
+	}
+
+	var workflow *unstructured.Unstructured
+	switch opts.Format {
+	case FormatArgo:
+		workflow, err = ArgoWorkflow(task, job)
+	case FormatTekton:
+		workflow, err = TektonPipelineRun(task, job)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(out, workflow), nil
+}
+
+// findTask returns the named Task, or the only Task when name is empty
+func findTask(objects []client.Object, name string) (*kubetaskv1alpha1.Task, error) {
+	var tasks []*kubetaskv1alpha1.Task
+	for _, obj := range objects {
+		if task, ok := obj.(*kubetaskv1alpha1.Task); ok && (name == "" || task.Name == name) {
+			tasks = append(tasks, task)
+		}
+	}
+	switch {
+	case len(tasks) == 0 && name != "":
+		return nil, fmt.Errorf("Task %q not found", name)
+	case len(tasks) == 0:
+		return nil, fmt.Errorf("no Task found")
+	case len(tasks) > 1 && name == "":
+		return nil, fmt.Errorf("found %d Tasks, select one by name", len(tasks))
+	case len(tasks) > 1:
+		return nil, fmt.Errorf("found %d Tasks named %q in different namespaces", len(tasks), name)
+	}
+	return tasks[0].DeepCopy(), nil
+}
+
+// configMap converts the context ConfigMap, dropping its owner reference to the Task
+func configMap(cm *corev1.ConfigMap) (*unstructured.Unstructured, error) {
+	cm = cm.DeepCopy()
+	cm.OwnerReferences = nil
+	cm.APIVersion = "v1"
+	cm.Kind = "ConfigMap"
+	obj, err := toMap(cm)
+	if err != nil {
+		return nil, err
+	}
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// workflowMetadata returns the metadata shared by Workflows and PipelineRuns.
+// generateName is used so the exported resource can be submitted repeatedly.
+func workflowMetadata(task *kubetaskv1alpha1.Task) map[string]any {
+	labels := map[string]any{}
+	for k, v := range task.Labels {
+		labels[k] = v
+	}
+	labels[controller.TaskLabelKey] = task.Name
+	return map[string]any{
+		"generateName": task.Name + "-",
+		"namespace":    task.Namespace,
+		"labels":       labels,
+	}
+}
+
+// podMetadata returns the labels and annotations of the Job's pod template
+func podMetadata(job *batchv1.Job) map[string]any {
+	meta := map[string]any{}
+	if labels := stringMap(job.Spec.Template.Labels); len(labels) > 0 {
+		meta["labels"] = labels
+	}
+	// Workflow engines rename the agent container, the kubectl default no longer applies
+	annotations := stringMap(job.Spec.Template.Annotations)
+	delete(annotations, controller.DefaultContainerAnnotation)
+	if len(annotations) > 0 {
+		meta["annotations"] = annotations
+	}
+	return meta
+}
+
+// toMap converts a typed object to its unstructured form
+func toMap(obj any) (map[string]any, error) {
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+// stringMap converts a string map to its unstructured form
+func stringMap(in map[string]string) map[string]any {
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// copyFields copies the listed fields from src to dst when they are set
+func copyFields(dst, src map[string]any, keys ...string) {
+	for _, key := range keys {
+		if v, ok := src[key]; ok {
+			dst[key] = v
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const manifests = `
+apiVersion: kubetask.io/v1alpha1
+kind: Agent
+metadata:
+  name: default
+spec:
+  agentImage: quay.io/kubetask/agent:v1
+  serviceAccountName: kubetask-agent
+  workspaceDir: /workspace
+  command: ["sh", "-c", "agent run"]
+  preflight:
+    enabled: true
+    cliCommand: ["agent", "--version"]
+  podSpec:
+    runtimeClassName: gvisor
+    scheduling:
+      nodeSelector:
+        pool: agents
+---
+apiVersion: kubetask.io/v1alpha1
+kind: Task
+metadata:
+  name: fix-bug
+  namespace: team-a
+  labels:
+    team: a
+spec:
+  description: Fix the flaky test
+`
+
+func exportAs(t *testing.T, format Format) []*unstructured.Unstructured {
+	t.Helper()
+	objects, err := Decode(strings.NewReader(manifests), "team-a")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	// Pre-flight status is not available offline and must not block the export
+	out, err := Export(context.Background(), objects, Options{Format: format})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(out) != 2 || out[0].GetKind() != "ConfigMap" {
+		t.Fatalf("Export() = %d objects, want context ConfigMap and workflow", len(out))
+	}
+	if refs := out[0].GetOwnerReferences(); len(refs) != 0 {
+		t.Errorf("ConfigMap owner references = %v, want none", refs)
+	}
+	return out
+}
+
+func TestExport_Argo(t *testing.T) {
+	out := exportAs(t, FormatArgo)
+	wf := out[1]
+
+	if wf.GetKind() != "Workflow" || wf.GetGenerateName() != "fix-bug-" || wf.GetNamespace() != "team-a" {
+		t.Errorf("Workflow metadata = %s %s/%s", wf.GetKind(), wf.GetNamespace(), wf.GetGenerateName())
+	}
+	if wf.GetLabels()["team"] != "a" || wf.GetLabels()["kubetask.io/task"] != "fix-bug" {
+		t.Errorf("Workflow labels = %v, want Task labels plus kubetask.io/task", wf.GetLabels())
+	}
+
+	sa, _, _ := unstructured.NestedString(wf.Object, "spec", "serviceAccountName")
+	if sa != "kubetask-agent" {
+		t.Errorf("serviceAccountName = %q, want kubetask-agent", sa)
+	}
+	nodeSelector, _, _ := unstructured.NestedStringMap(wf.Object, "spec", "nodeSelector")
+	if nodeSelector["pool"] != "agents" {
+		t.Errorf("nodeSelector = %v, want pool=agents", nodeSelector)
+	}
+	patch, _, _ := unstructured.NestedString(wf.Object, "spec", "podSpecPatch")
+	if patch != `{"runtimeClassName":"gvisor"}` {
+		t.Errorf("podSpecPatch = %q, want runtimeClassName", patch)
+	}
+
+	templates, _, _ := unstructured.NestedSlice(wf.Object, "spec", "templates")
+	if len(templates) != 1 {
+		t.Fatalf("templates = %d, want 1", len(templates))
+	}
+	image, _, _ := unstructured.NestedString(templates[0].(map[string]any), "container", "image")
+	if image != "quay.io/kubetask/agent:v1" {
+		t.Errorf("container image = %q, want agent image", image)
+	}
+	volumes, _, _ := unstructured.NestedSlice(wf.Object, "spec", "volumes")
+	if len(volumes) == 0 {
+		t.Errorf("volumes are empty, want the context ConfigMap volume")
+	}
+}
+
+func TestExport_Tekton(t *testing.T) {
+	out := exportAs(t, FormatTekton)
+	pr := out[1]
+
+	if pr.GetAPIVersion() != "tekton.dev/v1" || pr.GetKind() != "PipelineRun" {
+		t.Errorf("got %s %s, want tekton.dev/v1 PipelineRun", pr.GetAPIVersion(), pr.GetKind())
+	}
+	runtimeClass, _, _ := unstructured.NestedString(pr.Object, "spec", "taskRunTemplate", "podTemplate", "runtimeClassName")
+	if runtimeClass != "gvisor" {
+		t.Errorf("podTemplate runtimeClassName = %q, want gvisor", runtimeClass)
+	}
+
+	tasks, _, _ := unstructured.NestedSlice(pr.Object, "spec", "pipelineSpec", "tasks")
+	if len(tasks) != 1 {
+		t.Fatalf("pipeline tasks = %d, want 1", len(tasks))
+	}
+	steps, _, _ := unstructured.NestedSlice(tasks[0].(map[string]any), "taskSpec", "steps")
+	if len(steps) == 0 {
+		t.Fatalf("steps are empty")
+	}
+	agent := steps[len(steps)-1].(map[string]any)
+	if agent["image"] != "quay.io/kubetask/agent:v1" {
+		t.Errorf("last step image = %v, want agent image", agent["image"])
+	}
+	if _, ok := agent["resources"]; ok {
+		t.Errorf("step has container resources, want computeResources")
+	}
+}
+
+func TestExport_Errors(t *testing.T) {
+	objects, err := Decode(strings.NewReader(manifests), "team-a")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	for name, opts := range map[string]Options{
+		"unknown format": {Format: "jenkins"},
+		"unknown Task":   {Format: FormatArgo, TaskName: "missing"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Export(context.Background(), objects, opts); err == nil {
+				t.Errorf("Export() error = nil, want error")
+			}
+		})
+	}
+
+	t.Run("missing Agent", func(t *testing.T) {
+		if _, err := Export(context.Background(), objects[1:], Options{Format: FormatArgo}); err == nil {
+			t.Errorf("Export() error = nil, want error for missing Agent")
+		}
+	})
+}
+
+func TestEncode(t *testing.T) {
+	out := exportAs(t, FormatArgo)
+	var buf bytes.Buffer
+	if err := Encode(&buf, out); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if got := strings.Count(buf.String(), "\n---\n"); got != 1 {
+		t.Errorf("Encode() wrote %d separators, want 1:\n%s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), "kind: Workflow") {
+		t.Errorf("Encode() output is missing the Workflow:\n%s", buf.String())
+	}
+}
+
+func TestPlan(t *testing.T) {
+	objects, err := Decode(strings.NewReader(manifests+`---
+apiVersion: kubetask.io/v1alpha1
+kind: Task
+metadata:
+  name: add-docs
+  namespace: team-a
+spec:
+  agentRef: missing
+  description: Document the API
+`), "team-a")
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	planned, err := Plan(context.Background(), objects, Options{Format: FormatPlan})
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(planned) != 2 || planned[0].Name != "add-docs" || planned[1].Name != "fix-bug" {
+		t.Fatalf("Plan() = %+v, want add-docs and fix-bug", planned)
+	}
+
+	if planned[0].Error == "" {
+		t.Errorf("add-docs Error is empty, want an error for the missing Agent")
+	}
+
+	fixBug := planned[1]
+	if fixBug.Agent != "default" || fixBug.Image != "quay.io/kubetask/agent:v1" || fixBug.Labels["team"] != "a" {
+		t.Errorf("fix-bug = %+v, want default Agent, its image, and Task labels", fixBug)
+	}
+	sum := fixBug.Files["/workspace/task.md"]
+	if len(sum) != 64 {
+		t.Errorf("Files = %v, want a checksum for /workspace/task.md", fixBug.Files)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodePlan(&buf, planned); err != nil {
+		t.Fatalf("EncodePlan() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "name: fix-bug") {
+		t.Errorf("EncodePlan() = %s, want fix-bug", buf.String())
+	}
+}
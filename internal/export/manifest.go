@@ -0,0 +1,70 @@
+// Copyright Contributors to the KubeTask project
+
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Decode reads multi-document YAML or JSON manifests into typed objects. Objects
+// without a namespace are placed in namespace.
+func Decode(r io.Reader, namespace string) ([]client.Object, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []client.Object
+	for {
+		var raw runtime.RawExtension
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || bytes.Equal(bytes.TrimSpace(raw.Raw), []byte("null")) {
+			continue
+		}
+
+		obj, gvk, err := deserializer.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		o, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported manifest of kind %s", gvk.Kind)
+		}
+		if o.GetNamespace() == "" {
+			o.SetNamespace(namespace)
+		}
+		objects = append(objects, o)
+	}
+}
+
+// Encode writes objects as multi-document YAML
+func Encode(w io.Writer, objects []*unstructured.Unstructured) error {
+	for i, obj := range objects {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright Contributors to the KubeTask project
+
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/controller"
+)
+
+// FormatPlan lists the Tasks that would run, without rendering them for an engine
+const FormatPlan Format = "plan"
+
+// PlannedTask describes a Task as the controller would run it
+type PlannedTask struct {
+	// Name and Namespace of the Task
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Agent is the Agent the Task runs with
+	Agent string `json:"agent"`
+
+	// Image is the resolved agent image
+	Image string `json:"image"`
+
+	// Labels of the Task, which usually carry the per-item values of a batch
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Files maps the paths of merged context files, including task.md, to the
+	// SHA-256 of their content. Git contexts are fetched when the Task runs and
+	// are not included.
+	Files map[string]string `json:"files,omitempty"`
+
+	// Error explains why the Task could not be resolved; it would fail when created
+	Error string `json:"error,omitempty"`
+}
+
+// Plan resolves the Agent and merges the contexts of every Task in objects, or only
+// the Task named by opts.TaskName, without creating anything. Use it to review a
+// batch of Tasks, such as the output of helm template, before applying it.
+// Tasks that fail to resolve are reported with an Error rather than failing the plan.
+func Plan(ctx context.Context, objects []client.Object, opts Options) ([]PlannedTask, error) {
+	var tasks []*kubetaskv1alpha1.Task
+	for _, obj := range objects {
+		if task, ok := obj.(*kubetaskv1alpha1.Task); ok && (opts.TaskName == "" || task.Name == opts.TaskName) {
+			tasks = append(tasks, task.DeepCopy())
+		}
+	}
+	if len(tasks) == 0 {
+		if opts.TaskName != "" {
+			return nil, fmt.Errorf("Task %q not found", opts.TaskName)
+		}
+		return nil, fmt.Errorf("no Task found")
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	planned := make([]PlannedTask, 0, len(tasks))
+	for _, task := range tasks {
+		item := PlannedTask{
+			Name:      task.Name,
+			Namespace: task.Namespace,
+			Agent:     task.Spec.AgentRef,
+			Labels:    task.Labels,
+		}
+		if item.Agent == "" {
+			item.Agent = "default"
+		}
+
+		job, contextConfigMap, err := controller.RenderTask(ctx, c, task, opts.DefaultAgentImage)
+		if err != nil {
+			item.Error = err.Error()
+			planned = append(planned, item)
+			continue
+		}
+		item.Image = agentContainer(job).Image
+		item.Files = contextChecksums(job, contextConfigMap)
+		planned = append(planned, item)
+	}
+
+	sort.Slice(planned, func(i, j int) bool {
+		if planned[i].Namespace != planned[j].Namespace {
+			return planned[i].Namespace < planned[j].Namespace
+		}
+		return planned[i].Name < planned[j].Name
+	})
+	return planned, nil
+}
+
+// EncodePlan writes a plan as YAML
+func EncodePlan(w io.Writer, planned []PlannedTask) error {
+	data, err := yaml.Marshal(planned)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// agentContainer returns the agent container of a rendered Job
+func agentContainer(job *batchv1.Job) *corev1.Container {
+	containers := job.Spec.Template.Spec.Containers
+	name := job.Spec.Template.Annotations[controller.DefaultContainerAnnotation]
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return &containers[0]
+}
+
+// contextChecksums maps the mount paths of the context ConfigMap's files to the
+// SHA-256 of their content. Files copied into place by context refresh have no
+// subPath mount and are keyed by their ConfigMap key instead.
+func contextChecksums(job *batchv1.Job, contextConfigMap *corev1.ConfigMap) map[string]string {
+	if contextConfigMap == nil {
+		return nil
+	}
+
+	paths := map[string]string{}
+	for _, mount := range agentContainer(job).VolumeMounts {
+		if mount.SubPath != "" {
+			if _, ok := contextConfigMap.Data[mount.SubPath]; ok {
+				paths[mount.SubPath] = mount.MountPath
+			}
+		}
+	}
+
+	checksums := make(map[string]string, len(contextConfigMap.Data))
+	for key, content := range contextConfigMap.Data {
+		path, ok := paths[key]
+		if !ok {
+			path = key
+		}
+		sum := sha256.Sum256([]byte(content))
+		checksums[path] = hex.EncodeToString(sum[:])
+	}
+	return checksums
+}
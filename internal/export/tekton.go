@@ -0,0 +1,94 @@
+// Copyright Contributors to the KubeTask project
+
+package export
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// tektonStepFields are the container fields supported by Tekton steps and sidecars.
+// Probes, ports, and lifecycle hooks have no step equivalent and are dropped.
+var tektonStepFields = []string{
+	"name", "image", "command", "args", "workingDir", "env", "envFrom",
+	"volumeMounts", "volumeDevices", "imagePullPolicy", "securityContext",
+}
+
+// TektonPipelineRun converts the Job built for a Task into a Tekton PipelineRun with
+// an embedded single-task Pipeline. Init containers run as the first steps, the
+// agent container as the last one, and any additional containers become sidecars.
+func TektonPipelineRun(task *kubetaskv1alpha1.Task, job *batchv1.Job) (*unstructured.Unstructured, error) {
+	pod, err := toMap(&job.Spec.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+	containers, _ := pod["containers"].([]any)
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("job %q has no containers", job.Name)
+	}
+	initContainers, _ := pod["initContainers"].([]any)
+
+	var steps []any
+	for _, c := range append(initContainers, containers[0]) {
+		steps = append(steps, tektonStep(c.(map[string]any)))
+	}
+	taskSpec := map[string]any{"steps": steps}
+	if len(containers) > 1 {
+		var sidecars []any
+		for _, c := range containers[1:] {
+			sidecars = append(sidecars, tektonStep(c.(map[string]any)))
+		}
+		taskSpec["sidecars"] = sidecars
+	}
+	if metadata := podMetadata(job); len(metadata) > 0 {
+		taskSpec["metadata"] = metadata
+	}
+	copyFields(taskSpec, pod, "volumes")
+
+	spec := map[string]any{
+		"pipelineSpec": map[string]any{
+			"tasks": []any{map[string]any{
+				"name":     agentTemplateName,
+				"taskSpec": taskSpec,
+			}},
+		},
+	}
+
+	taskRunTemplate := map[string]any{}
+	copyFields(taskRunTemplate, pod, "serviceAccountName")
+	podTemplate := map[string]any{}
+	copyFields(podTemplate, pod, "nodeSelector", "tolerations", "affinity", "securityContext",
+		"runtimeClassName", "dnsPolicy", "dnsConfig", "hostAliases", "topologySpreadConstraints")
+	if len(podTemplate) > 0 {
+		taskRunTemplate["podTemplate"] = podTemplate
+	}
+	if len(taskRunTemplate) > 0 {
+		spec["taskRunTemplate"] = taskRunTemplate
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		timeout := time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second
+		spec["timeouts"] = map[string]any{"pipeline": timeout.String()}
+	}
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "tekton.dev/v1",
+		"kind":       "PipelineRun",
+		"metadata":   workflowMetadata(task),
+		"spec":       spec,
+	}}, nil
+}
+
+// tektonStep converts an unstructured container into a Tekton step
+func tektonStep(container map[string]any) map[string]any {
+	step := map[string]any{}
+	copyFields(step, container, tektonStepFields...)
+	if resources, ok := container["resources"].(map[string]any); ok && len(resources) > 0 {
+		step["computeResources"] = resources
+	}
+	return step
+}
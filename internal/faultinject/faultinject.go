@@ -0,0 +1,225 @@
+// Copyright Contributors to the KubeTask project
+
+// Package faultinject wraps controller-runtime clients to inject API failures,
+// slow responses and lost responses into selected calls, so tests can exercise the
+// crash-recovery paths of the controllers
+package faultinject
+
+import (
+	"context"
+	stderrors "errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// Operation is a kind of client call
+type Operation string
+
+// Operations of the client calls an Injector intercepts
+const (
+	OpGet          Operation = "Get"
+	OpList         Operation = "List"
+	OpCreate       Operation = "Create"
+	OpUpdate       Operation = "Update"
+	OpPatch        Operation = "Patch"
+	OpDelete       Operation = "Delete"
+	OpStatusUpdate Operation = "StatusUpdate"
+	OpStatusPatch  Operation = "StatusPatch"
+)
+
+// Fault is injected into the client calls it matches
+type Fault struct {
+	// Operation selects calls of one kind; empty matches every kind
+	Operation Operation
+
+	// Object selects calls on objects of the same type, such as &batchv1.Job{}, or
+	// &batchv1.JobList{} for lists; nil matches every type
+	Object runtime.Object
+
+	// Match further selects calls by the object passed to them; nil matches all
+	Match func(obj runtime.Object) bool
+
+	// Skip lets the first matching calls through unchanged
+	Skip int
+
+	// Times bounds how often the fault is injected; zero injects it into every
+	// matching call
+	Times int
+
+	// Delay is waited before matching calls, or until their context is done
+	Delay time.Duration
+
+	// Err is returned from matching calls. If nil, calls are only delayed.
+	Err error
+
+	// AfterCall performs the call before returning Err, like a request the API
+	// server applied but whose response was lost
+	AfterCall bool
+}
+
+// Injector injects Faults into the calls of the clients it wraps. The first Fault
+// matching a call applies to it.
+type Injector struct {
+	mu       sync.Mutex
+	faults   []*fault
+	disabled bool
+	injected int
+}
+
+// fault tracks the calls a Fault matched and was injected into
+type fault struct {
+	Fault
+	matched  int
+	injected int
+}
+
+// New returns an Injector with the given faults
+func New(faults ...Fault) *Injector {
+	i := &Injector{}
+	for _, f := range faults {
+		i.Add(f)
+	}
+	return i
+}
+
+// Add registers another fault, which applies after those already added
+func (i *Injector) Add(f Fault) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = append(i.faults, &fault{Fault: f})
+}
+
+// SetEnabled turns injection on or off. Calls made while it is off are not counted
+// for Skip and Times.
+func (i *Injector) SetEnabled(enabled bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.disabled = !enabled
+}
+
+// Injected returns how many calls a fault was injected into
+func (i *Injector) Injected() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.injected
+}
+
+// Wrap returns a client that injects the faults into the calls of c
+func (i *Injector) Wrap(c client.WithWatch) client.WithWatch {
+	return interceptor.NewClient(c, i.Funcs())
+}
+
+// Funcs returns interceptor functions injecting the faults, for clients built with
+// fake.ClientBuilder.WithInterceptorFuncs
+func (i *Injector) Funcs() interceptor.Funcs {
+	return interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			return i.inject(ctx, OpGet, obj, func() error { return c.Get(ctx, key, obj, opts...) })
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			return i.inject(ctx, OpList, list, func() error { return c.List(ctx, list, opts...) })
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			return i.inject(ctx, OpCreate, obj, func() error { return c.Create(ctx, obj, opts...) })
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			return i.inject(ctx, OpUpdate, obj, func() error { return c.Update(ctx, obj, opts...) })
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			return i.inject(ctx, OpPatch, obj, func() error { return c.Patch(ctx, obj, patch, opts...) })
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			return i.inject(ctx, OpDelete, obj, func() error { return c.Delete(ctx, obj, opts...) })
+		},
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			call := func() error { return c.SubResource(subResource).Update(ctx, obj, opts...) }
+			if subResource != "status" {
+				return call()
+			}
+			return i.inject(ctx, OpStatusUpdate, obj, call)
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			call := func() error { return c.SubResource(subResource).Patch(ctx, obj, patch, opts...) }
+			if subResource != "status" {
+				return call()
+			}
+			return i.inject(ctx, OpStatusPatch, obj, call)
+		},
+	}
+}
+
+// inject performs call with the first fault matching it applied
+func (i *Injector) inject(ctx context.Context, op Operation, obj runtime.Object, call func() error) error {
+	f := i.match(op, obj)
+	if f == nil {
+		return call()
+	}
+	if f.Delay > 0 {
+		timer := time.NewTimer(f.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if f.Err == nil {
+		return call()
+	}
+	if f.AfterCall {
+		if err := call(); err != nil {
+			return err
+		}
+	}
+	return f.Err
+}
+
+// match returns the fault to inject into a call, counting the call, or nil
+func (i *Injector) match(op Operation, obj runtime.Object) *Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.disabled {
+		return nil
+	}
+	for _, f := range i.faults {
+		if f.Operation != "" && f.Operation != op {
+			continue
+		}
+		if f.Object != nil && reflect.TypeOf(f.Object) != reflect.TypeOf(obj) {
+			continue
+		}
+		if f.Match != nil && !f.Match(obj) {
+			continue
+		}
+		f.matched++
+		if f.matched <= f.Skip || (f.Times > 0 && f.injected >= f.Times) {
+			continue
+		}
+		f.injected++
+		i.injected++
+		return &f.Fault
+	}
+	return nil
+}
+
+// Conflict returns the error of a write based on an outdated resourceVersion
+func Conflict() error {
+	return errors.NewConflict(schema.GroupResource{}, "", stderrors.New("injected conflict"))
+}
+
+// ServerTimeout returns the error of a request the API server did not finish in time
+func ServerTimeout() error {
+	return errors.NewServerTimeout(schema.GroupResource{}, "injected", 1)
+}
+
+// Unavailable returns the error of an API server that cannot serve requests
+func Unavailable() error {
+	return errors.NewServiceUnavailable("injected outage")
+}
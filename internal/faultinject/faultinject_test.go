@@ -0,0 +1,85 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newConfigMap(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+}
+
+func TestInjector(t *testing.T) {
+	ctx := context.Background()
+	injector := New(
+		Fault{Operation: OpCreate, Object: &corev1.ConfigMap{}, Skip: 1, Times: 1, Err: Conflict()},
+		Fault{Operation: OpGet, Match: func(obj runtime.Object) bool { _, ok := obj.(*corev1.Secret); return ok }, Err: Unavailable()},
+	)
+	c := injector.Wrap(fake.NewClientBuilder().Build())
+
+	if err := c.Create(ctx, newConfigMap("first")); err != nil {
+		t.Fatalf("skipped Create() error = %v", err)
+	}
+	if err := c.Create(ctx, newConfigMap("second")); !apierrors.IsConflict(err) {
+		t.Fatalf("Create() error = %v, want a conflict", err)
+	}
+	if err := c.Create(ctx, newConfigMap("third")); err != nil {
+		t.Fatalf("Create() after Times error = %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "second", Namespace: "default"}, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() of the failed Create error = %v, want NotFound", err)
+	}
+
+	secretKey := types.NamespacedName{Name: "token", Namespace: "default"}
+	for range 2 {
+		if err := c.Get(ctx, secretKey, &corev1.Secret{}); !apierrors.IsServiceUnavailable(err) {
+			t.Errorf("Get() error = %v, want every call to fail", err)
+		}
+	}
+	if got := injector.Injected(); got != 3 {
+		t.Errorf("Injected() = %d, want 3", got)
+	}
+
+	injector.SetEnabled(false)
+	if err := c.Get(ctx, secretKey, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() with injection disabled error = %v, want NotFound", err)
+	}
+}
+
+func TestInjector_AfterCall(t *testing.T) {
+	ctx := context.Background()
+	injector := New(Fault{Operation: OpCreate, Times: 1, Err: ServerTimeout(), AfterCall: true})
+	c := injector.Wrap(fake.NewClientBuilder().Build())
+
+	if err := c.Create(ctx, newConfigMap("lost")); !apierrors.IsServerTimeout(err) {
+		t.Fatalf("Create() error = %v, want a server timeout", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(newConfigMap("lost")), &corev1.ConfigMap{}); err != nil {
+		t.Errorf("Get() error = %v, want the ConfigMap created despite the lost response", err)
+	}
+}
+
+func TestInjector_Delay(t *testing.T) {
+	injector := New(Fault{Operation: OpList, Delay: time.Hour})
+	c := injector.Wrap(fake.NewClientBuilder().Build())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.List(ctx, &corev1.ConfigMapList{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("List() error = %v, want the context deadline", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright Contributors to the KubeTask project
+
+// Package images lists the container images the controller injects into Pods on its
+// own, as opposed to the images Agents and AgentEvals name, and points them at a
+// registry mirror for air-gapped clusters. The controller can check at startup that
+// each of them is available, so a missing mirror copy fails the rollout rather
+// than the first Task that needs it.
+package images
+
+import (
+	"strings"
+)
+
+// Names of the images the controller injects
+const (
+	// Agent is the default agent image, used when neither the Agent nor the
+	// namespace's KubeTaskConfig sets one
+	Agent = "agent"
+
+	// GitSync clones Git contexts and fills the context cache
+	GitSync = "git-sync"
+
+	// ObjectStore downloads ObjectStore contexts
+	ObjectStore = "object-store"
+
+	// ORAS pulls OCI contexts
+	ORAS = "oras"
+)
+
+// dockerHub is the registry of image references without a registry host
+const dockerHub = "docker.io"
+
+// Image is an image the controller injects, by name and reference
+type Image struct {
+	Name string
+	Ref  string
+}
+
+// Set is every image the controller injects
+type Set struct {
+	Agent       string
+	GitSync     string
+	ObjectStore string
+	ORAS        string
+}
+
+// List returns the images of s, in a stable order
+func (s Set) List() []Image {
+	return []Image{
+		{Name: Agent, Ref: s.Agent},
+		{Name: GitSync, Ref: s.GitSync},
+		{Name: ObjectStore, Ref: s.ObjectStore},
+		{Name: ORAS, Ref: s.ORAS},
+	}
+}
+
+// WithRegistry returns s with every image moved to registry, see Rewrite
+func (s Set) WithRegistry(registry string) Set {
+	return Set{
+		Agent:       Rewrite(s.Agent, registry),
+		GitSync:     Rewrite(s.GitSync, registry),
+		ObjectStore: Rewrite(s.ObjectStore, registry),
+		ORAS:        Rewrite(s.ORAS, registry),
+	}
+}
+
+// Rewrite replaces the registry host of ref with registry, which may include a path
+// such as mirror.example.com/upstream, keeping the repository path and tag or digest:
+// quay.io/kubetask/agent:v1 becomes mirror.example.com/upstream/kubetask/agent:v1.
+// Docker Hub images keep their library/ prefix. ref is returned unchanged if
+// registry is empty or ref is already in registry.
+func Rewrite(ref, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	if registry == "" || strings.HasPrefix(ref, registry+"/") {
+		return ref
+	}
+	_, repository := splitRegistry(ref)
+	return registry + "/" + repository
+}
+
+// reference is a parsed image reference
+type reference struct {
+	Registry   string
+	Repository string
+	// Reference is the tag or digest of the image
+	Reference string
+}
+
+// parseReference parses ref into its registry, repository and tag or digest, which
+// defaults to the latest tag
+func parseReference(ref string) reference {
+	registry, rest := splitRegistry(ref)
+	repository, digest, ok := strings.Cut(rest, "@")
+	if ok {
+		return reference{Registry: registry, Repository: repository, Reference: digest}
+	}
+	tag := "latest"
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+	return reference{Registry: registry, Repository: repository, Reference: tag}
+}
+
+// splitRegistry splits ref into its registry host and the rest of the reference.
+// The first path element is a registry host if it contains a dot or port, or is
+// localhost; otherwise the image is on Docker Hub.
+func splitRegistry(ref string) (string, string) {
+	first, rest, ok := strings.Cut(ref, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first, rest
+	}
+	if !ok {
+		rest = "library/" + ref
+	} else {
+		rest = ref
+	}
+	return dockerHub, rest
+}
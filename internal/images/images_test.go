@@ -0,0 +1,60 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package images
+
+import "testing"
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		ref, registry, want string
+	}{
+		{"quay.io/kubetask/kubetask-agent-gemini:latest", "", "quay.io/kubetask/kubetask-agent-gemini:latest"},
+		{"quay.io/kubetask/kubetask-agent-gemini:latest", "mirror.example.com", "mirror.example.com/kubetask/kubetask-agent-gemini:latest"},
+		{"registry.k8s.io/git-sync/git-sync:v4.4.0", "mirror.example.com/upstream/", "mirror.example.com/upstream/git-sync/git-sync:v4.4.0"},
+		{"alpine:3.20", "mirror.example.com", "mirror.example.com/library/alpine:3.20"},
+		{"bitnami/git@sha256:abc", "localhost:5000", "localhost:5000/bitnami/git@sha256:abc"},
+		{"mirror.example.com/upstream/git-sync/git-sync:v4.4.0", "mirror.example.com/upstream", "mirror.example.com/upstream/git-sync/git-sync:v4.4.0"},
+	}
+	for _, tt := range tests {
+		if got := Rewrite(tt.ref, tt.registry); got != tt.want {
+			t.Errorf("Rewrite(%q, %q) = %q, want %q", tt.ref, tt.registry, got, tt.want)
+		}
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want reference
+	}{
+		{"quay.io/kubetask/agent:v1", reference{"quay.io", "kubetask/agent", "v1"}},
+		{"quay.io/kubetask/agent", reference{"quay.io", "kubetask/agent", "latest"}},
+		{"localhost:5000/agent", reference{"localhost:5000", "agent", "latest"}},
+		{"registry:5000/team/agent:v2", reference{"registry:5000", "team/agent", "v2"}},
+		{"alpine", reference{"docker.io", "library/alpine", "latest"}},
+		{"quay.io/kubetask/agent@sha256:abc", reference{"quay.io", "kubetask/agent", "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		if got := parseReference(tt.ref); got != tt.want {
+			t.Errorf("parseReference(%q) = %+v, want %+v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestSetList(t *testing.T) {
+	set := Set{
+		Agent:       "quay.io/kubetask/agent:v1",
+		GitSync:     "registry.k8s.io/git-sync/git-sync:v4.4.0",
+		ObjectStore: "rclone/rclone:1.68.2",
+		ORAS:        "ghcr.io/oras-project/oras:v1.2.2",
+	}.WithRegistry("mirror.example.com")
+	list := set.List()
+	if len(list) != 4 || list[0] != (Image{Agent, "mirror.example.com/kubetask/agent:v1"}) ||
+		list[1] != (Image{GitSync, "mirror.example.com/git-sync/git-sync:v4.4.0"}) ||
+		list[2] != (Image{ObjectStore, "mirror.example.com/rclone/rclone:1.68.2"}) ||
+		list[3] != (Image{ORAS, "mirror.example.com/oras-project/oras:v1.2.2"}) {
+		t.Errorf("List() = %v", list)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright Contributors to the KubeTask project
+
+package images
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// manifestMediaTypes are the manifest types requested from registries, so that
+// registries serving only image indexes or only Docker manifests both answer
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// dockerHubAPIHost serves the registry API of Docker Hub images
+const dockerHubAPIHost = "registry-1.docker.io"
+
+// Auth is a username and password for a registry
+type Auth struct {
+	Username string
+	Password string
+}
+
+// Verifier checks that images can be pulled by asking their registries for the
+// image manifests over the registry HTTP API. No image layers are downloaded.
+type Verifier struct {
+	// Client sends the registry requests. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// Auths are the credentials for registries by host, as read by
+	// LoadDockerConfig. Other registries are accessed anonymously.
+	Auths map[string]Auth
+}
+
+// Verify checks every image and returns the errors of all that cannot be pulled
+func (v *Verifier) Verify(ctx context.Context, images []Image) error {
+	var errs []error
+	for _, image := range images {
+		if err := v.check(ctx, image.Ref); err != nil {
+			errs = append(errs, fmt.Errorf("%s image %s: %w", image.Name, image.Ref, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// check requests the manifest of ref, authenticating with a bearer token when the
+// registry asks for one
+func (v *Verifier) check(ctx context.Context, ref string) error {
+	r := parseReference(ref)
+	host := r.Registry
+	if host == dockerHub {
+		host = dockerHubAPIHost
+	}
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, r.Repository, r.Reference)
+	auth, hasAuth := v.Auths[r.Registry]
+
+	resp, err := v.headManifest(ctx, manifestURL, func(req *http.Request) {
+		if hasAuth {
+			req.SetBasicAuth(auth.Username, auth.Password)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		if !strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+			return fmt.Errorf("registry %s requires credentials", r.Registry)
+		}
+		token, err := v.token(ctx, challenge, r.Repository, auth, hasAuth)
+		if err != nil {
+			return fmt.Errorf("unable to authenticate to registry %s: %w", r.Registry, err)
+		}
+		resp, err = v.headManifest(ctx, manifestURL, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("not found in registry %s", r.Registry)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("access denied by registry %s", r.Registry)
+	default:
+		return fmt.Errorf("registry %s returned %s", r.Registry, resp.Status)
+	}
+}
+
+// headManifest sends a HEAD request for a manifest
+func (v *Verifier) headManifest(ctx context.Context, manifestURL string, authorize func(*http.Request)) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	authorize(req)
+	resp, err := v.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// token requests a pull token for repository from the realm of a bearer challenge
+func (v *Verifier) token(ctx context.Context, challenge, repository string, auth Auth, hasAuth bool) (string, error) {
+	params := parseChallenge(challenge[len("bearer "):])
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid bearer challenge %q", challenge)
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", "repository:"+repository+":pull")
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasAuth {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	resp, err := v.client().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("unable to decode token response: %w", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+func (v *Verifier) client() *http.Client {
+	if v.Client != nil {
+		return v.Client
+	}
+	return http.DefaultClient
+}
+
+// parseChallenge parses the comma-separated key="value" parameters of a
+// WWW-Authenticate challenge
+func parseChallenge(s string) map[string]string {
+	params := make(map[string]string)
+	for s != "" {
+		key, rest, ok := strings.Cut(strings.TrimLeft(s, " ,"), "=")
+		if !ok {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+		s = rest
+	}
+	return params
+}
+
+// LoadDockerConfig reads the registry credentials of a .dockerconfigjson file, as
+// stored in kubernetes.io/dockerconfigjson Secrets, keyed by registry host
+func LoadDockerConfig(path string) (map[string]Auth, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	auths := make(map[string]Auth, len(config.Auths))
+	for key, entry := range config.Auths {
+		auth := Auth{Username: entry.Username, Password: entry.Password}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth of registry %s in %s: %w", key, path, err)
+			}
+			auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		auths[registryHost(key)] = auth
+	}
+	return auths, nil
+}
+
+// registryHost returns the host of a registry key of a Docker config, which may be
+// a URL such as https://index.docker.io/v1/
+func registryHost(key string) string {
+	host := key
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if host == "index.docker.io" || host == dockerHubAPIHost {
+		return dockerHub
+	}
+	return host
+}
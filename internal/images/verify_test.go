@@ -0,0 +1,90 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package images
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRegistry serves the manifest of kubetask/agent:v1, requiring a bearer token
+// that its token endpoint hands out for alice's credentials
+func newRegistry(t *testing.T) *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "alice" || password != "secret" || r.URL.Query().Get("scope") != "repository:kubetask/agent:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token": "pull-token"}`)
+		case r.Header.Get("Authorization") != "Bearer pull-token":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, srv.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/kubetask/agent/manifests/v1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	srv := newRegistry(t)
+	host := strings.TrimPrefix(srv.URL, "https://")
+	v := &Verifier{Client: srv.Client(), Auths: map[string]Auth{host: {Username: "alice", Password: "secret"}}}
+
+	if err := v.Verify(context.Background(), []Image{{Name: Agent, Ref: host + "/kubetask/agent:v1"}}); err != nil {
+		t.Errorf("Verify() error = %v, want nil", err)
+	}
+
+	err := v.Verify(context.Background(), []Image{
+		{Name: Agent, Ref: host + "/kubetask/agent:v1"},
+		{Name: GitSync, Ref: host + "/kubetask/agent:v2"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "git-sync image "+host+"/kubetask/agent:v2: not found") || strings.Contains(err.Error(), ":v1") {
+		t.Errorf("Verify() error = %v, want only the missing git-sync image", err)
+	}
+
+	anonymous := &Verifier{Client: srv.Client()}
+	err = anonymous.Verify(context.Background(), []Image{{Name: Agent, Ref: host + "/kubetask/agent:v1"}})
+	if err == nil || !strings.Contains(err.Error(), "unable to authenticate") {
+		t.Errorf("Verify() without credentials error = %v, want an authentication error", err)
+	}
+}
+
+func TestLoadDockerConfig(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("alice:secret"))
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := fmt.Sprintf(`{"auths": {
+		"https://index.docker.io/v1/": {"auth": %q},
+		"mirror.example.com": {"username": "bob", "password": "hunter2"}
+	}}`, auth)
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	auths, err := LoadDockerConfig(path)
+	if err != nil {
+		t.Fatalf("LoadDockerConfig() error = %v", err)
+	}
+	if got := auths["docker.io"]; got != (Auth{"alice", "secret"}) {
+		t.Errorf("docker.io auth = %+v", got)
+	}
+	if got := auths["mirror.example.com"]; got != (Auth{"bob", "hunter2"}) {
+		t.Errorf("mirror.example.com auth = %+v", got)
+	}
+}
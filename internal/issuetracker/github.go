@@ -0,0 +1,77 @@
+// Copyright Contributors to the KubeTask project
+
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AnnotationGitHubIssue holds the GitHub issue a Task works on, e.g. "org/repo#42"
+	AnnotationGitHubIssue = "kubetask.io/github-issue"
+
+	// DefaultGitHubAPIURL is the GitHub REST API base URL
+	DefaultGitHubAPIURL = "https://api.github.com"
+)
+
+// gitHubIssuePattern matches "owner/repo#number" issue references
+var gitHubIssuePattern = regexp.MustCompile(`^([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)#([0-9]+)$`)
+
+// GitHub comments on and closes GitHub issues through the REST API
+type GitHub struct {
+	// BaseURL is the REST API base URL, DefaultGitHubAPIURL if empty.
+	// For GitHub Enterprise Server use "https://<host>/api/v3".
+	BaseURL string
+
+	// Token authenticates requests
+	Token string
+
+	// CloseOnSuccess closes the issue as completed when the Task completes
+	CloseOnSuccess bool
+
+	// HTTPClient is used for requests, a client with a 30s timeout if nil
+	HTTPClient *http.Client
+}
+
+var _ Tracker = &GitHub{}
+
+// Annotation implements Tracker
+func (g *GitHub) Annotation() string {
+	return AnnotationGitHubIssue
+}
+
+// Report implements Tracker
+func (g *GitHub) Report(ctx context.Context, issue string, task *kubetaskv1alpha1.Task) error {
+	m := gitHubIssuePattern.FindStringSubmatch(issue)
+	if m == nil {
+		return reconcile.TerminalError(fmt.Errorf("invalid GitHub issue reference %q, want owner/repo#number", issue))
+	}
+	baseURL := g.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	issueURL := fmt.Sprintf("%s/repos/%s/%s/issues/%s", strings.TrimSuffix(baseURL, "/"), m[1], m[2], m[3])
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+g.Token)
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	comment := map[string]string{"body": summary(task, func(s string) string { return "`" + s + "`" })}
+	if err := doJSON(ctx, g.HTTPClient, http.MethodPost, issueURL+"/comments", header, comment, nil); err != nil {
+		return err
+	}
+
+	if g.CloseOnSuccess && task.Status.Phase == kubetaskv1alpha1.TaskPhaseCompleted {
+		update := map[string]string{"state": "closed", "state_reason": "completed"}
+		return doJSON(ctx, g.HTTPClient, http.MethodPatch, issueURL, header, update, nil)
+	}
+	return nil
+}
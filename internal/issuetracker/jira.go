@@ -0,0 +1,96 @@
+// Copyright Contributors to the KubeTask project
+
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// AnnotationJiraIssue holds the key of the Jira issue a Task works on, e.g. "PROJ-123"
+const AnnotationJiraIssue = "kubetask.io/jira-issue"
+
+// jiraIssueKeyPattern matches Jira issue keys
+var jiraIssueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// Jira comments on and transitions Jira issues through the REST API v2
+type Jira struct {
+	// BaseURL is the Jira site URL, e.g. "https://example.atlassian.net"
+	BaseURL string
+
+	// User and APIToken authenticate with basic auth
+	User     string
+	APIToken string
+
+	// SuccessTransition is the transition applied when the Task completes, e.g. "Done".
+	// No transition is applied if empty.
+	SuccessTransition string
+
+	// FailureTransition is the transition applied when the Task fails.
+	// No transition is applied if empty.
+	FailureTransition string
+
+	// HTTPClient is used for requests, a client with a 30s timeout if nil
+	HTTPClient *http.Client
+}
+
+var _ Tracker = &Jira{}
+
+// Annotation implements Tracker
+func (j *Jira) Annotation() string {
+	return AnnotationJiraIssue
+}
+
+// Report implements Tracker
+func (j *Jira) Report(ctx context.Context, issue string, task *kubetaskv1alpha1.Task) error {
+	if !jiraIssueKeyPattern.MatchString(issue) {
+		return reconcile.TerminalError(fmt.Errorf("invalid Jira issue key %q", issue))
+	}
+	issueURL := strings.TrimSuffix(j.BaseURL, "/") + "/rest/api/2/issue/" + url.PathEscape(issue)
+
+	comment := map[string]string{"body": summary(task, func(s string) string { return "{{" + s + "}}" })}
+	if err := doJSON(ctx, j.HTTPClient, http.MethodPost, issueURL+"/comment", j.header(), comment, nil); err != nil {
+		return err
+	}
+
+	transition := j.SuccessTransition
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed {
+		transition = j.FailureTransition
+	}
+	if transition == "" {
+		return nil
+	}
+
+	var transitions struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"transitions"`
+	}
+	if err := doJSON(ctx, j.HTTPClient, http.MethodGet, issueURL+"/transitions", j.header(), nil, &transitions); err != nil {
+		return err
+	}
+	for _, t := range transitions.Transitions {
+		if strings.EqualFold(t.Name, transition) {
+			body := map[string]any{"transition": map[string]string{"id": t.ID}}
+			return doJSON(ctx, j.HTTPClient, http.MethodPost, issueURL+"/transitions", j.header(), body, nil)
+		}
+	}
+	// The issue may already be in the target status, which is not an error worth retrying
+	return nil
+}
+
+// header returns the authentication headers for Jira requests
+func (j *Jira) header() http.Header {
+	req := &http.Request{Header: http.Header{}}
+	req.SetBasicAuth(j.User, j.APIToken)
+	return req.Header
+}
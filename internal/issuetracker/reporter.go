@@ -0,0 +1,140 @@
+// Copyright Contributors to the KubeTask project
+
+// Package issuetracker writes Task results back to the issues that requested them.
+//
+// A Task opts in with an issue annotation, for example
+// `kubetask.io/jira-issue: PROJ-123` or `kubetask.io/github-issue: org/repo#42`.
+// When the Task finishes, the Reporter posts a result summary to the issue and
+// transitions it according to the tracker's configuration.
+package issuetracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// reportedSuffix is appended to an issue annotation key to record that the result was written back
+const reportedSuffix = "-reported"
+
+// Tracker writes the result of a finished Task back to an issue
+type Tracker interface {
+	// Annotation is the Task annotation holding the issue reference for this tracker
+	Annotation() string
+
+	// Report posts a result summary to the issue and transitions it according to the Task outcome
+	Report(ctx context.Context, issue string, task *kubetaskv1alpha1.Task) error
+}
+
+// Reporter reports finished Tasks to the issues referenced by their annotations
+type Reporter struct {
+	client.Client
+
+	// Trackers are the enabled issue trackers
+	Trackers []Tracker
+}
+
+// Reconcile reports a finished Task to every referenced issue it was not reported to yet
+func (r *Reporter) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := r.Get(ctx, req.NamespacedName, task); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if task.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted && task.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	for _, tracker := range r.Trackers {
+		key := tracker.Annotation()
+		issue := task.Annotations[key]
+		if issue == "" || task.Annotations[key+reportedSuffix] != "" {
+			continue
+		}
+
+		if err := tracker.Report(ctx, issue, task); err != nil {
+			log.Error(err, "unable to report Task result", "issue", issue)
+			return ctrl.Result{}, err
+		}
+
+		// Record the write-back so the issue is not commented on again
+		patch := client.MergeFrom(task.DeepCopy())
+		task.Annotations[key+reportedSuffix] = string(task.Status.Phase)
+		if err := r.Patch(ctx, task, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("reported Task result", "issue", issue, "phase", task.Status.Phase)
+	}
+	return ctrl.Result{}, nil
+}
+
+// summary formats the result of a finished Task.
+// code renders inline code in the tracker's markup.
+func summary(task *kubetaskv1alpha1.Task, code func(string) string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "KubeTask %s %s", code(task.Namespace+"/"+task.Name), strings.ToLower(string(task.Status.Phase)))
+	if task.Status.StartTime != nil && task.Status.CompletionTime != nil {
+		fmt.Fprintf(&b, " in %s", task.Status.CompletionTime.Sub(task.Status.StartTime.Time).Round(time.Second))
+	}
+	b.WriteString(".\n")
+
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed {
+		if cond := meta.FindStatusCondition(task.Status.Conditions, "Ready"); cond != nil && cond.Message != "" {
+			fmt.Fprintf(&b, "\nFailure: %s\n", cond.Message)
+		}
+		for _, a := range task.Status.FailureArtifacts {
+			fmt.Fprintf(&b, "\nWorkspace snapshot: %s in PVC %s\n", code(a.Path), code(a.ClaimName))
+		}
+	}
+
+	if changes := task.Status.Changes; changes != nil {
+		if len(changes.PullRequests) > 0 {
+			b.WriteString("\nPull requests:\n")
+			for _, pr := range changes.PullRequests {
+				fmt.Fprintf(&b, "- %s\n", pr)
+			}
+		}
+		if len(changes.Commits) > 0 {
+			commits := make([]string, 0, len(changes.Commits))
+			for _, c := range changes.Commits {
+				commits = append(commits, code(c))
+			}
+			fmt.Fprintf(&b, "\nCommits: %s\n", strings.Join(commits, ", "))
+		}
+		if len(changes.ChangedFiles) > 0 {
+			fmt.Fprintf(&b, "\nChanged files: %d\n", len(changes.ChangedFiles))
+		}
+	}
+	return b.String()
+}
+
+// SetupWithManager sets up the reporter with the Manager
+func (r *Reporter) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("issue-reporter").
+		For(&kubetaskv1alpha1.Task{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			for _, tracker := range r.Trackers {
+				if obj.GetAnnotations()[tracker.Annotation()] != "" {
+					return true
+				}
+			}
+			return false
+		}))).
+		Complete(r)
+}
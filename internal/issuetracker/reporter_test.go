@@ -0,0 +1,107 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package issuetracker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// fakeTracker records the issues it was asked to report to
+type fakeTracker struct {
+	annotation string
+	reported   []string
+}
+
+func (f *fakeTracker) Annotation() string { return f.annotation }
+
+func (f *fakeTracker) Report(_ context.Context, issue string, _ *kubetaskv1alpha1.Task) error {
+	f.reported = append(f.reported, issue)
+	return nil
+}
+
+func newFinishedTask(phase kubetaskv1alpha1.TaskPhase, annotations map[string]string) *kubetaskv1alpha1.Task {
+	start := metav1.NewTime(time.Unix(1700000000, 0))
+	end := metav1.NewTime(start.Add(2 * time.Minute))
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix-bug", Namespace: "team-a", Annotations: annotations},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:          phase,
+			StartTime:      &start,
+			CompletionTime: &end,
+		},
+	}
+}
+
+func TestReporter_ReportsFinishedTasksOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+
+	running := newFinishedTask(kubetaskv1alpha1.TaskPhaseRunning, map[string]string{AnnotationJiraIssue: "PROJ-1"})
+	running.Name = "running"
+	completed := newFinishedTask(kubetaskv1alpha1.TaskPhaseCompleted, map[string]string{AnnotationJiraIssue: "PROJ-2"})
+
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(running, completed).Build()
+	jira := &fakeTracker{annotation: AnnotationJiraIssue}
+	github := &fakeTracker{annotation: AnnotationGitHubIssue}
+	r := &Reporter{Client: k8sClient, Trackers: []Tracker{jira, github}}
+
+	for _, name := range []string{"running", "fix-bug", "fix-bug"} {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "team-a"}}
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile(%s) error = %v", name, err)
+		}
+	}
+
+	if len(jira.reported) != 1 || jira.reported[0] != "PROJ-2" {
+		t.Errorf("Jira reported = %v, want [PROJ-2]", jira.reported)
+	}
+	if len(github.reported) != 0 {
+		t.Errorf("GitHub reported = %v, want none", github.reported)
+	}
+
+	updated := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "fix-bug", Namespace: "team-a"}, updated); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := updated.Annotations[AnnotationJiraIssue+reportedSuffix]; got != "Completed" {
+		t.Errorf("reported annotation = %q, want Completed", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	task := newFinishedTask(kubetaskv1alpha1.TaskPhaseFailed, nil)
+	task.Status.Conditions = []metav1.Condition{{
+		Type: "Ready", Status: metav1.ConditionFalse, Reason: "JobFailed", Message: "Job fix-bug-job failed",
+	}}
+	task.Status.Changes = &kubetaskv1alpha1.TaskChanges{
+		PullRequests: []string{"https://github.com/org/repo/pull/7"},
+		Commits:      []string{"abc1234", "def5678"},
+	}
+
+	got := summary(task, func(s string) string { return "`" + s + "`" })
+	for _, want := range []string{
+		"KubeTask `team-a/fix-bug` failed in 2m0s.",
+		"Failure: Job fix-bug-job failed",
+		"- https://github.com/org/repo/pull/7",
+		"Commits: `abc1234`, `def5678`",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// recordedRequest is a request received by a fake tracker API
+type recordedRequest struct {
+	method string
+	path   string
+	body   map[string]any
+}
+
+// fakeAPI serves canned responses keyed by "METHOD path" and records requests
+func fakeAPI(t *testing.T, responses map[string]string) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+	var requests []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recordedRequest{method: r.Method, path: r.URL.Path}
+		if r.Body != nil && r.ContentLength != 0 {
+			_ = json.NewDecoder(r.Body).Decode(&rec.body)
+		}
+		requests = append(requests, rec)
+
+		resp, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestJira_Report(t *testing.T) {
+	srv, requests := fakeAPI(t, map[string]string{
+		"POST /rest/api/2/issue/PROJ-2/comment":     `{}`,
+		"GET /rest/api/2/issue/PROJ-2/transitions":  `{"transitions":[{"id":"11","name":"In Review"},{"id":"31","name":"Done"}]}`,
+		"POST /rest/api/2/issue/PROJ-2/transitions": ``,
+	})
+	jira := &Jira{BaseURL: srv.URL, User: "bot@example.com", APIToken: "token", SuccessTransition: "done", HTTPClient: srv.Client()}
+
+	task := newFinishedTask(kubetaskv1alpha1.TaskPhaseCompleted, nil)
+	if err := jira.Report(context.Background(), "PROJ-2", task); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if len(*requests) != 3 {
+		t.Fatalf("got %d requests, want comment, list transitions, transition: %+v", len(*requests), *requests)
+	}
+	comment, _ := (*requests)[0].body["body"].(string)
+	if !strings.Contains(comment, "{{team-a/fix-bug}} completed") {
+		t.Errorf("comment = %q, want Jira markup summary", comment)
+	}
+	transition, _ := (*requests)[2].body["transition"].(map[string]any)
+	if transition["id"] != "31" {
+		t.Errorf("transition = %v, want id 31", transition)
+	}
+
+	if err := jira.Report(context.Background(), "not-a-key", task); err == nil {
+		t.Errorf("Report() error = nil, want error for invalid issue key")
+	}
+}
+
+func TestGitHub_Report(t *testing.T) {
+	srv, requests := fakeAPI(t, map[string]string{
+		"POST /repos/org/repo/issues/42/comments": `{}`,
+		"PATCH /repos/org/repo/issues/42":         `{}`,
+	})
+	github := &GitHub{BaseURL: srv.URL, Token: "token", CloseOnSuccess: true, HTTPClient: srv.Client()}
+
+	t.Run("failed Task is commented on but not closed", func(t *testing.T) {
+		*requests = nil
+		task := newFinishedTask(kubetaskv1alpha1.TaskPhaseFailed, nil)
+		if err := github.Report(context.Background(), "org/repo#42", task); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+		if len(*requests) != 1 || (*requests)[0].method != http.MethodPost {
+			t.Errorf("requests = %+v, want a single comment", *requests)
+		}
+	})
+
+	t.Run("completed Task closes the issue", func(t *testing.T) {
+		*requests = nil
+		task := newFinishedTask(kubetaskv1alpha1.TaskPhaseCompleted, nil)
+		if err := github.Report(context.Background(), "org/repo#42", task); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+		if len(*requests) != 2 || (*requests)[1].body["state"] != "closed" {
+			t.Errorf("requests = %+v, want comment then close", *requests)
+		}
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		if err := github.Report(context.Background(), "org/repo/42", newFinishedTask(kubetaskv1alpha1.TaskPhaseCompleted, nil)); err == nil {
+			t.Errorf("Report() error = nil, want error for invalid reference")
+		}
+	})
+}
@@ -0,0 +1,349 @@
+// Copyright Contributors to the KubeTask project
+
+// Package loadgen creates Tasks in bulk and measures how fast the controller starts
+// them, and how many API requests and reconciles it needs to do so, so performance
+// regressions are caught before a release. It is used by cmd/loadgen.
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// RunLabel labels the Tasks of a load run with the run's ID, to watch and clean them up
+const RunLabel = "kubetask.io/loadgen-run"
+
+// Options configure a load run
+type Options struct {
+	// RunID identifies the run in the RunLabel of its Tasks and in their names
+	RunID string
+
+	// Namespace the Tasks are created in
+	Namespace string
+
+	// Tasks is the number of Tasks to create
+	Tasks int
+
+	// Concurrency is the number of Tasks created in parallel, 1 if not positive
+	Concurrency int
+
+	// AgentRef is the Agent the Tasks run with; empty uses the default Agent
+	AgentRef string
+
+	// Description is the description of the Tasks
+	Description string
+}
+
+// Latencies summarizes a distribution of latencies
+type Latencies struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// Report is the outcome of a load run
+type Report struct {
+	RunID string `json:"runID"`
+
+	// Tasks is the number of Tasks the run was asked to create
+	Tasks int `json:"tasks"`
+
+	// Created is the number of Tasks created; CreateErrors the failed creates
+	Created      int `json:"created"`
+	CreateErrors int `json:"createErrors"`
+
+	// Started is the number of created Tasks the controller started, i.e. set a start
+	// time on or failed, before the run ended
+	Started int `json:"started"`
+
+	// Duration is the time from the first create until the last Task started or the
+	// run timed out
+	Duration time.Duration `json:"duration"`
+
+	// CreateRate is the number of Tasks created per second
+	CreateRate float64 `json:"createRate"`
+
+	// StartLatency is the time from creating a Task until it was observed started
+	StartLatency Latencies `json:"startLatency"`
+
+	// Controller is the change of the controller's metrics over the run, if they
+	// were scraped
+	Controller *ControllerStats `json:"controller,omitempty"`
+}
+
+// Run creates the Tasks and waits until the controller started all of them or ctx is
+// done. It returns an error only if the run could not be observed; Tasks that failed
+// to be created or did not start in time are counted in the Report.
+func Run(ctx context.Context, c client.WithWatch, opts Options, metrics *MetricsSource) (Report, error) {
+	report := Report{RunID: opts.RunID, Tasks: opts.Tasks}
+
+	var before *Sample
+	if metrics != nil {
+		sample, err := metrics.Scrape(ctx)
+		if err != nil {
+			return report, fmt.Errorf("unable to scrape controller metrics: %w", err)
+		}
+		before = &sample
+	}
+
+	// Watch before creating, so no Task starts unobserved
+	tracker := newStartTracker()
+	observeCtx, stopObserving := context.WithCancel(ctx)
+	defer stopObserving()
+	observed := make(chan error, 1)
+	go func() {
+		observed <- tracker.observe(observeCtx, c, opts)
+	}()
+
+	begin := time.Now()
+	report.Created, report.CreateErrors = createTasks(ctx, c, opts, tracker)
+	createDuration := time.Since(begin)
+	if createDuration > 0 {
+		report.CreateRate = float64(report.Created) / createDuration.Seconds()
+	}
+
+	select {
+	case <-tracker.wait(observeCtx, report.Created):
+	case <-ctx.Done():
+	case err := <-observed:
+		if err != nil && ctx.Err() == nil {
+			return report, err
+		}
+	}
+	stopObserving()
+	report.Duration = time.Since(begin)
+	latencies := tracker.latencies()
+	report.Started = len(latencies)
+	report.StartLatency = summarize(latencies)
+
+	if metrics != nil {
+		// The run's context may be done after a timeout; the final scrape still runs
+		scrapeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		after, err := metrics.Scrape(scrapeCtx)
+		if err != nil {
+			return report, fmt.Errorf("unable to scrape controller metrics: %w", err)
+		}
+		stats := controllerStats(*before, after, report.Duration)
+		report.Controller = &stats
+	}
+	return report, nil
+}
+
+// Cleanup deletes the Tasks of a load run
+func Cleanup(ctx context.Context, c client.Client, namespace, runID string) error {
+	return c.DeleteAllOf(ctx, &kubetaskv1alpha1.Task{},
+		client.InNamespace(namespace),
+		client.MatchingLabels{RunLabel: runID},
+		client.PropagationPolicy(metav1.DeletePropagationBackground))
+}
+
+// taskName returns the name of the i-th Task of a run
+func taskName(runID string, i int) string {
+	return fmt.Sprintf("loadgen-%s-%05d", runID, i)
+}
+
+// newTask returns the i-th Task of a run
+func newTask(opts Options, i int) *kubetaskv1alpha1.Task {
+	description := opts.Description
+	if description == "" {
+		description = "Load test Task"
+	}
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      taskName(opts.RunID, i),
+			Namespace: opts.Namespace,
+			Labels:    map[string]string{RunLabel: opts.RunID},
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{AgentRef: opts.AgentRef, Description: &description},
+	}
+}
+
+// createTasks creates the Tasks of a run and returns how many were created and how
+// many failed to be
+func createTasks(ctx context.Context, c client.Client, opts Options, tracker *startTracker) (created, failed int) {
+	var mu sync.Mutex
+	var g errgroup.Group
+	g.SetLimit(max(opts.Concurrency, 1))
+	for i := range opts.Tasks {
+		if ctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			task := newTask(opts, i)
+			tracker.created(task.Name, time.Now())
+			err := c.Create(ctx, task)
+			if err != nil {
+				tracker.forget(task.Name)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+			} else {
+				created++
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return created, failed
+}
+
+// startTracker records when the Tasks of a run were created and observed started
+type startTracker struct {
+	mu        sync.Mutex
+	createdAt map[string]time.Time
+	startedIn map[string]time.Duration
+	changed   chan struct{}
+}
+
+func newStartTracker() *startTracker {
+	return &startTracker{
+		createdAt: map[string]time.Time{},
+		startedIn: map[string]time.Duration{},
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+// created records that the creation of a Task was requested at t
+func (s *startTracker) created(name string, t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.createdAt[name] = t
+}
+
+// forget drops a Task whose creation failed
+func (s *startTracker) forget(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.createdAt, name)
+}
+
+// record records a Task observed at t, if it started
+func (s *startTracker) record(task *kubetaskv1alpha1.Task, t time.Time) {
+	if !taskStarted(task) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	createdAt, ok := s.createdAt[task.Name]
+	if _, seen := s.startedIn[task.Name]; !ok || seen {
+		return
+	}
+	s.startedIn[task.Name] = t.Sub(createdAt)
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// wait returns a channel closed once n Tasks started, or ctx is done
+func (s *startTracker) wait(ctx context.Context, n int) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			s.mu.Lock()
+			started := len(s.startedIn)
+			s.mu.Unlock()
+			if started >= n {
+				return
+			}
+			select {
+			case <-s.changed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return done
+}
+
+// latencies returns the start latencies of the Tasks that started
+func (s *startTracker) latencies() []time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	latencies := make([]time.Duration, 0, len(s.startedIn))
+	for _, d := range s.startedIn {
+		latencies = append(latencies, d)
+	}
+	return latencies
+}
+
+// observe watches the Tasks of a run and records when they start, until ctx is done.
+// Closed watches are re-established after listing the Tasks, so no start is missed.
+func (s *startTracker) observe(ctx context.Context, c client.WithWatch, opts Options) error {
+	selector := []client.ListOption{client.InNamespace(opts.Namespace), client.MatchingLabels{RunLabel: opts.RunID}}
+	for ctx.Err() == nil {
+		tasks := &kubetaskv1alpha1.TaskList{}
+		if err := c.List(ctx, tasks, selector...); err != nil {
+			return fmt.Errorf("unable to list Tasks: %w", err)
+		}
+		now := time.Now()
+		for i := range tasks.Items {
+			s.record(&tasks.Items[i], now)
+		}
+
+		w, err := c.Watch(ctx, &kubetaskv1alpha1.TaskList{}, append(selector, &client.ListOptions{Raw: &metav1.ListOptions{
+			ResourceVersion: tasks.ResourceVersion,
+		}})...)
+		if err != nil {
+			return fmt.Errorf("unable to watch Tasks: %w", err)
+		}
+		s.drain(ctx, w)
+		w.Stop()
+	}
+	return nil
+}
+
+// drain records the Tasks of watch events until the watch closes or ctx is done
+func (s *startTracker) drain(ctx context.Context, w watch.Interface) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			if task, ok := event.Object.(*kubetaskv1alpha1.Task); ok && event.Type != watch.Deleted {
+				s.record(task, time.Now())
+			}
+		}
+	}
+}
+
+// taskStarted reports whether the controller started a Task, or failed it before
+func taskStarted(task *kubetaskv1alpha1.Task) bool {
+	return task.Status.StartTime != nil || task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed
+}
+
+// summarize returns the percentiles of latencies
+func summarize(latencies []time.Duration) Latencies {
+	if len(latencies) == 0 {
+		return Latencies{}
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	return Latencies{
+		P50: percentile(0.50),
+		P90: percentile(0.90),
+		P99: percentile(0.99),
+		Max: sorted[len(sorted)-1],
+	}
+}
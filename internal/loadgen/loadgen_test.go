@@ -0,0 +1,173 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// newStartingClient returns a client whose Tasks are started right after they are
+// created, as the controller would, except those skip selects
+func newStartingClient(t *testing.T, skip func(name string) bool) client.WithWatch {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if err := c.Create(ctx, obj, opts...); err != nil {
+					return err
+				}
+				task, ok := obj.(*kubetaskv1alpha1.Task)
+				if !ok || skip(task.Name) {
+					return nil
+				}
+				now := metav1.Now()
+				task.Status.Phase = kubetaskv1alpha1.TaskPhaseRunning
+				task.Status.StartTime = &now
+				return c.Status().Update(ctx, task)
+			},
+		}).
+		Build()
+}
+
+func TestRun(t *testing.T) {
+	c := newStartingClient(t, func(string) bool { return false })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	report, err := Run(ctx, c, Options{RunID: "r1", Namespace: "load", Tasks: 50, Concurrency: 8, AgentRef: "noop"}, nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Created != 50 || report.Started != 50 || report.CreateErrors != 0 {
+		t.Errorf("report = created %d, started %d, errors %d, want all 50 started", report.Created, report.Started, report.CreateErrors)
+	}
+	if report.StartLatency.Max <= 0 || report.StartLatency.P50 > report.StartLatency.P99 {
+		t.Errorf("start latency = %+v", report.StartLatency)
+	}
+	if violations := (Budget{}).Check(report); len(violations) != 0 {
+		t.Errorf("Check() = %v, want no violations", violations)
+	}
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "load", Name: taskName("r1", 7)}, task); err != nil {
+		t.Fatal(err)
+	}
+	if task.Spec.AgentRef != "noop" || task.Labels[RunLabel] != "r1" {
+		t.Errorf("Task = agentRef %q, labels %v", task.Spec.AgentRef, task.Labels)
+	}
+
+	if err := Cleanup(ctx, c, "load", "r1"); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	tasks := &kubetaskv1alpha1.TaskList{}
+	if err := c.List(ctx, tasks); err != nil || len(tasks.Items) != 0 {
+		t.Errorf("Tasks left after Cleanup() = %d, %v", len(tasks.Items), err)
+	}
+}
+
+func TestRun_TasksNotStarted(t *testing.T) {
+	c := newStartingClient(t, func(name string) bool { return strings.HasSuffix(name, "3") })
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	report, err := Run(ctx, c, Options{RunID: "r2", Namespace: "load", Tasks: 10}, nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Created != 10 || report.Started != 9 {
+		t.Errorf("report = created %d, started %d, want 9 of 10 started", report.Created, report.Started)
+	}
+	if violations := (Budget{}).Check(report); len(violations) != 1 || violations[0] != "1 of 10 Tasks did not start" {
+		t.Errorf("Check() = %v", violations)
+	}
+}
+
+func TestMetricsSource(t *testing.T) {
+	var scrapes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		// Every scrape adds 100 reconciles of the Task controller: 98 under 10ms, 2 under 1s
+		n := float64(scrapes.Add(1)) * 100
+		fmt.Fprintf(w, `# TYPE rest_client_requests_total counter
+rest_client_requests_total{code="200",method="GET"} %[1]g
+rest_client_requests_total{code="201",method="POST"} %[1]g
+# TYPE controller_runtime_reconcile_total counter
+controller_runtime_reconcile_total{controller="task",result="success"} %[1]g
+controller_runtime_reconcile_total{controller="crontask",result="success"} 5
+# TYPE controller_runtime_reconcile_time_seconds histogram
+controller_runtime_reconcile_time_seconds_bucket{controller="task",le="0.01"} %[2]g
+controller_runtime_reconcile_time_seconds_bucket{controller="task",le="1"} %[1]g
+controller_runtime_reconcile_time_seconds_bucket{controller="task",le="+Inf"} %[1]g
+controller_runtime_reconcile_time_seconds_sum{controller="task"} %[3]g
+controller_runtime_reconcile_time_seconds_count{controller="task"} %[1]g
+`, n, 0.98*n, n*0.02)
+	}))
+	defer server.Close()
+
+	metrics := &MetricsSource{URL: server.URL, Token: "secret"}
+	before, err := metrics.Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape() error = %v", err)
+	}
+	after, err := metrics.Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape() error = %v", err)
+	}
+
+	stats := controllerStats(before, after, 10*time.Second)
+	want := ControllerStats{APIRequests: 200, APIQPS: 20, Reconciles: 100, ReconcileMean: 20 * time.Millisecond, ReconcileP99: time.Second}
+	if stats != want {
+		t.Errorf("controllerStats() = %+v, want %+v", stats, want)
+	}
+
+	report := Report{Created: 100, Started: 100, Controller: &stats}
+	violations := Budget{APIRequestsPerTask: 1.5, ReconcileP99: 500 * time.Millisecond}.Check(report)
+	if len(violations) != 2 {
+		t.Errorf("Check() = %v, want the API requests and reconcile time exceeded", violations)
+	}
+
+	if _, err := (&MetricsSource{URL: server.URL}).Scrape(context.Background()); err == nil {
+		t.Error("Scrape() without the token succeeded")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	got := summarize(latencies)
+	want := Latencies{P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+	if got != want {
+		t.Errorf("summarize() = %+v, want %+v", got, want)
+	}
+	if got := summarize(nil); got != (Latencies{}) {
+		t.Errorf("summarize(nil) = %+v", got)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright Contributors to the KubeTask project
+
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Metrics of the controller a load run compares before and after
+const (
+	apiRequestsMetric    = "rest_client_requests_total"
+	reconcilesMetric     = "controller_runtime_reconcile_total"
+	reconcileTimeMetric  = "controller_runtime_reconcile_time_seconds"
+	taskControllerLabel  = "controller"
+	taskControllerName   = "task"
+	defaultScrapeTimeout = 10 * time.Second
+)
+
+// MetricsSource is the metrics endpoint of the controller, e.g.
+// http://localhost:8080/metrics after port-forwarding the controller's metrics port
+type MetricsSource struct {
+	URL string
+
+	// Token is sent as bearer token, for controllers serving metrics securely
+	Token string
+
+	// HTTPClient scrapes the endpoint, a client with a 10s timeout if nil
+	HTTPClient *http.Client
+}
+
+// Sample holds the values of the controller's metrics at one point in time
+type Sample struct {
+	// APIRequests is the number of requests the controller sent to the API server
+	APIRequests float64
+
+	// Reconciles is the number of Task reconciles, and ReconcileSeconds their total time
+	Reconciles       float64
+	ReconcileSeconds float64
+
+	// ReconcileBuckets are the cumulative counts of the Task reconcile time histogram,
+	// by upper bound in seconds
+	ReconcileBuckets map[float64]float64
+}
+
+// ControllerStats is the change of the controller's metrics over a load run
+type ControllerStats struct {
+	// APIRequests is the number of API requests the controller sent, and APIQPS their rate
+	APIRequests float64 `json:"apiRequests"`
+	APIQPS      float64 `json:"apiQPS"`
+
+	// Reconciles is the number of Task reconciles
+	Reconciles float64 `json:"reconciles"`
+
+	// ReconcileMean is the mean Task reconcile time, and ReconcileP99 the upper bound
+	// of the histogram bucket holding the 99th percentile
+	ReconcileMean time.Duration `json:"reconcileMean"`
+	ReconcileP99  time.Duration `json:"reconcileP99"`
+}
+
+// Scrape reads the controller's metrics
+func (m *MetricsSource) Scrape(ctx context.Context) (Sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.URL, nil)
+	if err != nil {
+		return Sample{}, err
+	}
+	if m.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+m.Token)
+	}
+	httpClient := m.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultScrapeTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Sample{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Sample{}, fmt.Errorf("%s returned %s", m.URL, resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return Sample{}, fmt.Errorf("unable to parse metrics: %w", err)
+	}
+	return sampleOf(families), nil
+}
+
+// sampleOf extracts a Sample from parsed metric families
+func sampleOf(families map[string]*dto.MetricFamily) Sample {
+	sample := Sample{ReconcileBuckets: map[float64]float64{}}
+	if family := families[apiRequestsMetric]; family != nil {
+		for _, m := range family.GetMetric() {
+			sample.APIRequests += m.GetCounter().GetValue()
+		}
+	}
+	if family := families[reconcilesMetric]; family != nil {
+		for _, m := range family.GetMetric() {
+			if isTaskController(m) {
+				sample.Reconciles += m.GetCounter().GetValue()
+			}
+		}
+	}
+	if family := families[reconcileTimeMetric]; family != nil {
+		for _, m := range family.GetMetric() {
+			if !isTaskController(m) {
+				continue
+			}
+			sample.ReconcileSeconds += m.GetHistogram().GetSampleSum()
+			for _, b := range m.GetHistogram().GetBucket() {
+				sample.ReconcileBuckets[b.GetUpperBound()] += float64(b.GetCumulativeCount())
+			}
+		}
+	}
+	return sample
+}
+
+// isTaskController reports whether a metric is labeled with the Task controller
+func isTaskController(m *dto.Metric) bool {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == taskControllerLabel {
+			return label.GetValue() == taskControllerName
+		}
+	}
+	return false
+}
+
+// controllerStats returns the change between two samples taken duration apart
+func controllerStats(before, after Sample, duration time.Duration) ControllerStats {
+	stats := ControllerStats{
+		APIRequests: after.APIRequests - before.APIRequests,
+		Reconciles:  after.Reconciles - before.Reconciles,
+	}
+	if duration > 0 {
+		stats.APIQPS = stats.APIRequests / duration.Seconds()
+	}
+	if stats.Reconciles <= 0 {
+		return stats
+	}
+	stats.ReconcileMean = seconds((after.ReconcileSeconds - before.ReconcileSeconds) / stats.Reconciles)
+
+	bounds := make([]float64, 0, len(after.ReconcileBuckets))
+	for bound := range after.ReconcileBuckets {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+	for _, bound := range bounds {
+		if after.ReconcileBuckets[bound]-before.ReconcileBuckets[bound] >= 0.99*stats.Reconciles {
+			stats.ReconcileP99 = seconds(bound)
+			break
+		}
+	}
+	return stats
+}
+
+// seconds converts seconds to a Duration; the +Inf bucket becomes the largest Duration
+func seconds(s float64) time.Duration {
+	if math.IsInf(s, 1) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(s * float64(time.Second))
+}
+
+// Budget bounds the performance of the controller under a load run. Zero fields are
+// not checked.
+type Budget struct {
+	// StartP99 bounds the 99th percentile of the start latency
+	StartP99 time.Duration
+
+	// APIRequestsPerTask bounds the API requests the controller sends per created Task
+	APIRequestsPerTask float64
+
+	// ReconcileP99 bounds the 99th percentile of the Task reconcile time
+	ReconcileP99 time.Duration
+}
+
+// Check returns the ways a load run exceeded the budget, including Tasks that were
+// not created or did not start
+func (b Budget) Check(report Report) []string {
+	var violations []string
+	if report.CreateErrors > 0 {
+		violations = append(violations, fmt.Sprintf("%d Tasks could not be created", report.CreateErrors))
+	}
+	if report.Started < report.Created {
+		violations = append(violations, fmt.Sprintf("%d of %d Tasks did not start", report.Created-report.Started, report.Created))
+	}
+	if b.StartP99 > 0 && report.StartLatency.P99 > b.StartP99 {
+		violations = append(violations, fmt.Sprintf("start latency p99 %s exceeds %s", report.StartLatency.P99, b.StartP99))
+	}
+	if stats := report.Controller; stats != nil && report.Created > 0 {
+		if perTask := stats.APIRequests / float64(report.Created); b.APIRequestsPerTask > 0 && perTask > b.APIRequestsPerTask {
+			violations = append(violations, fmt.Sprintf("%.1f API requests per Task exceed %.1f", perTask, b.APIRequestsPerTask))
+		}
+		if b.ReconcileP99 > 0 && stats.ReconcileP99 > b.ReconcileP99 {
+			violations = append(violations, fmt.Sprintf("reconcile time p99 %s exceeds %s", stats.ReconcileP99, b.ReconcileP99))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,87 @@
+// Copyright Contributors to the KubeTask project
+
+// Package quota computes TaskQuota consumption. It is shared by the TaskQuota
+// controller, which reports consumption in status, and the Task admission
+// webhook, which enforces the limits.
+package quota
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// Window is the rolling period daily limits are counted over
+const Window = 24 * time.Hour
+
+// Selector returns the label selector of a quota. A quota without a selector
+// matches every Task in its namespace.
+func Selector(quota *kubetaskv1alpha1.TaskQuota) (labels.Selector, error) {
+	if quota.Spec.Selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(quota.Spec.Selector)
+}
+
+// Counted reports whether a Task counts against quotas at all.
+// Comparison parents do not run an agent themselves; their child Tasks are counted instead.
+func Counted(task *kubetaskv1alpha1.Task) bool {
+	return task.Spec.Replicas == nil || len(task.Spec.Replicas.Compare) == 0
+}
+
+// Usage computes the consumption of the Tasks matched by selector at now
+func Usage(selector labels.Selector, tasks []kubetaskv1alpha1.Task, now time.Time) kubetaskv1alpha1.TaskQuotaUsage {
+	var usage kubetaskv1alpha1.TaskQuotaUsage
+	windowStart := now.Add(-Window)
+	for i := range tasks {
+		task := &tasks[i]
+		if !Counted(task) || !selector.Matches(labels.Set(task.Labels)) {
+			continue
+		}
+
+		if task.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted &&
+			task.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed {
+			usage.ConcurrentTasks++
+		}
+		if task.CreationTimestamp.Time.After(windowStart) {
+			usage.TasksPerDay++
+		}
+		if task.Status.StartTime != nil {
+			start, end := task.Status.StartTime.Time, now
+			if task.Status.CompletionTime != nil {
+				end = task.Status.CompletionTime.Time
+			}
+			if start.Before(windowStart) {
+				start = windowStart
+			}
+			if end.After(start) {
+				usage.RuntimePerDay.Duration += end.Sub(start)
+			}
+		}
+	}
+	usage.RuntimePerDay.Duration = usage.RuntimePerDay.Duration.Truncate(time.Second)
+	return usage
+}
+
+// Exceeded lists the limits of a quota that leave no room for another Task
+func Exceeded(spec kubetaskv1alpha1.TaskQuotaSpec, usage kubetaskv1alpha1.TaskQuotaUsage) []string {
+	var exceeded []string
+	if spec.MaxConcurrentTasks != nil && usage.ConcurrentTasks >= *spec.MaxConcurrentTasks {
+		exceeded = append(exceeded, fmt.Sprintf("maxConcurrentTasks (%d) reached with %d unfinished Tasks",
+			*spec.MaxConcurrentTasks, usage.ConcurrentTasks))
+	}
+	if spec.MaxTasksPerDay != nil && usage.TasksPerDay >= *spec.MaxTasksPerDay {
+		exceeded = append(exceeded, fmt.Sprintf("maxTasksPerDay (%d) reached with %d Tasks in the last 24h",
+			*spec.MaxTasksPerDay, usage.TasksPerDay))
+	}
+	if spec.MaxRuntimeHoursPerDay != nil &&
+		usage.RuntimePerDay.Duration >= time.Duration(*spec.MaxRuntimeHoursPerDay)*time.Hour {
+		exceeded = append(exceeded, fmt.Sprintf("maxRuntimeHoursPerDay (%d) reached with %s of runtime in the last 24h",
+			*spec.MaxRuntimeHoursPerDay, usage.RuntimePerDay.Duration))
+	}
+	return exceeded
+}
@@ -0,0 +1,129 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package quota
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestUsage(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	at := func(ago time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-ago))
+		return &t
+	}
+	newTask := func(team string, created time.Duration, phase kubetaskv1alpha1.TaskPhase, started, completed *metav1.Time) kubetaskv1alpha1.Task {
+		return kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:            map[string]string{"team": team},
+				CreationTimestamp: *at(created),
+			},
+			Status: kubetaskv1alpha1.TaskExecutionStatus{Phase: phase, StartTime: started, CompletionTime: completed},
+		}
+	}
+	comparison := newTask("sre", time.Hour, kubetaskv1alpha1.TaskPhaseRunning, at(time.Hour), nil)
+	comparison.Spec.Replicas = &kubetaskv1alpha1.TaskReplicas{Compare: []string{"a", "b"}}
+
+	tasks := []kubetaskv1alpha1.Task{
+		// Running for the last hour
+		newTask("sre", time.Hour, kubetaskv1alpha1.TaskPhaseRunning, at(time.Hour), nil),
+		// Not started yet
+		newTask("sre", time.Minute, "", nil, nil),
+		// Ran two hours, finished yesterday; only the last 30 minutes are in the window
+		newTask("sre", 26*time.Hour, kubetaskv1alpha1.TaskPhaseCompleted, at(25*time.Hour+30*time.Minute), at(23*time.Hour+30*time.Minute)),
+		// Other team
+		newTask("web", time.Hour, kubetaskv1alpha1.TaskPhaseRunning, at(time.Hour), nil),
+		comparison,
+	}
+
+	quota := &kubetaskv1alpha1.TaskQuota{Spec: kubetaskv1alpha1.TaskQuotaSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}},
+	}}
+	selector, err := Selector(quota)
+	if err != nil {
+		t.Fatalf("Selector() error = %v", err)
+	}
+
+	usage := Usage(selector, tasks, now)
+	want := kubetaskv1alpha1.TaskQuotaUsage{
+		ConcurrentTasks: 2,
+		TasksPerDay:     2,
+		RuntimePerDay:   metav1.Duration{Duration: 90 * time.Minute},
+	}
+	if usage != want {
+		t.Errorf("Usage() = %+v, want %+v", usage, want)
+	}
+
+	// Without a selector every Task in the namespace is counted
+	selector, _ = Selector(&kubetaskv1alpha1.TaskQuota{})
+	if usage := Usage(selector, tasks, now); usage.ConcurrentTasks != 3 {
+		t.Errorf("Usage() without selector ConcurrentTasks = %d, want 3", usage.ConcurrentTasks)
+	}
+}
+
+func TestExceeded(t *testing.T) {
+	usage := kubetaskv1alpha1.TaskQuotaUsage{
+		ConcurrentTasks: 2,
+		TasksPerDay:     10,
+		RuntimePerDay:   metav1.Duration{Duration: 3 * time.Hour},
+	}
+
+	tests := []struct {
+		name string
+		spec kubetaskv1alpha1.TaskQuotaSpec
+		want []string
+	}{
+		{name: "no limits"},
+		{name: "below limits", spec: kubetaskv1alpha1.TaskQuotaSpec{
+			MaxConcurrentTasks:    int32Ptr(3),
+			MaxTasksPerDay:        int32Ptr(11),
+			MaxRuntimeHoursPerDay: int32Ptr(4),
+		}},
+		{name: "concurrent limit reached", spec: kubetaskv1alpha1.TaskQuotaSpec{
+			MaxConcurrentTasks: int32Ptr(2),
+		}, want: []string{"maxConcurrentTasks (2)"}},
+		{name: "daily limits reached", spec: kubetaskv1alpha1.TaskQuotaSpec{
+			MaxTasksPerDay:        int32Ptr(10),
+			MaxRuntimeHoursPerDay: int32Ptr(3),
+		}, want: []string{"maxTasksPerDay (10)", "maxRuntimeHoursPerDay (3)"}},
+		{name: "zero blocks all Tasks", spec: kubetaskv1alpha1.TaskQuotaSpec{
+			MaxConcurrentTasks: int32Ptr(0),
+		}, want: []string{"maxConcurrentTasks (0)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Exceeded(tt.spec, usage)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Exceeded() = %v, want %d entries", got, len(tt.want))
+			}
+			for i := range tt.want {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("Exceeded()[%d] = %q, want prefix %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSelector_Invalid(t *testing.T) {
+	quota := &kubetaskv1alpha1.TaskQuota{Spec: kubetaskv1alpha1.TaskQuotaSpec{
+		Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: "Bogus"},
+		}},
+	}}
+	if _, err := Selector(quota); err == nil {
+		t.Error("Selector() error = nil, want error for invalid operator")
+	}
+}
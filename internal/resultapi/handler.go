@@ -0,0 +1,164 @@
+// Copyright Contributors to the KubeTask project
+
+// Package resultapi serves the endpoint agents push progress and results to while
+// their Task runs, as an alternative to reporting them in the termination message.
+package resultapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/controller"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// maxReportBytes bounds the size of report payloads
+const maxReportBytes = 64 << 10
+
+// errNotRunning is returned for reports on Tasks that are not Running
+var errNotRunning = stderrors.New("reports are only accepted while the Task is Running")
+
+// Report is the payload agents POST to their Task's report path.
+// All fields are optional; changes are added to the ones reported before.
+type Report struct {
+	// Message describes what the agent is doing
+	Message string `json:"message,omitempty"`
+
+	// Percent is the estimated completion of the Task, from 0 to 100
+	Percent *int32 `json:"percent,omitempty"`
+
+	// PullRequests are the URLs of pull requests the agent created or updated
+	PullRequests []string `json:"pullRequests,omitempty"`
+
+	// Commits are the SHAs of commits the agent pushed
+	Commits []string `json:"commits,omitempty"`
+
+	// ChangedFiles are the repository paths the agent modified
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+}
+
+// Handler records agent reports on the status of their Task.
+//
+// Agents authenticate with the bearer token the controller generated for their Task
+// (KUBETASK_RESULT_TOKEN), which only grants access to that Task. Reports are only
+// accepted while the Task is Running.
+type Handler struct {
+	// Client reads Tasks and token Secrets and updates Task status
+	Client client.Client
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := log.FromContext(r.Context()).WithName("resultapi")
+
+	key, ok := parseReportPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.authenticate(r.Context(), key, r.Header.Get("Authorization")); err != nil {
+		log.Info("rejected report", "task", key, "reason", err.Error())
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxReportBytes))
+	if err != nil {
+		http.Error(w, "unable to read request", http.StatusBadRequest)
+		return
+	}
+	report := Report{}
+	if err := json.Unmarshal(body, &report); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if report.Percent != nil && (*report.Percent < 0 || *report.Percent > 100) {
+		http.Error(w, "percent must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.record(r.Context(), key, report); err != nil {
+		if stderrors.Is(err, errNotRunning) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if errors.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		}
+		log.Error(err, "unable to record report", "task", key)
+		http.Error(w, "unable to record report", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseReportPath extracts the Task from a path built by build.ResultReportPath
+func parseReportPath(path string) (types.NamespacedName, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 6 || parts[0] != "v1" || parts[1] != "namespaces" || parts[3] != "tasks" || parts[5] != "report" ||
+		parts[2] == "" || parts[4] == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: parts[2], Name: parts[4]}, true
+}
+
+// authenticate compares the bearer token with the token Secret of the Task
+func (h *Handler) authenticate(ctx context.Context, key types.NamespacedName, authorization string) error {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return fmt.Errorf("missing bearer token")
+	}
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: key.Namespace, Name: build.ResultTokenSecretName(key.Name)}
+	if err := h.Client.Get(ctx, secretKey, secret); err != nil {
+		return fmt.Errorf("unable to get token Secret: %w", err)
+	}
+	expected := secret.Data[build.ResultTokenKey]
+	if len(expected) == 0 || subtle.ConstantTimeCompare(expected, []byte(token)) != 1 {
+		return fmt.Errorf("token mismatch")
+	}
+	return nil
+}
+
+// record updates the progress and changes of a running Task, retrying on conflicts
+// with the controller's own status updates
+func (h *Handler) record(ctx context.Context, key types.NamespacedName, report Report) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		task := &kubetaskv1alpha1.Task{}
+		if err := h.Client.Get(ctx, key, task); err != nil {
+			return err
+		}
+		if task.Status.Phase != kubetaskv1alpha1.TaskPhaseRunning {
+			return errNotRunning
+		}
+		task.Status.Progress = &kubetaskv1alpha1.TaskProgress{
+			Message:    report.Message,
+			Percent:    report.Percent,
+			UpdateTime: metav1.Now(),
+		}
+		task.Status.Changes = controller.MergeTaskChanges(task.Status.Changes,
+			controller.ReportedChanges(report.PullRequests, report.Commits, report.ChangedFiles))
+		return h.Client.Status().Update(ctx, task)
+	})
+}
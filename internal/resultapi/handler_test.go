@@ -0,0 +1,110 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package resultapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+func newTestClient(t *testing.T, phase kubetaskv1alpha1.TaskPhase) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:   phase,
+			Changes: &kubetaskv1alpha1.TaskChanges{Commits: []string{"abc1234"}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: build.ResultTokenSecretName("test-task"), Namespace: "default"},
+		Data:       map[string][]byte{build.ResultTokenKey: []byte("s3cret")},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(task, secret).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+}
+
+func TestHandler(t *testing.T) {
+	reportPath := build.ResultReportPath("default", "test-task")
+	tests := []struct {
+		name       string
+		phase      kubetaskv1alpha1.TaskPhase
+		method     string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+	}{
+		{name: "records report", phase: kubetaskv1alpha1.TaskPhaseRunning, path: reportPath, token: "s3cret",
+			body:       `{"message":"Running tests","percent":60,"pullRequests":["https://github.com/org/repo/pull/7"],"commits":["def5678"]}`,
+			wantStatus: http.StatusNoContent},
+		{name: "missing token", phase: kubetaskv1alpha1.TaskPhaseRunning, path: reportPath, body: `{}`, wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", phase: kubetaskv1alpha1.TaskPhaseRunning, path: reportPath, token: "guess", body: `{}`, wantStatus: http.StatusUnauthorized},
+		{name: "token of another Task", phase: kubetaskv1alpha1.TaskPhaseRunning, path: build.ResultReportPath("default", "other-task"),
+			token: "s3cret", body: `{}`, wantStatus: http.StatusUnauthorized},
+		{name: "finished Task", phase: kubetaskv1alpha1.TaskPhaseCompleted, path: reportPath, token: "s3cret", body: `{}`, wantStatus: http.StatusConflict},
+		{name: "invalid percent", phase: kubetaskv1alpha1.TaskPhaseRunning, path: reportPath, token: "s3cret", body: `{"percent":150}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid payload", phase: kubetaskv1alpha1.TaskPhaseRunning, path: reportPath, token: "s3cret", body: `not json`, wantStatus: http.StatusBadRequest},
+		{name: "unknown path", phase: kubetaskv1alpha1.TaskPhaseRunning, path: "/v1/tasks", token: "s3cret", body: `{}`, wantStatus: http.StatusNotFound},
+		{name: "wrong method", phase: kubetaskv1alpha1.TaskPhaseRunning, method: http.MethodGet, path: reportPath, token: "s3cret", wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := newTestClient(t, tt.phase)
+			h := &Handler{Client: k8sClient}
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if rec.Code != http.StatusNoContent {
+				return
+			}
+
+			task := &kubetaskv1alpha1.Task{}
+			if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: "test-task", Namespace: "default"}, task); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			progress := task.Status.Progress
+			if progress == nil || progress.Message != "Running tests" || progress.Percent == nil || *progress.Percent != 60 {
+				t.Errorf("Progress = %+v, want the reported progress", progress)
+			}
+			changes := task.Status.Changes
+			if len(changes.PullRequests) != 1 || len(changes.Commits) != 2 {
+				t.Errorf("Changes = %+v, want the reported changes added to the existing ones", changes)
+			}
+		})
+	}
+}
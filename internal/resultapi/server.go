@@ -0,0 +1,58 @@
+// Copyright Contributors to the KubeTask project
+
+package resultapi
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Server serves the result API as a Manager runnable
+type Server struct {
+	// Addr is the address the server binds to
+	Addr string
+
+	// Handler serves the report endpoint
+	Handler http.Handler
+}
+
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NeedLeaderElection returns false so every replica behind the Service can accept reports
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("resultapi")
+
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("serving result API", "addr", s.Addr)
+		if err := srv.ListenAndServe(); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
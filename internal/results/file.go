@@ -0,0 +1,144 @@
+// Copyright Contributors to the KubeTask project
+
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// FileStore keeps each Record as a JSON file, <dir>/<namespace>/<uid>.json, typically
+// on a PersistentVolume mounted by the controller. The records are indexed in memory
+// when the store is opened, so queries do not read the files. It is meant to be used
+// by a single process.
+type FileStore struct {
+	dir string
+
+	mu      sync.RWMutex
+	records map[types.UID]*Record
+}
+
+var _ Store = &FileStore{}
+
+// OpenFileStore creates dir if needed and loads the records stored in it
+func OpenFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	s := &FileStore{dir: dir, records: map[types.UID]*Record{}}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		record := &Record{}
+		if err := json.Unmarshal(data, record); err != nil {
+			return nil, fmt.Errorf("unable to parse result %s: %w", path, err)
+		}
+		s.records[record.UID] = record
+	}
+	return s, nil
+}
+
+// Put writes the record unless an identical one is stored already
+func (s *FileStore) Put(_ context.Context, record Record) error {
+	if record.UID == "" || record.Namespace == "" {
+		return fmt.Errorf("result of Task %s/%s has no namespace or UID", record.Namespace, record.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[record.UID]; ok && reflect.DeepEqual(*existing, record) {
+		return nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	path := s.path(record.Namespace, record.UID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write a temporary file and rename it, so a crash never leaves a partial record
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	s.records[record.UID] = &record
+	return nil
+}
+
+// Get returns a record from the index
+func (s *FileStore) Get(_ context.Context, namespace string, uid types.UID) (Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.records[uid]
+	if !ok || record.Namespace != namespace {
+		return Record{}, ErrNotFound
+	}
+	return *record, nil
+}
+
+// List returns the matching records from the index
+func (s *FileStore) List(_ context.Context, q Query) ([]Record, error) {
+	s.mu.RLock()
+	var matched []Record
+	for _, record := range s.records {
+		if q.Matches(record) {
+			matched = append(matched, *record)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(matched, func(a, b Record) int {
+		if c := b.CompletionTime.Compare(a.CompletionTime); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.UID), string(b.UID))
+	})
+	if q.Limit > 0 && len(matched) > q.Limit {
+		matched = matched[:q.Limit]
+	}
+	return matched, nil
+}
+
+// Prune deletes the files of old records
+func (s *FileStore) Prune(_ context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for uid, record := range s.records {
+		if !record.CompletionTime.Before(before) {
+			continue
+		}
+		if err := os.Remove(s.path(record.Namespace, uid)); err != nil && !os.IsNotExist(err) {
+			return deleted, err
+		}
+		delete(s.records, uid)
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (s *FileStore) path(namespace string, uid types.UID) string {
+	return filepath.Join(s.dir, namespace, string(uid)+".json")
+}
@@ -0,0 +1,111 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package results
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+var baseTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newRecord(namespace, name, agent string, phase kubetaskv1alpha1.TaskPhase, completedAfter time.Duration) Record {
+	return Record{
+		Namespace:      namespace,
+		Name:           name,
+		UID:            types.UID(namespace + "-" + name),
+		Agent:          agent,
+		Labels:         map[string]string{"team": agent},
+		Phase:          phase,
+		CreationTime:   baseTime,
+		CompletionTime: baseTime.Add(completedAfter),
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range []Record{
+		newRecord("team-a", "old", "claude", kubetaskv1alpha1.TaskPhaseCompleted, time.Hour),
+		newRecord("team-a", "failed", "claude", kubetaskv1alpha1.TaskPhaseFailed, 2*time.Hour),
+		newRecord("team-a", "gemini", "gemini", kubetaskv1alpha1.TaskPhaseCompleted, 3*time.Hour),
+		newRecord("team-b", "other", "claude", kubetaskv1alpha1.TaskPhaseCompleted, 4*time.Hour),
+	} {
+		if err := store.Put(ctx, record); err != nil {
+			t.Fatalf("Put(%s) error = %v", record.Name, err)
+		}
+	}
+	if err := store.Put(ctx, Record{Name: "no-uid"}); err == nil {
+		t.Error("Put() without UID error = nil")
+	}
+
+	names := func(records []Record) []string {
+		var names []string
+		for _, r := range records {
+			names = append(names, r.Name)
+		}
+		return names
+	}
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{name: "all, most recent first", want: []string{"other", "gemini", "failed", "old"}},
+		{name: "namespace and agent", query: Query{Namespace: "team-a", Agent: "claude"}, want: []string{"failed", "old"}},
+		{name: "phase", query: Query{Phase: kubetaskv1alpha1.TaskPhaseFailed}, want: []string{"failed"}},
+		{name: "labels", query: Query{Selector: labels.SelectorFromSet(labels.Set{"team": "gemini"})}, want: []string{"gemini"}},
+		{name: "time range", query: Query{Since: baseTime.Add(2 * time.Hour), Until: baseTime.Add(4 * time.Hour)}, want: []string{"gemini", "failed"}},
+		{name: "limit", query: Query{Limit: 1}, want: []string{"other"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.List(ctx, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(got); !slices.Equal(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Records survive reopening the store
+	reopened, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := reopened.Get(ctx, "team-a", "team-a-failed")
+	if err != nil || record.Phase != kubetaskv1alpha1.TaskPhaseFailed || !record.CompletionTime.Equal(baseTime.Add(2*time.Hour)) {
+		t.Errorf("Get() after reopening = %+v, %v", record, err)
+	}
+	if _, err := reopened.Get(ctx, "team-b", "team-a-failed"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() in another namespace error = %v, want ErrNotFound", err)
+	}
+
+	deleted, err := reopened.Prune(ctx, baseTime.Add(150*time.Minute))
+	if err != nil || deleted != 2 {
+		t.Fatalf("Prune() = %d, %v, want 2 deleted", deleted, err)
+	}
+	reopened, err = OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reopened.List(ctx, Query{}); !slices.Equal(names(got), []string{"other", "gemini"}) {
+		t.Errorf("List() after pruning = %v, want [other gemini]", names(got))
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright Contributors to the KubeTask project
+
+package results
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// listPath lists records, filtered by query parameters
+	listPath = "/api/v1/results"
+
+	// defaultLimit bounds list responses without a limit parameter
+	defaultLimit = 100
+
+	// maxLimit bounds the limit parameter
+	maxLimit = 1000
+)
+
+// List is the response of the list endpoint
+type List struct {
+	Items []Record `json:"items"`
+}
+
+// Handler serves the read-only query API of a Store:
+//
+//	GET /api/v1/results?namespace=&agent=&phase=&labelSelector=&since=&until=&limit=
+//	GET /api/v1/results/<namespace>/<uid>
+//
+// since and until are RFC 3339 times or durations before now, such as 720h. Lists
+// are sorted by completion time, most recent first, and hold at most limit records
+// (default 100, at most 1000).
+type Handler struct {
+	Store Store
+
+	// Token, if set, must be sent as a bearer token
+	Token string
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != listPath && !strings.HasPrefix(r.URL.Path, listPath+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(r.URL.Path, listPath+"/"); ok {
+		namespace, uid, ok := strings.Cut(rest, "/")
+		if !ok || namespace == "" || uid == "" || strings.Contains(uid, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		record, err := h.Store.Get(r.Context(), namespace, types.UID(uid))
+		if stderrors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			h.serverError(w, r, err)
+			return
+		}
+		writeJSON(w, record)
+		return
+	}
+
+	q, err := parseQuery(r.URL.Query(), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	records, err := h.Store.List(r.Context(), q)
+	if err != nil {
+		h.serverError(w, r, err)
+		return
+	}
+	if records == nil {
+		records = []Record{}
+	}
+	writeJSON(w, List{Items: records})
+}
+
+func (h *Handler) serverError(w http.ResponseWriter, r *http.Request, err error) {
+	log.FromContext(r.Context()).WithName("results").Error(err, "unable to query results", "path", r.URL.Path)
+	http.Error(w, "unable to query results", http.StatusInternalServerError)
+}
+
+// parseQuery parses the query parameters of the list endpoint
+func parseQuery(values url.Values, now time.Time) (Query, error) {
+	q := Query{
+		Namespace: values.Get("namespace"),
+		Agent:     values.Get("agent"),
+		Phase:     kubetaskv1alpha1.TaskPhase(values.Get("phase")),
+		Limit:     defaultLimit,
+	}
+	if s := values.Get("labelSelector"); s != "" {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return Query{}, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		q.Selector = selector
+	}
+	var err error
+	if q.Since, err = parseTime(values.Get("since"), now); err != nil {
+		return Query{}, fmt.Errorf("invalid since: %w", err)
+	}
+	if q.Until, err = parseTime(values.Get("until"), now); err != nil {
+		return Query{}, fmt.Errorf("invalid until: %w", err)
+	}
+	if s := values.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			return Query{}, fmt.Errorf("invalid limit %q", s)
+		}
+		q.Limit = min(limit, maxLimit)
+	}
+	return q, nil
+}
+
+// parseTime parses an RFC 3339 time, or a duration before now
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
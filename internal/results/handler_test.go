@@ -0,0 +1,125 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package results
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+func TestFromTask(t *testing.T) {
+	completion := metav1.NewTime(baseTime.Add(time.Hour))
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "fix", Namespace: "default", UID: "uid-1",
+			Annotations: map[string]string{audit.AnnotationCreatedBy: "alice"},
+		},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{
+			Phase:      kubetaskv1alpha1.TaskPhaseRunning,
+			Conditions: []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Message: "agent exited with code 1"}},
+		},
+	}
+	if _, ok := FromTask(task, "claude"); ok {
+		t.Error("FromTask() of a running Task ok = true")
+	}
+
+	task.Status.Phase = kubetaskv1alpha1.TaskPhaseFailed
+	task.Status.CompletionTime = &completion
+	record, ok := FromTask(task, "claude")
+	if !ok {
+		t.Fatal("FromTask() of a failed Task ok = false")
+	}
+	if record.Agent != "claude" || record.Creator != "alice" || record.Message != "agent exited with code 1" ||
+		!record.CompletionTime.Equal(completion.Time) || record.StartTime != nil {
+		t.Errorf("FromTask() = %+v", record)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	store, err := OpenFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range []Record{
+		newRecord("team-a", "first", "claude", kubetaskv1alpha1.TaskPhaseCompleted, time.Hour),
+		newRecord("team-a", "second", "claude", kubetaskv1alpha1.TaskPhaseFailed, 2*time.Hour),
+	} {
+		if err := store.Put(context.Background(), record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := &Handler{Store: store, Token: "secret"}
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("/api/v1/results", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token status = %d, want 401", w.Code)
+	}
+
+	w := get("/api/v1/results?namespace=team-a&phase=Failed", "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("list status = %d, body %s", w.Code, w.Body)
+	}
+	var list List
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "second" {
+		t.Errorf("list = %+v, want the failed Task", list.Items)
+	}
+
+	w = get("/api/v1/results/team-a/team-a-first", "secret")
+	var record Record
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &record) != nil || record.Name != "first" {
+		t.Errorf("get status = %d, body %s", w.Code, w.Body)
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/results/team-b/team-a-first": http.StatusNotFound,
+		"/api/v1/results?limit=0":             http.StatusBadRequest,
+		"/api/v1/results?since=yesterday":     http.StatusBadRequest,
+		"/api/v1/results?labelSelector=a%20b": http.StatusBadRequest,
+		"/other":                              http.StatusNotFound,
+	} {
+		if w := get(path, "secret"); w.Code != want {
+			t.Errorf("GET %s status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	now := baseTime
+	q, err := parseQuery(map[string][]string{
+		"since": {"24h"},
+		"until": {"2026-01-01T00:00:00Z"},
+		"limit": {"5000"},
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Since.Equal(now.Add(-24*time.Hour)) || !q.Until.Equal(now) || q.Limit != maxLimit {
+		t.Errorf("parseQuery() = %+v", q)
+	}
+	if q, _ := parseQuery(nil, now); q.Limit != defaultLimit {
+		t.Errorf("default limit = %d, want %d", q.Limit, defaultLimit)
+	}
+}
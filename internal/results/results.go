@@ -0,0 +1,128 @@
+// Copyright Contributors to the KubeTask project
+
+// Package results keeps the outcome of finished Tasks outside of etcd. The controller
+// records every Task in a Store when it finishes, before the Task's TTL can delete it,
+// and the Store answers queries for months of agent history that would be too much
+// for the API server to hold as Task objects.
+package results
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+// ErrNotFound is returned by Store.Get for records that do not exist
+var ErrNotFound = errors.New("result not found")
+
+// Record is the stored outcome of a finished Task
+type Record struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	UID       types.UID         `json:"uid"`
+	Agent     string            `json:"agent"`
+	Labels    map[string]string `json:"labels,omitempty"`
+
+	// Creator is the user that created the Task, from the kubetask.io/created-by annotation
+	Creator string `json:"creator,omitempty"`
+
+	Phase kubetaskv1alpha1.TaskPhase `json:"phase"`
+
+	// Message is the message of the Task's Ready condition
+	Message string `json:"message,omitempty"`
+
+	CreationTime   time.Time  `json:"creationTime"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime time.Time  `json:"completionTime"`
+
+	AgentImage       *kubetaskv1alpha1.AgentImageStatus `json:"agentImage,omitempty"`
+	Changes          *kubetaskv1alpha1.TaskChanges      `json:"changes,omitempty"`
+	Result           *kubetaskv1alpha1.TaskResult       `json:"result,omitempty"`
+	FailureArtifacts []kubetaskv1alpha1.FailureArtifact `json:"failureArtifacts,omitempty"`
+}
+
+// FromTask builds the Record of a finished Task run by agent. ok is false for Tasks
+// that have not finished.
+func FromTask(task *kubetaskv1alpha1.Task, agent string) (record Record, ok bool) {
+	if (task.Status.Phase != kubetaskv1alpha1.TaskPhaseCompleted && task.Status.Phase != kubetaskv1alpha1.TaskPhaseFailed) ||
+		task.Status.CompletionTime == nil {
+		return Record{}, false
+	}
+	record = Record{
+		Namespace:        task.Namespace,
+		Name:             task.Name,
+		UID:              task.UID,
+		Agent:            agent,
+		Labels:           task.Labels,
+		Creator:          task.Annotations[audit.AnnotationCreatedBy],
+		Phase:            task.Status.Phase,
+		CreationTime:     task.CreationTimestamp.UTC(),
+		CompletionTime:   task.Status.CompletionTime.UTC(),
+		AgentImage:       task.Status.AgentImage,
+		Changes:          task.Status.Changes,
+		Result:           task.Status.Result,
+		FailureArtifacts: task.Status.FailureArtifacts,
+	}
+	if task.Status.StartTime != nil {
+		startTime := task.Status.StartTime.UTC()
+		record.StartTime = &startTime
+	}
+	if ready := meta.FindStatusCondition(task.Status.Conditions, "Ready"); ready != nil {
+		record.Message = ready.Message
+	}
+	return record, true
+}
+
+// Query selects records. Empty fields match every record.
+type Query struct {
+	Namespace string
+	Agent     string
+	Phase     kubetaskv1alpha1.TaskPhase
+
+	// Selector matches the labels of the Task
+	Selector labels.Selector
+
+	// Since and Until bound the completion time, inclusive and exclusive
+	Since time.Time
+	Until time.Time
+
+	// Limit is the maximum number of records returned, 0 for all
+	Limit int
+}
+
+// Matches reports whether r is selected by q, ignoring Limit
+func (q Query) Matches(r *Record) bool {
+	switch {
+	case q.Namespace != "" && r.Namespace != q.Namespace,
+		q.Agent != "" && r.Agent != q.Agent,
+		q.Phase != "" && r.Phase != q.Phase,
+		q.Selector != nil && !q.Selector.Matches(labels.Set(r.Labels)),
+		!q.Since.IsZero() && r.CompletionTime.Before(q.Since),
+		!q.Until.IsZero() && !r.CompletionTime.Before(q.Until):
+		return false
+	}
+	return true
+}
+
+// Store persists Records
+type Store interface {
+	// Put adds or replaces the record of a Task, by UID
+	Put(ctx context.Context, record Record) error
+
+	// Get returns the record of the Task with uid in namespace, or ErrNotFound
+	Get(ctx context.Context, namespace string, uid types.UID) (Record, error)
+
+	// List returns the records matching q, most recently completed first
+	List(ctx context.Context, q Query) ([]Record, error)
+
+	// Prune deletes the records of Tasks completed before the given time and
+	// returns how many were deleted
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
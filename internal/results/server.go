@@ -0,0 +1,99 @@
+// Copyright Contributors to the KubeTask project
+
+package results
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// PruneInterval is how often records older than the retention are deleted
+const PruneInterval = time.Hour
+
+// Server serves the query API as a Manager runnable
+type Server struct {
+	// Addr is the address the server binds to
+	Addr string
+
+	// Handler serves the query API
+	Handler http.Handler
+}
+
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NeedLeaderElection returns false so the API is served while another replica leads
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("results")
+
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("serving results API", "addr", s.Addr)
+		if err := srv.ListenAndServe(); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Pruner deletes the records of a Store older than the retention, at startup and
+// every PruneInterval
+type Pruner struct {
+	Store Store
+
+	// Retention is how long records are kept after their Task completed
+	Retention time.Duration
+}
+
+var _ manager.LeaderElectionRunnable = &Pruner{}
+
+// NeedLeaderElection returns true so only the replica recording results prunes them
+func (p *Pruner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start prunes until the context is cancelled
+func (p *Pruner) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("results")
+
+	ticker := time.NewTicker(PruneInterval)
+	defer ticker.Stop()
+	for {
+		deleted, err := p.Store.Prune(ctx, time.Now().Add(-p.Retention))
+		if err != nil {
+			log.Error(err, "unable to prune results")
+		} else if deleted > 0 {
+			log.Info("pruned results", "deleted", deleted, "retention", p.Retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
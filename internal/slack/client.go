@@ -0,0 +1,97 @@
+// Copyright Contributors to the KubeTask project
+
+// Package slack implements the optional Slack chat-ops bridge.
+//
+// The bridge serves the `/kubetask` slash command, which creates Tasks from the
+// taskTemplate of a CronTask, and a notifier that posts Task progress and results
+// to the Slack thread the command started.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultAPIURL is the base URL of the Slack Web API
+const DefaultAPIURL = "https://slack.com/api"
+
+// Client is a minimal Slack Web API client authenticated with a bot token
+type Client struct {
+	// Token is the bot token (xoxb-...) used to post messages
+	Token string
+
+	// BaseURL is the Slack Web API base URL, DefaultAPIURL if empty
+	BaseURL string
+
+	// HTTPClient is used for requests, a client with a 10s timeout if nil
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client for the given bot token
+func NewClient(token string) *Client {
+	return &Client{
+		Token:      token,
+		BaseURL:    DefaultAPIURL,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// postMessageResponse is the subset of the chat.postMessage response used by the bridge
+type postMessageResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	TS    string `json:"ts,omitempty"`
+}
+
+// PostMessage posts text to a channel, replying in a thread if threadTS is set.
+// It returns the timestamp of the posted message, which identifies its thread.
+func (c *Client) PostMessage(ctx context.Context, channel, threadTS, text string) (string, error) {
+	payload := map[string]string{
+		"channel": channel,
+		"text":    text,
+	}
+	if threadTS != "" {
+		payload["thread_ts"] = threadTS
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/chat.postMessage", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("chat.postMessage returned HTTP %d", resp.StatusCode)
+	}
+	var result postMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decoding chat.postMessage response: %w", err)
+	}
+	if !result.OK {
+		return "", fmt.Errorf("chat.postMessage failed: %s", result.Error)
+	}
+	return result.TS, nil
+}
@@ -0,0 +1,221 @@
+// Copyright Contributors to the KubeTask project
+
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// AnnotationChannel records the Slack channel a Task was created from
+	AnnotationChannel = "kubetask.io/slack-channel"
+
+	// AnnotationThreadTS records the Slack thread that receives a Task's progress updates
+	AnnotationThreadTS = "kubetask.io/slack-thread-ts"
+
+	// AnnotationUser records the Slack user that ran the command
+	AnnotationUser = "kubetask.io/slack-user"
+
+	// AnnotationNotifiedPhase records the last Task phase posted to the thread
+	AnnotationNotifiedPhase = "kubetask.io/slack-notified-phase"
+
+	// TemplateLabelKey records the CronTask whose taskTemplate a Task was created from
+	TemplateLabelKey = "kubetask.io/slack-template"
+
+	// maxRequestAge bounds how old a signed request may be, to prevent replays
+	maxRequestAge = 5 * time.Minute
+
+	// maxRequestBytes bounds the size of slash command payloads
+	maxRequestBytes = 64 << 10
+
+	usage = "Usage: `/kubetask run <template> [instructions...]`, where <template> is the name of a CronTask whose taskTemplate is used."
+)
+
+// CommandHandler serves the `/kubetask` slash command.
+//
+// `/kubetask run <template> [instructions...]` creates a Task in Namespace from the
+// taskTemplate of the CronTask named <template>. Any instructions are appended to the
+// template's description. The Task is announced in the channel, and the announcement's
+// thread receives progress updates from the Notifier.
+type CommandHandler struct {
+	// Client creates Tasks and reads CronTask templates
+	Client client.Client
+
+	// Slack posts the announcement that starts the Task's thread
+	Slack *Client
+
+	// SigningSecret verifies that requests come from Slack
+	SigningSecret []byte
+
+	// Namespace is where templates are looked up and Tasks are created
+	Namespace string
+
+	// now returns the current time, time.Now if nil
+	now func() time.Time
+}
+
+// commandResponse is the immediate reply to a slash command
+type commandResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// ServeHTTP implements http.Handler
+func (h *CommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := log.FromContext(r.Context()).WithName("slack")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+	if err != nil {
+		http.Error(w, "unable to read request", http.StatusBadRequest)
+		return
+	}
+	if err := h.verify(r.Header, body); err != nil {
+		log.Info("rejected slash command", "reason", err.Error())
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	text := h.run(r.Context(), form)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(commandResponse{ResponseType: "ephemeral", Text: text})
+}
+
+// verify checks the Slack request signature as documented at
+// https://api.slack.com/authentication/verifying-requests-from-slack
+func (h *CommandHandler) verify(header http.Header, body []byte) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	signature := header.Get("X-Slack-Signature")
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("missing signature headers")
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+	if age := now().Sub(time.Unix(seconds, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return fmt.Errorf("request timestamp is %s old", age)
+	}
+
+	mac := hmac.New(sha256.New, h.SigningSecret)
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+	return nil
+}
+
+// run executes a verified command and returns the reply for the requesting user
+func (h *CommandHandler) run(ctx context.Context, form url.Values) string {
+	log := log.FromContext(ctx).WithName("slack")
+
+	fields := strings.Fields(form.Get("text"))
+	if len(fields) < 2 || fields[0] != "run" {
+		return usage
+	}
+	templateName := fields[1]
+	instructions := strings.Join(fields[2:], " ")
+	channel := form.Get("channel_id")
+	user := form.Get("user_id")
+
+	cronTask := &kubetaskv1alpha1.CronTask{}
+	if err := h.Client.Get(ctx, types.NamespacedName{Name: templateName, Namespace: h.Namespace}, cronTask); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("Template %q not found. %s", templateName, usage)
+		}
+		log.Error(err, "unable to get template", "template", templateName)
+		return fmt.Sprintf("Unable to get template %q: %v", templateName, err)
+	}
+
+	task := buildTask(cronTask, instructions, channel, user)
+	if err := h.Client.Create(ctx, task); err != nil {
+		log.Error(err, "unable to create Task", "template", templateName)
+		return fmt.Sprintf("Unable to create Task from template %q: %v", templateName, err)
+	}
+	log.Info("created Task from slash command", "task", task.Name, "template", templateName, "user", user)
+
+	// Announce the Task; its thread receives the progress updates
+	announcement := fmt.Sprintf("<@%s> started Task `%s/%s` from template `%s`", user, task.Namespace, task.Name, templateName)
+	threadTS, err := h.Slack.PostMessage(ctx, channel, "", announcement)
+	if err != nil {
+		log.Error(err, "unable to announce Task", "task", task.Name)
+		return fmt.Sprintf("Created Task `%s/%s`, but progress updates are unavailable: %v", task.Namespace, task.Name, err)
+	}
+
+	patch := client.MergeFrom(task.DeepCopy())
+	task.Annotations[AnnotationThreadTS] = threadTS
+	if err := h.Client.Patch(ctx, task, patch); err != nil {
+		log.Error(err, "unable to record Slack thread", "task", task.Name)
+		return fmt.Sprintf("Created Task `%s/%s`, but progress updates are unavailable: %v", task.Namespace, task.Name, err)
+	}
+	return fmt.Sprintf("Created Task `%s/%s`, progress is posted to the thread", task.Namespace, task.Name)
+}
+
+// buildTask creates a Task from a CronTask's taskTemplate with the given extra instructions
+func buildTask(cronTask *kubetaskv1alpha1.CronTask, instructions, channel, user string) *kubetaskv1alpha1.Task {
+	tmpl := cronTask.Spec.TaskTemplate
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: cronTask.Name + "-",
+			Namespace:    cronTask.Namespace,
+			Labels:       map[string]string{},
+			Annotations:  map[string]string{},
+		},
+		Spec: *tmpl.Spec.DeepCopy(),
+	}
+
+	// Merge labels and annotations from template
+	for k, v := range tmpl.Labels {
+		task.Labels[k] = v
+	}
+	for k, v := range tmpl.Annotations {
+		task.Annotations[k] = v
+	}
+	task.Labels[TemplateLabelKey] = cronTask.Name
+	task.Annotations[AnnotationChannel] = channel
+	task.Annotations[AnnotationUser] = user
+
+	if instructions != "" {
+		description := instructions
+		if task.Spec.Description != nil && *task.Spec.Description != "" {
+			description = *task.Spec.Description + "\n\n" + instructions
+		}
+		task.Spec.Description = &description
+	}
+	return task
+}
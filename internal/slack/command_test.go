@@ -0,0 +1,209 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// fakeSlack records chat.postMessage calls
+type fakeSlack struct {
+	mu       sync.Mutex
+	messages []map[string]string
+}
+
+func (f *fakeSlack) serve(t *testing.T) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("decoding chat.postMessage payload: %v", err)
+		}
+		f.mu.Lock()
+		f.messages = append(f.messages, payload)
+		ts := fmt.Sprintf("1700000000.%06d", len(f.messages))
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(postMessageResponse{OK: true, TS: ts})
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{Token: "xoxb-test", BaseURL: srv.URL, HTTPClient: srv.Client()}
+}
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() error = %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&kubetaskv1alpha1.Task{}).Build()
+}
+
+func signedRequest(secret []byte, ts time.Time, form url.Values) *http.Request {
+	body := form.Encode()
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+
+	req := httptest.NewRequest(http.MethodPost, CommandPath, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestCommandHandler_Verify(t *testing.T) {
+	secret := []byte("signing-secret")
+	now := time.Unix(1700000000, 0)
+	h := &CommandHandler{SigningSecret: secret, now: func() time.Time { return now }}
+	form := url.Values{"text": {"help"}}
+
+	tests := []struct {
+		name    string
+		req     *http.Request
+		wantErr bool
+	}{
+		{
+			name: "valid signature",
+			req:  signedRequest(secret, now, form),
+		},
+		{
+			name:    "wrong secret",
+			req:     signedRequest([]byte("other"), now, form),
+			wantErr: true,
+		},
+		{
+			name:    "replayed request",
+			req:     signedRequest(secret, now.Add(-10*time.Minute), form),
+			wantErr: true,
+		},
+		{
+			name:    "unsigned request",
+			req:     httptest.NewRequest(http.MethodPost, CommandPath, strings.NewReader(form.Encode())),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(form.Encode())
+			err := h.verify(tt.req.Header, body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommandHandler_Run(t *testing.T) {
+	description := "Update dependencies"
+	template := &kubetaskv1alpha1.CronTask{
+		ObjectMeta: metav1.ObjectMeta{Name: "deps", Namespace: "kubetask"},
+		Spec: kubetaskv1alpha1.CronTaskSpec{
+			Schedule: "0 0 * * *",
+			TaskTemplate: kubetaskv1alpha1.TaskTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "platform"}},
+				Spec: kubetaskv1alpha1.TaskSpec{
+					Description: &description,
+					AgentRef:    "gemini",
+				},
+			},
+		},
+	}
+
+	secret := []byte("signing-secret")
+	now := time.Unix(1700000000, 0)
+	slack := &fakeSlack{}
+	k8sClient := newTestClient(t, template)
+	h := &CommandHandler{
+		Client:        k8sClient,
+		Slack:         slack.serve(t),
+		SigningSecret: secret,
+		Namespace:     "kubetask",
+		now:           func() time.Time { return now },
+	}
+
+	t.Run("creates Task from template", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedRequest(secret, now, url.Values{
+			"text":       {"run deps only bump patch versions"},
+			"channel_id": {"C123"},
+			"user_id":    {"U456"},
+		}))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("ServeHTTP() status = %d, want 200", rec.Code)
+		}
+
+		tasks := &kubetaskv1alpha1.TaskList{}
+		if err := k8sClient.List(context.Background(), tasks, client.InNamespace("kubetask")); err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(tasks.Items) != 1 {
+			t.Fatalf("got %d Tasks, want 1", len(tasks.Items))
+		}
+		task := tasks.Items[0]
+		if got, want := *task.Spec.Description, "Update dependencies\n\nonly bump patch versions"; got != want {
+			t.Errorf("description = %q, want %q", got, want)
+		}
+		if task.Spec.AgentRef != "gemini" || task.Labels["team"] != "platform" || task.Labels[TemplateLabelKey] != "deps" {
+			t.Errorf("Task = %+v, want template agentRef and labels", task.ObjectMeta)
+		}
+		if task.Annotations[AnnotationChannel] != "C123" || task.Annotations[AnnotationUser] != "U456" {
+			t.Errorf("annotations = %v, want channel C123 and user U456", task.Annotations)
+		}
+		if task.Annotations[AnnotationThreadTS] != "1700000000.000001" {
+			t.Errorf("thread ts = %q, want the announcement ts", task.Annotations[AnnotationThreadTS])
+		}
+		if len(slack.messages) != 1 || slack.messages[0]["channel"] != "C123" {
+			t.Errorf("posted messages = %v, want one announcement in C123", slack.messages)
+		}
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedRequest(secret, now, url.Values{"text": {"run missing"}}))
+
+		var resp commandResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if !strings.Contains(resp.Text, `Template "missing" not found`) {
+			t.Errorf("response = %q, want template not found", resp.Text)
+		}
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedRequest(secret, now, url.Values{"text": {"help"}}))
+
+		var resp commandResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if resp.Text != usage {
+			t.Errorf("response = %q, want usage", resp.Text)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright Contributors to the KubeTask project
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// Notifier posts the phase changes and results of Tasks created by the slash
+// command to their Slack thread.
+type Notifier struct {
+	client.Client
+
+	// Slack posts the thread replies
+	Slack *Client
+}
+
+// Reconcile posts a thread reply when a Task reaches a phase that was not posted yet
+func (n *Notifier) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	task := &kubetaskv1alpha1.Task{}
+	if err := n.Get(ctx, req.NamespacedName, task); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	channel := task.Annotations[AnnotationChannel]
+	threadTS := task.Annotations[AnnotationThreadTS]
+	phase := task.Status.Phase
+	if channel == "" || threadTS == "" || phase == "" || task.Annotations[AnnotationNotifiedPhase] == string(phase) {
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := n.Slack.PostMessage(ctx, channel, threadTS, progressMessage(task)); err != nil {
+		log.Error(err, "unable to post Task progress to Slack", "phase", phase)
+		return ctrl.Result{}, err
+	}
+
+	patch := client.MergeFrom(task.DeepCopy())
+	task.Annotations[AnnotationNotifiedPhase] = string(phase)
+	if err := n.Patch(ctx, task, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("posted Task progress to Slack", "phase", phase)
+	return ctrl.Result{}, nil
+}
+
+// progressMessage formats the thread reply for the Task's current phase
+func progressMessage(task *kubetaskv1alpha1.Task) string {
+	switch task.Status.Phase {
+	case kubetaskv1alpha1.TaskPhaseRunning:
+		return fmt.Sprintf(":hourglass_flowing_sand: Task is running (Job `%s`)", task.Status.JobName)
+
+	case kubetaskv1alpha1.TaskPhaseCompleted:
+		var b strings.Builder
+		b.WriteString(":white_check_mark: Task completed")
+		if d := duration(task); d != "" {
+			b.WriteString(" in " + d)
+		}
+		writeChanges(&b, task.Status.Changes)
+		return b.String()
+
+	case kubetaskv1alpha1.TaskPhaseFailed:
+		var b strings.Builder
+		b.WriteString(":x: Task failed")
+		if cond := meta.FindStatusCondition(task.Status.Conditions, "Ready"); cond != nil && cond.Message != "" {
+			b.WriteString(": " + cond.Message)
+		}
+		writeChanges(&b, task.Status.Changes)
+		for _, a := range task.Status.FailureArtifacts {
+			fmt.Fprintf(&b, "\nWorkspace snapshot: `%s` in PVC `%s`", a.Path, a.ClaimName)
+		}
+		return b.String()
+	}
+
+	return fmt.Sprintf("Task is %s", task.Status.Phase)
+}
+
+// writeChanges appends the changes reported by the agent to a message
+func writeChanges(b *strings.Builder, changes *kubetaskv1alpha1.TaskChanges) {
+	if changes == nil {
+		return
+	}
+	for _, pr := range changes.PullRequests {
+		fmt.Fprintf(b, "\nPull request: %s", pr)
+	}
+	if len(changes.Commits) > 0 {
+		fmt.Fprintf(b, "\nCommits: `%s`", strings.Join(changes.Commits, "`, `"))
+	}
+	if n := len(changes.ChangedFiles); n > 0 {
+		fmt.Fprintf(b, "\nChanged files: %d", n)
+	}
+}
+
+// duration returns how long a finished Task ran, or "" if unknown
+func duration(task *kubetaskv1alpha1.Task) string {
+	if task.Status.StartTime == nil || task.Status.CompletionTime == nil {
+		return ""
+	}
+	return task.Status.CompletionTime.Sub(task.Status.StartTime.Time).Round(time.Second).String()
+}
+
+// SetupWithManager sets up the notifier with the Manager
+func (n *Notifier) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("slack-notifier").
+		For(&kubetaskv1alpha1.Task{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetAnnotations()[AnnotationThreadTS] != ""
+		}))).
+		Complete(n)
+}
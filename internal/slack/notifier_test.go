@@ -0,0 +1,113 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package slack
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestProgressMessage(t *testing.T) {
+	start := metav1.NewTime(time.Unix(1700000000, 0))
+	end := metav1.NewTime(start.Add(90 * time.Second))
+
+	tests := []struct {
+		name   string
+		status kubetaskv1alpha1.TaskExecutionStatus
+		want   []string
+	}{
+		{
+			name:   "running",
+			status: kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning, JobName: "deps-abc-job"},
+			want:   []string{"running", "deps-abc-job"},
+		},
+		{
+			name: "completed with changes",
+			status: kubetaskv1alpha1.TaskExecutionStatus{
+				Phase:          kubetaskv1alpha1.TaskPhaseCompleted,
+				StartTime:      &start,
+				CompletionTime: &end,
+				Changes: &kubetaskv1alpha1.TaskChanges{
+					PullRequests: []string{"https://github.com/org/repo/pull/42"},
+					Commits:      []string{"3f2a9c1d"},
+					ChangedFiles: []string{"go.mod", "go.sum"},
+				},
+			},
+			want: []string{"completed in 1m30s", "https://github.com/org/repo/pull/42", "3f2a9c1d", "Changed files: 2"},
+		},
+		{
+			name: "failed",
+			status: kubetaskv1alpha1.TaskExecutionStatus{
+				Phase: kubetaskv1alpha1.TaskPhaseFailed,
+				Conditions: []metav1.Condition{{
+					Type: "Ready", Status: metav1.ConditionFalse, Reason: "JobFailed", Message: "Job deps-abc-job failed",
+				}},
+				FailureArtifacts: []kubetaskv1alpha1.FailureArtifact{{
+					Type: kubetaskv1alpha1.FailureArtifactTypeTarball, ClaimName: "post-mortems", Path: "deps-abc/uid.tar.gz",
+				}},
+			},
+			want: []string{"failed: Job deps-abc-job failed", "deps-abc/uid.tar.gz", "post-mortems"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := progressMessage(&kubetaskv1alpha1.Task{Status: tt.status})
+			for _, want := range tt.want {
+				if !strings.Contains(msg, want) {
+					t.Errorf("progressMessage() = %q, want it to contain %q", msg, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNotifier_PostsEachPhaseOnce(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deps-abc",
+			Namespace: "kubetask",
+			Annotations: map[string]string{
+				AnnotationChannel:  "C123",
+				AnnotationThreadTS: "1700000000.000001",
+			},
+		},
+		Status: kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning, JobName: "deps-abc-job"},
+	}
+
+	slack := &fakeSlack{}
+	k8sClient := newTestClient(t, task)
+	n := &Notifier{Client: k8sClient, Slack: slack.serve(t)}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "deps-abc", Namespace: "kubetask"}}
+
+	for i := 0; i < 2; i++ {
+		if _, err := n.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	if len(slack.messages) != 1 {
+		t.Fatalf("posted %d messages, want 1", len(slack.messages))
+	}
+	if slack.messages[0]["thread_ts"] != "1700000000.000001" {
+		t.Errorf("thread_ts = %q, want the Task's thread", slack.messages[0]["thread_ts"])
+	}
+
+	updated := &kubetaskv1alpha1.Task{}
+	if err := k8sClient.Get(context.Background(), req.NamespacedName, updated); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if updated.Annotations[AnnotationNotifiedPhase] != string(kubetaskv1alpha1.TaskPhaseRunning) {
+		t.Errorf("notified phase = %q, want Running", updated.Annotations[AnnotationNotifiedPhase])
+	}
+}
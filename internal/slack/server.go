@@ -0,0 +1,63 @@
+// Copyright Contributors to the KubeTask project
+
+package slack
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// CommandPath is the URL path the slash command is served on
+const CommandPath = "/slack/command"
+
+// Server serves the slash command handler as a Manager runnable
+type Server struct {
+	// Addr is the address the server binds to
+	Addr string
+
+	// Handler serves the slash command
+	Handler http.Handler
+}
+
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NeedLeaderElection returns false so every replica behind the Service can accept commands
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("slack")
+
+	mux := http.NewServeMux()
+	mux.Handle(CommandPath, s.Handler)
+	srv := &http.Server{
+		Addr:              s.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("serving Slack slash command", "addr", s.Addr, "path", CommandPath)
+		if err := srv.ListenAndServe(); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
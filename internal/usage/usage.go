@@ -0,0 +1,282 @@
+// Copyright Contributors to the KubeTask project
+
+// Package usage finds the resources that reference a Context, Agent, or credential
+// Secret, so owners know the blast radius of a change before editing a shared
+// resource. It is used by the kubectl-kubetask plugin and the controller's usage metrics.
+package usage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// Kinds of resources whose usage can be looked up
+const (
+	KindContext = "context"
+	KindAgent   = "agent"
+	KindSecret  = "secret"
+)
+
+// defaultAgentName is the Agent used by Tasks that do not set agentRef
+const defaultAgentName = "default"
+
+// Target is the resource whose users are looked up
+type Target struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// ParseTarget parses a "<kind>/<name>" argument such as context/coding-standards
+func ParseTarget(arg, namespace string) (Target, error) {
+	kind, name, ok := strings.Cut(arg, "/")
+	if !ok || name == "" {
+		return Target{}, fmt.Errorf("invalid resource %q, must be <kind>/<name>", arg)
+	}
+	kind = strings.ToLower(kind)
+	switch kind {
+	case KindContext, "contexts":
+		kind = KindContext
+	case KindAgent, "agents":
+		kind = KindAgent
+	case KindSecret, "secrets":
+		kind = KindSecret
+	default:
+		return Target{}, fmt.Errorf("unsupported kind %q, must be %s, %s, or %s", kind, KindContext, KindAgent, KindSecret)
+	}
+	return Target{Kind: kind, Namespace: namespace, Name: name}, nil
+}
+
+// Reference is a resource that uses the target
+type Reference struct {
+	// Kind of the referencing resource, such as Task or Agent
+	Kind      string
+	Namespace string
+	Name      string
+
+	// Via names the resource the target is used through, such as "agent/claude"
+	// for a Task whose Agent mounts the target Context. Empty for direct references.
+	Via string
+}
+
+// Key returns the "namespace/name" key of a resource
+func Key(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// ContextKeys returns the keys of the Contexts referenced by mounts of a resource in namespace
+func ContextKeys(namespace string, mounts []kubetaskv1alpha1.ContextMount) []string {
+	keys := make([]string, 0, len(mounts))
+	for _, mount := range mounts {
+		ns := mount.Namespace
+		if ns == "" {
+			ns = namespace
+		}
+		keys = append(keys, Key(ns, mount.Name))
+	}
+	return keys
+}
+
+// TaskAgents returns the names of the Agents a Task runs with: the compared
+// Agents of a comparison, otherwise agentRef or the default Agent
+func TaskAgents(spec *kubetaskv1alpha1.TaskSpec) []string {
+	if spec.Replicas != nil && len(spec.Replicas.Compare) > 0 {
+		return spec.Replicas.Compare
+	}
+	if spec.AgentRef != "" {
+		return []string{spec.AgentRef}
+	}
+	return []string{defaultAgentName}
+}
+
+// AgentSecrets returns the names of the Secrets an Agent exposes to agents,
+// through credentials and envFrom
+func AgentSecrets(agent *kubetaskv1alpha1.Agent) []string {
+	var secrets []string
+	for _, credential := range agent.Spec.Credentials {
+		secrets = append(secrets, credential.SecretRef.Name)
+	}
+	for _, source := range agent.Spec.EnvFrom {
+		if source.SecretRef != nil {
+			secrets = append(secrets, source.SecretRef.Name)
+		}
+	}
+	return secrets
+}
+
+// ContextSecrets returns the names of the Secrets a Context reads
+func ContextSecrets(c *kubetaskv1alpha1.Context) []string {
+	if c.Spec.Git != nil && c.Spec.Git.SecretRef != nil {
+		return []string{c.Spec.Git.SecretRef.Name}
+	}
+	if c.Spec.URL != nil && c.Spec.URL.HeadersSecretRef != nil {
+		return []string{c.Spec.URL.HeadersSecretRef.Name}
+	}
+	if c.Spec.ObjectStore != nil && c.Spec.ObjectStore.CredentialsSecretRef != nil {
+		return []string{c.Spec.ObjectStore.CredentialsSecretRef.Name}
+	}
+	if c.Spec.OCI != nil && c.Spec.OCI.PullSecretRef != nil {
+		return []string{c.Spec.OCI.PullSecretRef.Name}
+	}
+	return nil
+}
+
+// Find returns the resources that use target. Only resources in the target's
+// namespace are searched unless allNamespaces is set, which also finds Contexts
+// referenced from other namespaces.
+func Find(ctx context.Context, c client.Reader, target Target, allNamespaces bool) ([]Reference, error) {
+	return find(ctx, c, target, allNamespaces, false)
+}
+
+// ActiveTasks returns the Tasks that use target and have not finished yet.
+// Contexts are looked up in all namespaces, since they can be referenced across
+// namespaces; Agents and Secrets only in their own.
+func ActiveTasks(ctx context.Context, c client.Reader, target Target) ([]Reference, error) {
+	references, err := find(ctx, c, target, target.Kind == KindContext, true)
+	if err != nil {
+		return nil, err
+	}
+	tasks := references[:0]
+	for _, ref := range references {
+		if ref.Kind == "Task" {
+			tasks = append(tasks, ref)
+		}
+	}
+	return tasks, nil
+}
+
+func find(ctx context.Context, c client.Reader, target Target, allNamespaces, activeOnly bool) ([]Reference, error) {
+	var opts []client.ListOption
+	if !allNamespaces {
+		opts = append(opts, client.InNamespace(target.Namespace))
+	}
+
+	agents := &kubetaskv1alpha1.AgentList{}
+	tasks := &kubetaskv1alpha1.TaskList{}
+	cronTasks := &kubetaskv1alpha1.CronTaskList{}
+	evals := &kubetaskv1alpha1.AgentEvalList{}
+	contexts := &kubetaskv1alpha1.ContextList{}
+	for _, list := range []client.ObjectList{agents, tasks, cronTasks, evals, contexts} {
+		if err := c.List(ctx, list, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	f := &finder{
+		target:        target,
+		usingAgents:   map[string]string{},
+		usingContexts: map[string]string{},
+	}
+
+	// Agents and Contexts are the resources Tasks use the target through
+	for i := range contexts.Items {
+		item := &contexts.Items[i]
+		if target.Kind == KindSecret && item.Namespace == target.Namespace && slices.Contains(ContextSecrets(item), target.Name) {
+			f.usingContexts[Key(item.Namespace, item.Name)] = ""
+			f.add("Context", item.Namespace, item.Name, "")
+		}
+	}
+	for i := range agents.Items {
+		item := &agents.Items[i]
+		if via, ok := f.uses(item.Namespace, nil, item.Spec.Contexts, AgentSecrets(item)); ok {
+			f.usingAgents[Key(item.Namespace, item.Name)] = via
+			f.add("Agent", item.Namespace, item.Name, via)
+		}
+	}
+
+	for i := range tasks.Items {
+		item := &tasks.Items[i]
+		if activeOnly && (item.Status.Phase == kubetaskv1alpha1.TaskPhaseCompleted || item.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed) {
+			continue
+		}
+		f.addTask("Task", item.Namespace, item.Name, &item.Spec)
+	}
+	for i := range cronTasks.Items {
+		item := &cronTasks.Items[i]
+		f.addTask("CronTask", item.Namespace, item.Name, &item.Spec.TaskTemplate.Spec)
+	}
+	for i := range evals.Items {
+		item := &evals.Items[i]
+		for _, evalCase := range item.Spec.Cases {
+			spec := evalCase.Task.DeepCopy()
+			spec.Replicas = &kubetaskv1alpha1.TaskReplicas{Compare: item.Spec.AgentRefs}
+			if f.addTask("AgentEval", item.Namespace, item.Name, spec) {
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(f.references, func(i, j int) bool {
+		a, b := f.references[i], f.references[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return f.references, nil
+}
+
+// finder collects the references to a target
+type finder struct {
+	target     Target
+	references []Reference
+
+	// usingAgents and usingContexts map the keys of Agents and Contexts that use
+	// the target to how they use it
+	usingAgents   map[string]string
+	usingContexts map[string]string
+}
+
+func (f *finder) add(kind, namespace, name, via string) {
+	f.references = append(f.references, Reference{Kind: kind, Namespace: namespace, Name: name, Via: via})
+}
+
+// uses reports whether a resource uses the target directly or through one of its
+// Agents or Contexts, and through which
+func (f *finder) uses(namespace string, agentNames []string, mounts []kubetaskv1alpha1.ContextMount, secrets []string) (string, bool) {
+	switch f.target.Kind {
+	case KindAgent:
+		if namespace == f.target.Namespace && slices.Contains(agentNames, f.target.Name) {
+			return "", true
+		}
+	case KindContext:
+		if slices.Contains(ContextKeys(namespace, mounts), Key(f.target.Namespace, f.target.Name)) {
+			return "", true
+		}
+	case KindSecret:
+		if namespace == f.target.Namespace && slices.Contains(secrets, f.target.Name) {
+			return "", true
+		}
+	}
+
+	for _, key := range ContextKeys(namespace, mounts) {
+		if _, ok := f.usingContexts[key]; ok {
+			return KindContext + "/" + strings.TrimPrefix(key, namespace+"/"), true
+		}
+	}
+	for _, name := range agentNames {
+		if _, ok := f.usingAgents[Key(namespace, name)]; ok {
+			return KindAgent + "/" + name, true
+		}
+	}
+	return "", false
+}
+
+// addTask adds a resource running Tasks with spec if it uses the target
+func (f *finder) addTask(kind, namespace, name string, spec *kubetaskv1alpha1.TaskSpec) bool {
+	via, ok := f.uses(namespace, TaskAgents(spec), spec.Contexts, nil)
+	if ok {
+		f.add(kind, namespace, name, via)
+	}
+	return ok
+}
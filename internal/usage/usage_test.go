@@ -0,0 +1,187 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package usage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    Target
+		wantErr bool
+	}{
+		{arg: "context/standards", want: Target{Kind: KindContext, Namespace: "team", Name: "standards"}},
+		{arg: "Agents/claude", want: Target{Kind: KindAgent, Namespace: "team", Name: "claude"}},
+		{arg: "secret/api-key", want: Target{Kind: KindSecret, Namespace: "team", Name: "api-key"}},
+		{arg: "standards", wantErr: true},
+		{arg: "context/", wantErr: true},
+		{arg: "task/fix", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := ParseTarget(tt.arg, "team")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskAgents(t *testing.T) {
+	tests := []struct {
+		name string
+		spec kubetaskv1alpha1.TaskSpec
+		want []string
+	}{
+		{name: "default Agent", want: []string{"default"}},
+		{name: "agentRef", spec: kubetaskv1alpha1.TaskSpec{AgentRef: "claude"}, want: []string{"claude"}},
+		{
+			name: "comparison",
+			spec: kubetaskv1alpha1.TaskSpec{AgentRef: "claude", Replicas: &kubetaskv1alpha1.TaskReplicas{Compare: []string{"a", "b"}}},
+			want: []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TaskAgents(&tt.spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TaskAgents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	standards := kubetaskv1alpha1.ContextMount{Name: "standards"}
+	objects := []client.Object{
+		&kubetaskv1alpha1.Context{
+			ObjectMeta: metav1.ObjectMeta{Name: "standards", Namespace: "team"},
+			Spec: kubetaskv1alpha1.ContextSpec{Git: &kubetaskv1alpha1.GitContext{
+				Repository: "https://example.com/standards.git",
+				SecretRef:  &kubetaskv1alpha1.GitSecretReference{Name: "git-token"},
+			}},
+		},
+		&kubetaskv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team"},
+			Spec: kubetaskv1alpha1.AgentSpec{
+				Contexts:    []kubetaskv1alpha1.ContextMount{standards},
+				Credentials: []kubetaskv1alpha1.Credential{{Name: "api", SecretRef: kubetaskv1alpha1.SecretReference{Name: "api-key"}}},
+			},
+		},
+		&kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "team"}},
+		&kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "direct", Namespace: "team"},
+			Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: "other", Contexts: []kubetaskv1alpha1.ContextMount{standards}},
+		},
+		&kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "via-agent", Namespace: "team"},
+			Spec:       kubetaskv1alpha1.TaskSpec{AgentRef: "claude"},
+		},
+		&kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "team"}},
+		&kubetaskv1alpha1.CronTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "team"},
+			Spec: kubetaskv1alpha1.CronTaskSpec{TaskTemplate: kubetaskv1alpha1.TaskTemplateSpec{
+				Spec: kubetaskv1alpha1.TaskSpec{AgentRef: "claude"},
+			}},
+		},
+		&kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "cross", Namespace: "other"},
+			Spec: kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{
+				{Name: "standards", Namespace: "team"},
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	tests := []struct {
+		name          string
+		target        Target
+		allNamespaces bool
+		want          []Reference
+	}{
+		{
+			name:   "context",
+			target: Target{Kind: KindContext, Namespace: "team", Name: "standards"},
+			want: []Reference{
+				{Kind: "Agent", Namespace: "team", Name: "claude"},
+				{Kind: "CronTask", Namespace: "team", Name: "nightly", Via: "agent/claude"},
+				{Kind: "Task", Namespace: "team", Name: "direct"},
+				{Kind: "Task", Namespace: "team", Name: "via-agent", Via: "agent/claude"},
+			},
+		},
+		{
+			name:          "context in all namespaces",
+			target:        Target{Kind: KindContext, Namespace: "team", Name: "standards"},
+			allNamespaces: true,
+			want: []Reference{
+				{Kind: "Agent", Namespace: "team", Name: "claude"},
+				{Kind: "CronTask", Namespace: "team", Name: "nightly", Via: "agent/claude"},
+				{Kind: "Task", Namespace: "other", Name: "cross"},
+				{Kind: "Task", Namespace: "team", Name: "direct"},
+				{Kind: "Task", Namespace: "team", Name: "via-agent", Via: "agent/claude"},
+			},
+		},
+		{
+			name:   "default Agent",
+			target: Target{Kind: KindAgent, Namespace: "team", Name: "default"},
+			want: []Reference{
+				{Kind: "Task", Namespace: "team", Name: "unrelated"},
+			},
+		},
+		{
+			name:   "credential Secret",
+			target: Target{Kind: KindSecret, Namespace: "team", Name: "api-key"},
+			want: []Reference{
+				{Kind: "Agent", Namespace: "team", Name: "claude"},
+				{Kind: "CronTask", Namespace: "team", Name: "nightly", Via: "agent/claude"},
+				{Kind: "Task", Namespace: "team", Name: "via-agent", Via: "agent/claude"},
+			},
+		},
+		{
+			name:   "git Secret",
+			target: Target{Kind: KindSecret, Namespace: "team", Name: "git-token"},
+			want: []Reference{
+				{Kind: "Agent", Namespace: "team", Name: "claude", Via: "context/standards"},
+				{Kind: "Context", Namespace: "team", Name: "standards"},
+				{Kind: "CronTask", Namespace: "team", Name: "nightly", Via: "agent/claude"},
+				{Kind: "Task", Namespace: "team", Name: "direct", Via: "context/standards"},
+				{Kind: "Task", Namespace: "team", Name: "via-agent", Via: "agent/claude"},
+			},
+		},
+		{
+			name:   "unused",
+			target: Target{Kind: KindContext, Namespace: "team", Name: "missing"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Find(context.Background(), c, tt.target, tt.allNamespaces)
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
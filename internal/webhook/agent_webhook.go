@@ -0,0 +1,51 @@
+// Copyright Contributors to the KubeTask project
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// AgentCustomValidator rejects Agents whose jobTemplateOverrides set fields the
+// controller does not let them change, or whose reloadOnChange credentials cannot
+// be mounted, and protects Agents used by unfinished Tasks from deletion. The controller checks the overrides again when it builds
+// a Job, since the webhook ignores failures by default.
+type AgentCustomValidator struct {
+	*DeletionProtectionValidator
+}
+
+var _ admission.CustomValidator = &AgentCustomValidator{}
+
+// ValidateCreate checks the jobTemplateOverrides and credentials of a new Agent
+func (v *AgentCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateAgent(obj)
+}
+
+// ValidateUpdate checks the jobTemplateOverrides and credentials of a changed Agent
+func (v *AgentCustomValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, validateAgent(newObj)
+}
+
+// validateAgent returns an error if obj is not an Agent, a reloadOnChange credential
+// would hide the workspace or share a mountPath, or its jobTemplateOverrides set a
+// forbidden field
+func validateAgent(obj runtime.Object) error {
+	agent, ok := obj.(*kubetaskv1alpha1.Agent)
+	if !ok {
+		return fmt.Errorf("expected an Agent but got %T", obj)
+	}
+	if err := build.ValidateReloadCredentials(agent.Spec.Credentials, agent.Spec.WorkspaceDir); err != nil {
+		return err
+	}
+	if agent.Spec.JobTemplateOverrides == nil {
+		return nil
+	}
+	return build.ValidateJobTemplateOverrides(agent.Spec.JobTemplateOverrides.Raw, agent.Spec.ContainerName)
+}
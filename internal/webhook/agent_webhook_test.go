@@ -0,0 +1,53 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestAgentCustomValidator(t *testing.T) {
+	v := &AgentCustomValidator{DeletionProtectionValidator: &DeletionProtectionValidator{Mode: DeletionProtectionDeny}}
+	newAgent := func(overrides string) *kubetaskv1alpha1.Agent {
+		agent := &kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "platform"}}
+		if overrides != "" {
+			agent.Spec.JobTemplateOverrides = &runtime.RawExtension{Raw: []byte(overrides)}
+		}
+		return agent
+	}
+
+	if _, err := v.ValidateCreate(context.Background(), newAgent("")); err != nil {
+		t.Errorf("ValidateCreate() error = %v for an Agent without overrides", err)
+	}
+	sidecar := `{"spec": {"template": {"spec": {"containers": [{"name": "proxy", "image": "proxy:v1"}]}}}}`
+	if _, err := v.ValidateCreate(context.Background(), newAgent(sidecar)); err != nil {
+		t.Errorf("ValidateCreate() error = %v for an extra container", err)
+	}
+	privileged := `{"spec": {"template": {"spec": {"hostNetwork": true}}}}`
+	_, err := v.ValidateUpdate(context.Background(), newAgent(""), newAgent(privileged))
+	if err == nil || !strings.Contains(err.Error(), "spec.template.spec.hostNetwork") {
+		t.Errorf("ValidateUpdate() error = %v, want hostNetwork rejected", err)
+	}
+
+	key, tokenPath := "token", "/workspace/token"
+	shadowing := newAgent("")
+	shadowing.Spec.Credentials = []kubetaskv1alpha1.Credential{{
+		Name:           "github-token",
+		SecretRef:      kubetaskv1alpha1.SecretReference{Name: "github-app", Key: &key},
+		MountPath:      &tokenPath,
+		ReloadOnChange: true,
+	}}
+	_, err = v.ValidateCreate(context.Background(), shadowing)
+	if err == nil || !strings.Contains(err.Error(), "would hide the workspace /workspace") {
+		t.Errorf("ValidateCreate() error = %v, want reloadOnChange credential in the workspace rejected", err)
+	}
+}
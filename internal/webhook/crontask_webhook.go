@@ -0,0 +1,148 @@
+// Copyright Contributors to the KubeTask project
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+// +kubebuilder:webhook:path=/validate-kubetask-io-v1alpha1-crontask,mutating=false,failurePolicy=ignore,sideEffects=None,groups=kubetask.io,resources=crontasks,verbs=create;update,versions=v1alpha1,name=vcrontask.kubetask.io,admissionReviewVersions=v1
+
+// SetupCronTaskWebhookWithManager registers the CronTask validating webhook with the Manager
+func SetupCronTaskWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kubetaskv1alpha1.CronTask{}).
+		WithValidator(&CronTaskCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// CronTaskCustomValidator rejects CronTasks the controller could never schedule,
+// and warns about Agents and Contexts their Tasks reference that do not exist, so
+// mistakes surface when the CronTask is applied rather than at its first run
+type CronTaskCustomValidator struct {
+	// Client reads Agents and Contexts. If nil, references are not checked.
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &CronTaskCustomValidator{}
+
+// ValidateCreate checks the schedule, history limits and references of a new CronTask
+func (v *CronTaskCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cronTask, ok := obj.(*kubetaskv1alpha1.CronTask)
+	if !ok {
+		return nil, fmt.Errorf("expected a CronTask but got %T", obj)
+	}
+	return v.validate(ctx, cronTask)
+}
+
+// ValidateUpdate checks the schedule, history limits and references of a changed CronTask
+func (v *CronTaskCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	cronTask, ok := newObj.(*kubetaskv1alpha1.CronTask)
+	if !ok {
+		return nil, fmt.Errorf("expected a CronTask but got %T", newObj)
+	}
+	return v.validate(ctx, cronTask)
+}
+
+// ValidateDelete admits every deletion
+func (v *CronTaskCustomValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an error if the schedule does not parse, a history limit is
+// negative or the taskTemplate claims an approval, and warnings for missing Agents
+// and Contexts. References are only
+// warned about, since they may be created after the CronTask.
+func (v *CronTaskCustomValidator) validate(ctx context.Context, cronTask *kubetaskv1alpha1.CronTask) (admission.Warnings, error) {
+	spec := cronTask.Spec
+	// Parsed like the CronTask controller does
+	if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec.Schedule, err)
+	}
+
+	var problems []string
+	if spec.SuccessfulTasksHistoryLimit != nil && *spec.SuccessfulTasksHistoryLimit < 0 {
+		problems = append(problems, fmt.Sprintf("successfulTasksHistoryLimit must not be negative, got %d", *spec.SuccessfulTasksHistoryLimit))
+	}
+	if spec.FailedTasksHistoryLimit != nil && *spec.FailedTasksHistoryLimit < 0 {
+		problems = append(problems, fmt.Sprintf("failedTasksHistoryLimit must not be negative, got %d", *spec.FailedTasksHistoryLimit))
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid history limits: %s", strings.Join(problems, "; "))
+	}
+	// The Task webhook rejects Tasks created with an approval
+	if _, ok := cronTask.Spec.TaskTemplate.Annotations[audit.AnnotationApprovedBy]; ok {
+		return nil, fmt.Errorf("taskTemplate must not set annotation %s; approve each created Task instead", audit.AnnotationApprovedBy)
+	}
+
+	return v.missingReferences(ctx, cronTask)
+}
+
+// missingReferences warns about each Agent and non-optional Context referenced by
+// the CronTask's taskTemplate that does not exist
+func (v *CronTaskCustomValidator) missingReferences(ctx context.Context, cronTask *kubetaskv1alpha1.CronTask) (admission.Warnings, error) {
+	if v.Client == nil {
+		return nil, nil
+	}
+	spec := cronTask.Spec.TaskTemplate.Spec
+
+	agentNames := []string{"default"}
+	if spec.AgentRef != "" {
+		agentNames = []string{spec.AgentRef}
+	}
+	if spec.Replicas != nil && len(spec.Replicas.Compare) > 0 {
+		agentNames = spec.Replicas.Compare
+	}
+
+	var warnings admission.Warnings
+	for _, name := range agentNames {
+		found, err := v.exists(ctx, &kubetaskv1alpha1.Agent{}, cronTask.Namespace, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get Agent %q: %w", name, err)
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("Agent %q not found; scheduled Tasks fail until it is created", name))
+		}
+	}
+	for _, mount := range spec.Contexts {
+		if mount.Optional {
+			continue
+		}
+		namespace := mount.Namespace
+		if namespace == "" {
+			namespace = cronTask.Namespace
+		}
+		found, err := v.exists(ctx, &kubetaskv1alpha1.Context{}, namespace, mount.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get Context %s/%s: %w", namespace, mount.Name, err)
+		}
+		if !found {
+			warnings = append(warnings, fmt.Sprintf("Context %s/%s not found; scheduled Tasks fail until it is created", namespace, mount.Name))
+		}
+	}
+	return warnings, nil
+}
+
+// exists reports whether the named object exists
+func (v *CronTaskCustomValidator) exists(ctx context.Context, obj client.Object, namespace, name string) (bool, error) {
+	if err := v.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
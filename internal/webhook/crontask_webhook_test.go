@@ -0,0 +1,125 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+func TestCronTaskCustomValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "team"}},
+		&kubetaskv1alpha1.Context{ObjectMeta: metav1.ObjectMeta{Name: "standards", Namespace: "platform"}},
+	).Build()
+	v := &CronTaskCustomValidator{Client: c}
+
+	int32Ptr := func(v int32) *int32 { return &v }
+	newCronTask := func(schedule string, mutate func(*kubetaskv1alpha1.CronTaskSpec)) *kubetaskv1alpha1.CronTask {
+		cronTask := &kubetaskv1alpha1.CronTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "team"},
+			Spec: kubetaskv1alpha1.CronTaskSpec{
+				Schedule: schedule,
+				TaskTemplate: kubetaskv1alpha1.TaskTemplateSpec{Spec: kubetaskv1alpha1.TaskSpec{
+					AgentRef: "claude",
+					Contexts: []kubetaskv1alpha1.ContextMount{{Name: "standards", Namespace: "platform"}},
+				}},
+			},
+		}
+		if mutate != nil {
+			mutate(&cronTask.Spec)
+		}
+		return cronTask
+	}
+
+	tests := []struct {
+		name         string
+		cronTask     *kubetaskv1alpha1.CronTask
+		wantErr      string
+		wantWarnings []string
+	}{
+		{name: "valid", cronTask: newCronTask("0 2 * * *", nil)},
+		{name: "descriptor", cronTask: newCronTask("@daily", nil)},
+		{name: "too few fields", cronTask: newCronTask("0 2 * *", nil), wantErr: `invalid schedule "0 2 * *"`},
+		{name: "out of range", cronTask: newCronTask("0 25 * * *", nil), wantErr: "invalid schedule"},
+		{
+			name: "negative history limit",
+			cronTask: newCronTask("0 2 * * *", func(spec *kubetaskv1alpha1.CronTaskSpec) {
+				spec.FailedTasksHistoryLimit = int32Ptr(-1)
+			}),
+			wantErr: "failedTasksHistoryLimit must not be negative",
+		},
+		{
+			name: "zero history limits",
+			cronTask: newCronTask("0 2 * * *", func(spec *kubetaskv1alpha1.CronTaskSpec) {
+				spec.SuccessfulTasksHistoryLimit = int32Ptr(0)
+				spec.FailedTasksHistoryLimit = int32Ptr(0)
+			}),
+		},
+		{
+			name: "approved taskTemplate",
+			cronTask: newCronTask("0 2 * * *", func(spec *kubetaskv1alpha1.CronTaskSpec) {
+				spec.TaskTemplate.Annotations = map[string]string{audit.AnnotationApprovedBy: "bob@example.com"}
+			}),
+			wantErr: "must not set annotation kubetask.io/approved-by",
+		},
+		{
+			name: "missing default Agent",
+			cronTask: newCronTask("0 2 * * *", func(spec *kubetaskv1alpha1.CronTaskSpec) {
+				spec.TaskTemplate.Spec.AgentRef = ""
+			}),
+			wantWarnings: []string{`Agent "default" not found`},
+		},
+		{
+			name: "missing Contexts",
+			cronTask: newCronTask("0 2 * * *", func(spec *kubetaskv1alpha1.CronTaskSpec) {
+				spec.TaskTemplate.Spec.Contexts = []kubetaskv1alpha1.ContextMount{
+					{Name: "standards"},
+					{Name: "rollout", Optional: true},
+				}
+			}),
+			wantWarnings: []string{"Context team/standards not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := v.ValidateCreate(context.Background(), tt.cronTask)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ValidateCreate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateCreate() error = %v", err)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("ValidateCreate() warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+			for i, want := range tt.wantWarnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %d = %q, want it to contain %q", i, warnings[i], want)
+				}
+			}
+		})
+	}
+
+	// Updates are checked the same way
+	if _, err := v.ValidateUpdate(context.Background(), newCronTask("0 2 * * *", nil), newCronTask("every night", nil)); err == nil {
+		t.Error("ValidateUpdate() with an invalid schedule error = nil")
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright Contributors to the KubeTask project
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/pkg/build"
+)
+
+// defaultWorkspaceDir is the workspace directory of Agents that do not set one
+const defaultWorkspaceDir = "/workspace"
+
+// ProtectedMountRoots are directories a Context is never mounted into, even when
+// they are below a permitted root. Mounting a file there could replace system
+// configuration or the credentials Kubernetes mounts into the Pod.
+var ProtectedMountRoots = []string{
+	"/bin", "/boot", "/dev", "/etc", "/lib", "/lib64", "/proc",
+	"/run", "/sbin", "/sys", "/usr", "/var/run",
+}
+
+// ProtectedWindowsMountRoots are the protected directories of Agents on Windows
+// nodes, where Kubernetes mounts service account tokens below C:\var\run as well
+var ProtectedWindowsMountRoots = []string{
+	`C:\Windows`, `C:\Program Files`, `C:\Program Files (x86)`, `C:\ProgramData`, `C:\var\run`,
+}
+
+// checkMountPaths returns an error listing the Task's Context mountPaths that are
+// not under the workspace directory of its Agents or one of allowedRoots, or that
+// are under a protected root. Agents that do not exist yet are reported as warnings,
+// and only the protected roots are enforced for them. When all Agents target Windows
+// nodes, mountPaths may also be Windows paths such as C:\workspace\guide.md.
+func (v *TaskCustomValidator) checkMountPaths(ctx context.Context, task *kubetaskv1alpha1.Task) (admission.Warnings, error) {
+	var mountPaths []string
+	for _, mount := range task.Spec.Contexts {
+		if mount.MountPath != "" {
+			mountPaths = append(mountPaths, mount.MountPath)
+		}
+	}
+	if len(mountPaths) == 0 {
+		return nil, nil
+	}
+
+	roots, windows, warnings, err := v.permittedMountRoots(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, mountPath := range mountPaths {
+		if problem := checkMountPath(mountPath, roots, windows); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return warnings, fmt.Errorf("invalid Context mountPath: %s", strings.Join(problems, "; "))
+	}
+	return warnings, nil
+}
+
+// permittedMountRoots returns the workspace directories of the Task's Agents and
+// the allowed roots, or nil if any Agent is missing and its workspace is unknown,
+// and whether all of the Agents target Windows nodes
+func (v *TaskCustomValidator) permittedMountRoots(ctx context.Context, task *kubetaskv1alpha1.Task) ([]string, bool, admission.Warnings, error) {
+	if v.Client == nil {
+		return nil, false, nil, nil
+	}
+
+	agentNames := []string{"default"}
+	if task.Spec.AgentRef != "" {
+		agentNames = []string{task.Spec.AgentRef}
+	}
+	if task.Spec.Replicas != nil && len(task.Spec.Replicas.Compare) > 0 {
+		agentNames = task.Spec.Replicas.Compare
+	}
+
+	var workspaces []string
+	var warnings admission.Warnings
+	windows := true
+	for _, name := range agentNames {
+		agent := &kubetaskv1alpha1.Agent{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: task.Namespace}, agent); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, false, nil, fmt.Errorf("unable to get Agent %q: %w", name, err)
+			}
+			warnings = append(warnings, fmt.Sprintf("Agent %q not found; Context mountPaths are checked against protected paths only", name))
+			windows = false
+			continue
+		}
+		agentWindows := build.TargetsWindows(agent.Spec.PodSpec)
+		workspaceDir := agent.Spec.WorkspaceDir
+		if workspaceDir == "" {
+			workspaceDir = defaultWorkspaceDir
+			if agentWindows {
+				workspaceDir = build.DefaultWindowsWorkspaceDir
+			}
+		}
+		workspaces = append(workspaces, workspaceDir)
+		windows = windows && agentWindows
+	}
+	if len(warnings) > 0 {
+		return nil, false, warnings, nil
+	}
+
+	// A mountPath must be usable with every compared Agent, so with differing
+	// workspace directories only the allowed roots are common to all of them
+	roots := append([]string{}, v.AllowedMountRoots...)
+	if allEqual(workspaces) {
+		roots = append(roots, workspaces[0])
+	}
+	return roots, windows, nil, nil
+}
+
+// checkMountPath describes why mountPath is not permitted, or returns "" if it is.
+// With nil roots, only the protected roots are checked. For Windows Agents, paths
+// are compared in the form returned by windowsMountPath.
+func checkMountPath(mountPath string, roots []string, windows bool) string {
+	p, normalize, protectedRoots := mountPath, path.Clean, ProtectedMountRoots
+	if windows {
+		p = windowsMountPath(mountPath)
+		normalize = func(root string) string { return path.Clean(windowsMountPath(root)) }
+		protectedRoots = ProtectedWindowsMountRoots
+	}
+	cleaned := path.Clean(p)
+	if !path.IsAbs(p) || cleaned != strings.TrimSuffix(p, "/") {
+		return fmt.Sprintf("%s must be an absolute path without . or .. elements", mountPath)
+	}
+	for _, protected := range protectedRoots {
+		if underRoot(cleaned, normalize(protected)) {
+			return fmt.Sprintf("%s is under the protected path %s", mountPath, protected)
+		}
+	}
+	if roots == nil {
+		return ""
+	}
+	for _, root := range roots {
+		if underRoot(cleaned, normalize(root)) && cleaned != normalize(root) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s is not under the workspace directory or an allowed root (%s)", mountPath, strings.Join(roots, ", "))
+}
+
+// windowsMountPath returns a Windows path as a lowercase slash-separated path whose
+// first element is the drive, e.g. /c:/workspace/guide.md for C:\Workspace\Guide.md.
+// Paths without a drive are on the system drive; relative paths stay relative.
+func windowsMountPath(p string) string {
+	p = strings.ToLower(strings.ReplaceAll(p, `\`, "/"))
+	switch {
+	case len(p) >= 3 && p[1] == ':' && p[2] == '/':
+		return "/" + p
+	case strings.HasPrefix(p, "/"):
+		return "/c:" + p
+	}
+	return p
+}
+
+// underRoot reports whether p is root or below it
+func underRoot(p, root string) bool {
+	return p == root || root == "/" || strings.HasPrefix(p, root+"/")
+}
+
+// allEqual reports whether all values are the same
+func allEqual(values []string) bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
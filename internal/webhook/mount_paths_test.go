@@ -0,0 +1,112 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestTaskCustomValidator_MountPaths(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	agents := []*kubetaskv1alpha1.Agent{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "claude", Namespace: "default"}, Spec: kubetaskv1alpha1.AgentSpec{WorkspaceDir: "/src"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "windows", Namespace: "default"}, Spec: kubetaskv1alpha1.AgentSpec{
+			PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+				Scheduling: &kubetaskv1alpha1.PodScheduling{NodeSelector: map[string]string{"kubernetes.io/os": "windows"}},
+			},
+		}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(agents[0], agents[1], agents[2]).Build()
+	v := &TaskCustomValidator{Client: c, AllowedMountRoots: []string{"/home/agent/.config"}}
+
+	tests := []struct {
+		name         string
+		agentRef     string
+		compare      []string
+		mountPath    string
+		wantErr      string
+		wantWarnings bool
+	}{
+		{name: "default workspace", mountPath: "/workspace/guides/standards.md"},
+		{name: "Agent workspace", agentRef: "claude", mountPath: "/src/guides/standards.md"},
+		{name: "allowed root", mountPath: "/home/agent/.config/tool.json"},
+		{name: "outside the workspace", agentRef: "claude", mountPath: "/workspace/standards.md", wantErr: "not under the workspace directory"},
+		{name: "the workspace itself", mountPath: "/workspace", wantErr: "not under the workspace directory"},
+		{name: "relative", mountPath: "guides/standards.md", wantErr: "must be an absolute path"},
+		{name: "dot-dot", mountPath: "/workspace/../etc/passwd", wantErr: "must be an absolute path"},
+		{name: "service account token", mountPath: "/var/run/secrets/kubernetes.io/serviceaccount/token", wantErr: "protected path /var/run"},
+		{name: "system configuration", mountPath: "/etc/resolv.conf", wantErr: "protected path /etc"},
+		{name: "compared Agents with different workspaces", compare: []string{"default", "claude"}, mountPath: "/workspace/a.md", wantErr: "not under the workspace directory"},
+		{name: "missing Agent", agentRef: "gemini", mountPath: "/opt/standards.md", wantWarnings: true},
+		{name: "Windows workspace", agentRef: "windows", mountPath: `C:\workspace\guides\standards.md`},
+		{name: "Windows path with forward slashes", agentRef: "windows", mountPath: "c:/Workspace/standards.md"},
+		{name: "Windows path without drive", agentRef: "windows", mountPath: "/workspace/standards.md"},
+		{name: "Windows allowed root", agentRef: "windows", mountPath: `C:\home\agent\.config\tool.json`},
+		{name: "Windows outside the workspace", agentRef: "windows", mountPath: `D:\workspace\standards.md`, wantErr: "not under the workspace directory"},
+		{name: "Windows relative", agentRef: "windows", mountPath: `workspace\standards.md`, wantErr: "must be an absolute path"},
+		{name: "Windows dot-dot", agentRef: "windows", mountPath: `C:\workspace\..\Windows\win.ini`, wantErr: "must be an absolute path"},
+		{name: "Windows system directory", agentRef: "windows", mountPath: `C:\WINDOWS\System32\drivers\etc\hosts`, wantErr: `protected path C:\Windows`},
+		{name: "Windows path for a Linux Agent", mountPath: `C:\workspace\standards.md`, wantErr: "must be an absolute path"},
+		{name: "missing Agent and protected path", agentRef: "gemini", mountPath: "/etc/hosts", wantErr: "protected path /etc", wantWarnings: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"},
+				Spec: kubetaskv1alpha1.TaskSpec{
+					AgentRef: tt.agentRef,
+					Contexts: []kubetaskv1alpha1.ContextMount{{Name: "standards", MountPath: tt.mountPath}},
+				},
+			}
+			if tt.compare != nil {
+				task.Spec.Replicas = &kubetaskv1alpha1.TaskReplicas{Compare: tt.compare}
+			}
+
+			warnings, err := v.ValidateCreate(requestContext("alice@example.com"), task)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ValidateCreate() error = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateCreate() error = %v, want %q", err, tt.wantErr)
+			}
+			if (len(warnings) > 0) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want warnings %v", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestTaskCustomValidator_MountPathsOnUpdate(t *testing.T) {
+	v := &TaskCustomValidator{}
+	oldTask := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "fix", Namespace: "default"},
+		Spec:       kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "standards"}}},
+	}
+
+	relabeled := oldTask.DeepCopy()
+	relabeled.Labels = map[string]string{"team": "sre"}
+	if _, err := v.ValidateUpdate(context.Background(), oldTask, relabeled); err != nil {
+		t.Errorf("ValidateUpdate() without context changes error = %v, want nil", err)
+	}
+
+	remounted := oldTask.DeepCopy()
+	remounted.Spec.Contexts[0].MountPath = "/etc/standards.md"
+	if _, err := v.ValidateUpdate(context.Background(), oldTask, remounted); err == nil {
+		t.Error("ValidateUpdate() mounting into /etc error = nil, want error")
+	}
+}
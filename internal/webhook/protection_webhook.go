@@ -0,0 +1,115 @@
+// Copyright Contributors to the KubeTask project
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/usage"
+)
+
+// +kubebuilder:webhook:path=/validate-kubetask-io-v1alpha1-agent,mutating=false,failurePolicy=ignore,sideEffects=None,groups=kubetask.io,resources=agents,verbs=create;update;delete,versions=v1alpha1,name=vagent.kubetask.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-kubetask-io-v1alpha1-context,mutating=false,failurePolicy=ignore,sideEffects=None,groups=kubetask.io,resources=contexts,verbs=delete,versions=v1alpha1,name=vcontext.kubetask.io,admissionReviewVersions=v1
+
+// DeletionProtection is how deleting an Agent or Context used by unfinished Tasks is handled
+type DeletionProtection string
+
+const (
+	// DeletionProtectionDeny rejects the deletion
+	DeletionProtectionDeny DeletionProtection = "Deny"
+
+	// DeletionProtectionWarn admits the deletion with a warning
+	DeletionProtectionWarn DeletionProtection = "Warn"
+)
+
+// maxListedTasks bounds the number of Task names included in a rejection
+const maxListedTasks = 5
+
+// SetupDeletionProtectionWebhookWithManager registers the webhooks protecting
+// in-use Agents and Contexts from deletion. The Agent webhook also validates
+// jobTemplateOverrides, see AgentCustomValidator.
+func SetupDeletionProtectionWebhookWithManager(mgr ctrl.Manager, mode DeletionProtection) error {
+	if mode != DeletionProtectionDeny && mode != DeletionProtectionWarn {
+		return fmt.Errorf("invalid deletion protection %q, must be %s or %s", mode, DeletionProtectionDeny, DeletionProtectionWarn)
+	}
+	validator := &DeletionProtectionValidator{Client: mgr.GetClient(), Mode: mode}
+	agentValidator := &AgentCustomValidator{DeletionProtectionValidator: validator}
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&kubetaskv1alpha1.Agent{}).WithValidator(agentValidator).Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&kubetaskv1alpha1.Context{}).WithValidator(validator).Complete()
+}
+
+// DeletionProtectionValidator rejects, or warns on, the deletion of Agents and
+// Contexts referenced by Tasks that have not finished, which would otherwise
+// fail when the controller resolves them
+type DeletionProtectionValidator struct {
+	// Client reads Tasks and Agents
+	Client client.Reader
+
+	// Mode selects whether deletions are denied or only warned about
+	Mode DeletionProtection
+}
+
+var _ admission.CustomValidator = &DeletionProtectionValidator{}
+
+// ValidateCreate admits every Agent and Context
+func (v *DeletionProtectionValidator) ValidateCreate(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate admits every change
+func (v *DeletionProtectionValidator) ValidateUpdate(context.Context, runtime.Object, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete checks whether unfinished Tasks use the Agent or Context
+func (v *DeletionProtectionValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var target usage.Target
+	switch o := obj.(type) {
+	case *kubetaskv1alpha1.Agent:
+		target = usage.Target{Kind: usage.KindAgent, Namespace: o.Namespace, Name: o.Name}
+	case *kubetaskv1alpha1.Context:
+		target = usage.Target{Kind: usage.KindContext, Namespace: o.Namespace, Name: o.Name}
+	default:
+		return nil, fmt.Errorf("expected an Agent or Context but got %T", obj)
+	}
+
+	tasks, err := usage.ActiveTasks(ctx, v.Client, target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up Tasks using %s/%s: %w", target.Kind, target.Name, err)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	message := fmt.Sprintf("%s/%s is used by %d unfinished Task(s): %s", target.Kind, target.Name, len(tasks), taskNames(tasks, target.Namespace))
+	if v.Mode == DeletionProtectionWarn {
+		return admission.Warnings{message + "; they may fail"}, nil
+	}
+	return nil, fmt.Errorf("%s; wait for them to finish or delete them first", message)
+}
+
+// taskNames lists the first Tasks, qualifying those outside namespace
+func taskNames(tasks []usage.Reference, namespace string) string {
+	names := make([]string, 0, min(len(tasks), maxListedTasks))
+	for _, task := range tasks[:min(len(tasks), maxListedTasks)] {
+		if task.Namespace == namespace {
+			names = append(names, task.Name)
+		} else {
+			names = append(names, usage.Key(task.Namespace, task.Name))
+		}
+	}
+	if len(tasks) > maxListedTasks {
+		names = append(names, fmt.Sprintf("and %d more", len(tasks)-maxListedTasks))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,91 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/usage"
+)
+
+func TestDeletionProtectionValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	newTask := func(namespace, name string, phase kubetaskv1alpha1.TaskPhase, spec kubetaskv1alpha1.TaskSpec) *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+			Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: phase},
+		}
+	}
+	shared := []kubetaskv1alpha1.ContextMount{{Name: "shared", Namespace: "platform"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newTask("platform", "running", kubetaskv1alpha1.TaskPhaseRunning, kubetaskv1alpha1.TaskSpec{AgentRef: "claude"}),
+		newTask("platform", "done", kubetaskv1alpha1.TaskPhaseCompleted, kubetaskv1alpha1.TaskSpec{AgentRef: "retired"}),
+		newTask("team", "new", "", kubetaskv1alpha1.TaskSpec{Contexts: shared}),
+	).Build()
+
+	agent := func(name string) runtime.Object {
+		return &kubetaskv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "platform"}}
+	}
+	contextObj := func(name string) runtime.Object {
+		return &kubetaskv1alpha1.Context{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "platform"}}
+	}
+
+	tests := []struct {
+		name        string
+		mode        DeletionProtection
+		obj         runtime.Object
+		wantErr     string
+		wantWarning bool
+	}{
+		{name: "in-use Agent", mode: DeletionProtectionDeny, obj: agent("claude"), wantErr: "agent/claude is used by 1 unfinished Task(s): running"},
+		{name: "Agent of finished Tasks", mode: DeletionProtectionDeny, obj: agent("retired")},
+		{name: "Context used from another namespace", mode: DeletionProtectionDeny, obj: contextObj("shared"), wantErr: "team/new"},
+		{name: "unused Context", mode: DeletionProtectionDeny, obj: contextObj("unused")},
+		{name: "warn", mode: DeletionProtectionWarn, obj: agent("claude"), wantWarning: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &DeletionProtectionValidator{Client: c, Mode: tt.mode}
+			warnings, err := v.ValidateDelete(context.Background(), tt.obj)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ValidateDelete() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateDelete() error = %v", err)
+			}
+			if (len(warnings) > 0) != tt.wantWarning {
+				t.Errorf("ValidateDelete() warnings = %v, want warning %v", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestTaskNames(t *testing.T) {
+	var tasks []usage.Reference
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		tasks = append(tasks, usage.Reference{Kind: "Task", Namespace: "platform", Name: name})
+	}
+	tasks[0].Namespace = "team"
+
+	want := "team/a, b, c, d, e, and 2 more"
+	if got := taskNames(tasks, "platform"); got != want {
+		t.Errorf("taskNames() = %q, want %q", got, want)
+	}
+}
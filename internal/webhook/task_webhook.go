@@ -0,0 +1,258 @@
+// Copyright Contributors to the KubeTask project
+
+// Package webhook implements admission webhooks for KubeTask resources
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+	"github.com/kubetask/kubetask/internal/quota"
+)
+
+// +kubebuilder:webhook:path=/mutate-kubetask-io-v1alpha1-task,mutating=true,failurePolicy=fail,sideEffects=None,groups=kubetask.io,resources=tasks,verbs=create,versions=v1alpha1,name=mtask.kubetask.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-kubetask-io-v1alpha1-task,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubetask.io,resources=tasks,verbs=create;update;delete,versions=v1alpha1,name=vtask.kubetask.io,admissionReviewVersions=v1
+
+// SetupTaskWebhookWithManager registers the Task admission webhooks with the Manager.
+// allowedMountRoots are directories, besides the Agent's workspace directory, that
+// Contexts may be mounted into. controllerUsername is the identity the controller
+// uses against the API server; see TaskCustomValidator.ControllerUsername.
+func SetupTaskWebhookWithManager(mgr ctrl.Manager, allowedMountRoots []string, controllerUsername string) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kubetaskv1alpha1.Task{}).
+		WithDefaulter(&TaskCustomDefaulter{}).
+		WithValidator(&TaskCustomValidator{
+			Client:             mgr.GetClient(),
+			AllowedMountRoots:  allowedMountRoots,
+			ControllerUsername: controllerUsername,
+		}).
+		Complete()
+}
+
+// TaskCustomDefaulter stamps the identity of the requesting user on new Tasks.
+type TaskCustomDefaulter struct{}
+
+var _ admission.CustomDefaulter = &TaskCustomDefaulter{}
+
+// Default sets the created-by annotation and label from the admission request's user
+// info. Any user-supplied values are overwritten so they cannot be spoofed.
+func (d *TaskCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	task, ok := obj.(*kubetaskv1alpha1.Task)
+	if !ok {
+		return fmt.Errorf("expected a Task but got %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if task.Annotations == nil {
+		task.Annotations = map[string]string{}
+	}
+	task.Annotations[audit.AnnotationCreatedBy] = req.UserInfo.Username
+
+	if task.Labels == nil {
+		task.Labels = map[string]string{}
+	}
+	task.Labels[audit.LabelCreatedBy] = audit.CreatedByLabelValue(req.UserInfo.Username)
+	return nil
+}
+
+// TaskCustomValidator enforces TaskQuotas, checks Context mountPaths, protects audit
+// annotations, and records admission-time audit entries.
+type TaskCustomValidator struct {
+	// Client reads TaskQuotas, Tasks and Agents. If nil, quotas are not enforced
+	// and mountPaths are only checked against ProtectedMountRoots.
+	Client client.Reader
+
+	// AllowedMountRoots are directories, besides the Agent's workspace directory,
+	// that Contexts may be mounted into
+	AllowedMountRoots []string
+
+	// ControllerUsername is the identity of the controller. Deletions it requests
+	// are not recorded, since the controller records them itself. If empty, every
+	// deletion is recorded.
+	ControllerUsername string
+}
+
+var _ admission.CustomValidator = &TaskCustomValidator{}
+
+// ValidateCreate rejects Tasks that would exceed a TaskQuota, mount Contexts outside
+// the permitted paths or claim an approval, warns about Tasks without instructions,
+// and records the creation of a Task in the audit stream.
+func (v *TaskCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	task, ok := obj.(*kubetaskv1alpha1.Task)
+	if !ok {
+		return nil, fmt.Errorf("expected a Task but got %T", obj)
+	}
+
+	// A Task is approved after it exists, by the approving user (see checkApproval)
+	if _, ok := task.Annotations[audit.AnnotationApprovedBy]; ok {
+		return nil, fmt.Errorf("annotation %s cannot be set on create; it is set by the approving user on an existing Task", audit.AnnotationApprovedBy)
+	}
+
+	warnings, err := v.checkMountPaths(ctx, task)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.checkQuotas(ctx, task); err != nil {
+		return warnings, err
+	}
+	if warning := emptyPromptWarning(task); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	if !dryRun(ctx) {
+		audit.Record(audit.ForTask(task, audit.ActionCreated, requestActor(ctx)))
+	}
+	return warnings, nil
+}
+
+// ValidateUpdate rejects changes to the created-by annotation and label, approvals
+// not made by the requesting user, and changed Context mountPaths outside the
+// permitted paths.
+func (v *TaskCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTask, ok := oldObj.(*kubetaskv1alpha1.Task)
+	if !ok {
+		return nil, fmt.Errorf("expected a Task but got %T", oldObj)
+	}
+	newTask, ok := newObj.(*kubetaskv1alpha1.Task)
+	if !ok {
+		return nil, fmt.Errorf("expected a Task but got %T", newObj)
+	}
+
+	if oldTask.Annotations[audit.AnnotationCreatedBy] != newTask.Annotations[audit.AnnotationCreatedBy] {
+		return nil, fmt.Errorf("annotation %s is immutable", audit.AnnotationCreatedBy)
+	}
+	if oldTask.Labels[audit.LabelCreatedBy] != newTask.Labels[audit.LabelCreatedBy] {
+		return nil, fmt.Errorf("label %s is immutable", audit.LabelCreatedBy)
+	}
+	if err := checkApproval(ctx, oldTask, newTask); err != nil {
+		return nil, err
+	}
+
+	if !equality.Semantic.DeepEqual(oldTask.Spec.Contexts, newTask.Spec.Contexts) {
+		return v.checkMountPaths(ctx, newTask)
+	}
+	return nil, nil
+}
+
+// ValidateDelete records who deleted the Task. Deleting a Task that has not
+// finished yet is recorded as a cancellation. Dry runs and deletions by the
+// controller are not recorded.
+func (v *TaskCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	task, ok := obj.(*kubetaskv1alpha1.Task)
+	if !ok {
+		return nil, fmt.Errorf("expected a Task but got %T", obj)
+	}
+	actor := requestActor(ctx)
+	if dryRun(ctx) || (v.ControllerUsername != "" && actor == v.ControllerUsername) {
+		return nil, nil
+	}
+
+	action := audit.ActionCancelled
+	if task.Status.Phase == kubetaskv1alpha1.TaskPhaseCompleted ||
+		task.Status.Phase == kubetaskv1alpha1.TaskPhaseFailed {
+		action = audit.ActionDeleted
+	}
+	audit.Record(audit.ForTask(task, action, actor))
+	return nil, nil
+}
+
+// checkApproval returns an error if the approved-by annotation changes other than by
+// the requesting user approving the Task once, with their own identity
+func checkApproval(ctx context.Context, oldTask, newTask *kubetaskv1alpha1.Task) error {
+	oldApprover, wasApproved := oldTask.Annotations[audit.AnnotationApprovedBy]
+	newApprover, isApproved := newTask.Annotations[audit.AnnotationApprovedBy]
+	if wasApproved == isApproved && oldApprover == newApprover {
+		return nil
+	}
+	if wasApproved {
+		return fmt.Errorf("annotation %s is immutable once set", audit.AnnotationApprovedBy)
+	}
+	if actor := requestActor(ctx); actor == "" || newApprover != actor {
+		return fmt.Errorf("annotation %s must be set to the identity of the approving user %q", audit.AnnotationApprovedBy, actor)
+	}
+	return nil
+}
+
+// emptyPromptWarning warns about a Task with no instruction source of its own. It is
+// not rejected, since its Agent's contexts may provide the instructions; the controller
+// reports a Task that ends up without task.md in its PromptProvided condition.
+func emptyPromptWarning(task *kubetaskv1alpha1.Task) string {
+	spec := task.Spec
+	if (spec.Description != nil && *spec.Description != "") || spec.DescriptionFrom != nil ||
+		len(spec.Contexts) > 0 || len(spec.Files) > 0 {
+		return ""
+	}
+	return "Task has no description, descriptionFrom, contexts or files; unless its Agent's contexts provide instructions, the agent starts without task.md"
+}
+
+// checkQuotas returns an error if any TaskQuota matching the Task has no room for it
+func (v *TaskCustomValidator) checkQuotas(ctx context.Context, task *kubetaskv1alpha1.Task) error {
+	if v.Client == nil || !quota.Counted(task) {
+		return nil
+	}
+
+	quotaList := &kubetaskv1alpha1.TaskQuotaList{}
+	if err := v.Client.List(ctx, quotaList, client.InNamespace(task.Namespace)); err != nil {
+		return fmt.Errorf("unable to list TaskQuotas: %w", err)
+	}
+
+	// Tasks are listed once, when the first matching quota is found
+	var taskList *kubetaskv1alpha1.TaskList
+	for i := range quotaList.Items {
+		taskQuota := &quotaList.Items[i]
+		selector, err := quota.Selector(taskQuota)
+		if err != nil {
+			// The TaskQuota controller reports invalid selectors in status
+			logf.FromContext(ctx).Error(err, "ignoring TaskQuota with invalid selector", "taskQuota", taskQuota.Name)
+			continue
+		}
+		if !selector.Matches(labels.Set(task.Labels)) {
+			continue
+		}
+
+		if taskList == nil {
+			taskList = &kubetaskv1alpha1.TaskList{}
+			if err := v.Client.List(ctx, taskList, client.InNamespace(task.Namespace)); err != nil {
+				return fmt.Errorf("unable to list Tasks: %w", err)
+			}
+		}
+
+		usage := quota.Usage(selector, taskList.Items, time.Now())
+		if exceeded := quota.Exceeded(taskQuota.Spec, usage); len(exceeded) > 0 {
+			return fmt.Errorf("TaskQuota %s exceeded: %s", taskQuota.Name, strings.Join(exceeded, "; "))
+		}
+	}
+	return nil
+}
+
+// requestActor returns the username of the admission request, if available
+func requestActor(ctx context.Context) string {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+	return req.UserInfo.Username
+}
+
+// dryRun reports whether the admission request is a dry run, which is not persisted
+// and so must not be recorded in the audit stream
+func dryRun(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err == nil && req.DryRun != nil && *req.DryRun
+}
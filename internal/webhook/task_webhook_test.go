@@ -0,0 +1,323 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+	"github.com/kubetask/kubetask/internal/audit"
+)
+
+func requestContext(username string) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: username},
+		},
+	})
+}
+
+func TestTaskCustomDefaulter_SetsCreatedBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+	}{
+		{
+			name:        "no annotations",
+			annotations: nil,
+		},
+		{
+			name:        "spoofed annotation is overwritten",
+			annotations: map[string]string{audit.AnnotationCreatedBy: "someone-else"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-task",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+			}
+
+			d := &TaskCustomDefaulter{}
+			if err := d.Default(requestContext("alice@example.com"), task); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+
+			if got := task.Annotations[audit.AnnotationCreatedBy]; got != "alice@example.com" {
+				t.Errorf("Annotations[%s] = %q, want %q", audit.AnnotationCreatedBy, got, "alice@example.com")
+			}
+			if got := task.Labels[audit.LabelCreatedBy]; got != "alice_example.com" {
+				t.Errorf("Labels[%s] = %q, want %q", audit.LabelCreatedBy, got, "alice_example.com")
+			}
+		})
+	}
+}
+
+func TestTaskCustomValidator_CreatedByImmutable(t *testing.T) {
+	oldTask := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-task",
+			Namespace:   "default",
+			Annotations: map[string]string{audit.AnnotationCreatedBy: "alice@example.com"},
+		},
+	}
+
+	v := &TaskCustomValidator{}
+
+	unchanged := oldTask.DeepCopy()
+	unchanged.Annotations["team"] = "platform"
+	if _, err := v.ValidateUpdate(context.Background(), oldTask, unchanged); err != nil {
+		t.Errorf("ValidateUpdate() with unchanged created-by error = %v, want nil", err)
+	}
+
+	changed := oldTask.DeepCopy()
+	changed.Annotations[audit.AnnotationCreatedBy] = "mallory@example.com"
+	if _, err := v.ValidateUpdate(context.Background(), oldTask, changed); err == nil {
+		t.Errorf("ValidateUpdate() with changed created-by error = nil, want error")
+	}
+
+	relabeled := oldTask.DeepCopy()
+	relabeled.Labels = map[string]string{audit.LabelCreatedBy: "mallory"}
+	if _, err := v.ValidateUpdate(context.Background(), oldTask, relabeled); err == nil {
+		t.Errorf("ValidateUpdate() with changed created-by label error = nil, want error")
+	}
+}
+
+func TestTaskCustomValidator_ApprovedBy(t *testing.T) {
+	v := &TaskCustomValidator{}
+	newTask := func(approver string) *kubetaskv1alpha1.Task {
+		task := &kubetaskv1alpha1.Task{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-task",
+				Namespace:   "default",
+				Annotations: map[string]string{audit.AnnotationCreatedBy: "alice@example.com"},
+			},
+		}
+		if approver != "" {
+			task.Annotations[audit.AnnotationApprovedBy] = approver
+		}
+		return task
+	}
+
+	if _, err := v.ValidateCreate(requestContext("alice@example.com"), newTask("alice@example.com")); err == nil {
+		t.Error("ValidateCreate() with approved-by error = nil, want error")
+	}
+
+	tests := []struct {
+		name      string
+		oldTask   *kubetaskv1alpha1.Task
+		newTask   *kubetaskv1alpha1.Task
+		requester string
+		wantErr   bool
+	}{
+		{name: "approved by requesting user", oldTask: newTask(""), newTask: newTask("bob@example.com"), requester: "bob@example.com"},
+		{name: "approved on behalf of another user", oldTask: newTask(""), newTask: newTask("bob@example.com"), requester: "mallory@example.com", wantErr: true},
+		{name: "approval without request", oldTask: newTask(""), newTask: newTask("bob@example.com"), wantErr: true},
+		{name: "approver changed", oldTask: newTask("bob@example.com"), newTask: newTask("mallory@example.com"), requester: "mallory@example.com", wantErr: true},
+		{name: "approval removed", oldTask: newTask("bob@example.com"), newTask: newTask(""), requester: "bob@example.com", wantErr: true},
+		{name: "approval unchanged", oldTask: newTask("bob@example.com"), newTask: newTask("bob@example.com"), requester: "system:kubetask-controller"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.requester != "" {
+				ctx = requestContext(tt.requester)
+			}
+			_, err := v.ValidateUpdate(ctx, tt.oldTask, tt.newTask)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreatedByLabelValue(t *testing.T) {
+	tests := map[string]string{
+		"alice":                             "alice",
+		"alice@example.com":                 "alice_example.com",
+		"system:serviceaccount:ci:deployer": "system_serviceaccount_ci_deployer",
+		"oidc:" + strings.Repeat("x", 70):   "oidc_" + strings.Repeat("x", 58),
+		"-leading-and-trailing@":            "leading-and-trailing",
+	}
+	for username, want := range tests {
+		if got := audit.CreatedByLabelValue(username); got != want {
+			t.Errorf("CreatedByLabelValue(%q) = %q, want %q", username, got, want)
+		}
+	}
+}
+
+func TestTaskCustomValidator_EnforcesTaskQuota(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubetaskv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	maxConcurrent := int32(1)
+	taskQuota := &kubetaskv1alpha1.TaskQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-sre", Namespace: "default"},
+		Spec: kubetaskv1alpha1.TaskQuotaSpec{
+			Selector:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "sre"}},
+			MaxConcurrentTasks: &maxConcurrent,
+		},
+	}
+	running := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default", Labels: map[string]string{"team": "sre"}},
+		Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseRunning},
+	}
+	v := &TaskCustomValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(taskQuota, running).Build(),
+	}
+
+	newTask := func(namespace, team string) *kubetaskv1alpha1.Task {
+		return &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{
+			Name: "new", Namespace: namespace, Labels: map[string]string{"team": team},
+		}}
+	}
+
+	_, err := v.ValidateCreate(requestContext("alice@example.com"), newTask("default", "sre"))
+	if err == nil || !strings.Contains(err.Error(), "TaskQuota team-sre exceeded") {
+		t.Errorf("ValidateCreate() error = %v, want TaskQuota team-sre exceeded", err)
+	}
+	if _, err := v.ValidateCreate(requestContext("alice@example.com"), newTask("default", "web")); err != nil {
+		t.Errorf("ValidateCreate() for another team error = %v, want nil", err)
+	}
+	if _, err := v.ValidateCreate(requestContext("alice@example.com"), newTask("other", "sre")); err != nil {
+		t.Errorf("ValidateCreate() in another namespace error = %v, want nil", err)
+	}
+}
+
+func TestTaskCustomValidator_EmptyPromptWarning(t *testing.T) {
+	description := "Fix the flaky test"
+	tests := []struct {
+		name        string
+		spec        kubetaskv1alpha1.TaskSpec
+		wantWarning bool
+	}{
+		{name: "description", spec: kubetaskv1alpha1.TaskSpec{Description: &description}},
+		{name: "contexts", spec: kubetaskv1alpha1.TaskSpec{Contexts: []kubetaskv1alpha1.ContextMount{{Name: "runbook"}}}},
+		{name: "descriptionFrom", spec: kubetaskv1alpha1.TaskSpec{DescriptionFrom: &kubetaskv1alpha1.DescriptionSource{}}},
+		{name: "no instructions", spec: kubetaskv1alpha1.TaskSpec{}, wantWarning: true},
+	}
+
+	v := &TaskCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &kubetaskv1alpha1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			warnings, err := v.ValidateCreate(requestContext("alice@example.com"), task)
+			if err != nil {
+				t.Fatalf("ValidateCreate() error = %v, want nil", err)
+			}
+			warned := len(warnings) == 1 && strings.Contains(warnings[0], "starts without task.md")
+			if warned != tt.wantWarning || (!tt.wantWarning && len(warnings) > 0) {
+				t.Errorf("ValidateCreate() warnings = %v, want warning %v", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestTaskCustomValidator_AuditEntries(t *testing.T) {
+	var entries []string
+	sink := audit.Sink
+	audit.Sink = funcr.New(func(prefix, args string) {
+		entries = append(entries, args)
+	}, funcr.Options{})
+	t.Cleanup(func() { audit.Sink = sink })
+
+	dryRunContext := func(username string) context.Context {
+		dryRun := true
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: username},
+				DryRun:   &dryRun,
+			},
+		})
+	}
+	const controllerUsername = "system:serviceaccount:kubetask-system:kubetask-controller"
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Status:     kubetaskv1alpha1.TaskExecutionStatus{Phase: kubetaskv1alpha1.TaskPhaseCompleted},
+	}
+	v := &TaskCustomValidator{ControllerUsername: controllerUsername}
+
+	tests := []struct {
+		name     string
+		validate func() error
+		want     string
+	}{
+		{
+			name: "create",
+			validate: func() error {
+				_, err := v.ValidateCreate(requestContext("alice@example.com"), task)
+				return err
+			},
+			want: `"action"="Created"`,
+		},
+		{
+			name: "dry-run create",
+			validate: func() error {
+				_, err := v.ValidateCreate(dryRunContext("alice@example.com"), task)
+				return err
+			},
+		},
+		{
+			name: "delete",
+			validate: func() error {
+				_, err := v.ValidateDelete(requestContext("alice@example.com"), task)
+				return err
+			},
+			want: `"action"="Deleted"`,
+		},
+		{
+			name: "dry-run delete",
+			validate: func() error {
+				_, err := v.ValidateDelete(dryRunContext("alice@example.com"), task)
+				return err
+			},
+		},
+		{
+			name: "delete by the controller",
+			validate: func() error {
+				_, err := v.ValidateDelete(requestContext(controllerUsername), task)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries = nil
+			if err := tt.validate(); err != nil {
+				t.Fatalf("validate error = %v", err)
+			}
+			if tt.want == "" {
+				if len(entries) != 0 {
+					t.Errorf("audit entries = %v, want none", entries)
+				}
+				return
+			}
+			if len(entries) != 1 || !strings.Contains(entries[0], tt.want) {
+				t.Errorf("audit entries = %v, want one containing %s", entries, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright Contributors to the KubeTask project
+
+// Package agentsdk helps agent image authors follow the conventions of the KubeTask
+// controller: reading the task and its contexts, reporting progress and results,
+// and stopping cleanly when the Task is cancelled.
+//
+// The package only depends on the standard library, so it can be vendored into any
+// agent. See docs/agent-context-spec.md for the conventions it implements.
+//
+//	func main() {
+//		agentsdk.Run(func(ctx context.Context, a *agentsdk.Agent) error {
+//			task, err := a.ReadTask()
+//			if err != nil {
+//				return err
+//			}
+//			_ = a.ReportProgress(ctx, agentsdk.Progress{Message: "Working on " + a.TaskName})
+//			...
+//			return a.WriteResult(agentsdk.Result{Changes: agentsdk.Changes{PullRequests: []string{url}}})
+//		})
+//	}
+package agentsdk
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+// Environment variables set by the controller on the agent container
+const (
+	EnvTaskName      = "TASK_NAME"
+	EnvTaskNamespace = "TASK_NAMESPACE"
+	EnvWorkspaceDir  = "WORKSPACE_DIR"
+	EnvPodInfoDir    = "KUBETASK_PODINFO_DIR"
+	EnvResultURL     = "KUBETASK_RESULT_URL"
+	EnvResultToken   = "KUBETASK_RESULT_TOKEN"
+)
+
+const (
+	// DefaultWorkspaceDir is the workspace of Agents that do not set workspaceDir
+	DefaultWorkspaceDir = "/workspace"
+
+	// DefaultTerminationLogPath is where Kubernetes reads the termination message from
+	DefaultTerminationLogPath = "/dev/termination-log"
+
+	// TaskFileName is the file under the workspace holding the description and the
+	// contexts without a mountPath
+	TaskFileName = "task.md"
+
+	// ContextUpdatedFileName is the file under the workspace holding the time of the
+	// last context update, for Tasks with refreshing contexts
+	ContextUpdatedFileName = ".kubetask-context-updated"
+
+	// CancellationFileName is the file under the workspace the controller's command
+	// wrapper writes when the Task is cancelled
+	CancellationFileName = ".kubetask-cancelled"
+)
+
+// Agent is the view of the running Task from inside the agent container
+type Agent struct {
+	// TaskName and TaskNamespace identify the Task
+	TaskName      string
+	TaskNamespace string
+
+	// WorkspaceDir is the agent's working directory
+	WorkspaceDir string
+
+	// PodInfoDir holds the Downward API files with the Pod's labels and annotations
+	PodInfoDir string
+
+	// ResultURL and ResultToken address the result API. ResultURL is empty when the
+	// controller does not serve it.
+	ResultURL   string
+	ResultToken string
+
+	// TerminationLogPath is where results are written, DefaultTerminationLogPath if empty
+	TerminationLogPath string
+
+	// HTTPClient sends reports to the result API, a client with a 10s timeout if nil
+	HTTPClient *http.Client
+}
+
+// FromEnv returns the Agent described by the environment the controller sets up
+func FromEnv() *Agent {
+	workspaceDir := os.Getenv(EnvWorkspaceDir)
+	if workspaceDir == "" {
+		workspaceDir = DefaultWorkspaceDir
+	}
+	return &Agent{
+		TaskName:      os.Getenv(EnvTaskName),
+		TaskNamespace: os.Getenv(EnvTaskNamespace),
+		WorkspaceDir:  workspaceDir,
+		PodInfoDir:    os.Getenv(EnvPodInfoDir),
+		ResultURL:     os.Getenv(EnvResultURL),
+		ResultToken:   os.Getenv(EnvResultToken),
+	}
+}
+
+func (a *Agent) httpClient() *http.Client {
+	if a.HTTPClient != nil {
+		return a.HTTPClient
+	}
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+func (a *Agent) terminationLogPath() string {
+	if a.TerminationLogPath != "" {
+		return a.TerminationLogPath
+	}
+	return DefaultTerminationLogPath
+}
@@ -0,0 +1,174 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package agentsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTask(t *testing.T) {
+	content := "Review this PR\n\n" +
+		"<context name=\"coding-standards\" namespace=\"default\" type=\"Inline\">\n# Coding Standards\nFollow Go conventions.\n</context>\n\n" +
+		"<context name=\"security-policy\" namespace=\"default\" type=\"ConfigMap\">\n# Security Policy\nAll code must be reviewed.\n</context>"
+
+	task := ParseTask(content)
+	if task.Description != "Review this PR" {
+		t.Errorf("Description = %q, want %q", task.Description, "Review this PR")
+	}
+	if len(task.Contexts) != 2 {
+		t.Fatalf("Contexts = %+v, want 2", task.Contexts)
+	}
+	want := Context{Name: "security-policy", Namespace: "default", Type: "ConfigMap", Content: "# Security Policy\nAll code must be reviewed."}
+	if got, ok := task.Context("security-policy"); !ok || got != want {
+		t.Errorf("Context(security-policy) = %+v, want %+v", got, want)
+	}
+
+	if task := ParseTask("Just a description\n"); task.Description != "Just a description" || len(task.Contexts) != 0 {
+		t.Errorf("ParseTask() = %+v, want only the description", task)
+	}
+}
+
+func TestReadPodInfo(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "labels"), []byte("team=\"platform\"\nnote=\"say \\\"hi\\\"\""), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a := &Agent{PodInfoDir: dir}
+
+	labels, err := a.Labels()
+	if err != nil {
+		t.Fatalf("Labels() error = %v", err)
+	}
+	if want := map[string]string{"team": "platform", "note": `say "hi"`}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Labels() = %v, want %v", labels, want)
+	}
+	if _, err := (&Agent{}).Annotations(); err == nil {
+		t.Errorf("Annotations() error = nil, want an error without %s", EnvPodInfoDir)
+	}
+}
+
+func TestContextUpdated(t *testing.T) {
+	a := &Agent{WorkspaceDir: t.TempDir()}
+	if updated, err := a.ContextUpdated(); err != nil || !updated.IsZero() {
+		t.Errorf("ContextUpdated() = %v, %v, want the zero time before any update", updated, err)
+	}
+	if err := os.WriteFile(filepath.Join(a.WorkspaceDir, ContextUpdatedFileName), []byte("2026-01-02T03:04:05Z\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if updated, err := a.ContextUpdated(); err != nil || !updated.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("ContextUpdated() = %v, %v, want the sentinel time", updated, err)
+	}
+}
+
+func TestReportProgress(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding report: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	percent := int32(50)
+	progress := Progress{Message: "Running tests", Percent: &percent, Changes: Changes{Commits: []string{"abc1234"}}}
+
+	if err := (&Agent{}).ReportProgress(context.Background(), progress); err != nil {
+		t.Errorf("ReportProgress() error = %v, want a no-op without the result API", err)
+	}
+
+	a := &Agent{ResultURL: srv.URL, ResultToken: "s3cret", HTTPClient: srv.Client()}
+	if err := a.ReportProgress(context.Background(), progress); err != nil {
+		t.Fatalf("ReportProgress() error = %v", err)
+	}
+	want := map[string]any{"message": "Running tests", "percent": float64(50), "commits": []any{"abc1234"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("report = %v, want %v", got, want)
+	}
+
+	a.ResultToken = "guess"
+	if err := a.ReportProgress(context.Background(), progress); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("ReportProgress() error = %v, want the rejection", err)
+	}
+}
+
+func TestWriteResult(t *testing.T) {
+	a := &Agent{TerminationLogPath: filepath.Join(t.TempDir(), "termination-log")}
+	if err := os.WriteFile(a.TerminationLogPath, []byte("KubeTask-Snapshot: snap.tar.gz\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := a.WriteResult(Result{
+		Message: "Bumped dependencies",
+		Changes: Changes{PullRequests: []string{"https://github.com/org/repo/pull/42"}, ChangedFiles: []string{"go.mod", "go.sum"}},
+		Outputs: map[string]string{"version": "1.2.0", "bumped": "3"},
+	})
+	if err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+	content, _ := os.ReadFile(a.TerminationLogPath)
+	want := "KubeTask-Snapshot: snap.tar.gz\nBumped dependencies\n" +
+		"KubeTask-Pull-Request: https://github.com/org/repo/pull/42\n" +
+		"KubeTask-Changed-File: go.mod\nKubeTask-Changed-File: go.sum\n" +
+		"KubeTask-Output: bumped=3\nKubeTask-Output: version=1.2.0\n"
+	if string(content) != want {
+		t.Errorf("termination message = %q, want %q", content, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	a := &Agent{WorkspaceDir: t.TempDir(), TerminationLogPath: filepath.Join(t.TempDir(), "termination-log")}
+
+	if code := run(context.Background(), a, func(ctx context.Context, a *Agent) error { return nil }); code != 0 {
+		t.Errorf("run() = %d, want 0", code)
+	}
+	code := run(context.Background(), a, func(ctx context.Context, a *Agent) error { return errors.New("tests failed") })
+	if code != 1 {
+		t.Errorf("run() = %d, want 1", code)
+	}
+	if content, _ := os.ReadFile(a.TerminationLogPath); string(content) != "tests failed\n" {
+		t.Errorf("termination message = %q, want the error", content)
+	}
+}
+
+func TestRun_CancellationFile(t *testing.T) {
+	cancellationPollInterval = 10 * time.Millisecond
+	a := &Agent{WorkspaceDir: t.TempDir(), TerminationLogPath: filepath.Join(t.TempDir(), "termination-log")}
+
+	code := run(context.Background(), a, func(ctx context.Context, a *Agent) error {
+		if a.Cancelled() {
+			t.Errorf("Cancelled() = true before cancellation")
+		}
+		if err := os.WriteFile(filepath.Join(a.WorkspaceDir, CancellationFileName), []byte("2026-01-02T03:04:05Z\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return errors.New("context was not cancelled")
+		}
+	})
+	if code != 1 {
+		t.Errorf("run() = %d, want 1", code)
+	}
+	if content, _ := os.ReadFile(a.TerminationLogPath); !strings.HasPrefix(string(content), "cancelled: ") {
+		t.Errorf("termination message = %q, want the cancellation", content)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright Contributors to the KubeTask project
+
+package agentsdk
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Context is a context appended to task.md because it has no mountPath
+type Context struct {
+	Name      string
+	Namespace string
+	Type      string
+	Content   string
+}
+
+// Task is the parsed content of ${WORKSPACE_DIR}/task.md
+type Task struct {
+	// Description is the Task description, the part of task.md before the first context
+	Description string
+
+	// Contexts are the contexts appended to task.md, in order
+	Contexts []Context
+}
+
+// contextPattern matches the context tags the controller writes to task.md. Attribute
+// values are Go-quoted strings.
+var contextPattern = regexp.MustCompile(`(?s)<context name=("(?:[^"\\]|\\.)*") namespace=("(?:[^"\\]|\\.)*") type=("(?:[^"\\]|\\.)*")>\n(.*?)\n</context>`)
+
+// ReadTask reads and parses task.md from the workspace
+func (a *Agent) ReadTask() (*Task, error) {
+	content, err := os.ReadFile(filepath.Join(a.WorkspaceDir, TaskFileName))
+	if err != nil {
+		return nil, err
+	}
+	return ParseTask(string(content)), nil
+}
+
+// ParseTask splits task.md content into the description and the appended contexts.
+// Content that does not look like a context tag is kept in the description.
+func ParseTask(content string) *Task {
+	task := &Task{}
+	matches := contextPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		task.Description = strings.TrimSpace(content)
+		return task
+	}
+	task.Description = strings.TrimSpace(content[:matches[0][0]])
+	for _, m := range matches {
+		name, _ := strconv.Unquote(content[m[2]:m[3]])
+		namespace, _ := strconv.Unquote(content[m[4]:m[5]])
+		ctxType, _ := strconv.Unquote(content[m[6]:m[7]])
+		task.Contexts = append(task.Contexts, Context{
+			Name:      name,
+			Namespace: namespace,
+			Type:      ctxType,
+			Content:   content[m[8]:m[9]],
+		})
+	}
+	return task
+}
+
+// Context returns the appended context with the given name, if any
+func (t *Task) Context(name string) (Context, bool) {
+	for _, c := range t.Contexts {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Context{}, false
+}
+
+// ContextUpdated returns when the controller last refreshed the workspace contexts.
+// It returns the zero time if the Task does not refresh its contexts or they have not
+// changed since the agent started.
+func (a *Agent) ContextUpdated() (time.Time, error) {
+	content, err := os.ReadFile(filepath.Join(a.WorkspaceDir, ContextUpdatedFileName))
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(content)))
+}
+
+// Labels returns the labels of the agent Pod, which include the Task's labels
+func (a *Agent) Labels() (map[string]string, error) {
+	return a.readPodInfo("labels")
+}
+
+// Annotations returns the annotations of the agent Pod, which include the Task's annotations
+func (a *Agent) Annotations() (map[string]string, error) {
+	return a.readPodInfo("annotations")
+}
+
+// readPodInfo parses a Downward API metadata file with one key="value" per line
+func (a *Agent) readPodInfo(name string) (map[string]string, error) {
+	if a.PodInfoDir == "" {
+		return nil, fmt.Errorf("%s is not set", EnvPodInfoDir)
+	}
+	f, err := os.Open(filepath.Join(a.PodInfoDir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, quoted, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s line %q: %w", name, scanner.Text(), err)
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
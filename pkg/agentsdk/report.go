@@ -0,0 +1,147 @@
+// Copyright Contributors to the KubeTask project
+
+package agentsdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Trailers the controller parses from the termination message into Task.status.changes
+// and Task.status.result
+const (
+	TrailerPullRequest = "KubeTask-Pull-Request"
+	TrailerCommit      = "KubeTask-Commit"
+	TrailerChangedFile = "KubeTask-Changed-File"
+	TrailerOutput      = "KubeTask-Output"
+)
+
+// Changes are the pull requests, commits, and files the agent touched
+type Changes struct {
+	PullRequests []string `json:"pullRequests,omitempty"`
+	Commits      []string `json:"commits,omitempty"`
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+}
+
+// Progress is a report pushed to the result API while the agent runs
+type Progress struct {
+	// Message describes what the agent is doing
+	Message string `json:"message,omitempty"`
+
+	// Percent is the estimated completion, from 0 to 100
+	Percent *int32 `json:"percent,omitempty"`
+
+	// Changes are added to the changes reported before. Their fields are flattened
+	// into the report, as the result API expects.
+	Changes
+}
+
+// Result is what the agent reports when it exits
+type Result struct {
+	// Message is the human-readable summary shown on the Task
+	Message string
+
+	// Changes are recorded in Task.status.changes
+	Changes Changes
+
+	// Outputs are recorded in Task.status.result.outputs. Names may contain letters,
+	// digits, '-', '_' and '.'; values must not contain newlines.
+	Outputs map[string]string
+}
+
+// ResultAPIEnabled reports whether the controller serves the result API to this agent
+func (a *Agent) ResultAPIEnabled() bool {
+	return a.ResultURL != ""
+}
+
+// ReportProgress pushes progress to the result API. It does nothing if the result API
+// is not enabled, so agents can call it unconditionally.
+func (a *Agent) ReportProgress(ctx context.Context, progress Progress) error {
+	if !a.ResultAPIEnabled() {
+		return nil
+	}
+	body, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.ResultURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+a.ResultToken)
+
+	resp, err := a.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("result API returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Heartbeat pushes the current progress every interval until ctx is done, so the
+// Task shows the agent is alive during long steps. Failed reports are passed to
+// onError, if set, and do not stop the heartbeat.
+func (a *Agent) Heartbeat(ctx context.Context, interval time.Duration, current func() Progress, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.ReportProgress(ctx, current()); err != nil && onError != nil && ctx.Err() == nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// WriteResult appends the result to the termination message: the message followed by
+// one trailer line per change and output. It appends so the controller's command wrapper can add
+// its own trailers.
+func (a *Agent) WriteResult(result Result) error {
+	var b strings.Builder
+	if result.Message != "" {
+		b.WriteString(strings.TrimSpace(result.Message))
+		b.WriteString("\n")
+	}
+	for _, trailer := range []struct {
+		key    string
+		values []string
+	}{
+		{TrailerPullRequest, result.Changes.PullRequests},
+		{TrailerCommit, result.Changes.Commits},
+		{TrailerChangedFile, result.Changes.ChangedFiles},
+	} {
+		for _, v := range trailer.values {
+			fmt.Fprintf(&b, "%s: %s\n", trailer.key, v)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(result.Outputs)) {
+		fmt.Fprintf(&b, "%s: %s=%s\n", TrailerOutput, name, result.Outputs[name])
+	}
+
+	f, err := os.OpenFile(a.terminationLogPath(), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,68 @@
+// Copyright Contributors to the KubeTask project
+
+package agentsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// cancellationPollInterval is how often Run checks for the cancellation file
+var cancellationPollInterval = time.Second
+
+// Run runs fn with a context that is cancelled when the Task is cancelled or its Pod
+// is deleted: on SIGTERM, or as soon as the cancellation file appears, which may be
+// earlier when the Agent sets cancellation.signalDelaySeconds.
+// It exits the process with code 0 if fn succeeds. Otherwise it writes the error to
+// the termination message, where it appears on the Task, and exits with code 1.
+func Run(fn func(ctx context.Context, a *Agent) error) {
+	os.Exit(run(context.Background(), FromEnv(), fn))
+}
+
+func run(ctx context.Context, a *Agent, fn func(ctx context.Context, a *Agent) error) int {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(cancellationPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if a.Cancelled() {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err := fn(ctx, a)
+	if err == nil {
+		return 0
+	}
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		err = fmt.Errorf("cancelled: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, err)
+	if writeErr := a.WriteResult(Result{Message: err.Error()}); writeErr != nil {
+		fmt.Fprintln(os.Stderr, "unable to write termination message:", writeErr)
+	}
+	return 1
+}
+
+// Cancelled reports whether the Task was cancelled, for agents that poll rather than
+// use the context passed by Run
+func (a *Agent) Cancelled() bool {
+	_, err := os.Stat(filepath.Join(a.WorkspaceDir, CancellationFileName))
+	return err == nil
+}
@@ -0,0 +1,230 @@
+// Copyright Contributors to the KubeTask project
+
+// Package build renders the objects the KubeTask controller creates for a Task:
+// the context ConfigMap holding task.md and the mounted context files, and the Job
+// running the agent. It does not read the cluster; callers resolve the Agent and the
+// Task's contexts into a Config and ResolvedContexts first, as the controller does.
+//
+// Tools that need to show or check exactly what the controller would create, such
+// as renderers, linters or dashboards, can import this package instead of copying
+// the controller's logic:
+//
+//	cm, files, err := build.ContextConfigMap(task, cfg, description, contexts)
+//	if err != nil {
+//		return err
+//	}
+//	job := build.Job(task, task.Name+"-job", cfg, cm, files, nil, nil)
+package build
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// DefaultWorkspaceDir is the default workspace directory for agent containers
+	DefaultWorkspaceDir = "/workspace"
+
+	// DefaultAgentContainerName is the name of the agent container in Task Pods
+	// when the Agent does not set containerName
+	DefaultAgentContainerName = "agent"
+
+	// DefaultBackoffLimit is the backoffLimit of the Jobs of Tasks without a retry
+	// policy. It is the Kubernetes default, set explicitly since it also bounds the
+	// in-place container restarts of OnFailure agents.
+	DefaultBackoffLimit int32 = 6
+
+	// DefaultKeepAliveSeconds is the default keep-alive duration for human-in-the-loop (1 hour)
+	DefaultKeepAliveSeconds int32 = 3600
+
+	// EnvHumanInTheLoopKeepAlive is the environment variable name for keep-alive seconds
+	EnvHumanInTheLoopKeepAlive = "KUBETASK_KEEP_ALIVE_SECONDS"
+
+	// TaskLabelKey is the label key identifying the Task that owns a Job and its Pods
+	TaskLabelKey = "kubetask.io/task"
+
+	// AgentLabelKey is the label key recording the Agent a canary rollout Job ran with
+	AgentLabelKey = "kubetask.io/agent"
+
+	// CanaryTrackLabelKey is the label key recording whether a Job ran the canary or stable image
+	CanaryTrackLabelKey = "kubetask.io/canary-track"
+
+	// CanaryRolloutLabelKey is the label key identifying the rollout a Job belongs to
+	CanaryRolloutLabelKey = "kubetask.io/canary-rollout"
+
+	// DefaultContainerAnnotation names the agent container on Task Pods. kubectl
+	// uses it as the default container for exec and logs.
+	DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+	// ReloadCredentialsAnnotation on Task Pods lists, comma-separated, the mount
+	// paths of credentials with reloadOnChange. The kubelet updates these files when
+	// the Secret is rotated, so agents should re-read them rather than cache them.
+	ReloadCredentialsAnnotation = "kubetask.io/reload-credentials"
+)
+
+// Config is the resolved configuration of the agent that runs a Task: the Agent's
+// spec with defaults applied, plus the settings the controller adds from the
+// Task, the namespace's KubeTaskConfig and its own flags.
+type Config struct {
+	AgentImage         string
+	Command            []string
+	WorkspaceDir       string
+	Credentials        []kubetaskv1alpha1.Credential
+	EnvFrom            []corev1.EnvFromSource
+	PodSpec            *kubetaskv1alpha1.AgentPodSpec
+	ServiceAccountName string
+
+	// RuntimeClassName is the Task-level RuntimeClass override, if any
+	RuntimeClassName *string
+
+	// Resources is the Task-level resources override, if any
+	Resources *corev1.ResourceRequirements
+
+	// TimeoutSeconds is the Task's timeout, its own or the namespace default, if any
+	TimeoutSeconds *int64
+
+	// ContainerName is the name of the agent container, DefaultAgentContainerName if empty
+	ContainerName string
+
+	// ResourceRequests are the agent container's requests, if the Agent auto-applies
+	// its sizing recommendation
+	ResourceRequests corev1.ResourceList
+
+	// FailureSnapshot is set when failed workspaces are archived
+	FailureSnapshot *kubetaskv1alpha1.FailureSnapshot
+
+	// Cancellation configures how the agent is stopped, if set on the Agent
+	Cancellation *kubetaskv1alpha1.AgentCancellation
+
+	// SecurityProfile is the Agent's security profile, if any. MountToken keeps the
+	// service account token mounted under the restricted profile.
+	SecurityProfile kubetaskv1alpha1.SecurityProfile
+	MountToken      bool
+
+	// Monitoring is set when the Agent enables monitoring, to expose the agent's
+	// metrics port
+	Monitoring *kubetaskv1alpha1.AgentMonitoring
+
+	// Expose is the Agent's web UI configuration, if any. The UI port is only
+	// added to human-in-the-loop Tasks.
+	Expose *kubetaskv1alpha1.AgentExpose
+
+	// JobTemplateOverrides is the Agent's strategic merge patch of the Job, if any,
+	// applied by ApplyJobTemplateOverrides
+	JobTemplateOverrides []byte
+
+	// ContextCompressionMinSize is the size from which context files are
+	// compressed, or 0 if the Agent does not enable contextCompression
+	ContextCompressionMinSize int
+
+	// ContextCacheClaim is the claim Git contexts are served from, if any
+	ContextCacheClaim string
+
+	// GitSyncImage clones Git contexts, DefaultGitSyncImage if empty
+	GitSyncImage string
+
+	// ObjectStoreMounts are the Task's ObjectStore contexts downloaded by init
+	// containers, downloaded with ObjectStoreImage (DefaultObjectStoreImage if empty)
+	ObjectStoreMounts []ObjectStoreMount
+	ObjectStoreImage  string
+
+	// OCIMounts are the Task's OCI contexts, pulled with ORASImage
+	// (DefaultORASImage if empty)
+	OCIMounts []OCIMount
+	ORASImage string
+
+	// Preamble is prepended to task.md: the cluster-wide preamble followed by
+	// the namespace's KubeTaskConfig prompt
+	Preamble string
+
+	// ResultAPIURL is the result API endpoint for the agent's reports, if served
+	ResultAPIURL string
+
+	// Canary rollout routing, set when the Agent is mid-rollout
+	AgentName     string
+	CanaryTrack   string
+	CanaryRollout string
+}
+
+// FileMount is a file of the context ConfigMap mounted at a path in the agent container
+type FileMount struct {
+	Path string
+
+	// Compressed is set when the file is stored gzipped and decompressed by an init container
+	Compressed bool
+}
+
+// DirMount is a ConfigMap mounted as a directory in the agent container
+type DirMount struct {
+	Path          string
+	ConfigMapName string
+	Optional      bool
+}
+
+// GitMount is a Git repository cloned by an init container and mounted in the agent container
+type GitMount struct {
+	ContextName string // Context name (for volume naming)
+	Repository  string // Git repository URL
+	Ref         string // Git reference (branch, tag, or commit SHA)
+	RepoPath    string // Path within the repository to mount
+	MountPath   string // Where to mount in the container
+	Depth       int    // Clone depth (1 = shallow, 0 = full)
+	SecretName  string // Optional secret name for authentication
+}
+
+// ResolvedContext is the content of a context, ready to be written to the context ConfigMap
+type ResolvedContext struct {
+	Name      string // Context name (for XML tag)
+	Namespace string // Context namespace (for XML tag)
+	Type      string // Context type (for XML tag)
+	Content   string // Resolved content
+	MountPath string // Mount path (empty = append to task.md)
+}
+
+// ConfigMapKey converts a file path to a valid ConfigMap key.
+// ConfigMap keys must be alphanumeric, '-', '_', or '.'.
+func ConfigMapKey(filePath string) string {
+	// Drop the drive letter of Windows paths and use forward slashes
+	key := strings.ReplaceAll(filePath, `\`, "/")
+	if len(key) >= 2 && key[1] == ':' {
+		key = key[2:]
+	}
+	// Remove leading slash and replace remaining slashes with dashes
+	key = strings.TrimPrefix(key, "/")
+	key = strings.ReplaceAll(key, "/", "-")
+	return key
+}
+
+// TaskResourceLabels returns the labels of the objects a Task owns: the Task's own
+// labels, as on its Pod, plus the base labels. Grouping labels such as the CronTask,
+// comparison, batch and creator of the Task thereby select its Jobs, ConfigMaps,
+// Secrets and NetworkPolicies as well, for bulk operations and dashboards.
+func TaskResourceLabels(task *kubetaskv1alpha1.Task) map[string]string {
+	labels := taskPodMetadata(task.Labels)
+	labels["app"] = "kubetask"
+	labels[TaskLabelKey] = task.Name
+	return labels
+}
+
+// jobBackoffLimit returns the backoffLimit of a Task's Job. With a retry policy the
+// controller retries by recreating the Job, so its own Pod retries are disabled and
+// the Job fails with its first failed Pod; otherwise DefaultBackoffLimit applies.
+func jobBackoffLimit(task *kubetaskv1alpha1.Task) int32 {
+	if task.Spec.RetryPolicy != nil {
+		return 0
+	}
+	return DefaultBackoffLimit
+}
+
+// boolPtr returns a pointer to the given bool value
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// shellQuote quotes a single argument for safe use in a POSIX shell script
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
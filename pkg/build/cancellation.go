@@ -0,0 +1,40 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// CancellationFile is the file under ${WORKSPACE_DIR} the command wrapper writes, with
+	// the time of cancellation, when the agent container is asked to stop
+	CancellationFile = ".kubetask-cancelled"
+
+	// DefaultCancellationGracePeriodSeconds is the Kubernetes default termination grace period
+	DefaultCancellationGracePeriodSeconds int64 = 30
+)
+
+// cancellationSteps returns the wrapper steps that run the agent command and set
+// EXIT_CODE. The wrapper is PID 1, which ignores SIGTERM without a handler, so it
+// traps the signal, writes the cancellation file, and forwards the signal to the agent
+// after signalDelay seconds. The agent's exit code is kept so the steps after it
+// (failure snapshots) still run.
+func cancellationSteps(command []string, signalDelay int64) []string {
+	forward := `kill -TERM "$KUBETASK_PID" 2>/dev/null;`
+	if signalDelay > 0 {
+		// Delay in the background, so the wrapper keeps waiting for the agent's own exit code
+		forward = fmt.Sprintf(`(sleep %d; %s) &`, signalDelay, forward)
+	}
+	return []string{
+		fmt.Sprintf(`kubetask_cancel() { date -u +%%Y-%%m-%%dT%%H:%%M:%%SZ > "$WORKSPACE_DIR/%s"; %s }`, CancellationFile, forward),
+		"trap kubetask_cancel TERM INT",
+		strings.Join(command, " ") + " & KUBETASK_PID=$!",
+		`wait "$KUBETASK_PID"; EXIT_CODE=$?`,
+		// wait returns early when the trap runs; wait again until the agent exits
+		`while kill -0 "$KUBETASK_PID" 2>/dev/null; do wait "$KUBETASK_PID"; EXIT_CODE=$?; done`,
+		// Stop right away if cancelled again, e.g. during the human-in-the-loop sleep
+		`trap 'exit $EXIT_CODE' TERM INT`,
+	}
+}
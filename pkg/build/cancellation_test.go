@@ -0,0 +1,137 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestJob_WithCancellation(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+	gracePeriod, signalDelay := int64(120), int64(60)
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		Command:            []string{"claude", "-p", "task.md"},
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Cancellation: &kubetaskv1alpha1.AgentCancellation{
+			GracePeriodSeconds: &gracePeriod,
+			SignalDelaySeconds: &signalDelay,
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+	if podSpec.TerminationGracePeriodSeconds == nil || *podSpec.TerminationGracePeriodSeconds != 120 {
+		t.Errorf("TerminationGracePeriodSeconds = %v, want 120", podSpec.TerminationGracePeriodSeconds)
+	}
+	command := podSpec.Containers[0].Command
+	if len(command) != 3 || command[0] != "sh" {
+		t.Fatalf("Command = %v, want the sh -c wrapper", command)
+	}
+	for _, want := range []string{"trap kubetask_cancel TERM INT", "claude -p task.md & KUBETASK_PID=$!", CancellationFile, "(sleep 60; kill -TERM"} {
+		if !strings.Contains(command[2], want) {
+			t.Errorf("Command script should contain %q, got: %s", want, command[2])
+		}
+	}
+	if out, err := exec.Command("sh", "-n", "-c", command[2]).CombinedOutput(); err != nil {
+		t.Errorf("Command script is not valid shell: %v: %s", err, out)
+	}
+
+	// Without cancellation settings the command runs unwrapped
+	cfg.Cancellation = nil
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if command := job.Spec.Template.Spec.Containers[0].Command; len(command) != 3 || command[0] != "claude" {
+		t.Errorf("Command = %v, want the agent command as-is", command)
+	}
+	if job.Spec.Template.Spec.TerminationGracePeriodSeconds != nil {
+		t.Errorf("TerminationGracePeriodSeconds = %v, want the Kubernetes default", *job.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	}
+}
+
+func TestCancellationSteps(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	tests := []struct {
+		name        string
+		agent       string
+		signalDelay int64
+		wantExit    int
+		wantPartial bool
+	}{
+		{
+			name:     "agent handles the signal",
+			agent:    `trap 'exit 42' TERM; while :; do sleep 0.1; done`,
+			wantExit: 42,
+		},
+		{
+			name:        "agent polls the cancellation file",
+			agent:       `trap '' TERM; while [ ! -e "$WORKSPACE_DIR/` + CancellationFile + `" ]; do sleep 0.1; done; echo partial > "$WORKSPACE_DIR/partial"; exit 3`,
+			signalDelay: 10,
+			wantExit:    3,
+			wantPartial: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace := t.TempDir()
+			agentScript := filepath.Join(workspace, "agent.sh")
+			if err := os.WriteFile(agentScript, []byte(tt.agent), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			ready := filepath.Join(workspace, "started")
+			steps := append([]string{"touch " + shellQuote(ready)}, cancellationSteps([]string{"sh", agentScript}, tt.signalDelay)...)
+			steps = append(steps, "exit $EXIT_CODE")
+
+			cmd := exec.Command("sh", "-c", strings.Join(steps, "; "))
+			cmd.Env = append(os.Environ(), "WORKSPACE_DIR="+workspace)
+			if err := cmd.Start(); err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 50; i++ {
+				if _, err := os.Stat(ready); err == nil {
+					break
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+			time.Sleep(200 * time.Millisecond) // let the agent install its trap
+			if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+				t.Fatal(err)
+			}
+
+			done := make(chan error, 1)
+			go func() { done <- cmd.Wait() }()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				_ = cmd.Process.Kill()
+				t.Fatal("wrapper did not exit after SIGTERM")
+			}
+			if code := cmd.ProcessState.ExitCode(); code != tt.wantExit {
+				t.Errorf("exit code = %d, want %d", code, tt.wantExit)
+			}
+			if _, err := os.Stat(filepath.Join(workspace, CancellationFile)); err != nil {
+				t.Errorf("cancellation file was not written: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(workspace, "partial")); (err == nil) != tt.wantPartial {
+				t.Errorf("partial result written = %v, want %v", err == nil, tt.wantPartial)
+			}
+		})
+	}
+}
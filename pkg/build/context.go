@@ -0,0 +1,149 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// ContextConfigMapSuffix is the suffix for ConfigMap names created for context
+const ContextConfigMapSuffix = "-context"
+
+// contextVolumeName is the name of the volume of the context ConfigMap in the Job
+const contextVolumeName = "context-files"
+
+// contextHashLength is the number of hex digits of the content hash in the names of
+// immutable context ConfigMaps
+const contextHashLength = 10
+
+// ContextConfigMap builds the context ConfigMap of a Task from its description and
+// resolved contexts, in task.md order, and returns the files to mount from it:
+//   - Contexts with a mountPath become files of their own
+//   - The description and the other contexts, in <context> tags, make up
+//     ${WORKSPACE_DIR}/task.md, after cfg.Preamble
+//
+// The ConfigMap is nil when there is nothing to mount. It is immutable and named
+// <task>-context-<content hash>, so the Job mounts exactly the content it was
+// created with, and a re-render with other content yields another ConfigMap. Only
+// Tasks that refresh their contexts get a mutable <task>-context ConfigMap, which
+// the controller updates in place.
+func ContextConfigMap(task *kubetaskv1alpha1.Task, cfg Config, description string, contexts []ResolvedContext) (*corev1.ConfigMap, []FileMount, error) {
+	configMapData := make(map[string]string)
+	configMapBinaryData := make(map[string][]byte)
+	var fileMounts []FileMount
+
+	// Build task.md content: description + contexts without mountPath
+	var taskMdParts []string
+	if description != "" {
+		taskMdParts = append(taskMdParts, description)
+	}
+
+	for _, rc := range contexts {
+		if rc.MountPath != "" {
+			// Context has explicit mountPath - create separate file.
+			// ConfigMap data must be UTF-8, anything else goes to binaryData.
+			configMapKey := ConfigMapKey(rc.MountPath)
+			if utf8.ValidString(rc.Content) {
+				configMapData[configMapKey] = rc.Content
+			} else {
+				configMapBinaryData[configMapKey] = []byte(rc.Content)
+			}
+			fileMounts = append(fileMounts, FileMount{Path: rc.MountPath})
+		} else if !utf8.ValidString(rc.Content) {
+			return nil, nil, fmt.Errorf("Context %q has binary content and must be referenced with a mountPath", rc.Name)
+		} else {
+			// No mountPath - append to task.md with XML tags
+			xmlTag := fmt.Sprintf("<context name=%q namespace=%q type=%q>\n%s\n</context>",
+				rc.Name, rc.Namespace, rc.Type, rc.Content)
+			taskMdParts = append(taskMdParts, xmlTag)
+		}
+	}
+
+	// Create task.md if there's any content
+	// Mount at the configured workspace directory
+	taskMdPath := WorkspacePath(cfg.WorkspaceDir, "task.md")
+	if len(taskMdParts) > 0 {
+		if cfg.Preamble != "" {
+			taskMdParts = append([]string{cfg.Preamble}, taskMdParts...)
+		}
+		taskMdContent := strings.Join(taskMdParts, "\n\n")
+		configMapData["workspace-task.md"] = taskMdContent
+		fileMounts = append(fileMounts, FileMount{Path: taskMdPath})
+	}
+
+	// Compress large files, unless the agent copies refreshed contexts from the ConfigMap
+	refreshed := ContextRefreshEnabled(task) && len(cfg.Command) > 0
+	if cfg.ContextCompressionMinSize > 0 && !refreshed {
+		if err := compressContextFiles(configMapData, configMapBinaryData, fileMounts, cfg.ContextCompressionMinSize); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Create ConfigMap if there's any content
+	if len(configMapData) == 0 && len(configMapBinaryData) == 0 {
+		return nil, fileMounts, nil
+	}
+	name := task.Name + ContextConfigMapSuffix
+	if !refreshed {
+		name += "-" + contextHash(configMapData, configMapBinaryData)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: task.Namespace,
+			Labels:    TaskResourceLabels(task),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: task.APIVersion,
+					Kind:       task.Kind,
+					Name:       task.Name,
+					UID:        task.UID,
+					Controller: boolPtr(true),
+				},
+			},
+		},
+		Data: configMapData,
+	}
+	if len(configMapBinaryData) > 0 {
+		configMap.BinaryData = configMapBinaryData
+	}
+	if !refreshed {
+		configMap.Immutable = boolPtr(true)
+	}
+	return configMap, fileMounts, nil
+}
+
+// JobContextConfigMap returns the name of the context ConfigMap a Job mounts, or ""
+func JobContextConfigMap(job *batchv1.Job) string {
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name == contextVolumeName && volume.ConfigMap != nil {
+			return volume.ConfigMap.Name
+		}
+	}
+	return ""
+}
+
+// contextHash returns the hash of the content of a context ConfigMap used in its name
+func contextHash(data map[string]string, binaryData map[string][]byte) string {
+	h := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		fmt.Fprintf(h, "data\x00%s\x00%d\x00%s", key, len(data[key]), data[key])
+	}
+	for _, key := range slices.Sorted(maps.Keys(binaryData)) {
+		fmt.Fprintf(h, "binaryData\x00%s\x00%d\x00", key, len(binaryData[key]))
+		h.Write(binaryData[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:contextHashLength]
+}
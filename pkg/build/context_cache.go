@@ -0,0 +1,53 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// contextCacheVolumeName is the name of the Job volume backed by the context cache claim
+	contextCacheVolumeName = "context-cache"
+
+	// ContextCacheMountPath is where the context cache claim is mounted in cache init containers
+	ContextCacheMountPath = "/kubetask/cache"
+)
+
+// gitCacheScript resolves the ref to a commit, fetches the commit into the cache unless
+// another Task already did, and copies it to the git-sync link the agent mounts.
+// Entries are renamed into place, so concurrent Tasks never see partial checkouts.
+const gitCacheScript = `set -e
+g() { if [ -n "$GITSYNC_USERNAME$GITSYNC_PASSWORD" ]; then git -c http.extraHeader="Authorization: Basic $(printf '%s:%s' "$GITSYNC_USERNAME" "$GITSYNC_PASSWORD" | base64 | tr -d '\n')" "$@"; else git "$@"; fi; }
+if printf '%s' "$GITSYNC_REF" | grep -Eq '^[0-9a-f]{40}$'; then commit=$GITSYNC_REF
+else commit=$(g ls-remote "$GITSYNC_REPO" "$GITSYNC_REF" | head -n 1 | cut -f 1); fi
+if [ -z "$commit" ]; then echo "unable to resolve $GITSYNC_REF in $GITSYNC_REPO" >&2; exit 1; fi
+cache="$KUBETASK_CACHE_DIR/git/$commit"
+if [ ! -d "$cache" ]; then
+  mkdir -p "$KUBETASK_CACHE_DIR/git"
+  tmp=$(mktemp -d "$KUBETASK_CACHE_DIR/git/.tmp-XXXXXX")
+  g -C "$tmp" init -q
+  g -C "$tmp" fetch -q --depth "$GITSYNC_DEPTH" "$GITSYNC_REPO" "$commit"
+  g -C "$tmp" checkout -q FETCH_HEAD
+  mv -T "$tmp" "$cache" 2>/dev/null || rm -rf "$tmp"
+  echo "cached $GITSYNC_REPO@$commit"
+fi
+mkdir -p "$GITSYNC_ROOT/$GITSYNC_LINK"
+cp -a "$cache/." "$GITSYNC_ROOT/$GITSYNC_LINK/"`
+
+// gitCacheInitContainer creates an init container that serves a Git context from
+// the content-addressed context cache, fetching it on a cache miss. It runs in the
+// git-sync image and takes the same environment as the git-sync init container.
+func gitCacheInitContainer(gm GitMount, image, volumeName string, index int) corev1.Container {
+	container := gitSyncInitContainer(gm, image, volumeName, index)
+	container.Name = fmt.Sprintf("git-cache-%d", index)
+	container.Command = []string{"sh", "-c", gitCacheScript}
+	container.Env = append(container.Env, corev1.EnvVar{Name: "KUBETASK_CACHE_DIR", Value: ContextCacheMountPath})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      contextCacheVolumeName,
+		MountPath: ContextCacheMountPath,
+	})
+	return container
+}
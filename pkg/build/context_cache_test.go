@@ -0,0 +1,130 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestJob_WithContextCache(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		ContextCacheClaim:  "context-cache",
+	}
+	gitMounts := []GitMount{{
+		ContextName: "guides",
+		Repository:  "https://github.com/org/guides.git",
+		Ref:         "main",
+		MountPath:   "/workspace/guides",
+		SecretName:  "git-credentials",
+	}}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
+	podSpec := job.Spec.Template.Spec
+
+	if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Name != "git-cache-0" {
+		t.Fatalf("InitContainers = %+v, want git-cache-0", podSpec.InitContainers)
+	}
+	initContainer := podSpec.InitContainers[0]
+	if initContainer.Image != DefaultGitSyncImage {
+		t.Errorf("Image = %q, want %q", initContainer.Image, DefaultGitSyncImage)
+	}
+	if len(initContainer.Command) != 3 || initContainer.Command[2] != gitCacheScript {
+		t.Errorf("Command = %v, want the cache script", initContainer.Command)
+	}
+	env := make(map[string]bool)
+	for _, e := range initContainer.Env {
+		env[e.Name] = true
+	}
+	for _, name := range []string{"GITSYNC_REPO", "GITSYNC_REF", "GITSYNC_USERNAME", "GITSYNC_PASSWORD", "KUBETASK_CACHE_DIR"} {
+		if !env[name] {
+			t.Errorf("init container is missing env %s", name)
+		}
+	}
+
+	var cacheVolume bool
+	for _, v := range podSpec.Volumes {
+		if v.Name == contextCacheVolumeName && v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == "context-cache" {
+			cacheVolume = true
+		}
+	}
+	if !cacheVolume {
+		t.Errorf("Volumes = %+v, want the context cache claim", podSpec.Volumes)
+	}
+	for _, m := range podSpec.Containers[0].VolumeMounts {
+		if m.Name == contextCacheVolumeName {
+			t.Errorf("agent container must not mount the context cache: %+v", m)
+		}
+	}
+
+	// Without Git contexts the claim is not mounted
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.Name == contextCacheVolumeName {
+			t.Errorf("Volumes = %+v, want no context cache without Git contexts", job.Spec.Template.Spec.Volumes)
+		}
+	}
+}
+
+func TestGitCacheScript(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	upstream := filepath.Join(dir, "upstream")
+	run := func(env []string, name string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command(name, args...)
+		cmd.Env = append(os.Environ(), env...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("%s %v: %v\n%s", name, args, err, out)
+		}
+		return string(out)
+	}
+	gitEnv := []string{"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com"}
+	run(gitEnv, "git", "init", "-q", "-b", "main", upstream)
+	if err := os.WriteFile(filepath.Join(upstream, "guide.md"), []byte("Write tests"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run(gitEnv, "git", "-C", upstream, "add", ".")
+	run(gitEnv, "git", "-C", upstream, "commit", "-q", "-m", "Add guide")
+	commit := strings.TrimSpace(run(nil, "git", "-C", upstream, "rev-parse", "HEAD"))
+
+	cacheDir := filepath.Join(dir, "cache")
+	for i, root := range []string{filepath.Join(dir, "pod-1"), filepath.Join(dir, "pod-2")} {
+		out := run([]string{
+			"GITSYNC_REPO=file://" + upstream,
+			"GITSYNC_REF=main",
+			"GITSYNC_DEPTH=1",
+			"GITSYNC_ROOT=" + root,
+			"GITSYNC_LINK=repo",
+			"KUBETASK_CACHE_DIR=" + cacheDir,
+		}, "sh", "-c", gitCacheScript)
+		if cached := strings.Contains(out, "cached "); cached != (i == 0) {
+			t.Errorf("Task %d: output = %q, want a fetch only on the first cache miss", i, out)
+		}
+		content, err := os.ReadFile(filepath.Join(root, "repo", "guide.md"))
+		if err != nil || string(content) != "Write tests" {
+			t.Errorf("Task %d: guide.md = %q, %v", i, content, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "git", commit, "guide.md")); err != nil {
+		t.Errorf("cache entry for commit %s is missing: %v", commit, err)
+	}
+}
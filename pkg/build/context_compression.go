@@ -0,0 +1,91 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultContextCompressionMinSize is the size from which context files are
+	// compressed when the Agent enables contextCompression
+	DefaultContextCompressionMinSize = 64 * 1024
+
+	// ContextDecompressContainerName is the init container that decompresses context files
+	ContextDecompressContainerName = "context-decompress"
+
+	// compressedContextSuffix is appended to the ConfigMap key of compressed files
+	compressedContextSuffix = ".gz"
+
+	// Mount paths of the compressed ConfigMap and the decompressed files in the init container
+	compressedContextMountPath   = "/kubetask/context-compressed"
+	decompressedContextMountPath = "/kubetask/context"
+
+	// decompressedContextVolumeName is the emptyDir holding decompressed context files
+	decompressedContextVolumeName = "context-decompressed"
+)
+
+// compressContextFiles moves the context files of at least minSize bytes from
+// data to binaryData as gzip, under their key with a .gz suffix, and marks their
+// mounts as compressed. Compression is skipped for files it does not shrink.
+func compressContextFiles(data map[string]string, binaryData map[string][]byte, fileMounts []FileMount, minSize int) error {
+	for i := range fileMounts {
+		key := ConfigMapKey(fileMounts[i].Path)
+		content, ok := data[key]
+		if !ok || len(content) < minSize {
+			continue
+		}
+
+		var buf bytes.Buffer
+		zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return err
+		}
+		if _, err := zw.Write([]byte(content)); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		if buf.Len() >= len(content) {
+			continue
+		}
+
+		delete(data, key)
+		binaryData[key+compressedContextSuffix] = buf.Bytes()
+		fileMounts[i].Compressed = true
+	}
+	return nil
+}
+
+// contextDecompressInitContainer builds the init container that decompresses
+// the compressed context files into the decompressed context volume. It runs the
+// agent image, which already provides a shell for the execution contract.
+func contextDecompressInitContainer(image string, fileMounts []FileMount) corev1.Container {
+	var lines []string
+	for _, mount := range fileMounts {
+		if !mount.Compressed {
+			continue
+		}
+		key := ConfigMapKey(mount.Path)
+		lines = append(lines, fmt.Sprintf("gzip -dc %s > %s",
+			shellQuote(compressedContextMountPath+"/"+key+compressedContextSuffix),
+			shellQuote(decompressedContextMountPath+"/"+key)))
+	}
+
+	return corev1.Container{
+		Name:            ContextDecompressContainerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"sh", "-c", "set -e\n" + strings.Join(lines, "\n")},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: contextVolumeName, MountPath: compressedContextMountPath, ReadOnly: true},
+			{Name: decompressedContextVolumeName, MountPath: decompressedContextMountPath},
+		},
+	}
+}
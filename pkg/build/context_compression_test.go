@@ -0,0 +1,130 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestCompressContextFiles(t *testing.T) {
+	large := strings.Repeat("kubernetes manifests compress well\n", 4096)
+	data := map[string]string{
+		"workspace-task.md":  large,
+		"workspace-small.md": "small",
+	}
+	binaryData := map[string][]byte{}
+	fileMounts := []FileMount{
+		{Path: "/workspace/task.md"},
+		{Path: "/workspace/small.md"},
+	}
+
+	if err := compressContextFiles(data, binaryData, fileMounts, 1024); err != nil {
+		t.Fatalf("compressContextFiles() error = %v", err)
+	}
+
+	if _, ok := data["workspace-task.md"]; ok {
+		t.Error("large file should be removed from data")
+	}
+	compressed, ok := binaryData["workspace-task.md.gz"]
+	if !ok {
+		t.Fatal("large file should be stored gzipped in binaryData")
+	}
+	if len(compressed) >= len(large)/10 {
+		t.Errorf("compressed size %d, expected well under %d", len(compressed), len(large))
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decompressed) != large {
+		t.Error("decompressed content does not match")
+	}
+
+	if data["workspace-small.md"] != "small" {
+		t.Error("files under the minimum size should not be compressed")
+	}
+	if !fileMounts[0].Compressed || fileMounts[1].Compressed {
+		t.Errorf("compressed flags = %v", fileMounts)
+	}
+
+	// gzip adds a header, so tiny files grow
+	data = map[string]string{"workspace-tiny.md": "tiny"}
+	fileMounts = []FileMount{{Path: "/workspace/tiny.md"}}
+	if err := compressContextFiles(data, binaryData, fileMounts, 1); err != nil {
+		t.Fatalf("compressContextFiles() error = %v", err)
+	}
+	if data["workspace-tiny.md"] != "tiny" || fileMounts[0].Compressed {
+		t.Error("files that do not shrink should not be compressed")
+	}
+}
+
+func TestJob_WithCompressedContext(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: types.UID("test-uid")},
+	}
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-task" + ContextConfigMapSuffix}}
+	fileMounts := []FileMount{
+		{Path: "/workspace/task.md", Compressed: true},
+		{Path: "/workspace/guide.md"},
+	}
+
+	job := Job(task, "test-task-job", cfg, configMap, fileMounts, nil, nil)
+	podSpec := job.Spec.Template.Spec
+
+	if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Name != ContextDecompressContainerName {
+		t.Fatalf("expected the %s init container, got %v", ContextDecompressContainerName, podSpec.InitContainers)
+	}
+	init := podSpec.InitContainers[0]
+	if init.Image != cfg.AgentImage {
+		t.Errorf("init container image = %s, want the agent image", init.Image)
+	}
+	script := init.Command[len(init.Command)-1]
+	if !strings.Contains(script, "gzip -dc '/kubetask/context-compressed/workspace-task.md.gz' > '/kubetask/context/workspace-task.md'") {
+		t.Errorf("unexpected decompress script:\n%s", script)
+	}
+	if strings.Contains(script, "guide.md") {
+		t.Error("uncompressed files should not be decompressed")
+	}
+
+	var hasEmptyDir bool
+	for _, v := range podSpec.Volumes {
+		if v.Name == decompressedContextVolumeName && v.EmptyDir != nil {
+			hasEmptyDir = true
+		}
+	}
+	if !hasEmptyDir {
+		t.Error("expected an emptyDir for decompressed files")
+	}
+
+	mounts := map[string]corev1.VolumeMount{}
+	for _, m := range podSpec.Containers[0].VolumeMounts {
+		mounts[m.MountPath] = m
+	}
+	if m := mounts["/workspace/task.md"]; m.Name != decompressedContextVolumeName || m.SubPath != "workspace-task.md" {
+		t.Errorf("task.md mount = %+v, want subPath of %s", m, decompressedContextVolumeName)
+	}
+	if m := mounts["/workspace/guide.md"]; m.Name != "context-files" {
+		t.Errorf("guide.md mount = %+v, want the context ConfigMap", m)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// ContextRefreshMountPath is where the context ConfigMap is mounted as a whole when
+	// contexts are refreshed. Unlike subPath mounts, kubelet keeps it up to date.
+	ContextRefreshMountPath = "/kubetask/context"
+
+	// ContextRefreshSentinel is the file under ${WORKSPACE_DIR} holding the time of the
+	// last context update
+	ContextRefreshSentinel = ".kubetask-context-updated"
+
+	// contextRefreshPollSeconds is how often the agent container checks the mounted
+	// ConfigMap for updates
+	contextRefreshPollSeconds = 5
+)
+
+// ContextRefreshEnabled reports whether a Task asked for live context updates
+func ContextRefreshEnabled(task *kubetaskv1alpha1.Task) bool {
+	hitl := task.Spec.HumanInTheLoop
+	return hitl != nil && hitl.Enabled && hitl.RefreshContexts
+}
+
+// contextRefreshScript returns the shell snippet that copies context files into the
+// workspace and keeps them in sync with the mounted ConfigMap in the background.
+// kubelet swaps the ..data symlink of ConfigMap volumes on every update.
+func contextRefreshScript(fileMounts []FileMount) string {
+	var copies []string
+	for _, mount := range fileMounts {
+		copies = append(copies, fmt.Sprintf("mkdir -p %s && cp %s %s",
+			shellQuote(path.Dir(mount.Path)),
+			shellQuote(path.Join(ContextRefreshMountPath, ConfigMapKey(mount.Path))),
+			shellQuote(mount.Path)))
+	}
+	data := shellQuote(path.Join(ContextRefreshMountPath, "..data"))
+	steps := []string{
+		"kubetask_sync_contexts() { " + strings.Join(copies, "; ") + "; }",
+		"kubetask_sync_contexts",
+		fmt.Sprintf(`{ (last=$(readlink %s); while sleep %d; do current=$(readlink %s); `+
+			`if [ "$current" != "$last" ]; then last=$current; kubetask_sync_contexts; `+
+			`date -u +%%Y-%%m-%%dT%%H:%%M:%%SZ > "$WORKSPACE_DIR/%s"; fi; done) & }`,
+			data, contextRefreshPollSeconds, data, ContextRefreshSentinel),
+	}
+	return strings.Join(steps, "; ")
+}
@@ -0,0 +1,88 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func newRefreshingTask() *kubetaskv1alpha1.Task {
+	description := "Fix the flaky test"
+	return &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			Description: &description,
+			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{
+				Enabled:         true,
+				RefreshContexts: true,
+			},
+		},
+	}
+}
+
+func TestJob_WithContextRefresh(t *testing.T) {
+	task := newRefreshingTask()
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Command:            []string{"gemini", "--yolo"},
+	}
+	contextConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-task-context"}}
+	fileMounts := []FileMount{{Path: "/workspace/task.md"}, {Path: "/workspace/docs/guide.md"}}
+
+	job := Job(task, "test-task-job", cfg, contextConfigMap, fileMounts, nil, nil)
+	container := job.Spec.Template.Spec.Containers[0]
+
+	// Files are copied from the whole ConfigMap instead of read-only subPath mounts
+	var refreshMount bool
+	for _, m := range container.VolumeMounts {
+		if m.SubPath != "" {
+			t.Errorf("unexpected subPath mount %+v, refreshed contexts must not use subPath", m)
+		}
+		if m.Name == "context-files" && m.MountPath == ContextRefreshMountPath {
+			refreshMount = true
+		}
+	}
+	if !refreshMount {
+		t.Errorf("context ConfigMap is not mounted at %s: %+v", ContextRefreshMountPath, container.VolumeMounts)
+	}
+
+	script := container.Command[2]
+	for _, want := range []string{
+		"cp '/kubetask/context/workspace-task.md' '/workspace/task.md'",
+		"mkdir -p '/workspace/docs' && cp '/kubetask/context/workspace-docs-guide.md' '/workspace/docs/guide.md'",
+		`"$WORKSPACE_DIR/.kubetask-context-updated"`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Command script does not contain %q: %s", want, script)
+		}
+	}
+	if syncIdx, cmdIdx := strings.Index(script, "kubetask_sync_contexts;"), strings.Index(script, "gemini --yolo"); syncIdx < 0 || syncIdx > cmdIdx {
+		t.Errorf("contexts must be synced before the agent command runs: %s", script)
+	}
+	if out, err := exec.Command("sh", "-n", "-c", script).CombinedOutput(); err != nil {
+		t.Errorf("Command script is not valid shell: %v: %s\n%s", err, out, script)
+	}
+
+	// Without a command there is nothing to copy the files, so they stay mounted
+	cfg.Command = nil
+	job = Job(task, "test-task-job", cfg, contextConfigMap, fileMounts, nil, nil)
+	if mounts := job.Spec.Template.Spec.Containers[0].VolumeMounts; len(mounts) != 3 || mounts[0].SubPath == "" {
+		t.Errorf("VolumeMounts = %+v, want subPath mounts when the Agent has no command", mounts)
+	}
+}
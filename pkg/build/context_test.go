@@ -0,0 +1,115 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestContextConfigMap(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "kubetask.io/v1alpha1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+	cfg := Config{WorkspaceDir: "/workspace", Preamble: "Respond in Japanese."}
+	contexts := []ResolvedContext{
+		{Name: "guide", Namespace: "default", Type: "Inline", Content: "Use gofmt."},
+		{Name: "policy", Namespace: "default", Type: "Inline", Content: "No force pushes.", MountPath: "/workspace/policy.md"},
+		{Name: "logo", Namespace: "default", Type: "ConfigMap", Content: "\x89PNG\xff", MountPath: "/workspace/logo.png"},
+	}
+
+	cm, fileMounts, err := ContextConfigMap(task, cfg, "Fix the bug", contexts)
+	if err != nil {
+		t.Fatalf("ContextConfigMap() error = %v", err)
+	}
+	if !strings.HasPrefix(cm.Name, "test-task"+ContextConfigMapSuffix+"-") || len(cm.Name) != len("test-task-context-")+contextHashLength || cm.Namespace != "default" {
+		t.Errorf("ConfigMap = %s/%s, want default/test-task-context-<hash>", cm.Namespace, cm.Name)
+	}
+	if cm.Immutable == nil || !*cm.Immutable {
+		t.Errorf("Immutable = %v, want true", cm.Immutable)
+	}
+	if cm.Labels[TaskLabelKey] != "test-task" {
+		t.Errorf("Labels = %v, want the task label", cm.Labels)
+	}
+	if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].UID != "test-uid" {
+		t.Errorf("OwnerReferences = %v, want the Task", cm.OwnerReferences)
+	}
+
+	want := "Respond in Japanese.\n\nFix the bug\n\n" +
+		"<context name=\"guide\" namespace=\"default\" type=\"Inline\">\nUse gofmt.\n</context>"
+	if got := cm.Data["workspace-task.md"]; got != want {
+		t.Errorf("task.md = %q, want %q", got, want)
+	}
+	if got := cm.Data["workspace-policy.md"]; got != "No force pushes." {
+		t.Errorf("policy.md = %q, want the context content", got)
+	}
+	if got := string(cm.BinaryData["workspace-logo.png"]); got != "\x89PNG\xff" {
+		t.Errorf("binaryData logo.png = %q, want the binary content", got)
+	}
+
+	var paths []string
+	for _, fm := range fileMounts {
+		paths = append(paths, fm.Path)
+	}
+	if got := strings.Join(paths, ","); got != "/workspace/policy.md,/workspace/logo.png,/workspace/task.md" {
+		t.Errorf("file mounts = %s", got)
+	}
+}
+
+func TestContextConfigMap_Revisions(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{WorkspaceDir: "/workspace", Command: []string{"agent"}}
+	render := func(description string) *corev1.ConfigMap {
+		t.Helper()
+		cm, _, err := ContextConfigMap(task, cfg, description, nil)
+		if err != nil {
+			t.Fatalf("ContextConfigMap() error = %v", err)
+		}
+		return cm
+	}
+
+	first := render("Fix the bug")
+	if again := render("Fix the bug"); again.Name != first.Name {
+		t.Errorf("same content named %q and %q, want the same name", first.Name, again.Name)
+	}
+	if changed := render("Fix the bug and the linter"); changed.Name == first.Name {
+		t.Errorf("changed content kept the name %q", first.Name)
+	}
+
+	// Refreshed contexts are updated in place
+	task.Spec.HumanInTheLoop = &kubetaskv1alpha1.HumanInTheLoop{Enabled: true, RefreshContexts: true}
+	refreshed := render("Fix the bug")
+	if refreshed.Name != "test-task"+ContextConfigMapSuffix || refreshed.Immutable != nil {
+		t.Errorf("refreshed ConfigMap = %s (immutable %v), want the mutable test-task-context", refreshed.Name, refreshed.Immutable)
+	}
+}
+
+func TestContextConfigMap_Empty(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+
+	cm, fileMounts, err := ContextConfigMap(task, Config{WorkspaceDir: "/workspace", Preamble: "Be brief."}, "", nil)
+	if err != nil {
+		t.Fatalf("ContextConfigMap() error = %v", err)
+	}
+	if cm != nil || len(fileMounts) != 0 {
+		t.Errorf("ContextConfigMap() = %v, %v, want nothing to mount", cm, fileMounts)
+	}
+}
+
+func TestContextConfigMap_BinaryWithoutMountPath(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	contexts := []ResolvedContext{{Name: "logo", Content: "\x89PNG\xff"}}
+
+	_, _, err := ContextConfigMap(task, Config{WorkspaceDir: "/workspace"}, "Fix the bug", contexts)
+	if err == nil || !strings.Contains(err.Error(), `Context "logo" has binary content`) {
+		t.Errorf("ContextConfigMap() error = %v, want the binary content error", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// podInfoVolumeName is the name of the Downward API volume of Task Pods
+	podInfoVolumeName = "podinfo"
+
+	// PodInfoMountPath is where the Downward API volume is mounted in the agent container.
+	// It holds the labels and annotations of the Pod, which include the Task's, and the
+	// agent container's resource requests and limits.
+	PodInfoMountPath = "/kubetask/podinfo"
+)
+
+// taskMetadataExcludedPrefixes are Task annotation prefixes that are not copied to Pods.
+// kubectl's last-applied-configuration would repeat the whole Task in every Pod.
+var taskMetadataExcludedPrefixes = []string{"kubectl.kubernetes.io/"}
+
+// taskPodMetadata returns the Task labels and annotations that are copied to its Pod,
+// so agents can read them through the Downward API
+func taskPodMetadata(values map[string]string) map[string]string {
+	out := make(map[string]string, len(values))
+	for k, v := range values {
+		excluded := false
+		for _, prefix := range taskMetadataExcludedPrefixes {
+			excluded = excluded || strings.HasPrefix(k, prefix)
+		}
+		if !excluded {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// podInfoEnv exposes the Pod's identity and the agent container's resources as
+// environment variables. CPU is reported in millicores and memory in bytes; without
+// a limit, the node's allocatable capacity is reported.
+func podInfoEnv() []corev1.EnvVar {
+	fieldEnv := func(name, path string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: path},
+		}}
+	}
+	resourceEnv := func(name, res, divisor string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+			ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: res, Divisor: resource.MustParse(divisor)},
+		}}
+	}
+	return []corev1.EnvVar{
+		fieldEnv("KUBETASK_POD_NAME", "metadata.name"),
+		fieldEnv("KUBETASK_NODE_NAME", "spec.nodeName"),
+		resourceEnv("KUBETASK_CPU_LIMIT", "limits.cpu", "1m"),
+		resourceEnv("KUBETASK_MEMORY_LIMIT", "limits.memory", "1"),
+		{Name: "KUBETASK_PODINFO_DIR", Value: PodInfoMountPath},
+	}
+}
+
+// podInfoVolume returns the Downward API volume with the Pod's labels and annotations
+// and the agent container's resources, one file each
+func podInfoVolume(containerName string) corev1.Volume {
+	resourceFile := func(path, res, divisor string) corev1.DownwardAPIVolumeFile {
+		return corev1.DownwardAPIVolumeFile{Path: path, ResourceFieldRef: &corev1.ResourceFieldSelector{
+			ContainerName: containerName,
+			Resource:      res,
+			Divisor:       resource.MustParse(divisor),
+		}}
+	}
+	return corev1.Volume{
+		Name: podInfoVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{
+					{Path: "labels", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+					{Path: "annotations", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"}},
+					resourceFile("cpu_request", "requests.cpu", "1m"),
+					resourceFile("cpu_limit", "limits.cpu", "1m"),
+					resourceFile("memory_request", "requests.memory", "1"),
+					resourceFile("memory_limit", "limits.memory", "1"),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// UIPortName names the agent container port of the web UI of an Agent with expose
+	UIPortName = "ui"
+
+	// UIPortEnv tells the agent which port to serve its web UI on
+	UIPortEnv = "KUBETASK_UI_PORT"
+)
+
+// ExposeEnabled reports whether the web UI of a Task's agent is exposed, which is
+// only done during human-in-the-loop sessions
+func ExposeEnabled(task *kubetaskv1alpha1.Task, expose *kubetaskv1alpha1.AgentExpose) bool {
+	hitl := task.Spec.HumanInTheLoop
+	return expose != nil && hitl != nil && hitl.Enabled
+}
+
+// uiPort returns the agent container port of an exposed web UI, and the
+// environment variable telling the agent to serve it on that port
+func uiPort(expose *kubetaskv1alpha1.AgentExpose) (corev1.ContainerPort, corev1.EnvVar) {
+	return corev1.ContainerPort{Name: UIPortName, ContainerPort: expose.Port, Protocol: corev1.ProtocolTCP},
+		corev1.EnvVar{Name: UIPortEnv, Value: strconv.Itoa(int(expose.Port))}
+}
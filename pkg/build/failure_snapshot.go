@@ -0,0 +1,39 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"path"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// FailureSnapshotVolumeName is the name of the Job volume backed by the snapshot claim
+	FailureSnapshotVolumeName = "failure-snapshot"
+
+	// FailureSnapshotMountPath is where the snapshot claim is mounted in the agent container
+	FailureSnapshotMountPath = "/kubetask/snapshots"
+
+	// TrailerSnapshot reports the path of a failure snapshot within the snapshot claim.
+	// It is written by the command wrapper, not by agents.
+	TrailerSnapshot = "KubeTask-Snapshot"
+)
+
+// failureSnapshotPath returns the path of a Task's workspace tarball within the snapshot claim
+func failureSnapshotPath(task *kubetaskv1alpha1.Task) string {
+	return fmt.Sprintf("%s/%s.tar.gz", task.Name, task.UID)
+}
+
+// failureSnapshotScript returns the shell snippet that archives ${WORKSPACE_DIR} when
+// the wrapped command left a non-zero EXIT_CODE. On success, the tarball path is appended
+// to the termination log so the controller only records snapshots that were captured.
+func failureSnapshotScript(task *kubetaskv1alpha1.Task) string {
+	snapshotPath := failureSnapshotPath(task)
+	target := path.Join(FailureSnapshotMountPath, snapshotPath)
+	return fmt.Sprintf(
+		`if [ $EXIT_CODE -ne 0 ]; then mkdir -p %s && tar -czf %s -C "$WORKSPACE_DIR" . && echo "%s: %s" >> /dev/termination-log; fi`,
+		path.Dir(target), target, TrailerSnapshot, snapshotPath,
+	)
+}
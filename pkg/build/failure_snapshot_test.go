@@ -0,0 +1,82 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestJob_WithFailureSnapshot(t *testing.T) {
+	keepAlive := int32(60)
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{
+				Enabled:          true,
+				KeepAliveSeconds: &keepAlive,
+			},
+		},
+	}
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Command:            []string{"gemini", "--yolo"},
+		FailureSnapshot:    &kubetaskv1alpha1.FailureSnapshot{Enabled: true, ClaimName: "post-mortems"},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+	container := podSpec.Containers[0]
+
+	if len(container.Command) != 3 || container.Command[0] != "sh" || container.Command[1] != "-c" {
+		t.Fatalf("Command = %v, want sh -c wrapper", container.Command)
+	}
+
+	// The snapshot must be taken before the human-in-the-loop sleep
+	script := container.Command[2]
+	snapshotIdx := strings.Index(script, "tar -czf /kubetask/snapshots/test-task/test-uid.tar.gz")
+	sleepIdx := strings.Index(script, "sleep 60")
+	if snapshotIdx < 0 || sleepIdx < 0 || snapshotIdx > sleepIdx {
+		t.Errorf("Command script should archive the workspace before sleeping, got: %s", script)
+	}
+	if !strings.HasSuffix(script, "exit $EXIT_CODE") {
+		t.Errorf("Command script should exit with the original exit code, got: %s", script)
+	}
+
+	var foundVolume bool
+	for _, v := range podSpec.Volumes {
+		if v.Name == FailureSnapshotVolumeName {
+			foundVolume = true
+			if v.PersistentVolumeClaim == nil || v.PersistentVolumeClaim.ClaimName != "post-mortems" {
+				t.Errorf("snapshot volume = %+v, want claim post-mortems", v.VolumeSource)
+			}
+		}
+	}
+	if !foundVolume {
+		t.Errorf("snapshot volume not found")
+	}
+
+	var foundMount bool
+	for _, m := range container.VolumeMounts {
+		if m.Name == FailureSnapshotVolumeName && m.MountPath == FailureSnapshotMountPath {
+			foundMount = true
+		}
+	}
+	if !foundMount {
+		t.Errorf("snapshot volume mount not found")
+	}
+}
@@ -0,0 +1,738 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// DefaultGitSyncImage is the default git-sync container image
+	DefaultGitSyncImage = "registry.k8s.io/git-sync/git-sync:v4.4.0"
+)
+
+// gitSyncInitContainer creates an init container that clones a Git repository using git-sync.
+func gitSyncInitContainer(gm GitMount, image, volumeName string, index int) corev1.Container {
+	// Set default depth to 1 (shallow clone) if not specified
+	depth := gm.Depth
+	if depth <= 0 {
+		depth = 1
+	}
+
+	// Set default ref to HEAD if not specified
+	ref := gm.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: "GITSYNC_REPO", Value: gm.Repository},
+		{Name: "GITSYNC_REF", Value: ref},
+		{Name: "GITSYNC_ONE_TIME", Value: "true"},
+		{Name: "GITSYNC_DEPTH", Value: strconv.Itoa(depth)},
+		{Name: "GITSYNC_ROOT", Value: "/git"},
+		{Name: "GITSYNC_LINK", Value: "repo"},
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{Name: volumeName, MountPath: "/git"},
+	}
+
+	// Add secret volume mount for authentication if specified
+	if gm.SecretName != "" {
+		// Mount the secret and configure git-sync to use it
+		// git-sync supports GITSYNC_USERNAME/GITSYNC_PASSWORD for HTTPS
+		// and GITSYNC_SSH_KEY_FILE for SSH
+		envVars = append(envVars,
+			corev1.EnvVar{
+				Name: "GITSYNC_USERNAME",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: gm.SecretName},
+						Key:                  "username",
+						Optional:             boolPtr(true),
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: "GITSYNC_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: gm.SecretName},
+						Key:                  "password",
+						Optional:             boolPtr(true),
+					},
+				},
+			},
+		)
+	}
+
+	return corev1.Container{
+		Name:            fmt.Sprintf("git-sync-%d", index),
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env:             envVars,
+		VolumeMounts:    volumeMounts,
+	}
+}
+
+// Job builds the Job that runs the agent of a Task. contextConfigMap is the
+// Task's context ConfigMap, if any, whose files are mounted at fileMounts.
+func Job(task *kubetaskv1alpha1.Task, jobName string, cfg Config, contextConfigMap *corev1.ConfigMap, fileMounts []FileMount, dirMounts []DirMount, gitMounts []GitMount) *batchv1.Job {
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	var envVars []corev1.EnvVar
+	var initContainers []corev1.Container
+
+	// Base environment variables
+	envVars = append(envVars,
+		corev1.EnvVar{Name: "TASK_NAME", Value: task.Name},
+		corev1.EnvVar{Name: "TASK_NAMESPACE", Value: task.Namespace},
+		corev1.EnvVar{Name: "WORKSPACE_DIR", Value: cfg.WorkspaceDir},
+	)
+
+	// Add human-in-the-loop keep-alive environment variable if enabled
+	if task.Spec.HumanInTheLoop != nil && task.Spec.HumanInTheLoop.Enabled {
+		keepAliveSeconds := DefaultKeepAliveSeconds
+		if task.Spec.HumanInTheLoop.KeepAliveSeconds != nil {
+			keepAliveSeconds = *task.Spec.HumanInTheLoop.KeepAliveSeconds
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  EnvHumanInTheLoopKeepAlive,
+			Value: strconv.Itoa(int(keepAliveSeconds)),
+		})
+	}
+
+	// envFromSources collects Agent envFrom bundles, followed by secretRef entries
+	// for mounting entire secrets, so Credentials win on conflicting keys
+	envFromSources := append([]corev1.EnvFromSource(nil), cfg.EnvFrom...)
+
+	// Add credentials (secrets as env vars or file mounts). reloadPaths collects
+	// the files of credentials that are updated in place when rotated, and
+	// reloadVolumes the index in volumes of the projected volume of each directory.
+	var reloadPaths []string
+	reloadVolumes := map[string]int{}
+	for i, cred := range cfg.Credentials {
+		// Optional credentials were checked when the Task started; a Secret deleted
+		// afterwards must not keep the Pod from starting either
+		var optional *bool
+		if cred.Optional {
+			optional = boolPtr(true)
+		}
+
+		// Check if Key is specified - determines mounting behavior
+		if cred.SecretRef.Key == nil || *cred.SecretRef.Key == "" {
+			// No key specified: mount entire secret as environment variables
+			// When mounting entire secret, Env and MountPath are ignored
+			var prefix string
+			if cred.EnvPrefix != nil {
+				prefix = *cred.EnvPrefix
+			}
+			envFromSources = append(envFromSources, corev1.EnvFromSource{
+				Prefix: prefix,
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: cred.SecretRef.Name,
+					},
+					Optional: optional,
+				},
+			})
+			continue
+		}
+
+		// Key is specified: use the existing single-key mounting behavior
+		// Add as environment variable if Env is specified
+		if cred.Env != nil && *cred.Env != "" {
+			envVars = append(envVars, corev1.EnvVar{
+				Name: *cred.Env,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: cred.SecretRef.Name,
+						},
+						Key:      *cred.SecretRef.Key,
+						Optional: optional,
+					},
+				},
+			})
+		}
+
+		// Add as file mount if MountPath is specified
+		if cred.MountPath != nil && *cred.MountPath != "" {
+			volumeName := fmt.Sprintf("credential-%d", i)
+
+			// Default file mode is 0600 (read/write for owner only)
+			var fileMode int32 = 0600
+			if cred.FileMode != nil {
+				fileMode = *cred.FileMode
+			}
+
+			// A directory that is or contains the workspace cannot be mounted over,
+			// so such credentials keep their subPath mount (see ValidateReloadCredentials)
+			if cred.ReloadOnChange && !reloadShadowsWorkspace(*cred.MountPath, cfg.WorkspaceDir) {
+				// subPath mounts are never updated, so project the key into the
+				// mount path's directory, where the kubelet swaps in rotated values.
+				// Credentials sharing a directory share its volume.
+				projection := corev1.VolumeProjection{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: cred.SecretRef.Name,
+						},
+						Items: []corev1.KeyToPath{{
+							Key:  *cred.SecretRef.Key,
+							Path: path.Base(*cred.MountPath),
+							Mode: &fileMode,
+						}},
+						Optional: optional,
+					},
+				}
+				dir := path.Dir(*cred.MountPath)
+				if index, ok := reloadVolumes[dir]; ok {
+					volumes[index].Projected.Sources = append(volumes[index].Projected.Sources, projection)
+				} else {
+					reloadVolumes[dir] = len(volumes)
+					volumes = append(volumes, corev1.Volume{
+						Name: volumeName,
+						VolumeSource: corev1.VolumeSource{
+							Projected: &corev1.ProjectedVolumeSource{
+								Sources:     []corev1.VolumeProjection{projection},
+								DefaultMode: &fileMode,
+							},
+						},
+					})
+					volumeMounts = append(volumeMounts, corev1.VolumeMount{
+						Name:      volumeName,
+						MountPath: dir,
+						ReadOnly:  true,
+					})
+				}
+				reloadPaths = append(reloadPaths, *cred.MountPath)
+				continue
+			}
+
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: cred.SecretRef.Name,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  *cred.SecretRef.Key,
+								Path: "secret-file",
+								Mode: &fileMode,
+							},
+						},
+						DefaultMode: &fileMode,
+						Optional:    optional,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: *cred.MountPath,
+				SubPath:   "secret-file",
+			})
+		}
+	}
+
+	// Add context ConfigMap volume if it exists (for aggregated content). Refreshing
+	// contexts needs the sh wrapper, so Windows agents keep their subPath mounts.
+	windows := TargetsWindows(cfg.PodSpec)
+	refreshContexts := ContextRefreshEnabled(task) && len(cfg.Command) > 0 && contextConfigMap != nil && !windows
+	if contextConfigMap != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: contextVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: contextConfigMap.Name,
+					},
+				},
+			},
+		})
+
+		// Refreshed contexts are copied into place by the agent command from the whole
+		// ConfigMap, which kubelet keeps up to date (subPath mounts never change)
+		subPathMounts := fileMounts
+		if refreshContexts {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      contextVolumeName,
+				MountPath: ContextRefreshMountPath,
+				ReadOnly:  true,
+			})
+			subPathMounts = nil
+		}
+
+		// Add volume mounts for each file path
+		decompress := false
+		for _, mount := range subPathMounts {
+			configMapKey := ConfigMapKey(mount.Path)
+			volumeName := contextVolumeName
+			if mount.Compressed {
+				volumeName = decompressedContextVolumeName
+				decompress = true
+			}
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mount.Path,
+				SubPath:   configMapKey,
+			})
+		}
+
+		// Decompress gzipped files into an emptyDir before the agent starts
+		if decompress {
+			volumes = append(volumes, corev1.Volume{
+				Name:         decompressedContextVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			})
+			initContainers = append(initContainers, contextDecompressInitContainer(cfg.AgentImage, subPathMounts))
+		}
+	}
+
+	// Add directory mounts (ConfigMapRef - entire ConfigMap as a directory)
+	for i, dm := range dirMounts {
+		volumeName := fmt.Sprintf("dir-mount-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: dm.ConfigMapName,
+					},
+					Optional: &dm.Optional,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: dm.Path,
+		})
+	}
+
+	// Add Git context mounts (using git-sync init containers)
+	for i, gm := range gitMounts {
+		volumeName := fmt.Sprintf("git-context-%d", i)
+
+		// Add emptyDir volume for git content
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+
+		// Build init container for git-sync, or serve the repository from the context cache
+		gitSyncImage := cfg.GitSyncImage
+		if gitSyncImage == "" {
+			gitSyncImage = DefaultGitSyncImage
+		}
+		if cfg.ContextCacheClaim != "" {
+			initContainers = append(initContainers, gitCacheInitContainer(gm, gitSyncImage, volumeName, i))
+		} else {
+			initContainers = append(initContainers, gitSyncInitContainer(gm, gitSyncImage, volumeName, i))
+		}
+
+		// Add volume mount to agent container
+		// If repoPath is specified, use subPath to mount only that path
+		subPath := "repo"
+		if gm.RepoPath != "" {
+			subPath = "repo/" + strings.TrimPrefix(gm.RepoPath, "/")
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: gm.MountPath,
+			SubPath:   subPath,
+		})
+	}
+
+	// Mount the context cache claim shared by the git-cache init containers
+	if cfg.ContextCacheClaim != "" && len(gitMounts) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: contextCacheVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: cfg.ContextCacheClaim,
+				},
+			},
+		})
+	}
+
+	// Add ObjectStore context mounts (using rclone init containers)
+	for i, om := range cfg.ObjectStoreMounts {
+		volumeName := fmt.Sprintf("object-store-context-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		objectStoreImage := cfg.ObjectStoreImage
+		if objectStoreImage == "" {
+			objectStoreImage = DefaultObjectStoreImage
+		}
+		initContainers = append(initContainers, objectStoreInitContainer(om, objectStoreImage, volumeName, i))
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: om.MountPath,
+			SubPath:   "data",
+		})
+	}
+
+	// Add OCI context mounts (using oras init containers)
+	for i, om := range cfg.OCIMounts {
+		volumeName := fmt.Sprintf("oci-context-%d", i)
+		authVolumeName := fmt.Sprintf("oci-auth-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		if om.PullSecretName != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: authVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: om.PullSecretName,
+						Items:      []corev1.KeyToPath{{Key: corev1.DockerConfigJsonKey, Path: corev1.DockerConfigJsonKey}},
+					},
+				},
+			})
+		}
+		orasImage := cfg.ORASImage
+		if orasImage == "" {
+			orasImage = DefaultORASImage
+		}
+		initContainers = append(initContainers, ociInitContainer(om, orasImage, volumeName, authVolumeName, i))
+
+		// If path is specified, use subPath to mount only that path
+		subPath := "data"
+		if om.Path != "" {
+			subPath = "data/" + strings.TrimPrefix(om.Path, "/")
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: om.MountPath,
+			SubPath:   subPath,
+		})
+	}
+
+	containerName := cfg.ContainerName
+	if containerName == "" {
+		containerName = DefaultAgentContainerName
+	}
+
+	// Build pod labels and annotations, lowest priority first: the Task's (readable
+	// by the agent through the Downward API), then Agent.PodSpec, then the base labels
+	podLabels := taskPodMetadata(task.Labels)
+	podAnnotations := taskPodMetadata(task.Annotations)
+	if cfg.PodSpec != nil {
+		for k, v := range cfg.PodSpec.Labels {
+			podLabels[k] = v
+		}
+		for k, v := range cfg.PodSpec.Annotations {
+			podAnnotations[k] = v
+		}
+	}
+	podLabels["app"] = "kubetask"
+	podLabels[TaskLabelKey] = task.Name
+	// Name the agent container for kubectl and for status reporting
+	podAnnotations[DefaultContainerAnnotation] = containerName
+	// Tell the agent which credential files to re-read when they change
+	if len(reloadPaths) > 0 {
+		podAnnotations[ReloadCredentialsAnnotation] = strings.Join(reloadPaths, ",")
+	}
+
+	// Expose Pod metadata and the agent container's resources through the Downward API
+	envVars = append(envVars, podInfoEnv()...)
+	if cfg.ResultAPIURL != "" {
+		envVars = append(envVars, resultAPIEnv(task, cfg.ResultAPIURL)...)
+	}
+
+	// Expose the metrics endpoint of monitored Agents, and label their Pods for the
+	// Agent's PodMonitor
+	var ports []corev1.ContainerPort
+	if cfg.Monitoring != nil {
+		port, env := metricsPort(cfg.Monitoring)
+		ports = append(ports, port)
+		envVars = append(envVars, env)
+		podLabels[AgentLabelKey] = cfg.AgentName
+	}
+	if ExposeEnabled(task, cfg.Expose) {
+		port, env := uiPort(cfg.Expose)
+		ports = append(ports, port)
+		envVars = append(envVars, env)
+	}
+	volumes = append(volumes, podInfoVolume(containerName))
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{
+		Name:      podInfoVolumeName,
+		MountPath: PodInfoMountPath,
+		ReadOnly:  true,
+	})
+
+	// Build agent container
+	agentContainer := corev1.Container{
+		Name:            containerName,
+		Image:           cfg.AgentImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env:             envVars,
+		EnvFrom:         envFromSources,
+		Ports:           ports,
+		VolumeMounts:    volumeMounts,
+	}
+	agentContainer.Resources = agentResources(cfg)
+
+	// Apply command if specified
+	if len(cfg.Command) > 0 {
+		humanInTheLoop := task.Spec.HumanInTheLoop != nil && task.Spec.HumanInTheLoop.Enabled
+		if windows {
+			// Windows images have no sh; only human-in-the-loop wraps the command there,
+			// as the Agent cannot enable failure snapshots or delayed cancellation signals
+			agentContainer.Command = cfg.Command
+			if humanInTheLoop {
+				keepAliveSeconds := DefaultKeepAliveSeconds
+				if task.Spec.HumanInTheLoop.KeepAliveSeconds != nil {
+					keepAliveSeconds = *task.Spec.HumanInTheLoop.KeepAliveSeconds
+				}
+				agentContainer.Command = windowsHumanInTheLoopCommand(cfg.Command, keepAliveSeconds)
+			}
+		} else if humanInTheLoop || cfg.FailureSnapshot != nil || cfg.Cancellation != nil {
+			// Build the wrapped command that runs the original command, then archives the
+			// workspace on failure and/or sleeps for human-in-the-loop
+			// Format: sh -c '[context sync]; trap ...; original_command & wait; [snapshot]; [echo "Human-in-the-loop: ..."; sleep N]; exit $EXIT_CODE'
+			var steps []string
+			if refreshContexts {
+				steps = append(steps, contextRefreshScript(fileMounts))
+			}
+			var signalDelay int64
+			if cfg.Cancellation != nil && cfg.Cancellation.SignalDelaySeconds != nil {
+				signalDelay = *cfg.Cancellation.SignalDelaySeconds
+			}
+			steps = append(steps, cancellationSteps(cfg.Command, signalDelay)...)
+			if cfg.FailureSnapshot != nil {
+				steps = append(steps, failureSnapshotScript(task))
+			}
+			if humanInTheLoop {
+				keepAliveSeconds := DefaultKeepAliveSeconds
+				if task.Spec.HumanInTheLoop.KeepAliveSeconds != nil {
+					keepAliveSeconds = *task.Spec.HumanInTheLoop.KeepAliveSeconds
+				}
+				steps = append(steps, fmt.Sprintf(
+					`echo "Human-in-the-loop: keeping container alive for %d seconds. Use 'kubectl exec' to access."; sleep %d & wait $!`,
+					keepAliveSeconds, keepAliveSeconds,
+				))
+			}
+			steps = append(steps, "exit $EXIT_CODE")
+			agentContainer.Command = []string{"sh", "-c", strings.Join(steps, "; ")}
+		} else {
+			// No wrapping needed, use command as-is
+			agentContainer.Command = cfg.Command
+		}
+	}
+
+	// Mount the snapshot claim that receives failed workspaces
+	if cfg.FailureSnapshot != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: FailureSnapshotVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: cfg.FailureSnapshot.ClaimName,
+				},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      FailureSnapshotVolumeName,
+			MountPath: FailureSnapshotMountPath,
+		})
+	}
+
+	// Build PodSpec with scheduling configuration
+	podSpec := corev1.PodSpec{
+		ServiceAccountName: cfg.ServiceAccountName,
+		InitContainers:     initContainers,
+		Containers:         []corev1.Container{agentContainer},
+		Volumes:            volumes,
+		RestartPolicy:      corev1.RestartPolicyNever,
+	}
+	if cfg.Cancellation != nil && cfg.Cancellation.GracePeriodSeconds != nil {
+		podSpec.TerminationGracePeriodSeconds = cfg.Cancellation.GracePeriodSeconds
+	}
+
+	// Apply PodSpec configuration if specified
+	if cfg.PodSpec != nil {
+		// Apply scheduling configuration
+		if cfg.PodSpec.Scheduling != nil {
+			if cfg.PodSpec.Scheduling.NodeSelector != nil {
+				podSpec.NodeSelector = cfg.PodSpec.Scheduling.NodeSelector
+			}
+			if cfg.PodSpec.Scheduling.Tolerations != nil {
+				podSpec.Tolerations = cfg.PodSpec.Scheduling.Tolerations
+			}
+			if cfg.PodSpec.Scheduling.Affinity != nil {
+				podSpec.Affinity = cfg.PodSpec.Scheduling.Affinity
+			}
+			if cfg.PodSpec.Scheduling.TopologySpreadConstraints != nil {
+				podSpec.TopologySpreadConstraints = cfg.PodSpec.Scheduling.TopologySpreadConstraints
+			}
+		}
+
+		// Apply runtime class if specified (for gVisor, Kata, etc.)
+		if cfg.PodSpec.RuntimeClassName != nil {
+			podSpec.RuntimeClassName = cfg.PodSpec.RuntimeClassName
+		}
+
+		// Apply DNS configuration (for air-gapped or custom resolver setups)
+		if cfg.PodSpec.DNSPolicy != nil {
+			podSpec.DNSPolicy = *cfg.PodSpec.DNSPolicy
+		}
+		if cfg.PodSpec.DNSConfig != nil {
+			podSpec.DNSConfig = cfg.PodSpec.DNSConfig
+		}
+		if cfg.PodSpec.HostAliases != nil {
+			podSpec.HostAliases = cfg.PodSpec.HostAliases
+		}
+
+		// Apply restart policy (validated in getAgentConfig)
+		if cfg.PodSpec.RestartPolicy != nil {
+			podSpec.RestartPolicy = *cfg.PodSpec.RestartPolicy
+		}
+	}
+
+	jobLabels := TaskResourceLabels(task)
+
+	// Label canary rollout Jobs so the Agent controller can compare failure rates
+	if cfg.CanaryTrack != "" {
+		jobLabels[AgentLabelKey] = cfg.AgentName
+		jobLabels[CanaryTrackLabelKey] = cfg.CanaryTrack
+		jobLabels[CanaryRolloutLabelKey] = cfg.CanaryRollout
+	}
+
+	// Task-level RuntimeClass override (checked against the runtime policy in getAgentConfig)
+	if cfg.RuntimeClassName != nil {
+		podSpec.RuntimeClassName = cfg.RuntimeClassName
+	}
+
+	ApplySecurityProfile(&podSpec, cfg.SecurityProfile, cfg.MountToken)
+	if cfg.SecurityProfile != "" {
+		podLabels[SecurityProfileLabelKey] = string(cfg.SecurityProfile)
+	}
+
+	backoffLimit := jobBackoffLimit(task)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: task.Namespace,
+			Labels:    jobLabels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: task.APIVersion,
+					Kind:       task.Kind,
+					Name:       task.Name,
+					UID:        task.UID,
+					Controller: boolPtr(true),
+				},
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// The Job controller stops the agent at the Task's timeout
+			ActiveDeadlineSeconds: cfg.TimeoutSeconds,
+			BackoffLimit:          &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+// agentResources returns the resources of the agent container: the Agent's
+// podSpec.resources, then the requests of its auto-applied sizing recommendation and
+// the Task's override, each replacing the previous ones per resource. A request
+// above the resulting limit is lowered to it, as the API server would reject the Job.
+func agentResources(cfg Config) corev1.ResourceRequirements {
+	var resources corev1.ResourceRequirements
+	if cfg.PodSpec != nil && cfg.PodSpec.Resources != nil {
+		resources = *cfg.PodSpec.Resources.DeepCopy()
+	}
+	resources.Requests = mergeResourceList(resources.Requests, cfg.ResourceRequests)
+	if task := cfg.Resources; task != nil {
+		resources.Requests = mergeResourceList(resources.Requests, task.Requests)
+		resources.Limits = mergeResourceList(resources.Limits, task.Limits)
+		if len(task.Claims) > 0 {
+			resources.Claims = task.Claims
+		}
+	}
+	for name, request := range resources.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			resources.Requests[name] = limit.DeepCopy()
+		}
+	}
+	return resources
+}
+
+// mergeResourceList returns base with the quantities of override replacing its own
+func mergeResourceList(base, override corev1.ResourceList) corev1.ResourceList {
+	if len(override) == 0 {
+		return base
+	}
+	merged := corev1.ResourceList{}
+	for name, quantity := range base {
+		merged[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range override {
+		merged[name] = quantity.DeepCopy()
+	}
+	return merged
+}
+
+// ValidateReloadCredentials returns an error naming each credential with
+// reloadOnChange whose projected volume would be mounted over workspaceDir or a
+// directory containing it, and each one mounted at the same path as another.
+// Job gives the former a subPath mount instead, which is not updated on rotation.
+func ValidateReloadCredentials(credentials []kubetaskv1alpha1.Credential, workspaceDir string) error {
+	if workspaceDir == "" {
+		workspaceDir = DefaultWorkspaceDir
+	}
+
+	var problems []string
+	mountPaths := map[string]string{}
+	for _, cred := range credentials {
+		if !cred.ReloadOnChange || cred.MountPath == nil || *cred.MountPath == "" {
+			continue
+		}
+		mountPath := path.Clean(*cred.MountPath)
+		if reloadShadowsWorkspace(mountPath, workspaceDir) {
+			problems = append(problems, fmt.Sprintf("credential %q: directory %s of mountPath would hide the workspace %s", cred.Name, path.Dir(mountPath), workspaceDir))
+		}
+		if other, ok := mountPaths[mountPath]; ok {
+			problems = append(problems, fmt.Sprintf("credential %q: mountPath %s is used by credential %q", cred.Name, mountPath, other))
+			continue
+		}
+		mountPaths[mountPath] = cred.Name
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid reloadOnChange credentials: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// reloadShadowsWorkspace reports whether the directory of mountPath, which a
+// reloadOnChange credential's projected volume occupies, is workspaceDir or one
+// of its parents
+func reloadShadowsWorkspace(mountPath, workspaceDir string) bool {
+	dir := path.Dir(path.Clean(mountPath))
+	workspaceDir = path.Clean(workspaceDir)
+	return dir == workspaceDir || strings.HasPrefix(workspaceDir, strings.TrimSuffix(dir, "/")+"/")
+}
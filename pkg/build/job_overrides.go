@@ -0,0 +1,206 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// forbiddenOverridePodFields are the fields of spec.template.spec job template
+// overrides must not set: they escape the node or the security profile, or are
+// owned by structured Agent fields the controller relies on
+var forbiddenOverridePodFields = map[string]bool{
+	"hostNetwork":                  true,
+	"hostPID":                      true,
+	"hostIPC":                      true,
+	"hostUsers":                    true,
+	"nodeName":                     true,
+	"securityContext":              true,
+	"serviceAccount":               true,
+	"serviceAccountName":           true,
+	"automountServiceAccountToken": true,
+	"restartPolicy":                true,
+}
+
+// forbiddenOverrideAgentFields are the fields of the agent container job template
+// overrides must not set, since the controller wraps and configures the command
+var forbiddenOverrideAgentFields = []string{"image", "command", "args"}
+
+// ValidateJobTemplateOverrides returns an error naming each field of an Agent's
+// jobTemplateOverrides the controller does not let it change. agentContainer is
+// the name of the agent container, DefaultAgentContainerName if empty.
+func ValidateJobTemplateOverrides(overrides []byte, agentContainer string) error {
+	var patch map[string]any
+	if err := json.Unmarshal(overrides, &patch); err != nil {
+		return fmt.Errorf("jobTemplateOverrides must be an object: %w", err)
+	}
+	if agentContainer == "" {
+		agentContainer = DefaultAgentContainerName
+	}
+
+	var forbidden []string
+	// Patch directives such as $patch: replace could drop the controller's containers
+	// and volumes
+	forbidden = append(forbidden, overrideDirectives(patch, "")...)
+
+	for key, value := range patch {
+		switch key {
+		case "metadata":
+			forbidden = append(forbidden, overrideMetadata(value, key)...)
+		case "spec":
+			spec, _ := value.(map[string]any)
+			for key, value := range spec {
+				if key != "template" {
+					forbidden = append(forbidden, "spec."+key)
+					continue
+				}
+				template, _ := value.(map[string]any)
+				for key, value := range template {
+					switch key {
+					case "metadata":
+						forbidden = append(forbidden, overrideMetadata(value, "spec.template.metadata")...)
+					case "spec":
+						forbidden = append(forbidden, overridePodSpec(value, agentContainer)...)
+					default:
+						forbidden = append(forbidden, "spec.template."+key)
+					}
+				}
+			}
+		default:
+			forbidden = append(forbidden, key)
+		}
+	}
+	if len(forbidden) == 0 {
+		return nil
+	}
+	sort.Strings(forbidden)
+	return fmt.Errorf("jobTemplateOverrides must not set %s", strings.Join(forbidden, ", "))
+}
+
+// overrideDirectives returns the paths of strategic merge patch directives in value
+func overrideDirectives(value any, path string) []string {
+	var found []string
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if strings.HasPrefix(key, "$") {
+				found = append(found, childPath)
+				continue
+			}
+			found = append(found, overrideDirectives(child, childPath)...)
+		}
+	case []any:
+		for i, child := range v {
+			found = append(found, overrideDirectives(child, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return found
+}
+
+// overrideMetadata returns the forbidden fields of a metadata override: anything
+// but labels and annotations, and reserved keys
+func overrideMetadata(value any, path string) []string {
+	metadata, _ := value.(map[string]any)
+	var forbidden []string
+	for key, value := range metadata {
+		if key != "labels" && key != "annotations" {
+			forbidden = append(forbidden, path+"."+key)
+			continue
+		}
+		entries, _ := value.(map[string]any)
+		for name := range entries {
+			if reservedOverrideKey(name) {
+				forbidden = append(forbidden, fmt.Sprintf("%s.%s[%s]", path, key, name))
+			}
+		}
+	}
+	return forbidden
+}
+
+// reservedOverrideKey reports whether a label or annotation is set by the
+// controller or the Job controller, e.g. the Task label NetworkPolicies select
+func reservedOverrideKey(name string) bool {
+	prefix, _, found := strings.Cut(name, "/")
+	if !found {
+		return name == "job-name" || name == "controller-uid"
+	}
+	return prefix == "kubetask.io" || strings.HasSuffix(prefix, ".kubetask.io") ||
+		prefix == "batch.kubernetes.io"
+}
+
+// overridePodSpec returns the forbidden fields of a spec.template.spec override
+func overridePodSpec(value any, agentContainer string) []string {
+	const path = "spec.template.spec"
+	podSpec, _ := value.(map[string]any)
+	var forbidden []string
+	for key, value := range podSpec {
+		if forbiddenOverridePodFields[key] {
+			forbidden = append(forbidden, path+"."+key)
+			continue
+		}
+		items, _ := value.([]any)
+		switch key {
+		case "volumes":
+			for _, item := range items {
+				volume, _ := item.(map[string]any)
+				if _, ok := volume["hostPath"]; ok {
+					forbidden = append(forbidden, fmt.Sprintf("%s.volumes[%v].hostPath", path, volume["name"]))
+				}
+			}
+		case "containers", "initContainers", "ephemeralContainers":
+			for _, item := range items {
+				container, _ := item.(map[string]any)
+				name := container["name"]
+				if _, ok := container["securityContext"]; ok {
+					forbidden = append(forbidden, fmt.Sprintf("%s.%s[%v].securityContext", path, key, name))
+				}
+				if key != "containers" || name != agentContainer {
+					continue
+				}
+				for _, field := range forbiddenOverrideAgentFields {
+					if _, ok := container[field]; ok {
+						forbidden = append(forbidden, fmt.Sprintf("%s.%s[%v].%s", path, key, name, field))
+					}
+				}
+			}
+		}
+	}
+	return forbidden
+}
+
+// ApplyJobTemplateOverrides patches job with the Agent's jobTemplateOverrides, if
+// any, after checking them with ValidateJobTemplateOverrides. The security profile
+// is applied again, so containers added by the overrides are hardened as well.
+func ApplyJobTemplateOverrides(job *batchv1.Job, cfg Config) error {
+	if len(cfg.JobTemplateOverrides) == 0 {
+		return nil
+	}
+	if err := ValidateJobTemplateOverrides(cfg.JobTemplateOverrides, cfg.ContainerName); err != nil {
+		return err
+	}
+	original, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, cfg.JobTemplateOverrides, batchv1.Job{})
+	if err != nil {
+		return fmt.Errorf("unable to apply jobTemplateOverrides: %w", err)
+	}
+	result := &batchv1.Job{}
+	if err := json.Unmarshal(patched, result); err != nil {
+		return fmt.Errorf("unable to apply jobTemplateOverrides: %w", err)
+	}
+	ApplySecurityProfile(&result.Spec.Template.Spec, cfg.SecurityProfile, cfg.MountToken)
+	*job = *result
+	return nil
+}
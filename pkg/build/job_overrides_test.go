@@ -0,0 +1,136 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestValidateJobTemplateOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides string
+		container string
+		want      string
+	}{
+		{
+			name: "volumes, containers and labels",
+			overrides: `{"metadata": {"labels": {"team": "platform"}}, "spec": {"template": {
+				"metadata": {"annotations": {"example.com/owner": "platform"}},
+				"spec": {"volumes": [{"name": "fuse", "csi": {"driver": "fuse.example.com"}}],
+					"containers": [{"name": "agent", "volumeMounts": [{"name": "fuse", "mountPath": "/mnt/fuse"}]},
+						{"name": "proxy", "image": "proxy:v1"}]}}}}`,
+		},
+		{name: "not an object", overrides: `["spec"]`, want: "must be an object"},
+		{name: "job fields", overrides: `{"status": {}, "spec": {"backoffLimit": 3}}`, want: "spec.backoffLimit, status"},
+		{name: "job metadata", overrides: `{"metadata": {"name": "other"}}`, want: "metadata.name"},
+		{
+			name:      "reserved labels",
+			overrides: `{"spec": {"template": {"metadata": {"labels": {"kubetask.io/task": "other", "job-name": "other"}}}}}`,
+			want:      "spec.template.metadata.labels[job-name], spec.template.metadata.labels[kubetask.io/task]",
+		},
+		{
+			name:      "pod fields",
+			overrides: `{"spec": {"template": {"spec": {"hostNetwork": true, "serviceAccountName": "admin"}}}}`,
+			want:      "spec.template.spec.hostNetwork, spec.template.spec.serviceAccountName",
+		},
+		{
+			name:      "hostPath volume",
+			overrides: `{"spec": {"template": {"spec": {"volumes": [{"name": "docker", "hostPath": {"path": "/var/run/docker.sock"}}]}}}}`,
+			want:      "spec.template.spec.volumes[docker].hostPath",
+		},
+		{
+			name:      "container securityContext",
+			overrides: `{"spec": {"template": {"spec": {"initContainers": [{"name": "setup", "securityContext": {"privileged": true}}]}}}}`,
+			want:      "spec.template.spec.initContainers[setup].securityContext",
+		},
+		{
+			name:      "agent command",
+			overrides: `{"spec": {"template": {"spec": {"containers": [{"name": "agent", "command": ["sh"]}, {"name": "proxy", "command": ["proxy"]}]}}}}`,
+			want:      "spec.template.spec.containers[agent].command",
+		},
+		{
+			name:      "custom agent container name",
+			overrides: `{"spec": {"template": {"spec": {"containers": [{"name": "claude", "image": "other:v1"}]}}}}`,
+			container: "claude",
+			want:      "spec.template.spec.containers[claude].image",
+		},
+		{
+			name:      "patch directives",
+			overrides: `{"spec": {"template": {"spec": {"containers": [{"name": "agent", "$patch": "delete"}]}}}}`,
+			want:      "spec.template.spec.containers[0].$patch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJobTemplateOverrides([]byte(tt.overrides), tt.container)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateJobTemplateOverrides() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidateJobTemplateOverrides() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyJobTemplateOverrides(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: types.UID("test-uid")}}
+	cfg := Config{
+		AgentImage:      "test-agent:v1.0.0",
+		WorkspaceDir:    "/workspace",
+		SecurityProfile: kubetaskv1alpha1.SecurityProfileRestricted,
+		JobTemplateOverrides: []byte(`{"metadata": {"labels": {"team": "platform"}}, "spec": {"template": {"spec": {
+			"volumes": [{"name": "fuse", "csi": {"driver": "fuse.example.com"}}],
+			"containers": [{"name": "agent", "volumeMounts": [{"name": "fuse", "mountPath": "/mnt/fuse"}]},
+				{"name": "proxy", "image": "proxy:v1"}]}}}}`),
+	}
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if err := ApplyJobTemplateOverrides(job, cfg); err != nil {
+		t.Fatalf("ApplyJobTemplateOverrides() error = %v", err)
+	}
+
+	if job.Name != "test-task-job" || job.Labels[TaskLabelKey] != "test-task" || job.Labels["team"] != "platform" {
+		t.Errorf("Job %s labels = %v, want the Task labels and the override", job.Name, job.Labels)
+	}
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.Containers) != 2 {
+		t.Fatalf("containers = %d, want the agent and the proxy", len(podSpec.Containers))
+	}
+	agent, proxy := podSpec.Containers[0], podSpec.Containers[1]
+	if agent.Image != "test-agent:v1.0.0" || !hasVolumeMount(agent, "fuse") {
+		t.Errorf("agent container = %+v, want its image and the fuse mount", agent)
+	}
+	if proxy.SecurityContext == nil || proxy.SecurityContext.AllowPrivilegeEscalation == nil || *proxy.SecurityContext.AllowPrivilegeEscalation {
+		t.Errorf("proxy securityContext = %+v, want it hardened by the security profile", proxy.SecurityContext)
+	}
+	if podSpec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("restartPolicy = %s, want Never", podSpec.RestartPolicy)
+	}
+
+	cfg.JobTemplateOverrides = []byte(`{"spec": {"template": {"spec": {"hostPID": true}}}}`)
+	if err := ApplyJobTemplateOverrides(job, cfg); err == nil || !strings.Contains(err.Error(), "hostPID") {
+		t.Errorf("ApplyJobTemplateOverrides() error = %v, want hostPID rejected", err)
+	}
+}
+
+func hasVolumeMount(container corev1.Container, name string) bool {
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1839 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestConfigMapKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     string
+	}{
+		{
+			name:     "simple path",
+			filePath: "/workspace/task.md",
+			want:     "workspace-task.md",
+		},
+		{
+			name:     "nested path",
+			filePath: "/workspace/guides/standards.md",
+			want:     "workspace-guides-standards.md",
+		},
+		{
+			name:     "deeply nested path",
+			filePath: "/home/agent/.config/settings.json",
+			want:     "home-agent-.config-settings.json",
+		},
+		{
+			name:     "no leading slash",
+			filePath: "workspace/task.md",
+			want:     "workspace-task.md",
+		},
+		{
+			name:     "single file",
+			filePath: "/task.md",
+			want:     "task.md",
+		},
+		{
+			name:     "windows path",
+			filePath: `C:\workspace\guides\standards.md`,
+			want:     "workspace-guides-standards.md",
+		},
+		{
+			name:     "empty string",
+			filePath: "",
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConfigMapKey(tt.filePath)
+			if got != tt.want {
+				t.Errorf("ConfigMapKey(%q) = %q, want %q", tt.filePath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoolPtr(t *testing.T) {
+	trueVal := boolPtr(true)
+	if trueVal == nil || *trueVal != true {
+		t.Errorf("boolPtr(true) = %v, want *true", trueVal)
+	}
+
+	falseVal := boolPtr(false)
+	if falseVal == nil || *falseVal != false {
+		t.Errorf("boolPtr(false) = %v, want *false", falseVal)
+	}
+}
+
+func TestJob_BasicTask(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	// Verify job metadata
+	if job.Name != "test-task-job" {
+		t.Errorf("Job.Name = %q, want %q", job.Name, "test-task-job")
+	}
+	if job.Namespace != "default" {
+		t.Errorf("Job.Namespace = %q, want %q", job.Namespace, "default")
+	}
+
+	// Verify labels
+	if job.Labels["app"] != "kubetask" {
+		t.Errorf("Job.Labels[app] = %q, want %q", job.Labels["app"], "kubetask")
+	}
+	if job.Labels["kubetask.io/task"] != "test-task" {
+		t.Errorf("Job.Labels[kubetask.io/task] = %q, want %q", job.Labels["kubetask.io/task"], "test-task")
+	}
+
+	// Verify owner reference
+	if len(job.OwnerReferences) != 1 {
+		t.Fatalf("len(Job.OwnerReferences) = %d, want 1", len(job.OwnerReferences))
+	}
+	ownerRef := job.OwnerReferences[0]
+	if ownerRef.Name != "test-task" {
+		t.Errorf("OwnerReference.Name = %q, want %q", ownerRef.Name, "test-task")
+	}
+	if ownerRef.Controller == nil || *ownerRef.Controller != true {
+		t.Errorf("OwnerReference.Controller = %v, want true", ownerRef.Controller)
+	}
+
+	// Verify container
+	if len(job.Spec.Template.Spec.Containers) != 1 {
+		t.Fatalf("len(Containers) = %d, want 1", len(job.Spec.Template.Spec.Containers))
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Name != "agent" {
+		t.Errorf("Container.Name = %q, want %q", container.Name, "agent")
+	}
+	if container.Image != "test-agent:v1.0.0" {
+		t.Errorf("Container.Image = %q, want %q", container.Image, "test-agent:v1.0.0")
+	}
+
+	// Verify default restart policy
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("RestartPolicy = %q, want %q", job.Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyNever)
+	}
+
+	// Verify environment variables
+	envMap := make(map[string]string)
+	for _, env := range container.Env {
+		envMap[env.Name] = env.Value
+	}
+	if envMap["TASK_NAME"] != "test-task" {
+		t.Errorf("Env[TASK_NAME] = %q, want %q", envMap["TASK_NAME"], "test-task")
+	}
+	if envMap["TASK_NAMESPACE"] != "default" {
+		t.Errorf("Env[TASK_NAMESPACE] = %q, want %q", envMap["TASK_NAMESPACE"], "default")
+	}
+	if envMap["WORKSPACE_DIR"] != "/workspace" {
+		t.Errorf("Env[WORKSPACE_DIR] = %q, want %q", envMap["WORKSPACE_DIR"], "/workspace")
+	}
+
+	// Verify service account
+	if job.Spec.Template.Spec.ServiceAccountName != "test-sa" {
+		t.Errorf("ServiceAccountName = %q, want %q", job.Spec.Template.Spec.ServiceAccountName, "test-sa")
+	}
+
+	// Verify restart policy
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("RestartPolicy = %q, want %q", job.Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyNever)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestJob_WithCredentials(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	envName := "API_TOKEN"
+	mountPath := "/home/agent/.ssh/id_rsa"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				Name: "api-token",
+				SecretRef: kubetaskv1alpha1.SecretReference{
+					Name: "my-secret",
+					Key:  stringPtr("token"),
+				},
+				Env: &envName,
+			},
+			{
+				Name: "ssh-key",
+				SecretRef: kubetaskv1alpha1.SecretReference{
+					Name: "ssh-secret",
+					Key:  stringPtr("private-key"),
+				},
+				MountPath: &mountPath,
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	// Verify env credential
+	var foundEnvCred bool
+	for _, env := range container.Env {
+		if env.Name == "API_TOKEN" {
+			foundEnvCred = true
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+				t.Errorf("API_TOKEN env should have SecretKeyRef")
+			} else {
+				if env.ValueFrom.SecretKeyRef.Name != "my-secret" {
+					t.Errorf("SecretKeyRef.Name = %q, want %q", env.ValueFrom.SecretKeyRef.Name, "my-secret")
+				}
+				if env.ValueFrom.SecretKeyRef.Key != "token" {
+					t.Errorf("SecretKeyRef.Key = %q, want %q", env.ValueFrom.SecretKeyRef.Key, "token")
+				}
+			}
+		}
+	}
+	if !foundEnvCred {
+		t.Errorf("API_TOKEN env not found")
+	}
+
+	// Verify mount credential
+	var foundMountCred bool
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == "/home/agent/.ssh/id_rsa" {
+			foundMountCred = true
+		}
+	}
+	if !foundMountCred {
+		t.Errorf("SSH key mount not found at /home/agent/.ssh/id_rsa")
+	}
+
+	// Verify volume exists
+	var foundVolume bool
+	for _, vol := range job.Spec.Template.Spec.Volumes {
+		if vol.Secret != nil && vol.Secret.SecretName == "ssh-secret" {
+			foundVolume = true
+		}
+	}
+	if !foundVolume {
+		t.Errorf("Secret volume for ssh-secret not found")
+	}
+}
+
+func TestJob_WithReloadableCredential(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+
+	mountPath := "/var/run/secrets/github/token"
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				Name: "github-token",
+				SecretRef: kubetaskv1alpha1.SecretReference{
+					Name: "github-app",
+					Key:  stringPtr("token"),
+				},
+				MountPath:      &mountPath,
+				ReloadOnChange: true,
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+
+	var mount *corev1.VolumeMount
+	for i := range podSpec.Containers[0].VolumeMounts {
+		if podSpec.Containers[0].VolumeMounts[i].Name == "credential-0" {
+			mount = &podSpec.Containers[0].VolumeMounts[i]
+		}
+	}
+	if mount == nil {
+		t.Fatalf("credential-0 mount not found")
+	}
+	// subPath mounts are not updated when the Secret is rotated
+	if mount.MountPath != "/var/run/secrets/github" || mount.SubPath != "" || !mount.ReadOnly {
+		t.Errorf("mount = %+v, want a read-only mount of /var/run/secrets/github without subPath", mount)
+	}
+
+	var volume *corev1.Volume
+	for i := range podSpec.Volumes {
+		if podSpec.Volumes[i].Name == "credential-0" {
+			volume = &podSpec.Volumes[i]
+		}
+	}
+	if volume == nil || volume.Projected == nil || len(volume.Projected.Sources) != 1 || volume.Projected.Sources[0].Secret == nil {
+		t.Fatalf("credential-0 volume = %+v, want a projected Secret volume", volume)
+	}
+	projection := volume.Projected.Sources[0].Secret
+	if projection.Name != "github-app" || len(projection.Items) != 1 ||
+		projection.Items[0].Key != "token" || projection.Items[0].Path != "token" {
+		t.Errorf("projection = %+v, want key token of github-app at path token", projection)
+	}
+
+	if got := job.Spec.Template.Annotations[ReloadCredentialsAnnotation]; got != mountPath {
+		t.Errorf("%s annotation = %q, want %q", ReloadCredentialsAnnotation, got, mountPath)
+	}
+}
+
+func TestJob_ReloadOnChangeCredentialsSharingDirectory(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+
+	tokenPath, keyPath := "/var/run/secrets/github/token", "/var/run/secrets/github/app-key.pem"
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				Name:           "github-token",
+				SecretRef:      kubetaskv1alpha1.SecretReference{Name: "github-app", Key: stringPtr("token")},
+				MountPath:      &tokenPath,
+				ReloadOnChange: true,
+			},
+			{
+				Name:           "github-app-key",
+				SecretRef:      kubetaskv1alpha1.SecretReference{Name: "github-app-key", Key: stringPtr("private-key")},
+				MountPath:      &keyPath,
+				ReloadOnChange: true,
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+
+	// A directory can only be mounted once, so both keys share one projected volume
+	var mounts []corev1.VolumeMount
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.MountPath == "/var/run/secrets/github" {
+			mounts = append(mounts, mount)
+		}
+	}
+	if len(mounts) != 1 || mounts[0].Name != "credential-0" {
+		t.Fatalf("mounts of /var/run/secrets/github = %+v, want only credential-0", mounts)
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == "credential-1" {
+			t.Errorf("credential-1 volume = %+v, want it projected into credential-0", volume)
+		}
+		if volume.Name != "credential-0" {
+			continue
+		}
+		if volume.Projected == nil || len(volume.Projected.Sources) != 2 {
+			t.Fatalf("credential-0 volume = %+v, want a projected volume with two sources", volume)
+		}
+		for i, want := range []string{"token", "app-key.pem"} {
+			if got := volume.Projected.Sources[i].Secret.Items[0].Path; got != want {
+				t.Errorf("source %d path = %q, want %q", i, got, want)
+			}
+		}
+	}
+
+	if got, want := job.Spec.Template.Annotations[ReloadCredentialsAnnotation], tokenPath+","+keyPath; got != want {
+		t.Errorf("%s annotation = %q, want %q", ReloadCredentialsAnnotation, got, want)
+	}
+}
+
+func TestJob_ReloadOnChangeCredentialInWorkspace(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+
+	mountPath := "/workspace/token"
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				Name:           "github-token",
+				SecretRef:      kubetaskv1alpha1.SecretReference{Name: "github-app", Key: stringPtr("token")},
+				MountPath:      &mountPath,
+				ReloadOnChange: true,
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+
+	// Projecting into /workspace would hide the workspace behind a read-only volume
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if mount.MountPath == "/workspace" && mount.Name == "credential-0" {
+			t.Fatalf("credential-0 is mounted over the workspace: %+v", mount)
+		}
+		if mount.Name == "credential-0" && (mount.MountPath != mountPath || mount.SubPath != "secret-file") {
+			t.Errorf("credential-0 mount = %+v, want a subPath mount at %s", mount, mountPath)
+		}
+	}
+	if _, ok := job.Spec.Template.Annotations[ReloadCredentialsAnnotation]; ok {
+		t.Errorf("%s annotation set for a credential that is not reloaded", ReloadCredentialsAnnotation)
+	}
+}
+
+func TestValidateReloadCredentials(t *testing.T) {
+	credential := func(name, mountPath string) kubetaskv1alpha1.Credential {
+		return kubetaskv1alpha1.Credential{
+			Name:           name,
+			SecretRef:      kubetaskv1alpha1.SecretReference{Name: name, Key: stringPtr("token")},
+			MountPath:      &mountPath,
+			ReloadOnChange: true,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		credentials []kubetaskv1alpha1.Credential
+		wantErr     string
+	}{
+		{name: "own directory", credentials: []kubetaskv1alpha1.Credential{credential("github", "/var/run/secrets/github/token")}},
+		{
+			name: "shared directory",
+			credentials: []kubetaskv1alpha1.Credential{
+				credential("github", "/var/run/secrets/tokens/github"),
+				credential("gitlab", "/var/run/secrets/tokens/gitlab"),
+			},
+		},
+		{name: "below the workspace", credentials: []kubetaskv1alpha1.Credential{credential("github", "/workspace/.secrets/token")}},
+		{name: "in the workspace", credentials: []kubetaskv1alpha1.Credential{credential("github", "/workspace/token")}, wantErr: "would hide the workspace"},
+		{name: "in the root", credentials: []kubetaskv1alpha1.Credential{credential("github", "/token")}, wantErr: "would hide the workspace"},
+		{
+			name: "same mountPath",
+			credentials: []kubetaskv1alpha1.Credential{
+				credential("github", "/var/run/secrets/github/token"),
+				credential("github-next", "/var/run/secrets/github/token"),
+			},
+			wantErr: `mountPath /var/run/secrets/github/token is used by credential "github"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReloadCredentials(tt.credentials, "")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateReloadCredentials() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateReloadCredentials() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJob_WithEntireSecretCredential(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				// No Key specified - mount entire secret as env vars
+				Name: "api-keys",
+				SecretRef: kubetaskv1alpha1.SecretReference{
+					Name: "api-credentials",
+					// Key is nil - entire secret should be mounted
+				},
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	// Verify envFrom is set with secretRef
+	if len(container.EnvFrom) != 1 {
+		t.Fatalf("Expected 1 envFrom entry, got %d", len(container.EnvFrom))
+	}
+
+	envFrom := container.EnvFrom[0]
+	if envFrom.SecretRef == nil {
+		t.Errorf("EnvFrom.SecretRef should not be nil")
+	} else {
+		if envFrom.SecretRef.Name != "api-credentials" {
+			t.Errorf("EnvFrom.SecretRef.Name = %q, want %q", envFrom.SecretRef.Name, "api-credentials")
+		}
+	}
+	if envFrom.Prefix != "" {
+		t.Errorf("EnvFrom.Prefix = %q, want none", envFrom.Prefix)
+	}
+
+	// With a prefix, every key of the Secret is exposed under it
+	cfg.Credentials[0].EnvPrefix = stringPtr("AZURE_OPENAI_")
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if got := job.Spec.Template.Spec.Containers[0].EnvFrom[0].Prefix; got != "AZURE_OPENAI_" {
+		t.Errorf("EnvFrom.Prefix = %q, want %q", got, "AZURE_OPENAI_")
+	}
+}
+
+func TestJob_WithOptionalCredentials(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+
+	cfg := Config{
+		AgentImage:   "test-agent:v1.0.0",
+		WorkspaceDir: "/workspace",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{Name: "jira", SecretRef: kubetaskv1alpha1.SecretReference{Name: "jira"}, Optional: true},
+			{
+				Name:      "sentry",
+				SecretRef: kubetaskv1alpha1.SecretReference{Name: "sentry", Key: stringPtr("token")},
+				Env:       stringPtr("SENTRY_TOKEN"),
+				MountPath: stringPtr("/home/agent/.sentry/token"),
+				Optional:  true,
+			},
+			{Name: "github", SecretRef: kubetaskv1alpha1.SecretReference{Name: "github", Key: stringPtr("token")}, Env: stringPtr("GITHUB_TOKEN")},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+	container := podSpec.Containers[0]
+
+	if optional := container.EnvFrom[0].SecretRef.Optional; optional == nil || !*optional {
+		t.Errorf("EnvFrom[0].SecretRef.Optional = %v, want true", optional)
+	}
+	for _, env := range container.Env {
+		if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		optional := env.ValueFrom.SecretKeyRef.Optional
+		if want := env.Name == "SENTRY_TOKEN"; (optional != nil && *optional) != want {
+			t.Errorf("env %s optional = %v, want %v", env.Name, optional, want)
+		}
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == "sentry" {
+			if volume.Secret.Optional == nil || !*volume.Secret.Optional {
+				t.Errorf("volume %s Optional = %v, want true", volume.Name, volume.Secret.Optional)
+			}
+			return
+		}
+	}
+	t.Error("Secret volume of the sentry credential not found")
+}
+
+func TestJob_WithAgentEnvFrom(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		EnvFrom: []corev1.EnvFromSource{
+			{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "corporate-proxy"},
+				},
+			},
+			{
+				Prefix: "LLM_",
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "llm-endpoints"},
+				},
+			},
+		},
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				Name:      "api-keys",
+				SecretRef: kubetaskv1alpha1.SecretReference{Name: "api-credentials"},
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	// Agent envFrom bundles come first so Credentials win on conflicting keys
+	if len(container.EnvFrom) != 3 {
+		t.Fatalf("Expected 3 envFrom entries, got %d", len(container.EnvFrom))
+	}
+	if container.EnvFrom[0].ConfigMapRef == nil || container.EnvFrom[0].ConfigMapRef.Name != "corporate-proxy" {
+		t.Errorf("EnvFrom[0] = %+v, want configMapRef corporate-proxy", container.EnvFrom[0])
+	}
+	if container.EnvFrom[1].SecretRef == nil || container.EnvFrom[1].SecretRef.Name != "llm-endpoints" || container.EnvFrom[1].Prefix != "LLM_" {
+		t.Errorf("EnvFrom[1] = %+v, want secretRef llm-endpoints with prefix LLM_", container.EnvFrom[1])
+	}
+	if container.EnvFrom[2].SecretRef == nil || container.EnvFrom[2].SecretRef.Name != "api-credentials" {
+		t.Errorf("EnvFrom[2] = %+v, want secretRef api-credentials", container.EnvFrom[2])
+	}
+
+	// The caller's slice must not be modified
+	if len(cfg.EnvFrom) != 2 {
+		t.Errorf("cfg.EnvFrom modified, len = %d, want 2", len(cfg.EnvFrom))
+	}
+}
+
+func TestJob_WithMixedCredentials(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	envName := "GITHUB_TOKEN"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{
+				// Entire secret mount (no key)
+				Name: "all-api-keys",
+				SecretRef: kubetaskv1alpha1.SecretReference{
+					Name: "api-credentials",
+				},
+			},
+			{
+				// Single key mount with env rename
+				Name: "github-token",
+				SecretRef: kubetaskv1alpha1.SecretReference{
+					Name: "github-secret",
+					Key:  stringPtr("token"),
+				},
+				Env: &envName,
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	// Verify envFrom has 1 entry (entire secret)
+	if len(container.EnvFrom) != 1 {
+		t.Fatalf("Expected 1 envFrom entry, got %d", len(container.EnvFrom))
+	}
+	if container.EnvFrom[0].SecretRef.Name != "api-credentials" {
+		t.Errorf("EnvFrom.SecretRef.Name = %q, want %q", container.EnvFrom[0].SecretRef.Name, "api-credentials")
+	}
+
+	// Verify env has GITHUB_TOKEN from single key mount
+	var foundGithubToken bool
+	for _, env := range container.Env {
+		if env.Name == "GITHUB_TOKEN" {
+			foundGithubToken = true
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+				t.Errorf("GITHUB_TOKEN env should have SecretKeyRef")
+			} else {
+				if env.ValueFrom.SecretKeyRef.Name != "github-secret" {
+					t.Errorf("SecretKeyRef.Name = %q, want %q", env.ValueFrom.SecretKeyRef.Name, "github-secret")
+				}
+				if env.ValueFrom.SecretKeyRef.Key != "token" {
+					t.Errorf("SecretKeyRef.Key = %q, want %q", env.ValueFrom.SecretKeyRef.Key, "token")
+				}
+			}
+		}
+	}
+	if !foundGithubToken {
+		t.Errorf("GITHUB_TOKEN env not found")
+	}
+}
+
+func TestJob_WithHumanInTheLoop(t *testing.T) {
+	keepAlive := int32(1800)
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{
+				Enabled:          true,
+				KeepAliveSeconds: &keepAlive,
+			},
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Command:            []string{"sh", "-c", "echo hello"},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	// Verify command is wrapped
+	if len(container.Command) != 3 {
+		t.Fatalf("len(Command) = %d, want 3", len(container.Command))
+	}
+	if container.Command[0] != "sh" {
+		t.Errorf("Command[0] = %q, want %q", container.Command[0], "sh")
+	}
+	if container.Command[1] != "-c" {
+		t.Errorf("Command[1] = %q, want %q", container.Command[1], "-c")
+	}
+
+	// Verify wrapped script contains sleep
+	script := container.Command[2]
+	if !strings.Contains(script, "sleep 1800") {
+		t.Errorf("Command script should contain 'sleep 1800', got: %s", script)
+	}
+	if !strings.Contains(script, "Human-in-the-loop") {
+		t.Errorf("Command script should contain 'Human-in-the-loop', got: %s", script)
+	}
+	if !strings.Contains(script, "sh -c echo hello") {
+		t.Errorf("Command script should contain original command 'sh -c echo hello', got: %s", script)
+	}
+
+	// Verify keep-alive env var
+	var foundKeepAliveEnv bool
+	for _, env := range container.Env {
+		if env.Name == EnvHumanInTheLoopKeepAlive {
+			foundKeepAliveEnv = true
+			if env.Value != "1800" {
+				t.Errorf("KUBETASK_KEEP_ALIVE_SECONDS = %q, want %q", env.Value, "1800")
+			}
+		}
+	}
+	if !foundKeepAliveEnv {
+		t.Errorf("KUBETASK_KEEP_ALIVE_SECONDS env not found")
+	}
+}
+
+func TestJob_WithContainerName(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: types.UID("test-uid")},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{Enabled: true},
+		},
+	}
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		ContainerName:      "coder",
+		Command:            []string{"gemini", "--yolo"},
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			Annotations: map[string]string{"sidecar.istio.io/inject": "true"},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Name != "coder" {
+		t.Errorf("container name = %q, want %q", container.Name, "coder")
+	}
+	if len(container.Command) != 3 || !strings.Contains(container.Command[2], "gemini --yolo") || !strings.Contains(container.Command[2], "Human-in-the-loop") {
+		t.Errorf("Command = %v, want the wrapped agent command", container.Command)
+	}
+	annotations := job.Spec.Template.Annotations
+	if annotations[DefaultContainerAnnotation] != "coder" || annotations["sidecar.istio.io/inject"] != "true" {
+		t.Errorf("pod annotations = %v, want the default container and Agent annotations", annotations)
+	}
+
+	// Agents without containerName keep the "agent" container
+	cfg.ContainerName = ""
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if name := job.Spec.Template.Spec.Containers[0].Name; name != DefaultAgentContainerName {
+		t.Errorf("container name = %q, want %q", name, DefaultAgentContainerName)
+	}
+	if got := job.Spec.Template.Annotations[DefaultContainerAnnotation]; got != DefaultAgentContainerName {
+		t.Errorf("default container annotation = %q, want %q", got, DefaultAgentContainerName)
+	}
+}
+
+func TestJob_WithPodScheduling(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	runtimeClass := "gvisor"
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			Labels: map[string]string{
+				"custom-label": "custom-value",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/inject": "false",
+			},
+			Scheduling: &kubetaskv1alpha1.PodScheduling{
+				NodeSelector: map[string]string{
+					"node-type": "gpu",
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "ai-workload",
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.ScheduleAnyway,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "kubetask"},
+						},
+					},
+				},
+			},
+			RuntimeClassName: &runtimeClass,
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	podSpec := job.Spec.Template.Spec
+
+	// Verify node selector
+	if podSpec.NodeSelector["node-type"] != "gpu" {
+		t.Errorf("NodeSelector[node-type] = %q, want %q", podSpec.NodeSelector["node-type"], "gpu")
+	}
+
+	// Verify tolerations
+	if len(podSpec.Tolerations) != 1 {
+		t.Fatalf("len(Tolerations) = %d, want 1", len(podSpec.Tolerations))
+	}
+	if podSpec.Tolerations[0].Key != "dedicated" {
+		t.Errorf("Tolerations[0].Key = %q, want %q", podSpec.Tolerations[0].Key, "dedicated")
+	}
+
+	// Verify topology spread constraints
+	if len(podSpec.TopologySpreadConstraints) != 1 {
+		t.Fatalf("len(TopologySpreadConstraints) = %d, want 1", len(podSpec.TopologySpreadConstraints))
+	}
+	if podSpec.TopologySpreadConstraints[0].TopologyKey != "topology.kubernetes.io/zone" {
+		t.Errorf("TopologySpreadConstraints[0].TopologyKey = %q, want %q", podSpec.TopologySpreadConstraints[0].TopologyKey, "topology.kubernetes.io/zone")
+	}
+
+	// Verify runtime class
+	if podSpec.RuntimeClassName == nil || *podSpec.RuntimeClassName != "gvisor" {
+		t.Errorf("RuntimeClassName = %v, want %q", podSpec.RuntimeClassName, "gvisor")
+	}
+
+	// Verify custom label on pod template
+	podLabels := job.Spec.Template.ObjectMeta.Labels
+	if podLabels["custom-label"] != "custom-value" {
+		t.Errorf("PodLabels[custom-label] = %q, want %q", podLabels["custom-label"], "custom-value")
+	}
+	// Verify base labels are still present
+	if podLabels["app"] != "kubetask" {
+		t.Errorf("PodLabels[app] = %q, want %q", podLabels["app"], "kubetask")
+	}
+
+	// Verify custom annotation on pod template
+	podAnnotations := job.Spec.Template.ObjectMeta.Annotations
+	if podAnnotations["sidecar.istio.io/inject"] != "false" {
+		t.Errorf("PodAnnotations[sidecar.istio.io/inject] = %q, want %q", podAnnotations["sidecar.istio.io/inject"], "false")
+	}
+	// Annotations must not leak onto the Job itself
+	if len(job.Annotations) != 0 {
+		t.Errorf("Job.Annotations = %v, want empty", job.Annotations)
+	}
+}
+
+func TestJob_WithDNSConfig(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	dnsPolicy := corev1.DNSNone
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			DNSPolicy: &dnsPolicy,
+			DNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.10"},
+				Searches:    []string{"corp.internal"},
+			},
+			HostAliases: []corev1.HostAlias{
+				{
+					IP:        "10.1.2.3",
+					Hostnames: []string{"git.corp.internal"},
+				},
+			},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	podSpec := job.Spec.Template.Spec
+
+	// Verify DNS policy
+	if podSpec.DNSPolicy != corev1.DNSNone {
+		t.Errorf("DNSPolicy = %q, want %q", podSpec.DNSPolicy, corev1.DNSNone)
+	}
+
+	// Verify DNS config
+	if podSpec.DNSConfig == nil {
+		t.Fatalf("DNSConfig is nil, want non-nil")
+	}
+	if len(podSpec.DNSConfig.Nameservers) != 1 || podSpec.DNSConfig.Nameservers[0] != "10.0.0.10" {
+		t.Errorf("DNSConfig.Nameservers = %v, want [10.0.0.10]", podSpec.DNSConfig.Nameservers)
+	}
+
+	// Verify host aliases
+	if len(podSpec.HostAliases) != 1 {
+		t.Fatalf("len(HostAliases) = %d, want 1", len(podSpec.HostAliases))
+	}
+	if podSpec.HostAliases[0].IP != "10.1.2.3" {
+		t.Errorf("HostAliases[0].IP = %q, want %q", podSpec.HostAliases[0].IP, "10.1.2.3")
+	}
+}
+
+func TestJob_WithTaskRuntimeClassOverride(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	agentRuntimeClass := "runc"
+	taskRuntimeClass := "gvisor"
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			RuntimeClassName: &agentRuntimeClass,
+		},
+		RuntimeClassName: &taskRuntimeClass,
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	podSpec := job.Spec.Template.Spec
+	if podSpec.RuntimeClassName == nil || *podSpec.RuntimeClassName != "gvisor" {
+		t.Errorf("RuntimeClassName = %v, want %q", podSpec.RuntimeClassName, "gvisor")
+	}
+}
+
+func TestJob_WithRestartPolicyOnFailure(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	restartPolicy := corev1.RestartPolicyOnFailure
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			RestartPolicy: &restartPolicy,
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		t.Errorf("RestartPolicy = %q, want %q", job.Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyOnFailure)
+	}
+	// In-place restarts count against the Job's backoffLimit
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != DefaultBackoffLimit {
+		t.Errorf("BackoffLimit = %v, want %d", job.Spec.BackoffLimit, DefaultBackoffLimit)
+	}
+}
+
+func TestJob_WithRetryPolicy(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			RetryPolicy: &kubetaskv1alpha1.RetryPolicy{MaxRetries: 2},
+		},
+	}
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+
+	// The controller retries by recreating the Job, so the Job must not start a
+	// second Pod of its own
+	if job.Spec.BackoffLimit == nil || *job.Spec.BackoffLimit != 0 {
+		t.Errorf("BackoffLimit = %v, want 0", job.Spec.BackoffLimit)
+	}
+}
+
+func TestJob_WithContextConfigMap(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	contextConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task-context",
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			"workspace-task.md": "# Test Task",
+		},
+	}
+
+	fileMounts := []FileMount{
+		{Path: "/workspace/task.md"},
+	}
+
+	job := Job(task, "test-task-job", cfg, contextConfigMap, fileMounts, nil, nil)
+
+	// Verify context-files volume exists
+	var foundContextVolume bool
+	for _, vol := range job.Spec.Template.Spec.Volumes {
+		if vol.Name == "context-files" && vol.ConfigMap != nil {
+			foundContextVolume = true
+			if vol.ConfigMap.Name != "test-task-context" {
+				t.Errorf("context-files volume ConfigMap.Name = %q, want %q", vol.ConfigMap.Name, "test-task-context")
+			}
+		}
+	}
+	if !foundContextVolume {
+		t.Errorf("context-files volume not found")
+	}
+
+	// Verify volume mount exists
+	container := job.Spec.Template.Spec.Containers[0]
+	var foundMount bool
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == "/workspace/task.md" {
+			foundMount = true
+			if mount.SubPath != "workspace-task.md" {
+				t.Errorf("VolumeMount.SubPath = %q, want %q", mount.SubPath, "workspace-task.md")
+			}
+		}
+	}
+	if !foundMount {
+		t.Errorf("Volume mount for /workspace/task.md not found")
+	}
+}
+
+func TestJob_WithDirMounts(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+	task.APIVersion = "kubetask.io/v1alpha1"
+	task.Kind = "Task"
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	dirMounts := []DirMount{
+		{
+			Path:          "/workspace/guides",
+			ConfigMapName: "guides-configmap",
+			Optional:      true,
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, dirMounts, nil)
+
+	// Verify dir-mount volume exists
+	var foundDirVolume bool
+	for _, vol := range job.Spec.Template.Spec.Volumes {
+		if vol.Name == "dir-mount-0" && vol.ConfigMap != nil {
+			foundDirVolume = true
+			if vol.ConfigMap.Name != "guides-configmap" {
+				t.Errorf("dir-mount-0 volume ConfigMap.Name = %q, want %q", vol.ConfigMap.Name, "guides-configmap")
+			}
+			if vol.ConfigMap.Optional == nil || *vol.ConfigMap.Optional != true {
+				t.Errorf("dir-mount-0 volume ConfigMap.Optional = %v, want true", vol.ConfigMap.Optional)
+			}
+		}
+	}
+	if !foundDirVolume {
+		t.Errorf("dir-mount-0 volume not found")
+	}
+
+	// Verify volume mount exists
+	container := job.Spec.Template.Spec.Containers[0]
+	var foundMount bool
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == "/workspace/guides" {
+			foundMount = true
+		}
+	}
+	if !foundMount {
+		t.Errorf("Volume mount for /workspace/guides not found")
+	}
+}
+
+func TestJob_WithGitMounts(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kubetask.io/v1alpha1",
+			Kind:       "Task",
+		},
+	}
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	gitMounts := []GitMount{
+		{
+			ContextName: "my-context",
+			Repository:  "https://github.com/org/repo.git",
+			Ref:         "main",
+			RepoPath:    ".claude/",
+			MountPath:   "/workspace/.claude",
+			Depth:       1,
+			SecretName:  "",
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
+
+	// Verify init container exists
+	if len(job.Spec.Template.Spec.InitContainers) != 1 {
+		t.Fatalf("Expected 1 init container, got %d", len(job.Spec.Template.Spec.InitContainers))
+	}
+
+	initContainer := job.Spec.Template.Spec.InitContainers[0]
+	if initContainer.Name != "git-sync-0" {
+		t.Errorf("Init container name = %q, want %q", initContainer.Name, "git-sync-0")
+	}
+	if initContainer.Image != DefaultGitSyncImage {
+		t.Errorf("Init container image = %q, want %q", initContainer.Image, DefaultGitSyncImage)
+	}
+
+	// Verify environment variables
+	envMap := make(map[string]string)
+	for _, env := range initContainer.Env {
+		envMap[env.Name] = env.Value
+	}
+	if envMap["GITSYNC_REPO"] != "https://github.com/org/repo.git" {
+		t.Errorf("GITSYNC_REPO = %q, want %q", envMap["GITSYNC_REPO"], "https://github.com/org/repo.git")
+	}
+	if envMap["GITSYNC_REF"] != "main" {
+		t.Errorf("GITSYNC_REF = %q, want %q", envMap["GITSYNC_REF"], "main")
+	}
+	if envMap["GITSYNC_ONE_TIME"] != "true" {
+		t.Errorf("GITSYNC_ONE_TIME = %q, want %q", envMap["GITSYNC_ONE_TIME"], "true")
+	}
+	if envMap["GITSYNC_DEPTH"] != "1" {
+		t.Errorf("GITSYNC_DEPTH = %q, want %q", envMap["GITSYNC_DEPTH"], "1")
+	}
+
+	// Verify emptyDir volume exists
+	var foundGitVolume bool
+	for _, vol := range job.Spec.Template.Spec.Volumes {
+		if vol.Name == "git-context-0" && vol.EmptyDir != nil {
+			foundGitVolume = true
+		}
+	}
+	if !foundGitVolume {
+		t.Errorf("git-context-0 emptyDir volume not found")
+	}
+
+	// Verify volume mount in agent container with correct subPath
+	container := job.Spec.Template.Spec.Containers[0]
+	var foundMount bool
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == "/workspace/.claude" && mount.Name == "git-context-0" {
+			foundMount = true
+			expectedSubPath := "repo/.claude/"
+			if mount.SubPath != expectedSubPath {
+				t.Errorf("Volume mount SubPath = %q, want %q", mount.SubPath, expectedSubPath)
+			}
+		}
+	}
+	if !foundMount {
+		t.Errorf("Volume mount for /workspace/.claude not found")
+	}
+}
+
+func TestJob_WithGitSyncImage(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{
+		AgentImage:   "test-agent:v1.0.0",
+		WorkspaceDir: "/workspace",
+		GitSyncImage: "mirror.example.com/git-sync/git-sync:v4.4.0",
+	}
+	gitMounts := []GitMount{{ContextName: "repo", Repository: "https://github.com/org/repo.git", MountPath: "/workspace/repo"}}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
+	if got := job.Spec.Template.Spec.InitContainers[0].Image; got != cfg.GitSyncImage {
+		t.Errorf("git-sync image = %q, want %q", got, cfg.GitSyncImage)
+	}
+
+	cfg.ContextCacheClaim = "context-cache"
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
+	if got := job.Spec.Template.Spec.InitContainers[0].Image; got != cfg.GitSyncImage {
+		t.Errorf("git-cache image = %q, want %q", got, cfg.GitSyncImage)
+	}
+}
+
+func TestJob_WithResourceRequests(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{AgentImage: "test-agent:v1.0.0", WorkspaceDir: "/workspace"}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if requests := job.Spec.Template.Spec.Containers[0].Resources.Requests; requests != nil {
+		t.Errorf("requests = %v, want none", requests)
+	}
+
+	cfg.ResourceRequests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")}
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if cpu := job.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU]; cpu.String() != "500m" {
+		t.Errorf("cpu request = %s, want 500m", cpu.String())
+	}
+}
+
+func TestJob_WithResources(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	agentResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}
+
+	tests := []struct {
+		name         string
+		sized        corev1.ResourceList
+		taskOverride *corev1.ResourceRequirements
+		wantRequests corev1.ResourceList
+		wantLimits   corev1.ResourceList
+	}{
+		{
+			name:         "agent resources",
+			wantRequests: agentResources.Requests,
+			wantLimits:   agentResources.Limits,
+		},
+		{
+			name:         "sizing recommendation replaces agent requests",
+			sized:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			wantRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			wantLimits:   agentResources.Limits,
+		},
+		{
+			name:  "task overrides per resource",
+			sized: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			taskOverride: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi"), "nvidia.com/gpu": resource.MustParse("1")},
+			},
+			wantRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+			wantLimits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi"), "nvidia.com/gpu": resource.MustParse("1")},
+		},
+		{
+			name:         "requests above a lowered limit are lowered",
+			taskOverride: &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+			wantRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			wantLimits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				AgentImage:       "test-agent:v1.0.0",
+				WorkspaceDir:     "/workspace",
+				PodSpec:          &kubetaskv1alpha1.AgentPodSpec{Resources: agentResources},
+				ResourceRequests: tt.sized,
+				Resources:        tt.taskOverride,
+			}
+			got := Job(task, "test-task-job", cfg, nil, nil, nil, nil).Spec.Template.Spec.Containers[0].Resources
+			if !equality.Semantic.DeepEqual(got.Requests, tt.wantRequests) {
+				t.Errorf("requests = %v, want %v", got.Requests, tt.wantRequests)
+			}
+			if !equality.Semantic.DeepEqual(got.Limits, tt.wantLimits) {
+				t.Errorf("limits = %v, want %v", got.Limits, tt.wantLimits)
+			}
+		})
+	}
+
+	// The Agent's resources are not modified by the overrides
+	if memory := agentResources.Requests[corev1.ResourceMemory]; memory.String() != "2Gi" {
+		t.Errorf("agent memory request = %s, want it unchanged", memory.String())
+	}
+}
+
+func TestJob_WithGitMountsAndAuth(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "kubetask.io/v1alpha1",
+			Kind:       "Task",
+		},
+	}
+
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	gitMounts := []GitMount{
+		{
+			ContextName: "private-repo",
+			Repository:  "https://github.com/org/private-repo.git",
+			Ref:         "v1.0.0",
+			RepoPath:    "",
+			MountPath:   "/workspace/git-private-repo",
+			Depth:       1,
+			SecretName:  "git-credentials",
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, gitMounts)
+
+	// Verify init container has auth env vars
+	initContainer := job.Spec.Template.Spec.InitContainers[0]
+	var foundUsername, foundPassword bool
+	for _, env := range initContainer.Env {
+		if env.Name == "GITSYNC_USERNAME" && env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			if env.ValueFrom.SecretKeyRef.Name == "git-credentials" && env.ValueFrom.SecretKeyRef.Key == "username" {
+				foundUsername = true
+			}
+		}
+		if env.Name == "GITSYNC_PASSWORD" && env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			if env.ValueFrom.SecretKeyRef.Name == "git-credentials" && env.ValueFrom.SecretKeyRef.Key == "password" {
+				foundPassword = true
+			}
+		}
+	}
+	if !foundUsername {
+		t.Errorf("GITSYNC_USERNAME env var with secret reference not found")
+	}
+	if !foundPassword {
+		t.Errorf("GITSYNC_PASSWORD env var with secret reference not found")
+	}
+
+	// Verify volume mount without subPath (entire repo)
+	container := job.Spec.Template.Spec.Containers[0]
+	var foundMount bool
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == "/workspace/git-private-repo" && mount.Name == "git-context-0" {
+			foundMount = true
+			if mount.SubPath != "repo" {
+				t.Errorf("Volume mount SubPath = %q, want %q", mount.SubPath, "repo")
+			}
+		}
+	}
+	if !foundMount {
+		t.Errorf("Volume mount for /workspace/git-private-repo not found")
+	}
+}
+
+func TestJob_Deterministic(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       "test-uid",
+		},
+	}
+
+	envName := "API_TOKEN"
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		Credentials: []kubetaskv1alpha1.Credential{
+			{Name: "api-token", SecretRef: kubetaskv1alpha1.SecretReference{Name: "my-secret", Key: stringPtr("token")}, Env: &envName},
+			{Name: "all", SecretRef: kubetaskv1alpha1.SecretReference{Name: "bundle"}},
+		},
+		EnvFrom: []corev1.EnvFromSource{
+			{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "proxy"}}},
+		},
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			Labels:      map[string]string{"b": "2", "a": "1", "c": "3"},
+			Annotations: map[string]string{"y": "2", "x": "1"},
+		},
+	}
+	contextConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-task-context"}}
+	fileMounts := []FileMount{{Path: "/workspace/task.md"}, {Path: "/workspace/guide.md"}}
+	dirMounts := []DirMount{{Path: "/workspace/docs", ConfigMapName: "docs"}, {Path: "/workspace/rules", ConfigMapName: "rules"}}
+	gitMounts := []GitMount{
+		{ContextName: "repo-a", Repository: "https://github.com/org/a.git", MountPath: "/workspace/a"},
+		{ContextName: "repo-b", Repository: "https://github.com/org/b.git", MountPath: "/workspace/b"},
+	}
+
+	// Generated children must not change between reconciles, so GitOps tools and
+	// controllers comparing them do not see spurious diffs
+	want := Job(task, "test-task-job", cfg, contextConfigMap, fileMounts, dirMounts, gitMounts)
+	for i := 0; i < 10; i++ {
+		got := Job(task, "test-task-job", cfg, contextConfigMap, fileMounts, dirMounts, gitMounts)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Job() is not deterministic:\n got: %+v\nwant: %+v", got.Spec.Template.Spec, want.Spec.Template.Spec)
+		}
+	}
+}
+
+func TestGitSyncInitContainer(t *testing.T) {
+	gm := GitMount{
+		ContextName: "test-context",
+		Repository:  "https://github.com/test/repo.git",
+		Ref:         "develop",
+		RepoPath:    "docs/",
+		MountPath:   "/workspace/docs",
+		Depth:       5,
+		SecretName:  "",
+	}
+
+	container := gitSyncInitContainer(gm, DefaultGitSyncImage, "git-vol-0", 0)
+
+	if container.Name != "git-sync-0" {
+		t.Errorf("Container name = %q, want %q", container.Name, "git-sync-0")
+	}
+
+	if container.Image != DefaultGitSyncImage {
+		t.Errorf("Container image = %q, want %q", container.Image, DefaultGitSyncImage)
+	}
+
+	// Check env vars
+	envMap := make(map[string]string)
+	for _, env := range container.Env {
+		if env.Value != "" {
+			envMap[env.Name] = env.Value
+		}
+	}
+
+	if envMap["GITSYNC_REPO"] != "https://github.com/test/repo.git" {
+		t.Errorf("GITSYNC_REPO = %q, want %q", envMap["GITSYNC_REPO"], "https://github.com/test/repo.git")
+	}
+	if envMap["GITSYNC_REF"] != "develop" {
+		t.Errorf("GITSYNC_REF = %q, want %q", envMap["GITSYNC_REF"], "develop")
+	}
+	if envMap["GITSYNC_DEPTH"] != "5" {
+		t.Errorf("GITSYNC_DEPTH = %q, want %q", envMap["GITSYNC_DEPTH"], "5")
+	}
+
+	// Verify volume mount
+	if len(container.VolumeMounts) != 1 {
+		t.Fatalf("Expected 1 volume mount, got %d", len(container.VolumeMounts))
+	}
+	if container.VolumeMounts[0].Name != "git-vol-0" {
+		t.Errorf("Volume mount name = %q, want %q", container.VolumeMounts[0].Name, "git-vol-0")
+	}
+	if container.VolumeMounts[0].MountPath != "/git" {
+		t.Errorf("Volume mount path = %q, want %q", container.VolumeMounts[0].MountPath, "/git")
+	}
+}
+
+// contains checks if a string contains a substring
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "plain", arg: "gemini", want: "'gemini'"},
+		{name: "spaces", arg: "hello world", want: "'hello world'"},
+		{name: "single quote", arg: "it's", want: `'it'\''s'`},
+		{name: "shell expansion", arg: "$(rm -rf /)", want: "'$(rm -rf /)'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shellQuote(tt.arg); got != tt.want {
+				t.Errorf("shellQuote(%q) = %q, want %q", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_DownwardAPI(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       "test-uid",
+			Labels:    map[string]string{"team": "platform", "app": "billing", "priority": "high"},
+			Annotations: map[string]string{
+				"cost-center": "cc-1234",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+	}
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+		ContainerName:      "claude",
+		PodSpec: &kubetaskv1alpha1.AgentPodSpec{
+			Labels: map[string]string{"priority": "low"},
+		},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	template := job.Spec.Template
+
+	// Task labels are copied, Agent labels and base labels take precedence
+	wantLabels := map[string]string{"team": "platform", "priority": "low", "app": "kubetask", TaskLabelKey: "test-task"}
+	for k, v := range wantLabels {
+		if template.Labels[k] != v {
+			t.Errorf("pod label %s = %q, want %q", k, template.Labels[k], v)
+		}
+	}
+	if template.Annotations["cost-center"] != "cc-1234" {
+		t.Errorf("pod annotations = %v, want the Task's cost-center", template.Annotations)
+	}
+	if _, ok := template.Annotations["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+		t.Errorf("pod annotations = %v, want kubectl annotations excluded", template.Annotations)
+	}
+
+	var podInfo *corev1.Volume
+	for i := range template.Spec.Volumes {
+		if template.Spec.Volumes[i].Name == podInfoVolumeName {
+			podInfo = &template.Spec.Volumes[i]
+		}
+	}
+	if podInfo == nil || podInfo.DownwardAPI == nil {
+		t.Fatalf("Volumes = %+v, want the podinfo Downward API volume", template.Spec.Volumes)
+	}
+	for _, item := range podInfo.DownwardAPI.Items {
+		if item.ResourceFieldRef != nil && item.ResourceFieldRef.ContainerName != "claude" {
+			t.Errorf("%s references container %q, want the agent container", item.Path, item.ResourceFieldRef.ContainerName)
+		}
+	}
+
+	container := template.Spec.Containers[0]
+	var mounted bool
+	for _, m := range container.VolumeMounts {
+		mounted = mounted || (m.Name == podInfoVolumeName && m.MountPath == PodInfoMountPath && m.ReadOnly)
+	}
+	if !mounted {
+		t.Errorf("VolumeMounts = %+v, want podinfo mounted read-only at %s", container.VolumeMounts, PodInfoMountPath)
+	}
+	env := make(map[string]corev1.EnvVar)
+	for _, e := range container.Env {
+		env[e.Name] = e
+	}
+	for _, name := range []string{"KUBETASK_POD_NAME", "KUBETASK_NODE_NAME", "KUBETASK_CPU_LIMIT", "KUBETASK_MEMORY_LIMIT"} {
+		if e, ok := env[name]; !ok || e.ValueFrom == nil {
+			t.Errorf("env %s = %+v, want a Downward API reference", name, e)
+		}
+	}
+	if env["KUBETASK_PODINFO_DIR"].Value != PodInfoMountPath {
+		t.Errorf("KUBETASK_PODINFO_DIR = %q, want %q", env["KUBETASK_PODINFO_DIR"].Value, PodInfoMountPath)
+	}
+}
+
+func TestJob_WithMonitoring(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+
+	job := Job(task, "test-task-job", Config{AgentImage: "test-agent:v1.0.0", AgentName: "claude"}, nil, nil, nil, nil)
+	if container := job.Spec.Template.Spec.Containers[0]; len(container.Ports) != 0 || job.Spec.Template.Labels[AgentLabelKey] != "" {
+		t.Errorf("ports = %v, labels = %v, want no metrics port without monitoring", container.Ports, job.Spec.Template.Labels)
+	}
+
+	port := int32(8000)
+	cfg := Config{AgentImage: "test-agent:v1.0.0", AgentName: "claude", Monitoring: &kubetaskv1alpha1.AgentMonitoring{Enabled: true, Port: &port}}
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	container := job.Spec.Template.Spec.Containers[0]
+	wantPorts := []corev1.ContainerPort{{Name: "metrics", ContainerPort: 8000, Protocol: corev1.ProtocolTCP}}
+	if !reflect.DeepEqual(container.Ports, wantPorts) {
+		t.Errorf("ports = %v, want %v", container.Ports, wantPorts)
+	}
+	var metricsPort string
+	for _, env := range container.Env {
+		if env.Name == MetricsPortEnv {
+			metricsPort = env.Value
+		}
+	}
+	if metricsPort != "8000" {
+		t.Errorf("%s = %q, want 8000", MetricsPortEnv, metricsPort)
+	}
+	if got := job.Spec.Template.Labels[AgentLabelKey]; got != "claude" {
+		t.Errorf("pod label %s = %q, want the Agent for its PodMonitor", AgentLabelKey, got)
+	}
+}
+
+func TestJob_WithObjectStoreMounts(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{
+		AgentImage:      "test-agent:v1.0.0",
+		SecurityProfile: kubetaskv1alpha1.SecurityProfileRestricted,
+		ObjectStoreMounts: []ObjectStoreMount{
+			{
+				ContextName: "corpus", MountPath: "/workspace/corpus", Provider: kubetaskv1alpha1.ObjectStoreProviderS3,
+				Bucket: "docs", Prefix: "corpus/v2", Region: "eu-west-1", SecretName: "s3-credentials",
+			},
+			{
+				ContextName: "model", MountPath: "/workspace/model.bin", Provider: kubetaskv1alpha1.ObjectStoreProviderAzure,
+				Bucket: "models", Key: "v1/model.bin", Endpoint: "https://acme.blob.core.windows.net",
+			},
+		},
+	}
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+
+	if len(podSpec.InitContainers) != 2 {
+		t.Fatalf("init containers = %d, want one per object store mount", len(podSpec.InitContainers))
+	}
+	s3, azure := podSpec.InitContainers[0], podSpec.InitContainers[1]
+	if s3.Image != DefaultObjectStoreImage || !reflect.DeepEqual(s3.Args, []string{"copy", "src:docs/corpus/v2", "/objects/data"}) {
+		t.Errorf("S3 init container = %s %v, want a copy of the prefix", s3.Image, s3.Args)
+	}
+	if !reflect.DeepEqual(azure.Args, []string{"copyto", "src:models/v1/model.bin", "/objects/data"}) {
+		t.Errorf("Azure init container args = %v, want a copy of the key", azure.Args)
+	}
+	env := map[string]corev1.EnvVar{}
+	for _, e := range s3.Env {
+		env[e.Name] = e
+	}
+	if env["RCLONE_CONFIG_SRC_TYPE"].Value != "s3" || env["RCLONE_CONFIG_SRC_REGION"].Value != "eu-west-1" ||
+		env["RCLONE_CONFIG_SRC_ACCESS_KEY_ID"].ValueFrom.SecretKeyRef.Name != "s3-credentials" {
+		t.Errorf("S3 init container env = %v, want the S3 remote with the Secret's credentials", s3.Env)
+	}
+	env = map[string]corev1.EnvVar{}
+	for _, e := range azure.Env {
+		env[e.Name] = e
+	}
+	if env["RCLONE_CONFIG_SRC_ACCOUNT"].Value != "acme" || env["RCLONE_CONFIG_SRC_ENV_AUTH"].Value != "true" {
+		t.Errorf("Azure init container env = %v, want the account and workload identity", azure.Env)
+	}
+	if sc := azure.SecurityContext; sc == nil || sc.RunAsUser == nil || *sc.RunAsUser == 0 || sc.AllowPrivilegeEscalation == nil {
+		t.Errorf("Azure init container securityContext = %+v, want non-root and hardened", sc)
+	}
+
+	var mounts []corev1.VolumeMount
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if strings.HasPrefix(mount.Name, "object-store-context-") {
+			mounts = append(mounts, mount)
+		}
+	}
+	wantMounts := []corev1.VolumeMount{
+		{Name: "object-store-context-0", MountPath: "/workspace/corpus", SubPath: "data"},
+		{Name: "object-store-context-1", MountPath: "/workspace/model.bin", SubPath: "data"},
+	}
+	if !reflect.DeepEqual(mounts, wantMounts) {
+		t.Errorf("agent object store mounts = %v, want %v", mounts, wantMounts)
+	}
+}
+
+func TestJob_WithOCIMounts(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{
+		AgentImage: "test-agent:v1.0.0",
+		OCIMounts: []OCIMount{
+			{ContextName: "prompts", Image: "registry.example.com/prompts/review:v3", Path: "review", MountPath: "/workspace/prompts", PullSecretName: "registry-credentials"},
+			{ContextName: "public", Image: "ghcr.io/org/guides@sha256:abc", MountPath: "/workspace/oci-public"},
+		},
+	}
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	podSpec := job.Spec.Template.Spec
+
+	if len(podSpec.InitContainers) != 2 {
+		t.Fatalf("init containers = %d, want one per OCI mount", len(podSpec.InitContainers))
+	}
+	private, public := podSpec.InitContainers[0], podSpec.InitContainers[1]
+	wantArgs := []string{"pull", "registry.example.com/prompts/review:v3", "--output", "/oci/data", "--registry-config", "/kubetask/oci-auth/.dockerconfigjson"}
+	if private.Image != DefaultORASImage || !reflect.DeepEqual(private.Args, wantArgs) {
+		t.Errorf("init container = %s %v, want %v", private.Image, private.Args, wantArgs)
+	}
+	if len(public.Args) != 4 || len(public.VolumeMounts) != 1 {
+		t.Errorf("init container args = %v, mounts = %v, want an anonymous pull", public.Args, public.VolumeMounts)
+	}
+
+	var authSecret string
+	for _, v := range podSpec.Volumes {
+		if v.Name == "oci-auth-0" && v.Secret != nil {
+			authSecret = v.Secret.SecretName
+		}
+		if v.Name == "oci-auth-1" {
+			t.Errorf("volume %s, want no pull secret volume for an anonymous pull", v.Name)
+		}
+	}
+	if authSecret != "registry-credentials" {
+		t.Errorf("pull secret volume = %q, want registry-credentials", authSecret)
+	}
+
+	var mounts []corev1.VolumeMount
+	for _, mount := range podSpec.Containers[0].VolumeMounts {
+		if strings.HasPrefix(mount.Name, "oci-context-") {
+			mounts = append(mounts, mount)
+		}
+	}
+	wantMounts := []corev1.VolumeMount{
+		{Name: "oci-context-0", MountPath: "/workspace/prompts", SubPath: "data/review"},
+		{Name: "oci-context-1", MountPath: "/workspace/oci-public", SubPath: "data"},
+	}
+	if !reflect.DeepEqual(mounts, wantMounts) {
+		t.Errorf("agent OCI mounts = %v, want %v", mounts, wantMounts)
+	}
+}
+
+func TestJob_WithExpose(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{
+		AgentImage: "test-agent:v1.0.0",
+		Command:    []string{"review-ui"},
+		Expose:     &kubetaskv1alpha1.AgentExpose{Port: 8080},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if container := job.Spec.Template.Spec.Containers[0]; len(container.Ports) != 0 {
+		t.Errorf("ports = %v, want no UI port outside human-in-the-loop sessions", container.Ports)
+	}
+
+	task.Spec.HumanInTheLoop = &kubetaskv1alpha1.HumanInTheLoop{Enabled: true}
+	job = Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	container := job.Spec.Template.Spec.Containers[0]
+	wantPorts := []corev1.ContainerPort{{Name: "ui", ContainerPort: 8080, Protocol: corev1.ProtocolTCP}}
+	if !reflect.DeepEqual(container.Ports, wantPorts) {
+		t.Errorf("ports = %v, want %v", container.Ports, wantPorts)
+	}
+	var uiPort string
+	for _, env := range container.Env {
+		if env.Name == UIPortEnv {
+			uiPort = env.Value
+		}
+	}
+	if uiPort != "8080" {
+		t.Errorf("%s = %q, want 8080", UIPortEnv, uiPort)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// MetricsPortName names the agent container port the PodMonitor of a monitored
+	// Agent scrapes
+	MetricsPortName = "metrics"
+
+	// DefaultMetricsPort is the port agents serve metrics on unless the Agent sets one
+	DefaultMetricsPort int32 = 9090
+
+	// DefaultMetricsPath is the path of the metrics endpoint unless the Agent sets one
+	DefaultMetricsPath = "/metrics"
+
+	// MetricsPortEnv tells the agent which port to serve metrics on
+	MetricsPortEnv = "KUBETASK_METRICS_PORT"
+)
+
+// MetricsPort returns the port the agent of a monitored Agent serves metrics on
+func MetricsPort(monitoring *kubetaskv1alpha1.AgentMonitoring) int32 {
+	if monitoring.Port != nil {
+		return *monitoring.Port
+	}
+	return DefaultMetricsPort
+}
+
+// MetricsPath returns the path of the metrics endpoint of a monitored Agent
+func MetricsPath(monitoring *kubetaskv1alpha1.AgentMonitoring) string {
+	if monitoring.Path != "" {
+		return monitoring.Path
+	}
+	return DefaultMetricsPath
+}
+
+// metricsPort returns the agent container port of a monitored Agent, and the
+// environment variable telling the agent to serve metrics on it
+func metricsPort(monitoring *kubetaskv1alpha1.AgentMonitoring) (corev1.ContainerPort, corev1.EnvVar) {
+	port := MetricsPort(monitoring)
+	return corev1.ContainerPort{Name: MetricsPortName, ContainerPort: port, Protocol: corev1.ProtocolTCP},
+		corev1.EnvVar{Name: MetricsPortEnv, Value: strconv.Itoa(int(port))}
+}
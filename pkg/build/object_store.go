@@ -0,0 +1,156 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// DefaultObjectStoreImage is the default rclone image downloading ObjectStore contexts
+	DefaultObjectStoreImage = "rclone/rclone:1.68.2"
+
+	// DefaultS3Region is the region of S3 buckets that do not set one
+	DefaultS3Region = "us-east-1"
+
+	// GCSEndpoint is the S3-compatible XML API of Google Cloud Storage
+	GCSEndpoint = "https://storage.googleapis.com"
+
+	// objectStoreDir is where object store init containers write the objects
+	objectStoreDir = "/objects"
+
+	// contextInitUID runs the rclone and oras init containers as a non-root user,
+	// so they pass the restricted security profile
+	contextInitUID int64 = 65532
+)
+
+// ObjectStoreMount is an object, or the objects under a prefix, downloaded from
+// object storage by an init container and mounted in the agent container
+type ObjectStoreMount struct {
+	ContextName string // Context name (for volume naming)
+	MountPath   string // Where to mount in the container: a file for a key, a directory otherwise
+
+	Provider   kubetaskv1alpha1.ObjectStoreProvider
+	Bucket     string
+	Key        string // Single object to download, if set
+	Prefix     string // Prefix of the objects to download when Key is empty
+	Region     string
+	Endpoint   string
+	SecretName string // Optional Secret with the credentials
+}
+
+// objectStoreInitContainer creates an init container that downloads an object store
+// context with rclone into volumeName. The remote is configured with environment
+// variables only, so no rclone config file is needed.
+func objectStoreInitContainer(om ObjectStoreMount, image, volumeName string, index int) corev1.Container {
+	secretEnv := func(name, key string, optional bool) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: om.SecretName},
+				Key:                  key,
+				Optional:             boolPtr(optional),
+			},
+		}}
+	}
+	const prefix = "RCLONE_CONFIG_SRC_"
+	var env []corev1.EnvVar
+	switch om.Provider {
+	case kubetaskv1alpha1.ObjectStoreProviderAzure:
+		env = append(env,
+			corev1.EnvVar{Name: prefix + "TYPE", Value: "azureblob"},
+			corev1.EnvVar{Name: prefix + "ACCOUNT", Value: azureAccount(om.Endpoint)},
+			corev1.EnvVar{Name: prefix + "ENDPOINT", Value: om.Endpoint},
+		)
+		if om.SecretName != "" {
+			// $(AZURE_SAS_TOKEN) is expanded by the kubelet
+			env = append(env,
+				secretEnv("AZURE_SAS_TOKEN", "sasToken", false),
+				corev1.EnvVar{Name: prefix + "SAS_URL", Value: fmt.Sprintf("%s/%s?$(AZURE_SAS_TOKEN)", strings.TrimSuffix(om.Endpoint, "/"), om.Bucket)},
+			)
+		} else {
+			env = append(env, corev1.EnvVar{Name: prefix + "ENV_AUTH", Value: "true"})
+		}
+	case kubetaskv1alpha1.ObjectStoreProviderGCS:
+		if om.SecretName == "" {
+			// Application default credentials, e.g. GKE Workload Identity
+			env = append(env,
+				corev1.EnvVar{Name: prefix + "TYPE", Value: "google cloud storage"},
+				corev1.EnvVar{Name: prefix + "ENV_AUTH", Value: "true"},
+			)
+			break
+		}
+		endpoint := om.Endpoint
+		if endpoint == "" {
+			endpoint = GCSEndpoint
+		}
+		env = append(env,
+			corev1.EnvVar{Name: prefix + "TYPE", Value: "s3"},
+			corev1.EnvVar{Name: prefix + "PROVIDER", Value: "GCS"},
+			corev1.EnvVar{Name: prefix + "ENDPOINT", Value: endpoint},
+		)
+	default:
+		provider := "AWS"
+		if om.Endpoint != "" {
+			provider = "Other"
+		}
+		region := om.Region
+		if region == "" {
+			region = DefaultS3Region
+		}
+		env = append(env,
+			corev1.EnvVar{Name: prefix + "TYPE", Value: "s3"},
+			corev1.EnvVar{Name: prefix + "PROVIDER", Value: provider},
+			corev1.EnvVar{Name: prefix + "REGION", Value: region},
+		)
+		if om.Endpoint != "" {
+			env = append(env, corev1.EnvVar{Name: prefix + "ENDPOINT", Value: om.Endpoint})
+		}
+		if om.SecretName == "" {
+			// IRSA or the node's instance profile
+			env = append(env, corev1.EnvVar{Name: prefix + "ENV_AUTH", Value: "true"})
+		}
+	}
+	if om.SecretName != "" && om.Provider != kubetaskv1alpha1.ObjectStoreProviderAzure {
+		env = append(env,
+			secretEnv(prefix+"ACCESS_KEY_ID", "accessKeyID", false),
+			secretEnv(prefix+"SECRET_ACCESS_KEY", "secretAccessKey", false),
+			secretEnv(prefix+"SESSION_TOKEN", "sessionToken", true),
+		)
+	}
+
+	// A key is copied to the file "data", a prefix to the directory "data"
+	args := []string{"copy", "src:" + path.Join(om.Bucket, om.Prefix), objectStoreDir + "/data"}
+	if om.Key != "" {
+		args = []string{"copyto", "src:" + path.Join(om.Bucket, om.Key), objectStoreDir + "/data"}
+	}
+
+	uid := contextInitUID
+	return corev1.Container{
+		Name:            fmt.Sprintf("object-store-%d", index),
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"rclone"},
+		Args:            args,
+		Env:             env,
+		VolumeMounts:    []corev1.VolumeMount{{Name: volumeName, MountPath: objectStoreDir}},
+		SecurityContext: &corev1.SecurityContext{RunAsUser: &uid},
+	}
+}
+
+// azureAccount returns the storage account of an Azure Blob endpoint such as
+// https://<account>.blob.core.windows.net
+func azureAccount(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	account, _, _ := strings.Cut(u.Hostname(), ".")
+	return account
+}
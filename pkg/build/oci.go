@@ -0,0 +1,52 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultORASImage is the default oras image pulling OCI contexts
+	DefaultORASImage = "ghcr.io/oras-project/oras:v1.2.2"
+
+	// ociDir is where OCI init containers pull the artifacts
+	ociDir = "/oci"
+
+	// ociAuthMountPath is where the pull secret of an OCI context is mounted
+	ociAuthMountPath = "/kubetask/oci-auth"
+)
+
+// OCIMount is an OCI artifact pulled by an init container and mounted in the agent container
+type OCIMount struct {
+	ContextName    string // Context name (for volume naming)
+	Image          string // Reference of the artifact
+	Path           string // Path within the artifact to mount
+	MountPath      string // Where to mount in the container
+	PullSecretName string // Optional kubernetes.io/dockerconfigjson Secret
+}
+
+// ociInitContainer creates an init container that pulls an OCI artifact with oras
+// into volumeName. The pull secret, if any, is mounted from authVolumeName.
+func ociInitContainer(om OCIMount, image, volumeName, authVolumeName string, index int) corev1.Container {
+	args := []string{"pull", om.Image, "--output", ociDir + "/data"}
+	volumeMounts := []corev1.VolumeMount{{Name: volumeName, MountPath: ociDir}}
+	if om.PullSecretName != "" {
+		args = append(args, "--registry-config", ociAuthMountPath+"/"+corev1.DockerConfigJsonKey)
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: authVolumeName, MountPath: ociAuthMountPath, ReadOnly: true})
+	}
+	uid := contextInitUID
+	return corev1.Container{
+		Name:            fmt.Sprintf("oci-pull-%d", index),
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"oras"},
+		Args:            args,
+		// oras keeps its cache in $HOME, which the image's user may not own
+		Env:             []corev1.EnvVar{{Name: "HOME", Value: ociDir}},
+		VolumeMounts:    volumeMounts,
+		SecurityContext: &corev1.SecurityContext{RunAsUser: &uid},
+	}
+}
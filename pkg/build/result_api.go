@@ -0,0 +1,46 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// ResultTokenKey is the key of the result API token in the token Secret of a Task
+	ResultTokenKey = "token"
+
+	// resultTokenSecretSuffix is appended to the Task name to name its token Secret
+	resultTokenSecretSuffix = "-result-token"
+)
+
+// ResultReportPath returns the result API path agents push the reports of a Task to
+func ResultReportPath(namespace, name string) string {
+	return fmt.Sprintf("/v1/namespaces/%s/tasks/%s/report", namespace, name)
+}
+
+// ResultTokenSecretName returns the name of the Secret holding the result API token of a Task
+func ResultTokenSecretName(taskName string) string {
+	return taskName + resultTokenSecretSuffix
+}
+
+// resultAPIEnv tells the agent where to push progress and results, and how to authenticate
+func resultAPIEnv(task *kubetaskv1alpha1.Task, resultAPIURL string) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "KUBETASK_RESULT_URL", Value: strings.TrimSuffix(resultAPIURL, "/") + ResultReportPath(task.Namespace, task.Name)},
+		{
+			Name: "KUBETASK_RESULT_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ResultTokenSecretName(task.Name)},
+					Key:                  ResultTokenKey,
+				},
+			},
+		},
+	}
+}
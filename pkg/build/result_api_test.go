@@ -0,0 +1,49 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func TestJob_WithResultAPI(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default", UID: "test-uid"},
+	}
+	cfg := Config{
+		AgentImage:         "test-agent:v1.0.0",
+		WorkspaceDir:       "/workspace",
+		ServiceAccountName: "test-sa",
+	}
+
+	env := func(cfg Config) map[string]string {
+		job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+		values := make(map[string]string)
+		for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+			values[e.Name] = e.Value
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				values[e.Name] = e.ValueFrom.SecretKeyRef.Name + "/" + e.ValueFrom.SecretKeyRef.Key
+			}
+		}
+		return values
+	}
+
+	if values := env(cfg); values["KUBETASK_RESULT_URL"] != "" || values["KUBETASK_RESULT_TOKEN"] != "" {
+		t.Errorf("env = %v, want no result API without a URL", values)
+	}
+
+	cfg.ResultAPIURL = "http://kubetask-result-api.kubetask-system.svc/"
+	values := env(cfg)
+	if want := "http://kubetask-result-api.kubetask-system.svc/v1/namespaces/default/tasks/test-task/report"; values["KUBETASK_RESULT_URL"] != want {
+		t.Errorf("KUBETASK_RESULT_URL = %q, want %q", values["KUBETASK_RESULT_URL"], want)
+	}
+	if want := "test-task-result-token/token"; values["KUBETASK_RESULT_TOKEN"] != want {
+		t.Errorf("KUBETASK_RESULT_TOKEN = %q, want from %q", values["KUBETASK_RESULT_TOKEN"], want)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+// SecurityProfileLabelKey records the security profile of an agent Pod
+const SecurityProfileLabelKey = "kubetask.io/security-profile"
+
+// ApplySecurityProfile expands a security profile into the Pod's security settings.
+// keepToken keeps the service account token mounted under the restricted profile,
+// for Agents whose ServiceAccount is granted permissions through rbac.
+func ApplySecurityProfile(podSpec *corev1.PodSpec, profile kubetaskv1alpha1.SecurityProfile, keepToken bool) {
+	switch profile {
+	case kubetaskv1alpha1.SecurityProfileRestricted:
+		podSpec.SecurityContext = podSecurityContext(podSpec.SecurityContext)
+		podSpec.SecurityContext.RunAsNonRoot = boolPtr(true)
+		podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		forEachContainer(podSpec, func(c *corev1.Container) {
+			c.SecurityContext = containerSecurityContext(c.SecurityContext)
+			c.SecurityContext.AllowPrivilegeEscalation = boolPtr(false)
+			c.SecurityContext.Capabilities = &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+		})
+		if !keepToken {
+			podSpec.AutomountServiceAccountToken = boolPtr(false)
+		}
+	case kubetaskv1alpha1.SecurityProfileBaseline:
+		podSpec.SecurityContext = podSecurityContext(podSpec.SecurityContext)
+		podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		forEachContainer(podSpec, func(c *corev1.Container) {
+			c.SecurityContext = containerSecurityContext(c.SecurityContext)
+			c.SecurityContext.AllowPrivilegeEscalation = boolPtr(false)
+		})
+	case kubetaskv1alpha1.SecurityProfilePrivilegedCI:
+		// Only the agent container needs privileges; context init containers do not
+		for i := range podSpec.Containers {
+			c := &podSpec.Containers[i]
+			c.SecurityContext = containerSecurityContext(c.SecurityContext)
+			c.SecurityContext.Privileged = boolPtr(true)
+		}
+	}
+}
+
+func podSecurityContext(sc *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if sc == nil {
+		return &corev1.PodSecurityContext{}
+	}
+	return sc
+}
+
+func containerSecurityContext(sc *corev1.SecurityContext) *corev1.SecurityContext {
+	if sc == nil {
+		return &corev1.SecurityContext{}
+	}
+	return sc
+}
+
+// forEachContainer calls fn for the init and regular containers of a Pod
+func forEachContainer(podSpec *corev1.PodSpec, fn func(*corev1.Container)) {
+	for i := range podSpec.InitContainers {
+		fn(&podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		fn(&podSpec.Containers[i])
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func securityProfileTestTask() *kubetaskv1alpha1.Task {
+	return &kubetaskv1alpha1.Task{
+		TypeMeta: metav1.TypeMeta{APIVersion: "kubetask.io/v1alpha1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-task",
+			Namespace: "default",
+			UID:       types.UID("test-uid"),
+		},
+	}
+}
+
+func TestJob_SecurityProfile(t *testing.T) {
+	gitMounts := []GitMount{{
+		ContextName: "repo",
+		Repository:  "https://github.com/example/repo",
+		Ref:         "main",
+		MountPath:   "/workspace/repo",
+	}}
+
+	tests := []struct {
+		name      string
+		profile   kubetaskv1alpha1.SecurityProfile
+		keepToken bool
+		check     func(t *testing.T, podSpec corev1.PodSpec)
+	}{
+		{
+			name:    "no profile",
+			profile: "",
+			check: func(t *testing.T, podSpec corev1.PodSpec) {
+				if podSpec.SecurityContext != nil {
+					t.Errorf("pod securityContext = %+v, want nil", podSpec.SecurityContext)
+				}
+				if podSpec.Containers[0].SecurityContext != nil {
+					t.Errorf("container securityContext = %+v, want nil", podSpec.Containers[0].SecurityContext)
+				}
+			},
+		},
+		{
+			name:    "restricted",
+			profile: kubetaskv1alpha1.SecurityProfileRestricted,
+			check: func(t *testing.T, podSpec corev1.PodSpec) {
+				sc := podSpec.SecurityContext
+				if sc == nil || sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+					t.Errorf("pod runAsNonRoot not set: %+v", sc)
+				}
+				if sc == nil || sc.SeccompProfile == nil || sc.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+					t.Errorf("pod seccompProfile not RuntimeDefault: %+v", sc)
+				}
+				if podSpec.AutomountServiceAccountToken == nil || *podSpec.AutomountServiceAccountToken {
+					t.Errorf("automountServiceAccountToken = %v, want false", podSpec.AutomountServiceAccountToken)
+				}
+				forEachContainer(&podSpec, func(c *corev1.Container) {
+					csc := c.SecurityContext
+					if csc == nil || csc.AllowPrivilegeEscalation == nil || *csc.AllowPrivilegeEscalation {
+						t.Errorf("container %s allows privilege escalation", c.Name)
+					}
+					if csc == nil || csc.Capabilities == nil || len(csc.Capabilities.Drop) != 1 || csc.Capabilities.Drop[0] != "ALL" {
+						t.Errorf("container %s does not drop ALL capabilities", c.Name)
+					}
+				})
+			},
+		},
+		{
+			name:      "restricted with rbac keeps token",
+			profile:   kubetaskv1alpha1.SecurityProfileRestricted,
+			keepToken: true,
+			check: func(t *testing.T, podSpec corev1.PodSpec) {
+				if podSpec.AutomountServiceAccountToken != nil {
+					t.Errorf("automountServiceAccountToken = %v, want unset", *podSpec.AutomountServiceAccountToken)
+				}
+			},
+		},
+		{
+			name:    "baseline",
+			profile: kubetaskv1alpha1.SecurityProfileBaseline,
+			check: func(t *testing.T, podSpec corev1.PodSpec) {
+				sc := podSpec.SecurityContext
+				if sc == nil || sc.SeccompProfile == nil || sc.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+					t.Errorf("pod seccompProfile not RuntimeDefault: %+v", sc)
+				}
+				if sc.RunAsNonRoot != nil {
+					t.Errorf("baseline should not require runAsNonRoot")
+				}
+				if podSpec.AutomountServiceAccountToken != nil {
+					t.Errorf("baseline should not change automountServiceAccountToken")
+				}
+				forEachContainer(&podSpec, func(c *corev1.Container) {
+					csc := c.SecurityContext
+					if csc == nil || csc.AllowPrivilegeEscalation == nil || *csc.AllowPrivilegeEscalation {
+						t.Errorf("container %s allows privilege escalation", c.Name)
+					}
+					if csc.Capabilities != nil {
+						t.Errorf("baseline should not drop capabilities of container %s", c.Name)
+					}
+				})
+			},
+		},
+		{
+			name:    "privileged-ci",
+			profile: kubetaskv1alpha1.SecurityProfilePrivilegedCI,
+			check: func(t *testing.T, podSpec corev1.PodSpec) {
+				agent := podSpec.Containers[0]
+				if agent.SecurityContext == nil || agent.SecurityContext.Privileged == nil || !*agent.SecurityContext.Privileged {
+					t.Errorf("agent container is not privileged")
+				}
+				for _, c := range podSpec.InitContainers {
+					if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+						t.Errorf("init container %s should not be privileged", c.Name)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				AgentImage:         "test-agent:v1.0.0",
+				WorkspaceDir:       "/workspace",
+				ServiceAccountName: "test-sa",
+				SecurityProfile:    tt.profile,
+				MountToken:         tt.keepToken,
+			}
+			job := Job(securityProfileTestTask(), "test-task-job", cfg, nil, nil, nil, gitMounts)
+			podSpec := job.Spec.Template.Spec
+			if len(podSpec.InitContainers) == 0 {
+				t.Fatalf("expected git init container")
+			}
+			tt.check(t, podSpec)
+
+			if got := job.Spec.Template.Labels[SecurityProfileLabelKey]; got != string(tt.profile) {
+				t.Errorf("pod label %s = %q, want %q", SecurityProfileLabelKey, got, tt.profile)
+			}
+		})
+	}
+}
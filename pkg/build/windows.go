@@ -0,0 +1,52 @@
+// Copyright Contributors to the KubeTask project
+
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+const (
+	// OSLabelKey is the well-known node label holding the node's operating system
+	OSLabelKey = "kubernetes.io/os"
+
+	// DefaultWindowsWorkspaceDir is the default workspace directory for agent
+	// containers on Windows nodes
+	DefaultWindowsWorkspaceDir = `C:\workspace`
+)
+
+// TargetsWindows reports whether an Agent's Pods are scheduled onto Windows nodes,
+// which is the case when podSpec.scheduling.nodeSelector selects kubernetes.io/os=windows.
+// Windows agent images have no sh, so their command is wrapped in PowerShell.
+func TargetsWindows(podSpec *kubetaskv1alpha1.AgentPodSpec) bool {
+	if podSpec == nil || podSpec.Scheduling == nil {
+		return false
+	}
+	return podSpec.Scheduling.NodeSelector[OSLabelKey] == "windows"
+}
+
+// WorkspacePath returns the path of name in workspaceDir, joined with the
+// backslash separator if workspaceDir is a Windows path
+func WorkspacePath(workspaceDir, name string) string {
+	if strings.Contains(workspaceDir, `\`) {
+		return strings.TrimSuffix(workspaceDir, `\`) + `\` + name
+	}
+	return strings.TrimSuffix(workspaceDir, "/") + "/" + name
+}
+
+// windowsHumanInTheLoopCommand returns the PowerShell command that runs the agent
+// command and keeps the container alive for keepAliveSeconds afterwards, keeping
+// the agent's exit code. It is the Windows counterpart of the sh wrapper.
+func windowsHumanInTheLoopCommand(command []string, keepAliveSeconds int32) []string {
+	steps := []string{
+		"& " + strings.Join(command, " "),
+		"$exitCode = $LASTEXITCODE",
+		fmt.Sprintf(`Write-Host "Human-in-the-loop: keeping container alive for %d seconds. Use 'kubectl exec' to access."`, keepAliveSeconds),
+		fmt.Sprintf("Start-Sleep -Seconds %d", keepAliveSeconds),
+		"exit $exitCode",
+	}
+	return []string{"powershell", "-NoLogo", "-NoProfile", "-Command", strings.Join(steps, "; ")}
+}
@@ -0,0 +1,109 @@
+// Copyright Contributors to the KubeTask project
+
+//go:build !integration
+
+package build
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubetaskv1alpha1 "github.com/kubetask/kubetask/api/v1alpha1"
+)
+
+func windowsPodSpec() *kubetaskv1alpha1.AgentPodSpec {
+	return &kubetaskv1alpha1.AgentPodSpec{
+		Scheduling: &kubetaskv1alpha1.PodScheduling{NodeSelector: map[string]string{OSLabelKey: "windows"}},
+	}
+}
+
+func TestTargetsWindows(t *testing.T) {
+	linux := &kubetaskv1alpha1.AgentPodSpec{
+		Scheduling: &kubetaskv1alpha1.PodScheduling{NodeSelector: map[string]string{OSLabelKey: "linux"}},
+	}
+	if TargetsWindows(nil) || TargetsWindows(&kubetaskv1alpha1.AgentPodSpec{}) || TargetsWindows(linux) {
+		t.Errorf("TargetsWindows() = true for a Linux Agent")
+	}
+	if !TargetsWindows(windowsPodSpec()) {
+		t.Errorf("TargetsWindows() = false with nodeSelector %s=windows", OSLabelKey)
+	}
+}
+
+func TestWorkspacePath(t *testing.T) {
+	tests := []struct{ dir, want string }{
+		{"/workspace", "/workspace/task.md"},
+		{"/workspace/", "/workspace/task.md"},
+		{`C:\workspace`, `C:\workspace\task.md`},
+		{`C:\workspace\`, `C:\workspace\task.md`},
+	}
+	for _, tt := range tests {
+		if got := WorkspacePath(tt.dir, "task.md"); got != tt.want {
+			t.Errorf("WorkspacePath(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestJob_WindowsHumanInTheLoop(t *testing.T) {
+	keepAlive := int32(600)
+	task := &kubetaskv1alpha1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"},
+		Spec: kubetaskv1alpha1.TaskSpec{
+			HumanInTheLoop: &kubetaskv1alpha1.HumanInTheLoop{Enabled: true, KeepAliveSeconds: &keepAlive, RefreshContexts: true},
+		},
+	}
+	cfg := Config{
+		AgentImage:   "agent:windows",
+		WorkspaceDir: DefaultWindowsWorkspaceDir,
+		Command:      []string{"agent.exe", "--task", `C:\workspace\task.md`},
+		PodSpec:      windowsPodSpec(),
+		Cancellation: &kubetaskv1alpha1.AgentCancellation{},
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-task-context"}}
+	fileMounts := []FileMount{{Path: WorkspacePath(cfg.WorkspaceDir, "task.md")}}
+
+	job := Job(task, "test-task-job", cfg, cm, fileMounts, nil, nil)
+	container := job.Spec.Template.Spec.Containers[0]
+
+	if len(container.Command) != 5 || container.Command[0] != "powershell" || container.Command[3] != "-Command" {
+		t.Fatalf("Command = %v, want a powershell wrapper", container.Command)
+	}
+	script := container.Command[4]
+	for _, want := range []string{`& agent.exe --task C:\workspace\task.md`, "Start-Sleep -Seconds 600", "exit $exitCode"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script = %q, want it to contain %q", script, want)
+		}
+	}
+
+	// Contexts are not refreshed, so task.md keeps its subPath mount
+	var mounted bool
+	for _, vm := range container.VolumeMounts {
+		if vm.MountPath == ContextRefreshMountPath {
+			t.Errorf("contexts mounted at %s for refreshing on Windows", ContextRefreshMountPath)
+		}
+		if vm.MountPath == `C:\workspace\task.md` && vm.SubPath == "workspace-task.md" {
+			mounted = true
+		}
+	}
+	if !mounted {
+		t.Errorf("VolumeMounts = %v, want task.md mounted from workspace-task.md", container.VolumeMounts)
+	}
+}
+
+func TestJob_WindowsWithoutHumanInTheLoop(t *testing.T) {
+	task := &kubetaskv1alpha1.Task{ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "default"}}
+	cfg := Config{
+		AgentImage:   "agent:windows",
+		WorkspaceDir: DefaultWindowsWorkspaceDir,
+		Command:      []string{"agent.exe"},
+		PodSpec:      windowsPodSpec(),
+		Cancellation: &kubetaskv1alpha1.AgentCancellation{},
+	}
+
+	job := Job(task, "test-task-job", cfg, nil, nil, nil, nil)
+	if got := job.Spec.Template.Spec.Containers[0].Command; len(got) != 1 || got[0] != "agent.exe" {
+		t.Errorf("Command = %v, want the agent command unwrapped", got)
+	}
+}